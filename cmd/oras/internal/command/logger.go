@@ -28,5 +28,8 @@ import (
 func GetLogger(cmd *cobra.Command, opts *option.Common) (context.Context, logrus.FieldLogger) {
 	ctx, logger := trace.NewLogger(cmd.Context(), opts.Debug)
 	cmd.SetContext(ctx)
+	if opts.TraceID != "" {
+		logger.Debugf("trace ID %s is sent in the %s header of registry requests", opts.TraceID, opts.TraceHeader)
+	}
 	return ctx, logger
 }
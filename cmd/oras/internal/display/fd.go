@@ -0,0 +1,60 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package display
+
+import (
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/json"
+	"oras.land/oras/cmd/oras/internal/display/status"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// WithPushOutputFD returns push handlers also writing the status events and
+// the JSON metadata to the file descriptors requested by fds.
+func WithPushOutputFD(statusHandler status.PushHandler, metadataHandler metadata.PushHandler, fds *option.OutputFD) (status.PushHandler, metadata.PushHandler) {
+	if w := fds.StatusWriter(); w != nil {
+		statusHandler = status.NewEventPushHandler(statusHandler, status.NewEventWriter(w))
+	}
+	if w := fds.MetadataWriter(); w != nil {
+		metadataHandler = metadata.NewTeePushHandler(metadataHandler, json.NewPushHandler(output.NewPrinter(w, w)))
+	}
+	return statusHandler, metadataHandler
+}
+
+// WithAttachOutputFD returns attach handlers also writing the status events
+// and the JSON metadata to the file descriptors requested by fds.
+func WithAttachOutputFD(statusHandler status.AttachHandler, metadataHandler metadata.AttachHandler, fds *option.OutputFD) (status.AttachHandler, metadata.AttachHandler) {
+	if w := fds.StatusWriter(); w != nil {
+		statusHandler = status.NewEventPushHandler(statusHandler, status.NewEventWriter(w))
+	}
+	if w := fds.MetadataWriter(); w != nil {
+		metadataHandler = metadata.NewTeeAttachHandler(metadataHandler, json.NewAttachHandler(output.NewPrinter(w, w)))
+	}
+	return statusHandler, metadataHandler
+}
+
+// WithPullOutputFD returns pull handlers also writing the status events and
+// the JSON metadata to the file descriptors requested by fds.
+func WithPullOutputFD(statusHandler status.PullHandler, metadataHandler metadata.PullHandler, path string, fds *option.OutputFD) (status.PullHandler, metadata.PullHandler) {
+	if w := fds.StatusWriter(); w != nil {
+		statusHandler = status.NewEventPullHandler(statusHandler, status.NewEventWriter(w))
+	}
+	if w := fds.MetadataWriter(); w != nil {
+		metadataHandler = metadata.NewTeePullHandler(metadataHandler, json.NewPullHandler(output.NewPrinter(w, w), path))
+	}
+	return statusHandler, metadataHandler
+}
@@ -26,6 +26,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/metadata/descriptor"
 	"oras.land/oras/cmd/oras/internal/display/metadata/json"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 	"oras.land/oras/cmd/oras/internal/display/metadata/table"
 	"oras.land/oras/cmd/oras/internal/display/metadata/template"
 	"oras.land/oras/cmd/oras/internal/display/metadata/text"
@@ -111,6 +112,15 @@ func NewPullHandler(printer *output.Printer, format option.Format, path string,
 	return statusHandler, metadataHandler, nil
 }
 
+// NewPullGroupHandler returns pull handlers for one of multiple artifacts
+// pulled concurrently. The status output is rendered in group if it is not nil.
+func NewPullGroupHandler(printer *output.Printer, group *status.PullGroup, reference string) (status.PullHandler, metadata.PullHandler) {
+	if group != nil {
+		return group.NewHandler(reference), text.NewPullHandler(printer)
+	}
+	return status.NewTextPullHandler(printer), text.NewPullHandler(printer)
+}
+
 // NewDiscoverHandler returns status and metadata handlers for discover command.
 func NewDiscoverHandler(out io.Writer, format option.Format, path string, rawReference string, desc ocispec.Descriptor, verbose bool, tty *os.File) (metadata.DiscoverHandler, error) {
 	var handler metadata.DiscoverHandler
@@ -129,6 +139,12 @@ func NewDiscoverHandler(out io.Writer, format option.Format, path string, rawRef
 	return handler, nil
 }
 
+// NewWideDiscoverHandler returns a discover handler listing the details and
+// the annotations of the referrers in a table.
+func NewWideDiscoverHandler(out io.Writer, rawReference string, desc ocispec.Descriptor, annotations []string) metadata.DiscoverHandler {
+	return table.NewWideDiscoverHandler(out, rawReference, desc, annotations)
+}
+
 // NewManifestFetchHandler returns a manifest fetch handler.
 func NewManifestFetchHandler(out io.Writer, format option.Format, outputDescriptor, pretty bool, outputPath string) (metadata.ManifestFetchHandler, content.ManifestFetchHandler, error) {
 	var metadataHandler metadata.ManifestFetchHandler
@@ -164,6 +180,22 @@ func NewManifestFetchHandler(out io.Writer, format option.Format, outputDescript
 	return metadataHandler, contentHandler, nil
 }
 
+// NewManifestPlatformsHandler returns a manifest platforms handler.
+func NewManifestPlatformsHandler(out io.Writer, format option.Format, reference string) (metadata.ManifestPlatformsHandler, error) {
+	var handler metadata.ManifestPlatformsHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewManifestPlatformsHandler(out, reference)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewManifestPlatformsHandler(out, reference)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewManifestPlatformsHandler(out, reference, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
 // NewTagHandler returns a tag handler.
 func NewTagHandler(printer *output.Printer, target option.Target) metadata.TagHandler {
 	return text.NewTagHandler(printer, target)
@@ -223,27 +255,95 @@ func NewManifestIndexUpdateHandler(outputPath string, printer *output.Printer, p
 }
 
 // NewCopyHandler returns copy handlers.
-func NewCopyHandler(printer *output.Printer, tty *os.File, fetcher fetcher.Fetcher) (status.CopyHandler, metadata.CopyHandler) {
+func NewCopyHandler(printer *output.Printer, format option.Format, tty *os.File, fetcher fetcher.Fetcher) (status.CopyHandler, metadata.CopyHandler, error) {
+	var statusHandler status.CopyHandler
 	if tty != nil {
-		return status.NewTTYCopyHandler(tty), text.NewCopyHandler(printer)
+		statusHandler = status.NewTTYCopyHandler(tty)
+	} else if format.Type == option.FormatTypeText.Name {
+		statusHandler = status.NewTextCopyHandler(printer, fetcher)
+	} else {
+		statusHandler = status.NewDiscardHandler()
+	}
+
+	recorder := &model.Transferred{}
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		return statusHandler, text.NewCopyHandler(printer), nil
+	case option.FormatTypeJSON.Name:
+		return status.NewRecordCopyHandler(statusHandler, recorder), json.NewCopyHandler(printer, recorder), nil
+	case option.FormatTypeGoTemplate.Name:
+		return status.NewRecordCopyHandler(statusHandler, recorder), template.NewCopyHandler(printer, format.Template, recorder), nil
+	default:
+		return nil, nil, errors.UnsupportedFormatTypeError(format.Type)
 	}
-	return status.NewTextCopyHandler(printer, fetcher), text.NewCopyHandler(printer)
 }
 
 // NewBackupHandler returns backup handlers.
-func NewBackupHandler(printer *output.Printer, tty *os.File, repo string, fetcher fetcher.Fetcher) (status.BackupHandler, metadata.BackupHandler) {
+func NewBackupHandler(printer *output.Printer, format option.Format, tty *os.File, repo string, fetcher fetcher.Fetcher) (status.BackupHandler, metadata.BackupHandler, error) {
+	var statusHandler status.BackupHandler
 	if tty != nil {
-		return status.NewTTYBackupHandler(tty, fetcher), text.NewBackupHandler(repo, printer)
+		statusHandler = status.NewTTYBackupHandler(tty, fetcher)
+	} else if format.Type == option.FormatTypeText.Name {
+		statusHandler = status.NewTextBackupHandler(printer, fetcher)
+	} else {
+		statusHandler = status.NewDiscardHandler()
+	}
+
+	recorder := &model.Transferred{}
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		return statusHandler, text.NewBackupHandler(repo, printer), nil
+	case option.FormatTypeJSON.Name:
+		return status.NewRecordBackupHandler(statusHandler, recorder), json.NewBackupHandler(printer, repo, recorder), nil
+	case option.FormatTypeGoTemplate.Name:
+		return status.NewRecordBackupHandler(statusHandler, recorder), template.NewBackupHandler(printer, format.Template, repo, recorder), nil
+	default:
+		return nil, nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+}
+
+// NewBackupGroupHandler returns backup handlers for one of multiple
+// repositories backed up concurrently. The status output is rendered in group
+// if it is not nil.
+func NewBackupGroupHandler(printer *output.Printer, group *status.BackupGroup, repo string, fetcher fetcher.Fetcher) (status.BackupHandler, metadata.BackupHandler) {
+	if group != nil {
+		return group.NewHandler(repo, fetcher), text.NewBackupHandler(repo, printer)
 	}
 	return status.NewTextBackupHandler(printer, fetcher), text.NewBackupHandler(repo, printer)
 }
 
 // NewRestoreHandler returns restore handlers.
-func NewRestoreHandler(printer *output.Printer, tty *os.File, fetcher fetcher.Fetcher, dryRun bool) (status.RestoreHandler, metadata.RestoreHandler) {
+func NewRestoreHandler(printer *output.Printer, format option.Format, tty *os.File, fetcher fetcher.Fetcher, dryRun bool) (status.RestoreHandler, metadata.RestoreHandler, error) {
+	var statusHandler status.RestoreHandler
 	if tty != nil {
-		return status.NewTTYRestoreHandler(tty, fetcher), text.NewRestoreHandler(printer, dryRun)
+		statusHandler = status.NewTTYRestoreHandler(tty, fetcher)
+	} else if format.Type == option.FormatTypeText.Name {
+		statusHandler = status.NewTextRestoreHandler(printer, fetcher)
+	} else {
+		statusHandler = status.NewDiscardHandler()
 	}
-	return status.NewTextRestoreHandler(printer, fetcher), text.NewRestoreHandler(printer, dryRun)
+
+	recorder := &model.Transferred{}
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		return statusHandler, text.NewRestoreHandler(printer, dryRun), nil
+	case option.FormatTypeJSON.Name:
+		return status.NewRecordRestoreHandler(statusHandler, recorder), json.NewRestoreHandler(printer, dryRun, recorder), nil
+	case option.FormatTypeGoTemplate.Name:
+		return status.NewRecordRestoreHandler(statusHandler, recorder), template.NewRestoreHandler(printer, format.Template, dryRun, recorder), nil
+	default:
+		return nil, nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+}
+
+// NewPruneHandler returns a prune handler.
+func NewPruneHandler(printer *output.Printer, repo string, dryRun bool) metadata.PruneHandler {
+	return text.NewPruneHandler(printer, repo, dryRun)
+}
+
+// NewBackupPruneHandler returns a backup prune handler.
+func NewBackupPruneHandler(printer *output.Printer, location string, dryRun bool) metadata.BackupPruneHandler {
+	return text.NewBackupPruneHandler(printer, location, dryRun)
 }
 
 // NewBlobPushHandler returns blob push handlers.
@@ -268,21 +368,105 @@ func NewBlobDeleteHandler(printer *output.Printer, target *option.Target) metada
 }
 
 // NewRepoTagsHandler returns a repo tags handler.
-func NewRepoTagsHandler(out io.Writer, format option.Format) (metadata.RepoTagsHandler, error) {
+func NewRepoTagsHandler(out io.Writer, format option.Format, showDigest, showCreated, groupByDigest bool) (metadata.RepoTagsHandler, error) {
 	var handler metadata.RepoTagsHandler
 	switch format.Type {
 	case option.FormatTypeText.Name:
-		handler = text.NewRepoTagsHandler(out)
+		handler = text.NewRepoTagsHandler(out, showDigest, showCreated, groupByDigest)
 	case option.FormatTypeJSON.Name:
-		handler = json.NewRepoTagsHandler(out)
+		handler = json.NewRepoTagsHandler(out, groupByDigest)
 	case option.FormatTypeGoTemplate.Name:
-		handler = template.NewRepoTagsHandler(out, format.Template)
+		handler = template.NewRepoTagsHandler(out, format.Template, groupByDigest)
 	default:
 		return nil, errors.UnsupportedFormatTypeError(format.Type)
 	}
 	return handler, nil
 }
 
+// NewRepoDiskUsageHandler returns a repo du handler.
+func NewRepoDiskUsageHandler(out io.Writer, format option.Format, repo string) (metadata.RepoDiskUsageHandler, error) {
+	var handler metadata.RepoDiskUsageHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewRepoDiskUsageHandler(out, repo)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewRepoDiskUsageHandler(out, repo)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewRepoDiskUsageHandler(out, repo, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewBackupInspectHandler returns a backup inspect handler.
+func NewBackupInspectHandler(out io.Writer, format option.Format) (metadata.BackupInspectHandler, error) {
+	var handler metadata.BackupInspectHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewBackupInspectHandler(out)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewBackupInspectHandler(out)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewBackupInspectHandler(out, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewHistoryHandler returns a history handler.
+func NewHistoryHandler(out io.Writer, format option.Format, reference string) (metadata.HistoryHandler, error) {
+	var handler metadata.HistoryHandler
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		handler = text.NewHistoryHandler(out, reference)
+	case option.FormatTypeJSON.Name:
+		handler = json.NewHistoryHandler(out, reference)
+	case option.FormatTypeGoTemplate.Name:
+		handler = template.NewHistoryHandler(out, reference, format.Template)
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+	return handler, nil
+}
+
+// NewPingHandler returns a ping handler.
+func NewPingHandler(out io.Writer, format option.Format, registry, repository string, write bool) (metadata.PingHandler, error) {
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		return text.NewPingHandler(out, registry, repository, write), nil
+	case option.FormatTypeJSON.Name:
+		return json.NewPingHandler(out, registry, repository), nil
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+}
+
+// NewConformanceHandler returns a conformance handler.
+func NewConformanceHandler(out io.Writer, format option.Format, repo string) (metadata.ConformanceHandler, error) {
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		return text.NewConformanceHandler(out, repo), nil
+	case option.FormatTypeJSON.Name:
+		return json.NewConformanceHandler(out, repo), nil
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+}
+
+// NewBenchHandler returns a bench handler.
+func NewBenchHandler(out io.Writer, format option.Format, repo string) (metadata.BenchHandler, error) {
+	switch format.Type {
+	case option.FormatTypeText.Name:
+		return text.NewBenchHandler(out, repo), nil
+	case option.FormatTypeJSON.Name:
+		return json.NewBenchHandler(out, repo), nil
+	default:
+		return nil, errors.UnsupportedFormatTypeError(format.Type)
+	}
+}
+
 // NewRepoListHandler returns a repo ls handler.
 func NewRepoListHandler(out io.Writer, format option.Format, registry, namespace string) (metadata.RepoListHandler, error) {
 	var handler metadata.RepoListHandler
@@ -22,6 +22,7 @@ import (
 
 	"oras.land/oras/internal/testutils"
 
+	"oras.land/oras/cmd/oras/internal/display/metadata/json"
 	"oras.land/oras/cmd/oras/internal/display/metadata/text"
 	"oras.land/oras/cmd/oras/internal/display/status"
 	"oras.land/oras/cmd/oras/internal/option"
@@ -56,20 +57,39 @@ func TestNewPullHandler(t *testing.T) {
 
 func TestNewCopyHandler(t *testing.T) {
 	printer := output.NewPrinter(os.Stdout, os.Stderr)
-	copyHandler, copyMetadataHandler := NewCopyHandler(printer, os.Stdout, nil)
+	copyHandler, copyMetadataHandler, err := NewCopyHandler(printer, option.Format{Type: option.FormatTypeText.Name}, os.Stdout, nil)
+	if err != nil {
+		t.Fatalf("NewCopyHandler() error = %v", err)
+	}
 	if _, ok := copyHandler.(*status.TTYCopyHandler); !ok {
 		t.Errorf("expected *status.TTYCopyHandler actual %v", reflect.TypeOf(copyHandler))
 	}
 	if _, ok := copyMetadataHandler.(*text.CopyHandler); !ok {
 		t.Errorf("expected metadata.CopyHandler actual %v", reflect.TypeOf(copyMetadataHandler))
 	}
-	copyHandler, copyMetadataHandler = NewCopyHandler(printer, nil, nil)
+	copyHandler, copyMetadataHandler, err = NewCopyHandler(printer, option.Format{Type: option.FormatTypeText.Name}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewCopyHandler() error = %v", err)
+	}
 	if _, ok := copyHandler.(*status.TextCopyHandler); !ok {
 		t.Errorf("expected *status.TextCopyHandler actual %v", reflect.TypeOf(copyHandler))
 	}
 	if _, ok := copyMetadataHandler.(*text.CopyHandler); !ok {
 		t.Errorf("expected metadata.CopyHandler actual %v", reflect.TypeOf(copyMetadataHandler))
 	}
+	copyHandler, copyMetadataHandler, err = NewCopyHandler(printer, option.Format{Type: option.FormatTypeJSON.Name}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewCopyHandler() error = %v", err)
+	}
+	if _, ok := copyHandler.(*status.RecordHandler); !ok {
+		t.Errorf("expected *status.RecordHandler actual %v", reflect.TypeOf(copyHandler))
+	}
+	if _, ok := copyMetadataHandler.(*json.CopyHandler); !ok {
+		t.Errorf("expected *json.CopyHandler actual %v", reflect.TypeOf(copyMetadataHandler))
+	}
+	if _, _, err := NewCopyHandler(printer, option.Format{Type: "unsupported"}, nil, nil); err == nil {
+		t.Error("NewCopyHandler() error = nil, want error for unsupported format")
+	}
 }
 
 func TestNewRepoTagsHandler(t *testing.T) {
@@ -87,7 +107,7 @@ func TestNewRepoTagsHandler(t *testing.T) {
 	// Test with stdout
 	for _, tt := range tests {
 		t.Run(tt.name+" with stdout", func(t *testing.T) {
-			handler, err := NewRepoTagsHandler(os.Stdout, tt.format)
+			handler, err := NewRepoTagsHandler(os.Stdout, tt.format, false, false, false)
 			if tt.expectError && err == nil {
 				t.Error("expected error, got nil")
 			}
@@ -142,7 +162,10 @@ func TestNewBackupHandler(t *testing.T) {
 	mockFetcher := testutils.NewMockFetcher()
 
 	t.Run("with TTY", func(t *testing.T) {
-		statusHandler, metadataHandler := NewBackupHandler(printer, os.Stdout, repo, mockFetcher.Fetcher)
+		statusHandler, metadataHandler, err := NewBackupHandler(printer, option.Format{Type: option.FormatTypeText.Name}, os.Stdout, repo, mockFetcher.Fetcher)
+		if err != nil {
+			t.Fatalf("NewBackupHandler() error = %v", err)
+		}
 		if _, ok := statusHandler.(*status.TTYBackupHandler); !ok {
 			t.Errorf("expected *status.TTYBackupHandler actual %v", reflect.TypeOf(statusHandler))
 		}
@@ -152,7 +175,10 @@ func TestNewBackupHandler(t *testing.T) {
 	})
 
 	t.Run("without TTY", func(t *testing.T) {
-		statusHandler, metadataHandler := NewBackupHandler(printer, nil, repo, mockFetcher.Fetcher)
+		statusHandler, metadataHandler, err := NewBackupHandler(printer, option.Format{Type: option.FormatTypeText.Name}, nil, repo, mockFetcher.Fetcher)
+		if err != nil {
+			t.Fatalf("NewBackupHandler() error = %v", err)
+		}
 		if _, ok := statusHandler.(*status.TextBackupHandler); !ok {
 			t.Errorf("expected *status.TextBackupHandler actual %v", reflect.TypeOf(statusHandler))
 		}
@@ -160,6 +186,25 @@ func TestNewBackupHandler(t *testing.T) {
 			t.Errorf("expected *text.BackupHandler actual %v", reflect.TypeOf(metadataHandler))
 		}
 	})
+
+	t.Run("JSON format", func(t *testing.T) {
+		statusHandler, metadataHandler, err := NewBackupHandler(printer, option.Format{Type: option.FormatTypeJSON.Name}, nil, repo, mockFetcher.Fetcher)
+		if err != nil {
+			t.Fatalf("NewBackupHandler() error = %v", err)
+		}
+		if _, ok := statusHandler.(*status.RecordHandler); !ok {
+			t.Errorf("expected *status.RecordHandler actual %v", reflect.TypeOf(statusHandler))
+		}
+		if _, ok := metadataHandler.(*json.BackupHandler); !ok {
+			t.Errorf("expected *json.BackupHandler actual %v", reflect.TypeOf(metadataHandler))
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		if _, _, err := NewBackupHandler(printer, option.Format{Type: "unsupported"}, nil, repo, mockFetcher.Fetcher); err == nil {
+			t.Error("NewBackupHandler() error = nil, want error")
+		}
+	})
 }
 
 func TestNewRestoreHandler(t *testing.T) {
@@ -167,7 +212,10 @@ func TestNewRestoreHandler(t *testing.T) {
 	mockFetcher := testutils.NewMockFetcher()
 
 	t.Run("with TTY", func(t *testing.T) {
-		statusHandler, metadataHandler := NewRestoreHandler(printer, os.Stdout, mockFetcher.Fetcher, false)
+		statusHandler, metadataHandler, err := NewRestoreHandler(printer, option.Format{Type: option.FormatTypeText.Name}, os.Stdout, mockFetcher.Fetcher, false)
+		if err != nil {
+			t.Fatalf("NewRestoreHandler() error = %v", err)
+		}
 		if _, ok := statusHandler.(*status.TTYRestoreHandler); !ok {
 			t.Errorf("expected *status.TTYRestoreHandler actual %v", reflect.TypeOf(statusHandler))
 		}
@@ -177,7 +225,10 @@ func TestNewRestoreHandler(t *testing.T) {
 	})
 
 	t.Run("without TTY", func(t *testing.T) {
-		statusHandler, metadataHandler := NewRestoreHandler(printer, nil, mockFetcher.Fetcher, false)
+		statusHandler, metadataHandler, err := NewRestoreHandler(printer, option.Format{Type: option.FormatTypeText.Name}, nil, mockFetcher.Fetcher, false)
+		if err != nil {
+			t.Fatalf("NewRestoreHandler() error = %v", err)
+		}
 		if _, ok := statusHandler.(*status.TextRestoreHandler); !ok {
 			t.Errorf("expected *status.TextRestoreHandler actual %v", reflect.TypeOf(statusHandler))
 		}
@@ -185,4 +236,23 @@ func TestNewRestoreHandler(t *testing.T) {
 			t.Errorf("expected *text.RestoreHandler actual %v", reflect.TypeOf(metadataHandler))
 		}
 	})
+
+	t.Run("JSON format", func(t *testing.T) {
+		statusHandler, metadataHandler, err := NewRestoreHandler(printer, option.Format{Type: option.FormatTypeJSON.Name}, nil, mockFetcher.Fetcher, false)
+		if err != nil {
+			t.Fatalf("NewRestoreHandler() error = %v", err)
+		}
+		if _, ok := statusHandler.(*status.RecordHandler); !ok {
+			t.Errorf("expected *status.RecordHandler actual %v", reflect.TypeOf(statusHandler))
+		}
+		if _, ok := metadataHandler.(*json.RestoreHandler); !ok {
+			t.Errorf("expected *json.RestoreHandler actual %v", reflect.TypeOf(metadataHandler))
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		if _, _, err := NewRestoreHandler(printer, option.Format{Type: "unsupported"}, nil, mockFetcher.Fetcher, false); err == nil {
+			t.Error("NewRestoreHandler() error = nil, want error")
+		}
+	})
 }
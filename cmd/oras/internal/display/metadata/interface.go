@@ -20,6 +20,11 @@ import (
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/backupcatalog"
+	"oras.land/oras/internal/bench"
+	"oras.land/oras/internal/conformance"
+	"oras.land/oras/internal/history"
+	"oras.land/oras/internal/probe"
 )
 
 // Renderer renders metadata information when an operation is complete.
@@ -50,12 +55,29 @@ type DiscoverHandler interface {
 	OnDiscovered(referrer, subject ocispec.Descriptor) error
 }
 
+// DiscoverManifestHandler handles the manifests of the discovered referrers.
+type DiscoverManifestHandler interface {
+	// OnManifestFetched is called after the manifest of a discovered referrer
+	// is fetched.
+	OnManifestFetched(referrer ocispec.Descriptor, content []byte) error
+}
+
 // ManifestFetchHandler handles metadata output for manifest fetch events.
 type ManifestFetchHandler interface {
 	// OnFetched is called after the manifest content is fetched.
 	OnFetched(path string, desc ocispec.Descriptor, content []byte) error
 }
 
+// ManifestPlatformsHandler handles metadata output for manifest platforms
+// command.
+type ManifestPlatformsHandler interface {
+	Renderer
+
+	// OnPlatformFound is called for each platform-specific manifest desc of
+	// the platform p.
+	OnPlatformFound(p ocispec.Platform, desc ocispec.Descriptor) error
+}
+
 // PullHandler handles metadata output for pull events.
 type PullHandler interface {
 	Renderer
@@ -106,6 +128,9 @@ type CopyHandler interface {
 	Renderer
 
 	OnCopied(target *option.BinaryTarget, desc ocispec.Descriptor) error
+	// OnCopyFailed is called when copying a tag fails and the copy continues
+	// with the other tags.
+	OnCopyFailed(target *option.BinaryTarget, err error) error
 }
 
 // BackupHandler handles metadata output for backup events.
@@ -114,11 +139,43 @@ type BackupHandler interface {
 
 	OnTagsFound(tags []string) error
 	OnArtifactPulled(tag string, referrerCount int) error
+	// OnArtifactFailed is called when backing up a tag fails and the backup
+	// continues with the other tags.
+	OnArtifactFailed(tag string, err error) error
 	OnTarExporting(path string) error
 	OnTarExported(path string, size int64) error
+	OnTarVolumesExported(path string, count int, size int64) error
+	OnBlobsDeduplicated(count int, size int64) error
+	// OnBackupResumed is called with the number of blobs already downloaded
+	// by an interrupted backup.
+	OnBackupResumed(count int) error
 	OnBackupCompleted(tagsCount int, path string, duration time.Duration) error
 }
 
+// BackupInspectHandler handles metadata output for backup inspect command.
+type BackupInspectHandler interface {
+	Renderer
+
+	// OnCatalogLoaded is called with the catalog of the inspected backup.
+	OnCatalogLoaded(catalog *backupcatalog.Catalog) error
+}
+
+// BackupPruneHandler handles metadata output for backup prune events.
+type BackupPruneHandler interface {
+	Renderer
+
+	// OnBackupKept is called when a backup is kept by the retention policy for
+	// the reasons.
+	OnBackupKept(name string, created time.Time, repositories string, reasons []string) error
+	// OnBackupExpired is called when a backup is not kept by the retention
+	// policy.
+	OnBackupExpired(name string, created time.Time, repositories string) error
+	// OnBackupPruned is called after an expired backup is deleted.
+	OnBackupPruned(name string) error
+	// OnBackupPruneCompleted is called when the prune operation completes.
+	OnBackupPruneCompleted(count int) error
+}
+
 // RestoreHandler handles metadata output for restore events.
 type RestoreHandler interface {
 	Renderer
@@ -126,9 +183,36 @@ type RestoreHandler interface {
 	OnTarLoaded(path string, size int64) error
 	OnTagsFound(tags []string) error
 	OnArtifactPushed(tag string, referrerCount int) error
+	// OnArtifactPlanned is called in dry run mode with the action, e.g.
+	// "create" or "overwrite", that would be taken on the tag.
+	OnArtifactPlanned(tag string, action string, referrerCount int) error
+	// OnRestoreResumed is called with the number of blobs and manifests
+	// already restored by an interrupted restore.
+	OnRestoreResumed(count int) error
+	// OnTagSkipped is called when a tag is not restored as it already exists
+	// in the target repository with a different digest.
+	OnTagSkipped(tag string, existing ocispec.Descriptor) error
+	// OnTagCorrupted is called when a tag is not restored as its content in
+	// the backup is corrupted.
+	OnTagCorrupted(tag string, err error) error
+	// OnArtifactFailed is called when restoring a tag fails and the restore
+	// continues with the other tags.
+	OnArtifactFailed(tag string, err error) error
 	OnRestoreCompleted(tagsCount int, repo string, duration time.Duration) error
 }
 
+// PruneHandler handles metadata output for prune events.
+type PruneHandler interface {
+	Renderer
+
+	// OnExpiredFound is called when an expired manifest is found.
+	OnExpiredFound(desc ocispec.Descriptor, expiresAt time.Time) error
+	// OnManifestPruned is called after an expired manifest is deleted.
+	OnManifestPruned(desc ocispec.Descriptor) error
+	// OnPruneCompleted is called when the prune operation completes.
+	OnPruneCompleted(count int) error
+}
+
 // BlobPushHandler handles metadata output for blob push events.
 type BlobPushHandler interface {
 	Renderer
@@ -159,6 +243,10 @@ type RepoTagsHandler interface {
 
 	// OnTagListed is called for each tag that is listed.
 	OnTagListed(tag string) error
+	// OnTagResolved is called instead of OnTagListed for each tag listed with
+	// the digest and the creation time of the manifest it points to, which
+	// are empty if not requested.
+	OnTagResolved(tag string, digest string, created string) error
 }
 
 // RepoListHandler handles metadata output for repo ls command.
@@ -168,3 +256,47 @@ type RepoListHandler interface {
 	// OnRepositoryListed is called for each repository that is listed.
 	OnRepositoryListed(repo string) error
 }
+
+// RepoDiskUsageHandler handles metadata output for repo du command.
+type RepoDiskUsageHandler interface {
+	Renderer
+
+	// OnTagMeasured is called with the storage usage of the content graph
+	// rooted at the manifest referenced by tag.
+	OnTagMeasured(tag string, desc ocispec.Descriptor, size int64, blobCount int) error
+	// OnTotalMeasured is called with the storage usage of the repository,
+	// counting blobs shared by multiple tags only once.
+	OnTotalMeasured(size int64, blobCount int) error
+}
+
+// HistoryHandler handles metadata output for history command.
+type HistoryHandler interface {
+	Renderer
+
+	// OnRecordFound is called for each movement of the tag, from the latest.
+	OnRecordFound(r history.Record) error
+}
+
+// PingHandler handles metadata output for ping command.
+type PingHandler interface {
+	Renderer
+
+	// OnProbed is called after the registry is probed.
+	OnProbed(result *probe.Result) error
+}
+
+// ConformanceHandler handles metadata output for conformance command.
+type ConformanceHandler interface {
+	Renderer
+
+	// OnCheckCompleted is called after a conformance check is completed.
+	OnCheckCompleted(result conformance.Result) error
+}
+
+// BenchHandler handles metadata output for bench commands.
+type BenchHandler interface {
+	Renderer
+
+	// OnRunCompleted is called after a benchmark run is completed.
+	OnRunCompleted(result bench.Result) error
+}
@@ -0,0 +1,113 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// BackupHandler handles JSON metadata output for backup events.
+type BackupHandler struct {
+	out      io.Writer
+	repo     string
+	recorder *model.Transferred
+	lock     sync.Mutex
+	tags     []model.BackupTag
+	failures []model.Failure
+	output   string
+	duration time.Duration
+}
+
+// NewBackupHandler returns a new handler for backup events, reporting the
+// descriptors transferred recorded by recorder.
+func NewBackupHandler(out io.Writer, repo string, recorder *model.Transferred) metadata.BackupHandler {
+	return &BackupHandler{
+		out:      out,
+		repo:     repo,
+		recorder: recorder,
+	}
+}
+
+// OnTagsFound implements metadata.BackupHandler.
+func (h *BackupHandler) OnTagsFound(_ []string) error {
+	return nil
+}
+
+// OnArtifactPulled implements metadata.BackupHandler.
+func (h *BackupHandler) OnArtifactPulled(tag string, referrerCount int) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.tags = append(h.tags, model.BackupTag{
+		Name:      tag,
+		Referrers: referrerCount,
+	})
+	return nil
+}
+
+// OnArtifactFailed implements metadata.BackupHandler.
+func (h *BackupHandler) OnArtifactFailed(tag string, err error) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.failures = append(h.failures, model.NewFailure(tag, err))
+	return nil
+}
+
+// OnTarExporting implements metadata.BackupHandler.
+func (h *BackupHandler) OnTarExporting(_ string) error {
+	return nil
+}
+
+// OnTarExported implements metadata.BackupHandler.
+func (h *BackupHandler) OnTarExported(_ string, _ int64) error {
+	return nil
+}
+
+// OnTarVolumesExported implements metadata.BackupHandler.
+func (h *BackupHandler) OnTarVolumesExported(_ string, _ int, _ int64) error {
+	return nil
+}
+
+// OnBlobsDeduplicated implements metadata.BackupHandler.
+func (h *BackupHandler) OnBlobsDeduplicated(_ int, _ int64) error {
+	return nil
+}
+
+// OnBackupResumed implements metadata.BackupHandler.
+func (h *BackupHandler) OnBackupResumed(_ int) error {
+	return nil
+}
+
+// OnBackupCompleted implements metadata.BackupHandler.
+func (h *BackupHandler) OnBackupCompleted(_ int, path string, duration time.Duration) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.output = path
+	h.duration = duration
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *BackupHandler) Render() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return output.PrintPrettyJSON(h.out, model.NewBackup(h.repo, h.output, h.tags, h.failures, h.duration, h.recorder.Transfers()))
+}
@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/conformance"
+)
+
+// conformanceHandler handles JSON metadata output for conformance command.
+type conformanceHandler struct {
+	out   io.Writer
+	model *model.Conformance
+}
+
+// NewConformanceHandler creates a new JSON handler for conformance command.
+func NewConformanceHandler(out io.Writer, repo string) metadata.ConformanceHandler {
+	return &conformanceHandler{
+		out:   out,
+		model: model.NewConformance(repo),
+	}
+}
+
+// OnCheckCompleted implements metadata.ConformanceHandler.
+func (h *conformanceHandler) OnCheckCompleted(result conformance.Result) error {
+	h.model.AddResult(result)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *conformanceHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
@@ -45,6 +45,11 @@ func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) err
 	return h.model.AddReferrer(referrer, subject)
 }
 
+// OnManifestFetched implements metadata.DiscoverManifestHandler.
+func (h *discoverHandler) OnManifestFetched(referrer ocispec.Descriptor, content []byte) error {
+	return h.model.SetManifest(referrer, content)
+}
+
 // Render implements metadata.DiscoverHandler.
 func (h *discoverHandler) Render() error {
 	return output.PrintPrettyJSON(h.out, h.model.Root)
@@ -0,0 +1,77 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+)
+
+func Test_discoverHandler_OnManifestFetched(t *testing.T) {
+	subject := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("subject"),
+		Size:      7,
+	}
+	manifest := []byte(`{"schemaVersion":2,"artifactType":"doc/example","annotations":{"k":"v"}}`)
+	referrer := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: "doc/example",
+		Digest:       digest.FromBytes(manifest),
+		Size:         int64(len(manifest)),
+	}
+	var out bytes.Buffer
+	handler := NewDiscoverHandler(&out, subject, "localhost:5000/test")
+	if err := handler.OnDiscovered(referrer, subject); err != nil {
+		t.Fatalf("OnDiscovered() error = %v", err)
+	}
+	manifestHandler, ok := handler.(metadata.DiscoverManifestHandler)
+	if !ok {
+		t.Fatal("discover handler does not handle manifests")
+	}
+	if err := manifestHandler.OnManifestFetched(referrer, manifest); err != nil {
+		t.Fatalf("OnManifestFetched() error = %v", err)
+	}
+	if err := manifestHandler.OnManifestFetched(subject, []byte("{")); err == nil {
+		t.Error("OnManifestFetched() error = nil, want error for invalid manifest")
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	var got struct {
+		Manifest  json.RawMessage `json:"manifest"`
+		Referrers []struct {
+			Manifest struct {
+				Annotations map[string]string `json:"annotations"`
+			} `json:"manifest"`
+		} `json:"referrers"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid output %q: %v", out.String(), err)
+	}
+	if got.Manifest != nil {
+		t.Errorf("subject manifest = %s, want omitted", got.Manifest)
+	}
+	if len(got.Referrers) != 1 || got.Referrers[0].Manifest.Annotations["k"] != "v" {
+		t.Errorf("output = %s, want the referrer manifest inlined", out.String())
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/history"
+)
+
+// historyHandler handles JSON metadata output for history command.
+type historyHandler struct {
+	out   io.Writer
+	model *model.TagHistory
+}
+
+// NewHistoryHandler creates a new JSON handler for history command.
+func NewHistoryHandler(out io.Writer, reference string) metadata.HistoryHandler {
+	return &historyHandler{
+		out:   out,
+		model: model.NewTagHistory(reference),
+	}
+}
+
+// OnRecordFound implements metadata.HistoryHandler.
+func (h *historyHandler) OnRecordFound(r history.Record) error {
+	h.model.AddRecord(r)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *historyHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
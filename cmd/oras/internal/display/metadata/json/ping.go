@@ -0,0 +1,53 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/probe"
+)
+
+// pingHandler handles JSON metadata output for ping command.
+type pingHandler struct {
+	out        io.Writer
+	registry   string
+	repository string
+	model      *model.Ping
+}
+
+// NewPingHandler creates a new JSON handler for ping command.
+func NewPingHandler(out io.Writer, registry, repository string) metadata.PingHandler {
+	return &pingHandler{
+		out:        out,
+		registry:   registry,
+		repository: repository,
+	}
+}
+
+// OnProbed implements metadata.PingHandler.
+func (h *pingHandler) OnProbed(result *probe.Result) error {
+	h.model = model.NewPing(h.registry, h.repository, result)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *pingHandler) Render() error {
+	return output.PrintPrettyJSON(h.out, h.model)
+}
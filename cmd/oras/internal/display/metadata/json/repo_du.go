@@ -0,0 +1,57 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// repoDiskUsageHandler handles JSON metadata output for repo du command.
+type repoDiskUsageHandler struct {
+	out   io.Writer
+	model *model.DiskUsage
+}
+
+// NewRepoDiskUsageHandler creates a new JSON handler for repo du command.
+func NewRepoDiskUsageHandler(out io.Writer, repo string) metadata.RepoDiskUsageHandler {
+	return &repoDiskUsageHandler{
+		out:   out,
+		model: model.NewDiskUsage(repo),
+	}
+}
+
+// OnTagMeasured implements metadata.RepoDiskUsageHandler.
+func (h *repoDiskUsageHandler) OnTagMeasured(tag string, desc ocispec.Descriptor, size int64, blobCount int) error {
+	h.model.AddTag(tag, desc, size, blobCount)
+	return nil
+}
+
+// OnTotalMeasured implements metadata.RepoDiskUsageHandler.
+func (h *repoDiskUsageHandler) OnTotalMeasured(size int64, blobCount int) error {
+	h.model.SetTotal(size, blobCount)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *repoDiskUsageHandler) Render() error {
+	h.model.Sort()
+	return output.PrintPrettyJSON(h.out, h.model)
+}
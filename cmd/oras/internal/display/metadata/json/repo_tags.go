@@ -25,15 +25,17 @@ import (
 
 // repoTagsHandler handles JSON metadata output for repo tags command.
 type repoTagsHandler struct {
-	out   io.Writer
-	model *model.Tags
+	out           io.Writer
+	model         *model.Tags
+	groupByDigest bool
 }
 
 // NewRepoTagsHandler creates a new handler for repo tags events.
-func NewRepoTagsHandler(out io.Writer) metadata.RepoTagsHandler {
+func NewRepoTagsHandler(out io.Writer, groupByDigest bool) metadata.RepoTagsHandler {
 	return &repoTagsHandler{
-		out:   out,
-		model: model.NewTags(),
+		out:           out,
+		model:         model.NewTags(),
+		groupByDigest: groupByDigest,
 	}
 }
 
@@ -43,7 +45,16 @@ func (h *repoTagsHandler) OnTagListed(tag string) error {
 	return nil
 }
 
+// OnTagResolved implements metadata.TagsHandler.
+func (h *repoTagsHandler) OnTagResolved(tag string, digest string, created string) error {
+	h.model.AddTagManifest(tag, digest, created)
+	return nil
+}
+
 // Render implements metadata.TagsHandler.
 func (h *repoTagsHandler) Render() error {
+	if h.groupByDigest {
+		h.model.GroupByDigest()
+	}
 	return output.PrintPrettyJSON(h.out, h.model)
 }
@@ -0,0 +1,52 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "time"
+
+// BackupTag is a tag backed up by oras backup.
+type BackupTag struct {
+	Name      string `json:"name"`
+	Referrers int    `json:"referrers"`
+}
+
+// backup contains metadata formatted by oras backup.
+type backup struct {
+	Repository string      `json:"repository"`
+	Output     string      `json:"output"`
+	Tags       []BackupTag `json:"tags"`
+	Failures   []Failure   `json:"failures"`
+	Duration   float64     `json:"durationMs"`
+	TransferReport
+}
+
+// NewBackup returns a metadata getter for backup command.
+func NewBackup(repo string, output string, tags []BackupTag, failures []Failure, duration time.Duration, transfers []Transfer) any {
+	if tags == nil {
+		tags = []BackupTag{}
+	}
+	if failures == nil {
+		failures = []Failure{}
+	}
+	return backup{
+		Repository:     repo,
+		Output:         output,
+		Tags:           tags,
+		Failures:       failures,
+		Duration:       milliseconds(duration),
+		TransferReport: NewTransferReport(transfers),
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"oras.land/oras/internal/bench"
+)
+
+// BenchLatency contains latency percentiles in milliseconds.
+type BenchLatency struct {
+	P50 float64 `json:"p50Ms"`
+	P90 float64 `json:"p90Ms"`
+	P99 float64 `json:"p99Ms"`
+	Max float64 `json:"maxMs"`
+}
+
+// BenchRun is the measurement of a benchmark run.
+type BenchRun struct {
+	Operation   string       `json:"operation"`
+	Size        int64        `json:"size"`
+	Count       int          `json:"count"`
+	Concurrency int          `json:"concurrency"`
+	Duration    float64      `json:"durationMs"`
+	Throughput  float64      `json:"throughputBytesPerSecond"`
+	Latency     BenchLatency `json:"latency"`
+}
+
+// Bench contains metadata formatted by oras bench.
+type Bench struct {
+	Repository string     `json:"repository"`
+	Runs       []BenchRun `json:"runs"`
+}
+
+// NewBench creates a new Bench model.
+func NewBench(repo string) *Bench {
+	return &Bench{
+		Repository: repo,
+		Runs:       []BenchRun{},
+	}
+}
+
+// AddResult adds the result of a benchmark run to the metadata.
+func (b *Bench) AddResult(result bench.Result) {
+	b.Runs = append(b.Runs, BenchRun{
+		Operation:   string(result.Operation),
+		Size:        result.Size,
+		Count:       result.Count,
+		Concurrency: result.Concurrency,
+		Duration:    milliseconds(result.Duration),
+		Throughput:  result.Throughput,
+		Latency: BenchLatency{
+			P50: milliseconds(result.Latency.P50),
+			P90: milliseconds(result.Latency.P90),
+			P99: milliseconds(result.Latency.P99),
+			Max: milliseconds(result.Latency.Max),
+		},
+	})
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"oras.land/oras/internal/conformance"
+)
+
+// ConformanceCheck is the result of a conformance check.
+type ConformanceCheck struct {
+	Suite  string `json:"suite"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Conformance contains metadata formatted by oras conformance.
+type Conformance struct {
+	Repository string             `json:"repository"`
+	Passed     int                `json:"passed"`
+	Failed     int                `json:"failed"`
+	Skipped    int                `json:"skipped"`
+	Checks     []ConformanceCheck `json:"checks"`
+}
+
+// NewConformance creates a new Conformance model.
+func NewConformance(repo string) *Conformance {
+	return &Conformance{
+		Repository: repo,
+		Checks:     []ConformanceCheck{},
+	}
+}
+
+// AddResult adds the result of a check to the metadata.
+func (c *Conformance) AddResult(result conformance.Result) {
+	check := ConformanceCheck{
+		Suite:  string(result.Suite),
+		Name:   result.Name,
+		Status: string(result.Status),
+	}
+	if result.Err != nil {
+		check.Error = result.Err.Error()
+	}
+	switch result.Status {
+	case conformance.StatusPass:
+		c.Passed++
+	case conformance.StatusFail:
+		c.Failed++
+	case conformance.StatusSkip:
+		c.Skipped++
+	}
+	c.Checks = append(c.Checks, check)
+}
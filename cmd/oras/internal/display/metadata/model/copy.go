@@ -0,0 +1,44 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// CopiedArtifact is an artifact copied by oras cp.
+type CopiedArtifact struct {
+	Descriptor
+	ReferenceAsTags []string `json:"referenceAsTags"`
+}
+
+// copied contains metadata formatted by oras cp.
+type copied struct {
+	Artifacts []CopiedArtifact `json:"artifacts"`
+	Failures  []Failure        `json:"failures"`
+	TransferReport
+}
+
+// NewCopy returns a metadata getter for cp command.
+func NewCopy(artifacts []CopiedArtifact, failures []Failure, transfers []Transfer) any {
+	if artifacts == nil {
+		artifacts = []CopiedArtifact{}
+	}
+	if failures == nil {
+		failures = []Failure{}
+	}
+	return copied{
+		Artifacts:      artifacts,
+		Failures:       failures,
+		TransferReport: NewTransferReport(transfers),
+	}
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/opencontainers/go-digest"
@@ -32,7 +33,9 @@ type Discover struct {
 // Node represents a node in the discovered reference tree.
 type Node struct {
 	Descriptor
-	Referrers []*Node `json:"referrers"`
+	// Manifest is the content of the referrer manifest, if fetched.
+	Manifest  json.RawMessage `json:"manifest,omitempty"`
+	Referrers []*Node         `json:"referrers"`
 }
 
 // AddReferrer adds a node to the discovered referrers tree.
@@ -47,6 +50,19 @@ func (d *Discover) AddReferrer(referrer, subject ocispec.Descriptor) error {
 	return nil
 }
 
+// SetManifest sets the manifest content of a discovered referrer.
+func (d *Discover) SetManifest(referrer ocispec.Descriptor, content []byte) error {
+	node, ok := d.nodes[referrer.Digest]
+	if !ok {
+		return fmt.Errorf("unexpected referrer descriptor: %v", referrer)
+	}
+	if !json.Valid(content) {
+		return fmt.Errorf("invalid manifest content of %s", referrer.Digest)
+	}
+	node.Manifest = content
+	return nil
+}
+
 // NewDiscover creates a new discover model.
 func NewDiscover(path string, root ocispec.Descriptor) Discover {
 	treeRoot := NewNode(path, root)
@@ -0,0 +1,31 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// Failure is an item failed in a bulk operation run with --continue-on-error.
+type Failure struct {
+	// Name is the name of the item, e.g. a tag.
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// NewFailure returns the failure of the item named name with err.
+func NewFailure(name string, err error) Failure {
+	return Failure{
+		Name:  name,
+		Error: err.Error(),
+	}
+}
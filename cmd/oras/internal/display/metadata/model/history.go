@@ -0,0 +1,37 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "oras.land/oras/internal/history"
+
+// TagHistory contains metadata formatted by oras history.
+type TagHistory struct {
+	Reference string           `json:"reference"`
+	Records   []history.Record `json:"records"`
+}
+
+// NewTagHistory creates a new TagHistory model.
+func NewTagHistory(reference string) *TagHistory {
+	return &TagHistory{
+		Reference: reference,
+		Records:   []history.Record{},
+	}
+}
+
+// AddRecord adds a movement of the tag to the metadata.
+func (h *TagHistory) AddRecord(r history.Record) {
+	h.Records = append(h.Records, r)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/internal/platform"
+)
+
+// PlatformManifest is a platform-specific manifest of an artifact.
+type PlatformManifest struct {
+	Platform     string   `json:"platform"`
+	OS           string   `json:"os"`
+	Architecture string   `json:"architecture"`
+	Variant      string   `json:"variant,omitempty"`
+	OSVersion    string   `json:"osVersion,omitempty"`
+	OSFeatures   []string `json:"osFeatures,omitempty"`
+	Digest       string   `json:"digest"`
+	MediaType    string   `json:"mediaType"`
+	Size         int64    `json:"size"`
+}
+
+// Platforms contains metadata formatted by oras manifest platforms.
+type Platforms struct {
+	Reference string             `json:"reference"`
+	Manifests []PlatformManifest `json:"manifests"`
+}
+
+// NewPlatforms creates a new Platforms model.
+func NewPlatforms(reference string) *Platforms {
+	return &Platforms{
+		Reference: reference,
+		Manifests: []PlatformManifest{},
+	}
+}
+
+// Add adds the manifest desc for the platform p to the metadata.
+func (ps *Platforms) Add(p ocispec.Platform, desc ocispec.Descriptor) {
+	ps.Manifests = append(ps.Manifests, PlatformManifest{
+		Platform:     platform.String(&p),
+		OS:           p.OS,
+		Architecture: p.Architecture,
+		Variant:      p.Variant,
+		OSVersion:    p.OSVersion,
+		OSFeatures:   p.OSFeatures,
+		Digest:       desc.Digest.String(),
+		MediaType:    desc.MediaType,
+		Size:         desc.Size,
+	})
+}
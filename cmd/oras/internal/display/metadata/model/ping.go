@@ -0,0 +1,77 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"time"
+
+	"oras.land/oras/internal/probe"
+)
+
+// Latency summarizes the round trip times to a registry in milliseconds.
+type Latency struct {
+	Min     float64 `json:"minMs"`
+	Average float64 `json:"averageMs"`
+	Max     float64 `json:"maxMs"`
+	Count   int     `json:"count"`
+}
+
+// Ping contains metadata formatted by oras ping.
+type Ping struct {
+	Registry      string           `json:"registry"`
+	Repository    string           `json:"repository,omitempty"`
+	APIVersion    string           `json:"apiVersion"`
+	Latency       Latency          `json:"latency"`
+	Referrers     probe.Capability `json:"referrersAPI"`
+	BlobMount     probe.Capability `json:"blobMount"`
+	RangeRequests probe.Capability `json:"rangeRequests"`
+	ChunkedUpload probe.Capability `json:"chunkedUpload"`
+}
+
+// NewPing creates a new Ping model from a probe result.
+func NewPing(registry, repository string, result *probe.Result) *Ping {
+	p := &Ping{
+		Registry:      registry,
+		Repository:    repository,
+		APIVersion:    result.APIVersion,
+		Referrers:     result.Referrers,
+		BlobMount:     result.BlobMount,
+		RangeRequests: result.RangeRequests,
+		ChunkedUpload: result.ChunkedUpload,
+	}
+	var minimum, maximum, sum time.Duration
+	for i, l := range result.Latencies {
+		if i == 0 || l < minimum {
+			minimum = l
+		}
+		maximum = max(maximum, l)
+		sum += l
+	}
+	if n := len(result.Latencies); n > 0 {
+		p.Latency = Latency{
+			Min:     milliseconds(minimum),
+			Average: milliseconds(sum / time.Duration(n)),
+			Max:     milliseconds(maximum),
+			Count:   n,
+		}
+	}
+	return p
+}
+
+// milliseconds converts d to milliseconds rounded to microseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Round(time.Microsecond)) / float64(time.Millisecond)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"cmp"
+	"slices"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// TagUsage is the storage usage of a tag.
+type TagUsage struct {
+	Tag       string `json:"tag"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+	BlobCount int    `json:"blobCount"`
+}
+
+// DiskUsage contains metadata formatted by oras repo du.
+type DiskUsage struct {
+	Repository string     `json:"repository"`
+	Tags       []TagUsage `json:"tags"`
+	TotalSize  int64      `json:"totalSize"`
+	BlobCount  int        `json:"blobCount"`
+}
+
+// NewDiskUsage creates a new DiskUsage model.
+func NewDiskUsage(repo string) *DiskUsage {
+	return &DiskUsage{
+		Repository: repo,
+		Tags:       []TagUsage{},
+	}
+}
+
+// AddTag adds the storage usage of a tag to the metadata.
+func (du *DiskUsage) AddTag(tag string, desc ocispec.Descriptor, size int64, blobCount int) {
+	du.Tags = append(du.Tags, TagUsage{
+		Tag:       tag,
+		Digest:    desc.Digest.String(),
+		Size:      size,
+		BlobCount: blobCount,
+	})
+}
+
+// SetTotal sets the deduplicated storage usage of the repository.
+func (du *DiskUsage) SetTotal(size int64, blobCount int) {
+	du.TotalSize = size
+	du.BlobCount = blobCount
+}
+
+// Sort sorts the tags by size in descending order, breaking ties by tag name.
+func (du *DiskUsage) Sort() {
+	slices.SortStableFunc(du.Tags, func(a, b TagUsage) int {
+		if c := cmp.Compare(b.Size, a.Size); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Tag, b.Tag)
+	})
+}
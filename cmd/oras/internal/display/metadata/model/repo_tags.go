@@ -15,9 +15,34 @@ limitations under the License.
 
 package model
 
+import "regexp"
+
+// versionTagRegexp matches the tags of full semantic versions, such as v1.2.3
+// and 1.2.3-rc.1, which are conventionally not moved to other manifests.
+var versionTagRegexp = regexp.MustCompile(`^v?[0-9]+\.[0-9]+\.[0-9]+([-+][0-9A-Za-z.+-]+)?$`)
+
+// TagManifest is the manifest a tag points to.
+type TagManifest struct {
+	Tag     string `json:"tag"`
+	Digest  string `json:"digest,omitempty"`
+	Created string `json:"created,omitempty"`
+}
+
+// TagGroup is a group of tags pointing to the same manifest.
+type TagGroup struct {
+	Digest string `json:"digest"`
+	// Versions are the tags of full semantic versions.
+	Versions []string `json:"versions"`
+	// Movable are the other tags, such as latest and v1, aliasing the
+	// versions.
+	Movable []string `json:"movable"`
+}
+
 // Tags contains metadata formatted by oras repo tags.
 type Tags struct {
-	Tags []string `json:"tags"`
+	Tags      []string      `json:"tags"`
+	Manifests []TagManifest `json:"manifests,omitempty"`
+	Groups    []TagGroup    `json:"groups,omitempty"`
 }
 
 // NewTags creates a new Tags model.
@@ -31,3 +56,39 @@ func NewTags() *Tags {
 func (t *Tags) AddTag(tag string) {
 	t.Tags = append(t.Tags, tag)
 }
+
+// AddTagManifest adds a tag to the metadata along with the digest and the
+// creation time of the manifest it points to, which are omitted if empty.
+func (t *Tags) AddTagManifest(tag string, digest string, created string) {
+	t.AddTag(tag)
+	t.Manifests = append(t.Manifests, TagManifest{
+		Tag:     tag,
+		Digest:  digest,
+		Created: created,
+	})
+}
+
+// GroupByDigest groups the tags added by AddTagManifest by the digests of the
+// manifests they point to, replacing the manifests in the metadata. The groups
+// are ordered by their first tags.
+func (t *Tags) GroupByDigest() {
+	groups := make(map[string]int)
+	for _, m := range t.Manifests {
+		i, ok := groups[m.Digest]
+		if !ok {
+			i = len(t.Groups)
+			groups[m.Digest] = i
+			t.Groups = append(t.Groups, TagGroup{
+				Digest:   m.Digest,
+				Versions: []string{},
+				Movable:  []string{},
+			})
+		}
+		if versionTagRegexp.MatchString(m.Tag) {
+			t.Groups[i].Versions = append(t.Groups[i].Versions, m.Tag)
+		} else {
+			t.Groups[i].Movable = append(t.Groups[i].Movable, m.Tag)
+		}
+	}
+	t.Manifests = nil
+}
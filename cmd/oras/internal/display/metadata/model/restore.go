@@ -0,0 +1,68 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "time"
+
+// Statuses of the restored tags.
+const (
+	// RestoreStatusRestored indicates the tag is restored.
+	RestoreStatusRestored = "restored"
+	// RestoreStatusSkipped indicates the tag is not restored as it exists in
+	// the target repository with a different digest.
+	RestoreStatusSkipped = "skipped"
+	// RestoreStatusCorrupted indicates the tag is not restored as its content
+	// in the backup is corrupted.
+	RestoreStatusCorrupted = "corrupted"
+	// RestoreStatusFailed indicates the tag fails to be restored and the
+	// restore continues with the other tags.
+	RestoreStatusFailed = "failed"
+)
+
+// RestoreTag is a tag restored by oras restore.
+type RestoreTag struct {
+	Name string `json:"name"`
+	// Status is one of RestoreStatusRestored, RestoreStatusSkipped,
+	// RestoreStatusCorrupted and RestoreStatusFailed, or the planned action, e.g. "create", in dry
+	// run mode.
+	Status    string `json:"status"`
+	Referrers int    `json:"referrers"`
+	// Error is the reason the tag is not restored.
+	Error string `json:"error,omitempty"`
+}
+
+// restore contains metadata formatted by oras restore.
+type restore struct {
+	Repository string       `json:"repository"`
+	DryRun     bool         `json:"dryRun"`
+	Tags       []RestoreTag `json:"tags"`
+	Duration   float64      `json:"durationMs"`
+	TransferReport
+}
+
+// NewRestore returns a metadata getter for restore command.
+func NewRestore(repo string, dryRun bool, tags []RestoreTag, duration time.Duration, transfers []Transfer) any {
+	if tags == nil {
+		tags = []RestoreTag{}
+	}
+	return restore{
+		Repository:     repo,
+		DryRun:         dryRun,
+		Tags:           tags,
+		Duration:       milliseconds(duration),
+		TransferReport: NewTransferReport(transfers),
+	}
+}
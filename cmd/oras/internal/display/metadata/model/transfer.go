@@ -0,0 +1,197 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Statuses of the transferred descriptors.
+const (
+	// TransferStatusCopied indicates the content is copied.
+	TransferStatusCopied = "copied"
+	// TransferStatusSkipped indicates the content already exists in the
+	// destination and is not copied.
+	TransferStatusSkipped = "skipped"
+	// TransferStatusMounted indicates the content is mounted from another
+	// repository of the destination registry.
+	TransferStatusMounted = "mounted"
+	// TransferStatusFailed indicates the copy of the content has started but
+	// not completed.
+	TransferStatusFailed = "failed"
+)
+
+// Transfer is the status of a transferred descriptor.
+type Transfer struct {
+	ocispec.Descriptor
+	// Status is one of TransferStatusCopied, TransferStatusSkipped,
+	// TransferStatusMounted and TransferStatusFailed.
+	Status string `json:"status"`
+	// Bytes is the number of bytes copied.
+	Bytes int64 `json:"bytes"`
+	// StartedAt is the time the copy started, if the content is copied.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	// CompletedAt is the time the copy completed, if the content is copied.
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// Duration is the duration of the copy in milliseconds.
+	Duration float64 `json:"durationMs,omitempty"`
+}
+
+// TransferSummary counts the transferred descriptors by status.
+type TransferSummary struct {
+	Copied  int   `json:"copied"`
+	Skipped int   `json:"skipped"`
+	Mounted int   `json:"mounted"`
+	Failed  int   `json:"failed"`
+	Bytes   int64 `json:"bytes"`
+}
+
+// TransferReport reports the status of the transferred descriptors.
+type TransferReport struct {
+	Descriptors []Transfer      `json:"descriptors"`
+	Summary     TransferSummary `json:"summary"`
+}
+
+// NewTransferReport creates a new TransferReport summarizing transfers.
+func NewTransferReport(transfers []Transfer) TransferReport {
+	report := TransferReport{
+		Descriptors: transfers,
+	}
+	if report.Descriptors == nil {
+		report.Descriptors = []Transfer{}
+	}
+	for _, t := range transfers {
+		switch t.Status {
+		case TransferStatusCopied:
+			report.Summary.Copied++
+		case TransferStatusSkipped:
+			report.Summary.Skipped++
+		case TransferStatusMounted:
+			report.Summary.Mounted++
+		case TransferStatusFailed:
+			report.Summary.Failed++
+		}
+		report.Summary.Bytes += t.Bytes
+	}
+	return report
+}
+
+// Transferred records the status of the transferred descriptors. It is safe
+// for concurrent use.
+type Transferred struct {
+	lock      sync.Mutex
+	transfers []Transfer
+	index     map[digest.Digest]int
+	// now returns the current time, time.Now if nil.
+	now func() time.Time
+}
+
+// Start records the start of copying desc.
+func (t *Transferred) Start(desc ocispec.Descriptor) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.timeNow()
+	transfer := t.get(desc)
+	transfer.Status = TransferStatusFailed
+	transfer.StartedAt = &now
+}
+
+// Copied records desc as copied.
+func (t *Transferred) Copied(desc ocispec.Descriptor) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.timeNow()
+	transfer := t.get(desc)
+	transfer.Status = TransferStatusCopied
+	transfer.Bytes = desc.Size
+	if transfer.StartedAt == nil {
+		transfer.StartedAt = &now
+	}
+	transfer.CompletedAt = &now
+	transfer.Duration = milliseconds(now.Sub(*transfer.StartedAt))
+}
+
+// Skipped records desc as skipped, unless it has already been copied or
+// mounted.
+func (t *Transferred) Skipped(desc ocispec.Descriptor) {
+	t.done(desc, TransferStatusSkipped)
+}
+
+// Mounted records desc as mounted, unless it has already been copied.
+func (t *Transferred) Mounted(desc ocispec.Descriptor) {
+	t.done(desc, TransferStatusMounted)
+}
+
+// Transfers returns the recorded transfers in the order they are first seen.
+// The transfers started but not completed are reported as failed.
+func (t *Transferred) Transfers() []Transfer {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	transfers := make([]Transfer, len(t.transfers))
+	copy(transfers, t.transfers)
+	return transfers
+}
+
+// done records desc as completed with status without copying, which does not
+// override the status of the content copied or mounted before.
+func (t *Transferred) done(desc ocispec.Descriptor, status string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	transfer := t.get(desc)
+	switch transfer.Status {
+	case TransferStatusCopied:
+		return
+	case TransferStatusMounted:
+		if status == TransferStatusSkipped {
+			return
+		}
+	}
+	transfer.Status = status
+}
+
+// get returns the transfer of desc, adding it if not found. The caller must
+// hold the lock.
+func (t *Transferred) get(desc ocispec.Descriptor) *Transfer {
+	if i, ok := t.index[desc.Digest]; ok {
+		return &t.transfers[i]
+	}
+	if t.index == nil {
+		t.index = make(map[digest.Digest]int)
+	}
+	t.index[desc.Digest] = len(t.transfers)
+	t.transfers = append(t.transfers, Transfer{
+		Descriptor: ocispec.Descriptor{
+			MediaType:    desc.MediaType,
+			Digest:       desc.Digest,
+			Size:         desc.Size,
+			ArtifactType: desc.ArtifactType,
+			Annotations:  desc.Annotations,
+		},
+	})
+	return &t.transfers[len(t.transfers)-1]
+}
+
+// timeNow returns the current time.
+func (t *Transferred) timeNow() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
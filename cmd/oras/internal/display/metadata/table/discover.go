@@ -19,10 +19,12 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"text/tabwriter"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
 	"oras.land/oras/cmd/oras/internal/output"
 )
 
@@ -32,6 +34,8 @@ type discoverHandler struct {
 	rawReference string
 	root         ocispec.Descriptor
 	verbose      bool
+	wide         bool
+	annotations  []string
 	referrers    []ocispec.Descriptor
 }
 
@@ -45,6 +49,19 @@ func NewDiscoverHandler(out io.Writer, rawReference string, root ocispec.Descrip
 	}
 }
 
+// NewWideDiscoverHandler creates a new handler for discover events, listing
+// the artifact type, creation time, size, digest and the annotations of
+// each referrer in aligned columns.
+func NewWideDiscoverHandler(out io.Writer, rawReference string, root ocispec.Descriptor, annotations []string) metadata.DiscoverHandler {
+	return &discoverHandler{
+		out:          out,
+		rawReference: rawReference,
+		root:         root,
+		wide:         true,
+		annotations:  annotations,
+	}
+}
+
 // OnDiscovered implements metadata.DiscoverHandler.
 func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) error {
 	if !content.Equal(subject, h.root) {
@@ -75,9 +92,43 @@ func (h *discoverHandler) Render() (err error) {
 	if err != nil {
 		return err
 	}
+	if h.wide {
+		return h.printWideReferrersTable()
+	}
 	return h.printDiscoveredReferrersTable()
 }
 
+func (h *discoverHandler) printWideReferrersTable() error {
+	w := tabwriter.NewWriter(h.out, 0, 0, 3, ' ', 0)
+	titles := append([]string{"Artifact Type", "Created", "Size", "Digest"}, h.annotations...)
+	if _, err := fmt.Fprintln(w, strings.Join(titles, "\t")); err != nil {
+		return err
+	}
+	for _, ref := range h.referrers {
+		row := []string{
+			cell(ref.ArtifactType),
+			cell(ref.Annotations[ocispec.AnnotationCreated]),
+			humanize.ToBytes(ref.Size).String(),
+			ref.Digest.String(),
+		}
+		for _, key := range h.annotations {
+			row = append(row, cell(ref.Annotations[key]))
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// cell returns value in a table cell, or "-" if value is empty.
+func cell(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(value)
+}
+
 func (h *discoverHandler) printDiscoveredReferrersTable() (err error) {
 	typeNameTitle := "Artifact Type"
 	typeNameLength := len(typeNameTitle)
@@ -173,3 +173,39 @@ func Test_discoverHandler_Render(t *testing.T) {
 		})
 	}
 }
+
+func TestTableDiscoverHandler_Wide(t *testing.T) {
+	buf := new(bytes.Buffer)
+	root := ocispec.Descriptor{Digest: "root"}
+	tdh := NewWideDiscoverHandler(buf, "rawRef", root, []string{"org.opencontainers.image.source"})
+
+	one := ocispec.Descriptor{
+		ArtifactType: "application/vnd.example.sbom",
+		Digest:       "one",
+		Size:         1024,
+		Annotations: map[string]string{
+			ocispec.AnnotationCreated:         "2024-01-01T00:00:00Z",
+			"org.opencontainers.image.source": "https://example.com/repo\twith tab",
+		},
+	}
+	two := ocispec.Descriptor{Digest: "two", Size: 12}
+	for _, referrer := range []ocispec.Descriptor{one, two} {
+		if err := tdh.OnDiscovered(referrer, root); err != nil {
+			t.Fatalf("OnDiscovered() unexpected error: %v", err)
+		}
+	}
+
+	if err := tdh.Render(); err != nil {
+		t.Fatalf("Render() unexpected error: %v", err)
+	}
+	expected := `Discovered 2 artifacts referencing rawRef
+Digest: root
+
+Artifact Type                  Created                Size    Digest   org.opencontainers.image.source
+application/vnd.example.sbom   2024-01-01T00:00:00Z   1 KB    one      https://example.com/repo with tab
+-                              -                      12  B   two      -
+`
+	if buf.String() != expected {
+		t.Errorf("Expected output <%s> actual <%s>", expected, buf.String())
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+// teePushHandler passes the push events to two handlers.
+type teePushHandler struct {
+	PushHandler
+	secondary PushHandler
+}
+
+// NewTeePushHandler returns a push handler passing the events to primary and
+// then to secondary.
+func NewTeePushHandler(primary, secondary PushHandler) PushHandler {
+	return &teePushHandler{PushHandler: primary, secondary: secondary}
+}
+
+// OnTagged implements TaggedHandler.
+func (h *teePushHandler) OnTagged(desc ocispec.Descriptor, tag string) error {
+	if err := h.PushHandler.OnTagged(desc, tag); err != nil {
+		return err
+	}
+	return h.secondary.OnTagged(desc, tag)
+}
+
+// OnCopied implements PushHandler.
+func (h *teePushHandler) OnCopied(opts *option.Target, root ocispec.Descriptor) error {
+	if err := h.PushHandler.OnCopied(opts, root); err != nil {
+		return err
+	}
+	return h.secondary.OnCopied(opts, root)
+}
+
+// Render implements Renderer.
+func (h *teePushHandler) Render() error {
+	if err := h.PushHandler.Render(); err != nil {
+		return err
+	}
+	return h.secondary.Render()
+}
+
+// teeAttachHandler passes the attach events to two handlers.
+type teeAttachHandler struct {
+	AttachHandler
+	secondary AttachHandler
+}
+
+// NewTeeAttachHandler returns an attach handler passing the events to primary
+// and then to secondary.
+func NewTeeAttachHandler(primary, secondary AttachHandler) AttachHandler {
+	return &teeAttachHandler{AttachHandler: primary, secondary: secondary}
+}
+
+// OnAttached implements AttachHandler.
+func (h *teeAttachHandler) OnAttached(target *option.Target, root ocispec.Descriptor, subject ocispec.Descriptor) {
+	h.AttachHandler.OnAttached(target, root, subject)
+	h.secondary.OnAttached(target, root, subject)
+}
+
+// Render implements Renderer.
+func (h *teeAttachHandler) Render() error {
+	if err := h.AttachHandler.Render(); err != nil {
+		return err
+	}
+	return h.secondary.Render()
+}
+
+// teePullHandler passes the pull events to two handlers.
+type teePullHandler struct {
+	PullHandler
+	secondary PullHandler
+}
+
+// NewTeePullHandler returns a pull handler passing the events to primary and
+// then to secondary.
+func NewTeePullHandler(primary, secondary PullHandler) PullHandler {
+	return &teePullHandler{PullHandler: primary, secondary: secondary}
+}
+
+// OnLayerSkipped implements PullHandler.
+func (h *teePullHandler) OnLayerSkipped(desc ocispec.Descriptor) error {
+	if err := h.PullHandler.OnLayerSkipped(desc); err != nil {
+		return err
+	}
+	return h.secondary.OnLayerSkipped(desc)
+}
+
+// OnFilePulled implements PullHandler.
+func (h *teePullHandler) OnFilePulled(name string, outputDir string, desc ocispec.Descriptor, descPath string) error {
+	if err := h.PullHandler.OnFilePulled(name, outputDir, desc, descPath); err != nil {
+		return err
+	}
+	return h.secondary.OnFilePulled(name, outputDir, desc, descPath)
+}
+
+// OnPulled implements PullHandler.
+func (h *teePullHandler) OnPulled(target *option.Target, desc ocispec.Descriptor) {
+	h.PullHandler.OnPulled(target, desc)
+	h.secondary.OnPulled(target, desc)
+}
+
+// Render implements Renderer.
+func (h *teePullHandler) Render() error {
+	if err := h.PullHandler.Render(); err != nil {
+		return err
+	}
+	return h.secondary.Render()
+}
@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"io"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/backupcatalog"
+)
+
+// backupInspectHandler handles template metadata output for backup inspect command.
+type backupInspectHandler struct {
+	out      io.Writer
+	catalog  *backupcatalog.Catalog
+	template string
+}
+
+// NewBackupInspectHandler creates a new template handler for backup inspect command.
+func NewBackupInspectHandler(out io.Writer, tmpl string) metadata.BackupInspectHandler {
+	return &backupInspectHandler{
+		out:      out,
+		template: tmpl,
+	}
+}
+
+// OnCatalogLoaded implements metadata.BackupInspectHandler.
+func (h *backupInspectHandler) OnCatalogLoaded(catalog *backupcatalog.Catalog) error {
+	h.catalog = catalog
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *backupInspectHandler) Render() error {
+	return output.ParseAndWrite(h.out, h.catalog, h.template)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"io"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/contentutil"
+)
+
+// CopyHandler handles go-template metadata output for cp events.
+type CopyHandler struct {
+	out       io.Writer
+	template  string
+	recorder  *model.Transferred
+	lock      sync.Mutex
+	path      string
+	artifacts []model.CopiedArtifact
+	failures  []model.Failure
+}
+
+// NewCopyHandler returns a new handler for cp events, reporting the
+// descriptors transferred recorded by recorder.
+func NewCopyHandler(out io.Writer, template string, recorder *model.Transferred) metadata.CopyHandler {
+	return &CopyHandler{
+		out:      out,
+		template: template,
+		recorder: recorder,
+	}
+}
+
+// OnTagged implements metadata.TaggedHandler.
+func (h *CopyHandler) OnTagged(desc ocispec.Descriptor, tag string) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for i := range h.artifacts {
+		if h.artifacts[i].Digest == desc.Digest {
+			h.artifacts[i].ReferenceAsTags = append(h.artifacts[i].ReferenceAsTags, h.path+":"+tag)
+			break
+		}
+	}
+	return nil
+}
+
+// OnCopied implements metadata.CopyHandler.
+func (h *CopyHandler) OnCopied(target *option.BinaryTarget, desc ocispec.Descriptor) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.path = target.To.Path
+	artifact := model.CopiedArtifact{
+		Descriptor:      model.FromDescriptor(target.To.Path, desc),
+		ReferenceAsTags: []string{},
+	}
+	if target.To.Reference != "" && !contentutil.IsDigest(target.To.Reference) {
+		artifact.ReferenceAsTags = append(artifact.ReferenceAsTags, target.To.Path+":"+target.To.Reference)
+	}
+	h.artifacts = append(h.artifacts, artifact)
+	return nil
+}
+
+// OnCopyFailed implements metadata.CopyHandler.
+func (h *CopyHandler) OnCopyFailed(target *option.BinaryTarget, err error) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.failures = append(h.failures, model.NewFailure(target.From.Reference, err))
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *CopyHandler) Render() error {
+	return output.ParseAndWrite(h.out, model.NewCopy(h.artifacts, h.failures, h.recorder.Transfers()), h.template)
+}
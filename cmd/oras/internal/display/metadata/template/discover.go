@@ -47,6 +47,11 @@ func (h *discoverHandler) OnDiscovered(referrer, subject ocispec.Descriptor) err
 	return h.model.AddReferrer(referrer, subject)
 }
 
+// OnManifestFetched implements metadata.DiscoverManifestHandler.
+func (h *discoverHandler) OnManifestFetched(referrer ocispec.Descriptor, content []byte) error {
+	return h.model.SetManifest(referrer, content)
+}
+
 // Render implements metadata.DiscoverHandler.
 func (h *discoverHandler) Render() error {
 	return output.ParseAndWrite(h.out, h.model.Root, h.template)
@@ -0,0 +1,54 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// manifestPlatformsHandler handles template metadata output for manifest
+// platforms command.
+type manifestPlatformsHandler struct {
+	out      io.Writer
+	model    *model.Platforms
+	template string
+}
+
+// NewManifestPlatformsHandler creates a new template handler for manifest
+// platforms command.
+func NewManifestPlatformsHandler(out io.Writer, reference string, tmpl string) metadata.ManifestPlatformsHandler {
+	return &manifestPlatformsHandler{
+		out:      out,
+		model:    model.NewPlatforms(reference),
+		template: tmpl,
+	}
+}
+
+// OnPlatformFound implements metadata.ManifestPlatformsHandler.
+func (h *manifestPlatformsHandler) OnPlatformFound(p ocispec.Platform, desc ocispec.Descriptor) error {
+	h.model.Add(p, desc)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *manifestPlatformsHandler) Render() error {
+	return output.ParseAndWrite(h.out, h.model, h.template)
+}
@@ -25,17 +25,19 @@ import (
 
 // repoTagsHandler handles template metadata output for repo tags command.
 type repoTagsHandler struct {
-	out      io.Writer
-	model    *model.Tags
-	template string
+	out           io.Writer
+	model         *model.Tags
+	template      string
+	groupByDigest bool
 }
 
 // NewRepoTagsHandler creates a new template handler for repo tags command.
-func NewRepoTagsHandler(out io.Writer, tmpl string) metadata.RepoTagsHandler {
+func NewRepoTagsHandler(out io.Writer, tmpl string, groupByDigest bool) metadata.RepoTagsHandler {
 	return &repoTagsHandler{
-		out:      out,
-		model:    model.NewTags(),
-		template: tmpl,
+		out:           out,
+		model:         model.NewTags(),
+		groupByDigest: groupByDigest,
+		template:      tmpl,
 	}
 }
 
@@ -45,7 +47,16 @@ func (h *repoTagsHandler) OnTagListed(tag string) error {
 	return nil
 }
 
+// OnTagResolved implements metadata.TagsHandler.
+func (h *repoTagsHandler) OnTagResolved(tag string, digest string, created string) error {
+	h.model.AddTagManifest(tag, digest, created)
+	return nil
+}
+
 // Render implements metadata.TagsHandler.
 func (h *repoTagsHandler) Render() error {
+	if h.groupByDigest {
+		h.model.GroupByDigest()
+	}
 	return output.ParseAndWrite(h.out, h.model, h.template)
 }
@@ -0,0 +1,126 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// RestoreHandler handles go-template metadata output for restore events.
+type RestoreHandler struct {
+	out      io.Writer
+	template string
+	repo     string
+	dryRun   bool
+	recorder *model.Transferred
+	tags     []model.RestoreTag
+	duration time.Duration
+}
+
+// NewRestoreHandler returns a new handler for restore events, reporting the
+// descriptors transferred recorded by recorder.
+func NewRestoreHandler(out io.Writer, template string, dryRun bool, recorder *model.Transferred) metadata.RestoreHandler {
+	return &RestoreHandler{
+		out:      out,
+		template: template,
+		dryRun:   dryRun,
+		recorder: recorder,
+	}
+}
+
+// OnTarLoaded implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnTarLoaded(_ string, _ int64) error {
+	return nil
+}
+
+// OnTagsFound implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnTagsFound(_ []string) error {
+	return nil
+}
+
+// OnArtifactPushed implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnArtifactPushed(tag string, referrerCount int) error {
+	h.tags = append(h.tags, model.RestoreTag{
+		Name:      tag,
+		Status:    model.RestoreStatusRestored,
+		Referrers: referrerCount,
+	})
+	return nil
+}
+
+// OnArtifactPlanned implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnArtifactPlanned(tag string, action string, referrerCount int) error {
+	h.tags = append(h.tags, model.RestoreTag{
+		Name:      tag,
+		Status:    action,
+		Referrers: referrerCount,
+	})
+	return nil
+}
+
+// OnRestoreResumed implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnRestoreResumed(_ int) error {
+	return nil
+}
+
+// OnTagSkipped implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnTagSkipped(tag string, existing ocispec.Descriptor) error {
+	h.tags = append(h.tags, model.RestoreTag{
+		Name:   tag,
+		Status: model.RestoreStatusSkipped,
+		Error:  fmt.Sprintf("the tag exists with digest %s", existing.Digest),
+	})
+	return nil
+}
+
+// OnTagCorrupted implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnTagCorrupted(tag string, err error) error {
+	h.tags = append(h.tags, model.RestoreTag{
+		Name:   tag,
+		Status: model.RestoreStatusCorrupted,
+		Error:  err.Error(),
+	})
+	return nil
+}
+
+// OnArtifactFailed implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnArtifactFailed(tag string, err error) error {
+	h.tags = append(h.tags, model.RestoreTag{
+		Name:   tag,
+		Status: model.RestoreStatusFailed,
+		Error:  err.Error(),
+	})
+	return nil
+}
+
+// OnRestoreCompleted implements metadata.RestoreHandler.
+func (h *RestoreHandler) OnRestoreCompleted(_ int, repo string, duration time.Duration) error {
+	h.repo = repo
+	h.duration = duration
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *RestoreHandler) Render() error {
+	return output.ParseAndWrite(h.out, model.NewRestore(h.repo, h.dryRun, h.tags, h.duration, h.recorder.Transfers()), h.template)
+}
@@ -48,6 +48,21 @@ func (bh *BackupHandler) OnTarExported(path string, size int64) error {
 	return bh.printer.Printf("Exported to %s (%s)\n", path, humanize.ToBytes(size))
 }
 
+// OnTarVolumesExported implements metadata.BackupHandler.
+func (bh *BackupHandler) OnTarVolumesExported(path string, count int, size int64) error {
+	return bh.printer.Printf("Exported to %s.001 - %s.%03d (%d volumes, %s)\n", path, path, count, count, humanize.ToBytes(size))
+}
+
+// OnBlobsDeduplicated implements metadata.BackupHandler.
+func (bh *BackupHandler) OnBlobsDeduplicated(count int, size int64) error {
+	return bh.printer.Printf("Deduplicated %d blob(s) shared with other backups, saving %s\n", count, humanize.ToBytes(size))
+}
+
+// OnBackupResumed implements metadata.BackupHandler.
+func (bh *BackupHandler) OnBackupResumed(count int) error {
+	return bh.printer.Printf("Resuming an interrupted backup: %d blob(s) already downloaded\n", count)
+}
+
 // OnTarExporting implements metadata.BackupHandler.
 func (bh *BackupHandler) OnTarExporting(path string) error {
 	return bh.printer.Printf("Exporting to %s\n", path)
@@ -59,6 +74,11 @@ func (bh *BackupHandler) OnArtifactPulled(tag string, referrerCount int) error {
 	return bh.printer.Printf("Pulled tag %s with %d referrer(s)\n", tag, referrerCount)
 }
 
+// OnArtifactFailed implements metadata.BackupHandler.
+func (bh *BackupHandler) OnArtifactFailed(tag string, err error) error {
+	return bh.printer.Printf("Failed to back up tag %s: %v\n", tag, err)
+}
+
 // OnTagsFound implements metadata.BackupHandler.
 func (bh *BackupHandler) OnTagsFound(tags []string) error {
 	if len(tags) == 0 {
@@ -0,0 +1,79 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/internal/backupcatalog"
+)
+
+// backupInspectHandler handles text output for backup inspect command.
+type backupInspectHandler struct {
+	out     io.Writer
+	catalog *backupcatalog.Catalog
+}
+
+// NewBackupInspectHandler creates a new text handler for backup inspect command.
+func NewBackupInspectHandler(out io.Writer) metadata.BackupInspectHandler {
+	return &backupInspectHandler{
+		out: out,
+	}
+}
+
+// OnCatalogLoaded implements metadata.BackupInspectHandler.
+func (h *backupInspectHandler) OnCatalogLoaded(catalog *backupcatalog.Catalog) error {
+	h.catalog = catalog
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *backupInspectHandler) Render() error {
+	if _, err := fmt.Fprintf(h.out, "Created: %s\nTool:    %s %s\n", h.catalog.Created.Format(time.RFC3339), h.catalog.Tool, h.catalog.Version); err != nil {
+		return err
+	}
+	for _, repo := range h.catalog.Repositories {
+		if _, err := fmt.Fprintf(h.out, "\nRepository: %s\n", repo.Name); err != nil {
+			return err
+		}
+		if repo.Path != "" {
+			if _, err := fmt.Fprintf(h.out, "Path:       %s\n", repo.Path); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(h.out, "Size:       %s (%d bytes) in %d blobs\n", humanize.ToBytes(repo.Size), repo.Size, repo.BlobCount); err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(h.out, 0, 0, 2, ' ', 0)
+		if _, err := fmt.Fprintln(w, "TAG\tDIGEST\tMEDIA TYPE\tREFERRERS"); err != nil {
+			return err
+		}
+		for _, tag := range repo.Tags {
+			if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", tag.Name, tag.Digest, tag.MediaType, tag.Referrers); err != nil {
+				return err
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
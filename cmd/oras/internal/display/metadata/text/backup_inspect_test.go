@@ -0,0 +1,60 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"oras.land/oras/internal/backupcatalog"
+)
+
+func TestBackupInspectHandler_Render(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewBackupInspectHandler(&buf)
+	catalog := &backupcatalog.Catalog{
+		Tool:    "oras",
+		Version: "1.3.0",
+		Created: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Repositories: []backupcatalog.Repository{{
+			Name: "localhost:5000/hello",
+			Path: "localhost_5000/hello",
+			Tags: []backupcatalog.Tag{
+				{Name: "v1", MediaType: "application/vnd.oci.image.manifest.v1+json", Digest: "sha256:aaaa", Referrers: 2},
+			},
+			BlobCount: 3,
+			Size:      2048,
+		}},
+	}
+	if err := handler.OnCatalogLoaded(catalog); err != nil {
+		t.Fatalf("OnCatalogLoaded() error = %v", err)
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "Created: 2025-01-02T03:04:05Z\n" +
+		"Tool:    oras 1.3.0\n" +
+		"\n" +
+		"Repository: localhost:5000/hello\n" +
+		"Path:       localhost_5000/hello\n" +
+		"Size:       2 KB (2048 bytes) in 3 blobs\n" +
+		"TAG  DIGEST       MEDIA TYPE                                  REFERRERS\n" +
+		"v1   sha256:aaaa  application/vnd.oci.image.manifest.v1+json  2\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render() output = %q, want %q", got, want)
+	}
+}
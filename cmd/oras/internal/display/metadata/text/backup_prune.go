@@ -0,0 +1,68 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"strings"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// BackupPruneHandler handles text metadata output for backup prune events.
+type BackupPruneHandler struct {
+	printer  *output.Printer
+	location string
+	dryRun   bool
+}
+
+// NewBackupPruneHandler returns a new handler for backup prune events.
+func NewBackupPruneHandler(printer *output.Printer, location string, dryRun bool) metadata.BackupPruneHandler {
+	return &BackupPruneHandler{
+		printer:  printer,
+		location: location,
+		dryRun:   dryRun,
+	}
+}
+
+// OnBackupKept implements metadata.BackupPruneHandler.
+func (h *BackupPruneHandler) OnBackupKept(name string, created time.Time, repositories string, reasons []string) error {
+	return h.printer.Printf("Keep    %s of %s created at %s (%s)\n", name, repositories, created.Format(time.RFC3339), strings.Join(reasons, ", "))
+}
+
+// OnBackupExpired implements metadata.BackupPruneHandler.
+func (h *BackupPruneHandler) OnBackupExpired(name string, created time.Time, repositories string) error {
+	return h.printer.Printf("Expired %s of %s created at %s\n", name, repositories, created.Format(time.RFC3339))
+}
+
+// OnBackupPruned implements metadata.BackupPruneHandler.
+func (h *BackupPruneHandler) OnBackupPruned(name string) error {
+	return h.printer.Println("Deleted", name)
+}
+
+// OnBackupPruneCompleted implements metadata.BackupPruneHandler.
+func (h *BackupPruneHandler) OnBackupPruneCompleted(count int) error {
+	if h.dryRun {
+		return h.printer.Printf("Dry run complete: %d backup(s) would be pruned from %q\n", count, h.location)
+	}
+	return h.printer.Printf("Pruned %d backup(s) from %q\n", count, h.location)
+}
+
+// Render implements metadata.Renderer.
+func (h *BackupPruneHandler) Render() error {
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestBackupPruneHandler(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		dryRun bool
+		want   string
+	}{
+		{
+			name: "prune",
+			want: "Keep    backups/new.tar of localhost:5000/test created at 2024-01-01T00:00:00Z (last, weekly)\n" +
+				"Expired backups/old.tar of localhost:5000/test created at 2024-01-01T00:00:00Z\n" +
+				"Deleted backups/old.tar\n" +
+				"Pruned 1 backup(s) from \"backups\"\n",
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			want: "Keep    backups/new.tar of localhost:5000/test created at 2024-01-01T00:00:00Z (last, weekly)\n" +
+				"Expired backups/old.tar of localhost:5000/test created at 2024-01-01T00:00:00Z\n" +
+				"Dry run complete: 1 backup(s) would be pruned from \"backups\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			h := NewBackupPruneHandler(output.NewPrinter(out, os.Stderr), "backups", tt.dryRun)
+			if err := h.OnBackupKept("backups/new.tar", created, "localhost:5000/test", []string{"last", "weekly"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := h.OnBackupExpired("backups/old.tar", created, "localhost:5000/test"); err != nil {
+				t.Fatal(err)
+			}
+			if !tt.dryRun {
+				if err := h.OnBackupPruned("backups/old.tar"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := h.OnBackupPruneCompleted(1); err != nil {
+				t.Fatal(err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
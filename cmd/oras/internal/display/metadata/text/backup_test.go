@@ -17,6 +17,7 @@ package text
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -67,6 +68,28 @@ func TestBackupHandler_OnTarExported(t *testing.T) {
 	}
 }
 
+func TestBackupHandler_OnTarVolumesExported(t *testing.T) {
+	out := &bytes.Buffer{}
+	bh := NewBackupHandler("any", output.NewPrinter(out, os.Stderr))
+	if err := bh.OnTarVolumesExported("test.tar", 3, 2048); err != nil {
+		t.Fatalf("OnTarVolumesExported() error = %v", err)
+	}
+	if got, want := out.String(), "Exported to test.tar.001 - test.tar.003 (3 volumes, 2 KB)\n"; got != want {
+		t.Errorf("OnTarVolumesExported() got = %q, want %q", got, want)
+	}
+}
+
+func TestBackupHandler_OnBlobsDeduplicated(t *testing.T) {
+	out := &bytes.Buffer{}
+	bh := NewBackupHandler("any", output.NewPrinter(out, os.Stderr))
+	if err := bh.OnBlobsDeduplicated(3, 2048); err != nil {
+		t.Fatalf("OnBlobsDeduplicated() error = %v", err)
+	}
+	if got, want := out.String(), "Deduplicated 3 blob(s) shared with other backups, saving 2 KB\n"; got != want {
+		t.Errorf("OnBlobsDeduplicated() got = %q, want %q", got, want)
+	}
+}
+
 func TestBackupHandler_OnTarExporting(t *testing.T) {
 	path := "test.tar"
 	tests := []struct {
@@ -132,6 +155,17 @@ func TestBackupHandler_OnArtifactPulled(t *testing.T) {
 	}
 }
 
+func TestBackupHandler_OnArtifactFailed(t *testing.T) {
+	out := &bytes.Buffer{}
+	bh := NewBackupHandler("any", output.NewPrinter(out, os.Stderr))
+	if err := bh.OnArtifactFailed("latest", errors.New("blob unknown")); err != nil {
+		t.Fatalf("OnArtifactFailed() error = %v", err)
+	}
+	if got, want := out.String(), "Failed to back up tag latest: blob unknown\n"; got != want {
+		t.Errorf("OnArtifactFailed() got = %v, want %v", got, want)
+	}
+}
+
 func TestBackupHandler_OnTagsFound(t *testing.T) {
 	repo := "testRepo"
 	tests := []struct {
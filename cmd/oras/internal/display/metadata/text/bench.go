@@ -0,0 +1,63 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/internal/bench"
+)
+
+// benchHandler handles text output for bench commands.
+type benchHandler struct {
+	out   io.Writer
+	model *model.Bench
+}
+
+// NewBenchHandler creates a new text handler for bench commands.
+func NewBenchHandler(out io.Writer, repo string) metadata.BenchHandler {
+	return &benchHandler{
+		out:   out,
+		model: model.NewBench(repo),
+	}
+}
+
+// OnRunCompleted implements metadata.BenchHandler.
+func (h *benchHandler) OnRunCompleted(result bench.Result) error {
+	h.model.AddResult(result)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *benchHandler) Render() error {
+	w := tabwriter.NewWriter(h.out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "OPERATION\tSIZE\tCOUNT\tCONCURRENCY\tTHROUGHPUT\tP50\tP90\tP99\tMAX"); err != nil {
+		return err
+	}
+	for _, r := range h.model.Runs {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s/s\t%.2fms\t%.2fms\t%.2fms\t%.2fms\n",
+			r.Operation, humanize.ToBytes(r.Size), r.Count, r.Concurrency, humanize.ToBytes(int64(r.Throughput)),
+			r.Latency.P50, r.Latency.P90, r.Latency.P99, r.Latency.Max); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,54 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"oras.land/oras/internal/bench"
+)
+
+func TestBenchHandler_Render(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewBenchHandler(&buf, "localhost:5000/bench")
+	result := bench.Result{
+		Operation:   bench.OperationPush,
+		Size:        1 << 20,
+		Count:       10,
+		Concurrency: 4,
+		Duration:    time.Second,
+		Throughput:  10 << 20,
+		Latency: bench.Latency{
+			P50: 100 * time.Millisecond,
+			P90: 200 * time.Millisecond,
+			P99: 300 * time.Millisecond,
+			Max: 301500 * time.Microsecond,
+		},
+	}
+	if err := h.OnRunCompleted(result); err != nil {
+		t.Fatalf("OnRunCompleted() error = %v", err)
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "OPERATION  SIZE  COUNT  CONCURRENCY  THROUGHPUT  P50       P90       P99       MAX\n" +
+		"push       1 MB  10     4            10 MB/s     100.00ms  200.00ms  300.00ms  301.50ms\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
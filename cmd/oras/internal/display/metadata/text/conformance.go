@@ -0,0 +1,65 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/internal/conformance"
+)
+
+// conformanceHandler handles text output for conformance command.
+type conformanceHandler struct {
+	out   io.Writer
+	model *model.Conformance
+}
+
+// NewConformanceHandler creates a new text handler for conformance command.
+func NewConformanceHandler(out io.Writer, repo string) metadata.ConformanceHandler {
+	return &conformanceHandler{
+		out:   out,
+		model: model.NewConformance(repo),
+	}
+}
+
+// OnCheckCompleted implements metadata.ConformanceHandler.
+func (h *conformanceHandler) OnCheckCompleted(result conformance.Result) error {
+	h.model.AddResult(result)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *conformanceHandler) Render() error {
+	w := tabwriter.NewWriter(h.out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "SUITE\tCHECK\tRESULT\tDETAIL"); err != nil {
+		return err
+	}
+	for _, c := range h.model.Checks {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Suite, c.Name, strings.ToUpper(c.Status), c.Error); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(h.out, "\n%d passed, %d failed, %d skipped against %s\n", h.model.Passed, h.model.Failed, h.model.Skipped, h.model.Repository)
+	return err
+}
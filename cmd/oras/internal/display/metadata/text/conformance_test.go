@@ -0,0 +1,50 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"oras.land/oras/internal/conformance"
+)
+
+func TestConformanceHandler_Render(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewConformanceHandler(&buf, "localhost:5000/test")
+	results := []conformance.Result{
+		{Suite: conformance.SuitePush, Name: "push blob", Status: conformance.StatusPass},
+		{Suite: conformance.SuiteManagement, Name: "delete manifest", Status: conformance.StatusFail, Err: errors.New("unsupported")},
+		{Suite: conformance.SuiteManagement, Name: "fetch deleted", Status: conformance.StatusSkip, Err: errors.New("prerequisite failed")},
+	}
+	for _, r := range results {
+		if err := h.OnCheckCompleted(r); err != nil {
+			t.Fatalf("OnCheckCompleted() error = %v", err)
+		}
+	}
+	if err := h.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "SUITE       CHECK            RESULT  DETAIL\n" +
+		"push        push blob        PASS    \n" +
+		"management  delete manifest  FAIL    unsupported\n" +
+		"management  fetch deleted    SKIP    prerequisite failed\n" +
+		"\n1 passed, 1 failed, 1 skipped against localhost:5000/test\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
@@ -16,6 +16,9 @@ limitations under the License.
 package text
 
 import (
+	"fmt"
+	"text/tabwriter"
+
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/option"
@@ -26,6 +29,17 @@ import (
 type CopyHandler struct {
 	printer *output.Printer
 	desc    ocispec.Descriptor
+	// copied are the destination tags and digests of the artifacts copied,
+	// summarized on rendering if more than one artifact is copied.
+	copied []copiedArtifact
+	// failed is the number of artifacts failed to copy.
+	failed int
+}
+
+// copiedArtifact is an artifact copied to the destination.
+type copiedArtifact struct {
+	tag    string
+	digest string
 }
 
 // NewCopyHandler returns a new handler for cp events.
@@ -42,11 +56,40 @@ func (h *CopyHandler) OnTagged(_ ocispec.Descriptor, tag string) error {
 
 // Render implements metadata.Renderer.
 func (h *CopyHandler) Render() error {
-	return h.printer.Println("Digest:", h.desc.Digest)
+	if len(h.copied) == 0 && h.failed > 0 {
+		// no digest to print
+		return nil
+	}
+	if len(h.copied) <= 1 {
+		return h.printer.Println("Digest:", h.desc.Digest)
+	}
+	if err := h.printer.Printf("Copied %d artifact(s):\n", len(h.copied)); err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(h.printer, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "TAG\tDIGEST"); err != nil {
+		return err
+	}
+	for _, copied := range h.copied {
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", copied.tag, copied.digest); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
 }
 
 // OnCopied implements metadata.CopyHandler.
 func (h *CopyHandler) OnCopied(target *option.BinaryTarget, desc ocispec.Descriptor) error {
 	h.desc = desc
+	h.copied = append(h.copied, copiedArtifact{
+		tag:    target.To.Reference,
+		digest: desc.Digest.String(),
+	})
 	return h.printer.Println("Copied", target.From.GetDisplayReference(), "=>", target.To.GetDisplayReference())
 }
+
+// OnCopyFailed implements metadata.CopyHandler.
+func (h *CopyHandler) OnCopyFailed(target *option.BinaryTarget, err error) error {
+	h.failed++
+	return h.printer.Println("Failed to copy", target.From.GetDisplayReference(), "=>", target.To.GetDisplayReference()+":", err)
+}
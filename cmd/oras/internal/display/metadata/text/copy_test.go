@@ -17,6 +17,7 @@ package text
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -393,3 +394,53 @@ func TestCopyHandler_Integration(t *testing.T) {
 		t.Errorf("Integration test failed.\nGot:\n%q\nWant:\n%q", got, expected)
 	}
 }
+
+func TestCopyHandler_Render_multipleArtifacts(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewCopyHandler(output.NewPrinter(buf, os.Stderr))
+	for _, tag := range []string{"v1", "v10"} {
+		target := &option.BinaryTarget{
+			From: option.Target{Type: option.TargetTypeRemote, RawReference: "localhost:5000/src:" + tag},
+			To:   option.Target{Type: option.TargetTypeRemote, RawReference: "localhost:5000/dst:" + tag, Reference: tag},
+		}
+		if err := handler.OnCopied(target, ocispec.Descriptor{Digest: digest.Digest("sha256:" + tag)}); err != nil {
+			t.Fatalf("OnCopied() error = %v", err)
+		}
+	}
+	buf.Reset()
+	if err := handler.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "Copied 2 artifact(s):\n" +
+		"TAG  DIGEST\n" +
+		"v1   sha256:v1\n" +
+		"v10  sha256:v10\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render() output = %q, want %q", got, want)
+	}
+}
+
+func TestCopyHandler_OnCopyFailed(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewCopyHandler(output.NewPrinter(buf, os.Stderr))
+	target := &option.BinaryTarget{
+		From: option.Target{Type: option.TargetTypeRemote, RawReference: "localhost:5000/src:v1", Reference: "v1"},
+		To:   option.Target{Type: option.TargetTypeRemote, RawReference: "localhost:5000/dst:v1", Reference: "v1"},
+	}
+	if err := handler.OnCopyFailed(target, errors.New("manifest unknown")); err != nil {
+		t.Fatalf("OnCopyFailed() error = %v", err)
+	}
+	want := "Failed to copy [registry] localhost:5000/src:v1 => [registry] localhost:5000/dst:v1: manifest unknown\n"
+	if got := buf.String(); got != want {
+		t.Errorf("OnCopyFailed() output = %q, want %q", got, want)
+	}
+
+	// no digest is rendered as no artifact is copied
+	buf.Reset()
+	if err := handler.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got := buf.String(); got != "" {
+		t.Errorf("Render() output = %q, want empty", got)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/history"
+)
+
+// historyHandler handles text output for history command.
+type historyHandler struct {
+	out   io.Writer
+	model *model.TagHistory
+}
+
+// NewHistoryHandler creates a new text handler for history command.
+func NewHistoryHandler(out io.Writer, reference string) metadata.HistoryHandler {
+	return &historyHandler{
+		out:   out,
+		model: model.NewTagHistory(reference),
+	}
+}
+
+// OnRecordFound implements metadata.HistoryHandler.
+func (h *historyHandler) OnRecordFound(r history.Record) error {
+	h.model.AddRecord(r)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *historyHandler) Render() error {
+	if len(h.model.Records) == 0 {
+		_, err := fmt.Fprintf(h.out, "No history recorded for %s\n", h.model.Reference)
+		return err
+	}
+	w := tabwriter.NewWriter(h.out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "TIME\tDIGEST\tPREVIOUS\tACTOR\tCOMMAND"); err != nil {
+		return err
+	}
+	for _, r := range h.model.Records {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Time.UTC().Format(time.RFC3339), shortDigest(r.Digest), shortDigest(r.Previous), orDash(r.Actor), orDash(r.Command)); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// shortDigest returns the short form of the digest dgst, or "-" if dgst is
+// empty.
+func shortDigest(dgst string) string {
+	if dgst == "" {
+		return "-"
+	}
+	return descriptor.ShortDigest(ocispec.Descriptor{Digest: digest.Digest(dgst)})
+}
+
+// orDash returns s, or "-" if s is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
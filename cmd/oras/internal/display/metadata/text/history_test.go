@@ -0,0 +1,66 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"oras.land/oras/internal/history"
+)
+
+func TestHistoryHandler(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewHistoryHandler(&buf, "localhost:5000/repo:latest")
+	if err := handler.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got, want := buf.String(), "No history recorded for localhost:5000/repo:latest\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	handler = NewHistoryHandler(&buf, "localhost:5000/repo:latest")
+	for _, r := range []history.Record{
+		{
+			Tag:      "latest",
+			Digest:   "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+			Previous: "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			Actor:    "ci",
+			Command:  "tag",
+			Time:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Tag:    "latest",
+			Digest: "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			Time:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		if err := handler.OnRecordFound(r); err != nil {
+			t.Fatalf("OnRecordFound() error = %v", err)
+		}
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "TIME                  DIGEST        PREVIOUS      ACTOR  COMMAND\n" +
+		"2024-01-02T00:00:00Z  bbbbbbbbbbbb  aaaaaaaaaaaa  ci     tag\n" +
+		"2024-01-01T00:00:00Z  aaaaaaaaaaaa  -             -      -\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+// manifestPlatformsHandler handles text output for manifest platforms command.
+type manifestPlatformsHandler struct {
+	out   io.Writer
+	model *model.Platforms
+}
+
+// NewManifestPlatformsHandler creates a new text handler for manifest
+// platforms command.
+func NewManifestPlatformsHandler(out io.Writer, reference string) metadata.ManifestPlatformsHandler {
+	return &manifestPlatformsHandler{
+		out:   out,
+		model: model.NewPlatforms(reference),
+	}
+}
+
+// OnPlatformFound implements metadata.ManifestPlatformsHandler.
+func (h *manifestPlatformsHandler) OnPlatformFound(p ocispec.Platform, desc ocispec.Descriptor) error {
+	h.model.Add(p, desc)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *manifestPlatformsHandler) Render() error {
+	w := tabwriter.NewWriter(h.out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "PLATFORM\tDIGEST\tSIZE"); err != nil {
+		return err
+	}
+	for _, m := range h.model.Manifests {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%d\n", m.Platform, m.Digest, m.Size); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
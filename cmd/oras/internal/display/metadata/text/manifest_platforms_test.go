@@ -0,0 +1,52 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestManifestPlatformsHandler_Render(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewManifestPlatformsHandler(&buf, "localhost:5000/test:v1")
+	platforms := []ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm", Variant: "v7"},
+		{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763.5458"},
+	}
+	for i, desc := range []ocispec.Descriptor{
+		{Digest: "sha256:aaaa", Size: 577},
+		{Digest: "sha256:bbbb", Size: 1024},
+		{Digest: "sha256:cc", Size: 8},
+	} {
+		if err := handler.OnPlatformFound(platforms[i], desc); err != nil {
+			t.Fatalf("OnPlatformFound() error = %v", err)
+		}
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "PLATFORM                       DIGEST       SIZE\n" +
+		"linux/amd64                    sha256:aaaa  577\n" +
+		"linux/arm/v7                   sha256:bbbb  1024\n" +
+		"windows/amd64:10.0.17763.5458  sha256:cc    8\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render() output = %q, want %q", got, want)
+	}
+}
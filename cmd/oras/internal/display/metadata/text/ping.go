@@ -0,0 +1,93 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+	"oras.land/oras/internal/probe"
+)
+
+// pingHandler handles text output for ping command.
+type pingHandler struct {
+	out        io.Writer
+	registry   string
+	repository string
+	write      bool
+	model      *model.Ping
+}
+
+// NewPingHandler creates a new text handler for ping command.
+func NewPingHandler(out io.Writer, registry, repository string, write bool) metadata.PingHandler {
+	return &pingHandler{
+		out:        out,
+		registry:   registry,
+		repository: repository,
+		write:      write,
+	}
+}
+
+// OnProbed implements metadata.PingHandler.
+func (h *pingHandler) OnProbed(result *probe.Result) error {
+	h.model = model.NewPing(h.registry, h.repository, result)
+	return nil
+}
+
+// Render implements metadata.Renderer.
+func (h *pingHandler) Render() error {
+	m := h.model
+	w := tabwriter.NewWriter(h.out, 0, 0, 1, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Registry:\t%s\n", m.Registry)
+	if m.Repository != "" {
+		_, _ = fmt.Fprintf(w, "Repository:\t%s\n", m.Repository)
+	}
+	apiVersion := m.APIVersion
+	if apiVersion == "" {
+		apiVersion = "not reported"
+	}
+	_, _ = fmt.Fprintf(w, "API version:\t%s\n", apiVersion)
+	_, _ = fmt.Fprintf(w, "Latency:\tmin %.2fms, avg %.2fms, max %.2fms (%d requests)\n",
+		m.Latency.Min, m.Latency.Average, m.Latency.Max, m.Latency.Count)
+	_, _ = fmt.Fprintf(w, "Referrers API:\t%s\n", h.describe(m.Referrers, false, false))
+	_, _ = fmt.Fprintf(w, "Range requests:\t%s\n", h.describe(m.RangeRequests, true, false))
+	_, _ = fmt.Fprintf(w, "Chunked uploads:\t%s\n", h.describe(m.ChunkedUpload, false, true))
+	blobMount := h.describe(m.BlobMount, true, true)
+	if m.BlobMount == probe.Likely {
+		blobMount += " (mounted within the repository, mounts across repositories may still be refused)"
+	}
+	_, _ = fmt.Fprintf(w, "Blob mounting:\t%s\n", blobMount)
+	return w.Flush()
+}
+
+// describe explains why a capability could not be probed.
+func (h *pingHandler) describe(c probe.Capability, needsBlob, needsWrite bool) string {
+	if c != probe.Unknown {
+		return string(c)
+	}
+	switch {
+	case h.repository == "":
+		return "unknown (specify a repository to probe)"
+	case needsWrite && !h.write:
+		return "unknown (use --write to probe)"
+	case needsBlob:
+		return "unknown (specify a tag or digest of a manifest to probe)"
+	}
+	return string(c)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"oras.land/oras/internal/probe"
+)
+
+func TestPingHandler_Render(t *testing.T) {
+	result := &probe.Result{
+		APIVersion:    "registry/2.0",
+		Latencies:     []time.Duration{2 * time.Millisecond, 4 * time.Millisecond},
+		Referrers:     probe.Supported,
+		RangeRequests: probe.Unsupported,
+		ChunkedUpload: probe.Unknown,
+		BlobMount:     probe.Unknown,
+	}
+	tests := []struct {
+		name       string
+		repository string
+		write      bool
+		blobMount  probe.Capability
+		want       string
+	}{
+		{
+			name:       "read only probe",
+			repository: "hello",
+			want: "Registry:        localhost:5000\n" +
+				"Repository:      hello\n" +
+				"API version:     registry/2.0\n" +
+				"Latency:         min 2.00ms, avg 3.00ms, max 4.00ms (2 requests)\n" +
+				"Referrers API:   supported\n" +
+				"Range requests:  unsupported\n" +
+				"Chunked uploads: unknown (use --write to probe)\n" +
+				"Blob mounting:   unknown (use --write to probe)\n",
+		},
+		{
+			name: "registry only probe",
+			want: "Registry:        localhost:5000\n" +
+				"API version:     registry/2.0\n" +
+				"Latency:         min 2.00ms, avg 3.00ms, max 4.00ms (2 requests)\n" +
+				"Referrers API:   supported\n" +
+				"Range requests:  unsupported\n" +
+				"Chunked uploads: unknown (specify a repository to probe)\n" +
+				"Blob mounting:   unknown (specify a repository to probe)\n",
+		},
+		{
+			name:       "write probe",
+			repository: "hello",
+			write:      true,
+			blobMount:  probe.Likely,
+			want: "Registry:        localhost:5000\n" +
+				"Repository:      hello\n" +
+				"API version:     registry/2.0\n" +
+				"Latency:         min 2.00ms, avg 3.00ms, max 4.00ms (2 requests)\n" +
+				"Referrers API:   supported\n" +
+				"Range requests:  unsupported\n" +
+				"Chunked uploads: unknown\n" +
+				"Blob mounting:   likely (mounted within the repository, mounts across repositories may still be refused)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewPingHandler(&buf, "localhost:5000", tt.repository, tt.write)
+			result := *result
+			if tt.blobMount != "" {
+				result.BlobMount = tt.blobMount
+			}
+			if err := h.OnProbed(&result); err != nil {
+				t.Fatalf("OnProbed() error = %v", err)
+			}
+			if err := h.Render(); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
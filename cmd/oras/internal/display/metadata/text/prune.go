@@ -0,0 +1,63 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+// PruneHandler handles text metadata output for prune events.
+type PruneHandler struct {
+	printer *output.Printer
+	repo    string
+	dryRun  bool
+}
+
+// NewPruneHandler returns a new handler for prune events.
+func NewPruneHandler(printer *output.Printer, repo string, dryRun bool) metadata.PruneHandler {
+	return &PruneHandler{
+		printer: printer,
+		repo:    repo,
+		dryRun:  dryRun,
+	}
+}
+
+// OnExpiredFound implements metadata.PruneHandler.
+func (h *PruneHandler) OnExpiredFound(desc ocispec.Descriptor, expiresAt time.Time) error {
+	return h.printer.Printf("Expired %s@%s at %s\n", h.repo, desc.Digest, expiresAt.Format(time.RFC3339))
+}
+
+// OnManifestPruned implements metadata.PruneHandler.
+func (h *PruneHandler) OnManifestPruned(desc ocispec.Descriptor) error {
+	return h.printer.Println("Deleted", h.repo+"@"+desc.Digest.String())
+}
+
+// OnPruneCompleted implements metadata.PruneHandler.
+func (h *PruneHandler) OnPruneCompleted(count int) error {
+	if h.dryRun {
+		return h.printer.Printf("Dry run complete: %d expired manifest(s) would be pruned from %q\n", count, h.repo)
+	}
+	return h.printer.Printf("Pruned %d expired manifest(s) from %q\n", count, h.repo)
+}
+
+// Render implements metadata.Renderer.
+func (h *PruneHandler) Render() error {
+	return nil
+}
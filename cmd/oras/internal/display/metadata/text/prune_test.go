@@ -0,0 +1,70 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/output"
+)
+
+func TestPruneHandler(t *testing.T) {
+	desc := ocispec.Descriptor{Digest: digest.FromString("foo")}
+	expiresAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		dryRun bool
+		want   string
+	}{
+		{
+			name: "prune",
+			want: "Expired localhost:5000/test@" + desc.Digest.String() + " at 2024-01-01T00:00:00Z\n" +
+				"Deleted localhost:5000/test@" + desc.Digest.String() + "\n" +
+				"Pruned 1 expired manifest(s) from \"localhost:5000/test\"\n",
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			want: "Expired localhost:5000/test@" + desc.Digest.String() + " at 2024-01-01T00:00:00Z\n" +
+				"Dry run complete: 1 expired manifest(s) would be pruned from \"localhost:5000/test\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			h := NewPruneHandler(output.NewPrinter(out, os.Stderr), "localhost:5000/test", tt.dryRun)
+			if err := h.OnExpiredFound(desc, expiresAt); err != nil {
+				t.Fatal(err)
+			}
+			if !tt.dryRun {
+				if err := h.OnManifestPruned(desc); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := h.OnPruneCompleted(1); err != nil {
+				t.Fatal(err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestRepoDiskUsageHandler_Render(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewRepoDiskUsageHandler(&buf, "localhost:5000/test")
+	if err := handler.OnTagMeasured("small", ocispec.Descriptor{Digest: "sha256:aaaa"}, 100, 2); err != nil {
+		t.Fatalf("OnTagMeasured() error = %v", err)
+	}
+	if err := handler.OnTagMeasured("large", ocispec.Descriptor{Digest: "sha256:bbbb"}, 2048, 3); err != nil {
+		t.Fatalf("OnTagMeasured() error = %v", err)
+	}
+	if err := handler.OnTotalMeasured(2100, 4); err != nil {
+		t.Fatalf("OnTotalMeasured() error = %v", err)
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "TAG    DIGEST       SIZE    BLOBS\n" +
+		"large  sha256:bbbb  2 KB    3\n" +
+		"small  sha256:aaaa  100  B  2\n" +
+		"Total: 2.05 KB (2100 bytes) in 4 unique blobs\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render() output = %q, want %q", got, want)
+	}
+}
@@ -16,20 +16,35 @@ limitations under the License.
 package text
 
 import (
+	"fmt"
 	"io"
+	"strings"
+	"text/tabwriter"
 
 	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
 )
 
 // repoTagsHandler handles text output for repo tags command.
 type repoTagsHandler struct {
-	out io.Writer
+	out           io.Writer
+	showDigest    bool
+	showCreated   bool
+	groupByDigest bool
+	model         *model.Tags
 }
 
-// NewRepoTagsHandler creates a new text handler for repo tags command.
-func NewRepoTagsHandler(out io.Writer) metadata.RepoTagsHandler {
+// NewRepoTagsHandler creates a new text handler for repo tags command. The
+// tags are printed in a table with the digests and the creation times of the
+// manifests if showDigest or showCreated is set, or grouped by the digests if
+// groupByDigest is set.
+func NewRepoTagsHandler(out io.Writer, showDigest, showCreated, groupByDigest bool) metadata.RepoTagsHandler {
 	return &repoTagsHandler{
-		out: out,
+		out:           out,
+		showDigest:    showDigest,
+		showCreated:   showCreated,
+		groupByDigest: groupByDigest,
+		model:         model.NewTags(),
 	}
 }
 
@@ -39,7 +54,66 @@ func (h *repoTagsHandler) OnTagListed(tag string) error {
 	return err
 }
 
+// OnTagResolved implements metadata.TagsHandler.
+func (h *repoTagsHandler) OnTagResolved(tag string, digest string, created string) error {
+	h.model.AddTagManifest(tag, digest, created)
+	return nil
+}
+
 // Render implements metadata.TagsHandler.
 func (h *repoTagsHandler) Render() error {
-	return nil
+	if h.groupByDigest {
+		return h.renderGroups()
+	}
+	if !h.showDigest && !h.showCreated {
+		return nil
+	}
+	header := []string{"TAG"}
+	if h.showDigest {
+		header = append(header, "DIGEST")
+	}
+	if h.showCreated {
+		header = append(header, "CREATED")
+	}
+	w := tabwriter.NewWriter(h.out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return err
+	}
+	for _, m := range h.model.Manifests {
+		row := []string{m.Tag}
+		if h.showDigest {
+			row = append(row, m.Digest)
+		}
+		if h.showCreated {
+			row = append(row, orDash(m.Created))
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// renderGroups prints the tags grouped by the digests of the manifests, with
+// the version tags separated from the movable tags aliasing them.
+func (h *repoTagsHandler) renderGroups() error {
+	h.model.GroupByDigest()
+	w := tabwriter.NewWriter(h.out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "DIGEST\tVERSIONS\tMOVABLE"); err != nil {
+		return err
+	}
+	for _, g := range h.model.Groups {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", g.Digest, joinTags(g.Versions), joinTags(g.Movable)); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// joinTags joins tags with commas, or returns "-" if there is no tag.
+func joinTags(tags []string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	return strings.Join(tags, ",")
 }
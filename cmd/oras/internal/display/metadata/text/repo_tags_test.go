@@ -0,0 +1,103 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package text
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRepoTagsHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		showDigest  bool
+		showCreated bool
+		want        string
+	}{
+		{
+			name: "tags only",
+			want: "v1\nv2\n",
+		},
+		{
+			name:       "digest",
+			showDigest: true,
+			want: "TAG  DIGEST\n" +
+				"v1   sha256:aaaa\n" +
+				"v2   sha256:bb\n",
+		},
+		{
+			name:        "digest and created",
+			showDigest:  true,
+			showCreated: true,
+			want: "TAG  DIGEST       CREATED\n" +
+				"v1   sha256:aaaa  2024-01-01T00:00:00Z\n" +
+				"v2   sha256:bb    -\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := NewRepoTagsHandler(&buf, tt.showDigest, tt.showCreated, false)
+			for _, tag := range []struct{ name, digest, created string }{
+				{"v1", "sha256:aaaa", "2024-01-01T00:00:00Z"},
+				{"v2", "sha256:bb", ""},
+			} {
+				var err error
+				if tt.showDigest || tt.showCreated {
+					err = handler.OnTagResolved(tag.name, tag.digest, tag.created)
+				} else {
+					err = handler.OnTagListed(tag.name)
+				}
+				if err != nil {
+					t.Fatalf("handling tag %s: %v", tag.name, err)
+				}
+			}
+			if err := handler.Render(); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoTagsHandler_groupByDigest(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewRepoTagsHandler(&buf, false, false, true)
+	for _, tag := range []struct{ name, digest string }{
+		{"latest", "sha256:aaaa"},
+		{"stable", "sha256:bb"},
+		{"v1", "sha256:aaaa"},
+		{"v1.0.0", "sha256:bb"},
+		{"v1.1.0-rc.1", "sha256:aaaa"},
+		{"v2.0", "sha256:cc"},
+	} {
+		if err := handler.OnTagResolved(tag.name, tag.digest, ""); err != nil {
+			t.Fatalf("OnTagResolved() error = %v", err)
+		}
+	}
+	if err := handler.Render(); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "DIGEST       VERSIONS     MOVABLE\n" +
+		"sha256:aaaa  v1.1.0-rc.1  latest,v1\n" +
+		"sha256:bb    v1.0.0       stable\n" +
+		"sha256:cc    -            v2.0\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"strings"
 	"time"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
 	"oras.land/oras/cmd/oras/internal/output"
 )
@@ -71,6 +72,37 @@ func (rh *RestoreHandler) OnArtifactPushed(tag string, referrerCount int) error
 	return rh.printer.Printf("Pushed tag %s with %d referrer(s)\n", tag, referrerCount)
 }
 
+// OnArtifactPlanned implements metadata.RestoreHandler.
+func (rh *RestoreHandler) OnArtifactPlanned(tag string, action string, referrerCount int) error {
+	return rh.printer.Printf("Dry run: would %s tag %s with %d referrer(s)\n", action, tag, referrerCount)
+}
+
+// OnRestoreResumed implements metadata.RestoreHandler.
+func (rh *RestoreHandler) OnRestoreResumed(count int) error {
+	return rh.printer.Printf("Resuming an interrupted restore: %d blob(s) and manifest(s) already restored\n", count)
+}
+
+// OnTagSkipped implements metadata.RestoreHandler.
+func (rh *RestoreHandler) OnTagSkipped(tag string, existing ocispec.Descriptor) error {
+	if rh.dryRun {
+		return rh.printer.Printf("Dry run: would skip tag %s existing with digest %s\n", tag, existing.Digest)
+	}
+	return rh.printer.Printf("Skipped tag %s existing with digest %s\n", tag, existing.Digest)
+}
+
+// OnTagCorrupted implements metadata.RestoreHandler.
+func (rh *RestoreHandler) OnTagCorrupted(tag string, err error) error {
+	if rh.dryRun {
+		return rh.printer.Printf("Dry run: would skip tag %s with corrupted content: %v\n", tag, err)
+	}
+	return rh.printer.Printf("Skipped tag %s with corrupted content: %v\n", tag, err)
+}
+
+// OnArtifactFailed implements metadata.RestoreHandler.
+func (rh *RestoreHandler) OnArtifactFailed(tag string, err error) error {
+	return rh.printer.Printf("Failed to restore tag %s: %v\n", tag, err)
+}
+
 // OnRestoreCompleted implements metadata.RestoreHandler.
 func (rh *RestoreHandler) OnRestoreCompleted(tagsCount int, repo string, duration time.Duration) error {
 	if rh.dryRun {
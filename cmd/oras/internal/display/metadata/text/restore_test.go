@@ -17,12 +17,14 @@ package text
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"testing"
 	"time"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras/cmd/oras/internal/output"
 )
 
@@ -200,6 +202,103 @@ func TestRestoreHandler_OnArtifactPushed(t *testing.T) {
 	}
 }
 
+func TestRestoreHandler_OnArtifactPlanned(t *testing.T) {
+	out := &bytes.Buffer{}
+	handler := NewRestoreHandler(output.NewPrinter(out, os.Stderr), true)
+	if err := handler.OnArtifactPlanned("latest", "overwrite", 2); err != nil {
+		t.Fatalf("OnArtifactPlanned() error = %v", err)
+	}
+	if got, want := out.String(), "Dry run: would overwrite tag latest with 2 referrer(s)\n"; got != want {
+		t.Errorf("OnArtifactPlanned() got = %v, want %v", got, want)
+	}
+}
+
+func TestRestoreHandler_OnRestoreResumed(t *testing.T) {
+	out := &bytes.Buffer{}
+	handler := NewRestoreHandler(output.NewPrinter(out, os.Stderr), false)
+	if err := handler.OnRestoreResumed(3); err != nil {
+		t.Fatalf("OnRestoreResumed() error = %v", err)
+	}
+	if got, want := out.String(), "Resuming an interrupted restore: 3 blob(s) and manifest(s) already restored\n"; got != want {
+		t.Errorf("OnRestoreResumed() got = %v, want %v", got, want)
+	}
+}
+
+func TestRestoreHandler_OnTagSkipped(t *testing.T) {
+	existing := ocispec.Descriptor{Digest: "sha256:aaaa"}
+	tests := []struct {
+		name   string
+		dryRun bool
+		want   string
+	}{
+		{
+			name:   "normal restore",
+			dryRun: false,
+			want:   "Skipped tag latest existing with digest sha256:aaaa\n",
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			want:   "Dry run: would skip tag latest existing with digest sha256:aaaa\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			handler := NewRestoreHandler(output.NewPrinter(out, os.Stderr), tt.dryRun)
+			if err := handler.OnTagSkipped("latest", existing); err != nil {
+				t.Fatalf("OnTagSkipped() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("OnTagSkipped() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestoreHandler_OnTagCorrupted(t *testing.T) {
+	corruptErr := errors.New("sha256:aaaa: digest mismatch")
+	tests := []struct {
+		name   string
+		dryRun bool
+		want   string
+	}{
+		{
+			name:   "normal restore",
+			dryRun: false,
+			want:   "Skipped tag latest with corrupted content: sha256:aaaa: digest mismatch\n",
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			want:   "Dry run: would skip tag latest with corrupted content: sha256:aaaa: digest mismatch\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			handler := NewRestoreHandler(output.NewPrinter(out, os.Stderr), tt.dryRun)
+			if err := handler.OnTagCorrupted("latest", corruptErr); err != nil {
+				t.Fatalf("OnTagCorrupted() error = %v", err)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("OnTagCorrupted() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestoreHandler_OnArtifactFailed(t *testing.T) {
+	out := &bytes.Buffer{}
+	handler := NewRestoreHandler(output.NewPrinter(out, os.Stderr), false)
+	if err := handler.OnArtifactFailed("latest", errors.New("connection reset")); err != nil {
+		t.Fatalf("OnArtifactFailed() error = %v", err)
+	}
+	if got, want := out.String(), "Failed to restore tag latest: connection reset\n"; got != want {
+		t.Errorf("OnArtifactFailed() got = %v, want %v", got, want)
+	}
+}
+
 func TestRestoreHandler_OnRestoreCompleted(t *testing.T) {
 	tagsCount := 5
 	repo := "example.com/myrepo"
@@ -0,0 +1,87 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prompt asks users to confirm destructive operations.
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// IsInteractive returns true if r is a terminal.
+func IsInteractive(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Confirm prints prompt to w and returns true if the user answers "y" or
+// "yes" in r.
+func Confirm(r io.Reader, w io.Writer, prompt string) (bool, error) {
+	_, _ = fmt.Fprint(w, prompt, " [y/N] ")
+	response, err := readLine(r)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(response) {
+	case "y", "yes":
+		return true, nil
+	default:
+		_, _ = fmt.Fprintln(w, "Operation cancelled.")
+		return false, nil
+	}
+}
+
+// ConfirmByTyping prints prompt to w and returns true if the user types
+// expected, such as the name of the affected repository, in r.
+func ConfirmByTyping(r io.Reader, w io.Writer, prompt string, expected string) (bool, error) {
+	_, _ = fmt.Fprintf(w, "%s\nType %q to confirm: ", prompt, expected)
+	response, err := readLine(r)
+	if err != nil {
+		return false, err
+	}
+	if response != expected {
+		_, _ = fmt.Fprintln(w, "Operation cancelled.")
+		return false, nil
+	}
+	return true, nil
+}
+
+// readLine reads a line from r without the trailing spaces.
+func readLine(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Scan()
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
+
+// Secret prints prompt to w and reads a secret from the terminal f without
+// echoing it.
+func Secret(f *os.File, w io.Writer, prompt string) (string, error) {
+	_, _ = fmt.Fprint(w, prompt)
+	secret, err := term.ReadPassword(int(f.Fd()))
+	_, _ = fmt.Fprintln(w)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(secret)), nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prompt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "y\n", want: true},
+		{input: "YES\n", want: true},
+		{input: "n\n", want: false},
+		{input: "", want: false},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got, err := Confirm(strings.NewReader(tt.input), &out, "Delete?")
+		if err != nil {
+			t.Fatalf("Confirm(%q) error = %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("Confirm(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if !strings.HasPrefix(out.String(), "Delete? [y/N] ") {
+			t.Errorf("Confirm(%q) prompt = %q", tt.input, out.String())
+		}
+	}
+}
+
+func TestConfirmByTyping(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "localhost:5000/hello\n", want: true},
+		{input: "  localhost:5000/hello  \n", want: true},
+		{input: "y\n", want: false},
+		{input: "localhost:5000/hello2\n", want: false},
+		{input: "", want: false},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got, err := ConfirmByTyping(strings.NewReader(tt.input), &out, "Delete?", "localhost:5000/hello")
+		if err != nil {
+			t.Fatalf("ConfirmByTyping(%q) error = %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("ConfirmByTyping(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if want := "Delete?\nType \"localhost:5000/hello\" to confirm: "; !strings.HasPrefix(out.String(), want) {
+			t.Errorf("ConfirmByTyping(%q) prompt = %q, want prefix %q", tt.input, out.String(), want)
+		}
+	}
+}
+
+func TestIsInteractive(t *testing.T) {
+	if IsInteractive(strings.NewReader("")) {
+		t.Error("IsInteractive() = true for a non-file reader")
+	}
+}
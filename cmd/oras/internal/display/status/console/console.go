@@ -23,7 +23,7 @@ import (
 )
 
 const (
-	// MinWidth is the minimal width of supported console.
+	// MinWidth is the width assumed if the console width cannot be determined.
 	MinWidth = 80
 	// MinHeight is the minimal height of supported console.
 	MinHeight = 10
@@ -58,6 +58,8 @@ func NewConsole(f *os.File) (Console, error) {
 
 // GetHeightWidth returns the width and height of the console.
 // If the console size cannot be determined, returns a default value of 80x10.
+// Consoles narrower than 80 columns are reported as is so that the output can
+// be laid out without wrapping.
 func (c *console) GetHeightWidth() (height, width int) {
 	windowSize, err := c.Size()
 	if err != nil {
@@ -66,7 +68,7 @@ func (c *console) GetHeightWidth() (height, width int) {
 	if windowSize.Height < MinHeight {
 		windowSize.Height = MinHeight
 	}
-	if windowSize.Width < MinWidth {
+	if windowSize.Width == 0 {
 		windowSize.Width = MinWidth
 	}
 	return int(windowSize.Height), int(windowSize.Width)
@@ -86,6 +86,11 @@ func TestConsole_GetHeightWidth(t *testing.T) {
 	gotHeight, gotWidth = c.GetHeightWidth()
 	validateSize(t, gotWidth, gotHeight, MinWidth, MinHeight)
 
+	// narrow width
+	_ = pty.Resize(containerd.WinSize{Width: 40, Height: MinHeight})
+	gotHeight, gotWidth = c.GetHeightWidth()
+	validateSize(t, gotWidth, gotHeight, 40, MinHeight)
+
 	// valid zero and height
 	_ = pty.Resize(containerd.WinSize{Width: 200, Height: 100})
 	gotHeight, gotWidth = c.GetHeightWidth()
@@ -65,6 +65,11 @@ func (DiscardHandler) PostCopy(_ context.Context, _ ocispec.Descriptor) error {
 	return nil
 }
 
+// OnMounted implements CopyHandler.
+func (DiscardHandler) OnMounted(_ context.Context, _ ocispec.Descriptor) error {
+	return nil
+}
+
 // OnNodeDownloading implements PullHandler.
 func (DiscardHandler) OnNodeDownloading(desc ocispec.Descriptor) error {
 	return nil
@@ -90,6 +95,11 @@ func (DiscardHandler) OnNodeSkipped(desc ocispec.Descriptor) error {
 	return nil
 }
 
+// OnFileDeleted implements PullHandler.
+func (DiscardHandler) OnFileDeleted(string) error {
+	return nil
+}
+
 // OnFetching implements referenceFetchHandler.
 func (DiscardHandler) OnFetching(string) error {
 	return nil
@@ -0,0 +1,189 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Status events.
+const (
+	EventLoading     = "loading"
+	EventExists      = "exists"
+	EventUploading   = "uploading"
+	EventUploaded    = "uploaded"
+	EventProcessing  = "processing"
+	EventDownloading = "downloading"
+	EventDownloaded  = "downloaded"
+	EventRestored    = "restored"
+	EventSkipped     = "skipped"
+	EventDeleted     = "deleted"
+)
+
+// Event is a status event written as a JSON line.
+type Event struct {
+	Event     string `json:"event"`
+	MediaType string `json:"mediaType,omitempty"`
+	Digest    string `json:"digest,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Path      string `json:"path,omitempty"`
+}
+
+// EventWriter writes status events as JSON lines concurrent-safely.
+type EventWriter struct {
+	w    io.Writer
+	lock sync.Mutex
+}
+
+// NewEventWriter returns an event writer writing to w.
+func NewEventWriter(w io.Writer) *EventWriter {
+	return &EventWriter{w: w}
+}
+
+// Write writes event.
+func (ew *EventWriter) Write(event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	ew.lock.Lock()
+	defer ew.lock.Unlock()
+	_, err = ew.w.Write(append(b, '\n'))
+	return err
+}
+
+// writeNode writes the event of the node desc.
+func (ew *EventWriter) writeNode(event string, desc ocispec.Descriptor) error {
+	return ew.Write(Event{
+		Event:     event,
+		MediaType: desc.MediaType,
+		Digest:    desc.Digest.String(),
+		Size:      desc.Size,
+		Name:      desc.Annotations[ocispec.AnnotationTitle],
+	})
+}
+
+// eventPushHandler writes the status events of push to an event writer in
+// addition to the wrapped handler.
+type eventPushHandler struct {
+	PushHandler
+	events *EventWriter
+}
+
+// NewEventPushHandler returns a push handler writing the status events to
+// events in addition to h.
+func NewEventPushHandler(h PushHandler, events *EventWriter) PushHandler {
+	return &eventPushHandler{PushHandler: h, events: events}
+}
+
+// OnFileLoading is called before a file is being loaded.
+func (h *eventPushHandler) OnFileLoading(name string) error {
+	if err := h.PushHandler.OnFileLoading(name); err != nil {
+		return err
+	}
+	return h.events.Write(Event{Event: EventLoading, Name: name})
+}
+
+// OnCopySkipped is called when an object already exists.
+func (h *eventPushHandler) OnCopySkipped(ctx context.Context, desc ocispec.Descriptor) error {
+	if err := h.PushHandler.OnCopySkipped(ctx, desc); err != nil {
+		return err
+	}
+	return h.events.writeNode(EventExists, desc)
+}
+
+// PreCopy is called before an object is uploaded.
+func (h *eventPushHandler) PreCopy(ctx context.Context, desc ocispec.Descriptor) error {
+	if err := h.PushHandler.PreCopy(ctx, desc); err != nil {
+		return err
+	}
+	return h.events.writeNode(EventUploading, desc)
+}
+
+// PostCopy is called after an object is uploaded.
+func (h *eventPushHandler) PostCopy(ctx context.Context, desc ocispec.Descriptor) error {
+	if err := h.PushHandler.PostCopy(ctx, desc); err != nil {
+		return err
+	}
+	return h.events.writeNode(EventUploaded, desc)
+}
+
+// eventPullHandler writes the status events of pull to an event writer in
+// addition to the wrapped handler.
+type eventPullHandler struct {
+	PullHandler
+	events *EventWriter
+}
+
+// NewEventPullHandler returns a pull handler writing the status events to
+// events in addition to h.
+func NewEventPullHandler(h PullHandler, events *EventWriter) PullHandler {
+	return &eventPullHandler{PullHandler: h, events: events}
+}
+
+// OnNodeProcessing is called when processing a manifest.
+func (h *eventPullHandler) OnNodeProcessing(desc ocispec.Descriptor) error {
+	if err := h.PullHandler.OnNodeProcessing(desc); err != nil {
+		return err
+	}
+	return h.events.writeNode(EventProcessing, desc)
+}
+
+// OnNodeDownloading is called before downloading a node.
+func (h *eventPullHandler) OnNodeDownloading(desc ocispec.Descriptor) error {
+	if err := h.PullHandler.OnNodeDownloading(desc); err != nil {
+		return err
+	}
+	return h.events.writeNode(EventDownloading, desc)
+}
+
+// OnNodeDownloaded is called after a node is downloaded.
+func (h *eventPullHandler) OnNodeDownloaded(desc ocispec.Descriptor) error {
+	if err := h.PullHandler.OnNodeDownloaded(desc); err != nil {
+		return err
+	}
+	return h.events.writeNode(EventDownloaded, desc)
+}
+
+// OnNodeRestored is called after a deduplicated node is restored.
+func (h *eventPullHandler) OnNodeRestored(desc ocispec.Descriptor) error {
+	if err := h.PullHandler.OnNodeRestored(desc); err != nil {
+		return err
+	}
+	return h.events.writeNode(EventRestored, desc)
+}
+
+// OnNodeSkipped is called when a node is skipped.
+func (h *eventPullHandler) OnNodeSkipped(desc ocispec.Descriptor) error {
+	if err := h.PullHandler.OnNodeSkipped(desc); err != nil {
+		return err
+	}
+	return h.events.writeNode(EventSkipped, desc)
+}
+
+// OnFileDeleted is called after an extraneous local file is deleted.
+func (h *eventPullHandler) OnFileDeleted(path string) error {
+	if err := h.PullHandler.OnFileDeleted(path); err != nil {
+		return err
+	}
+	return h.events.Write(Event{Event: EventDeleted, Path: path})
+}
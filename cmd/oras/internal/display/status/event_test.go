@@ -0,0 +1,101 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// decodeEvents decodes the JSON lines of events.
+func decodeEvents(t *testing.T, b []byte) []Event {
+	t.Helper()
+	var events []Event
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		var event Event
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("invalid event %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestEventPushHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewEventPushHandler(NewDiscardHandler(), NewEventWriter(&buf))
+	desc := ocispec.Descriptor{
+		MediaType:   "application/vnd.test",
+		Digest:      digest.FromString("test"),
+		Size:        4,
+		Annotations: map[string]string{ocispec.AnnotationTitle: "test.txt"},
+	}
+	ctx := context.Background()
+	if err := h.OnFileLoading("test.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.PreCopy(ctx, desc); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.PostCopy(ctx, desc); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.OnCopySkipped(ctx, desc); err != nil {
+		t.Fatal(err)
+	}
+
+	node := func(event string) Event {
+		return Event{Event: event, MediaType: desc.MediaType, Digest: desc.Digest.String(), Size: desc.Size, Name: "test.txt"}
+	}
+	want := []Event{{Event: EventLoading, Name: "test.txt"}, node(EventUploading), node(EventUploaded), node(EventExists)}
+	if got := decodeEvents(t, buf.Bytes()); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestEventPullHandler(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewEventPullHandler(NewDiscardHandler(), NewEventWriter(&buf))
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("manifest"),
+		Size:      8,
+	}
+	for _, on := range []func(ocispec.Descriptor) error{h.OnNodeProcessing, h.OnNodeDownloading, h.OnNodeDownloaded, h.OnNodeRestored, h.OnNodeSkipped} {
+		if err := on(desc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := h.OnFileDeleted("old.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	var want []Event
+	for _, event := range []string{EventProcessing, EventDownloading, EventDownloaded, EventRestored, EventSkipped} {
+		want = append(want, Event{Event: event, MediaType: desc.MediaType, Digest: desc.Digest.String(), Size: desc.Size})
+	}
+	want = append(want, Event{Event: EventDeleted, Path: "old.txt"})
+	if got := decodeEvents(t, buf.Bytes()); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
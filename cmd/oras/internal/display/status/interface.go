@@ -54,6 +54,8 @@ type PullHandler interface {
 	OnNodeRestored(desc ocispec.Descriptor) error
 	// OnNodeSkipped is called when a node is skipped.
 	OnNodeSkipped(desc ocispec.Descriptor) error
+	// OnFileDeleted is called after an extraneous local file is deleted.
+	OnFileDeleted(path string) error
 }
 
 // CopyHandler handles status output for cp command.
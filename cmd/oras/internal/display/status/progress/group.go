@@ -0,0 +1,220 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/status/console"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/internal/progress"
+)
+
+// GroupManager renders the statuses tracked in multiple groups on one
+// console. Each group is headed by a summary of its statuses, and all groups
+// are followed by an overall summary.
+type GroupManager struct {
+	lock         sync.RWMutex // locks groups, their status and the console
+	console      console.Console
+	groups       []*group
+	rows         int
+	renderDone   chan struct{}
+	renderClosed chan struct{}
+	prompts      map[progress.State]string
+}
+
+// NewGroupManager initializes a new grouped progress manager.
+func NewGroupManager(tty *os.File, prompts map[progress.State]string) (*GroupManager, error) {
+	c, err := console.NewConsole(tty)
+	if err != nil {
+		return nil, err
+	}
+	return newGroupManager(c, prompts), nil
+}
+
+func newGroupManager(c console.Console, prompts map[progress.State]string) *GroupManager {
+	m := &GroupManager{
+		console:      c,
+		renderDone:   make(chan struct{}),
+		renderClosed: make(chan struct{}),
+		prompts:      prompts,
+	}
+	startRendering(m.console, m.render, m.renderDone, m.renderClosed)
+	return m
+}
+
+// Group returns a manager tracking statuses in a new group named name.
+// Closing the returned manager waits for the updates of the group only.
+func (m *GroupManager) Group(name string) progress.Manager {
+	g := &group{
+		name:    name,
+		manager: m,
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.groups = append(m.groups, g)
+	return g
+}
+
+func (m *GroupManager) render() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	height, width := m.console.GetHeightWidth()
+	var lines []string
+	var overall summary
+	for _, g := range m.groups {
+		var groupSummary summary
+		for _, s := range g.status {
+			groupSummary.add(s)
+		}
+		overall.merge(groupSummary)
+		lines = append(lines, groupSummary.render(g.name, width))
+		for _, s := range g.status {
+			view := s.Render(width)
+			lines = append(lines, view[0], view[1])
+		}
+	}
+	lines = append(lines, overall.render("Total", width))
+
+	// render with culling: only the latter lines are rendered.
+	if n := len(lines) - height; n > 0 {
+		lines = lines[n:]
+	}
+	for ; m.rows < len(lines); m.rows++ {
+		m.console.NewRow()
+	}
+	for i, line := range lines {
+		m.console.OutputTo(uint(len(lines)-i), line)
+	}
+}
+
+// Close waits for the updates of all groups and stops rendering.
+func (m *GroupManager) Close() error {
+	if m.closed() {
+		return errManagerStopped
+	}
+	m.lock.RLock()
+	groups := m.groups
+	m.lock.RUnlock()
+	for _, g := range groups {
+		g.updating.Wait()
+	}
+	close(m.renderDone)
+	<-m.renderClosed
+	return nil
+}
+
+func (m *GroupManager) closed() bool {
+	select {
+	case <-m.renderClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+// group is a group of statuses in a GroupManager.
+type group struct {
+	name     string
+	manager  *GroupManager
+	status   []*status
+	updating sync.WaitGroup
+}
+
+// Track appends a new status to the group.
+func (g *group) Track(desc ocispec.Descriptor) (progress.Tracker, error) {
+	m := g.manager
+	if m.closed() {
+		return nil, errManagerStopped
+	}
+	s := newStatus(desc)
+	m.lock.Lock()
+	g.status = append(g.status, s)
+	m.lock.Unlock()
+	return newTracker(s, &g.updating, m.prompts), nil
+}
+
+// Close waits for the updates of the group.
+func (g *group) Close() error {
+	g.updating.Wait()
+	return nil
+}
+
+// summary is the aggregated progress of statuses.
+type summary struct {
+	done   int
+	count  int
+	offset int64
+	total  int64
+}
+
+// add adds the progress of s to the summary.
+func (sum *summary) add(s *status) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	sum.count++
+	sum.total += s.descriptor.Size
+	switch {
+	case s.done:
+		sum.done++
+		sum.offset += s.descriptor.Size
+	case s.offset > 0:
+		sum.offset += s.offset
+	}
+}
+
+// merge adds another summary to the summary.
+func (sum *summary) merge(other summary) {
+	sum.done += other.done
+	sum.count += other.count
+	sum.offset += other.offset
+	sum.total += other.total
+}
+
+// render returns a human-readable TTY line of the summary.
+// Format:
+//
+//	[name-----------------------------][margin][done/count size_per_size percent]
+//
+// The sizes are hidden on consoles too narrow to fit the name.
+func (sum *summary) render(name string, width int) string {
+	percent := 1.0
+	if sum.total > 0 {
+		percent = float64(sum.offset) / float64(sum.total)
+	}
+	right := fmt.Sprintf(" %d/%d %s/%s %6.2f%%", sum.done, sum.count, humanize.ToBytes(sum.offset), humanize.ToBytes(sum.total), percent*100)
+	if lenName := utf8.RuneCountInString(name); width-utf8.RuneCountInString(right) < min(minLabelLength, lenName) {
+		// keep the name readable on narrow consoles
+		right = fmt.Sprintf(" %d/%d %6.2f%%", sum.done, sum.count, percent*100)
+		if width-utf8.RuneCountInString(right) < 1 {
+			right = ""
+		}
+	}
+	lenMargin := width - utf8.RuneCountInString(name) - utf8.RuneCountInString(right)
+	if lenMargin < 0 {
+		// hide partial name with one space left
+		name = truncate(name, max(utf8.RuneCountInString(name)+lenMargin, 1))
+		lenMargin = 0
+	}
+	return name + strings.Repeat(" ", lenMargin) + right
+}
@@ -0,0 +1,128 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/internal/progress"
+)
+
+func Test_GroupManager(t *testing.T) {
+	desc := ocispec.Descriptor{
+		MediaType: "application/vnd.docker.image.rootfs.diff.tar.gzip",
+		Size:      1234567890,
+		Digest:    "sha256:c775e7b757ede630cd0aa1113bd102661ab38829ca52a6422ab782862f268646",
+		Annotations: map[string]string{
+			"org.opencontainers.image.title": "hello.bin",
+		},
+	}
+
+	// simulate a console run
+	c := newMockConsole(80, 24)
+	m := newGroupManager(c, map[progress.State]string{
+		progress.StateExists: "Exists",
+	})
+	hello := m.Group("localhost:5000/hello")
+	world := m.Group("localhost:5000/world")
+	tracker, err := hello.Track(desc)
+	if err != nil {
+		t.Fatalf("group.Track() error = %v, wantErr nil", err)
+	}
+	if err = tracker.Update(progress.Status{
+		State:  progress.StateExists,
+		Offset: -1,
+	}); err != nil {
+		t.Errorf("tracker.Update() error = %v, wantErr nil", err)
+	}
+	if err := tracker.Close(); err != nil {
+		t.Errorf("tracker.Close() error = %v, wantErr nil", err)
+	}
+	tracker, err = world.Track(ocispec.Descriptor{
+		MediaType: "application/vnd.oci.image.manifest.v1+json",
+		Size:      1234567890,
+		Digest:    "sha256:c775e7b757ede630cd0aa1113bd102661ab38829ca52a6422ab782862f268646",
+	})
+	if err != nil {
+		t.Fatalf("group.Track() error = %v, wantErr nil", err)
+	}
+	if err := tracker.Fail(errors.New("failed")); err != nil {
+		t.Errorf("tracker.Fail() error = %v, wantErr nil", err)
+	}
+	if err := tracker.Close(); err != nil {
+		t.Errorf("tracker.Close() error = %v, wantErr nil", err)
+	}
+	if err := hello.Close(); err != nil {
+		t.Errorf("group.Close() error = %v, wantErr nil", err)
+	}
+	if err := m.Close(); err != nil {
+		t.Errorf("GroupManager.Close() error = %v, wantErr nil", err)
+	}
+	if _, err := hello.Track(desc); err != errManagerStopped {
+		t.Errorf("group.Track() error = %v, wantErr %v", err, errManagerStopped)
+	}
+
+	// verify the console output
+	want := []string{
+		"localhost:5000/hello                                 1/1 1.15 GB/1.15 GB 100.00%",
+		"✓ Exists hello.bin                                   1.15/1.15 GB 100.00%     0s",
+		"  └─ sha256:c775e7b757ede630cd0aa1113bd102661ab38829ca52a6422ab782862f268646    ",
+		"localhost:5000/world                                    0/1 0  B/1.15 GB   0.00%",
+	}
+	if len(c.view) != len(want)+3 {
+		t.Fatalf("console view length = %d, want %d", len(c.view), len(want)+3)
+	}
+	escRegexp := regexp.MustCompile("\x1b\\[[0-9]+m")
+	equal := func(got, want string) bool {
+		return escRegexp.ReplaceAllString(got, "") == want
+	}
+	for i, v := range want {
+		if !equal(c.view[i], v) {
+			t.Errorf("console view[%d] = %q, want %q", i, c.view[i], v)
+		}
+	}
+	if want := "Total                                                 1/2 1.15 GB/2.3 GB  50.00%"; !equal(c.view[len(c.view)-1], want) {
+		t.Errorf("console view[%d] = %q, want %q", len(c.view)-1, c.view[len(c.view)-1], want)
+	}
+}
+
+func Test_summary_render(t *testing.T) {
+	sum := summary{
+		done:   1,
+		count:  2,
+		offset: 1234567890,
+		total:  2469135780,
+	}
+	tests := []struct {
+		name  string
+		width int
+		want  string
+	}{
+		{"default console", 60, "localhost:5000/hello              1/2 1.15 GB/2.3 GB  50.00%"},
+		{"narrow console", 30, "localhost:5000/he. 1/2  50.00%"},
+		{"very narrow console", 10, "localhost."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sum.render("localhost:5000/hello", tt.width); got != tt.want {
+				t.Errorf("summary.render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -66,19 +66,31 @@ func newManager(c console.Console, prompts map[progress.State]string) progress.M
 }
 
 func (m *manager) start() {
-	m.console.Save()
+	startRendering(m.console, m.render, m.renderDone, m.renderClosed)
+}
+
+// startRendering renders periodically until done is closed, after which it
+// renders for the last time and closes closed. It also renders immediately
+// once the console is resized so that the statuses are laid out for the new
+// width.
+func startRendering(c console.Console, render func(), done, closed chan struct{}) {
+	c.Save()
 	renderTicker := time.NewTicker(bufFlushDuration)
+	resized, stopResize := notifyResize()
 	go func() {
-		defer m.console.Restore()
+		defer c.Restore()
 		defer renderTicker.Stop()
+		defer stopResize()
 		for {
 			select {
-			case <-m.renderDone:
-				m.render()
-				close(m.renderClosed)
+			case <-done:
+				render()
+				close(closed)
 				return
 			case <-renderTicker.C:
-				m.render()
+				render()
+			case <-resized:
+				render()
 			}
 		}
 	}()
@@ -123,15 +135,21 @@ func (m *manager) Track(desc ocispec.Descriptor) (progress.Tracker, error) {
 }
 
 func (m *manager) newTracker(s *status) progress.Tracker {
+	return newTracker(s, &m.updating, m.prompts)
+}
+
+// newTracker returns a tracker applying updates to s, with the updating
+// routine counted by updating.
+func newTracker(s *status, updating *sync.WaitGroup, prompts map[progress.State]string) progress.Tracker {
 	ch := make(chan statusUpdate, bufferSize)
-	m.updating.Go(func() {
+	updating.Go(func() {
 		for update := range ch {
 			update(s)
 		}
 	})
 	return &messenger{
 		update:  ch,
-		prompts: m.prompts,
+		prompts: prompts,
 	}
 }
 
@@ -31,6 +31,12 @@ const (
 	barLength    = 20
 	speedLength  = 7    // speed_size(4) + space(1) + speed_unit(2)
 	zeroDuration = "0s" // default zero value of time.Duration.String()
+	// minLabelLength is the minimal length of the status text and the object
+	// name kept visible before hiding other parts of a status on narrow
+	// consoles.
+	minLabelLength = 12
+	// lenDigestPrefix is the length of the prefix "  └─ " of the digest line.
+	lenDigestPrefix = 5
 )
 
 var (
@@ -73,6 +79,10 @@ func newStatus(desc ocispec.Descriptor) *status {
 //	[left--------------------------------------------][margin][right---------------------------------]
 //	mark(1) bar(22) speed(8) action(<=11) name(<=126)        size_per_size(<=13) percent(8) time(>=6)
 //	 └─ digest(72)
+//
+// On consoles too narrow to fit the label, the bar and the speed are hidden
+// first, followed by the size and the time. The label and the digest are
+// truncated to fit the width.
 func (s *status) Render(width int) [2]string {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -103,48 +113,74 @@ func (s *status) Render(width int) [2]string {
 		offset = fmt.Sprintf("%.2f", humanize.RoundTo(s.total.Size*percent))
 	}
 
-	// render the left side of the primary line
-	var left string
-	lenLeft := 0 // manually calculate the string length due to the color escape sequence
+	// render the mark and the progress bar of the primary line
+	var mark, bar string
+	lenBar := 0 // manually calculate the string length due to the color escape sequence
 	if s.done {
-		left = fmt.Sprintf("%s %s %s", doneMarkColor.Apply("✓"), s.text, name)
+		mark = doneMarkColor.Apply("✓")
 	} else {
-		var mark string
 		if s.err == nil {
 			mark = spinnerColor.Apply(string(s.mark.symbol()))
 		} else {
 			mark = failureColor.Apply("✗")
 		}
-		lenBar := int(percent * barLength)
+		lenFilled := int(percent * barLength)
 		speed := s.calculateSpeed()
-		left = fmt.Sprintf("%s [%s%s](%*s/s) %s %s", mark,
-			progressColor.Apply(strings.Repeat(" ", lenBar)), strings.Repeat(".", barLength-lenBar),
-			speedLength, speed, s.text, name)
-		// bar + wrapper(2) + space(1) + speed + "/s"(2) + wrapper(2) = len(bar) + len(speed) + 7
-		lenLeft = barLength + speedLength + 7
+		bar = fmt.Sprintf(" [%s%s](%*s/s)", progressColor.Apply(strings.Repeat(" ", lenFilled)), strings.Repeat(".", barLength-lenFilled), speedLength, speed)
+		// space(1) + wrapper(2) + bar + wrapper(2) + speed + "/s)"(3) = len(bar) + len(speed) + 7
+		lenBar = barLength + speedLength + 7
 	}
-	// mark(1) + space(1) + prompt + space(1) + name = len(prompt) + len(name) + 3
-	lenLeft += utf8.RuneCountInString(s.text) + utf8.RuneCountInString(name) + 3
+	label := s.text + " " + name
+	lenLabel := utf8.RuneCountInString(label)
 
 	// render the right side of the primary line
 	right := fmt.Sprintf(" %s/%s %6.2f%% %6s", offset, s.total, percent*100, s.durationString())
 	lenRight := utf8.RuneCountInString(right)
 
+	// degrade gracefully on narrow consoles: hide the progress bar, then the
+	// size and the duration, to keep the label readable
+	// mark(1) + space(1) = 2
+	available := func() int { return width - lenBar - lenRight - 2 }
+	if lenBar > 0 && available() < min(minLabelLength, lenLabel) {
+		bar, lenBar = "", 0
+	}
+	if available() < min(minLabelLength, lenLabel) {
+		right = fmt.Sprintf(" %6.2f%%", percent*100)
+		lenRight = utf8.RuneCountInString(right)
+	}
+	if available() < 1 {
+		right, lenRight = "", 0
+	}
+
 	// render view
-	lenMargin := width - lenLeft - lenRight
+	lenMargin := available() - lenLabel
 	if lenMargin < 0 {
-		// hide partial name with one space left
-		left = left[:len(left)+lenMargin-1] + "."
+		// hide partial label with one space left
+		label = truncate(label, lenLabel+lenMargin)
 		lenMargin = 0
 	}
+	digest := truncate(s.descriptor.Digest.String(), width-lenDigestPrefix)
 	var padding string
-	if paddingLen := width - len(s.descriptor.Digest) - 5; paddingLen > 0 {
+	if paddingLen := width - utf8.RuneCountInString(digest) - lenDigestPrefix; paddingLen > 0 {
 		padding = strings.Repeat(" ", paddingLen)
 	}
 	return [2]string{
-		fmt.Sprintf("%s%s%s", left, strings.Repeat(" ", lenMargin), right),
-		fmt.Sprintf("  └─ %s%s", s.descriptor.Digest, padding),
+		fmt.Sprintf("%s%s %s%s%s", mark, bar, label, strings.Repeat(" ", lenMargin), right),
+		fmt.Sprintf("  └─ %s%s", digest, padding),
+	}
+}
+
+// truncate returns s with at most width runes. The last rune of a truncated
+// string is replaced with "." to indicate the truncation.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
 	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "."
 }
 
 // calculateSpeed calculates the speed of the progress and update last status.
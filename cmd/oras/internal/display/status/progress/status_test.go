@@ -243,6 +243,62 @@ func Test_status_Render(t *testing.T) {
 				"  └─ sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855    ",
 			},
 		},
+		{
+			name: "operation in progress on a narrow console",
+			status: func() *status {
+				return &status{
+					text:       "Test",
+					startTime:  time.Now().Add(-time.Second * 100),
+					descriptor: desc,
+					offset:     123456789,
+					total:      humanize.ToBytes(desc.Size),
+					speed:      newSpeedWindow(10),
+				}
+			},
+			width: 50,
+			want: [2]string{
+				"⠋ Test hello.bin       0.12/1.15 GB  10.00%  1m40s",
+				"  └─ sha256:c775e7b757ede630cd0aa1113bd102661ab38.",
+			},
+		},
+		{
+			name: "operation in progress on a very narrow console",
+			status: func() *status {
+				return &status{
+					text:       "Test",
+					startTime:  time.Now().Add(-time.Second * 100),
+					descriptor: desc,
+					offset:     123456789,
+					total:      humanize.ToBytes(desc.Size),
+					speed:      newSpeedWindow(10),
+				}
+			},
+			width: 24,
+			want: [2]string{
+				"⠋ Test hello.bin  10.00%",
+				"  └─ sha256:c775e7b757e.",
+			},
+		},
+		{
+			name: "long status text succeeded on a very narrow console",
+			status: func() *status {
+				return &status{
+					done:       true,
+					text:       "Long Long Long Long Long Long Long Long test",
+					startTime:  time.Now().Add(-time.Second * 100),
+					endTime:    time.Now(),
+					descriptor: desc,
+					offset:     1234567890,
+					total:      humanize.ToBytes(desc.Size),
+					speed:      newSpeedWindow(10),
+				}
+			},
+			width: 24,
+			want: [2]string{
+				"✓ Long Long Lon. 100.00%",
+				"  └─ sha256:c775e7b757e.",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -424,3 +480,25 @@ func Test_updateStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_truncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"fit", "hello.bin", 9, "hello.bin"},
+		{"truncated", "hello.bin", 5, "hell."},
+		{"multi-byte runes", "你好世界.bin", 4, "你好世."},
+		{"zero width", "hello.bin", 0, ""},
+		{"negative width", "hello.bin", -1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncate(tt.s, tt.width); got != tt.want {
+				t.Errorf("truncate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
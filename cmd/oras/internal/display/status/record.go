@@ -0,0 +1,97 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+// RecordHandler records the status of the transferred descriptors for the
+// metadata output, and forwards the events to the wrapped status handler.
+type RecordHandler struct {
+	handler  BackupHandler
+	recorder *model.Transferred
+}
+
+// NewRecordCopyHandler returns a copy handler recording the transferred
+// descriptors to recorder.
+func NewRecordCopyHandler(handler CopyHandler, recorder *model.Transferred) CopyHandler {
+	return &RecordHandler{
+		handler:  handler,
+		recorder: recorder,
+	}
+}
+
+// NewRecordBackupHandler returns a backup handler recording the transferred
+// descriptors to recorder.
+func NewRecordBackupHandler(handler BackupHandler, recorder *model.Transferred) BackupHandler {
+	return &RecordHandler{
+		handler:  handler,
+		recorder: recorder,
+	}
+}
+
+// NewRecordRestoreHandler returns a restore handler recording the
+// transferred descriptors to recorder.
+func NewRecordRestoreHandler(handler RestoreHandler, recorder *model.Transferred) RestoreHandler {
+	return &RecordHandler{
+		handler:  handler,
+		recorder: recorder,
+	}
+}
+
+// StartTracking implements CopyHandler.
+func (rh *RecordHandler) StartTracking(gt oras.GraphTarget) (oras.GraphTarget, error) {
+	return rh.handler.StartTracking(gt)
+}
+
+// StopTracking implements CopyHandler.
+func (rh *RecordHandler) StopTracking() error {
+	return rh.handler.StopTracking()
+}
+
+// OnCopySkipped implements CopyHandler.
+func (rh *RecordHandler) OnCopySkipped(ctx context.Context, desc ocispec.Descriptor) error {
+	rh.recorder.Skipped(desc)
+	return rh.handler.OnCopySkipped(ctx, desc)
+}
+
+// PreCopy implements CopyHandler.
+func (rh *RecordHandler) PreCopy(ctx context.Context, desc ocispec.Descriptor) error {
+	rh.recorder.Start(desc)
+	return rh.handler.PreCopy(ctx, desc)
+}
+
+// PostCopy implements CopyHandler.
+func (rh *RecordHandler) PostCopy(ctx context.Context, desc ocispec.Descriptor) error {
+	rh.recorder.Copied(desc)
+	return rh.handler.PostCopy(ctx, desc)
+}
+
+// OnMounted implements CopyHandler.
+func (rh *RecordHandler) OnMounted(ctx context.Context, desc ocispec.Descriptor) error {
+	rh.recorder.Mounted(desc)
+	if mh, ok := rh.handler.(interface {
+		OnMounted(context.Context, ocispec.Descriptor) error
+	}); ok {
+		return mh.OnMounted(ctx, desc)
+	}
+	return nil
+}
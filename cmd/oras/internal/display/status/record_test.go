@@ -0,0 +1,82 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/cmd/oras/internal/display/metadata/model"
+)
+
+func TestRecordHandler(t *testing.T) {
+	blob := func(content string) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			MediaType: "application/octet-stream",
+			Digest:    digest.FromString(content),
+			Size:      int64(len(content)),
+		}
+	}
+	copied, skipped, mounted, failed := blob("copied"), blob("skipped"), blob("mounted"), blob("failed")
+
+	recorder := &model.Transferred{}
+	handler := NewRecordCopyHandler(NewDiscardHandler(), recorder)
+	for _, step := range []func() error{
+		func() error { return handler.PreCopy(ctx, copied) },
+		func() error { return handler.PostCopy(ctx, copied) },
+		func() error { return handler.OnCopySkipped(ctx, skipped) },
+		func() error { return handler.OnMounted(ctx, mounted) },
+		func() error { return handler.PreCopy(ctx, failed) },
+		// copied content skipped later is still reported as copied
+		func() error { return handler.OnCopySkipped(ctx, copied) },
+	} {
+		if err := step(); err != nil {
+			t.Fatalf("RecordHandler error = %v", err)
+		}
+	}
+
+	report := model.NewTransferReport(recorder.Transfers())
+	want := []struct {
+		desc   ocispec.Descriptor
+		status string
+		bytes  int64
+	}{
+		{copied, model.TransferStatusCopied, copied.Size},
+		{skipped, model.TransferStatusSkipped, 0},
+		{mounted, model.TransferStatusMounted, 0},
+		{failed, model.TransferStatusFailed, 0},
+	}
+	if len(report.Descriptors) != len(want) {
+		t.Fatalf("got %d transfers, want %d", len(report.Descriptors), len(want))
+	}
+	for i, w := range want {
+		got := report.Descriptors[i]
+		if got.Digest != w.desc.Digest || got.Status != w.status || got.Bytes != w.bytes {
+			t.Errorf("transfer %d = {%s %s %d}, want {%s %s %d}", i, got.Digest, got.Status, got.Bytes, w.desc.Digest, w.status, w.bytes)
+		}
+	}
+	if got := report.Descriptors[0]; got.StartedAt == nil || got.CompletedAt == nil {
+		t.Errorf("copied transfer has no timing: %+v", got)
+	}
+	if got := report.Descriptors[3]; got.StartedAt == nil || got.CompletedAt != nil {
+		t.Errorf("failed transfer timing = %v, %v, want started but not completed", got.StartedAt, got.CompletedAt)
+	}
+	wantSummary := model.TransferSummary{Copied: 1, Skipped: 1, Mounted: 1, Failed: 1, Bytes: copied.Size}
+	if report.Summary != wantSummary {
+		t.Errorf("summary = %+v, want %+v", report.Summary, wantSummary)
+	}
+}
@@ -128,6 +128,11 @@ func (ph *TextPullHandler) OnNodeSkipped(desc ocispec.Descriptor) error {
 	return ph.printer.PrintStatus(desc, PullPromptSkipped)
 }
 
+// OnFileDeleted implements PullHandler.
+func (ph *TextPullHandler) OnFileDeleted(path string) error {
+	return ph.printer.Println(PullPromptDeleted, path)
+}
+
 // NewTextPullHandler returns a new handler for pull command.
 func NewTextPullHandler(printer *output.Printer) PullHandler {
 	return &TextPullHandler{
@@ -151,6 +151,15 @@ func TestTextPullHandler_OnNodeSkipped(t *testing.T) {
 	validatePrinted(t, "Skipped     0b442c23c1dd oci-image")
 }
 
+func TestTextPullHandler_OnFileDeleted(t *testing.T) {
+	builder.Reset()
+	ph := NewTextPullHandler(printer)
+	if ph.OnFileDeleted("old.txt") != nil {
+		t.Error("OnFileDeleted() should not return an error")
+	}
+	validatePrinted(t, "Deleted     old.txt")
+}
+
 func TestTextPushHandler_OnCopySkipped(t *testing.T) {
 	builder.Reset()
 	ph := NewTextPushHandler(printer, mockFetcher.Fetcher)
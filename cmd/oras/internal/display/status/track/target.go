@@ -51,6 +51,11 @@ func NewTarget(t oras.GraphTarget, prompts map[progress.State]string, tty *os.Fi
 	if err != nil {
 		return nil, err
 	}
+	return NewTargetWithManager(t, manager), nil
+}
+
+// NewTargetWithManager creates a new tracked Target reporting to manager.
+func NewTargetWithManager(t oras.GraphTarget, manager progress.Manager) GraphTarget {
 	gt := &graphTarget{
 		GraphTarget: t,
 		manager:     manager,
@@ -59,9 +64,9 @@ func NewTarget(t oras.GraphTarget, prompts map[progress.State]string, tty *os.Fi
 	if _, ok := t.(registry.ReferencePusher); ok {
 		return &referenceGraphTarget{
 			graphTarget: gt,
-		}, nil
+		}
 	}
-	return gt, nil
+	return gt
 }
 
 // Mount mounts a blob from a specified repository. This method is invoked only
@@ -17,6 +17,7 @@ package status
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"sync"
 
@@ -25,6 +26,7 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
+	sprogress "oras.land/oras/cmd/oras/internal/display/status/progress"
 	"oras.land/oras/cmd/oras/internal/display/status/track"
 	"oras.land/oras/internal/progress"
 )
@@ -104,9 +106,19 @@ func NewTTYAttachHandler(tty *os.File, fetcher content.Fetcher) AttachHandler {
 	return NewTTYPushHandler(tty, fetcher)
 }
 
+// pullPrompts are the prompts of pull progress.
+var pullPrompts = map[progress.State]string{
+	progress.StateInitialized:  PullPromptDownloading,
+	progress.StateTransmitting: PullPromptDownloading,
+	progress.StateTransmitted:  PullPromptPulled,
+	progress.StateSkipped:      PullPromptSkipped,
+	progress.StateRestored:     PullPromptRestored,
+}
+
 // TTYPullHandler handles TTY status output for pull events.
 type TTYPullHandler struct {
 	tty     *os.File
+	group   progress.Manager
 	tracked track.GraphTarget
 }
 
@@ -142,16 +154,19 @@ func (ph *TTYPullHandler) OnNodeSkipped(desc ocispec.Descriptor) error {
 	return ph.tracked.Report(desc, progress.StateSkipped)
 }
 
+// OnFileDeleted implements PullHandler.
+func (ph *TTYPullHandler) OnFileDeleted(path string) error {
+	_, err := fmt.Fprintln(ph.tty, PullPromptDeleted, path)
+	return err
+}
+
 // TrackTarget returns a tracked target.
 func (ph *TTYPullHandler) TrackTarget(gt oras.GraphTarget) (oras.GraphTarget, StopTrackTargetFunc, error) {
-	prompt := map[progress.State]string{
-		progress.StateInitialized:  PullPromptDownloading,
-		progress.StateTransmitting: PullPromptDownloading,
-		progress.StateTransmitted:  PullPromptPulled,
-		progress.StateSkipped:      PullPromptSkipped,
-		progress.StateRestored:     PullPromptRestored,
+	if ph.group != nil {
+		ph.tracked = track.NewTargetWithManager(gt, ph.group)
+		return ph.tracked, ph.tracked.Close, nil
 	}
-	tracked, err := track.NewTarget(gt, prompt, ph.tty)
+	tracked, err := track.NewTarget(gt, pullPrompts, ph.tty)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -159,6 +174,34 @@ func (ph *TTYPullHandler) TrackTarget(gt oras.GraphTarget) (oras.GraphTarget, St
 	return tracked, tracked.Close, nil
 }
 
+// PullGroup renders the tty status output of concurrent pulls, grouped by
+// artifact.
+type PullGroup struct {
+	manager *sprogress.GroupManager
+}
+
+// NewPullGroup returns a new group rendering pull status output to tty.
+func NewPullGroup(tty *os.File) (*PullGroup, error) {
+	manager, err := sprogress.NewGroupManager(tty, pullPrompts)
+	if err != nil {
+		return nil, err
+	}
+	return &PullGroup{manager: manager}, nil
+}
+
+// NewHandler returns a new handler for the pull of the artifact referenced by
+// reference, rendering its status output in a group of its own.
+func (g *PullGroup) NewHandler(reference string) PullHandler {
+	return &TTYPullHandler{
+		group: g.manager.Group(reference),
+	}
+}
+
+// Close stops rendering the status output.
+func (g *PullGroup) Close() error {
+	return g.manager.Close()
+}
+
 // TTYCopyHandler handles tty status output for copy events.
 type TTYCopyHandler struct {
 	tty       *os.File
@@ -228,9 +271,19 @@ func (ch *TTYCopyHandler) OnMounted(_ context.Context, desc ocispec.Descriptor)
 	return ch.tracked.Report(desc, progress.StateMounted)
 }
 
+// backupPrompts are the prompts of backup progress.
+var backupPrompts = map[progress.State]string{
+	progress.StateInitialized:  backupPromptPulling,
+	progress.StateTransmitting: backupPromptPulling,
+	progress.StateTransmitted:  backupPromptPulled,
+	progress.StateExists:       backupPromptExists,
+	progress.StateSkipped:      backupPromptSkipped,
+}
+
 // TTYBackupHandler handles tty status output for backup events.
 type TTYBackupHandler struct {
 	tty       *os.File
+	group     progress.Manager
 	committed *sync.Map
 	tracked   track.GraphTarget
 	fetcher   content.Fetcher
@@ -247,16 +300,12 @@ func NewTTYBackupHandler(tty *os.File, fetcher content.Fetcher) BackupHandler {
 
 // StartTracking returns a tracked target from a graph target.
 func (bh *TTYBackupHandler) StartTracking(gt oras.GraphTarget) (oras.GraphTarget, error) {
-	prompts := map[progress.State]string{
-		progress.StateInitialized:  backupPromptPulling,
-		progress.StateTransmitting: backupPromptPulling,
-		progress.StateTransmitted:  backupPromptPulled,
-		progress.StateExists:       backupPromptExists,
-		progress.StateSkipped:      backupPromptSkipped,
+	if bh.group != nil {
+		bh.tracked = track.NewTargetWithManager(gt, bh.group)
+		return bh.tracked, nil
 	}
-
 	var err error
-	bh.tracked, err = track.NewTarget(gt, prompts, bh.tty)
+	bh.tracked, err = track.NewTarget(gt, backupPrompts, bh.tty)
 	if err != nil {
 		return nil, err
 	}
@@ -294,6 +343,36 @@ func (bh *TTYBackupHandler) PostCopy(ctx context.Context, desc ocispec.Descripto
 	return nil
 }
 
+// BackupGroup renders the tty status output of concurrent backups, grouped by
+// repository.
+type BackupGroup struct {
+	manager *sprogress.GroupManager
+}
+
+// NewBackupGroup returns a new group rendering backup status output to tty.
+func NewBackupGroup(tty *os.File) (*BackupGroup, error) {
+	manager, err := sprogress.NewGroupManager(tty, backupPrompts)
+	if err != nil {
+		return nil, err
+	}
+	return &BackupGroup{manager: manager}, nil
+}
+
+// NewHandler returns a new handler for the backup of repo, rendering its
+// status output in a group of its own.
+func (g *BackupGroup) NewHandler(repo string, fetcher content.Fetcher) BackupHandler {
+	return &TTYBackupHandler{
+		group:     g.manager.Group(repo),
+		committed: &sync.Map{},
+		fetcher:   fetcher,
+	}
+}
+
+// Close stops rendering the status output.
+func (g *BackupGroup) Close() error {
+	return g.manager.Close()
+}
+
 // TTYRestoreHandler handles tty status output for restore events.
 type TTYRestoreHandler struct {
 	tty       *os.File
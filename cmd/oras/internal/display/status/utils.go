@@ -29,6 +29,7 @@ const (
 	PullPromptSkipped     = "Skipped    "
 	PullPromptRestored    = "Restored   "
 	PullPromptDownloaded  = "Downloaded "
+	PullPromptDeleted     = "Deleted    "
 )
 
 // Prompts for push/attach events.
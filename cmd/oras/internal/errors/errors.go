@@ -72,6 +72,62 @@ func (o *Error) Error() string {
 	return ret
 }
 
+// ExitCodePartialFailure is the exit code of a bulk operation run with
+// --continue-on-error when some of its items fail.
+const ExitCodePartialFailure = 2
+
+// ItemError is the error of an item, e.g. a tag, failed in a bulk operation.
+type ItemError struct {
+	Item string
+	Err  error
+	// Repository is true if the item is a repository failed as a whole
+	// instead of a tag.
+	Repository bool
+}
+
+// PartialFailureError is returned by a bulk operation run with
+// --continue-on-error when some of its items fail. Its message reports all
+// the failed items.
+type PartialFailureError struct {
+	// Operation is the verb of the operation, e.g. "copy".
+	Operation string
+	// Total is the number of tags attempted, excluding the tags of the
+	// repositories failed as a whole.
+	Total    int
+	Failures []ItemError
+}
+
+// Error implements the error interface.
+func (e *PartialFailureError) Error() string {
+	var tags, repositories int
+	for _, failure := range e.Failures {
+		if failure.Repository {
+			repositories++
+		} else {
+			tags++
+		}
+	}
+	var counts []string
+	switch repositories {
+	case 0:
+	case 1:
+		counts = append(counts, "1 repository")
+	default:
+		counts = append(counts, fmt.Sprintf("%d repositories", repositories))
+	}
+	if tags > 0 || repositories == 0 {
+		counts = append(counts, fmt.Sprintf("%d of %d tag(s)", tags, e.Total))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "failed to %s %s:", e.Operation, strings.Join(counts, " and "))
+	for _, failure := range e.Failures {
+		// indent the recommendations of the item errors, if any
+		msg := strings.ReplaceAll(failure.Err.Error(), "\n", "\n    ")
+		fmt.Fprintf(&sb, "\n  %s: %s", failure.Item, msg)
+	}
+	return sb.String()
+}
+
 // CheckArgs checks the args with the checker function.
 func CheckArgs(checker func(args []string) (bool, string), Usage string) cobra.PositionalArgs {
 	return func(cmd *cobra.Command, args []string) error {
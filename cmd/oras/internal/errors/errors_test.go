@@ -191,3 +191,55 @@ func TestUnwrapCopyError(t *testing.T) {
 		})
 	}
 }
+
+func TestPartialFailureError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *PartialFailureError
+		want string
+	}{
+		{
+			name: "tags",
+			err: &PartialFailureError{
+				Operation: "copy",
+				Total:     5,
+				Failures: []ItemError{
+					{Item: "v1", Err: errors.New("connection reset")},
+					{Item: "v3", Err: &Error{Err: errors.New("manifest unknown"), Recommendation: "check the tag"}},
+				},
+			},
+			want: "failed to copy 2 of 5 tag(s):\n  v1: connection reset\n  v3: manifest unknown\n    check the tag",
+		},
+		{
+			name: "repositories",
+			err: &PartialFailureError{
+				Operation: "back up",
+				Total:     3,
+				Failures: []ItemError{
+					{Item: "localhost:5000/a", Err: errors.New("no tags found"), Repository: true},
+					{Item: "localhost:5000/b", Err: errors.New("unauthorized"), Repository: true},
+				},
+			},
+			want: "failed to back up 2 repositories:\n  localhost:5000/a: no tags found\n  localhost:5000/b: unauthorized",
+		},
+		{
+			name: "tags and a repository",
+			err: &PartialFailureError{
+				Operation: "restore",
+				Total:     3,
+				Failures: []ItemError{
+					{Item: "localhost:5000/a", Err: errors.New("unauthorized"), Repository: true},
+					{Item: "localhost:5000/b:v1", Err: errors.New("connection reset")},
+				},
+			},
+			want: "failed to restore 1 repository and 1 of 3 tag(s):\n  localhost:5000/a: unauthorized\n  localhost:5000/b:v1: connection reset",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("PartialFailureError.Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// anonymousFallbackClient is a client retrying the read requests anonymously
+// once the stored credential is rejected by the registry.
+type anonymousFallbackClient struct {
+	client    *auth.Client
+	anonymous *auth.Client
+	registry  string
+	store     credentials.Store
+	logger    logrus.FieldLogger
+
+	fallback atomic.Bool
+	reported sync.Once
+}
+
+// newAnonymousFallbackClient returns a client sending requests with client,
+// and retrying rejected read requests anonymously. The anonymous client
+// shares the HTTP client but not the token cache of client.
+func newAnonymousFallbackClient(client *auth.Client, registry string, store credentials.Store, logger logrus.FieldLogger) *anonymousFallbackClient {
+	anonymous := *client
+	anonymous.Credential = nil
+	anonymous.Cache = auth.NewCache()
+	return &anonymousFallbackClient{
+		client:    client,
+		anonymous: &anonymous,
+		registry:  registry,
+		store:     store,
+		logger:    logger,
+	}
+}
+
+// Do sends req with the stored credential, or anonymously if the stored
+// credential has been rejected for a read request before.
+func (c *anonymousFallbackClient) Do(req *http.Request) (*http.Response, error) {
+	read := req.Method == http.MethodGet || req.Method == http.MethodHead
+	if read && c.fallback.Load() {
+		return c.anonymous.Do(req)
+	}
+	resp, err := c.client.Do(req)
+	if !read || !isUnauthorized(resp, err) {
+		return resp, err
+	}
+	anonymousResp, anonymousErr := c.anonymous.Do(req)
+	if anonymousErr != nil {
+		return resp, err
+	}
+	if anonymousResp.StatusCode < 200 || anonymousResp.StatusCode >= 300 {
+		// only a successful anonymous access proves the content is public,
+		// otherwise the rejection of the stored credential is reported
+		_ = anonymousResp.Body.Close()
+		return resp, err
+	}
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	c.fallback.Store(true)
+	c.reported.Do(func() {
+		identity := "the stored credential"
+		if cred, err := c.store.Get(req.Context(), c.registry); err == nil && cred.Username != "" {
+			identity = "the stored credential of user " + cred.Username
+		}
+		c.logger.Warnf("%s was rejected by %s, continuing anonymously", identity, c.registry)
+	})
+	return anonymousResp, nil
+}
+
+// isUnauthorized returns true if the request is rejected by the registry or
+// the authorization server with 401 Unauthorized.
+func isUnauthorized(resp *http.Response, err error) bool {
+	if err != nil {
+		var errResp *errcode.ErrorResponse
+		return errors.As(err, &errResp) && errResp.StatusCode == http.StatusUnauthorized
+	}
+	return resp.StatusCode == http.StatusUnauthorized
+}
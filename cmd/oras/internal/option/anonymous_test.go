@@ -0,0 +1,126 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spf13/pflag"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+)
+
+func Test_anonymousFallbackClient_Do(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); ok {
+			// expired credential
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodGet {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/v2/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	uri, _ := url.Parse(ts.URL)
+	store := credentials.NewMemoryStore()
+	if err := store.Put(context.Background(), uri.Host, auth.Credential{Username: "alice", Password: "expired"}); err != nil {
+		t.Fatal(err)
+	}
+	client := &auth.Client{
+		Client:     ts.Client(),
+		Cache:      auth.NewCache(),
+		Credential: credentials.Credential(store),
+	}
+	logger, hook := test.NewNullLogger()
+	c := newAnonymousFallbackClient(client, uri.Host, store, logger)
+
+	// PUT requests are not retried, and the Basic challenge makes the client
+	// send the stored credential in the following requests
+	req, _ := http.NewRequest(http.MethodPut, ts.URL+"/v2/", nil)
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Do() status of PUT = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+	if c.fallback.Load() {
+		t.Error("fallback after a rejected PUT, want no fallback")
+	}
+
+	// failed anonymous retries report the rejection of the stored credential
+	req, _ = http.NewRequest(http.MethodGet, ts.URL+"/v2/missing", nil)
+	resp, err = c.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Do() status of GET missing = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+	if c.fallback.Load() {
+		t.Error("fallback after a failed anonymous retry, want no fallback")
+	}
+
+	// GET requests are retried anonymously and reported once
+	for range 2 {
+		req, _ = http.NewRequest(http.MethodGet, ts.URL+"/v2/", nil)
+		resp, err = c.Do(req)
+		if err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Do() status of GET = %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+	}
+	if len(hook.Entries) != 1 || hook.LastEntry().Level != logrus.WarnLevel {
+		t.Fatalf("got log entries %v, want one warning", hook.AllEntries())
+	}
+	if want := "the stored credential of user alice was rejected by " + uri.Host + ", continuing anonymously"; hook.LastEntry().Message != want {
+		t.Errorf("warning = %q, want %q", hook.LastEntry().Message, want)
+	}
+}
+
+func TestRemote_EnableAnonymousFallbackFlag(t *testing.T) {
+	var remo Remote
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	remo.ApplyFlags(fs)
+	if fs.Lookup("allow-anonymous-fallback") != nil {
+		t.Error("--allow-anonymous-fallback is registered without being enabled")
+	}
+	remo.EnableAnonymousFallbackFlag()
+	fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+	remo.ApplyFlags(fs)
+	if fs.Lookup("allow-anonymous-fallback") == nil {
+		t.Error("--allow-anonymous-fallback is not registered after being enabled")
+	}
+}
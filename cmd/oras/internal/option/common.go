@@ -16,24 +16,207 @@ limitations under the License.
 package option
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"oras.land/oras-go/v2/registry/remote/retry"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/cassette"
+	"oras.land/oras/internal/dryrun"
+	"oras.land/oras/internal/registriesconf"
+	"oras.land/oras/internal/report"
+	"oras.land/oras/internal/trace"
+	"oras.land/oras/internal/uploadsession"
 )
 
 // Common option struct.
 type Common struct {
 	Printer *output.Printer
 	Debug   bool
+	DryRun  bool
+	Record  string
+	Replay  string
+	Report  string
+
+	// NoRegistriesConf disables reading the containers registries.conf.
+	NoRegistriesConf bool
+	// TraceID is the trace ID sent in the TraceHeader of every registry
+	// request, generated per command if not specified.
+	TraceID     string
+	TraceHeader string
+
+	applyDryRun    bool
+	dryRunOut      io.Writer
+	recorder       *cassette.Writer
+	player         *cassette.Player
+	registriesConf *registriesconf.Config
+	reporter       *report.Recorder
+	uploads        *uploadsession.Tracker
+}
+
+// EnableDryRunFlag sets dry run flag as applicable.
+func (opts *Common) EnableDryRunFlag() {
+	opts.applyDryRun = true
 }
 
 // ApplyFlags applies flags to a command flag set.
 func (opts *Common) ApplyFlags(fs *pflag.FlagSet) {
 	fs.BoolVarP(&opts.Debug, "debug", "d", false, "output debug logs (implies --no-tty)")
+	fs.StringVar(&opts.Record, "record", "", "[Experimental] record registry interactions to a cassette `file` for replaying")
+	fs.StringVar(&opts.Replay, "replay", "", "[Experimental] replay registry interactions from a cassette `file` recorded by --record instead of accessing registries")
+	fs.StringVar(&opts.Report, "report", "", "[Experimental] write a local JSON report of the references, digests, bytes, durations, retries and HTTP statuses of the command to `file`")
+	fs.BoolVar(&opts.NoRegistriesConf, "no-registries-conf", false, "[Experimental] do not apply the mirror, insecure and blocked registry settings of the containers registries.conf and its registries.conf.d drop-ins")
+	fs.StringVar(&opts.TraceID, "trace-id", "", "[Experimental] trace `ID` sent with every registry request to correlate client and registry logs, generated if not specified")
+	fs.StringVar(&opts.TraceHeader, "trace-header", trace.HeaderTraceParent, "[Experimental] `name` of the header carrying the trace ID, e.g. X-Request-Id, empty to disable")
+	if opts.applyDryRun {
+		fs.BoolVar(&opts.DryRun, "dry-run", false, "[Experimental] print the planned registry operations without changing any registry, with HTTP requests printed if --debug is set")
+	}
 }
 
 // Parse gets target options from user input.
 func (opts *Common) Parse(cmd *cobra.Command) error {
 	opts.Printer = output.NewPrinter(cmd.OutOrStdout(), cmd.OutOrStderr())
+	opts.dryRunOut = cmd.ErrOrStderr()
+	opts.uploads = uploadsession.FromContext(cmd.Context())
+	if err := opts.parseRegistriesConf(cmd); err != nil {
+		return err
+	}
+	if err := opts.parseTrace(); err != nil {
+		return err
+	}
+	opts.parseReport(cmd)
+	return opts.parseCassette()
+}
+
+// parseReport enables the report of the command to be written when it
+// finishes.
+func (opts *Common) parseReport(cmd *cobra.Command) {
+	if opts.Report == "" {
+		return
+	}
+	if opts.reporter = report.FromContext(cmd.Context()); opts.reporter != nil {
+		opts.reporter.Enable(opts.Report, cmd.CommandPath(), cmd.Flags().Args(), opts.TraceID)
+	}
+}
+
+// retryPolicy returns the retry policy of the registry clients, or nil for
+// the default policy.
+func (opts *Common) retryPolicy() func() retry.Policy {
+	if opts.reporter == nil {
+		return nil
+	}
+	return func() retry.Policy {
+		return opts.reporter.RetryPolicy(retry.DefaultPolicy)
+	}
+}
+
+// parseTrace generates or validates the trace ID.
+func (opts *Common) parseTrace() error {
+	if opts.TraceHeader == "" {
+		opts.TraceID = ""
+		return nil
+	}
+	if opts.TraceID == "" {
+		opts.TraceID = trace.NewTraceID()
+		return nil
+	}
+	if err := trace.ValidateTraceID(opts.TraceHeader, opts.TraceID); err != nil {
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: `Use a trace ID of 32 lowercase hexadecimal digits, or another header like "--trace-header X-Request-Id"`,
+		}
+	}
 	return nil
 }
+
+// correlate sends the trace ID with every request sent by base.
+func (opts *Common) correlate(base http.RoundTripper) http.RoundTripper {
+	if opts.TraceHeader == "" || opts.TraceID == "" {
+		return base
+	}
+	return trace.NewCorrelationTransport(base, opts.TraceHeader, opts.TraceID)
+}
+
+// parseRegistriesConf loads the containers registries.conf unless disabled.
+// A per-user or system wide registries.conf failing to load is ignored with
+// a warning, as it is shared with other tools and not specified for oras.
+func (opts *Common) parseRegistriesConf(cmd *cobra.Command) (err error) {
+	if opts.NoRegistriesConf {
+		return nil
+	}
+	path := registriesconf.Path()
+	if opts.registriesConf, err = registriesconf.Load(path); err != nil {
+		if os.Getenv(registriesconf.EnvPath) == "" {
+			_, logger := trace.NewLogger(cmd.Context(), opts.Debug)
+			logger.Warnf("Ignoring the registries configuration: %v", err)
+			return nil
+		}
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: fmt.Sprintf("fix the configuration file, unset %s or use --no-registries-conf to ignore it", registriesconf.EnvPath),
+		}
+	}
+	return nil
+}
+
+// parseCassette prepares the cassette to record to or replay from.
+func (opts *Common) parseCassette() (err error) {
+	switch {
+	case opts.Record != "" && opts.Replay != "":
+		return &oerrors.Error{
+			Err:            errors.New("--record and --replay cannot be used at the same time"),
+			Recommendation: "record the interactions first, then replay them in a separate run",
+		}
+	case opts.Record != "":
+		if opts.recorder, err = cassette.Create(opts.Record); err != nil {
+			return fmt.Errorf("failed to create cassette: %w", err)
+		}
+	case opts.Replay != "":
+		if opts.player, err = cassette.Load(opts.Replay); err != nil {
+			return fmt.Errorf("failed to load cassette: %w", err)
+		}
+	}
+	return nil
+}
+
+// wrapTransport wraps the registry transport to record, replay or suppress
+// registry interactions as requested.
+func (opts *Common) wrapTransport(base http.RoundTripper) http.RoundTripper {
+	transport := base
+	switch {
+	case opts.player != nil:
+		transport = opts.player
+	case opts.recorder != nil:
+		transport = opts.recorder.Recorder(base)
+	}
+	if opts.uploads != nil && opts.player == nil {
+		// track upload sessions to cancel them on interruption
+		transport = opts.uploads.Transport(transport)
+	}
+	if opts.reporter != nil {
+		transport = opts.reporter.Transport(transport)
+	}
+	if opts.DryRun {
+		transport = dryrun.NewTransport(transport, opts.ReportDryRun)
+	}
+	return transport
+}
+
+// ReportDryRun prints a registry operation suppressed in dry run mode.
+func (opts *Common) ReportDryRun(op dryrun.Operation) {
+	if opts.dryRunOut == nil {
+		return
+	}
+	if op.Action != "" {
+		_, _ = fmt.Fprintln(opts.dryRunOut, "[dry-run] Would", op.Action)
+	}
+	if opts.Debug {
+		_, _ = fmt.Fprintf(opts.dryRunOut, "[dry-run]   %s %s\n", op.Method, op.URL)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/internal/cassette"
+	"oras.land/oras/internal/dryrun"
+	"oras.land/oras/internal/registriesconf"
+	"oras.land/oras/internal/trace"
+)
+
+func TestCommon_ReportDryRun(t *testing.T) {
+	ops := []dryrun.Operation{
+		{Method: "POST", URL: "https://localhost:5000/v2/test/blobs/uploads/"},
+		{Method: "PUT", URL: "https://localhost:5000/v2/test/manifests/v1", Action: "push manifest sha256:abc to localhost:5000/test"},
+	}
+	tests := []struct {
+		name  string
+		debug bool
+		want  string
+	}{
+		{
+			name: "actions only",
+			want: "[dry-run] Would push manifest sha256:abc to localhost:5000/test\n",
+		},
+		{
+			name:  "with requests",
+			debug: true,
+			want: "[dry-run]   POST https://localhost:5000/v2/test/blobs/uploads/\n" +
+				"[dry-run] Would push manifest sha256:abc to localhost:5000/test\n" +
+				"[dry-run]   PUT https://localhost:5000/v2/test/manifests/v1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := &Common{Debug: tt.debug, dryRunOut: &buf}
+			for _, op := range ops {
+				opts.ReportDryRun(op)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("ReportDryRun() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTarget_NewTarget_dryRun(t *testing.T) {
+	target := Target{Type: TargetTypeOCILayout, Path: t.TempDir(), RawReference: "layout"}
+	if _, err := target.NewTarget(Common{DryRun: true}, nil); err == nil {
+		t.Error("NewTarget() error = nil, want error for OCI layout in dry run mode")
+	}
+}
+
+func TestCommon_parseCassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	opts := &Common{Record: path, Replay: path}
+	if err := opts.parseCassette(); err == nil {
+		t.Error("parseCassette() error = nil, want error for --record with --replay")
+	}
+
+	opts = &Common{Record: path}
+	if err := opts.parseCassette(); err != nil {
+		t.Fatalf("parseCassette() error = %v", err)
+	}
+	if _, ok := opts.wrapTransport(http.DefaultTransport).(*cassette.Recorder); !ok {
+		t.Error("wrapTransport() is not a recorder")
+	}
+
+	opts = &Common{Replay: path, DryRun: true}
+	if err := opts.parseCassette(); err != nil {
+		t.Fatalf("parseCassette() error = %v", err)
+	}
+	if _, ok := opts.wrapTransport(http.DefaultTransport).(*dryrun.Transport); !ok {
+		t.Error("wrapTransport() is not a dry run transport")
+	}
+}
+
+func TestCommon_parseTrace(t *testing.T) {
+	opts := &Common{TraceHeader: trace.HeaderTraceParent}
+	if err := opts.parseTrace(); err != nil {
+		t.Fatalf("parseTrace() error = %v", err)
+	}
+	if err := trace.ValidateTraceID(opts.TraceHeader, opts.TraceID); err != nil {
+		t.Errorf("parseTrace() generated invalid trace ID: %v", err)
+	}
+	if _, ok := opts.correlate(http.DefaultTransport).(*trace.CorrelationTransport); !ok {
+		t.Error("correlate() is not a correlation transport")
+	}
+
+	opts = &Common{TraceHeader: trace.HeaderTraceParent, TraceID: "incident-42"}
+	if err := opts.parseTrace(); err == nil {
+		t.Error("parseTrace() error = nil, want error for invalid traceparent trace ID")
+	}
+
+	opts = &Common{TraceHeader: "X-Request-Id", TraceID: "incident-42"}
+	if err := opts.parseTrace(); err != nil || opts.TraceID != "incident-42" {
+		t.Errorf("parseTrace() = %q, %v, want %q", opts.TraceID, err, "incident-42")
+	}
+
+	opts = &Common{TraceID: "incident-42"}
+	if err := opts.parseTrace(); err != nil {
+		t.Fatalf("parseTrace() error = %v", err)
+	}
+	if opts.correlate(http.DefaultTransport) != http.DefaultTransport {
+		t.Error("correlate() wraps the transport with the trace header disabled")
+	}
+}
+
+func TestCommon_parseRegistriesConf(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv(registriesconf.EnvPath, "")
+	invalid := filepath.Join(configDir, "containers", "registries.conf")
+	if err := os.MkdirAll(filepath.Dir(invalid), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalid, []byte("[[registry]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	// the invalid per-user file is ignored
+	opts := &Common{}
+	if err := opts.parseRegistriesConf(cmd); err != nil {
+		t.Fatalf("parseRegistriesConf() error = %v, want nil", err)
+	}
+	if opts.registriesConf != nil {
+		t.Errorf("parseRegistriesConf() loaded %+v, want nil", opts.registriesConf)
+	}
+
+	// the invalid file specified by the environment variable fails
+	t.Setenv(registriesconf.EnvPath, invalid)
+	if err := opts.parseRegistriesConf(cmd); err == nil {
+		t.Error("parseRegistriesConf() error = nil, want error")
+	}
+}
@@ -16,47 +16,60 @@ limitations under the License.
 package option
 
 import (
-	"bufio"
-	"fmt"
 	"io"
-	"strings"
+	"os"
 
 	"github.com/spf13/pflag"
+	"oras.land/oras/cmd/oras/internal/display/prompt"
 )
 
+// Prompt option struct.
+type Prompt struct {
+	Yes bool
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Prompt) ApplyFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&opts.Yes, "yes", "y", false, "[Experimental] assume yes to confirmation prompts")
+}
+
+// Interactive returns true if confirmations are asked through r, which is the
+// case if r is a terminal and --yes is not set.
+func (opts *Prompt) Interactive(r io.Reader) bool {
+	return !opts.Yes && prompt.IsInteractive(r)
+}
+
 // Confirmation option struct.
 type Confirmation struct {
+	Prompt
 	Force bool
 }
 
 // ApplyFlags applies flags to a command flag set.
 func (opts *Confirmation) ApplyFlags(fs *pflag.FlagSet) {
 	fs.BoolVarP(&opts.Force, "force", "f", false, "ignore nonexistent references, never prompt")
+	opts.Prompt.ApplyFlags(fs)
 }
 
 // AskForConfirmation prints a propmt to ask for confirmation before doing an
 // action and takes user input as response.
-func (opts *Confirmation) AskForConfirmation(r io.Reader, prompt string) (bool, error) {
-	if opts.Force {
+func (opts *Confirmation) AskForConfirmation(r io.Reader, msg string) (bool, error) {
+	if opts.Force || opts.Yes {
 		return true, nil
 	}
+	return prompt.Confirm(r, os.Stdout, msg)
+}
 
-	fmt.Print(prompt, " [y/N] ")
-
-	var response string
-	scanner := bufio.NewScanner(r)
-	if ok := scanner.Scan(); ok {
-		response = scanner.Text()
-	}
-	if err := scanner.Err(); err != nil {
-		return false, err
-	}
-
-	switch strings.ToLower(response) {
-	case "y", "yes":
+// AskForTypedConfirmation asks for confirmation before doing a destructive
+// action. In a terminal, the user is asked to type expected, such as the
+// name of the affected repository. Otherwise, a yes or no answer is read
+// from r.
+func (opts *Confirmation) AskForTypedConfirmation(r io.Reader, msg string, expected string) (bool, error) {
+	if opts.Force || opts.Yes {
 		return true, nil
-	default:
-		fmt.Println("Operation cancelled.")
-		return false, nil
 	}
+	if prompt.IsInteractive(r) {
+		return prompt.ConfirmByTyping(r, os.Stdout, msg, expected)
+	}
+	return prompt.Confirm(r, os.Stdout, msg)
 }
@@ -69,3 +69,50 @@ func TestConfirmation_AskForConfirmation_manuallyConfirmed(t *testing.T) {
 		t.Fatalf("Confirmation.AskForConfirmation() got %v, want %v", got, false)
 	}
 }
+
+func TestConfirmation_AskForConfirmation_assumedYes(t *testing.T) {
+	opts := Confirmation{
+		Prompt: Prompt{Yes: true},
+	}
+	r := strings.NewReader("no")
+
+	got, err := opts.AskForConfirmation(r, "")
+	if err != nil {
+		t.Fatal("Confirmation.AskForConfirmation() error =", err)
+	}
+	if !reflect.DeepEqual(got, true) {
+		t.Fatalf("Confirmation.AskForConfirmation() got %v, want %v", got, true)
+	}
+}
+
+func TestConfirmation_AskForTypedConfirmation(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  Confirmation
+		input string
+		want  bool
+	}{
+		{"forcibly confirmed", Confirmation{Force: true}, "", true},
+		{"assumed yes", Confirmation{Prompt: Prompt{Yes: true}}, "", true},
+		{"non-interactive yes", Confirmation{}, "yes", true},
+		{"non-interactive no", Confirmation{}, "localhost:5000/hello", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.AskForTypedConfirmation(strings.NewReader(tt.input), "", "localhost:5000/hello")
+			if err != nil {
+				t.Fatal("Confirmation.AskForTypedConfirmation() error =", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Confirmation.AskForTypedConfirmation() got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrompt_Interactive(t *testing.T) {
+	opts := Prompt{}
+	if opts.Interactive(strings.NewReader("")) {
+		t.Fatal("Prompt.Interactive() = true for a non-terminal reader")
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+	"oras.land/oras/cmd/oras/internal/display/prompt"
+	"oras.land/oras/internal/credential"
+	"oras.land/oras/internal/trace"
+)
+
+// CheckCredentialExpiry checks the expiry of the credential for registry once
+// per registry, before any transfer starts, so that a credential expiring soon
+// is renewed before starting a long operation instead of being rejected in the
+// middle of it. The renewed credential is used by the clients created
+// afterwards.
+func (remo *Remote) CheckCredentialExpiry(cmd *cobra.Command, registry string) error {
+	if remo.CredentialMinValidity <= 0 || remo.renewed == nil {
+		return nil
+	}
+	if _, checked := remo.renewed.Load(registry); checked {
+		return nil
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_, logger := trace.NewLogger(ctx, false)
+	cred := remo.Credential()
+	if cred == auth.EmptyCredential {
+		store, err := remo.credentialStore()
+		if err == nil {
+			cred, err = credentials.Credential(store)(ctx, registry)
+		}
+		if err != nil {
+			// the credential store errors are reported when accessing the
+			// registry, if the registry requires authentication
+			logger.Debugf("skipped checking the credential expiry for %s: %v", registry, err)
+			return nil
+		}
+	}
+	var renewed *auth.Credential
+	if cred != auth.EmptyCredential {
+		interactive := prompt.IsInteractive(os.Stdin) && prompt.IsInteractive(os.Stderr)
+		var err error
+		if renewed, err = remo.renewExpiring(registry, cred, time.Now(), interactive, logger); err != nil {
+			return err
+		}
+	}
+	remo.renewed.Store(registry, renewed)
+	return nil
+}
+
+// renewedCredential wraps credFunc to return the credential for registry
+// renewed by CheckCredentialExpiry, if any.
+func (remo *Remote) renewedCredential(credFunc auth.CredentialFunc, registry string) auth.CredentialFunc {
+	if remo.renewed == nil {
+		return credFunc
+	}
+	value, _ := remo.renewed.Load(registry)
+	renewed, _ := value.(*auth.Credential)
+	if renewed == nil {
+		return credFunc
+	}
+	return func(context.Context, string) (auth.Credential, error) {
+		return *renewed, nil
+	}
+}
+
+// renewExpiring prompts for a new credential if cred is known to expire
+// within the minimum validity, or warns if not interactive. An error is
+// returned if cred has expired and cannot be renewed.
+func (remo *Remote) renewExpiring(registry string, cred auth.Credential, now time.Time, interactive bool, logger logrus.FieldLogger) (*auth.Credential, error) {
+	expiry, ok := credential.Expiry(cred)
+	if !ok {
+		return nil, nil
+	}
+	remaining := expiry.Sub(now).Round(time.Second)
+	if remaining >= remo.CredentialMinValidity {
+		return nil, nil
+	}
+	var status string
+	if remaining <= 0 {
+		status = fmt.Sprintf("expired at %s", expiry.Format(time.RFC3339))
+	} else {
+		status = fmt.Sprintf("expires in %s", remaining)
+	}
+	if interactive {
+		_, _ = fmt.Fprintf(os.Stderr, "The credential for %s %s.\n", registry, status)
+		if cred.Username != "" {
+			password, err := prompt.Secret(os.Stdin, os.Stderr, fmt.Sprintf("Password for %s: ", cred.Username))
+			if err != nil {
+				return nil, err
+			}
+			if password != "" {
+				return &auth.Credential{Username: cred.Username, Password: password}, nil
+			}
+		} else {
+			token, err := prompt.Secret(os.Stdin, os.Stderr, "Token: ")
+			if err != nil {
+				return nil, err
+			}
+			if token != "" {
+				return &auth.Credential{RefreshToken: token}, nil
+			}
+		}
+	}
+	if remaining <= 0 {
+		return nil, fmt.Errorf("the credential for %s %s, run `oras login %s` to renew it", registry, status, registry)
+	}
+	logger.Warnf("the credential for %s %s, which may be too short for long operations. Run `oras login %s` to renew it", registry, status, registry)
+	return nil, nil
+}
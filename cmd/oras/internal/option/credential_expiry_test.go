@@ -0,0 +1,117 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestRemote_renewExpiring(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	ecrCredential := func(expiry time.Time) auth.Credential {
+		token := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(`{"expiration":%d}`, expiry.Unix())))
+		return auth.Credential{Username: "AWS", Password: token}
+	}
+	tests := []struct {
+		name         string
+		cred         auth.Credential
+		wantWarnings int
+		wantErr      bool
+	}{
+		{name: "unknown expiry", cred: auth.Credential{Username: "alice", Password: "secret"}},
+		{name: "valid", cred: ecrCredential(now.Add(time.Hour))},
+		{name: "expiring", cred: ecrCredential(now.Add(time.Minute)), wantWarnings: 1},
+		{name: "expired", cred: ecrCredential(now.Add(-time.Minute)), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remo := &Remote{CredentialMinValidity: 10 * time.Minute}
+			logger, hook := test.NewNullLogger()
+			renewed, err := remo.renewExpiring("localhost:5000", tt.cred, now, false, logger)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Remote.renewExpiring() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if renewed != nil {
+				t.Errorf("Remote.renewExpiring() = %v, want nil when not interactive", renewed)
+			}
+			if len(hook.Entries) != tt.wantWarnings {
+				t.Errorf("got %d warnings, want %d", len(hook.Entries), tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestRemote_CheckCredentialExpiry(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	ecrPassword := func(expiry time.Time) string {
+		return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(`{"expiration":%d}`, expiry.Unix())))
+	}
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	// no stored credential
+	remo := &Remote{CredentialMinValidity: 10 * time.Minute, renewed: &sync.Map{}}
+	if err := remo.CheckCredentialExpiry(cmd, "localhost:5000"); err != nil {
+		t.Errorf("Remote.CheckCredentialExpiry() error = %v", err)
+	}
+
+	// expiring credentials are only warned about when not interactive
+	remo = &Remote{CredentialMinValidity: 10 * time.Minute, Username: "AWS", Secret: ecrPassword(time.Now().Add(time.Minute)), renewed: &sync.Map{}}
+	if err := remo.CheckCredentialExpiry(cmd, "localhost:5000"); err != nil {
+		t.Errorf("Remote.CheckCredentialExpiry() error = %v", err)
+	}
+	if _, checked := remo.renewed.Load("localhost:5000"); !checked {
+		t.Error("Remote.CheckCredentialExpiry() does not record the checked registry")
+	}
+
+	// expired credentials fail the command before any transfer
+	remo = &Remote{CredentialMinValidity: 10 * time.Minute, Username: "AWS", Secret: ecrPassword(time.Now().Add(-time.Minute)), renewed: &sync.Map{}}
+	if err := remo.CheckCredentialExpiry(cmd, "localhost:5000"); err == nil {
+		t.Error("Remote.CheckCredentialExpiry() error = nil, want error for expired credential")
+	}
+}
+
+func TestRemote_renewedCredential(t *testing.T) {
+	stored := auth.Credential{Username: "alice", Password: "old"}
+	credFunc := func(context.Context, string) (auth.Credential, error) {
+		return stored, nil
+	}
+	remo := &Remote{renewed: &sync.Map{}}
+	remo.renewed.Store("checked.example", (*auth.Credential)(nil))
+	remo.renewed.Store("renewed.example", &auth.Credential{Username: "alice", Password: "new"})
+	for registry, want := range map[string]string{
+		"unchecked.example": "old",
+		"checked.example":   "old",
+		"renewed.example":   "new",
+	} {
+		cred, err := remo.renewedCredential(credFunc, registry)(context.Background(), registry)
+		if err != nil {
+			t.Fatalf("credential for %s error = %v", registry, err)
+		}
+		if cred.Password != want {
+			t.Errorf("credential for %s = %q, want %q", registry, cred.Password, want)
+		}
+	}
+}
@@ -18,6 +18,7 @@ package option
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/pflag"
@@ -41,3 +42,29 @@ func (opts *Descriptor) Marshal(desc ocispec.Descriptor) ([]byte, error) {
 	}
 	return b, nil
 }
+
+// DescriptorFile option struct.
+type DescriptorFile struct {
+	DescriptorFilePath string
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *DescriptorFile) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&opts.DescriptorFilePath, "descriptor-file", "", "", "[Experimental] write the descriptor of the resulting manifest in JSON to `file`, regardless of the output format")
+}
+
+// WriteDescriptor writes the JSON encoding of desc to the descriptor file if
+// requested.
+func (opts *DescriptorFile) WriteDescriptor(desc ocispec.Descriptor) error {
+	if opts.DescriptorFilePath == "" {
+		return nil
+	}
+	b, err := json.Marshal(desc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal descriptor: %w", err)
+	}
+	if err := os.WriteFile(opts.DescriptorFilePath, append(b, '\n'), 0666); err != nil {
+		return fmt.Errorf("failed to write descriptor file: %w", err)
+	}
+	return nil
+}
@@ -17,6 +17,8 @@ package option
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -57,3 +59,33 @@ func TestDescriptor_Marshal(t *testing.T) {
 		t.Fatalf("Descriptor.Marshal() got %v, want %v", got, want)
 	}
 }
+
+func TestDescriptorFile_WriteDescriptor(t *testing.T) {
+	blob := []byte("hello world")
+	desc := ocispec.Descriptor{
+		MediaType: "test",
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	path := filepath.Join(t.TempDir(), "descriptor.json")
+	opts := DescriptorFile{DescriptorFilePath: path}
+	if err := opts.WriteDescriptor(desc); err != nil {
+		t.Fatal("DescriptorFile.WriteDescriptor() error =", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got ocispec.Descriptor
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, desc) {
+		t.Fatalf("DescriptorFile.WriteDescriptor() wrote %v, want %v", got, desc)
+	}
+
+	// no file is written if not requested
+	if err := (&DescriptorFile{}).WriteDescriptor(desc); err != nil {
+		t.Fatal("DescriptorFile.WriteDescriptor() error =", err)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/expiry"
+)
+
+// Expiry option struct.
+type Expiry struct {
+	ExpiresIn time.Duration
+
+	expiresInFlag string
+	enabled       bool
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Expiry) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&opts.expiresInFlag, "expires-in", "", "", "[Experimental] mark the artifact as expired after the given `duration` (e.g. 12h, 30d, 2w), honored by 'oras prune'")
+}
+
+// Parse parses the expiry duration.
+func (opts *Expiry) Parse(cmd *cobra.Command) error {
+	// an explicit zero duration marks the artifact as expired immediately
+	opts.enabled = cmd.Flags().Changed("expires-in")
+	if !opts.enabled {
+		return nil
+	}
+	d, err := expiry.ParseDuration(opts.expiresInFlag)
+	if err != nil {
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: `Please specify the duration in the form of "30d", "2w" or "12h"`,
+		}
+	}
+	opts.ExpiresIn = d
+	return nil
+}
+
+// Enabled returns true if --expires-in is specified.
+func (opts *Expiry) Enabled() bool {
+	return opts.enabled
+}
+
+// ApplyExpiry adds the expiry annotation, computed relative to now, to the
+// manifest annotations. An expiry annotation explicitly provided by users is
+// kept as is.
+func (opts *Expiry) ApplyExpiry(annotations map[string]map[string]string, now time.Time) map[string]map[string]string {
+	if !opts.enabled {
+		return annotations
+	}
+	if annotations == nil {
+		annotations = make(map[string]map[string]string)
+	}
+	manifestAnnotations := annotations[AnnotationManifest]
+	if manifestAnnotations == nil {
+		manifestAnnotations = make(map[string]string)
+		annotations[AnnotationManifest] = manifestAnnotations
+	}
+	if _, ok := manifestAnnotations[expiry.AnnotationExpires]; !ok {
+		manifestAnnotations[expiry.AnnotationExpires] = now.Add(opts.ExpiresIn).UTC().Format(time.RFC3339)
+	}
+	return annotations
+}
@@ -0,0 +1,77 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/internal/expiry"
+)
+
+func TestExpiry_Parse(t *testing.T) {
+	parse := func(args ...string) (*Expiry, error) {
+		var opts Expiry
+		cmd := &cobra.Command{}
+		opts.ApplyFlags(cmd.Flags())
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		return &opts, opts.Parse(cmd)
+	}
+	opts, err := parse("--expires-in", "30d")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if want := 30 * 24 * time.Hour; !opts.Enabled() || opts.ExpiresIn != want {
+		t.Errorf("Parse() ExpiresIn = %v, want %v", opts.ExpiresIn, want)
+	}
+
+	// an explicit zero duration is distinguished from an unset flag
+	opts, err = parse("--expires-in", "0s")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !opts.Enabled() || opts.ExpiresIn != 0 {
+		t.Errorf("Parse() Enabled() = %v, ExpiresIn = %v, want true, 0s", opts.Enabled(), opts.ExpiresIn)
+	}
+	if opts, err = parse(); err != nil || opts.Enabled() {
+		t.Errorf("Parse() Enabled() = %v, error = %v, want false, nil", opts.Enabled(), err)
+	}
+
+	if _, err := parse("--expires-in", "soon"); err == nil {
+		t.Error("Parse() expects error for invalid duration")
+	}
+}
+
+func TestExpiry_ApplyExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	opts := Expiry{expiresInFlag: "1d", ExpiresIn: 24 * time.Hour, enabled: true}
+
+	got := opts.ApplyExpiry(nil, now)
+	if want := "2024-01-02T00:00:00Z"; got[AnnotationManifest][expiry.AnnotationExpires] != want {
+		t.Errorf("ApplyExpiry() = %v, want %v", got[AnnotationManifest][expiry.AnnotationExpires], want)
+	}
+
+	existing := map[string]map[string]string{
+		AnnotationManifest: {expiry.AnnotationExpires: "2030-01-01T00:00:00Z"},
+	}
+	got = opts.ApplyExpiry(existing, now)
+	if want := "2030-01-01T00:00:00Z"; got[AnnotationManifest][expiry.AnnotationExpires] != want {
+		t.Errorf("ApplyExpiry() overrode user annotation: got %v, want %v", got[AnnotationManifest][expiry.AnnotationExpires], want)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+// OutputFD option struct.
+type OutputFD struct {
+	StatusFD   int
+	MetadataFD int
+
+	statusFile   *os.File
+	metadataFile *os.File
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *OutputFD) ApplyFlags(fs *pflag.FlagSet) {
+	fs.IntVarP(&opts.StatusFD, "status-fd", "", 0, "[Experimental] write the status events as JSON lines to the open file descriptor `fd`, in addition to the status output")
+	fs.IntVarP(&opts.MetadataFD, "metadata-fd", "", 0, "[Experimental] write the metadata in JSON to the open file descriptor `fd`, regardless of the output format")
+}
+
+// Parse opens the requested file descriptors.
+func (opts *OutputFD) Parse(cmd *cobra.Command) error {
+	var err error
+	if cmd.Flags().Changed("status-fd") {
+		if opts.statusFile, err = openFD("status-fd", opts.StatusFD); err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("metadata-fd") {
+		if opts.metadataFile, err = openFD("metadata-fd", opts.MetadataFD); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StatusWriter returns the writer of the status file descriptor, or nil if
+// not requested.
+func (opts *OutputFD) StatusWriter() io.Writer {
+	if opts.statusFile == nil {
+		return nil
+	}
+	return opts.statusFile
+}
+
+// MetadataWriter returns the writer of the metadata file descriptor, or nil if
+// not requested.
+func (opts *OutputFD) MetadataWriter() io.Writer {
+	if opts.metadataFile == nil {
+		return nil
+	}
+	return opts.metadataFile
+}
+
+// openFD returns the file of the open file descriptor fd specified by flag.
+func openFD(flag string, fd int) (*os.File, error) {
+	if fd < 1 {
+		return nil, &oerrors.Error{
+			Err:            fmt.Errorf("invalid file descriptor %d for --%s", fd, flag),
+			Recommendation: "specify a file descriptor opened for writing, such as 3 with '3>file' in the shell",
+		}
+	}
+	f := os.NewFile(uintptr(fd), flag)
+	if f == nil {
+		return nil, fmt.Errorf("invalid file descriptor %d for --%s", fd, flag)
+	}
+	if _, err := f.Stat(); err != nil {
+		return nil, &oerrors.Error{
+			Err:            fmt.Errorf("file descriptor %d for --%s is not open: %w", fd, flag, err),
+			Recommendation: "open the file descriptor for writing before running oras, such as with '3>file' in the shell",
+		}
+	}
+	return f, nil
+}
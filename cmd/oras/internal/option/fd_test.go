@@ -0,0 +1,67 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestOutputFD_Parse(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "fd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	openFD := strconv.Itoa(int(f.Fd()))
+
+	tests := []struct {
+		name         string
+		args         []string
+		wantStatus   bool
+		wantMetadata bool
+		wantErr      bool
+	}{
+		{name: "not requested"},
+		{name: "status", args: []string{"--status-fd", openFD}, wantStatus: true},
+		{name: "metadata", args: []string{"--metadata-fd", openFD}, wantMetadata: true},
+		{name: "invalid", args: []string{"--status-fd", "0"}, wantErr: true},
+		{name: "not open", args: []string{"--metadata-fd", "987"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts OutputFD
+			cmd := &cobra.Command{}
+			opts.ApplyFlags(cmd.Flags())
+			if err := cmd.Flags().Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := opts.Parse(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OutputFD.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := opts.StatusWriter() != nil; got != tt.wantStatus {
+				t.Errorf("OutputFD.StatusWriter() requested = %v, want %v", got, tt.wantStatus)
+			}
+			if got := opts.MetadataWriter() != nil; got != tt.wantMetadata {
+				t.Errorf("OutputFD.MetadataWriter() requested = %v, want %v", got, tt.wantMetadata)
+			}
+		})
+	}
+}
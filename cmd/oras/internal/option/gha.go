@@ -0,0 +1,132 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// EnvGitHubOutput is the environment variable of the GitHub Actions step
+// output file.
+const EnvGitHubOutput = "GITHUB_OUTPUT"
+
+// GitHubActions option struct.
+type GitHubActions struct {
+	GHA bool
+
+	commandPath string
+	errWriter   io.Writer
+	outputPath  string
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *GitHubActions) ApplyFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&opts.GHA, "gha", "", false, "[Experimental] emit GitHub Actions workflow commands for the result and errors to stderr, and write the digest, reference, size and media type of the result to $"+EnvGitHubOutput)
+}
+
+// Parse gets the GitHub Actions step output file.
+func (opts *GitHubActions) Parse(cmd *cobra.Command) error {
+	if opts.GHA {
+		opts.commandPath = cmd.CommandPath()
+		opts.errWriter = cmd.ErrOrStderr()
+		opts.outputPath = os.Getenv(EnvGitHubOutput)
+	}
+	return nil
+}
+
+// Command wraps the RunE of cmd to report the returned error as a workflow
+// error command. It is applied after other error handlers so that the final
+// error message is reported.
+func (opts *GitHubActions) Command(cmd *cobra.Command) *cobra.Command {
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		err := runE(cmd, args)
+		if err != nil && opts.GHA {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "::error title=%s::%s\n", escapeProperty(cmd.CommandPath()+" failed"), escapeData(err.Error()))
+		}
+		return err
+	}
+	return cmd
+}
+
+// ReportResult emits a workflow notice command for the artifact desc in the
+// repository, such as "Pushed localhost:5000/hello@sha256:...", and writes
+// the digest, the reference, the size and the media type of desc as step
+// outputs if $GITHUB_OUTPUT is set.
+func (opts *GitHubActions) ReportResult(action string, repository string, desc ocispec.Descriptor) error {
+	if !opts.GHA {
+		return nil
+	}
+	reference := repository + "@" + desc.Digest.String()
+	if _, err := fmt.Fprintf(opts.errWriter, "::notice title=%s::%s\n", escapeProperty(opts.commandPath), escapeData(action+" "+reference)); err != nil {
+		return err
+	}
+	if opts.outputPath == "" {
+		return nil
+	}
+	fp, err := os.OpenFile(opts.outputPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write GitHub Actions step outputs: %w", err)
+	}
+	defer fp.Close()
+	delimiter, err := newOutputDelimiter()
+	if err != nil {
+		return fmt.Errorf("failed to write GitHub Actions step outputs: %w", err)
+	}
+	for _, output := range [][2]string{
+		{"digest", desc.Digest.String()},
+		{"reference", reference},
+		{"size", strconv.FormatInt(desc.Size, 10)},
+		{"media-type", desc.MediaType},
+	} {
+		// the values may come from the registry, so the multiline syntax is
+		// used to prevent injecting other outputs via newlines
+		if _, err := fmt.Fprintf(fp, "%s<<%s\n%s\n%s\n", output[0], delimiter, output[1], delimiter); err != nil {
+			return fmt.Errorf("failed to write GitHub Actions step outputs: %w", err)
+		}
+	}
+	return nil
+}
+
+// newOutputDelimiter returns a random delimiter for the multiline step
+// outputs, which cannot be guessed by the registry.
+func newOutputDelimiter() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "ghadelimiter_" + hex.EncodeToString(b), nil
+}
+
+// escapeData escapes the message of a workflow command.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value of a workflow command.
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
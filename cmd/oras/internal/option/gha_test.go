@@ -0,0 +1,168 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"bytes"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+)
+
+func TestGitHubActions_ReportResult(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "output")
+	t.Setenv(EnvGitHubOutput, outputPath)
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{Use: "push"}
+	cmd.SetErr(&stderr)
+	opts := GitHubActions{GHA: true}
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	desc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("test"),
+		Size:      42,
+	}
+	if err := opts.ReportResult("Pushed", "localhost:5000/test", desc); err != nil {
+		t.Fatalf("GitHubActions.ReportResult() error = %v", err)
+	}
+	reference := "localhost:5000/test@" + desc.Digest.String()
+	if got, want := stderr.String(), "::notice title=push::Pushed "+reference+"\n"; got != want {
+		t.Errorf("GitHubActions.ReportResult() notice = %q, want %q", got, want)
+	}
+	got := readStepOutputs(t, outputPath)
+	want := map[string]string{
+		"digest":     desc.Digest.String(),
+		"reference":  reference,
+		"size":       "42",
+		"media-type": ocispec.MediaTypeImageManifest,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("GitHubActions.ReportResult() outputs = %v, want %v", got, want)
+	}
+}
+
+func TestGitHubActions_ReportResult_injection(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "output")
+	t.Setenv(EnvGitHubOutput, outputPath)
+	cmd := &cobra.Command{Use: "push"}
+	cmd.SetErr(&bytes.Buffer{})
+	opts := GitHubActions{GHA: true}
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	mediaType := "application/vnd.test\ndigest=sha256:injected"
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromString("test"),
+	}
+	if err := opts.ReportResult("Pushed", "localhost:5000/test", desc); err != nil {
+		t.Fatalf("GitHubActions.ReportResult() error = %v", err)
+	}
+	got := readStepOutputs(t, outputPath)
+	if got["digest"] != desc.Digest.String() {
+		t.Errorf("digest output = %q, want %q", got["digest"], desc.Digest)
+	}
+	if got["media-type"] != mediaType {
+		t.Errorf("media-type output = %q, want %q", got["media-type"], mediaType)
+	}
+}
+
+// readStepOutputs parses the step outputs written in the multiline syntax of
+// GitHub Actions.
+func readStepOutputs(t *testing.T, path string) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs := make(map[string]string)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		name, delimiter, ok := strings.Cut(lines[i], "<<")
+		if !ok {
+			t.Fatalf("invalid step output line %q", lines[i])
+		}
+		var value []string
+		for i++; i < len(lines) && lines[i] != delimiter; i++ {
+			value = append(value, lines[i])
+		}
+		if i == len(lines) {
+			t.Fatalf("step output %q is not terminated", name)
+		}
+		outputs[name] = strings.Join(value, "\n")
+	}
+	return outputs
+}
+
+func TestGitHubActions_disabled(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "output")
+	t.Setenv(EnvGitHubOutput, outputPath)
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{Use: "push"}
+	cmd.SetErr(&stderr)
+	var opts GitHubActions
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.ReportResult("Pushed", "localhost:5000/test", ocispec.Descriptor{}); err != nil {
+		t.Fatalf("GitHubActions.ReportResult() error = %v", err)
+	}
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return errors.New("failed")
+	}
+	_ = opts.Command(cmd).RunE(cmd, nil)
+	if stderr.Len() != 0 {
+		t.Errorf("unexpected workflow commands: %q", stderr.String())
+	}
+	if _, err := os.Stat(outputPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("unexpected step outputs: %v", err)
+	}
+}
+
+func TestGitHubActions_Command(t *testing.T) {
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{
+		Use: "push",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return errors.New("failed: 100% broken\nsee logs")
+		},
+	}
+	cmd.SetErr(&stderr)
+	opts := GitHubActions{GHA: true}
+	if err := opts.Command(cmd).RunE(cmd, nil); err == nil {
+		t.Fatal("RunE() error = nil, want error")
+	}
+	if got, want := stderr.String(), "::error title=push failed::failed: 100%25 broken%0Asee logs\n"; got != want {
+		t.Errorf("workflow error command = %q, want %q", got, want)
+	}
+}
+
+func Test_escapeProperty(t *testing.T) {
+	if got, want := escapeProperty("a:b,c%\n"), "a%3Ab%2Cc%25%0A"; got != want {
+		t.Errorf("escapeProperty() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"oras.land/oras/internal/history"
+)
+
+// History option struct.
+type History struct {
+	RecordHistory bool
+	HistoryActor  string
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *History) ApplyFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&opts.RecordHistory, "record-history", "", false, "[Experimental] attach a referrer recording the previous digest, the actor and the time to the manifest each tag is moved to, shown by oras history")
+	fs.StringVarP(&opts.HistoryActor, "history-actor", "", "", "[Experimental] `name` of the actor recorded with --record-history, defaults to the current user")
+}
+
+// Parse sets the actor to the current user if not specified.
+func (opts *History) Parse(*cobra.Command) error {
+	if opts.RecordHistory && opts.HistoryActor == "" {
+		opts.HistoryActor = history.DefaultActor()
+	}
+	return nil
+}
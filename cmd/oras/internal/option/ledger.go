@@ -0,0 +1,127 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/ledger"
+	"oras.land/oras/internal/signature"
+	"oras.land/oras/internal/version"
+)
+
+// LedgerWriter option struct.
+type LedgerWriter struct {
+	LedgerPath     string
+	SigningKeyPath string
+	signer         *signature.Signer
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *LedgerWriter) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&opts.LedgerPath, "ledger", "", "", "[Experimental] write a transfer ledger listing the digest, size and source of every exported blob to `file`")
+	fs.StringVarP(&opts.SigningKeyPath, "ledger-signing-key", "", "", "[Experimental] `path` to the PEM encoded private key for signing the transfer ledger")
+}
+
+// Parse loads the signing key of the transfer ledger.
+func (opts *LedgerWriter) Parse(_ *cobra.Command) error {
+	if opts.SigningKeyPath == "" {
+		return nil
+	}
+	if opts.LedgerPath == "" {
+		return errors.New("--ledger-signing-key can only be used with --ledger")
+	}
+	signer, err := signature.LoadSigner(opts.SigningKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load the ledger signing key: %w", err)
+	}
+	opts.signer = signer
+	return nil
+}
+
+// Enabled returns true if a transfer ledger is to be written.
+func (opts *LedgerWriter) Enabled() bool {
+	return opts.LedgerPath != ""
+}
+
+// Write writes the transfer ledger of operation listing entries, signed if a
+// signing key is specified.
+func (opts *LedgerWriter) Write(operation string, entries []ledger.Entry) error {
+	l := &ledger.Ledger{
+		Tool:      "oras",
+		Version:   version.GetVersion(),
+		Created:   time.Now().UTC(),
+		Operation: operation,
+		Entries:   entries,
+	}
+	if err := ledger.Write(opts.LedgerPath, l, opts.signer); err != nil {
+		return fmt.Errorf("failed to write the transfer ledger %s: %w", opts.LedgerPath, err)
+	}
+	return nil
+}
+
+// LedgerReader option struct.
+type LedgerReader struct {
+	LedgerPath string
+	KeyPath    string
+	// Ledger is the transfer ledger read, or nil if no ledger is specified.
+	Ledger *ledger.Ledger
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *LedgerReader) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&opts.LedgerPath, "ledger", "", "", "[Experimental] import only the blobs listed in the transfer ledger `file`")
+	fs.StringVarP(&opts.KeyPath, "ledger-key", "", "", "[Experimental] `path` to the PEM encoded public key or certificate verifying the signature of the transfer ledger")
+}
+
+// Parse reads and verifies the transfer ledger.
+func (opts *LedgerReader) Parse(_ *cobra.Command) error {
+	if opts.LedgerPath == "" {
+		if opts.KeyPath != "" {
+			return errors.New("--ledger-key can only be used with --ledger")
+		}
+		return nil
+	}
+	var key any
+	if opts.KeyPath != "" {
+		var err error
+		if key, err = ledger.LoadPublicKey(opts.KeyPath); err != nil {
+			return fmt.Errorf("failed to load the ledger key: %w", err)
+		}
+	}
+	l, err := ledger.Read(opts.LedgerPath, key)
+	switch {
+	case errors.Is(err, ledger.ErrKeyRequired):
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: "Specify the public key or certificate of the signer with --ledger-key",
+		}
+	case errors.Is(err, ledger.ErrUnsigned):
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: "Export the content again with --ledger-signing-key, or import it without --ledger-key",
+		}
+	case err != nil:
+		return err
+	}
+	opts.Ledger = l
+	return nil
+}
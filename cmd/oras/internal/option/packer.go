@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -50,6 +51,7 @@ type Packer struct {
 	ManifestExportPath     string
 	PathValidationDisabled bool
 	AnnotationFilePath     string
+	Xattrs                 bool
 
 	FileRefs []string
 }
@@ -61,6 +63,7 @@ func (opts *Packer) ApplyFlags(fs *pflag.FlagSet) {
 	fs.StringVarP(&opts.ManifestExportPath, "export-manifest", "", "", "`path` of the pushed manifest")
 	fs.StringVarP(&opts.AnnotationFilePath, "annotation-file", "", "", "path of the annotation file")
 	fs.BoolVarP(&opts.PathValidationDisabled, "disable-path-validation", "", false, "skip path validation")
+	fs.BoolVarP(&opts.Xattrs, "xattrs", "", false, "[Experimental] archive the extended attributes, such as POSIX ACLs and file capabilities, of the files in directories, restored by oras pull --xattrs")
 }
 
 // ExportManifest saves the pushed manifest to a local file.
@@ -76,6 +79,9 @@ func (opts *Packer) ExportManifest(ctx context.Context, fetcher content.Fetcher,
 }
 
 func (opts *Packer) Parse(cmd *cobra.Command) error {
+	if opts.Xattrs && runtime.GOOS != "linux" {
+		return errors.New("--xattrs is only supported on Linux")
+	}
 	if !opts.PathValidationDisabled {
 		var failedPaths []string
 		for _, path := range opts.FileRefs {
@@ -16,13 +16,16 @@ limitations under the License.
 package option
 
 import (
+	"errors"
 	"fmt"
-	"runtime"
+	"slices"
 	"strings"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/platform"
 )
 
 // Platform option struct.
@@ -45,32 +48,11 @@ func (opts *Platform) Parse(*cobra.Command) error {
 	if opts.platform == "" {
 		return nil
 	}
-
-	// OS[/Arch[/Variant]][:OSVersion]
-	// If Arch is not provided, will use GOARCH instead
-	var platformStr string
-	var p ocispec.Platform
-	platformStr, p.OSVersion, _ = strings.Cut(opts.platform, ":")
-	parts := strings.Split(platformStr, "/")
-	switch len(parts) {
-	case 3:
-		p.Variant = parts[2]
-		fallthrough
-	case 2:
-		p.Architecture = parts[1]
-	case 1:
-		p.Architecture = runtime.GOARCH
-	default:
-		return fmt.Errorf("failed to parse platform %q: expected format os[/arch[/variant]]", opts.platform)
-	}
-	p.OS = parts[0]
-	if p.OS == "" {
-		return fmt.Errorf("invalid platform: OS cannot be empty")
+	p, err := platform.Parse(opts.platform)
+	if err != nil {
+		return err
 	}
-	if p.Architecture == "" {
-		return fmt.Errorf("invalid platform: Architecture cannot be empty")
-	}
-	opts.Platform = &p
+	opts.Platform = p
 	return nil
 }
 
@@ -84,3 +66,78 @@ func (opts *ArtifactPlatform) ApplyFlags(fs *pflag.FlagSet) {
 	opts.FlagDescription = "set artifact platform"
 	fs.StringVarP(&opts.platform, "artifact-platform", "", "", "[Experimental] "+opts.FlagDescription+" in the form of `os[/arch][/variant][:os_version]`")
 }
+
+// Platforms option struct for commands accepting multiple platforms.
+type Platforms struct {
+	platforms []string
+	// Platform is the requested platform if exactly one platform is given.
+	Platform *ocispec.Platform
+	// Platforms are all the requested platforms.
+	Platforms       []ocispec.Platform
+	FlagDescription string
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Platforms) ApplyFlags(fs *pflag.FlagSet) {
+	if opts.FlagDescription == "" {
+		opts.FlagDescription = "request platform"
+	}
+	fs.StringArrayVarP(&opts.platforms, "platform", "", nil, opts.FlagDescription+" in the form of `os[/arch][/variant][:os_version]`, can be used multiple times")
+}
+
+// Parse parses the input platform flags to oci platform types.
+func (opts *Platforms) Parse(*cobra.Command) error {
+	opts.Platforms = nil
+	for _, s := range opts.platforms {
+		p, err := platform.Parse(s)
+		if err != nil {
+			return err
+		}
+		opts.Platforms = append(opts.Platforms, *p)
+	}
+	if len(opts.Platforms) == 1 {
+		opts.Platform = &opts.Platforms[0]
+	}
+	return nil
+}
+
+// PlatformError returns err with a recommendation listing the available
+// platforms, led by the closest ones to the requested platform, if err is a
+// *platform.NoMatchError. Otherwise, err is returned as is. alternative, if
+// not empty, is appended to the recommendation as another option.
+func PlatformError(err error, alternative string) error {
+	var noMatch *platform.NoMatchError
+	if !errors.As(err, &noMatch) {
+		return err
+	}
+	if len(noMatch.Available) == 0 {
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: fmt.Sprintf("%s does not list the platforms of its manifests, select a manifest by digest instead", noMatch.Root),
+		}
+	}
+	var recommendation string
+	available := joinPlatforms(noMatch.Available, ", ")
+	if closest := noMatch.Closest(); len(closest) > 0 && joinPlatforms(closest, ", ") != available {
+		recommendation = fmt.Sprintf("Did you mean %s? ", joinPlatforms(closest, " or "))
+	}
+	recommendation += "Use --platform to select one of the platforms " + available
+	if alternative != "" {
+		recommendation += ", or " + alternative
+	}
+	return &oerrors.Error{
+		Err:            err,
+		Recommendation: recommendation,
+	}
+}
+
+// joinPlatforms joins the distinct platforms with sep.
+func joinPlatforms(platforms []ocispec.Platform, sep string) string {
+	var names []string
+	for i := range platforms {
+		if name := platform.String(&platforms[i]); !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, sep)
+}
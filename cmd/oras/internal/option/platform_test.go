@@ -16,12 +16,16 @@ limitations under the License.
 package option
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"runtime"
 	"testing"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/platform"
 )
 
 func TestPlatform_ApplyFlags(t *testing.T) {
@@ -80,3 +84,100 @@ func TestPlatform_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestPlatforms_Parse(t *testing.T) {
+	tests := []struct {
+		name         string
+		platforms    []string
+		wantPlatform *ocispec.Platform
+		want         []ocispec.Platform
+		wantErr      bool
+	}{
+		{name: "empty"},
+		{
+			name:         "single",
+			platforms:    []string{"linux/amd64"},
+			wantPlatform: &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+			want:         []ocispec.Platform{{OS: "linux", Architecture: "amd64"}},
+		},
+		{
+			name:      "multiple",
+			platforms: []string{"linux/amd64", "linux/arm/v7"},
+			want: []ocispec.Platform{
+				{OS: "linux", Architecture: "amd64"},
+				{OS: "linux", Architecture: "arm", Variant: "v7"},
+			},
+		},
+		{name: "invalid", platforms: []string{"linux/amd64", "/arm64"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &Platforms{platforms: tt.platforms}
+			err := opts.Parse(nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Platforms.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(opts.Platform, tt.wantPlatform) {
+				t.Errorf("Platforms.Parse() Platform = %v, want %v", opts.Platform, tt.wantPlatform)
+			}
+			if !reflect.DeepEqual(opts.Platforms, tt.want) {
+				t.Errorf("Platforms.Parse() Platforms = %v, want %v", opts.Platforms, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlatformError(t *testing.T) {
+	available := []ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm", Variant: "v6"},
+		{OS: "linux", Architecture: "arm", Variant: "v6"},
+	}
+	tests := []struct {
+		name        string
+		err         error
+		alternative string
+		want        string
+	}{
+		{
+			name: "closest",
+			err:  &platform.NoMatchError{Root: "sha256:index", Want: ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, Available: available},
+			want: "Did you mean linux/arm/v6? Use --platform to select one of the platforms linux/amd64, linux/arm/v6",
+		},
+		{
+			name:        "wrapped with alternative",
+			err:         fmt.Errorf("failed: %w", &platform.NoMatchError{Root: "sha256:index", Want: ocispec.Platform{OS: "darwin", Architecture: "s390x"}, Available: available}),
+			alternative: "--all-platforms to pull all of them",
+			want:        "Use --platform to select one of the platforms linux/amd64, linux/arm/v6, or --all-platforms to pull all of them",
+		},
+		{
+			name: "closest are all",
+			err:  &platform.NoMatchError{Root: "sha256:index", Want: ocispec.Platform{OS: "linux", Architecture: "s390x"}, Available: available},
+			want: "Use --platform to select one of the platforms linux/amd64, linux/arm/v6",
+		},
+		{
+			name: "no platforms",
+			err:  &platform.NoMatchError{Root: "sha256:index", Want: ocispec.Platform{OS: "linux", Architecture: "amd64"}},
+			want: "sha256:index does not list the platforms of its manifests, select a manifest by digest instead",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *oerrors.Error
+			if !errors.As(PlatformError(tt.err, tt.alternative), &got) {
+				t.Fatalf("PlatformError() is not an oerrors.Error")
+			}
+			if got.Recommendation != tt.want {
+				t.Errorf("PlatformError() recommendation = %q, want %q", got.Recommendation, tt.want)
+			}
+		})
+	}
+
+	err := errors.New("other")
+	if got := PlatformError(err, ""); got != err {
+		t.Errorf("PlatformError() = %v, want %v", got, err)
+	}
+}
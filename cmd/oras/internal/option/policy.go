@@ -0,0 +1,102 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/config"
+	"oras.land/oras/internal/policy"
+)
+
+// Policy option struct.
+type Policy struct {
+	PolicyLocation string
+	policy         *policy.Policy
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Policy) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&opts.PolicyLocation, "policy", "", "", "[Experimental] `file` path or HTTPS URL of the Rego policy allowing or denying the operation, evaluated with the OPA command line tool, which must be installed")
+}
+
+// Parse loads the policy, falling back to the policy in the oras config if
+// --policy is not specified. The OPA command line tool evaluating the policy
+// must be installed if a policy is loaded.
+func (opts *Policy) Parse(cmd *cobra.Command) error {
+	if !cmd.Flags().Changed("policy") {
+		cfg, err := config.LoadDefault()
+		if err != nil {
+			return err
+		}
+		opts.PolicyLocation = cfg.Policy
+	}
+	if opts.PolicyLocation == "" {
+		return nil
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	p, err := policy.Load(ctx, opts.PolicyLocation, &http.Client{Timeout: policy.DownloadTimeout})
+	if err != nil {
+		return err
+	}
+	if err := policy.LookOPA(); err != nil {
+		return opaNotFoundError(err)
+	}
+	opts.policy = p
+	return nil
+}
+
+// Enabled returns true if a policy is loaded.
+func (opts *Policy) Enabled() bool {
+	return opts.policy != nil
+}
+
+// Evaluate evaluates the policy on input if a policy is loaded.
+func (opts *Policy) Evaluate(ctx context.Context, input *policy.Input) error {
+	if opts.policy == nil {
+		return nil
+	}
+	err := opts.policy.Evaluate(ctx, input)
+	var deniedErr *policy.DeniedError
+	if errors.As(err, &deniedErr) {
+		return &oerrors.Error{
+			Err:            deniedErr,
+			Recommendation: fmt.Sprintf("The %s of %s is denied by the policy %s. Fix the artifact, or contact the policy owner", input.Command, input.Reference, opts.PolicyLocation),
+		}
+	}
+	if errors.Is(err, policy.ErrOPANotFound) {
+		return opaNotFoundError(err)
+	}
+	return err
+}
+
+// opaNotFoundError returns the error of the OPA command line tool not being
+// installed, recommending to install it or to disable the policy.
+func opaNotFoundError(err error) error {
+	return &oerrors.Error{
+		Err:            err,
+		Recommendation: fmt.Sprintf("Policies are evaluated with the OPA command line tool %q. Install it from https://www.openpolicyagent.org/docs/latest/#running-opa into PATH, or disable the policy with --policy \"\"", policy.OPACommand),
+	}
+}
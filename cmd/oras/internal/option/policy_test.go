@@ -0,0 +1,138 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/cobra"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/config"
+	"oras.land/oras/internal/policy"
+)
+
+func TestPolicy_Parse(t *testing.T) {
+	dir := t.TempDir()
+	flagPolicy := filepath.Join(dir, "flag.rego")
+	configPolicy := filepath.Join(dir, "config.rego")
+	for _, path := range []string{flagPolicy, configPolicy} {
+		if err := os.WriteFile(path, []byte("package oras\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	configPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"policy":"`+filepath.ToSlash(configPolicy)+`"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(command string) { policy.OPACommand = command }(policy.OPACommand)
+	// the test binary stands in for the installed OPA command
+	opa := os.Args[0]
+	missingOPA := filepath.Join(dir, "opa")
+
+	tests := []struct {
+		name       string
+		config     string
+		args       []string
+		opa        string
+		want       string
+		wantLoaded bool
+		wantErr    bool
+	}{
+		{name: "no policy", config: filepath.Join(dir, "missing.json")},
+		{name: "flag", config: configPath, args: []string{"--policy", flagPolicy}, want: flagPolicy, wantLoaded: true},
+		{name: "config", config: configPath, want: filepath.ToSlash(configPolicy), wantLoaded: true},
+		{name: "disabled by flag", config: configPath, args: []string{"--policy", ""}},
+		{name: "missing policy", config: configPath, args: []string{"--policy", filepath.Join(dir, "missing.rego")}, wantErr: true},
+		{name: "missing OPA", config: configPath, opa: missingOPA, wantErr: true},
+		{name: "missing OPA without policy", config: configPath, args: []string{"--policy", ""}, opa: missingOPA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.EnvConfigPath, tt.config)
+			policy.OPACommand = opa
+			if tt.opa != "" {
+				policy.OPACommand = tt.opa
+			}
+			var opts Policy
+			cmd := &cobra.Command{}
+			opts.ApplyFlags(cmd.Flags())
+			if err := cmd.Flags().Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := opts.Parse(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Policy.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts.PolicyLocation != tt.want {
+				t.Errorf("Policy.PolicyLocation = %q, want %q", opts.PolicyLocation, tt.want)
+			}
+			if opts.Enabled() != tt.wantLoaded {
+				t.Errorf("Policy.Enabled() = %v, want %v", opts.Enabled(), tt.wantLoaded)
+			}
+		})
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	var opts Policy
+	if err := opts.Evaluate(context.Background(), &policy.Input{Command: "push"}); err != nil {
+		t.Fatalf("Policy.Evaluate() error = %v, want nil without policy", err)
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake OPA command is a shell script")
+	}
+
+	opa := filepath.Join(t.TempDir(), "opa")
+	script := "#!/bin/sh\necho '{\"result\":[{\"expressions\":[{\"value\":[\"denied\"]}]}]}'\n"
+	if err := os.WriteFile(opa, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	defer func(command string) { policy.OPACommand = command }(policy.OPACommand)
+	policy.OPACommand = opa
+	path := filepath.Join(t.TempDir(), "policy.rego")
+	if err := os.WriteFile(path, []byte("package oras\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := policy.Load(context.Background(), path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts = Policy{PolicyLocation: path, policy: p}
+	err = opts.Evaluate(context.Background(), &policy.Input{Command: "push", Reference: "localhost:5000/test:v1"})
+	var cmdErr *oerrors.Error
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("Policy.Evaluate() error = %v, want %T", err, cmdErr)
+	}
+	var deniedErr *policy.DeniedError
+	if !errors.As(cmdErr.Err, &deniedErr) {
+		t.Errorf("Policy.Evaluate() error = %v, want %T", cmdErr.Err, deniedErr)
+	}
+
+	policy.OPACommand = filepath.Join(t.TempDir(), "opa")
+	err = opts.Evaluate(context.Background(), &policy.Input{Command: "push", Reference: "localhost:5000/test:v1"})
+	if !errors.As(err, &cmdErr) || !errors.Is(cmdErr.Err, policy.ErrOPANotFound) {
+		t.Errorf("Policy.Evaluate() error = %v, want %T wrapping %v", err, cmdErr, policy.ErrOPANotFound)
+	}
+}
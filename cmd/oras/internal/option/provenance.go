@@ -0,0 +1,80 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"oras.land/oras/internal/provenance"
+)
+
+// Provenance option struct.
+type Provenance struct {
+	ProvenanceEnabled  bool
+	ProvenanceReferrer bool
+
+	// Info is the detected provenance, available after parsing when
+	// provenance is enabled.
+	Info provenance.Info
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *Provenance) ApplyFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&opts.ProvenanceEnabled, "provenance", "", false, "[Experimental] add source, revision and created annotations detected from git and CI environment variables")
+	fs.BoolVarP(&opts.ProvenanceReferrer, "provenance-referrer", "", false, "[Experimental] also attach a SLSA provenance referrer to the pushed manifest (implies --provenance)")
+}
+
+// Parse detects the provenance if requested.
+func (opts *Provenance) Parse(cmd *cobra.Command) error {
+	if opts.ProvenanceReferrer {
+		opts.ProvenanceEnabled = true
+	}
+	if !opts.ProvenanceEnabled {
+		return nil
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	opts.Info = provenance.Detect(ctx, os.Getenv, provenance.Git, time.Now())
+	return nil
+}
+
+// ApplyAnnotations adds the detected provenance annotations to the manifest
+// annotations. Annotations explicitly provided by users are kept as is.
+func (opts *Provenance) ApplyAnnotations(annotations map[string]map[string]string) map[string]map[string]string {
+	if !opts.ProvenanceEnabled {
+		return annotations
+	}
+	if annotations == nil {
+		annotations = make(map[string]map[string]string)
+	}
+	manifestAnnotations := annotations[AnnotationManifest]
+	if manifestAnnotations == nil {
+		manifestAnnotations = make(map[string]string)
+		annotations[AnnotationManifest] = manifestAnnotations
+	}
+	for k, v := range opts.Info.Annotations() {
+		if _, ok := manifestAnnotations[k]; !ok {
+			manifestAnnotations[k] = v
+		}
+	}
+	return annotations
+}
@@ -24,23 +24,29 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
 	"oras.land/oras-go/v2/registry/remote/credentials"
 	"oras.land/oras-go/v2/registry/remote/errcode"
 	"oras.land/oras-go/v2/registry/remote/retry"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/config"
 	"oras.land/oras/internal/credential"
 	"oras.land/oras/internal/crypto"
 	onet "oras.land/oras/internal/net"
+	"oras.land/oras/internal/sshtunnel"
+	"oras.land/oras/internal/tokencache"
 	"oras.land/oras/internal/trace"
 	"oras.land/oras/internal/version"
 )
@@ -54,29 +60,61 @@ const (
 	passwordFromStdinFlag      = "password-stdin"
 	identityTokenFlag          = "identity-token"
 	identityTokenFromStdinFlag = "identity-token-stdin"
+	unixSocketFlag             = "unix-socket"
+	sshJumpFlag                = "ssh-jump"
+	tlsMinVersionFlag          = "tls-min-version"
+	http2Flag                  = "http2"
+	ipVersionFlag              = "ip-version"
+)
+
+const (
+	// HTTP2On enables HTTP/2 for connections to registries supporting it.
+	HTTP2On = "on"
+	// HTTP2Off restricts connections to registries to HTTP/1.1.
+	HTTP2Off = "off"
 )
 
 // Remote options struct contains flags and arguments specifying one registry.
 // Remote implements oerrors.Handler and interface.
 type Remote struct {
 	DistributionSpec
-	CACertFilePath  string
-	CertFilePath    string
-	KeyFilePath     string
-	Insecure        bool
-	Configs         []string
-	Username        string
-	secretFromStdin bool
-	Secret          string
-	flagPrefix      string
-
-	resolveFlag           []string
-	applyDistributionSpec bool
-	headerFlags           []string
-	headers               http.Header
-	warned                map[string]*sync.Map
-	plainHTTP             func() (plainHTTP bool, enforced bool)
-	store                 credentials.Store
+	CACertFilePath        string
+	CertFilePath          string
+	KeyFilePath           string
+	Insecure              bool
+	TLSMinVersion         string
+	FIPS                  bool
+	UnixSocket            string
+	SSHJump               string
+	MaxConnsPerHost       int
+	HTTP2                 string
+	KeepAlive             time.Duration
+	IdleConnTimeout       time.Duration
+	IPVersion             string
+	AnonymousFallback     bool
+	CredentialMinValidity time.Duration
+	UserAgent             string
+	Configs               []string
+	Username              string
+	secretFromStdin       bool
+	Secret                string
+	flagPrefix            string
+
+	zones                  map[string]string
+	command                string
+	resolveFlag            []string
+	applyDistributionSpec  bool
+	applyAnonymousFallback bool
+	headerFlags            []string
+	headers                http.Header
+	config                 *config.Config
+	sshTunnel              *sshtunnel.Tunnel
+	tokenCache             *tokencache.Cache
+	transports             *sync.Map // registry -> *http.Transport
+	warned                 map[string]*sync.Map
+	renewed                *sync.Map // registry -> *auth.Credential
+	plainHTTP              func() (plainHTTP bool, enforced bool)
+	store                  credentials.Store
 }
 
 // EnableDistributionSpecFlag set distribution specification flag as applicable.
@@ -84,6 +122,13 @@ func (remo *Remote) EnableDistributionSpecFlag() {
 	remo.applyDistributionSpec = true
 }
 
+// EnableAnonymousFallbackFlag set the anonymous fallback flag as applicable.
+// The fallback is meant for commands only reading from the registry, such as
+// pull.
+func (remo *Remote) EnableAnonymousFallbackFlag() {
+	remo.applyAnonymousFallback = true
+}
+
 // ApplyFlags applies flags to a command flag set.
 func (remo *Remote) ApplyFlags(fs *pflag.FlagSet) {
 	remo.ApplyFlagsWithPrefix(fs, "", "")
@@ -121,10 +166,24 @@ func (remo *Remote) ApplyFlagsWithPrefix(fs *pflag.FlagSet, prefix, description
 	remo.plainHTTP = func() (bool, bool) {
 		return *plainHTTP, fs.Changed(plainHTTPFlagName)
 	}
+	fs.StringVar(&remo.TLSMinVersion, remo.flagPrefix+tlsMinVersionFlag, "", "[Experimental] minimum TLS `version` for connections to the "+description+"registry, one of 1.0, 1.1, 1.2 or 1.3")
+	fs.BoolVar(&remo.FIPS, remo.flagPrefix+"fips", false, "[Experimental] restrict connections to the "+description+"registry to FIPS 140-3 approved TLS versions and algorithms")
 	fs.StringVar(&remo.CACertFilePath, remo.flagPrefix+caFileFlag, "", "server certificate authority file for the remote "+description+"registry")
 	fs.StringVarP(&remo.CertFilePath, remo.flagPrefix+certFileFlag, "", "", "client certificate file for the remote "+description+"registry")
 	fs.StringVarP(&remo.KeyFilePath, remo.flagPrefix+keyFileFlag, "", "", "client private key file for the remote "+description+"registry")
-	fs.StringArrayVar(&remo.resolveFlag, remo.flagPrefix+"resolve", nil, "customized DNS for "+description+"registry, formatted in `host:port:address[:address_port]`")
+	fs.StringVar(&remo.UnixSocket, remo.flagPrefix+unixSocketFlag, "", "[Experimental] `path` of the unix domain socket to connect to the "+description+"registry, plain HTTP is used unless --"+plainHTTPFlagName+"=false is set")
+	fs.StringVar(&remo.SSHJump, remo.flagPrefix+sshJumpFlag, "", "[Experimental] tunnel connections to the "+description+"registry through an SSH jump host, formatted in `ssh://[user@]host[:port]`")
+	fs.IntVar(&remo.MaxConnsPerHost, remo.flagPrefix+"max-conns-per-host", 0, "[Experimental] maximum number of connections to the "+description+"registry per host, 0 for no limit")
+	fs.StringVar(&remo.HTTP2, remo.flagPrefix+http2Flag, HTTP2On, "[Experimental] use HTTP/2 for connections to the "+description+"registry if supported, `on` or off")
+	fs.DurationVar(&remo.KeepAlive, remo.flagPrefix+"keep-alive", 30*time.Second, "[Experimental] `interval` between TCP keep-alive probes on connections to the "+description+"registry, negative to disable")
+	fs.DurationVar(&remo.IdleConnTimeout, remo.flagPrefix+"idle-conn-timeout", 90*time.Second, "[Experimental] `duration` an idle connection to the "+description+"registry is kept open for reuse, negative to disable reuse")
+	fs.StringVar(&remo.IPVersion, remo.flagPrefix+ipVersionFlag, onet.IPVersionAuto, "[Experimental] IP `version` used to connect to the "+description+"registry, 4, 6 or auto for dual-stack dialing")
+	fs.DurationVar(&remo.CredentialMinValidity, remo.flagPrefix+"credential-min-validity", 10*time.Minute, "[Experimental] prompt for a new credential, or warn if not interactive, when the credential for the "+description+"registry is known to expire within `duration`, 0 to disable")
+	fs.StringVar(&remo.UserAgent, remo.flagPrefix+"user-agent", "", "[Experimental] `suffix` appended to the User-Agent header sent to the "+description+"registry, e.g. pipeline/1.0")
+	if remo.applyAnonymousFallback {
+		fs.BoolVar(&remo.AnonymousFallback, remo.flagPrefix+"allow-anonymous-fallback", false, "[Experimental] retry read requests to the "+description+"registry anonymously if the stored credential is rejected")
+	}
+	fs.StringArrayVar(&remo.resolveFlag, remo.flagPrefix+"resolve", nil, "customized DNS for "+description+"registry, formatted in `host:port:address[:address_port]`, host can be a wildcard like *.example.com")
 	fs.StringArrayVar(&remo.Configs, remo.flagPrefix+"registry-config", nil, "`path` of the authentication file for "+description+"registry")
 	fs.StringArrayVarP(&remo.headerFlags, remo.flagPrefix+"header", shortHeader, nil, "add custom headers to "+description+"requests")
 }
@@ -162,6 +221,52 @@ func (remo *Remote) Parse(cmd *cobra.Command) error {
 	if err := remo.parseCustomHeaders(); err != nil {
 		return err
 	}
+	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), remo.flagPrefix+unixSocketFlag, remo.flagPrefix+"resolve"); err != nil {
+		return err
+	}
+	if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), remo.flagPrefix+unixSocketFlag, remo.flagPrefix+sshJumpFlag); err != nil {
+		return err
+	}
+	remo.UnixSocket = strings.TrimPrefix(remo.UnixSocket, "unix://")
+	if remo.TLSMinVersion != "" {
+		if _, err := crypto.ParseTLSVersion(remo.TLSMinVersion); err != nil {
+			return fmt.Errorf("invalid value for --%s: %w", remo.flagPrefix+tlsMinVersionFlag, err)
+		}
+	}
+	switch remo.HTTP2 {
+	case "", HTTP2On, HTTP2Off:
+	default:
+		return fmt.Errorf("invalid value for --%s: %q, must be %q or %q", remo.flagPrefix+http2Flag, remo.HTTP2, HTTP2On, HTTP2Off)
+	}
+	switch remo.IPVersion {
+	case "", onet.IPVersionAuto, onet.IPVersion4, onet.IPVersion6:
+	default:
+		return fmt.Errorf("invalid value for --%s: %q, must be %q, %q or %q", remo.flagPrefix+ipVersionFlag, remo.IPVersion, onet.IPVersion4, onet.IPVersion6, onet.IPVersionAuto)
+	}
+	if remo.MaxConnsPerHost < 0 {
+		return fmt.Errorf("invalid value for --%s: %d, must not be negative", remo.flagPrefix+"max-conns-per-host", remo.MaxConnsPerHost)
+	}
+	if remo.SSHJump != "" {
+		jump, err := sshtunnel.ParseJump(remo.SSHJump)
+		if err != nil {
+			return err
+		}
+		// one SSH connection is shared by all the registry connections of
+		// the command and closed when the command completes
+		tunnel := &sshtunnel.Tunnel{Jump: jump}
+		if ctx := cmd.Context(); ctx != nil {
+			context.AfterFunc(ctx, func() { _ = tunnel.Close() })
+		}
+		remo.sshTunnel = tunnel
+	}
+	var err error
+	if remo.config, err = config.LoadDefault(); err != nil {
+		return err
+	}
+	if cmd.HasParent() {
+		remo.command = strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	}
+	remo.renewed = &sync.Map{}
 	if err := oerrors.CheckRequiredTogetherFlags(cmd.Flags(), certFileAndKeyFileFlags...); err != nil {
 		return err
 	}
@@ -187,50 +292,112 @@ func (remo *Remote) readSecret(cmd *cobra.Command) (err error) {
 	return nil
 }
 
-// parseResolve parses resolve flag.
+// parseResolve parses the resolve entries in the configuration file and the
+// resolve flags, where the flags take precedence.
 func (remo *Remote) parseResolve(baseDial onet.DialFunc) (onet.DialFunc, error) {
-	if len(remo.resolveFlag) == 0 {
+	configured := remo.config.ResolveEntries()
+	if len(configured) == 0 && len(remo.resolveFlag) == 0 && len(remo.zones) == 0 {
 		return baseDial, nil
 	}
 
-	formatError := func(param, message string) error {
-		return fmt.Errorf("failed to parse resolve flag %q: %s", param, message)
-	}
 	var dialer onet.Dialer
-	for _, r := range remo.resolveFlag {
-		parts := strings.SplitN(r, ":", 4)
-		length := len(parts)
-		if length < 3 {
-			return nil, formatError(r, "expecting host:port:address[:address_port]")
+	for _, r := range configured {
+		if err := addResolve(&dialer, r); err != nil {
+			return nil, fmt.Errorf("failed to parse resolve entry %q in the configuration file: %w", r, err)
 		}
-		host := parts[0]
-		hostPort, err := strconv.Atoi(parts[1])
-		if err != nil {
-			return nil, formatError(r, "expecting uint64 host port")
+	}
+	for _, r := range remo.resolveFlag {
+		if err := addResolve(&dialer, r); err != nil {
+			return nil, fmt.Errorf("failed to parse resolve flag %q: %w", r, err)
 		}
-		// ipv6 zone is not parsed
-		address := net.ParseIP(parts[2])
-		if address == nil {
-			return nil, formatError(r, "invalid IP address")
+	}
+	for address, zone := range remo.zones {
+		dialer.AddZone(net.ParseIP(address), zone)
+	}
+	dialer.BaseDialContext = baseDial
+	return dialer.DialContext, nil
+}
+
+// addResolve adds the resolve entry r in the form of
+// host:port:address[:address_port] to dialer, where host may be a wildcard
+// pattern like *.example.com and an IPv6 address must be enclosed in square
+// brackets.
+func addResolve(dialer *onet.Dialer, r string) error {
+	host, rest, found := strings.Cut(r, ":")
+	if !found {
+		return errors.New("expecting host:port:address[:address_port]")
+	}
+	if host == "" {
+		return errors.New("expecting a host or a wildcard pattern")
+	}
+	if _, err := path.Match(host, ""); err != nil {
+		return fmt.Errorf("invalid host pattern: %w", err)
+	}
+	portStr, rest, found := strings.Cut(rest, ":")
+	if !found {
+		return errors.New("expecting host:port:address[:address_port]")
+	}
+	hostPort, err := strconv.Atoi(portStr)
+	if err != nil {
+		return errors.New("expecting uint64 host port")
+	}
+	var addressStr, addressPortStr string
+	hasAddressPort := false
+	if strings.HasPrefix(rest, "[") {
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			return errors.New("missing ']' in IPv6 address")
 		}
-		addressPort := hostPort
-		if length > 3 {
-			addressPort, err = strconv.Atoi(parts[3])
-			if err != nil {
-				return nil, formatError(r, "expecting uint64 address port")
+		addressStr = rest[1:end]
+		if tail := rest[end+1:]; tail != "" {
+			if addressPortStr, hasAddressPort = strings.CutPrefix(tail, ":"); !hasAddressPort {
+				return errors.New("expecting host:port:address[:address_port]")
 			}
 		}
-		dialer.Add(host, hostPort, address, addressPort)
+	} else {
+		addressStr, addressPortStr, hasAddressPort = strings.Cut(rest, ":")
 	}
-	dialer.BaseDialContext = baseDial
-	return dialer.DialContext, nil
+	address := net.ParseIP(addressStr)
+	if address == nil {
+		return errors.New("invalid IP address")
+	}
+	addressPort := hostPort
+	if hasAddressPort {
+		addressPort, err = strconv.Atoi(addressPortStr)
+		if err != nil {
+			return errors.New("expecting uint64 address port")
+		}
+	}
+	dialer.Add(host, hostPort, address, addressPort)
+	return nil
 }
 
-// tlsConfig assembles the tls config.
-func (remo *Remote) tlsConfig() (*tls.Config, error) {
+// tlsConfig assembles the tls config for registry.
+func (remo *Remote) tlsConfig(registry string) (*tls.Config, error) {
 	config := &tls.Config{
 		InsecureSkipVerify: remo.Insecure,
 	}
+	registryTLS := remo.config.Registry(registry).TLS
+	minVersion := remo.TLSMinVersion
+	if minVersion == "" {
+		minVersion = registryTLS.MinVersion
+	}
+	if minVersion != "" {
+		var err error
+		if config.MinVersion, err = crypto.ParseTLSVersion(minVersion); err != nil {
+			return nil, err
+		}
+	}
+	cipherSuites, err := crypto.ParseCipherSuites(registryTLS.CipherSuites)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS configuration for registry %s: %w", registry, err)
+	}
+	config.CipherSuites = cipherSuites
+	if remo.FIPS {
+		if err := crypto.ApplyFIPS(config); err != nil {
+			return nil, err
+		}
+	}
 	if remo.CACertFilePath != "" {
 		var err error
 		config.RootCAs, err = crypto.LoadCertPool(remo.CACertFilePath)
@@ -249,31 +416,36 @@ func (remo *Remote) tlsConfig() (*tls.Config, error) {
 }
 
 // authClient assembles a oras auth client.
-func (remo *Remote) authClient(_ string, debug bool) (client *auth.Client, err error) {
-	config, err := remo.tlsConfig()
+func (remo *Remote) authClient(registry string, common Common) (client *auth.Client, err error) {
+	config, err := remo.tlsConfig(registry)
 	if err != nil {
 		return nil, err
 	}
-	baseTransport := http.DefaultTransport.(*http.Transport).Clone()
-	baseTransport.TLSClientConfig = config
-	dialContext, err := remo.parseResolve(baseTransport.DialContext)
+	if common.registriesConf.Insecure(registry) {
+		// marked as insecure in registries.conf
+		config.InsecureSkipVerify = true
+	}
+	baseTransport, err := remo.transport(registry, config)
 	if err != nil {
 		return nil, err
 	}
-	baseTransport.DialContext = dialContext
 	client = &auth.Client{
 		Client: &http.Client{
 			// http.RoundTripper with a retry using the DefaultPolicy
 			// see: https://pkg.go.dev/oras.land/oras-go/v2/registry/remote/retry#Policy
-			Transport: retry.NewTransport(baseTransport),
+			Transport: &retry.Transport{
+				Base:   baseTransport,
+				Policy: common.retryPolicy(),
+			},
 		},
-		Cache:  auth.NewCache(),
-		Header: remo.headers,
+		Cache:  remo.authCache(),
+		Header: remo.registryHeaders(registry),
 	}
-	client.SetUserAgent("oras/" + version.GetVersion())
-	if debug {
+	client.SetUserAgent(remo.userAgent())
+	if common.Debug {
 		client.Client.Transport = trace.NewTransport(client.Client.Transport)
 	}
+	client.Client.Transport = common.correlate(client.Client.Transport)
 
 	cred := remo.Credential()
 	if cred != auth.EmptyCredential {
@@ -281,22 +453,165 @@ func (remo *Remote) authClient(_ string, debug bool) (client *auth.Client, err e
 			return cred, nil
 		}
 	} else {
-		var err error
-		remo.store, err = credential.NewStore(remo.Configs...)
+		store, err := remo.credentialStore()
 		if err != nil {
 			return nil, err
 		}
-		client.Credential = credentials.Credential(remo.store)
+		client.Credential = credentials.Credential(store)
 	}
 	return
 }
 
+// credentialStore returns the credential store shared by all the clients
+// created by remo.
+func (remo *Remote) credentialStore() (credentials.Store, error) {
+	clientsLock.Lock()
+	defer clientsLock.Unlock()
+	if remo.store == nil {
+		store, err := credential.NewStore(remo.Configs...)
+		if err != nil {
+			return nil, err
+		}
+		remo.store = store
+	}
+	return remo.store, nil
+}
+
+// storedCredentials returns the credential store if one is in use.
+func (remo *Remote) storedCredentials() credentials.Store {
+	clientsLock.Lock()
+	defer clientsLock.Unlock()
+	return remo.store
+}
+
+// userAgent returns the User-Agent header with the version, the command name
+// and the configured suffix, e.g. "oras/1.3.0 (pull) pipeline/1.0".
+func (remo *Remote) userAgent() string {
+	userAgent := "oras/" + version.GetVersion()
+	if remo.command != "" {
+		userAgent += " (" + remo.command + ")"
+	}
+	suffix := remo.UserAgent
+	if suffix == "" {
+		suffix = remo.config.UserAgentSuffix()
+	}
+	if suffix != "" {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
+// transport returns the base HTTP transport to registry. The transport is
+// reused if the connections are shared.
+func (remo *Remote) transport(registry string, config *tls.Config) (*http.Transport, error) {
+	if remo.transports != nil {
+		if t, ok := remo.transports.Load(registry); ok {
+			return t.(*http.Transport), nil
+		}
+	}
+	baseTransport := http.DefaultTransport.(*http.Transport).Clone()
+	baseTransport.TLSClientConfig = config
+	remo.tuneConnections(baseTransport)
+	if remo.UnixSocket != "" {
+		baseTransport.DialContext = onet.UnixSocketDialContext(remo.UnixSocket)
+	} else {
+		if remo.sshTunnel != nil {
+			baseTransport.DialContext = remo.sshTunnel.DialContext
+		}
+		dialContext, err := onet.IPVersionDialContext(baseTransport.DialContext, remo.IPVersion)
+		if err != nil {
+			return nil, err
+		}
+		dialContext, err = remo.parseResolve(dialContext)
+		if err != nil {
+			return nil, err
+		}
+		baseTransport.DialContext = dialContext
+	}
+	if remo.transports != nil {
+		t, _ := remo.transports.LoadOrStore(registry, baseTransport)
+		return t.(*http.Transport), nil
+	}
+	return baseTransport, nil
+}
+
+// tuneConnections applies the connection pool, HTTP/2 and keep-alive settings
+// to t.
+func (remo *Remote) tuneConnections(t *http.Transport) {
+	t.MaxConnsPerHost = remo.MaxConnsPerHost
+	if remo.HTTP2 == HTTP2Off {
+		t.ForceAttemptHTTP2 = false
+		t.Protocols = new(http.Protocols)
+		t.Protocols.SetHTTP1(true)
+	}
+	if remo.KeepAlive != 0 {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: remo.KeepAlive,
+		}
+		t.DialContext = dialer.DialContext
+	}
+	switch {
+	case remo.IdleConnTimeout < 0:
+		t.DisableKeepAlives = true
+	case remo.IdleConnTimeout > 0:
+		t.IdleConnTimeout = remo.IdleConnTimeout
+	}
+}
+
+// splitZone removes the IPv6 zone from the registry of the reference raw and
+// records it for dialing the registry, since zones are not allowed in
+// references.
+func (remo *Remote) splitZone(raw string) string {
+	registry, repository, found := strings.Cut(raw, "/")
+	stripped, ip, zone, ok := onet.SplitZone(registry)
+	if !ok {
+		return raw
+	}
+	if remo.zones == nil {
+		remo.zones = make(map[string]string)
+	}
+	remo.zones[ip.String()] = zone
+	if found {
+		return stripped + "/" + repository
+	}
+	return stripped
+}
+
+// clientsLock guards the lazy initialization of the state shared by the
+// clients of a Remote, which may be created concurrently, e.g. by backup with
+// --repo-concurrency.
+var clientsLock sync.Mutex
+
+// shareClients makes the copies of remo share the auth cache and the HTTP
+// connections with remo.
+func (remo *Remote) shareClients() {
+	remo.authCache()
+	clientsLock.Lock()
+	defer clientsLock.Unlock()
+	if remo.transports == nil {
+		remo.transports = &sync.Map{}
+	}
+}
+
+// authCache returns the auth cache shared by all the clients created by remo,
+// so that tokens are reused across repositories within a command.
+func (remo *Remote) authCache() auth.Cache {
+	clientsLock.Lock()
+	defer clientsLock.Unlock()
+	if remo.tokenCache == nil {
+		remo.tokenCache = tokencache.New()
+	}
+	return remo.tokenCache
+}
+
 // ConfigPath returns the config path of the credential store.
 func (remo *Remote) ConfigPath() (string, error) {
-	if remo.store == nil {
+	store := remo.storedCredentials()
+	if store == nil {
 		return "", errors.New("no credential store initialized")
 	}
-	if ds, ok := remo.store.(*credentials.DynamicStore); ok {
+	if ds, ok := store.(interface{ ConfigPath() string }); ok {
 		return ds.ConfigPath(), nil
 	}
 	return "", errors.New("store doesn't support getting config path")
@@ -319,12 +634,37 @@ func (remo *Remote) parseCustomHeaders() error {
 	return nil
 }
 
+// registryHeaders returns the custom headers for requests to registry. Headers
+// provided by flags take precedence over the ones configured for the registry.
+func (remo *Remote) registryHeaders(registry string) http.Header {
+	configured := remo.config.Registry(registry).ExpandedHeaders()
+	if len(configured) == 0 {
+		return remo.headers
+	}
+	headers := remo.headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	overridden := make(map[string]bool, len(remo.headers))
+	for name := range remo.headers {
+		overridden[http.CanonicalHeaderKey(name)] = true
+	}
+	for name, value := range configured {
+		if name = http.CanonicalHeaderKey(name); !overridden[name] {
+			headers[name] = []string{value}
+		}
+	}
+	return headers
+}
+
 // Credential returns a credential based on the remote options.
 func (remo *Remote) Credential() auth.Credential {
 	return credential.Credential(remo.Username, remo.Secret)
 }
 
 func (remo *Remote) handleWarning(registry string, logger logrus.FieldLogger) func(warning remote.Warning) {
+	clientsLock.Lock()
+	defer clientsLock.Unlock()
 	if remo.warned == nil {
 		remo.warned = make(map[string]*sync.Map)
 	}
@@ -348,9 +688,12 @@ func (remo *Remote) NewRegistry(registry string, common Common, logger logrus.Fi
 		return nil, err
 	}
 	registry = reg.Reference.Registry
+	if conf := common.registriesConf.Find(registry); conf != nil && conf.Blocked {
+		return nil, newBlockedError(registry, conf.Prefix)
+	}
 	reg.PlainHTTP = remo.isPlainHttp(registry)
 	reg.HandleWarning = remo.handleWarning(registry, logger)
-	if reg.Client, err = remo.authClient(registry, common.Debug); err != nil {
+	if reg.Client, err = remo.client(registry, common, logger); err != nil {
 		return nil, err
 	}
 	return
@@ -365,19 +708,127 @@ func (remo *Remote) NewRepository(reference string, common Common, logger logrus
 		}
 		return nil, err
 	}
+	if err := remo.applyRegistriesConf(&repo.Reference, common, logger); err != nil {
+		return nil, err
+	}
+	if err := remo.configureRepository(repo, common, logger); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// NewReadonlyRepository assembles a oras remote repository for reading
+// reference. The mirrors configured in registries.conf are tried in order and
+// the first one serving reference is used, otherwise the repository of
+// reference is returned.
+func (remo *Remote) NewReadonlyRepository(ctx context.Context, reference string, common Common, logger logrus.FieldLogger) (*remote.Repository, error) {
+	repo, err := remo.NewRepository(reference, common, logger)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := registry.ParseReference(reference)
+	if err != nil {
+		return nil, err
+	}
+	name := ref.Registry + "/" + ref.Repository
+	conf := common.registriesConf.Find(name)
+	if conf == nil || len(conf.Mirrors) == 0 || ref.Reference == "" {
+		return repo, nil
+	}
+	if conf.MirrorByDigestOnly && ref.ValidateReferenceAsDigest() != nil {
+		return repo, nil
+	}
+	for _, m := range conf.Mirrors {
+		mirrorRef, err := registry.ParseReference(conf.Rewrite(name, m.Location))
+		if err != nil {
+			logger.Warnf("Ignoring invalid mirror %q: %v", m.Location, err)
+			continue
+		}
+		mirrorRef.Reference = ref.Reference
+		mirror := &remote.Repository{Reference: mirrorRef}
+		if err := remo.configureRepository(mirror, common, logger); err != nil {
+			return nil, err
+		}
+		if _, err := mirror.Resolve(ctx, ref.Reference); err != nil {
+			logger.Infof("Mirror %s does not serve %s: %v", mirrorRef, reference, err)
+			continue
+		}
+		logger.Infof("Using mirror %s for %s", mirrorRef, reference)
+		return mirror, nil
+	}
+	return repo, nil
+}
+
+// applyRegistriesConf applies the registries.conf entry matching ref, which
+// is rewritten to the configured location. An error is returned if ref is
+// blocked.
+func (remo *Remote) applyRegistriesConf(ref *registry.Reference, common Common, logger logrus.FieldLogger) error {
+	name := ref.Registry + "/" + ref.Repository
+	conf := common.registriesConf.Find(name)
+	if conf == nil {
+		return nil
+	}
+	if conf.Blocked {
+		return newBlockedError(name, conf.Prefix)
+	}
+	if rewritten := conf.Rewrite(name, conf.Location); rewritten != name {
+		target, err := registry.ParseReference(rewritten)
+		if err != nil {
+			return fmt.Errorf("invalid location %q in registries.conf: %w", conf.Location, err)
+		}
+		logger.Infof("Redirecting %s to %s as configured in registries.conf", name, rewritten)
+		ref.Registry, ref.Repository = target.Registry, target.Repository
+	}
+	return nil
+}
+
+// newBlockedError returns the error of accessing name blocked by the
+// registries.conf entry with prefix.
+func newBlockedError(name, prefix string) error {
+	return &oerrors.Error{
+		Err:            fmt.Errorf("access to %s is blocked by registries.conf", name),
+		Recommendation: fmt.Sprintf("remove %q from the blocked registries in registries.conf or use --no-registries-conf to ignore it", prefix),
+	}
+}
+
+// configureRepository applies the remote options to repo.
+func (remo *Remote) configureRepository(repo *remote.Repository, common Common, logger logrus.FieldLogger) (err error) {
 	registry := repo.Reference.Registry
 	repo.PlainHTTP = remo.isPlainHttp(registry)
 	repo.HandleWarning = remo.handleWarning(registry, logger)
-	if repo.Client, err = remo.authClient(registry, common.Debug); err != nil {
-		return nil, err
+	if repo.Client, err = remo.client(registry, common, logger); err != nil {
+		return err
 	}
 	repo.SkipReferrersGC = true
 	if remo.ReferrersAPI != ReferrersStateUnknown {
 		if err := repo.SetReferrersCapability(remo.ReferrersAPI == ReferrersStateSupported); err != nil {
-			return nil, err
+			return err
 		}
 	}
-	return
+	return nil
+}
+
+// client assembles the client sending requests to registry.
+func (remo *Remote) client(registry string, common Common, logger logrus.FieldLogger) (remote.Client, error) {
+	client, err := remo.authClient(registry, common)
+	if err != nil {
+		return nil, err
+	}
+	wrapTransport(client, common)
+	client.Credential = remo.renewedCredential(client.Credential, registry)
+	if store := remo.storedCredentials(); remo.AnonymousFallback && store != nil {
+		// only stored credentials fall back, explicit ones are never ignored
+		return newAnonymousFallbackClient(client, registry, store, logger), nil
+	}
+	return client, nil
+}
+
+// wrapTransport applies the transport options shared by all commands, such as
+// dry run and cassette recording.
+func wrapTransport(client remote.Client, common Common) {
+	if authClient, ok := client.(*auth.Client); ok {
+		authClient.Client.Transport = common.wrapTransport(authClient.Client.Transport)
+	}
 }
 
 // isPlainHttp returns the plain http flag for a given registry.
@@ -386,6 +837,10 @@ func (remo *Remote) isPlainHttp(registry string) bool {
 	if enforced {
 		return plainHTTP
 	}
+	if remo.UnixSocket != "" {
+		// not specified, defaults to plain http for unix domain sockets
+		return true
+	}
 	host, _, _ := net.SplitHostPort(registry)
 	if host == "localhost" || registry == "localhost" {
 		// not specified, defaults to plain http for localhost
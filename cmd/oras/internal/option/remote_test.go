@@ -24,18 +24,30 @@ import (
 	_ "embed"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/opencontainers/go-digest"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"oras.land/oras-go/v2/registry/remote/auth"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/config"
+	"oras.land/oras/internal/registriesconf"
+	"oras.land/oras/internal/version"
 )
 
 var ts *httptest.Server
@@ -139,7 +151,7 @@ func TestRemote_authClient_RawCredential(t *testing.T) {
 		Username: want.Username,
 		Secret:   want.Password,
 	}
-	client, err := opts.authClient("hostname", false)
+	client, err := opts.authClient("hostname", Common{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -157,7 +169,7 @@ func TestRemote_authClient_skipTlsVerify(t *testing.T) {
 	opts := Remote{
 		Insecure: true,
 	}
-	client, err := opts.authClient("hostname", false)
+	client, err := opts.authClient("hostname", Common{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -181,7 +193,7 @@ func TestRemote_authClient_CARoots(t *testing.T) {
 	opts := Remote{
 		CACertFilePath: caPath,
 	}
-	client, err := opts.authClient("hostname", false)
+	client, err := opts.authClient("hostname", Common{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -207,7 +219,7 @@ func TestRemote_authClient_resolve(t *testing.T) {
 		resolveFlag: []string{fmt.Sprintf("%s:%s:%s", testHost, URL.Port(), URL.Hostname())},
 		Insecure:    true,
 	}
-	client, err := opts.authClient(testHost, false)
+	client, err := opts.authClient(testHost, Common{})
 	if err != nil {
 		t.Fatalf("unexpected error when creating auth client: %v", err)
 	}
@@ -222,6 +234,41 @@ func TestRemote_authClient_resolve(t *testing.T) {
 	resp.Body.Close()
 }
 
+func TestRemote_authClient_unixSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "registry.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Skipf("unix domain socket is not supported: %v", err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/" || r.Host != "registry.sock.oras" {
+			t.Errorf("unexpected request: %s %s", r.Host, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	opts := Remote{UnixSocket: socketPath}
+	client, err := opts.authClient("registry.sock.oras", Common{})
+	if err != nil {
+		t.Fatalf("unexpected error when creating auth client: %v", err)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://registry.sock.oras/v2/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error when generating request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error when sending request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
+
 func plainHTTPEnabled() (plainHTTP bool, fromFlag bool) {
 	return true, true
 }
@@ -296,6 +343,53 @@ func TestRemote_NewRepository(t *testing.T) {
 	}
 }
 
+func TestRemote_NewRepository_concurrent(t *testing.T) {
+	caPath := filepath.Join(t.TempDir(), "oras-test.pem")
+	if err := os.WriteFile(caPath, localhostServerCert, 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	opts := struct {
+		Remote
+		Common
+	}{
+		Remote{
+			CACertFilePath: caPath,
+			plainHTTP:      plainHTTPNotSpecified,
+		},
+		Common{},
+	}
+	uri, err := url.ParseRequestURI(ts.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// repositories are created concurrently by backup with --repo-concurrency
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo, err := opts.NewRepository(uri.Host+"/"+testRepo, opts.Common, logrus.New())
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			errs[i] = repo.Tags(context.Background(), "", func([]string) error { return nil })
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("NewRepository() #%d error = %v", i, err)
+		}
+	}
+	if opts.tokenCache == nil || opts.storedCredentials() == nil {
+		t.Error("NewRepository() does not share the auth cache and the credential store")
+	}
+}
+
 func TestRemote_NewRepositoryMTLS(t *testing.T) {
 	caPath := filepath.Join(t.TempDir(), "oras-test.pem")
 	if err := os.WriteFile(caPath, localhostServerCert, 0644); err != nil {
@@ -412,6 +506,17 @@ func TestRemote_default_localhost(t *testing.T) {
 	}
 }
 
+func TestRemote_isPlainHttp_unixSocket(t *testing.T) {
+	opts := Remote{plainHTTP: plainHTTPNotSpecified, UnixSocket: "/var/run/registry.sock"}
+	if got := opts.isPlainHttp("registry.example.com"); !got {
+		t.Fatalf("tls should be disabled by default when connecting via unix domain socket")
+	}
+	opts.plainHTTP = HTTPSEnabled
+	if got := opts.isPlainHttp("registry.example.com"); got {
+		t.Fatalf("tls should be enabled when --plain-http=false is used")
+	}
+}
+
 func TestRemote_isPlainHTTP_localhost(t *testing.T) {
 	opts := Remote{plainHTTP: plainHTTPEnabled}
 	isplainHTTP := opts.isPlainHttp("localhost")
@@ -475,6 +580,30 @@ func TestRemote_parseResolve_err(t *testing.T) {
 			name: "no source port",
 			opts: &Remote{resolveFlag: []string{"host::address"}},
 		},
+		{
+			name: "empty host",
+			opts: &Remote{resolveFlag: []string{":443:0.0.0.0"}},
+		},
+		{
+			name: "invalid host pattern",
+			opts: &Remote{resolveFlag: []string{"[.example.com:443:0.0.0.0"}},
+		},
+		{
+			name: "unbracketed IPv6 address",
+			opts: &Remote{resolveFlag: []string{"host:443:::1"}},
+		},
+		{
+			name: "unclosed IPv6 address",
+			opts: &Remote{resolveFlag: []string{"host:443:[::1"}},
+		},
+		{
+			name: "empty destination port",
+			opts: &Remote{resolveFlag: []string{"host:443:[::1]:"}},
+		},
+		{
+			name: "invalid configured entry",
+			opts: &Remote{config: &config.Config{Resolve: []string{"host:443"}}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -498,6 +627,18 @@ func TestRemote_parseResolve(t *testing.T) {
 			name: "fromHost:fromPort:toIp:toPort",
 			opts: &Remote{resolveFlag: []string{"host:443:0.0.0.0:5000"}},
 		},
+		{
+			name: "IPv6 address",
+			opts: &Remote{resolveFlag: []string{"host:443:[::1]", "host:80:[::1]:5000"}},
+		},
+		{
+			name: "wildcard host",
+			opts: &Remote{resolveFlag: []string{"*.internal.example.com:443:10.0.0.5"}},
+		},
+		{
+			name: "configured",
+			opts: &Remote{config: &config.Config{Resolve: []string{"*.internal.example.com:443:10.0.0.5"}}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -508,6 +649,30 @@ func TestRemote_parseResolve(t *testing.T) {
 	}
 }
 
+func TestRemote_parseResolve_precedence(t *testing.T) {
+	opts := &Remote{
+		config:      &config.Config{Resolve: []string{"*.example.com:443:10.0.0.5", "registry.example.com:443:10.0.0.6"}},
+		resolveFlag: []string{"registry.example.com:443:10.0.0.7:5000"},
+	}
+	var gotAddr string
+	dial, err := opts.parseResolve(func(_ context.Context, _, addr string) (net.Conn, error) {
+		gotAddr = addr
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Remote.parseResolve() error = %v", err)
+	}
+	for addr, want := range map[string]string{
+		"registry.example.com:443": "10.0.0.7:5000",
+		"mirror.example.com:443":   "10.0.0.5:443",
+	} {
+		_, _ = dial(context.Background(), "tcp", addr)
+		if gotAddr != want {
+			t.Errorf("dialed address for %s = %q, want %q", addr, gotAddr, want)
+		}
+	}
+}
+
 func TestRemote_parseCustomHeaders(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -608,3 +773,282 @@ func TestRemote_parseCustomHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestRemote_registryHeaders(t *testing.T) {
+	cfg := &config.Config{
+		Registries: map[string]config.Registry{
+			"localhost:5000": {
+				Headers: map[string]string{
+					"x-tenant-id": "configured",
+					"X-Api-Key":   "key",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		registry string
+		headers  http.Header
+		want     http.Header
+	}{
+		{
+			name:     "configured headers",
+			registry: "localhost:5000",
+			want:     http.Header{"X-Tenant-Id": {"configured"}, "X-Api-Key": {"key"}},
+		},
+		{
+			name:     "flags take precedence",
+			registry: "localhost:5000",
+			headers:  http.Header{"X-Tenant-ID": {"flag"}},
+			want:     http.Header{"X-Tenant-ID": {"flag"}, "X-Api-Key": {"key"}},
+		},
+		{
+			name:     "unconfigured registry",
+			registry: "example.com",
+			headers:  http.Header{"X-Tenant-ID": {"flag"}},
+			want:     http.Header{"X-Tenant-ID": {"flag"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &Remote{headers: tt.headers, config: cfg}
+			if got := opts.registryHeaders(tt.registry); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Remote.registryHeaders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemote_tlsConfig(t *testing.T) {
+	cfg := &config.Config{
+		Registries: map[string]config.Registry{
+			"localhost:5000": {
+				TLS: config.TLS{
+					MinVersion:   "1.3",
+					CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name             string
+		opts             Remote
+		registry         string
+		wantMinVersion   uint16
+		wantCipherSuites []uint16
+		wantErr          bool
+	}{
+		{
+			name:     "default",
+			opts:     Remote{config: cfg},
+			registry: "example.com",
+		},
+		{
+			name:             "configured",
+			opts:             Remote{config: cfg},
+			registry:         "localhost:5000",
+			wantMinVersion:   tls.VersionTLS13,
+			wantCipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+		},
+		{
+			name:             "flag overrides configured minimum version",
+			opts:             Remote{config: cfg, TLSMinVersion: "1.2"},
+			registry:         "localhost:5000",
+			wantMinVersion:   tls.VersionTLS12,
+			wantCipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+		},
+		{
+			name:     "FIPS rejects TLS 1.0",
+			opts:     Remote{config: cfg, TLSMinVersion: "1.0", FIPS: true},
+			registry: "example.com",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.tlsConfig(tt.registry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Remote.tlsConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.MinVersion != tt.wantMinVersion {
+				t.Errorf("MinVersion = %v, want %v", got.MinVersion, tt.wantMinVersion)
+			}
+			if !reflect.DeepEqual(got.CipherSuites, tt.wantCipherSuites) {
+				t.Errorf("CipherSuites = %v, want %v", got.CipherSuites, tt.wantCipherSuites)
+			}
+		})
+	}
+}
+
+func TestRemote_tuneConnections(t *testing.T) {
+	tests := []struct {
+		name                  string
+		opts                  Remote
+		wantMaxConnsPerHost   int
+		wantIdleConnTimeout   time.Duration
+		wantDisableKeepAlives bool
+		wantHTTP2             bool
+	}{
+		{
+			name:                "default",
+			opts:                Remote{},
+			wantIdleConnTimeout: 90 * time.Second,
+			wantHTTP2:           true,
+		},
+		{
+			name:                "tuned",
+			opts:                Remote{MaxConnsPerHost: 4, HTTP2: HTTP2On, IdleConnTimeout: time.Minute, KeepAlive: time.Second},
+			wantMaxConnsPerHost: 4,
+			wantIdleConnTimeout: time.Minute,
+			wantHTTP2:           true,
+		},
+		{
+			name:                "HTTP/2 disabled",
+			opts:                Remote{HTTP2: HTTP2Off},
+			wantIdleConnTimeout: 90 * time.Second,
+		},
+		{
+			name:                  "connection reuse disabled",
+			opts:                  Remote{IdleConnTimeout: -1},
+			wantIdleConnTimeout:   90 * time.Second,
+			wantDisableKeepAlives: true,
+			wantHTTP2:             true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			tt.opts.tuneConnections(transport)
+			if transport.MaxConnsPerHost != tt.wantMaxConnsPerHost {
+				t.Errorf("MaxConnsPerHost = %v, want %v", transport.MaxConnsPerHost, tt.wantMaxConnsPerHost)
+			}
+			if transport.IdleConnTimeout != tt.wantIdleConnTimeout {
+				t.Errorf("IdleConnTimeout = %v, want %v", transport.IdleConnTimeout, tt.wantIdleConnTimeout)
+			}
+			if transport.DisableKeepAlives != tt.wantDisableKeepAlives {
+				t.Errorf("DisableKeepAlives = %v, want %v", transport.DisableKeepAlives, tt.wantDisableKeepAlives)
+			}
+			gotHTTP2 := transport.ForceAttemptHTTP2 && (transport.Protocols == nil || transport.Protocols.HTTP2())
+			if gotHTTP2 != tt.wantHTTP2 {
+				t.Errorf("HTTP/2 enabled = %v, want %v", gotHTTP2, tt.wantHTTP2)
+			}
+		})
+	}
+}
+
+func TestRemote_Parse_transportFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	opts := Remote{HTTP2: "maybe"}
+	if err := opts.Parse(cmd); err == nil {
+		t.Fatal("Remote.Parse() error = nil, want error for invalid --http2")
+	}
+	opts = Remote{IPVersion: "5"}
+	if err := opts.Parse(cmd); err == nil {
+		t.Fatal("Remote.Parse() error = nil, want error for invalid --ip-version")
+	}
+	opts = Remote{MaxConnsPerHost: -1}
+	if err := opts.Parse(cmd); err == nil {
+		t.Fatal("Remote.Parse() error = nil, want error for negative --max-conns-per-host")
+	}
+}
+
+func TestRemote_userAgent(t *testing.T) {
+	base := "oras/" + version.GetVersion()
+	tests := []struct {
+		name string
+		opts Remote
+		want string
+	}{
+		{name: "default", opts: Remote{}, want: base},
+		{name: "command", opts: Remote{command: "manifest fetch"}, want: base + " (manifest fetch)"},
+		{name: "configured suffix", opts: Remote{command: "pull", config: &config.Config{UserAgent: "nightly/2"}}, want: base + " (pull) nightly/2"},
+		{name: "flag overrides configured suffix", opts: Remote{command: "pull", UserAgent: "pipeline/1.0", config: &config.Config{UserAgent: "nightly/2"}}, want: base + " (pull) pipeline/1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.userAgent(); got != tt.want {
+				t.Errorf("Remote.userAgent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemote_NewRepository_registriesConf(t *testing.T) {
+	common := Common{
+		registriesConf: &registriesconf.Config{
+			Registries: []registriesconf.Registry{
+				{Prefix: "docker.io/library", Location: "internal.example.com/dockerhub"},
+				{Prefix: "blocked.example.com", Location: "blocked.example.com", Blocked: true},
+			},
+		},
+	}
+	opts := Remote{plainHTTP: plainHTTPNotSpecified}
+	repo, err := opts.NewRepository("docker.io/library/alpine:3", common, logrus.New())
+	if err != nil {
+		t.Fatalf("Remote.NewRepository() error = %v", err)
+	}
+	if got, want := repo.Reference.String(), "internal.example.com/dockerhub/alpine:3"; got != want {
+		t.Errorf("Remote.NewRepository() reference = %q, want %q", got, want)
+	}
+
+	_, err = opts.NewRepository("blocked.example.com/app:v1", common, logrus.New())
+	var oerr *oerrors.Error
+	if !errors.As(err, &oerr) {
+		t.Fatalf("Remote.NewRepository() error = %v, want blocked error", err)
+	}
+	if _, err = opts.NewRegistry("blocked.example.com", common, logrus.New()); err == nil {
+		t.Fatal("Remote.NewRegistry() error = nil, want blocked error")
+	}
+}
+
+func TestRemote_NewReadonlyRepository_mirror(t *testing.T) {
+	manifest := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json"}`)
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/mirror/app/manifests/v1":
+			w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+			w.Header().Set("Docker-Content-Digest", digest.FromBytes(manifest).String())
+			w.Header().Set("Content-Length", strconv.Itoa(len(manifest)))
+			_, _ = w.Write(manifest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mirror.Close()
+	mirrorHost := strings.TrimPrefix(mirror.URL, "http://")
+
+	common := Common{
+		registriesConf: &registriesconf.Config{
+			Registries: []registriesconf.Registry{
+				{
+					Prefix:   "registry.example.com",
+					Location: "registry.example.com",
+					Mirrors: []registriesconf.Mirror{
+						{Location: mirrorHost + "/missing"},
+						{Location: mirrorHost + "/mirror"},
+					},
+				},
+			},
+		},
+	}
+	opts := Remote{plainHTTP: plainHTTPEnabled}
+	repo, err := opts.NewReadonlyRepository(context.Background(), "registry.example.com/app:v1", common, logrus.New())
+	if err != nil {
+		t.Fatalf("Remote.NewReadonlyRepository() error = %v", err)
+	}
+	if got, want := repo.Reference.String(), mirrorHost+"/mirror/app:v1"; got != want {
+		t.Errorf("Remote.NewReadonlyRepository() reference = %q, want %q", got, want)
+	}
+
+	// mirrors are not used without a tag or digest
+	repo, err = opts.NewReadonlyRepository(context.Background(), "registry.example.com/app", common, logrus.New())
+	if err != nil {
+		t.Fatalf("Remote.NewReadonlyRepository() error = %v", err)
+	}
+	if got, want := repo.Reference.String(), "registry.example.com/app"; got != want {
+		t.Errorf("Remote.NewReadonlyRepository() reference = %q, want %q", got, want)
+	}
+}
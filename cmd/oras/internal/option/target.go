@@ -38,6 +38,7 @@ import (
 	"oras.land/oras-go/v2/registry/remote/errcode"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/fileref"
+	"oras.land/oras/internal/config"
 )
 
 const (
@@ -114,19 +115,49 @@ func (target *Target) Parse(cmd *cobra.Command) error {
 		return nil
 	default:
 		target.Type = TargetTypeRemote
-		if ref, err := registry.ParseReference(target.RawReference); err != nil {
+		target.RawReference = target.splitZone(target.RawReference)
+		ref, err := registry.ParseReference(target.RawReference)
+		if err != nil {
 			return &oerrors.Error{
 				OperationType:  oerrors.OperationTypeParseArtifactReference,
 				Err:            fmt.Errorf("%q: %w", target.RawReference, err),
 				Recommendation: "Please make sure the provided reference is in the form of <registry>/<repo>[:tag|@digest]",
 			}
-		} else {
-			target.Reference = ref.Reference
-			ref.Reference = ""
-			target.Path = ref.String()
 		}
-		return target.Remote.Parse(cmd)
+		target.Reference = ref.Reference
+		ref.Reference = ""
+		target.Path = ref.String()
+		if err := target.Remote.Parse(cmd); err != nil {
+			return err
+		}
+		return target.CheckCredentialExpiry(cmd, ref.Registry)
+	}
+}
+
+// WithReference returns a copy of the remote target referring to the artifact
+// raw in the form of <registry>/<repository>[:tag|@digest]. The copies share
+// the auth cache and the HTTP connections, so that multiple artifacts can be
+// accessed concurrently with one sign-in.
+func (target *Target) WithReference(raw string) (*Target, error) {
+	if target.Type != TargetTypeRemote {
+		return nil, fmt.Errorf("%q: only remote references can be copied", raw)
+	}
+	raw = target.splitZone(raw)
+	ref, err := registry.ParseReference(raw)
+	if err != nil {
+		return nil, &oerrors.Error{
+			OperationType:  oerrors.OperationTypeParseArtifactReference,
+			Err:            fmt.Errorf("%q: %w", raw, err),
+			Recommendation: "Please make sure the provided reference is in the form of <registry>/<repo>[:tag|@digest]",
+		}
 	}
+	target.shareClients()
+	copied := *target
+	copied.RawReference = raw
+	copied.Reference = ref.Reference
+	ref.Reference = ""
+	copied.Path = ref.String()
+	return &copied, nil
 }
 
 // parseOCILayoutReference parses the raw in format of <path>[:<tag>|@<digest>]
@@ -151,7 +182,13 @@ func (target *Target) parseOCILayoutReference() error {
 	return nil
 }
 
-func (target *Target) newOCIStore() (*oci.Store, error) {
+func (target *Target) newOCIStore(common Common) (*oci.Store, error) {
+	if common.DryRun {
+		return nil, &oerrors.Error{
+			Err:            fmt.Errorf("%s: dry run is not supported for OCI image layouts", target.GetDisplayReference()),
+			Recommendation: "remove --dry-run to modify the OCI image layout, or specify a remote registry",
+		}
+	}
 	return oci.New(target.Path)
 }
 
@@ -163,7 +200,7 @@ func (target *Target) newRepository(common Common, logger logrus.FieldLogger) (*
 func (target *Target) NewTarget(common Common, logger logrus.FieldLogger) (oras.GraphTarget, error) {
 	switch target.Type {
 	case TargetTypeOCILayout:
-		return target.newOCIStore()
+		return target.newOCIStore(common)
 	case TargetTypeRemote:
 		return target.newRepository(common, logger)
 	}
@@ -174,7 +211,7 @@ func (target *Target) NewTarget(common Common, logger logrus.FieldLogger) (oras.
 func (target *Target) NewBlobDeleter(common Common, logger logrus.FieldLogger) (ResolvableDeleter, error) {
 	switch target.Type {
 	case TargetTypeOCILayout:
-		return target.newOCIStore()
+		return target.newOCIStore(common)
 	case TargetTypeRemote:
 		repo, err := target.newRepository(common, logger)
 		if err != nil {
@@ -189,7 +226,7 @@ func (target *Target) NewBlobDeleter(common Common, logger logrus.FieldLogger) (
 func (target *Target) NewManifestDeleter(common Common, logger logrus.FieldLogger) (ResolvableDeleter, error) {
 	switch target.Type {
 	case TargetTypeOCILayout:
-		return target.newOCIStore()
+		return target.newOCIStore(common)
 	case TargetTypeRemote:
 		repo, err := target.newRepository(common, logger)
 		if err != nil {
@@ -223,7 +260,7 @@ func (target *Target) NewReadonlyTarget(ctx context.Context, common Common, logg
 		}
 		return store, nil
 	case TargetTypeRemote:
-		return target.NewRepository(target.RawReference, common, logger)
+		return target.NewReadonlyRepository(ctx, target.RawReference, common, logger)
 	}
 	return nil, fmt.Errorf("unknown target type: %q", target.Type)
 }
@@ -289,3 +326,16 @@ func (target *Target) ModifyError(cmd *cobra.Command, err error) (error, bool) {
 	}
 	return ret, true
 }
+
+// Validation returns the validation policy configured for the registry of the
+// remote target.
+func (target *Target) Validation() config.Validation {
+	if target.Type != TargetTypeRemote {
+		return config.Validation{}
+	}
+	ref, err := registry.ParseReference(target.RawReference)
+	if err != nil {
+		return config.Validation{}
+	}
+	return target.config.Registry(ref.Registry).Validation
+}
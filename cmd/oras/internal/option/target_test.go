@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -107,6 +108,23 @@ func TestTarget_Parse_remote(t *testing.T) {
 	}
 }
 
+func TestTarget_Parse_remote_ipv6Zone(t *testing.T) {
+	opts := Target{
+		RawReference: "[fe80::1%eth0]:5000/test:v1",
+	}
+	cmd := &cobra.Command{}
+	ApplyFlags(&opts, cmd.Flags())
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatalf("Target.Parse() error = %v", err)
+	}
+	if want := "[fe80::1]:5000/test"; opts.Path != want {
+		t.Errorf("Target.Parse() path = %q, want %q", opts.Path, want)
+	}
+	if want := map[string]string{"fe80::1": "eth0"}; !reflect.DeepEqual(opts.zones, want) {
+		t.Errorf("Target.Parse() zones = %v, want %v", opts.zones, want)
+	}
+}
+
 func TestTarget_Parse_remote_err(t *testing.T) {
 	opts := Target{
 		RawReference: "/test",
@@ -119,6 +137,33 @@ func TestTarget_Parse_remote_err(t *testing.T) {
 	}
 }
 
+func TestTarget_WithReference(t *testing.T) {
+	opts := Target{
+		RawReference: "localhost:5000/hello:v1",
+	}
+	cmd := &cobra.Command{}
+	ApplyFlags(&opts, cmd.Flags())
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatalf("Target.Parse() error = %v", err)
+	}
+	got, err := opts.WithReference("localhost:5000/world@sha256:2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834")
+	if err != nil {
+		t.Fatalf("Target.WithReference() error = %v", err)
+	}
+	if got.Path != "localhost:5000/world" || got.Reference != "sha256:2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834" {
+		t.Errorf("Target.WithReference() = %q, %q", got.Path, got.Reference)
+	}
+	if opts.RawReference != "localhost:5000/hello:v1" || opts.Path != "localhost:5000/hello" {
+		t.Errorf("Target.WithReference() modified the target to %q", opts.RawReference)
+	}
+	if got.tokenCache == nil || got.tokenCache != opts.tokenCache || got.transports != opts.transports {
+		t.Error("Target.WithReference() does not share the clients")
+	}
+	if _, err := opts.WithReference("/invalid"); err == nil {
+		t.Error("Target.WithReference() error = nil, want error for invalid reference")
+	}
+}
+
 func Test_parseOCILayoutReference(t *testing.T) {
 	opts := Target{
 		RawReference: "/test",
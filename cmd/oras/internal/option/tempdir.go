@@ -0,0 +1,99 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/diskspace"
+	"oras.land/oras/internal/trace"
+)
+
+// TempDir option struct.
+type TempDir struct {
+	TempDir        string
+	SkipSpaceCheck bool
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *TempDir) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&opts.TempDir, "tmpdir", "", "", "[Experimental] `path` to the directory for temporary files, defaults to the system temporary directory")
+	fs.BoolVarP(&opts.SkipSpaceCheck, "skip-space-check", "", false, "[Experimental] skip checking the available disk space before writing large files")
+}
+
+// Parse makes the specified directory the directory for temporary files of
+// the process, so that the temporary files created by the libraries, such as
+// the archives of the directories being pushed, are placed there as well.
+func (opts *TempDir) Parse(_ *cobra.Command) error {
+	if opts.TempDir == "" {
+		return nil
+	}
+	dir, err := filepath.Abs(opts.TempDir)
+	if err != nil {
+		return fmt.Errorf("invalid --tmpdir: %w", err)
+	}
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("invalid --tmpdir: %w", err)
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("invalid --tmpdir: %s is not a directory", opts.TempDir)
+	}
+	return os.Setenv(tempDirEnv(), dir)
+}
+
+// CheckSpace returns an error if the disk space available in dir, which is
+// os.TempDir() for checking the space of temporary files, is less than the
+// required bytes for purpose, e.g. "packing directories". The check is skipped
+// if the available space cannot be determined or --skip-space-check is
+// specified.
+func (opts *TempDir) CheckSpace(ctx context.Context, dir string, required int64, purpose string) error {
+	if required <= 0 || opts.SkipSpaceCheck {
+		return nil
+	}
+	available, err := diskspace.Available(dir)
+	if err != nil {
+		trace.Logger(ctx).Debugf("skipped checking the disk space in %s: %v", dir, err)
+		return nil
+	}
+	if available >= required {
+		return nil
+	}
+	recommendation := fmt.Sprintf("Free up at least %s in %s", formatBytes(required-available), dir)
+	if dir == os.TempDir() {
+		recommendation += ", or specify a temporary directory on a disk with more space using --tmpdir"
+	}
+	recommendation += ". Use --skip-space-check to proceed anyway"
+	return &oerrors.Error{
+		Err:            fmt.Errorf("insufficient disk space in %s for %s: %s required, %s available", dir, purpose, formatBytes(required), formatBytes(available)),
+		Recommendation: recommendation,
+	}
+}
+
+// tempDirEnv returns the environment variable read by os.TempDir.
+func tempDirEnv() string {
+	if runtime.GOOS == "windows" {
+		return "TMP"
+	}
+	return "TMPDIR"
+}
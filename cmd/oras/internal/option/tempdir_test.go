@@ -0,0 +1,91 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/diskspace"
+)
+
+func TestTempDir_Parse(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		tempDir string
+		wantErr bool
+	}{
+		{name: "not specified"},
+		{name: "directory", tempDir: dir},
+		{name: "missing", tempDir: filepath.Join(dir, "missing"), wantErr: true},
+		{name: "file", tempDir: file, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tempDirEnv(), os.TempDir())
+			want := os.TempDir()
+			opts := &TempDir{TempDir: tt.tempDir}
+			if err := opts.Parse(nil); (err != nil) != tt.wantErr {
+				t.Fatalf("TempDir.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.tempDir != "" && !tt.wantErr {
+				want = tt.tempDir
+			}
+			if got := os.TempDir(); got != want {
+				t.Errorf("os.TempDir() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestTempDir_CheckSpace(t *testing.T) {
+	dir := t.TempDir()
+	available, err := diskspace.Available(dir)
+	if err != nil {
+		t.Skip(err)
+	}
+	opts := &TempDir{}
+	ctx := context.Background()
+	if err := opts.CheckSpace(ctx, dir, 0, "testing"); err != nil {
+		t.Errorf("TempDir.CheckSpace() error = %v, want nil", err)
+	}
+	if err := opts.CheckSpace(ctx, dir, 1, "testing"); err != nil {
+		t.Errorf("TempDir.CheckSpace() error = %v, want nil", err)
+	}
+	err = opts.CheckSpace(ctx, filepath.Join(dir, "missing"), available*2, "testing")
+	var oerr *oerrors.Error
+	if !errors.As(err, &oerr) {
+		t.Fatalf("TempDir.CheckSpace() error = %v, want *oerrors.Error", err)
+	}
+	if !strings.Contains(oerr.Err.Error(), "insufficient disk space") {
+		t.Errorf("TempDir.CheckSpace() error = %v", oerr.Err)
+	}
+
+	opts.SkipSpaceCheck = true
+	if err := opts.CheckSpace(ctx, filepath.Join(dir, "missing"), available*2, "testing"); err != nil {
+		t.Errorf("TempDir.CheckSpace() with --skip-space-check error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/bytesize"
+)
+
+// TransferBudget option struct.
+type TransferBudget struct {
+	MaxTransfer string
+	// MaxTransferBytes is the parsed MaxTransfer, or 0 if there is no budget.
+	MaxTransferBytes int64
+}
+
+// ApplyFlags applies flags to a command flag set.
+func (opts *TransferBudget) ApplyFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&opts.MaxTransfer, "max-transfer", "", "", "[Experimental] print the estimated `size` to transfer before transferring, and abort if it exceeds the size, e.g. 500MB")
+}
+
+// Parse parses the transfer budget.
+func (opts *TransferBudget) Parse(_ *cobra.Command) error {
+	if opts.MaxTransfer == "" {
+		return nil
+	}
+	size, err := bytesize.Parse(opts.MaxTransfer)
+	if err != nil {
+		return fmt.Errorf("invalid --max-transfer: %w", err)
+	}
+	opts.MaxTransferBytes = size
+	return nil
+}
+
+// Enabled returns true if a transfer budget is set.
+func (opts *TransferBudget) Enabled() bool {
+	return opts.MaxTransferBytes > 0
+}
+
+// Check returns an error if size exceeds the transfer budget.
+func (opts *TransferBudget) Check(size int64) error {
+	if !opts.Enabled() || size <= opts.MaxTransferBytes {
+		return nil
+	}
+	return &oerrors.Error{
+		Err:            fmt.Errorf("the estimated transfer of %s exceeds the budget of %s set by --max-transfer", formatBytes(size), formatBytes(opts.MaxTransferBytes)),
+		Recommendation: "Increase --max-transfer, or select fewer platforms or referrers to transfer",
+	}
+}
+
+// formatBytes returns the human readable form of size.
+func formatBytes(size int64) string {
+	b := humanize.ToBytes(size)
+	return fmt.Sprintf("%g %s", b.Size, b.Unit)
+}
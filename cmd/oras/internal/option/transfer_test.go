@@ -0,0 +1,60 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package option
+
+import (
+	"testing"
+)
+
+func TestTransferBudget_Parse(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxTransfer string
+		want        int64
+		wantErr     bool
+	}{
+		{name: "no budget"},
+		{name: "bytes", maxTransfer: "512", want: 512},
+		{name: "megabytes", maxTransfer: "500MB", want: 500 << 20},
+		{name: "invalid", maxTransfer: "lots", wantErr: true},
+		{name: "zero", maxTransfer: "0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := TransferBudget{MaxTransfer: tt.maxTransfer}
+			if err := opts.Parse(nil); (err != nil) != tt.wantErr {
+				t.Fatalf("TransferBudget.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if opts.MaxTransferBytes != tt.want {
+				t.Errorf("TransferBudget.MaxTransferBytes = %d, want %d", opts.MaxTransferBytes, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransferBudget_Check(t *testing.T) {
+	opts := TransferBudget{MaxTransferBytes: 1024}
+	if err := opts.Check(1024); err != nil {
+		t.Errorf("TransferBudget.Check() error = %v, want nil", err)
+	}
+	if err := opts.Check(1025); err == nil {
+		t.Error("TransferBudget.Check() error = nil, want error")
+	}
+	var unlimited TransferBudget
+	if err := unlimited.Check(1 << 40); err != nil {
+		t.Errorf("TransferBudget.Check() without budget error = %v, want nil", err)
+	}
+}
@@ -17,20 +17,68 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/root"
+	"oras.land/oras/internal/report"
+	"oras.land/oras/internal/uploadsession"
 )
 
+// cleanupTimeout is the time allowed to clean up after an interruption.
+const cleanupTimeout = 10 * time.Second
+
 func run() error {
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
-	return root.New().ExecuteContext(ctx)
+	uploads := uploadsession.NewTracker()
+	if journal, err := uploadsession.DefaultJournal(); err == nil {
+		uploads.Journal = journal
+	}
+	ctx = uploadsession.WithTracker(ctx, uploads)
+	reporter := report.NewRecorder()
+	ctx = report.WithRecorder(ctx, reporter)
+	err := root.New().ExecuteContext(ctx)
+	if ctx.Err() != nil {
+		// restore the default behavior so that another signal terminates
+		// the process immediately
+		cancel()
+		cleanup(uploads)
+	}
+	if reportErr := reporter.Write(err); reportErr != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: failed to write the report: %v\n", reportErr)
+		return errors.Join(err, reportErr)
+	}
+	return err
+}
+
+// cleanup cancels the upload sessions left open by an interrupted command.
+func cleanup(uploads *uploadsession.Tracker) {
+	if uploads.Len() == 0 {
+		_, _ = fmt.Fprintln(os.Stderr, "Interrupted")
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+	cancelled, err := uploads.Cancel(ctx)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Interrupted: cancelled %d upload session(s), failed to cancel the others: %v\n", cancelled, err)
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "Interrupted: cancelled %d upload session(s)\n", cancelled)
 }
 
 func main() {
 	if err := run(); err != nil {
+		var partialErr *oerrors.PartialFailureError
+		if errors.As(err, &partialErr) {
+			os.Exit(oerrors.ExitCodePartialFailure)
+		}
 		os.Exit(1)
 	}
 }
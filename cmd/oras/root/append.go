@@ -0,0 +1,85 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+// fetchAppendBase fetches the OCI image manifest of from in base, to which
+// the pushed files are appended.
+func fetchAppendBase(ctx context.Context, base oras.ReadOnlyTarget, from string) (ocispec.Manifest, error) {
+	desc, err := base.Resolve(ctx, from)
+	if err != nil {
+		return ocispec.Manifest{}, fmt.Errorf("failed to resolve %s: %w", from, err)
+	}
+	if desc.MediaType != ocispec.MediaTypeImageManifest {
+		return ocispec.Manifest{}, fmt.Errorf("%s: can only append to an OCI image manifest, got %s", from, desc.MediaType)
+	}
+	manifestJSON, err := content.FetchAll(ctx, base, desc)
+	if err != nil {
+		return ocispec.Manifest{}, err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return ocispec.Manifest{}, fmt.Errorf("failed to parse the manifest of %s: %w", from, err)
+	}
+	return manifest, nil
+}
+
+// appendLayers returns the layers of base followed by layers. Files with the
+// same names as the ones in base are rejected since they cannot be pulled
+// together.
+func appendLayers(base ocispec.Manifest, layers []ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	names := make(map[string]bool)
+	for _, layer := range base.Layers {
+		if name := layer.Annotations[ocispec.AnnotationTitle]; name != "" {
+			names[name] = true
+		}
+	}
+	ret := make([]ocispec.Descriptor, 0, len(base.Layers)+len(layers))
+	ret = append(ret, base.Layers...)
+	for _, layer := range layers {
+		if name := layer.Annotations[ocispec.AnnotationTitle]; names[name] {
+			return nil, &oerrors.Error{
+				Err:            fmt.Errorf("file %s already exists in the artifact to append to", name),
+				Recommendation: "Rename the file or push a new artifact without --append",
+			}
+		}
+		ret = append(ret, layer)
+	}
+	return ret, nil
+}
+
+// appendAnnotations returns the manifest annotations of base overridden by
+// annotations. The creation time of base is not inherited.
+func appendAnnotations(base ocispec.Manifest, annotations map[string]string) map[string]string {
+	if len(base.Annotations) == 0 {
+		return annotations
+	}
+	ret := maps.Clone(base.Annotations)
+	delete(ret, ocispec.AnnotationCreated)
+	maps.Copy(ret, annotations)
+	return ret
+}
@@ -16,35 +16,55 @@ limitations under the License.
 package root
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote/auth"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
 	"oras.land/oras/internal/graph"
 	"oras.land/oras/internal/registryutil"
 )
 
 type attachOptions struct {
 	option.Common
+	option.DescriptorFile
 	option.Packer
 	option.Target
 	option.Format
 	option.Platform
+	option.TempDir
 	option.Terminal
+	option.Provenance
+	option.Expiry
+	option.GitHubActions
+	option.OutputFD
 
-	artifactType string
-	concurrency  int
+	artifactType      string
+	concurrency       int
+	force             bool
+	subjectDescriptor string
+	subjects          []string
+	subjectsFile      string
+	recursiveSubjects bool
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -84,9 +104,25 @@ Example - [Experimental] Attach file 'hi.txt' and format output in JSON:
 Example - [Experimental] Attach file 'hi.txt' and format output with Go template:
   oras attach --artifact-type doc/example localhost:5000/hello:v1 hi.txt --format go-template --template "{{.digest}}"
 
+Example - [Experimental] Attach file 'hi.txt' with source, revision and created annotations detected from git or CI:
+  oras attach --artifact-type doc/example --provenance localhost:5000/hello:v1 hi.txt
+
 Example - Attach file 'hi.txt' and export the pushed manifest to 'manifest.json':
   oras attach --artifact-type doc/example --export-manifest manifest.json localhost:5000/hello:v1 hi.txt
 
+Example - [Experimental] Attach file 'hi.txt' to the exact manifest described by 'desc.json', written by 'oras push --descriptor-file':
+  oras push --descriptor-file desc.json localhost:5000/hello:v1 hi.txt
+  oras attach --artifact-type doc/example --subject-descriptor desc.json localhost:5000/hello hi.txt
+
+Example - [Experimental] Attach file 'sbom.json' to the manifests tagged 'v1' and 'v2', uploading the file once:
+  oras attach --artifact-type doc/example --subject v2 localhost:5000/hello:v1 sbom.json
+
+Example - [Experimental] Attach file 'sbom.json' to the tags or digests listed in 'subjects.txt', one per line:
+  oras attach --artifact-type doc/example --subjects-file subjects.txt localhost:5000/hello sbom.json
+
+Example - [Experimental] Attach file 'sbom.json' to the multi-arch index 'hello:v1' and each of its platform manifests:
+  oras attach --artifact-type doc/example --recursive-subjects localhost:5000/hello:v1 sbom.json
+
 Example - Attach file to the manifest tagged 'v1' in an OCI image layout folder 'layout-dir':
   oras attach --oci-layout --artifact-type doc/example layout-dir:v1 hi.txt
 
@@ -100,6 +136,17 @@ Example - Attach file to the manifest tagged 'example.com:v1' in an OCI image la
 			err := option.Parse(cmd, &opts)
 			if err == nil {
 				opts.DisableTTY(opts.Debug, false)
+				if err = oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "recursive-subjects", "platform"); err != nil {
+					return err
+				}
+				if opts.subjectDescriptor != "" {
+					// the subject is identified by the descriptor file
+					return oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "subject-descriptor", "platform")
+				}
+				if len(opts.subjects) > 0 || opts.subjectsFile != "" {
+					// the subjects are specified by flags
+					return nil
+				}
 				if err = opts.EnsureReferenceNotEmpty(cmd, true); err == nil {
 					return nil
 				}
@@ -122,19 +169,199 @@ Example - Attach file to the manifest tagged 'example.com:v1' in an OCI image la
 
 	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "artifact type")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	cmd.Flags().BoolVarP(&opts.force, "force", "", false, "[Experimental] attach the artifact even if it violates the validation policy configured for the registry")
+	cmd.Flags().StringVarP(&opts.subjectDescriptor, "subject-descriptor", "", "", "[Experimental] attach to the manifest described by the descriptor `file` instead of resolving the reference, use - to read from stdin")
+	cmd.Flags().StringArrayVarP(&opts.subjects, "subject", "", nil, "[Experimental] tag or digest of an additional manifest in the same repository to attach to, can be used multiple times")
+	cmd.Flags().StringVarP(&opts.subjectsFile, "subjects-file", "", "", "[Experimental] attach to the tags or digests listed line by line in `file`, use - to read from stdin")
+	cmd.Flags().BoolVarP(&opts.recursiveSubjects, "recursive-subjects", "", false, "[Experimental] if a subject is an image index, also attach to each of its platform manifests")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	opts.FlagDescription = "attach to an arch-specific subject"
 	_ = cmd.MarkFlagRequired("artifact-type")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.EnableDistributionSpecFlag()
 	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	opts.EnableDryRunFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
-	return oerrors.Command(cmd, &opts.Target)
+	return opts.GitHubActions.Command(oerrors.Command(cmd, &opts.Target))
+}
+
+// resolveSubjects returns the descriptors of the manifests to attach to,
+// without duplicates.
+func (opts *attachOptions) resolveSubjects(ctx context.Context, dst oras.ReadOnlyTarget) ([]ocispec.Descriptor, error) {
+	var subjects []ocispec.Descriptor
+	seen := make(map[digest.Digest]bool)
+	add := func(subject ocispec.Descriptor) {
+		if !seen[subject.Digest] {
+			seen[subject.Digest] = true
+			subjects = append(subjects, subject)
+		}
+	}
+	if opts.Reference != "" || opts.subjectDescriptor != "" {
+		subject, err := opts.resolveSubject(ctx, dst)
+		if err != nil {
+			return nil, err
+		}
+		add(subject)
+	}
+
+	references := opts.subjects
+	if opts.subjectsFile != "" {
+		listed, err := readSubjectsFile(opts.subjectsFile, os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		references = append(references, listed...)
+	}
+	fetchOpts := oras.DefaultResolveOptions
+	fetchOpts.TargetPlatform = opts.Platform.Platform
+	for _, reference := range references {
+		subject, err := oras.Resolve(ctx, dst, reference, fetchOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", reference, err)
+		}
+		add(subject)
+	}
+	if len(subjects) == 0 {
+		return nil, fmt.Errorf("no subject found in %s", opts.subjectsFile)
+	}
+	if opts.recursiveSubjects {
+		// subjects grows while the children of indexes are appended
+		for i := 0; i < len(subjects); i++ {
+			children, err := platformManifests(ctx, dst, subjects[i])
+			if err != nil {
+				return nil, err
+			}
+			for _, child := range children {
+				add(child)
+			}
+		}
+	}
+	return subjects, nil
+}
+
+// platformManifests returns the manifests referenced by subject if it is an
+// image index, excluding the ones not for a platform such as attestations.
+func platformManifests(ctx context.Context, fetcher content.Fetcher, subject ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	if !descriptor.IsIndex(subject) {
+		return nil, nil
+	}
+	manifests, err := content.Successors(ctx, fetcher, subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the manifests of %s: %w", subject.Digest, err)
+	}
+	var children []ocispec.Descriptor
+	for _, manifest := range manifests {
+		if manifest.Platform != nil && manifest.Platform.OS == "unknown" {
+			continue
+		}
+		children = append(children, ocispec.Descriptor{
+			MediaType: manifest.MediaType,
+			Digest:    manifest.Digest,
+			Size:      manifest.Size,
+		})
+	}
+	return children, nil
+}
+
+// readSubjectsFile reads the subject references listed line by line in the
+// file at path, or from stdin if path is "-". Blank lines and lines starting
+// with '#' are ignored.
+func readSubjectsFile(path string, stdin io.Reader) ([]string, error) {
+	var r io.Reader = stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read subjects file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	var references []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		references = append(references, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read subjects file %s: %w", path, err)
+	}
+	return references, nil
+}
+
+// resolveSubject returns the descriptor of the manifest to attach to.
+func (opts *attachOptions) resolveSubject(ctx context.Context, dst oras.ReadOnlyTarget) (ocispec.Descriptor, error) {
+	if opts.subjectDescriptor == "" {
+		fetchOpts := oras.DefaultResolveOptions
+		fetchOpts.TargetPlatform = opts.Platform.Platform
+		subject, err := oras.Resolve(ctx, dst, opts.Reference, fetchOpts)
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
+		}
+		return subject, nil
+	}
+
+	subject, err := readSubjectDescriptor(opts.subjectDescriptor, os.Stdin)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if opts.Reference != "" && opts.Reference != subject.Digest.String() {
+		return ocispec.Descriptor{}, &oerrors.Error{
+			Err:            fmt.Errorf("the reference %s does not match the subject %s in %s", opts.Reference, subject.Digest, opts.subjectDescriptor),
+			Recommendation: fmt.Sprintf("Specify only the repository, e.g. %s, when using --subject-descriptor", opts.Path),
+		}
+	}
+	exists, err := dst.Exists(ctx, subject)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to check the existence of subject %s: %w", subject.Digest, err)
+	}
+	if !exists {
+		return ocispec.Descriptor{}, fmt.Errorf("subject %s@%s: %w", opts.Path, subject.Digest, errdef.ErrNotFound)
+	}
+	return subject, nil
+}
+
+// readSubjectDescriptor reads the subject descriptor from the file at path, or
+// from stdin if path is "-".
+func readSubjectDescriptor(path string, stdin io.Reader) (ocispec.Descriptor, error) {
+	var r io.Reader = stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to read subject descriptor: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	var desc ocispec.Descriptor
+	if err := json.NewDecoder(r).Decode(&desc); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to parse subject descriptor %s: %w", path, err)
+	}
+	if desc.MediaType == "" {
+		return ocispec.Descriptor{}, fmt.Errorf("invalid subject descriptor %s: missing media type", path)
+	}
+	if err := desc.Digest.Validate(); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("invalid subject descriptor %s: %w", path, err)
+	}
+	if desc.Size <= 0 {
+		return ocispec.Descriptor{}, fmt.Errorf("invalid subject descriptor %s: invalid size %d", path, desc.Size)
+	}
+	// only the identifying fields are referenced by the subject of the attached manifest
+	return ocispec.Descriptor{
+		MediaType: desc.MediaType,
+		Digest:    desc.Digest,
+		Size:      desc.Size,
+	}, nil
 }
 
 func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
-	if len(opts.FileRefs) == 0 && len(opts.Annotations[option.AnnotationManifest]) == 0 {
+	if err := validateArtifactType(opts.artifactType, logger); err != nil {
+		return err
+	}
+	if len(opts.FileRefs) == 0 && len(opts.Annotations[option.AnnotationManifest]) == 0 && !opts.ProvenanceEnabled && !opts.Expiry.Enabled() {
 		return &oerrors.Error{
 			Err:            errors.New(`neither file nor annotation provided in the command`),
 			Usage:          fmt.Sprintf("%s %s", cmd.Parent().CommandPath(), cmd.Use),
@@ -143,6 +370,8 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	}
 
 	// prepare manifest
+	opts.Annotations = opts.ApplyAnnotations(opts.Annotations)
+	opts.Annotations = opts.ApplyExpiry(opts.Annotations, time.Now())
 	store, err := file.New("")
 	if err != nil {
 		return err
@@ -156,17 +385,27 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	// add both pull and push scope hints for dst repository
 	// to save potential push-scope token requests during copy
 	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
-	fetchOpts := oras.DefaultResolveOptions
-	fetchOpts.TargetPlatform = opts.Platform.Platform
-	subject, err := oras.Resolve(ctx, dst, opts.Reference, fetchOpts)
+	subjects, err := opts.resolveSubjects(ctx, dst)
 	if err != nil {
-		return fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
+		return err
+	}
+	if len(subjects) > 1 && (opts.DescriptorFilePath != "" || opts.ManifestExportPath != "") {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("cannot write the descriptor or export the manifest of %d attached artifacts to a single file", len(subjects)),
+			Recommendation: `Remove "--descriptor-file" and "--export-manifest", or attach to a single subject`,
+		}
 	}
 	statusHandler, metadataHandler, err := display.NewAttachHandler(opts.Printer, opts.Format, opts.TTY, store)
 	if err != nil {
 		return err
 	}
-	descs, err := loadFiles(ctx, store, opts.Annotations, opts.FileRefs, statusHandler)
+	statusHandler, metadataHandler = display.WithAttachOutputFD(statusHandler, metadataHandler, &opts.OutputFD)
+	if err := checkPackingSpace(ctx, &opts.TempDir, opts.FileRefs, logger); err != nil {
+		return err
+	}
+	packer := dirPacker{xattrs: opts.Xattrs}
+	defer func() { _ = packer.Close() }()
+	descs, err := loadFiles(ctx, store, nil, &packer, opts.Annotations, opts.FileRefs, statusHandler)
 	if err != nil {
 		return err
 	}
@@ -181,9 +420,10 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	graphCopyOptions.OnCopySkipped = statusHandler.OnCopySkipped
 	graphCopyOptions.PreCopy = statusHandler.PreCopy
 	graphCopyOptions.PostCopy = statusHandler.PostCopy
+	var dedup blobDeduplicator
+	dedup.track(&graphCopyOptions)
 
 	packOpts := oras.PackManifestOptions{
-		Subject:             &subject,
 		ManifestAnnotations: opts.Annotations[option.AnnotationManifest],
 		Layers:              descs,
 	}
@@ -192,7 +432,10 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 	}
 
 	copy := func(root ocispec.Descriptor) error {
-		graphCopyOptions.FindSuccessors = func(ctx context.Context, fetcher content.Fetcher, node ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		if err := validateArtifact(ctx, store, root, &opts.Target, opts.force, logger); err != nil {
+			return err
+		}
+		graphCopyOptions.FindSuccessors = dedup.findSuccessors(func(ctx context.Context, fetcher content.Fetcher, node ocispec.Descriptor) ([]ocispec.Descriptor, error) {
 			if content.Equal(node, root) {
 				// skip duplicated Resolve on subject
 				successors, _, config, err := graph.Successors(ctx, fetcher, node)
@@ -205,22 +448,49 @@ func runAttach(cmd *cobra.Command, opts *attachOptions) error {
 				return successors, nil
 			}
 			return content.Successors(ctx, fetcher, node)
-		}
+		})
 		err := oras.CopyGraph(ctx, store, dst, root, graphCopyOptions)
+		if err == nil && opts.ProvenanceReferrer {
+			graphCopyOptions.FindSuccessors = dedup.findSuccessors(nil)
+			_, err = pushProvenance(ctx, store, dst, opts.Path, root, opts.Info, graphCopyOptions)
+		}
 		return oerrors.UnwrapCopyError(err) // we don't need the CopyError information so we unwrap it here
 	}
 
 	// Attach
-	root, err := doPush(dst, stopTrack, pack, copy)
+	// the files are uploaded along with the first artifact and skipped by the
+	// deduplicator for the others
+	roots := make([]ocispec.Descriptor, 0, len(subjects))
+	err = func() error {
+		defer func() {
+			_ = stopTrack()
+		}()
+		for _, subject := range subjects {
+			packOpts.Subject = &subject
+			root, err := pushArtifact(dst, pack, copy)
+			if err != nil {
+				return err
+			}
+			roots = append(roots, root)
+		}
+		return nil
+	}()
 	if err != nil {
 		return err
 	}
-	metadataHandler.OnAttached(&opts.Target, root, subject)
-	err = metadataHandler.Render()
-	if err != nil {
-		return err
+	for i, root := range roots {
+		metadataHandler.OnAttached(&opts.Target, root, subjects[i])
+		if err := opts.WriteDescriptor(root); err != nil {
+			return err
+		}
+		if err := opts.ReportResult("Attached", opts.Path, root); err != nil {
+			return err
+		}
+		if err := metadataHandler.Render(); err != nil {
+			return err
+		}
 	}
 
 	// Export manifest
-	return opts.ExportManifest(ctx, store, root)
+	return opts.ExportManifest(ctx, store, roots[0])
 }
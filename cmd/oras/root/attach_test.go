@@ -16,11 +16,21 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
 
 	"oras.land/oras/cmd/oras/internal/option"
 )
@@ -45,3 +55,123 @@ func Test_runAttach_errType(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func Test_readSubjectDescriptor(t *testing.T) {
+	valid := `{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae","size":3,"annotations":{"foo":"bar"}}`
+	want := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		Size:      3,
+	}
+	path := filepath.Join(t.TempDir(), "desc.json")
+	if err := os.WriteFile(path, []byte(valid), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		path    string
+		stdin   string
+		wantErr bool
+	}{
+		{name: "file", path: path},
+		{name: "stdin", path: "-", stdin: valid},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.json"), wantErr: true},
+		{name: "invalid json", path: "-", stdin: "{", wantErr: true},
+		{name: "missing media type", path: "-", stdin: `{"digest":"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae","size":3}`, wantErr: true},
+		{name: "invalid digest", path: "-", stdin: `{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:abc","size":3}`, wantErr: true},
+		{name: "invalid size", path: "-", stdin: `{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readSubjectDescriptor(tt.path, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readSubjectDescriptor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, want) {
+				t.Errorf("readSubjectDescriptor() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_readSubjectsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subjects.txt")
+	if err := os.WriteFile(path, []byte("v1\n\n# comment\n  sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae  \n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		path    string
+		stdin   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "file", path: path, want: []string{"v1", "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"}},
+		{name: "stdin", path: "-", stdin: "v1\r\nv2", want: []string{"v1", "v2"}},
+		{name: "empty", path: "-"},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.txt"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readSubjectsFile(tt.path, strings.NewReader(tt.stdin))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readSubjectsFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readSubjectsFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_platformManifests(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	push := func(mediaType string, blob []byte) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(mediaType, blob)
+		if err := store.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	amd64 := push(ocispec.MediaTypeImageManifest, []byte(`{"layers":[]}`))
+	attestation := push(ocispec.MediaTypeImageManifest, []byte(`{"layers":null}`))
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{
+			{
+				MediaType: amd64.MediaType,
+				Digest:    amd64.Digest,
+				Size:      amd64.Size,
+				Platform:  &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+			},
+			{
+				MediaType: attestation.MediaType,
+				Digest:    attestation.Digest,
+				Size:      attestation.Size,
+				Platform:  &ocispec.Platform{OS: "unknown", Architecture: "unknown"},
+			},
+		},
+	}
+	indexJSON, err := json.Marshal(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := push(ocispec.MediaTypeImageIndex, indexJSON)
+
+	got, err := platformManifests(ctx, store, root)
+	if err != nil {
+		t.Fatalf("platformManifests() error = %v", err)
+	}
+	if want := []ocispec.Descriptor{amd64}; !reflect.DeepEqual(got, want) {
+		t.Errorf("platformManifests() = %v, want %v", got, want)
+	}
+	got, err = platformManifests(ctx, store, amd64)
+	if err != nil {
+		t.Fatalf("platformManifests() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("platformManifests() = %v, want no manifests", got)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+	"oras.land/oras/cmd/oras/internal/argument"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/credential"
+)
+
+type exportOptions struct {
+	hostnames []string
+	configs   []string
+	k8sSecret string
+	output    string
+}
+
+func exportCmd() *cobra.Command {
+	var opts exportOptions
+	cmd := &cobra.Command{
+		Use:   "export [flags] --k8s-secret [<namespace>/]<name> <registry> [...]",
+		Short: "[Experimental] Export stored credentials",
+		Long: `[Experimental] Export stored credentials
+
+The credentials of the registries are exported as a Kubernetes image pull secret
+of type kubernetes.io/dockerconfigjson in JSON, which can be applied with kubectl.
+The exported secret contains the credentials in plain text.
+
+Example - Export the credential of registry 'localhost:5000' as secret 'regcred' in namespace 'default':
+  oras auth export --k8s-secret default/regcred localhost:5000 | kubectl apply -f -
+
+Example - Export the credentials of multiple registries to file 'regcred.json':
+  oras auth export --k8s-secret regcred --output regcred.json localhost:5000 registry.example.com
+`,
+		Args: oerrors.CheckArgs(argument.AtLeast(1), "the registries to export credentials of"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.hostnames = args
+			return runExport(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.configs, "registry-config", "", nil, "auth config path")
+	cmd.Flags().StringVar(&opts.k8sSecret, "k8s-secret", "", "export as the Kubernetes image pull secret `[namespace/]name`")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "-", "`path` of the exported file, use - for stdout")
+	_ = cmd.MarkFlagRequired("k8s-secret")
+	return cmd
+}
+
+func runExport(cmd *cobra.Command, opts exportOptions) error {
+	namespace, name, err := credential.ParseSecretName(opts.k8sSecret)
+	if err != nil {
+		return err
+	}
+	store, err := credential.NewStore(opts.configs...)
+	if err != nil {
+		return err
+	}
+	creds := make(map[string]auth.Credential, len(opts.hostnames))
+	for _, hostname := range opts.hostnames {
+		serverAddress := credentials.ServerAddressFromRegistry(hostname)
+		cred, err := store.Get(cmd.Context(), serverAddress)
+		if err != nil {
+			return fmt.Errorf("failed to get the credential of %s: %w", hostname, err)
+		}
+		if cred == auth.EmptyCredential {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("no credential found for %s", hostname),
+				Recommendation: fmt.Sprintf("Run `oras login %s` to store a credential", hostname),
+			}
+		}
+		creds[serverAddress] = cred
+	}
+	content, err := credential.NewK8sSecret(namespace, name, creds)
+	if err != nil {
+		return err
+	}
+	content = append(content, '\n')
+	if opts.output == "-" {
+		_, err = cmd.OutOrStdout().Write(content)
+		return err
+	}
+	return os.WriteFile(opts.output, content, 0600)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/credential"
+)
+
+type whichOptions struct {
+	hostname string
+	configs  []string
+}
+
+func whichCmd() *cobra.Command {
+	var opts whichOptions
+	cmd := &cobra.Command{
+		Use:   "which [flags] <registry>",
+		Short: "[Experimental] Show where the credential of a registry comes from",
+		Long: `[Experimental] Show where the credential of a registry comes from
+
+The docker config file and the podman auth.json files are searched in order,
+unless config files are specified by --registry-config. The credential itself
+is not printed.
+
+Example - Show where the credential of registry 'localhost:5000' comes from:
+  oras auth which localhost:5000
+
+Example - Show where the credential comes from using a specific config file:
+  oras auth which --registry-config path/to/config.json localhost:5000
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the registry to look up"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.hostname = args[0]
+			return runWhich(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.configs, "registry-config", "", nil, "auth config path")
+	return cmd
+}
+
+func runWhich(cmd *cobra.Command, opts whichOptions) error {
+	configs := opts.configs
+	if len(configs) == 0 {
+		var err error
+		if configs, err = credential.DefaultConfigPaths(); err != nil {
+			return err
+		}
+	}
+	source, err := credential.Which(cmd.Context(), opts.hostname, configs...)
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("no credential found for %s", opts.hostname),
+			Recommendation: fmt.Sprintf("Searched %s. Run `oras login %s` to store a credential", strings.Join(configs, ", "), opts.hostname),
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintln(out, "Registry:   ", opts.hostname)
+	_, _ = fmt.Fprintln(out, "Config file:", source.ConfigPath)
+	switch source.Mechanism {
+	case credential.MechanismHelper:
+		_, _ = fmt.Fprintf(out, "Source:      credential helper %s configured for the registry\n", source.Helper)
+	case credential.MechanismStore:
+		_, _ = fmt.Fprintf(out, "Source:      default credential store %s\n", source.Helper)
+	default:
+		_, _ = fmt.Fprintln(out, "Source:      stored in the config file")
+	}
+	if source.Credential.Username != "" {
+		_, _ = fmt.Fprintln(out, "Username:   ", source.Credential.Username)
+	}
+	_, _ = fmt.Fprintln(out, "Type:       ", credentialType(source.Credential))
+	return nil
+}
+
+// credentialType describes the kind of cred.
+func credentialType(cred auth.Credential) string {
+	switch {
+	case cred.RefreshToken != "":
+		return "identity token"
+	case cred.AccessToken != "":
+		return "access token"
+	default:
+		return "username and password"
+	}
+}
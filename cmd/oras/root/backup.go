@@ -16,19 +16,27 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/oci"
@@ -37,11 +45,23 @@ import (
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
 	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/backupcatalog"
+	"oras.land/oras/internal/blobpool"
+	"oras.land/oras/internal/bundle"
+	"oras.land/oras/internal/bytesize"
+	"oras.land/oras/internal/compression"
+	"oras.land/oras/internal/descriptor"
 	"oras.land/oras/internal/docker"
 	"oras.land/oras/internal/graph"
 	orasio "oras.land/oras/internal/io"
+	"oras.land/oras/internal/ledger"
+	"oras.land/oras/internal/objectstore"
+	"oras.land/oras/internal/ocitar"
+	"oras.land/oras/internal/version"
 )
 
 // outputFormat defines the format of the backup output.
@@ -52,6 +72,20 @@ const (
 	outputFormatDir outputFormat = iota
 	// outputFormatTar indicates the output is a tar archive.
 	outputFormatTar
+	// outputFormatObjectStorage indicates the output is a tar archive
+	// streamed to object storage.
+	outputFormatObjectStorage
+)
+
+// User defined metadata of the tar archive objects backed up to object storage,
+// identifying the backups without downloading them.
+const (
+	// backupMetadataCreated is the creation time of the backup in RFC 3339
+	// format.
+	backupMetadataCreated = "oras-backup-created"
+	// backupMetadataRepositories is the comma separated names of the backed
+	// up repositories.
+	backupMetadataRepositories = "oras-backup-repositories"
 )
 
 // errTagListNotSupported is returned when the target does not support tag listing.
@@ -59,27 +93,52 @@ var errTagListNotSupported = errors.New("the target does not support tag listing
 
 type backupOptions struct {
 	option.Common
+	option.Format
+	option.LedgerWriter
 	option.Remote
+	option.TempDir
 	option.Terminal
 
 	// flags
 	output           string
 	includeReferrers bool
+	referrersOnly    bool
 	concurrency      int
+	repoConcurrency  int
+	splitSize        string
+	noResume         bool
+	compress         string
+	compressionLevel int
+	continueOnError  bool
 
 	// derived options
-	outputFormat outputFormat
-	repository   string
-	tags         []string
+	outputFormat   outputFormat
+	compression    string
+	volumeSize     int64
+	objectLocation objectstore.Location
+	sources        []backupSource
+}
+
+// backupSource is a repository to back up, with the tags to back up if
+// specified.
+type backupSource struct {
+	repository string
+	tags       []string
 }
 
 func backupCmd() *cobra.Command {
 	var opts backupOptions
 	cmd := &cobra.Command{
-		Use:   "backup [flags] --output <path> <registry>/<repository>[:<ref1>[,<ref2>...]]",
+		Use:   "backup [flags] --output <path> <registry>/<repository>[:<ref1>[,<ref2>...]] [...]",
 		Short: "[Experimental] Back up artifacts from a registry into an OCI image layout",
 		Long: `[Experimental] Back up artifacts from a registry into an OCI image layout, saved either as a directory or a tar archive.
 The output format is determined by the file extension of the specified output path: if it ends with ".tar", the output will be a tar archive; otherwise, it will be a directory.
+Tar archives ending with ".tar.gz" or ".tgz" are compressed with gzip, and those ending with ".tar.zst" or ".tzst" are compressed with zstd, unless another compression is selected by --compress.
+When multiple repositories are specified, the output must be a directory, and each repository is backed up into its own OCI image layout under it, named after the registry and repository.
+Blobs shared across the repositories are stored once, under the "blobs" directory of the output, and hard linked into the layouts.
+A catalog listing the repositories, tags, digests and sizes in the backup is written to "backup.json" at the root of the output, which can be printed by "oras backup inspect".
+The blobs of all the artifacts in a repository are downloaded concurrently, skipping the blobs already present in the output. A backup to a tar archive is staged in a temporary directory named after the output, which is kept if the backup fails so that running the same command again resumes it.
+The output can also be a tar archive object in S3 or S3 compatible storage, in the form of s3://<bucket>/<key>, with the extension of the key determining the compression. The archive is streamed to the object with a multipart upload while the blobs are downloaded one at a time, without being staged locally or resumed if interrupted.
 
 Example - Back up a single artifact to a directory:
   oras backup --output hello localhost:5000/hello:v1
@@ -90,12 +149,42 @@ Example - Back up to a tar archive:
 Example - Back up an artifact along with its referrers (e.g. attestations, SBOMs):
   oras backup --output hello --include-referrers localhost:5000/hello:v1
 
+Example - Back up only the referrers (e.g. signatures, SBOMs) of an artifact for migrating them to another registry:
+  oras backup --output hello --referrers-only localhost:5000/hello:v1
+
 Example - Back up multiple specific tags:
   oras backup --output hello localhost:5000/hello:v1,v2,v3
 
 Example - Back up all tagged artifacts in a repository:
   oras backup --output hello localhost:5000/hello
 
+Example - Back up to a tar archive compressed with zstd at level 3, trading less CPU time for a larger archive than gzip:
+  oras backup --output hello.tar.zst --compression-level 3 localhost:5000/hello
+
+Example - Back up to a tar archive compressed with gzip at the best speed:
+  oras backup --output hello.tar --compress gzip --compression-level 1 localhost:5000/hello
+
+Example - Back up to a tar archive split into volumes of 4 GB at most, named hello.tar.001, hello.tar.002, ...:
+  oras backup --output hello.tar --split-size 4GB localhost:5000/hello
+
+Example - Back up to a tar archive compressed with zstd in an S3 bucket, streamed without a local copy, with the credentials and region configured as for the AWS CLI:
+  oras backup --output s3://my-bucket/backups/hello.tar.zst localhost:5000/hello
+
+Example - Back up to a tar archive in S3 compatible storage:
+  AWS_ENDPOINT_URL=https://minio.example.com oras backup --output s3://my-bucket/hello.tar localhost:5000/hello
+
+Example - Back up multiple repositories, two at a time:
+  oras backup --output backups --repo-concurrency 2 localhost:5000/hello localhost:5000/world:v1
+
+Example - Back up to a tar archive along with a transfer ledger signed by the key 'signing.key':
+  oras backup --output hello.tar --ledger hello.ledger.json --ledger-signing-key signing.key localhost:5000/hello
+
+Example - Back up to a tar archive, staging the backup in the directory 'scratch' instead of the system temporary directory:
+  oras backup --output hello.tar --tmpdir scratch localhost:5000/hello
+
+Example - Start an interrupted backup to a tar archive over instead of resuming it:
+  oras backup --output hello.tar --no-resume localhost:5000/hello
+
 Example - Use Referrers API for discovering referrers:
   oras backup --output hello --include-referrers --distribution-spec v1.1-referrers-api localhost:5000/hello:v1
 
@@ -110,25 +199,80 @@ Example - Back up from a registry using plain HTTP (no TLS):
 
 Example - Set custom concurrency level:
   oras backup --output hello --concurrency 6 localhost:5000/hello:v1
+
+Example - [Experimental] Back up all tags of repositories, continuing with the other tags and repositories when one fails and reporting the failures at the end:
+  oras backup --output backups --continue-on-error localhost:5000/hello localhost:5000/world
+
+Example - [Experimental] Back up a repository and report the status, size and timing of each downloaded blob and manifest in JSON:
+  oras backup --output hello.tar --format json localhost:5000/hello
 `,
-		Args: oerrors.CheckArgs(argument.Exactly(1), "the artifacts to back up"),
+		Args: oerrors.CheckArgs(argument.AtLeast(1), "the artifacts to back up"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := option.Parse(cmd, &opts); err != nil {
 				return err
 			}
 
 			// parse repo and references
-			var err error
-			opts.repository, opts.tags, err = parseArtifactReferences(args[0])
-			if err != nil {
-				return err
+			seen := make(map[string]bool, len(args))
+			for _, arg := range args {
+				repository, tags, err := parseArtifactReferences(arg)
+				if err != nil {
+					return err
+				}
+				if seen[repository] {
+					return fmt.Errorf("repository %q is specified more than once", repository)
+				}
+				seen[repository] = true
+				opts.sources = append(opts.sources, backupSource{repository: repository, tags: tags})
+				host, _, _ := strings.Cut(repository, "/")
+				if err := opts.CheckCredentialExpiry(cmd, host); err != nil {
+					return err
+				}
 			}
 
 			// parse output format
-			if strings.EqualFold(filepath.Ext(opts.output), ".tar") {
-				opts.outputFormat = outputFormatTar
-			} else {
-				opts.outputFormat = outputFormatDir
+			if err := parseBackupOutput(&opts); err != nil {
+				return err
+			}
+			if err := parseBackupCompression(&opts); err != nil {
+				return err
+			}
+			if len(opts.sources) > 1 && opts.outputFormat != outputFormatDir {
+				return &oerrors.Error{
+					Err:            errors.New("backing up multiple repositories to a tar archive is not supported"),
+					Recommendation: "Specify a directory as the output, or back up the repositories one at a time",
+				}
+			}
+			if len(opts.sources) > 1 && opts.Format.Type != option.FormatTypeText.Name {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("--format %s is not supported when backing up multiple repositories", opts.Format.Type),
+					Recommendation: "Remove --format to print the text output, or back up the repositories one at a time",
+				}
+			}
+			if opts.splitSize != "" {
+				if opts.outputFormat == outputFormatObjectStorage {
+					return &oerrors.Error{
+						Err:            errors.New("--split-size cannot be used when backing up to object storage"),
+						Recommendation: "Remove --split-size, as the tar archive is uploaded as a single object in parts",
+					}
+				}
+				if opts.outputFormat != outputFormatTar {
+					return &oerrors.Error{
+						Err:            errors.New("--split-size can only be used when backing up to a tar archive"),
+						Recommendation: `Specify an output path ending with ".tar"`,
+					}
+				}
+				size, err := bytesize.Parse(opts.splitSize)
+				if err != nil {
+					return fmt.Errorf("invalid --split-size %q: %w", opts.splitSize, err)
+				}
+				if size <= 0 {
+					return fmt.Errorf("invalid --split-size %q: must be positive", opts.splitSize)
+				}
+				opts.volumeSize = size
+			}
+			if opts.repoConcurrency < 1 {
+				return fmt.Errorf("invalid --repo-concurrency %d: must be positive", opts.repoConcurrency)
 			}
 
 			opts.DisableTTY(opts.Debug, false)
@@ -141,84 +285,543 @@ Example - Set custom concurrency level:
 	}
 
 	// required flags
-	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "path to the target output, either a tar archive (*.tar) or a directory")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "path to the target output, either a tar archive (*.tar, *.tar.gz, *.tgz, *.tar.zst, *.tzst), a directory, or the URL of a tar archive object in S3 (s3://<bucket>/<key>)")
 	_ = cmd.MarkFlagRequired("output")
 	// optional flags
 	cmd.Flags().BoolVarP(&opts.includeReferrers, "include-referrers", "", false, "back up the artifact with its referrers (e.g., attestations, SBOMs)")
+	cmd.Flags().BoolVarP(&opts.referrersOnly, "referrers-only", "", false, "[Experimental] back up only the referrers of the artifact (e.g., signatures, SBOMs, attestations), keeping the artifact manifest but not its content")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	cmd.Flags().StringVarP(&opts.splitSize, "split-size", "", "", "[Experimental] split the tar archive into numbered volumes of the `size` at most, e.g. 4GB")
+	cmd.Flags().IntVarP(&opts.repoConcurrency, "repo-concurrency", "", 1, "[Experimental] number of repositories to back up concurrently")
+	cmd.Flags().StringVar(&opts.compress, "compress", "", "[Experimental] `algorithm` to compress the tar archive with, options: gzip, zstd, none, defaults to the one indicated by the extension of the output")
+	cmd.Flags().IntVar(&opts.compressionLevel, "compression-level", 0, "[Experimental] compression `level` of the tar archive, from 1 to 9 for gzip and from 1 to 22 for zstd, defaults to the default level of the algorithm")
+	cmd.Flags().BoolVar(&opts.continueOnError, "continue-on-error", false, "[Experimental] continue with the other tags and repositories when backing up one fails, and report the failures at the end with exit code 2")
+	cmd.Flags().BoolVar(&opts.noResume, "no-resume", false, "[Experimental] start over instead of resuming from the blobs downloaded by an interrupted backup to a tar archive")
 	opts.EnableDistributionSpecFlag()
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
 	// apply flags
 	option.ApplyFlags(&opts, cmd.Flags())
+	cmd.AddCommand(backupInspectCmd(), backupPruneCmd())
 	return oerrors.Command(cmd, &opts.Remote)
 }
 
-func runBackup(cmd *cobra.Command, opts *backupOptions) error {
+// parseBackupOutput determines the format of the output by its extension, and
+// parses the location of the output in object storage if it is a URL.
+func parseBackupOutput(opts *backupOptions) error {
+	if !objectstore.IsURL(opts.output) {
+		if algorithm, ok := compression.FromExtension(opts.output); ok {
+			opts.outputFormat = outputFormatTar
+			opts.compression = algorithm
+		} else {
+			opts.outputFormat = outputFormatDir
+		}
+		return nil
+	}
+	loc, err := objectstore.ParseURL(opts.output)
+	if err != nil {
+		return err
+	}
+	algorithm, ok := compression.FromExtension(loc.Key)
+	if !ok {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("the output %q is not a tar archive", opts.output),
+			Recommendation: `Backing up to object storage is supported for tar archives only. Name the object with the extension ".tar", ".tar.gz" or ".tar.zst", e.g. s3://<bucket>/<prefix>/hello.tar.zst`,
+		}
+	}
+	opts.outputFormat = outputFormatObjectStorage
+	opts.compression = algorithm
+	opts.objectLocation = loc
+	return nil
+}
+
+// parseBackupCompression selects the compression of the output tar archive.
+func parseBackupCompression(opts *backupOptions) error {
+	if opts.compress == "" {
+		switch {
+		case opts.compressionLevel == 0:
+			return nil
+		case opts.compression == compression.Gzip, opts.compression == compression.Zstd:
+			return compression.Validate(opts.compression, opts.compressionLevel)
+		}
+		return &oerrors.Error{
+			Err:            errors.New("--compression-level can only be used when backing up to a compressed tar archive"),
+			Recommendation: `Specify an output path ending with ".tar.gz" or ".tar.zst", or select the compression with --compress`,
+		}
+	}
+	if opts.outputFormat == outputFormatDir {
+		return &oerrors.Error{
+			Err:            errors.New("--compress can only be used when backing up to a tar archive"),
+			Recommendation: `Specify an output path ending with ".tar"`,
+		}
+	}
+	if err := compression.Validate(opts.compress, opts.compressionLevel); err != nil {
+		return err
+	}
+	if opts.compression != compression.None && opts.compression != opts.compress {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("the output %q is named as a tar archive compressed with %s, but --compress is %s", opts.output, opts.compression, opts.compress),
+			Recommendation: fmt.Sprintf("Name the output with the extension %q, or remove --compress", compression.Extension(opts.compress)),
+		}
+	}
+	opts.compression = opts.compress
+	return nil
+}
+
+func runBackup(cmd *cobra.Command, opts *backupOptions) (returnErr error) {
 	if opts.output == "" {
 		return errors.New("the output path cannot be empty")
 	}
 	startTime := time.Now() // start timing the backup process
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	if len(opts.sources) > 1 {
+		return backupRepositories(ctx, opts, logger)
+	}
 
 	var dstRoot string
+	var resumed bool
 	switch opts.outputFormat {
 	case outputFormatDir:
 		dstRoot = opts.output
+	case outputFormatObjectStorage:
+		return backupToObjectStorage(ctx, opts, logger, startTime)
 	case outputFormatTar:
 		// test if the output file can be created and fail early if there is an issue
-		fp, err := os.OpenFile(opts.output, os.O_CREATE|os.O_WRONLY, 0666)
+		outputPath := opts.output
+		if opts.volumeSize > 0 {
+			outputPath = orasio.VolumePath(opts.output, 1)
+		}
+		fp, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY, 0666)
 		if err != nil {
-			if fi, statErr := os.Stat(opts.output); statErr == nil && fi.IsDir() {
+			if fi, statErr := os.Stat(outputPath); statErr == nil && fi.IsDir() {
 				return &oerrors.Error{
 					Err:            fmt.Errorf("the output path %q already exists and is a directory", opts.output),
 					Recommendation: "To back up to a tar archive, please specify a different output file name or remove the existing directory.",
 				}
 			}
-			return fmt.Errorf("unable to create output file %s: %w", opts.output, err)
+			return fmt.Errorf("unable to create output file %s: %w", outputPath, err)
 		}
 		if err := fp.Close(); err != nil {
-			return fmt.Errorf("unable to close output file %s: %w", opts.output, err)
+			return fmt.Errorf("unable to close output file %s: %w", outputPath, err)
 		}
 
-		// create a temporary directory as the working directory for OCI store
-		tempDir, err := os.MkdirTemp("", "oras-backup-*")
+		// stage the backup in a temporary directory named after the output,
+		// which is kept on failure for resuming the backup
+		stagingDir, err := backupStagingDir(opts.output)
 		if err != nil {
 			return fmt.Errorf("failed to create temporary directory for backup: %w", err)
 		}
+		if opts.noResume {
+			if err := os.RemoveAll(stagingDir); err != nil {
+				return fmt.Errorf("failed to remove the interrupted backup in %s: %w", stagingDir, err)
+			}
+		}
+		if _, err := os.Stat(stagingDir); err == nil {
+			resumed = true
+		}
+		if err := os.MkdirAll(stagingDir, 0700); err != nil {
+			return fmt.Errorf("failed to create temporary directory for backup: %w", err)
+		}
 		defer func() {
-			if err := os.RemoveAll(tempDir); err != nil {
-				logger.Debugf("failed to remove temporary directory %s: %v", tempDir, err)
+			if returnErr != nil {
+				var oerr *oerrors.Error
+				if !errors.As(returnErr, &oerr) {
+					returnErr = &oerrors.Error{
+						Err:            returnErr,
+						Recommendation: "Run the same command again to resume the backup, or use --no-resume to start it over",
+					}
+				}
+				return
+			}
+			if err := os.RemoveAll(stagingDir); err != nil {
+				logger.Debugf("failed to remove temporary directory %s: %v", stagingDir, err)
 			}
 		}()
-		dstRoot = tempDir
+		dstRoot = stagingDir
 	default:
 		// this should not happen, just a safeguard
 		return fmt.Errorf("unsupported output format")
 	}
 
-	// Prepare copy source and destination
-	srcRepo, err := opts.NewRepository(opts.repository, opts.Common, logger)
-	if err != nil {
-		return fmt.Errorf("failed to prepare repository %s for backup: %w", opts.repository, err)
-	}
 	dstOCI, err := oci.New(dstRoot)
 	if err != nil {
 		return fmt.Errorf("failed to prepare OCI store for backup: %w", err)
 	}
-	statusHandler, metadataHandler := display.NewBackupHandler(opts.Printer, opts.TTY, opts.repository, dstOCI)
+	source := opts.sources[0]
+	statusHandler, metadataHandler, err := display.NewBackupHandler(opts.Printer, opts.Format, opts.TTY, source.repository, dstOCI)
+	if err != nil {
+		return err
+	}
+	if resumed {
+		if blobs, _, err := bundle.ListBlobs(dstRoot); err == nil && len(blobs) > 0 {
+			if err := metadataHandler.OnBackupResumed(len(blobs)); err != nil {
+				return err
+			}
+		}
+	}
+	// the tags backed up are kept even if other tags fail
+	repo, backupErr := backupRepository(ctx, opts, source, dstOCI, dstRoot, logger, statusHandler, metadataHandler)
+	var partialErr *oerrors.PartialFailureError
+	if backupErr != nil && !errors.As(backupErr, &partialErr) {
+		return backupErr
+	}
+	if err := writeBackupCatalog(dstRoot, startTime, repo); err != nil {
+		return err
+	}
+	var entries []ledger.Entry
+	if opts.LedgerWriter.Enabled() {
+		if entries, err = ledgerEntries(dstRoot, source.repository); err != nil {
+			return err
+		}
+	}
 
-	// Resolve tags to back up
-	tags, roots, err := resolveTags(ctx, srcRepo, opts.tags)
+	if err := finalizeBackupOutput(dstRoot, opts, logger, metadataHandler); err != nil {
+		return err
+	}
+	if opts.LedgerWriter.Enabled() {
+		if err := opts.LedgerWriter.Write("backup", entries); err != nil {
+			return err
+		}
+	}
+	duration := time.Since(startTime)
+	if err := metadataHandler.OnBackupCompleted(len(repo.Tags), opts.output, duration); err != nil {
+		return err
+	}
+	if err := metadataHandler.Render(); err != nil {
+		return err
+	}
+	return backupErr
+}
+
+// backupToObjectStorage backs up the repository to a tar archive streamed to
+// object storage with a multipart upload, without staging the backup locally.
+// As the tar archive is written sequentially, the blobs are downloaded one at a
+// time and an interrupted backup cannot be resumed.
+func backupToObjectStorage(ctx context.Context, opts *backupOptions, logger logrus.FieldLogger, startTime time.Time) (returnErr error) {
+	client, err := objectstore.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+	source := opts.sources[0]
+	metadata := map[string]string{
+		backupMetadataCreated:      startTime.UTC().Format(time.RFC3339Nano),
+		backupMetadataRepositories: source.repository,
+	}
+	pr, pw := io.Pipe()
+	uploaded := make(chan error, 1)
+	go func() {
+		err := client.Upload(ctx, opts.objectLocation, pr, metadata)
+		// fail the writes to the archive if the upload fails
+		pr.CloseWithError(err)
+		uploaded <- err
+	}()
+	uploading := true
+	defer func() {
+		if uploading {
+			// abort the upload
+			pw.CloseWithError(returnErr)
+			<-uploaded
+		}
+	}()
+	archive := &countingWriter{w: pw}
+	zw, err := compression.NewWriter(archive, opts.compression, opts.compressionLevel)
+	if err != nil {
+		return err
+	}
+	dst := ocitar.NewWriter(zw)
+	// blobs are written into the archive one at a time, so downloading more
+	// concurrently would only keep connections waiting
+	opts.concurrency = 1
+
+	statusHandler, metadataHandler, err := display.NewBackupHandler(opts.Printer, opts.Format, opts.TTY, source.repository, dst)
+	if err != nil {
+		return err
+	}
+	if err := metadataHandler.OnTarExporting(opts.output); err != nil {
+		return err
+	}
+	repo, backupErr := backupRepository(ctx, opts, source, dst, opts.output, logger, statusHandler, metadataHandler)
+	var partialErr *oerrors.PartialFailureError
+	if backupErr != nil && !errors.As(backupErr, &partialErr) {
+		return backupErr
+	}
+	blobs := dst.Blobs()
+	entries := make([]ledger.Entry, len(blobs))
+	for i, blob := range blobs {
+		repo.BlobCount++
+		repo.Size += blob.Size
+		entries[i] = ledger.Entry{
+			Digest: blob.Digest,
+			Size:   blob.Size,
+			Source: source.repository,
+		}
+	}
+	catalog, err := newBackupCatalog(startTime, repo).Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to write the backup catalog: %w", err)
+	}
+	if err := dst.WriteFile(backupcatalog.FileName, catalog); err != nil {
+		return fmt.Errorf("failed to write the backup catalog: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("failed to create tar archive at %s: %w", opts.output, err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to create tar archive at %s: %w", opts.output, err)
+	}
+	uploading = false
+	if err := pw.Close(); err != nil {
+		return err
+	}
+	if err := <-uploaded; err != nil {
+		return err
+	}
+	if err := metadataHandler.OnTarExported(opts.output, archive.n); err != nil {
+		return err
+	}
+	if opts.LedgerWriter.Enabled() {
+		if err := opts.LedgerWriter.Write("backup", entries); err != nil {
+			return err
+		}
+	}
+	if err := metadataHandler.OnBackupCompleted(len(repo.Tags), opts.output, time.Since(startTime)); err != nil {
+		return err
+	}
+	if err := metadataHandler.Render(); err != nil {
+		return err
+	}
+	return backupErr
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write implements io.Writer.
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// backupRepositories backs up multiple repositories concurrently, each into an
+// OCI layout of its own under the output directory. Blobs are stored once in a
+// content addressed directory under the output directory and hard linked into
+// the layouts.
+func backupRepositories(ctx context.Context, opts *backupOptions, logger logrus.FieldLogger) (returnErr error) {
+	startTime := time.Now()
+	var group *status.BackupGroup
+	printers := make([]*output.Printer, len(opts.sources))
+	outputs := make([]bytes.Buffer, len(opts.sources))
+	for i := range opts.sources {
+		printers[i] = opts.Printer
+	}
+	if opts.TTY != nil {
+		var err error
+		if group, err = status.NewBackupGroup(opts.TTY); err != nil {
+			return err
+		}
+		// hold back the text output until the progress rendering stops
+		for i := range opts.sources {
+			printers[i] = output.NewPrinter(&outputs[i], opts.Printer)
+			printers[i].Verbose = opts.Printer.Verbose
+		}
+		defer func() {
+			if err := group.Close(); err != nil && returnErr == nil {
+				returnErr = err
+			}
+			for i := range outputs {
+				if _, err := opts.Printer.Write(outputs[i].Bytes()); err != nil && returnErr == nil {
+					returnErr = err
+				}
+			}
+		}()
+	}
+
+	// share blobs across the repositories
+	pool, err := blobpool.New(filepath.Join(opts.output, ocispec.ImageBlobsDir))
 	if err != nil {
+		return fmt.Errorf("failed to prepare the shared blob directory: %w", err)
+	}
+
+	repos := make([]backupcatalog.Repository, len(opts.sources))
+	entries := make([][]ledger.Entry, len(opts.sources))
+	// failures of the tags and repositories with --continue-on-error
+	var failuresLock sync.Mutex
+	failures := &oerrors.PartialFailureError{Operation: "back up"}
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(opts.repoConcurrency)
+	for i, source := range opts.sources {
+		eg.Go(func() error {
+			startTime := time.Now()
+			dirName := repositoryDirName(source.repository)
+			dstRoot := filepath.Join(opts.output, dirName)
+			dstOCI, err := oci.New(dstRoot)
+			if err != nil {
+				return fmt.Errorf("failed to prepare OCI store for backup: %w", err)
+			}
+			statusHandler, metadataHandler := display.NewBackupGroupHandler(printers[i], group, source.repository, dstOCI)
+			dst := pool.Target(dstOCI, dstRoot)
+			repo, err := backupRepository(egCtx, opts, source, dst, dstRoot, logger, statusHandler, metadataHandler)
+			var partialErr *oerrors.PartialFailureError
+			if err != nil && !errors.As(err, &partialErr) {
+				if !opts.continueOnError || ctx.Err() != nil {
+					return err
+				}
+				// skip the repository failed as a whole, e.g. without tags
+				failuresLock.Lock()
+				defer failuresLock.Unlock()
+				failures.Failures = append(failures.Failures, oerrors.ItemError{Item: source.repository, Err: err, Repository: true})
+				return nil
+			}
+			failuresLock.Lock()
+			failures.Total += len(repo.Tags)
+			if partialErr != nil {
+				failures.Total += len(partialErr.Failures)
+				failures.Failures = append(failures.Failures, partialErr.Failures...)
+			}
+			failuresLock.Unlock()
+			repo.Path = filepath.ToSlash(dirName)
+			repos[i] = repo
+			if opts.LedgerWriter.Enabled() {
+				if entries[i], err = ledgerEntries(dstRoot, source.repository); err != nil {
+					return err
+				}
+			}
+			if count, size := dst.Deduplicated(); count > 0 {
+				if err := metadataHandler.OnBlobsDeduplicated(count, size); err != nil {
+					return err
+				}
+			}
+			if err := finalizeBackupOutput(dstRoot, opts, logger, metadataHandler); err != nil {
+				return err
+			}
+			return metadataHandler.OnBackupCompleted(len(repo.Tags), dstRoot, time.Since(startTime))
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	// leave out the repositories failed as a whole
+	repos = slices.DeleteFunc(repos, func(repo backupcatalog.Repository) bool {
+		return repo.Path == ""
+	})
+	if err := writeBackupCatalog(opts.output, startTime, repos...); err != nil {
 		return err
 	}
+	if opts.LedgerWriter.Enabled() {
+		if err := opts.LedgerWriter.Write("backup", slices.Concat(entries...)); err != nil {
+			return err
+		}
+	}
+	if len(failures.Failures) > 0 {
+		return failures
+	}
+	return nil
+}
+
+// ledgerEntries returns the transfer ledger entries of the blobs in the OCI
+// image layout at root, backed up from repository.
+func ledgerEntries(root string, repository string) ([]ledger.Entry, error) {
+	blobs, _, err := bundle.ListBlobs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the blobs in %s: %w", root, err)
+	}
+	entries := make([]ledger.Entry, len(blobs))
+	for i, blob := range blobs {
+		entries[i] = ledger.Entry{
+			Digest: blob.Digest,
+			Size:   blob.Size,
+			Source: repository,
+		}
+	}
+	return entries, nil
+}
+
+// writeBackupCatalog writes the catalog of the backed up repositories to the
+// backup root directory.
+func writeBackupCatalog(root string, created time.Time, repos ...backupcatalog.Repository) error {
+	catalog := newBackupCatalog(created, repos...)
+	for i := range catalog.Repositories {
+		count, size, err := backupcatalog.MeasureLayout(filepath.Join(root, filepath.FromSlash(catalog.Repositories[i].Path)))
+		if err != nil {
+			return fmt.Errorf("failed to measure the backup of %q: %w", catalog.Repositories[i].Name, err)
+		}
+		catalog.Repositories[i].BlobCount = count
+		catalog.Repositories[i].Size = size
+	}
+	if err := catalog.WriteFile(root); err != nil {
+		return fmt.Errorf("failed to write the backup catalog: %w", err)
+	}
+	return nil
+}
+
+// newBackupCatalog returns the catalog of the backed up repositories.
+func newBackupCatalog(created time.Time, repos ...backupcatalog.Repository) *backupcatalog.Catalog {
+	return &backupcatalog.Catalog{
+		Tool:         "oras",
+		Version:      version.GetVersion(),
+		Created:      created.UTC(),
+		Repositories: repos,
+	}
+}
+
+// backupStagingDir returns the temporary directory to stage the backup to the
+// tar archive at output in, which is the same for the same output so that an
+// interrupted backup can be resumed.
+func backupStagingDir(output string) (string, error) {
+	path, err := filepath.Abs(output)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(path))
+	return filepath.Join(os.TempDir(), "oras-backup-"+hex.EncodeToString(sum[:8])), nil
+}
+
+// repositoryDirName returns the name of the directory to back up repository
+// into, which is in the form of <registry>/<repository>.
+func repositoryDirName(repository string) string {
+	// the port of the registry is separated by "_" for portable file names
+	return filepath.FromSlash(strings.ReplaceAll(repository, ":", "_"))
+}
+
+// backupRepository backs up the tagged artifacts of source into dst, an OCI
+// layout at dstRoot, and returns the catalog entry of the repository. With
+// --continue-on-error, the failed tags are left out of the catalog entry and
+// returned in an oerrors.PartialFailureError.
+func backupRepository(ctx context.Context, opts *backupOptions, source backupSource, dst oras.GraphTarget, dstRoot string, logger logrus.FieldLogger, statusHandler status.BackupHandler, metadataHandler metadata.BackupHandler) (backupcatalog.Repository, error) {
+	repo := backupcatalog.Repository{Name: source.repository}
+	// Prepare copy source
+	srcRepo, err := opts.NewRepository(source.repository, opts.Common, logger)
+	if err != nil {
+		return repo, fmt.Errorf("failed to prepare repository %s for backup: %w", source.repository, err)
+	}
+
+	// Resolve tags to back up
+	tags, roots, err := resolveTags(ctx, srcRepo, source.tags)
+	if err != nil {
+		return repo, err
+	}
 	if len(tags) == 0 {
-		return &oerrors.Error{
-			Err:            fmt.Errorf("no tags found in repository %q", opts.repository),
-			Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags"`, opts.repository),
+		return repo, &oerrors.Error{
+			Err:            fmt.Errorf("no tags found in repository %q", source.repository),
+			Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags"`, source.repository),
 		}
 	}
 	if err := metadataHandler.OnTagsFound(tags); err != nil {
-		return err
+		return repo, err
+	}
+	var downloaded map[digest.Digest]bool
+	if !opts.referrersOnly {
+		// fail early instead of running out of space in the middle
+		pending, err := planBackup(ctx, opts, srcRepo, dst, dstRoot, roots)
+		if err != nil {
+			return repo, err
+		}
+		if downloaded, err = downloadBlobs(ctx, srcRepo, dst, pending, opts.concurrency, opts.continueOnError, statusHandler); err != nil {
+			return repo, fmt.Errorf("failed to back up blobs from %q to %q: %w", source.repository, dstRoot, oerrors.UnwrapCopyError(err))
+		}
 	}
 
 	// Prepare copy options
@@ -226,7 +829,13 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 	copyGraphOpts.Concurrency = opts.concurrency
 	copyGraphOpts.PreCopy = statusHandler.PreCopy
 	copyGraphOpts.PostCopy = statusHandler.PostCopy
-	copyGraphOpts.OnCopySkipped = statusHandler.OnCopySkipped
+	copyGraphOpts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if downloaded[desc.Digest] {
+			// already reported when downloaded
+			return nil
+		}
+		return statusHandler.OnCopySkipped(ctx, desc)
+	}
 	extCopyGraphOpts := oras.ExtendedCopyGraphOptions{
 		CopyGraphOptions: copyGraphOpts,
 		FindPredecessors: func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
@@ -234,9 +843,15 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 		},
 	}
 
+	var failures []oerrors.ItemError
 	for i, tag := range tags {
+		name := tag
+		if len(opts.sources) > 1 {
+			// qualify the tag as the output of repositories may interleave
+			name = source.repository + ":" + tag
+		}
 		referrerCount, err := func() (referrerCount int, retErr error) {
-			trackedDst, err := statusHandler.StartTracking(dstOCI)
+			trackedDst, err := statusHandler.StartTracking(dst)
 			if err != nil {
 				return 0, err
 			}
@@ -247,24 +862,142 @@ func runBackup(cmd *cobra.Command, opts *backupOptions) error {
 				}
 			}()
 
+			if opts.referrersOnly {
+				return backupReferrers(ctx, srcRepo, trackedDst, tag, roots[i], extCopyGraphOpts)
+			}
 			if opts.includeReferrers {
 				return backupTagWithReferrers(ctx, srcRepo, trackedDst, tag, roots[i], extCopyGraphOpts)
 			}
 			return 0, backupTag(ctx, srcRepo, trackedDst, tag, roots[i], copyGraphOpts)
 		}()
 		if err != nil {
-			return fmt.Errorf("failed to back up tag %q from %q to %q: %w", tag, opts.repository, dstRoot, oerrors.UnwrapCopyError(err))
+			if !opts.continueOnError || ctx.Err() != nil {
+				return repo, fmt.Errorf("failed to back up tag %q from %q to %q: %w", tag, source.repository, dstRoot, oerrors.UnwrapCopyError(err))
+			}
+			err = oerrors.UnwrapCopyError(err)
+			if err := metadataHandler.OnArtifactFailed(name, err); err != nil {
+				return repo, err
+			}
+			failures = append(failures, oerrors.ItemError{Item: name, Err: err})
+			continue
 		}
-		if err := metadataHandler.OnArtifactPulled(tag, referrerCount); err != nil {
-			return err
+		repo.Tags = append(repo.Tags, backupcatalog.NewTag(tag, roots[i], referrerCount))
+		if err := metadataHandler.OnArtifactPulled(name, referrerCount); err != nil {
+			return repo, err
 		}
 	}
+	if len(failures) > 0 {
+		return repo, &oerrors.PartialFailureError{
+			Operation: "back up",
+			Total:     len(tags),
+			Failures:  failures,
+		}
+	}
+	return repo, nil
+}
 
-	if err := finalizeBackupOutput(dstRoot, opts, logger, metadataHandler); err != nil {
-		return err
+// planBackup returns the blobs of roots to download from src as they are not
+// present in dst yet. It estimates the size of backing up roots, and returns an
+// error if dstRoot, or the temporary directory and the directory of the output
+// tar archive, do not have enough space for it.
+func planBackup(ctx context.Context, opts *backupOptions, src oras.ReadOnlyGraphTarget, dst content.ReadOnlyStorage, dstRoot string, roots []ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	var findReferrers func(context.Context, ocispec.Descriptor) ([]ocispec.Descriptor, error)
+	if opts.includeReferrers {
+		findReferrers = func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+			return registry.Referrers(ctx, src, desc, "")
+		}
 	}
-	duration := time.Since(startTime)
-	return metadataHandler.OnBackupCompleted(len(tags), opts.output, duration)
+	// content shared by the artifacts or already backed up is stored once
+	seen := make(map[digest.Digest]bool)
+	var pending []ocispec.Descriptor
+	skip := func(ctx context.Context, desc ocispec.Descriptor) (bool, error) {
+		if seen[desc.Digest] {
+			return true, nil
+		}
+		seen[desc.Digest] = true
+		exists, err := dst.Exists(ctx, desc)
+		if err == nil && !exists && !descriptor.IsManifest(desc) {
+			pending = append(pending, desc)
+		}
+		return exists, err
+	}
+	var required int64
+	for _, root := range roots {
+		estimate, err := estimateTransfer(ctx, src, root, findReferrers, skip)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate the size of the backup: %w", err)
+		}
+		required += estimate.Size
+	}
+	switch opts.outputFormat {
+	case outputFormatDir:
+		return pending, opts.TempDir.CheckSpace(ctx, dstRoot, required, "the backup")
+	case outputFormatObjectStorage:
+		// the backup is streamed to object storage without local copies
+		return pending, nil
+	}
+	// the backup is staged in a temporary directory before being archived
+	if err := opts.TempDir.CheckSpace(ctx, os.TempDir(), required, "staging the backup"); err != nil {
+		return nil, err
+	}
+	return pending, opts.TempDir.CheckSpace(ctx, filepath.Dir(opts.output), required, "the backup tar archive")
+}
+
+// downloadBlobs downloads blobs from src to dst concurrently, so that the blobs
+// of all the artifacts in a repository are downloaded in parallel rather than
+// one artifact at a time. The digests of the downloaded blobs are returned.
+// With continueOnError, the blobs failing to download are left out for the
+// tags referencing them to fail when backed up.
+func downloadBlobs(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget, blobs []ocispec.Descriptor, concurrency int, continueOnError bool, statusHandler status.BackupHandler) (downloaded map[digest.Digest]bool, returnErr error) {
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+	trackedDst, err := statusHandler.StartTracking(dst)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		stopErr := statusHandler.StopTracking()
+		if returnErr == nil {
+			returnErr = stopErr
+		}
+	}()
+
+	if concurrency <= 0 {
+		// the default concurrency of oras.CopyGraph
+		concurrency = 3
+	}
+	copyGraphOpts := oras.DefaultCopyGraphOptions
+	copyGraphOpts.PreCopy = statusHandler.PreCopy
+	copyGraphOpts.PostCopy = statusHandler.PostCopy
+	copyGraphOpts.OnCopySkipped = statusHandler.OnCopySkipped
+	var failedLock sync.Mutex
+	failed := make(map[digest.Digest]bool)
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(concurrency)
+	for _, blob := range blobs {
+		eg.Go(func() error {
+			err := oras.CopyGraph(egCtx, src, trackedDst, blob, copyGraphOpts)
+			if err != nil && continueOnError && ctx.Err() == nil {
+				// leave the blob to fail the tags referencing it
+				failedLock.Lock()
+				defer failedLock.Unlock()
+				failed[blob.Digest] = true
+				return nil
+			}
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	downloaded = make(map[digest.Digest]bool, len(blobs))
+	for _, blob := range blobs {
+		if !failed[blob.Digest] {
+			downloaded[blob.Digest] = true
+		}
+	}
+	return downloaded, nil
 }
 
 // backupTag copies the artifact identified by the tag from src to dst.
@@ -289,31 +1022,91 @@ func backupTagWithReferrers(ctx context.Context, src oras.ReadOnlyGraphTarget, d
 // countReferrers counts the total number of referrers for the given artifact identified by tag, including the referrers
 // of its children manifests if the artifact is an image index or manifest list.
 func countReferrers(ctx context.Context, target oras.ReadOnlyGraphTarget, tag string, root ocispec.Descriptor, extCopyGraphOpts oras.ExtendedCopyGraphOptions) (int, error) {
-	referrers, err := graph.RecursiveFindReferrers(ctx, target, []ocispec.Descriptor{root}, extCopyGraphOpts)
+	_, referrers, err := findReferrers(ctx, target, tag, root, extCopyGraphOpts)
+	if err != nil {
+		return 0, err
+	}
+	return len(referrers), nil
+}
+
+// findReferrers recursively finds the referrers of the given artifact identified by tag, including the referrers of its
+// children manifests if the artifact is an image index or manifest list. The artifact and its children manifests are
+// returned as the subjects.
+func findReferrers(ctx context.Context, target oras.ReadOnlyGraphTarget, tag string, root ocispec.Descriptor, extCopyGraphOpts oras.ExtendedCopyGraphOptions) (subjects []ocispec.Descriptor, referrers []ocispec.Descriptor, err error) {
+	referrers, err = graph.RecursiveFindReferrers(ctx, target, []ocispec.Descriptor{root}, extCopyGraphOpts)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count referrers for tag %q, digest %q: %w", tag, root.Digest.String(), err)
+		return nil, nil, fmt.Errorf("failed to count referrers for tag %q, digest %q: %w", tag, root.Digest.String(), err)
 	}
-	referrerCount := len(referrers)
+	subjects = []ocispec.Descriptor{root}
 	if root.MediaType != ocispec.MediaTypeImageIndex && root.MediaType != docker.MediaTypeManifestList {
-		// If the root is not an image index or manifest list, we have counted all referrers
-		return referrerCount, nil
+		// If the root is not an image index or manifest list, we have found all referrers
+		return subjects, referrers, nil
 	}
 
-	// count referrers of children manifests
+	// find referrers of children manifests
 	manifestBytes, err := content.FetchAll(ctx, target, root)
 	if err != nil {
-		return 0, fmt.Errorf("failed to fetch content of tag %q, digest %q: %w", tag, root.Digest.String(), err)
+		return nil, nil, fmt.Errorf("failed to fetch content of tag %q, digest %q: %w", tag, root.Digest.String(), err)
 	}
 	var index ocispec.Index
 	if err = json.Unmarshal(manifestBytes, &index); err != nil {
-		return 0, fmt.Errorf("failed to unmarshal index for tag %q, digest %q: %w", tag, root.Digest.String(), err)
+		return nil, nil, fmt.Errorf("failed to unmarshal index for tag %q, digest %q: %w", tag, root.Digest.String(), err)
 	}
 	childrenReferrers, err := graph.RecursiveFindReferrers(ctx, target, index.Manifests, extCopyGraphOpts)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count referrers for children manifests of tag %q, digest %q: %w", tag, root.Digest.String(), err)
+		return nil, nil, fmt.Errorf("failed to count referrers for children manifests of tag %q, digest %q: %w", tag, root.Digest.String(), err)
 	}
-	referrerCount += len(childrenReferrers)
-	return referrerCount, nil
+	subjects = append(subjects, index.Manifests...)
+	referrers = append(referrers, childrenReferrers...)
+	return subjects, referrers, nil
+}
+
+// backupReferrers copies the referrers of the artifact identified by tag from src to dst without the content of the
+// artifact. Only the manifest of the artifact is kept and tagged so that the referrers can be restored to an artifact
+// already existing in the target registry.
+func backupReferrers(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, tag string, root ocispec.Descriptor, extCopyGraphOpts oras.ExtendedCopyGraphOptions) (int, error) {
+	subjects, referrers, err := findReferrers(ctx, src, tag, root, extCopyGraphOpts)
+	if err != nil {
+		return 0, err
+	}
+	if err := copyReferrers(ctx, src, dst, subjects, append(referrers, root), extCopyGraphOpts.CopyGraphOptions); err != nil {
+		return 0, err
+	}
+	if err := dst.Tag(ctx, root, tag); err != nil {
+		return 0, fmt.Errorf("failed to tag %q with %q: %w", root.Digest.String(), tag, err)
+	}
+	return len(referrers), nil
+}
+
+// copyReferrers copies the graphs of the given referrers from src to dst, leaving out the subjects and their content.
+func copyReferrers(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.Target, subjects []ocispec.Descriptor, referrers []ocispec.Descriptor, copyGraphOpts oras.CopyGraphOptions) error {
+	excluded := make(map[digest.Digest]bool, len(subjects))
+	for _, subject := range subjects {
+		excluded[subject.Digest] = true
+	}
+	findSuccessors := copyGraphOpts.FindSuccessors
+	if findSuccessors == nil {
+		findSuccessors = content.Successors
+	}
+	copyGraphOpts.FindSuccessors = func(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		if excluded[desc.Digest] {
+			// the content of a subject is not copied
+			return nil, nil
+		}
+		successors, err := findSuccessors(ctx, fetcher, desc)
+		if err != nil {
+			return nil, err
+		}
+		return slices.DeleteFunc(successors, func(successor ocispec.Descriptor) bool {
+			return excluded[successor.Digest]
+		}), nil
+	}
+	for _, referrer := range referrers {
+		if err := oras.CopyGraph(ctx, src, dst, referrer, copyGraphOpts); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // finalizeBackupOutput finalizes the backup output by removing temporary directories and exporting to a tar archive if needed.
@@ -332,6 +1125,9 @@ func finalizeBackupOutput(dstRoot string, opts *backupOptions, logger logrus.Fie
 	if err := metadataHandler.OnTarExporting(opts.output); err != nil {
 		return err
 	}
+	if opts.volumeSize > 0 {
+		return exportBackupVolumes(dstRoot, opts, logger, metadataHandler)
+	}
 	tarFile, err := os.Create(opts.output)
 	if err != nil {
 		return fmt.Errorf("failed to create output file %s: %w", opts.output, err)
@@ -342,7 +1138,7 @@ func finalizeBackupOutput(dstRoot string, opts *backupOptions, logger logrus.Fie
 			returnErr = err
 		}
 	}()
-	if err := orasio.TarDirectory(tarFile, dstRoot); err != nil {
+	if err := exportBackup(tarFile, dstRoot, opts); err != nil {
 		// remove the output file in case of error
 		if err := os.Remove(opts.output); err != nil && !errors.Is(err, fs.ErrNotExist) {
 			logger.Debugf("failed to remove output file %s: %v", opts.output, err)
@@ -356,6 +1152,46 @@ func finalizeBackupOutput(dstRoot string, opts *backupOptions, logger logrus.Fie
 	return metadataHandler.OnTarExported(opts.output, fi.Size())
 }
 
+// exportBackup writes the tar archive of dstRoot to w, compressed as selected.
+func exportBackup(w io.Writer, dstRoot string, opts *backupOptions) error {
+	zw, err := compression.NewWriter(w, opts.compression, opts.compressionLevel)
+	if err != nil {
+		return err
+	}
+	err = orasio.TarDirectory(zw, dstRoot)
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// exportBackupVolumes exports the backup to a tar archive split into volumes.
+func exportBackupVolumes(dstRoot string, opts *backupOptions, logger logrus.FieldLogger, metadataHandler metadata.BackupHandler) error {
+	w := orasio.NewVolumeWriter(opts.output, opts.volumeSize)
+	err := exportBackup(w, dstRoot, opts)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// remove the volumes in case of error
+		for n := 1; n <= w.Count(); n++ {
+			if err := os.Remove(orasio.VolumePath(opts.output, n)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				logger.Debugf("failed to remove output file %s: %v", orasio.VolumePath(opts.output, n), err)
+			}
+		}
+		return fmt.Errorf("failed to create tar archive volumes at %s: %w", opts.output, err)
+	}
+	var size int64
+	for n := 1; n <= w.Count(); n++ {
+		fi, err := os.Stat(orasio.VolumePath(opts.output, n))
+		if err != nil {
+			return fmt.Errorf("failed to stat output file %s: %w", orasio.VolumePath(opts.output, n), err)
+		}
+		size += fi.Size()
+	}
+	return metadataHandler.OnTarVolumesExported(opts.output, w.Count(), size)
+}
+
 // resolveTags resolves tags to their descriptors.
 // It returns the resolved tags and their corresponding descriptors.
 func resolveTags(ctx context.Context, target oras.ReadOnlyTarget, specifiedTags []string) ([]string, []ocispec.Descriptor, error) {
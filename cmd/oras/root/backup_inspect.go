@@ -0,0 +1,149 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/backupcatalog"
+	"oras.land/oras/internal/compression"
+	"oras.land/oras/internal/httpfile"
+	orasio "oras.land/oras/internal/io"
+	"oras.land/oras/internal/objectstore"
+)
+
+type backupInspectOptions struct {
+	option.Common
+	option.Format
+
+	path string
+}
+
+func backupInspectCmd() *cobra.Command {
+	var opts backupInspectOptions
+	cmd := &cobra.Command{
+		Use:   "inspect [flags] <path>",
+		Short: "[Experimental] Show the catalog of a backup",
+		Long: `[Experimental] Show the catalog of a backup created by "oras backup", listing the backed up repositories, tags, digests and sizes.
+The backup can be a directory, a tar archive, a set of tar archive volumes, or the URL of a tar archive over HTTP(S) or in S3 (s3://<bucket>/<key>), which is not extracted.
+
+Example - Show the catalog of a backup in a directory:
+  oras backup inspect hello
+
+Example - Show the catalog of a backup in a tar archive:
+  oras backup inspect hello.tar
+
+Example - Show the catalog of a backup in tar archive volumes hello.tar.001, hello.tar.002, ...:
+  oras backup inspect hello.tar
+
+Example - Show the catalog of a backup in a tar archive in an S3 bucket:
+  oras backup inspect s3://my-bucket/backups/hello.tar.zst
+
+Example - Show the catalog of a backup in JSON format:
+  oras backup inspect hello.tar --format json
+
+Example - Show the digests of the backed up tags using the given Go template:
+  oras backup inspect hello.tar --format go-template --template "{{range .repositories}}{{range .tags}}{{println .digest}}{{end}}{{end}}"
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the backup to inspect"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.path = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackupInspect(cmd.Context(), &opts)
+		},
+	}
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+func runBackupInspect(ctx context.Context, opts *backupInspectOptions) error {
+	handler, err := display.NewBackupInspectHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	catalog, err := readBackupCatalog(ctx, opts.path)
+	if err != nil {
+		if errors.Is(err, backupcatalog.ErrNotFound) {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("%q: %w", opts.path, err),
+				Recommendation: fmt.Sprintf(`The backup may be created by an earlier version of oras. To list the tags in it, use "oras repo tags --oci-layout %s"`, opts.path),
+			}
+		}
+		return fmt.Errorf("failed to read the catalog of %q: %w", opts.path, err)
+	}
+	if err := handler.OnCatalogLoaded(catalog); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+// readBackupCatalog reads the catalog of the backup at path, which is either a
+// directory, a tar archive, a set of tar archive volumes or the URL of a tar
+// archive over HTTP or in object storage.
+func readBackupCatalog(ctx context.Context, path string) (*backupcatalog.Catalog, error) {
+	if objectstore.IsURL(path) || httpfile.IsURL(path) {
+		rc, _, err := openRemoteArchive(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return readBackupCatalogTar(rc)
+	}
+	if volumeSet, ok := findVolumeSet(path); ok {
+		rc, _, _, err := orasio.OpenVolumes(volumeSet)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return readBackupCatalogTar(rc)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return backupcatalog.ReadDir(path)
+	}
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	return readBackupCatalogTar(fp)
+}
+
+// readBackupCatalogTar reads the catalog of the backup in the tar archive read
+// from r, decompressed if compressed.
+func readBackupCatalogTar(r io.Reader) (*backupcatalog.Catalog, error) {
+	zr, err := compression.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return backupcatalog.ReadTar(zr)
+}
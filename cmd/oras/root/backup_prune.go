@@ -0,0 +1,396 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/backupcatalog"
+	"oras.land/oras/internal/compression"
+	"oras.land/oras/internal/expiry"
+	orasio "oras.land/oras/internal/io"
+	"oras.land/oras/internal/objectstore"
+	"oras.land/oras/internal/retention"
+)
+
+type backupPruneOptions struct {
+	option.Common
+	option.Confirmation
+
+	location    string
+	keepLast    int
+	keepDaily   int
+	keepWeekly  int
+	keepMonthly int
+	keepWithin  string
+	dryRun      bool
+
+	policy retention.Policy
+}
+
+// prunableBackup is a backup found by "oras backup prune".
+type prunableBackup struct {
+	name         string
+	created      time.Time
+	repositories string
+	remove       func(ctx context.Context) error
+}
+
+func backupPruneCmd() *cobra.Command {
+	var opts backupPruneOptions
+	cmd := &cobra.Command{
+		Use:   "prune [flags] {<directory> | s3://<bucket>[/<prefix>]}",
+		Short: "[Experimental] Delete the backups not kept by a retention policy",
+		Long: `[Experimental] Delete the backups created by "oras backup" in a directory or under a prefix of an S3 bucket which are not kept by a retention policy.
+The tar archives, compressed tar archives, sets of tar archive volumes and directories right under the location are examined, and only the ones with a catalog created by "oras backup" are considered as backups, so that other files are never deleted.
+The backups of different sets of repositories are rotated separately, and ordered by the creation time in their catalogs. For the tar archives backed up to S3 by "oras backup", the creation time is read from the object metadata without downloading the archives.
+A backup is kept if it is kept by any of the --keep-* flags, and at least one of them must be specified.
+
+Example - Keep the last 7 backups and the last backup of each of the last 4 weeks in the directory 'backups':
+  oras backup prune --keep-last 7 --keep-weekly 4 backups
+
+Example - List the backups to be deleted without deleting them:
+  oras backup prune --keep-last 7 --dry-run backups
+
+Example - Keep the last backup of each of the last 14 days and 12 months under a prefix of an S3 bucket:
+  oras backup prune --keep-daily 14 --keep-monthly 12 s3://my-bucket/backups
+
+Example - Keep the backups created in the last 30 days without prompting for confirmation:
+  oras backup prune --keep-within 30d --yes backups
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the directory or S3 prefix of the backups to prune"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.location = args[0]
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			return parseRetentionPolicy(&opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackupPrune(cmd, &opts)
+		},
+	}
+	cmd.Flags().IntVar(&opts.keepLast, "keep-last", 0, "keep the `n` most recent backups")
+	cmd.Flags().IntVar(&opts.keepDaily, "keep-daily", 0, "keep the most recent backup of each of the `n` most recent days with backups")
+	cmd.Flags().IntVar(&opts.keepWeekly, "keep-weekly", 0, "keep the most recent backup of each of the `n` most recent weeks with backups")
+	cmd.Flags().IntVar(&opts.keepMonthly, "keep-monthly", 0, "keep the most recent backup of each of the `n` most recent months with backups")
+	cmd.Flags().StringVar(&opts.keepWithin, "keep-within", "", "keep the backups created within the `duration` before now, e.g. 30d, 2w, 12h")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "list the backups to be deleted without deleting them")
+	option.ApplyFlags(&opts, cmd.Flags())
+	return cmd
+}
+
+// parseRetentionPolicy parses the retention policy from the --keep-* flags.
+func parseRetentionPolicy(opts *backupPruneOptions) error {
+	for flag, n := range map[string]int{
+		"--keep-last":    opts.keepLast,
+		"--keep-daily":   opts.keepDaily,
+		"--keep-weekly":  opts.keepWeekly,
+		"--keep-monthly": opts.keepMonthly,
+	} {
+		if n < 0 {
+			return fmt.Errorf("invalid %s %d: must not be negative", flag, n)
+		}
+	}
+	opts.policy = retention.Policy{
+		Last:    opts.keepLast,
+		Daily:   opts.keepDaily,
+		Weekly:  opts.keepWeekly,
+		Monthly: opts.keepMonthly,
+	}
+	if opts.keepWithin != "" {
+		within, err := expiry.ParseDuration(opts.keepWithin)
+		if err != nil {
+			return fmt.Errorf("invalid --keep-within: %w", err)
+		}
+		opts.policy.Within = within
+	}
+	if opts.policy.IsZero() {
+		return &oerrors.Error{
+			Err:            errors.New("no retention policy is specified"),
+			Recommendation: "Specify the backups to keep with at least one of --keep-last, --keep-daily, --keep-weekly, --keep-monthly and --keep-within",
+		}
+	}
+	return nil
+}
+
+func runBackupPrune(cmd *cobra.Command, opts *backupPruneOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	var backups []prunableBackup
+	var err error
+	if objectstore.IsURL(opts.location) {
+		backups, err = findObjectBackups(ctx, opts.location, logger)
+	} else {
+		backups, err = findLocalBackups(opts.location, logger)
+	}
+	if err != nil {
+		return err
+	}
+	handler := display.NewBackupPruneHandler(opts.Printer, opts.location, opts.dryRun)
+
+	expired, err := selectExpiredBackups(backups, opts.policy, time.Now(), handler)
+	if err != nil {
+		return err
+	}
+	if opts.dryRun || len(expired) == 0 {
+		if err := handler.OnBackupPruneCompleted(len(expired)); err != nil {
+			return err
+		}
+		return handler.Render()
+	}
+
+	prompt := fmt.Sprintf("Are you sure you want to delete %d backup(s) from %q?", len(expired), opts.location)
+	confirmed, err := opts.AskForTypedConfirmation(os.Stdin, prompt, opts.location)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+	for _, backup := range expired {
+		if err := backup.remove(ctx); err != nil {
+			return fmt.Errorf("failed to delete the backup %s: %w", backup.name, err)
+		}
+		if err := handler.OnBackupPruned(backup.name); err != nil {
+			return err
+		}
+	}
+	if err := handler.OnBackupPruneCompleted(len(expired)); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+// selectExpiredBackups applies policy to the backups of each set of
+// repositories separately, reports the backups kept and expired to handler in
+// the order of the repositories and the creation time from the most recent,
+// and returns the expired backups.
+func selectExpiredBackups(backups []prunableBackup, policy retention.Policy, now time.Time, handler metadata.BackupPruneHandler) ([]prunableBackup, error) {
+	backups = slices.Clone(backups)
+	slices.SortStableFunc(backups, func(a, b prunableBackup) int {
+		if c := strings.Compare(a.repositories, b.repositories); c != 0 {
+			return c
+		}
+		return b.created.Compare(a.created)
+	})
+	var expired []prunableBackup
+	for start := 0; start < len(backups); {
+		end := start + 1
+		for end < len(backups) && backups[end].repositories == backups[start].repositories {
+			end++
+		}
+		group := backups[start:end]
+		times := make([]time.Time, len(group))
+		for i, backup := range group {
+			// days, weeks and months are determined in the local time zone
+			times[i] = backup.created.Local()
+		}
+		for i, reasons := range policy.Apply(times, now) {
+			backup := group[i]
+			if len(reasons) > 0 {
+				if err := handler.OnBackupKept(backup.name, backup.created, backup.repositories, reasons); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := handler.OnBackupExpired(backup.name, backup.created, backup.repositories); err != nil {
+				return nil, err
+			}
+			expired = append(expired, backup)
+		}
+		start = end
+	}
+	return expired, nil
+}
+
+// findLocalBackups finds the backups right under the directory dir.
+func findLocalBackups(dir string, logger logrus.FieldLogger) ([]prunableBackup, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the backups in %q: %w", dir, err)
+	}
+	var backups []prunableBackup
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		var catalog *backupcatalog.Catalog
+		var remove func(context.Context) error
+		switch {
+		case entry.IsDir():
+			catalog, err = backupcatalog.ReadDir(path)
+			remove = func(context.Context) error {
+				return os.RemoveAll(path)
+			}
+		case !entry.Type().IsRegular():
+			continue
+		default:
+			volumeSet, isVolume := orasio.VolumeSetPath(path)
+			if isVolume {
+				path = volumeSet
+			}
+			if _, ok := compression.FromExtension(path); !ok {
+				continue
+			}
+			if isVolume {
+				catalog, remove, err = readVolumeSetBackup(path)
+			} else {
+				catalog, remove, err = readArchiveBackup(path)
+			}
+		}
+		if err != nil {
+			// not a backup created by oras
+			logger.Debugf("skipped %s: %v", path, err)
+			continue
+		}
+		backups = append(backups, prunableBackup{
+			name:         path,
+			created:      catalog.Created,
+			repositories: catalogRepositories(catalog),
+			remove:       remove,
+		})
+	}
+	return backups, nil
+}
+
+// readArchiveBackup reads the catalog of the backup in the tar archive at path,
+// and returns it with the function removing the archive.
+func readArchiveBackup(path string) (*backupcatalog.Catalog, func(context.Context) error, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer fp.Close()
+	catalog, err := readBackupCatalogTar(fp)
+	if err != nil {
+		return nil, nil, err
+	}
+	return catalog, func(context.Context) error {
+		return os.Remove(path)
+	}, nil
+}
+
+// readVolumeSetBackup reads the catalog of the backup in the tar archive
+// volume set at path, and returns it with the function removing the volumes.
+func readVolumeSetBackup(path string) (*backupcatalog.Catalog, func(context.Context) error, error) {
+	rc, count, _, err := orasio.OpenVolumes(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rc.Close()
+	catalog, err := readBackupCatalogTar(rc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return catalog, func(context.Context) error {
+		for n := 1; n <= count; n++ {
+			if err := os.Remove(orasio.VolumePath(path, n)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// findObjectBackups finds the tar archive backups right under the prefix of
+// the object storage URL.
+func findObjectBackups(ctx context.Context, url string, logger logrus.FieldLogger) ([]prunableBackup, error) {
+	prefix, err := objectstore.ParsePrefix(url)
+	if err != nil {
+		return nil, err
+	}
+	client, err := objectstore.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := client.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	var backups []prunableBackup
+	for _, object := range objects {
+		if _, ok := compression.FromExtension(object.Key); !ok {
+			continue
+		}
+		created, repositories, err := readObjectBackup(ctx, client, object.Location)
+		if err != nil {
+			// not a backup created by oras
+			logger.Debugf("skipped %s: %v", object.Location, err)
+			continue
+		}
+		loc := object.Location
+		backups = append(backups, prunableBackup{
+			name:         loc.String(),
+			created:      created,
+			repositories: repositories,
+			remove: func(ctx context.Context) error {
+				return client.Delete(ctx, loc)
+			},
+		})
+	}
+	return backups, nil
+}
+
+// readObjectBackup returns the creation time and the repositories of the tar
+// archive backup at loc, read from the object metadata set by "oras backup",
+// or from the catalog in the archive otherwise.
+func readObjectBackup(ctx context.Context, client *objectstore.Client, loc objectstore.Location) (time.Time, string, error) {
+	metadata, err := client.Metadata(ctx, loc)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	if value, ok := metadata[backupMetadataCreated]; ok {
+		created, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("invalid metadata %s: %q", backupMetadataCreated, value)
+		}
+		return created, metadata[backupMetadataRepositories], nil
+	}
+	rc, _, err := client.Open(ctx, loc)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	defer rc.Close()
+	catalog, err := readBackupCatalogTar(rc)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return catalog.Created, catalogRepositories(catalog), nil
+}
+
+// catalogRepositories returns the sorted, comma separated names of the
+// repositories in catalog.
+func catalogRepositories(catalog *backupcatalog.Catalog) string {
+	names := make([]string, len(catalog.Repositories))
+	for i, repo := range catalog.Repositories {
+		names[i] = repo.Name
+	}
+	slices.Sort(names)
+	return strings.Join(names, ",")
+}
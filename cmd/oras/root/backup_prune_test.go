@@ -0,0 +1,185 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"oras.land/oras/cmd/oras/internal/display/metadata/text"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/backupcatalog"
+	orasio "oras.land/oras/internal/io"
+	"oras.land/oras/internal/retention"
+)
+
+// writeTestBackup writes a backup of repository created at created into dir.
+func writeTestBackup(t *testing.T, dir string, repository string, created time.Time) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	catalog := newBackupCatalog(created, backupcatalog.Repository{Name: repository})
+	if err := catalog.WriteFile(dir); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeTestBackupArchive writes a backup of repository created at created
+// into the tar archive at path, split into volumes of volumeSize if positive.
+func writeTestBackupArchive(t *testing.T, path string, repository string, created time.Time, volumeSize int64) {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestBackup(t, dir, repository, created)
+	var buf bytes.Buffer
+	if err := orasio.TarDirectory(&buf, dir); err != nil {
+		t.Fatal(err)
+	}
+	if volumeSize <= 0 {
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	w := orasio.NewVolumeWriter(path, volumeSize)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_findLocalBackups(t *testing.T) {
+	dir := t.TempDir()
+	day := func(d int) time.Time {
+		return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	writeTestBackup(t, filepath.Join(dir, "hello-1"), "localhost:5000/hello", day(1))
+	writeTestBackupArchive(t, filepath.Join(dir, "hello-2.tar"), "localhost:5000/hello", day(2), 0)
+	writeTestBackupArchive(t, filepath.Join(dir, "hello-3.tar"), "localhost:5000/hello", day(3), 1024)
+	writeTestBackupArchive(t, filepath.Join(dir, "notes.txt"), "localhost:5000/hello", day(4), 0)
+	// not created by oras
+	if err := os.WriteFile(filepath.Join(dir, "other.tar"), []byte("not a tar archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "other"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := findLocalBackups(dir, logrus.New())
+	if err != nil {
+		t.Fatalf("findLocalBackups() error = %v", err)
+	}
+	var got []string
+	for _, backup := range backups {
+		if backup.repositories != "localhost:5000/hello" {
+			t.Errorf("repositories of %s = %q, want %q", backup.name, backup.repositories, "localhost:5000/hello")
+		}
+		got = append(got, filepath.Base(backup.name)+"@"+backup.created.Format(time.DateOnly))
+	}
+	want := []string{"hello-1@2024-01-01", "hello-2.tar@2024-01-02", "hello-3.tar@2024-01-03"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("findLocalBackups() = %v, want %v", got, want)
+	}
+
+	// removing the backups
+	for _, backup := range backups {
+		if err := backup.remove(context.Background()); err != nil {
+			t.Fatalf("remove(%s) error = %v", backup.name, err)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var left []string
+	for _, entry := range entries {
+		left = append(left, entry.Name())
+	}
+	if want := []string{"notes.txt", "other", "other.tar"}; !reflect.DeepEqual(left, want) {
+		t.Errorf("files left = %v, want %v", left, want)
+	}
+}
+
+func Test_selectExpiredBackups(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	var backups []prunableBackup
+	for i := range 3 {
+		for _, repository := range []string{"localhost:5000/world", "localhost:5000/hello"} {
+			backups = append(backups, prunableBackup{
+				name:         repository + "-" + string(rune('a'+i)),
+				created:      now.Add(-time.Duration(i) * time.Hour),
+				repositories: repository,
+			})
+		}
+	}
+	var out bytes.Buffer
+	handler := text.NewBackupPruneHandler(output.NewPrinter(&out, io.Discard), "backups", true)
+	expired, err := selectExpiredBackups(backups, retention.Policy{Last: 1}, now, handler)
+	if err != nil {
+		t.Fatalf("selectExpiredBackups() error = %v", err)
+	}
+	var got []string
+	for _, backup := range expired {
+		got = append(got, backup.name)
+	}
+	want := []string{"localhost:5000/hello-b", "localhost:5000/hello-c", "localhost:5000/world-b", "localhost:5000/world-c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("selectExpiredBackups() = %v, want %v", got, want)
+	}
+}
+
+func Test_parseRetentionPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    backupPruneOptions
+		want    retention.Policy
+		wantErr bool
+	}{
+		{
+			name: "last and weekly",
+			opts: backupPruneOptions{keepLast: 7, keepWeekly: 4},
+			want: retention.Policy{Last: 7, Weekly: 4},
+		},
+		{
+			name: "within",
+			opts: backupPruneOptions{keepWithin: "30d"},
+			want: retention.Policy{Within: 30 * 24 * time.Hour},
+		},
+		{name: "no policy", opts: backupPruneOptions{}, wantErr: true},
+		{name: "negative", opts: backupPruneOptions{keepLast: -1, keepDaily: 1}, wantErr: true},
+		{name: "invalid duration", opts: backupPruneOptions{keepWithin: "a month"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parseRetentionPolicy(&tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRetentionPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.opts.policy != tt.want {
+				t.Errorf("parseRetentionPolicy() = %v, want %v", tt.opts.policy, tt.want)
+			}
+		})
+	}
+}
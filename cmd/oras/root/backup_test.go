@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -37,6 +38,10 @@ import (
 	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/cmd/oras/internal/display/status"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/compression"
+	"oras.land/oras/internal/objectstore"
 )
 
 func TestParseArtifactReferences(t *testing.T) {
@@ -676,6 +681,102 @@ func Test_countReferrers(t *testing.T) {
 	})
 }
 
+func Test_backupReferrers(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	tag := "v1"
+
+	layerDesc, err := oras.PushBytes(ctx, src, "application/vnd.test.layer", []byte("layer"))
+	if err != nil {
+		t.Fatalf("failed to push layer: %v", err)
+	}
+	manifestDesc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "test/artifact", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layerDesc},
+	})
+	if err != nil {
+		t.Fatalf("failed to create manifest: %v", err)
+	}
+	signatureLayerDesc, err := oras.PushBytes(ctx, src, "application/vnd.test.signature", []byte("signature"))
+	if err != nil {
+		t.Fatalf("failed to push signature layer: %v", err)
+	}
+	referrerDesc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "test/signature", oras.PackManifestOptions{
+		Subject: &manifestDesc,
+		Layers:  []ocispec.Descriptor{signatureLayerDesc},
+	})
+	if err != nil {
+		t.Fatalf("failed to create referrer: %v", err)
+	}
+	nestedReferrerDesc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "test/nested", oras.PackManifestOptions{Subject: &referrerDesc})
+	if err != nil {
+		t.Fatalf("failed to create nested referrer: %v", err)
+	}
+
+	dst := memory.New()
+	count, err := backupReferrers(ctx, src, dst, tag, manifestDesc, oras.DefaultExtendedCopyGraphOptions)
+	if err != nil {
+		t.Fatalf("backupReferrers() error = %v, wantErr nil", err)
+	}
+	if wantCount := 2; count != wantCount {
+		t.Errorf("backupReferrers() count = %d, want %d", count, wantCount)
+	}
+	for _, desc := range []ocispec.Descriptor{manifestDesc, referrerDesc, signatureLayerDesc, nestedReferrerDesc} {
+		if exists, err := dst.Exists(ctx, desc); err != nil || !exists {
+			t.Errorf("dst.Exists(%s) = %v, %v, want true, nil", desc.Digest, exists, err)
+		}
+	}
+	if exists, err := dst.Exists(ctx, layerDesc); err != nil || exists {
+		t.Errorf("dst.Exists(%s) = %v, %v, want false, nil", layerDesc.Digest, exists, err)
+	}
+	got, err := dst.Resolve(ctx, tag)
+	if err != nil {
+		t.Fatalf("dst.Resolve() error = %v, wantErr nil", err)
+	}
+	if !content.Equal(got, manifestDesc) {
+		t.Errorf("dst.Resolve() = %v, want %v", got, manifestDesc)
+	}
+
+	// restore the referrers to a target with the artifact only
+	restoreDst := memory.New()
+	if err := oras.CopyGraph(ctx, src, restoreDst, manifestDesc, oras.DefaultCopyGraphOptions); err != nil {
+		t.Fatalf("failed to copy artifact: %v", err)
+	}
+	subjects, referrers, err := findReferrers(ctx, dst, tag, manifestDesc, oras.DefaultExtendedCopyGraphOptions)
+	if err != nil {
+		t.Fatalf("findReferrers() error = %v, wantErr nil", err)
+	}
+	if err := copyReferrers(ctx, dst, restoreDst, subjects, referrers, oras.DefaultCopyGraphOptions); err != nil {
+		t.Fatalf("copyReferrers() error = %v, wantErr nil", err)
+	}
+	gotReferrers, err := restoreDst.Predecessors(ctx, manifestDesc)
+	if err != nil {
+		t.Fatalf("restoreDst.Predecessors() error = %v, wantErr nil", err)
+	}
+	if len(gotReferrers) != 1 || !content.Equal(gotReferrers[0], referrerDesc) {
+		t.Errorf("restoreDst.Predecessors() = %v, want [%v]", gotReferrers, referrerDesc)
+	}
+	if exists, err := restoreDst.Exists(ctx, nestedReferrerDesc); err != nil || !exists {
+		t.Errorf("restoreDst.Exists(%s) = %v, %v, want true, nil", nestedReferrerDesc.Digest, exists, err)
+	}
+}
+
+func Test_repositoryDirName(t *testing.T) {
+	tests := []struct {
+		repository string
+		want       string
+	}{
+		{"localhost:5000/hello", filepath.Join("localhost_5000", "hello")},
+		{"registry.example.com/org/hello", filepath.Join("registry.example.com", "org", "hello")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repository, func(t *testing.T) {
+			if got := repositoryDirName(tt.repository); got != tt.want {
+				t.Errorf("repositoryDirName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // Mock implementations
 type mockLogger struct {
 	debugMessages []string
@@ -740,6 +841,19 @@ func (m *mockBackupHandler) OnTarExported(path string, size int64) error {
 	return m.tarExportedResult
 }
 
+func (m *mockBackupHandler) OnTarVolumesExported(path string, count int, size int64) error {
+	m.tarExportedCalled = true
+	return m.tarExportedResult
+}
+
+func (m *mockBackupHandler) OnBlobsDeduplicated(count int, size int64) error {
+	return nil
+}
+
+func (m *mockBackupHandler) OnBackupResumed(count int) error {
+	return nil
+}
+
 func (m *mockBackupHandler) OnTagsFound(tags []string) error {
 	return nil
 }
@@ -748,6 +862,10 @@ func (m *mockBackupHandler) OnArtifactPulled(tag string, referrerCount int) erro
 	return nil
 }
 
+func (m *mockBackupHandler) OnArtifactFailed(tag string, err error) error {
+	return nil
+}
+
 func (m *mockBackupHandler) OnBackupCompleted(tagsCount int, path string, duration time.Duration) error {
 	return nil
 }
@@ -755,3 +873,200 @@ func (m *mockBackupHandler) OnBackupCompleted(tagsCount int, path string, durati
 func (m *mockBackupHandler) Render() error {
 	return nil
 }
+
+func Test_planBackup_downloadBlobs(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	var layers []ocispec.Descriptor
+	for _, blob := range []string{"foo", "bar", "baz"} {
+		desc, err := oras.PushBytes(ctx, src, "application/vnd.test.layer", []byte(blob))
+		if err != nil {
+			t.Fatalf("failed to push layer: %v", err)
+		}
+		layers = append(layers, desc)
+	}
+	manifestDesc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "test/artifact", oras.PackManifestOptions{
+		Layers: layers,
+	})
+	if err != nil {
+		t.Fatalf("failed to create manifest: %v", err)
+	}
+
+	// the first layer is downloaded by an interrupted backup
+	dst := memory.New()
+	if err := oras.CopyGraph(ctx, src, dst, layers[0], oras.DefaultCopyGraphOptions); err != nil {
+		t.Fatalf("failed to copy layer: %v", err)
+	}
+	opts := &backupOptions{outputFormat: outputFormatDir}
+	pending, err := planBackup(ctx, opts, src, dst, t.TempDir(), []ocispec.Descriptor{manifestDesc})
+	if err != nil {
+		t.Fatalf("planBackup() error = %v", err)
+	}
+	// the config of the manifest is the empty descriptor
+	want := map[string]bool{
+		ocispec.DescriptorEmptyJSON.Digest.String(): true,
+		layers[1].Digest.String():                   true,
+		layers[2].Digest.String():                   true,
+	}
+	if len(pending) != len(want) {
+		t.Fatalf("planBackup() = %v, want %d blobs", pending, len(want))
+	}
+	for _, desc := range pending {
+		if !want[desc.Digest.String()] {
+			t.Errorf("planBackup() returned unexpected blob %s", desc.Digest)
+		}
+	}
+
+	statusHandler := status.NewTextBackupHandler(output.NewPrinter(io.Discard, io.Discard), dst)
+	downloaded, err := downloadBlobs(ctx, src, dst, pending, 2, false, statusHandler)
+	if err != nil {
+		t.Fatalf("downloadBlobs() error = %v", err)
+	}
+	for _, desc := range pending {
+		if !downloaded[desc.Digest] {
+			t.Errorf("downloadBlobs() did not report %s as downloaded", desc.Digest)
+		}
+		if exists, err := dst.Exists(ctx, desc); err != nil || !exists {
+			t.Errorf("dst.Exists(%s) = %v, %v, want true, nil", desc.Digest, exists, err)
+		}
+	}
+	if downloaded[layers[0].Digest] {
+		t.Errorf("downloadBlobs() reported %s already present as downloaded", layers[0].Digest)
+	}
+}
+
+func Test_downloadBlobs_continueOnError(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	foo, err := oras.PushBytes(ctx, src, "application/vnd.test.layer", []byte("foo"))
+	if err != nil {
+		t.Fatalf("failed to push layer: %v", err)
+	}
+	missing := content.NewDescriptorFromBytes("application/vnd.test.layer", []byte("missing"))
+	blobs := []ocispec.Descriptor{foo, missing}
+
+	dst := memory.New()
+	statusHandler := status.NewTextBackupHandler(output.NewPrinter(io.Discard, io.Discard), dst)
+	if _, err := downloadBlobs(ctx, src, dst, blobs, 1, false, statusHandler); err == nil {
+		t.Fatal("downloadBlobs() error = nil, want error for the missing blob")
+	}
+
+	dst = memory.New()
+	statusHandler = status.NewTextBackupHandler(output.NewPrinter(io.Discard, io.Discard), dst)
+	downloaded, err := downloadBlobs(ctx, src, dst, blobs, 1, true, statusHandler)
+	if err != nil {
+		t.Fatalf("downloadBlobs() error = %v", err)
+	}
+	if !downloaded[foo.Digest] {
+		t.Errorf("downloadBlobs() did not report %s as downloaded", foo.Digest)
+	}
+	if downloaded[missing.Digest] {
+		t.Errorf("downloadBlobs() reported the missing blob %s as downloaded", missing.Digest)
+	}
+}
+
+func Test_backupStagingDir(t *testing.T) {
+	dir, err := backupStagingDir("hello.tar")
+	if err != nil {
+		t.Fatalf("backupStagingDir() error = %v", err)
+	}
+	if filepath.Dir(dir) != filepath.Clean(os.TempDir()) {
+		t.Errorf("backupStagingDir() = %s, want a directory under %s", dir, os.TempDir())
+	}
+	abs, err := filepath.Abs("hello.tar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := backupStagingDir(abs); err != nil || again != dir {
+		t.Errorf("backupStagingDir(%q) = %s, %v, want %s", abs, again, err, dir)
+	}
+	if other, err := backupStagingDir("world.tar"); err != nil || other == dir {
+		t.Errorf("backupStagingDir() of another output = %s, %v, want a different directory", other, err)
+	}
+}
+
+func Test_parseBackupCompression(t *testing.T) {
+	tests := []struct {
+		name             string
+		output           string
+		compress         string
+		compressionLevel int
+		want             string
+		wantErr          bool
+	}{
+		{name: "tar", output: "hello.tar", want: compression.None},
+		{name: "gzip by extension", output: "hello.tgz", want: compression.Gzip},
+		{name: "zstd by extension with level", output: "hello.tar.zst", compressionLevel: 3, want: compression.Zstd},
+		{name: "selected for tar", output: "hello.tar", compress: compression.Zstd, want: compression.Zstd},
+		{name: "selected matching extension", output: "hello.tar.gz", compress: compression.Gzip, compressionLevel: 1, want: compression.Gzip},
+		{name: "disabled for tar", output: "hello.tar", compress: compression.None, want: compression.None},
+		{name: "directory", output: "hello"},
+		{name: "conflicting extension", output: "hello.tar.gz", compress: compression.Zstd, wantErr: true},
+		{name: "selected for directory", output: "hello", compress: compression.Gzip, wantErr: true},
+		{name: "level without compression", output: "hello.tar", compressionLevel: 3, wantErr: true},
+		{name: "level for directory", output: "hello", compressionLevel: 3, wantErr: true},
+		{name: "level out of range", output: "hello.tar.gz", compressionLevel: 10, wantErr: true},
+		{name: "unsupported", output: "hello.tar", compress: "brotli", wantErr: true},
+		{name: "object by extension", output: "s3://bucket/hello.tar.zst", want: compression.Zstd},
+		{name: "selected for object", output: "s3://bucket/hello.tar", compress: compression.Gzip, want: compression.Gzip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &backupOptions{
+				output:           tt.output,
+				compress:         tt.compress,
+				compressionLevel: tt.compressionLevel,
+				outputFormat:     outputFormatDir,
+			}
+			if err := parseBackupOutput(opts); err != nil {
+				t.Fatal(err)
+			}
+			err := parseBackupCompression(opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBackupCompression() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && opts.compression != tt.want {
+				t.Errorf("parseBackupCompression() compression = %q, want %q", opts.compression, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseBackupOutput(t *testing.T) {
+	tests := []struct {
+		name            string
+		output          string
+		wantFormat      outputFormat
+		wantCompression string
+		wantLocation    objectstore.Location
+		wantErr         bool
+	}{
+		{name: "directory", output: "hello", wantFormat: outputFormatDir},
+		{name: "tar", output: "hello.tar", wantFormat: outputFormatTar, wantCompression: compression.None},
+		{name: "compressed tar", output: "hello.tgz", wantFormat: outputFormatTar, wantCompression: compression.Gzip},
+		{
+			name:            "object",
+			output:          "s3://bucket/backups/hello.tar.zst",
+			wantFormat:      outputFormatObjectStorage,
+			wantCompression: compression.Zstd,
+			wantLocation:    objectstore.Location{Bucket: "bucket", Key: "backups/hello.tar.zst"},
+		},
+		{name: "object not a tar archive", output: "s3://bucket/backups/hello", wantErr: true},
+		{name: "bucket only", output: "s3://bucket", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &backupOptions{output: tt.output}
+			err := parseBackupOutput(opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBackupOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts.outputFormat != tt.wantFormat || opts.compression != tt.wantCompression || opts.objectLocation != tt.wantLocation {
+				t.Errorf("parseBackupOutput() = %v, %q, %v, want %v, %q, %v", opts.outputFormat, opts.compression, opts.objectLocation, tt.wantFormat, tt.wantCompression, tt.wantLocation)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/bench"
+	"oras.land/oras/internal/bytesize"
+	"oras.land/oras/internal/registryutil"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench [command]",
+		Short: "[Experimental] Benchmark blob transfers",
+	}
+
+	cmd.AddCommand(
+		pushCmd(),
+		pullCmd(),
+	)
+	return cmd
+}
+
+type benchOptions struct {
+	option.Common
+	option.Target
+	option.Format
+
+	sizes         []string
+	count         int
+	concurrencies []int
+	seed          uint64
+	keep          bool
+
+	config bench.Config
+}
+
+// applyBenchFlags applies the flags shared by bench commands.
+func applyBenchFlags(opts *benchOptions, fs *pflag.FlagSet) {
+	fs.StringSliceVar(&opts.sizes, "size", []string{"1MB"}, "sizes of the generated blobs, e.g. 64KB,10MB,1GB")
+	fs.IntVar(&opts.count, "count", 10, "number of blobs transferred per run")
+	fs.IntSliceVar(&opts.concurrencies, "concurrency", []int{1, 4}, "numbers of concurrent transfers, one run is performed per size and concurrency")
+	fs.Uint64Var(&opts.seed, "seed", 0, "seed of the generated blob content, random if not specified")
+	fs.BoolVar(&opts.keep, "keep", false, "keep the generated blobs in the target after the benchmark")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON)
+	option.ApplyFlags(opts, fs)
+}
+
+// parse parses the bench options for the given operation.
+func (opts *benchOptions) parse(cmd *cobra.Command, op bench.Operation) error {
+	if err := option.Parse(cmd, opts); err != nil {
+		return err
+	}
+	if opts.Reference != "" {
+		return fmt.Errorf("%q: tag or digest is not allowed, blobs are generated for the benchmark", opts.RawReference)
+	}
+	if opts.count < 1 {
+		return fmt.Errorf("invalid count %d, must be at least 1", opts.count)
+	}
+	opts.config = bench.Config{
+		Operation: op,
+		Count:     opts.count,
+		Seed:      opts.seed,
+		Keep:      opts.keep,
+	}
+	if !cmd.Flags().Changed("seed") {
+		opts.config.Seed = rand.Uint64()
+	}
+	for _, s := range opts.sizes {
+		size, err := bytesize.Parse(s)
+		if err != nil {
+			return err
+		}
+		opts.config.Sizes = append(opts.config.Sizes, size)
+	}
+	for _, c := range opts.concurrencies {
+		if c < 1 {
+			return fmt.Errorf("invalid concurrency %d, must be at least 1", c)
+		}
+	}
+	opts.config.Concurrencies = opts.concurrencies
+	return nil
+}
+
+func runBench(cmd *cobra.Command, opts *benchOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	target, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	ctx = registryutil.WithScopeHint(ctx, target, auth.ActionPull, auth.ActionPush, auth.ActionDelete)
+	handler, err := display.NewBenchHandler(opts.Printer, opts.Format, opts.Path)
+	if err != nil {
+		return err
+	}
+	logger.Infof("benchmarking %s with seed %d", opts.config.Operation, opts.config.Seed)
+	if err := bench.Run(ctx, target, opts.config, handler.OnRunCompleted); err != nil {
+		return err
+	}
+	return handler.Render()
+}
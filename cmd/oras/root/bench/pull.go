@@ -0,0 +1,58 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/argument"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/bench"
+)
+
+func pullCmd() *cobra.Command {
+	var opts benchOptions
+	cmd := &cobra.Command{
+		Use:   "pull [flags] <name>",
+		Short: "[Experimental] Benchmark downloading blobs from a repository",
+		Long: `[Experimental] Benchmark downloading blobs from a repository
+
+Random blobs are generated in memory and pushed to the repository without being
+measured, then pulled back. A run is performed for every combination of blob
+size and concurrency, reporting the throughput and the latency percentiles of
+single blob downloads. The generated blobs are deleted after each run unless
+--keep is specified.
+
+Example - Benchmark downloading ten 1 MB blobs sequentially and with 4 concurrent transfers:
+  oras bench pull localhost:5000/bench
+
+Example - Benchmark downloading blobs of several sizes at several concurrency levels:
+  oras bench pull --size 64KB,10MB --concurrency 1,4,16 --count 20 localhost:5000/bench
+
+Example - Benchmark downloading reproducible blobs and output the results in JSON format:
+  oras bench pull --seed 42 --format json localhost:5000/bench
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the repository to benchmark"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			return opts.parse(cmd, bench.OperationPull)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBench(cmd, &opts)
+		},
+	}
+	applyBenchFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
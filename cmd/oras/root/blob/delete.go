@@ -53,6 +53,9 @@ Example - Delete a blob:
 Example - Delete a blob without prompting confirmation:
   oras blob delete --force localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
 
+Example - Delete a blob, answering yes to the confirmation prompt:
+  oras blob delete --yes localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
 Example - Delete a blob and print its descriptor:
   oras blob delete --descriptor --force localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
   `,
@@ -70,6 +73,7 @@ Example - Delete a blob and print its descriptor:
 	}
 
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
+	opts.EnableDryRunFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
 }
@@ -100,7 +104,7 @@ func deleteBlob(cmd *cobra.Command, opts *deleteBlobOptions) (err error) {
 	}
 
 	prompt := fmt.Sprintf("Are you sure you want to delete the blob %q?", desc.Digest)
-	confirmed, err := opts.AskForConfirmation(os.Stdin, prompt)
+	confirmed, err := opts.AskForTypedConfirmation(os.Stdin, prompt, opts.Path)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,212 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blob
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/registryutil"
+)
+
+type existsBlobOptions struct {
+	option.Common
+	option.Pretty
+	option.Target
+
+	digestsFile string
+	concurrency int
+}
+
+// blobExistence is the existence of a blob reported by "oras blob exists".
+type blobExistence struct {
+	Digest digest.Digest `json:"digest"`
+	Exists bool          `json:"exists"`
+	Size   int64         `json:"size,omitempty"`
+}
+
+// existsReport is the JSON output of "oras blob exists".
+type existsReport struct {
+	Repository string          `json:"repository"`
+	Blobs      []blobExistence `json:"blobs"`
+}
+
+func existsCmd() *cobra.Command {
+	var opts existsBlobOptions
+	cmd := &cobra.Command{
+		Use:   "exists [flags] {<name> --digests-file <file> | <name>@<digest>}",
+		Short: "[Experimental] Check whether blobs exist in a registry or an OCI image layout",
+		Long: `[Experimental] Check whether blobs exist in a registry or an OCI image layout
+
+The digests are checked concurrently and the existence of each blob is printed
+as JSON, in the order of the digests.
+
+Example - Check whether the blobs listed in 'digests.txt', one digest per line, exist:
+  oras blob exists localhost:5000/hello --digests-file digests.txt
+
+Example - Check whether the blobs of the digests read from stdin exist:
+  sha256sum -b *.tar.gz | awk '{print "sha256:"$1}' | oras blob exists localhost:5000/hello --digests-file -
+
+Example - Check whether a blob exists:
+  oras blob exists localhost:5000/hello@sha256:9a201d228ebd966211f7d1131be19f152be428bd373a92071c71d8deaf83b3e5
+
+Example - Check whether the blobs exist in OCI image layout folder 'layout-dir':
+  oras blob exists --oci-layout layout-dir --digests-file digests.txt
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the repository to check"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			if opts.digestsFile == "-" {
+				if err := option.CheckStdinConflict(cmd.Flags()); err != nil {
+					return err
+				}
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return existsBlobs(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.digestsFile, "digests-file", "", "", "`file` listing the digests of the blobs to check, one per line, or - for stdin")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func existsBlobs(cmd *cobra.Command, opts *existsBlobOptions) error {
+	var digests []digest.Digest
+	if opts.Reference != "" {
+		dgst, err := digest.Parse(opts.Reference)
+		if err != nil {
+			return fmt.Errorf("%s: blobs can only be referenced by digest: %w", opts.RawReference, err)
+		}
+		digests = append(digests, dgst)
+	}
+	if opts.digestsFile != "" {
+		listed, err := readDigestsFile(opts.digestsFile, os.Stdin)
+		if err != nil {
+			return err
+		}
+		digests = append(digests, listed...)
+	}
+	if len(digests) == 0 {
+		return &oerrors.Error{
+			Err:            errors.New("no digests to check"),
+			Recommendation: "Specify the digests with --digests-file or the blob as <name>@<digest>",
+		}
+	}
+
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	blobs, err := opts.NewBlobDeleter(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	ctx = registryutil.WithScopeHint(ctx, blobs, auth.ActionPull)
+	results, err := checkBlobs(ctx, blobs, digests, opts.concurrency)
+	if err != nil {
+		return err
+	}
+	report, err := json.Marshal(existsReport{
+		Repository: opts.Path,
+		Blobs:      results,
+	})
+	if err != nil {
+		return err
+	}
+	return opts.Output(os.Stdout, report)
+}
+
+// readDigestsFile reads the digests listed one per line in the file at path,
+// or stdin if path is "-". Blank lines and lines starting with # are ignored,
+// and duplicate digests are listed once.
+func readDigestsFile(path string, stdin io.Reader) ([]digest.Digest, error) {
+	var r io.Reader = stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read digests file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	var digests []digest.Digest
+	seen := make(map[digest.Digest]bool)
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dgst, err := digest.Parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid digest %q at line %d of %s: %w", line, lineNum, path, err)
+		}
+		if !seen[dgst] {
+			seen[dgst] = true
+			digests = append(digests, dgst)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read digests file %s: %w", path, err)
+	}
+	return digests, nil
+}
+
+// checkBlobs resolves the blobs of digests concurrently and returns their
+// existence in the order of digests.
+func checkBlobs(ctx context.Context, blobs content.Resolver, digests []digest.Digest, concurrency int) ([]blobExistence, error) {
+	results := make([]blobExistence, len(digests))
+	eg, egCtx := errgroup.WithContext(ctx)
+	if concurrency > 0 {
+		eg.SetLimit(concurrency)
+	}
+	for i, dgst := range digests {
+		eg.Go(func() error {
+			results[i].Digest = dgst
+			desc, err := blobs.Resolve(egCtx, dgst.String())
+			if err != nil {
+				if errors.Is(err, errdef.ErrNotFound) {
+					return nil
+				}
+				return fmt.Errorf("failed to check %s: %w", dgst, err)
+			}
+			results[i].Exists = true
+			results[i].Size = desc.Size
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func Test_readDigestsFile(t *testing.T) {
+	foo := digest.FromString("foo")
+	bar := digest.FromString("bar")
+	tests := []struct {
+		name    string
+		input   string
+		want    []digest.Digest
+		wantErr bool
+	}{
+		{
+			name:  "digests with comments and blank lines",
+			input: "# blobs\n" + foo.String() + "\n\n  " + bar.String() + "  \n",
+			want:  []digest.Digest{foo, bar},
+		},
+		{
+			name:  "duplicate digests",
+			input: foo.String() + "\n" + bar.String() + "\n" + foo.String() + "\n",
+			want:  []digest.Digest{foo, bar},
+		},
+		{
+			name:  "empty",
+			input: "\n# nothing\n",
+		},
+		{
+			name:    "invalid digest",
+			input:   foo.String() + "\nsha256:foo\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readDigestsFile("-", strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readDigestsFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readDigestsFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testBlobResolver resolves the blobs of a map.
+type testBlobResolver map[string]ocispec.Descriptor
+
+func (r testBlobResolver) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	if reference == "error" {
+		return ocispec.Descriptor{}, errors.New("error")
+	}
+	desc, ok := r[reference]
+	if !ok {
+		return ocispec.Descriptor{}, errdef.ErrNotFound
+	}
+	return desc, nil
+}
+
+func Test_checkBlobs(t *testing.T) {
+	foo := digest.FromString("foo")
+	bar := digest.FromString("bar")
+	baz := digest.FromString("baz")
+	blobs := testBlobResolver{
+		foo.String(): {Digest: foo, Size: 3},
+		baz.String(): {Digest: baz, Size: 3},
+	}
+	got, err := checkBlobs(context.Background(), blobs, []digest.Digest{foo, bar, baz}, 2)
+	if err != nil {
+		t.Fatalf("checkBlobs() error = %v", err)
+	}
+	want := []blobExistence{
+		{Digest: foo, Exists: true, Size: 3},
+		{Digest: bar},
+		{Digest: baz, Exists: true, Size: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("checkBlobs() = %v, want %v", got, want)
+	}
+
+	if _, err := checkBlobs(context.Background(), blobs, []digest.Digest{"error"}, 2); err == nil {
+		t.Error("checkBlobs() error = nil, want error")
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/status"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/bundle"
+	"oras.land/oras/internal/ledger"
+	"oras.land/oras/internal/version"
+)
+
+type createOptions struct {
+	option.Common
+	option.LedgerWriter
+	option.Platform
+	option.Target
+	option.TempDir
+	option.Terminal
+
+	output      string
+	compress    bool
+	recursive   bool
+	concurrency int
+}
+
+func createCmd() *cobra.Command {
+	var opts createOptions
+	cmd := &cobra.Command{
+		Use:   "create [flags] <name>{:<tag>|@<digest>} <file>",
+		Short: "[Experimental] Export an artifact into a single-file bundle",
+		Long: `[Experimental] Export an artifact into a single-file bundle
+
+A bundle is an OCI image layout tar archive starting with a "bundle.json" file,
+which lists the checksums of all the blobs in the bundle so that the bundle can
+be verified after being carried to another site. Bundles ending with ".tar.gz"
+or ".tgz" are compressed with gzip, and bundles ending with ".tar" are not.
+
+Example - Export an artifact into a compressed bundle:
+  oras bundle create localhost:5000/hello:v1 hello.oci.tar.gz
+
+Example - Export an artifact and its referrers into a compressed bundle:
+  oras bundle create -r localhost:5000/hello:v1 hello.oci.tar.gz
+
+Example - Export a specific platform of a multi-arch image into an uncompressed bundle:
+  oras bundle create --platform linux/arm64 localhost:5000/hello:v1 hello.oci.tar
+
+Example - Export an artifact from an OCI image layout folder 'layout-dir' into a bundle:
+  oras bundle create --oci-layout layout-dir:v1 hello.oci.tar.gz
+
+Example - Export an artifact into a bundle along with a transfer ledger signed by the key 'signing.key':
+  oras bundle create --ledger hello.ledger.json --ledger-signing-key signing.key localhost:5000/hello:v1 hello.oci.tar.gz
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(2), "the artifact to export and the bundle file to create"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			opts.output = args[1]
+			var err error
+			if opts.compress, err = bundle.IsCompressed(opts.output); err != nil {
+				return &oerrors.Error{
+					Err:            err,
+					Recommendation: `Name the bundle with the extension ".tar.gz" to compress it with gzip`,
+				}
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(cmd, &opts)
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "r", false, "export the referrers of the artifact as well")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runCreate(cmd *cobra.Command, opts *createOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	src, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	resolveOpts := oras.DefaultResolveOptions
+	resolveOpts.TargetPlatform = opts.Platform.Platform
+	root, err := oras.Resolve(ctx, src, opts.Reference, resolveOpts)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
+	}
+
+	staging, err := os.MkdirTemp("", "oras-bundle-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(staging); err != nil {
+			logger.Debugf("failed to remove temporary directory %s: %v", staging, err)
+		}
+	}()
+	store, err := oci.New(staging)
+	if err != nil {
+		return err
+	}
+	statusHandler, _, err := display.NewCopyHandler(opts.Printer, option.Format{Type: option.FormatTypeText.Name}, opts.TTY, store)
+	if err != nil {
+		return err
+	}
+	if err := exportGraph(ctx, statusHandler, src, store, root, opts); err != nil {
+		return err
+	}
+	tag := opts.Reference
+	if _, err := digest.Parse(tag); err == nil || opts.Platform.Platform != nil {
+		// the tag of a platform-specific manifest would be misleading
+		tag = ""
+	}
+	if tag != "" {
+		if err := store.Tag(ctx, root, tag); err != nil {
+			return err
+		}
+	}
+
+	// the ingest directory of the store is not part of the layout
+	if err := os.RemoveAll(filepath.Join(staging, "ingest")); err != nil {
+		return err
+	}
+	blobs, size, err := bundle.ListBlobs(staging)
+	if err != nil {
+		return err
+	}
+	m := &bundle.Manifest{
+		Tool:    "oras",
+		Version: version.GetVersion(),
+		Created: time.Now().UTC(),
+		Source:  opts.RawReference,
+		Tag:     tag,
+		Root:    root,
+		Blobs:   blobs,
+		Size:    size,
+	}
+	if err := opts.TempDir.CheckSpace(ctx, filepath.Dir(opts.output), size, "the bundle"); err != nil {
+		return err
+	}
+	if err := writeBundle(opts.output, staging, m, opts.compress); err != nil {
+		return err
+	}
+	if opts.LedgerWriter.Enabled() {
+		entries := make([]ledger.Entry, len(blobs))
+		for i, blob := range blobs {
+			entries[i] = ledger.Entry{
+				Digest: blob.Digest,
+				Size:   blob.Size,
+				Source: opts.RawReference,
+			}
+		}
+		if err := opts.LedgerWriter.Write("bundle create", entries); err != nil {
+			return err
+		}
+	}
+	fi, err := os.Stat(opts.output)
+	if err != nil {
+		return err
+	}
+	if err := opts.Printer.Println("Created", opts.output, "with", len(blobs), "blobs,", fi.Size(), "bytes"); err != nil {
+		return err
+	}
+	return opts.Printer.Println("Digest:", root.Digest)
+}
+
+// exportGraph copies the graph of root, along with the referrers of root if
+// requested, from src to the OCI image layout store.
+func exportGraph(ctx context.Context, statusHandler status.CopyHandler, src oras.ReadOnlyGraphTarget, store oras.GraphTarget, root ocispec.Descriptor, opts *createOptions) (err error) {
+	dst, err := statusHandler.StartTracking(store)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		stopErr := statusHandler.StopTracking()
+		if err == nil {
+			err = stopErr
+		}
+	}()
+	copyOpts := oras.DefaultExtendedCopyGraphOptions
+	copyOpts.Concurrency = opts.concurrency
+	copyOpts.OnCopySkipped = statusHandler.OnCopySkipped
+	copyOpts.PreCopy = statusHandler.PreCopy
+	copyOpts.PostCopy = statusHandler.PostCopy
+	if !opts.recursive {
+		return oras.CopyGraph(ctx, src, dst, root, copyOpts.CopyGraphOptions)
+	}
+	copyOpts.FindPredecessors = func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		return registry.Referrers(ctx, src, desc, "")
+	}
+	return oras.ExtendedCopyGraph(ctx, src, dst, root, copyOpts)
+}
+
+// writeBundle writes the bundle of the OCI image layout at root to path.
+// The bundle is written to a temporary file renamed to path on success, so
+// that no partial bundle is left behind.
+func writeBundle(path string, root string, m *bundle.Manifest, compress bool) (err error) {
+	fp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create bundle %s: %w", path, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = fp.Close()
+			if removeErr := os.Remove(fp.Name()); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+				err = errors.Join(err, removeErr)
+			}
+		}
+	}()
+	if err := fp.Chmod(0644); err != nil {
+		return err
+	}
+	if err := bundle.Write(fp, root, m, compress); err != nil {
+		return fmt.Errorf("failed to write bundle %s: %w", path, err)
+	}
+	if err := fp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(fp.Name(), path)
+}
@@ -0,0 +1,215 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/status"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/bundle"
+	"oras.land/oras/internal/registryutil"
+)
+
+type pushOptions struct {
+	option.Common
+	option.LedgerReader
+	option.Target
+	option.TempDir
+	option.Terminal
+
+	input       string
+	concurrency int
+}
+
+func pushCmd() *cobra.Command {
+	var opts pushOptions
+	cmd := &cobra.Command{
+		Use:   "push [flags] <file> <name>[:<tag>|@<digest>]",
+		Short: "[Experimental] Import an artifact from a single-file bundle",
+		Long: `[Experimental] Import an artifact from a single-file bundle
+
+The checksums of all the blobs in the bundle are verified against the
+"bundle.json" file of the bundle before anything is pushed. The artifact is
+tagged with the tag it is exported with if no tag is specified. If a transfer
+ledger is specified, only the blobs listed in the ledger are pushed.
+
+Example - Import an artifact from a bundle, tagged with the tag it is exported with:
+  oras bundle push hello.oci.tar.gz localhost:5000/hello
+
+Example - Import an artifact from a bundle with a specific tag:
+  oras bundle push hello.oci.tar.gz localhost:5000/hello:v1-imported
+
+Example - Import an artifact from a bundle into an OCI image layout folder 'layout-dir':
+  oras bundle push --oci-layout hello.oci.tar.gz layout-dir
+
+Example - Import an artifact from a bundle, verifying its content against a transfer ledger signed by the key 'signing.pub':
+  oras bundle push --ledger hello.ledger.json --ledger-key signing.pub hello.oci.tar.gz localhost:5000/hello
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(2), "the bundle file to import and the destination to push to"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.input = args[0]
+			opts.RawReference = args[1]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPush(cmd, &opts)
+		},
+	}
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runPush(cmd *cobra.Command, opts *pushOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	staging, err := os.MkdirTemp("", "oras-bundle-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(staging); err != nil {
+			logger.Debugf("failed to remove temporary directory %s: %v", staging, err)
+		}
+	}()
+	if err := checkExtractSpace(ctx, opts.input, &opts.TempDir); err != nil {
+		return err
+	}
+	m, err := extractBundle(opts.input, staging)
+	if err != nil {
+		return err
+	}
+	if err := m.Verify(staging, nil); err != nil {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("failed to verify bundle %s: %w", opts.input, err),
+			Recommendation: "The bundle is corrupted or tampered with. Create the bundle again from the source",
+		}
+	}
+	if err := opts.Printer.Println("Verified", len(m.Blobs), "blobs of", m.Source, "in", opts.input); err != nil {
+		return err
+	}
+	var src oras.ReadOnlyGraphTarget
+	if src, err = oci.NewFromFS(ctx, os.DirFS(staging)); err != nil {
+		return err
+	}
+	if l := opts.LedgerReader.Ledger; l != nil {
+		for _, blob := range m.Blobs {
+			if err := l.Check(ocispec.Descriptor{Digest: blob.Digest, Size: blob.Size}); err != nil {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("failed to verify bundle %s against the transfer ledger %s: %w", opts.input, opts.LedgerReader.LedgerPath, err),
+					Recommendation: "Make sure the transfer ledger is created along with the bundle",
+				}
+			}
+		}
+		if err := opts.Printer.Println("Verified", len(m.Blobs), "blobs against the transfer ledger", opts.LedgerReader.LedgerPath); err != nil {
+			return err
+		}
+		src = l.Target(src)
+	}
+
+	dst, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
+	ref := opts.Reference
+	if ref == "" {
+		ref = m.Tag
+	}
+	if dgst, err := digest.Parse(ref); err == nil && dgst != m.Root.Digest {
+		return fmt.Errorf("the bundled artifact %s does not match the destination digest %s", m.Root.Digest, dgst)
+	}
+	statusHandler, _, err := display.NewCopyHandler(opts.Printer, option.Format{Type: option.FormatTypeText.Name}, opts.TTY, dst)
+	if err != nil {
+		return err
+	}
+	if err := importGraph(ctx, statusHandler, src, dst, m.Root, opts.concurrency); err != nil {
+		return err
+	}
+	if ref != "" && ref != m.Root.Digest.String() {
+		if err := dst.Tag(ctx, m.Root, ref); err != nil {
+			return err
+		}
+		if err := opts.Printer.Println("Pushed", opts.Path+":"+ref); err != nil {
+			return err
+		}
+	} else if err := opts.Printer.Println("Pushed", opts.Path+"@"+m.Root.Digest.String()); err != nil {
+		return err
+	}
+	return opts.Printer.Println("Digest:", m.Root.Digest)
+}
+
+// checkExtractSpace returns an error if the temporary directory does not have
+// enough space for extracting the bundle at path.
+func checkExtractSpace(ctx context.Context, path string, tempDir *option.TempDir) error {
+	fp, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	m, err := bundle.ReadManifest(fp)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle %s: %w", path, err)
+	}
+	return tempDir.CheckSpace(ctx, os.TempDir(), m.Size, "extracting the bundle")
+}
+
+// extractBundle extracts the bundle at path into the directory dir.
+func extractBundle(path string, dir string) (*bundle.Manifest, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	m, err := bundle.Extract(fp, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract bundle %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// importGraph copies the graph of root, along with all the artifacts in the
+// bundle referring to root, from the bundle to dst.
+func importGraph(ctx context.Context, statusHandler status.CopyHandler, src oras.ReadOnlyGraphTarget, target oras.GraphTarget, root ocispec.Descriptor, concurrency int) (err error) {
+	dst, err := statusHandler.StartTracking(target)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		stopErr := statusHandler.StopTracking()
+		if err == nil {
+			err = stopErr
+		}
+	}()
+	copyOpts := oras.DefaultExtendedCopyGraphOptions
+	copyOpts.Concurrency = concurrency
+	copyOpts.OnCopySkipped = statusHandler.OnCopySkipped
+	copyOpts.PreCopy = statusHandler.PreCopy
+	copyOpts.PostCopy = statusHandler.PostCopy
+	return oras.ExtendedCopyGraph(ctx, src, dst, root, copyOpts)
+}
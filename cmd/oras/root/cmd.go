@@ -17,9 +17,13 @@ package root
 
 import (
 	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/root/auth"
+	"oras.land/oras/cmd/oras/root/bench"
 	"oras.land/oras/cmd/oras/root/blob"
+	"oras.land/oras/cmd/oras/root/bundle"
 	"oras.land/oras/cmd/oras/root/manifest"
 	"oras.land/oras/cmd/oras/root/repo"
+	"oras.land/oras/cmd/oras/root/trust"
 )
 
 func New() *cobra.Command {
@@ -35,14 +39,23 @@ func New() *cobra.Command {
 		versionCmd(),
 		discoverCmd(),
 		resolveCmd(),
+		historyCmd(),
+		verifyLocalCmd(),
 		copyCmd(),
 		tagCmd(),
 		attachCmd(),
 		backupCmd(),
 		restoreCmd(),
+		pruneCmd(),
+		pingCmd(),
+		conformanceCmd(),
+		auth.Cmd(),
+		bench.Cmd(),
 		blob.Cmd(),
+		bundle.Cmd(),
 		manifest.Cmd(),
 		repo.Cmd(),
+		trust.Cmd(),
 	)
 	return cmd
 }
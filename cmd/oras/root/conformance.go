@@ -0,0 +1,132 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/conformance"
+)
+
+type conformanceOptions struct {
+	option.Common
+	option.Remote
+	option.Format
+
+	rawReference string
+	suiteNames   []string
+	suites       []conformance.Suite
+}
+
+func conformanceCmd() *cobra.Command {
+	var opts conformanceOptions
+	cmd := &cobra.Command{
+		Use:   "conformance [flags] <registry>/<repository>",
+		Short: "[Experimental] Check a repository against the OCI distribution specification",
+		Long: `[Experimental] Check a repository against the OCI distribution specification
+
+Runs the pull, push, content discovery and management workflows of the OCI
+distribution specification against the target repository and reports the result
+of each check. Test content is pushed to the repository with a tag prefixed by
+"oras-conformance-" and deleted afterwards. Pull, push and delete permissions to
+the repository are required.
+
+Exits with a non-zero code if any check fails.
+
+Example - Run all conformance checks against a repository:
+  oras conformance localhost:5000/conformance
+
+Example - Run only the pull and push checks:
+  oras conformance --suite pull,push localhost:5000/conformance
+
+Example - Run conformance checks and output the results in JSON format:
+  oras conformance --format json localhost:5000/conformance
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the repository to check"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.rawReference = args[0]
+			for _, name := range opts.suiteNames {
+				suite, err := conformance.ParseSuite(strings.TrimSpace(name))
+				if err != nil {
+					return &oerrors.Error{
+						Err:            err,
+						Recommendation: fmt.Sprintf("supported suites: %s", strings.Join(suiteNames(), ", ")),
+					}
+				}
+				opts.suites = append(opts.suites, suite)
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConformance(cmd, &opts)
+		},
+	}
+	cmd.Flags().StringSliceVar(&opts.suiteNames, "suite", suiteNames(), "conformance suites to run")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Remote)
+}
+
+func suiteNames() []string {
+	names := make([]string, len(conformance.Suites))
+	for i, s := range conformance.Suites {
+		names[i] = string(s)
+	}
+	return names
+}
+
+func runConformance(cmd *cobra.Command, opts *conformanceOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	repo, err := opts.NewRepository(opts.rawReference, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if repo.Reference.Reference != "" {
+		return fmt.Errorf("%q: tag or digest is not allowed, the repository is checked with generated test content", opts.rawReference)
+	}
+	ctx = auth.AppendRepositoryScope(ctx, repo.Reference, auth.ActionPull, auth.ActionPush, auth.ActionDelete)
+	handler, err := display.NewConformanceHandler(opts.Printer, opts.Format, repo.Reference.String())
+	if err != nil {
+		return err
+	}
+
+	var failed, total int
+	err = conformance.Run(ctx, repo, opts.suites, func(result conformance.Result) error {
+		total++
+		if result.Status == conformance.StatusFail {
+			failed++
+		}
+		return handler.OnCheckCompleted(result)
+	})
+	if err != nil {
+		return err
+	}
+	if err := handler.Render(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d conformance checks failed", failed, total)
+	}
+	return nil
+}
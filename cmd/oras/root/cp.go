@@ -16,14 +16,23 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
@@ -33,24 +42,66 @@ import (
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
 	"oras.land/oras/cmd/oras/internal/display/status"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
 	"oras.land/oras/internal/docker"
 	"oras.land/oras/internal/graph"
+	orasio "oras.land/oras/internal/io"
 	"oras.land/oras/internal/listener"
+	"oras.land/oras/internal/platform"
+	"oras.land/oras/internal/policy"
+	"oras.land/oras/internal/promotion"
 	"oras.land/oras/internal/registryutil"
+	"oras.land/oras/internal/signature"
+	"oras.land/oras/internal/tagfilter"
+	"oras.land/oras/internal/trace"
+	"oras.land/oras/internal/version"
 )
 
 type copyOptions struct {
 	option.Common
-	option.Platform
+	option.DescriptorFile
+	option.Platforms
 	option.BinaryTarget
+	option.Format
+	option.TempDir
 	option.Terminal
+	option.Policy
+	option.GitHubActions
+	option.TransferBudget
+	option.History
 
 	recursive   bool
 	concurrency int
 	extraRefs   []string
+	srcTags     []string
+	allTags     bool
+	tagRegex    string
+	tagPattern  *regexp.Regexp
+	keepPolicy  string
+	tagPolicy   tagfilter.Policy
+	resign      bool
+	key         string
+	signer      *signature.Signer
+
+	manifestsOnly bool
+	verifyOnly    bool
+
+	continueOnError bool
+
+	includeArtifactTypes []string
+	excludeArtifactTypes []string
+
+	outputRecord string
+	fromRecord   string
+	record       *promotion.Record
+	replay       *promotion.Record
+	// expectedDigest is the recorded destination digest of a replayed copy.
+	expectedDigest string
+
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -58,10 +109,13 @@ type copyOptions struct {
 func copyCmd() *cobra.Command {
 	var opts copyOptions
 	cmd := &cobra.Command{
-		Use:     "cp [flags] <from>{:<tag>|@<digest>} <to>[:<tag>[,<tag>][...]]",
+		Use:     "cp [flags] <from>{:<tag>[,<tag>][...]|@<digest>} <to>[:<tag>[,<tag>][...]]",
 		Aliases: []string{"copy"},
 		Short:   "Copy artifacts from one target to another",
 		Long: `Copy artifacts from one target to another. When copying an image index, all of its manifests will be copied
+When multiple platforms are specified, only the manifests of these platforms in the image index are copied, and an index pruned to them is pushed to the destination.
+When multiple source tags or --all-tags are specified, each tag is copied to the same tag in the destination, and blobs shared by the artifacts are copied only once.
+When --from-record is specified, no source or destination is accepted as they are read from the promotion record.
 
 Example - Copy an artifact between registries:
   oras cp localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
@@ -75,47 +129,172 @@ Example - Upload an artifact from an OCI image layout folder:
 Example - Upload an artifact from an OCI layout tar archive:
   oras cp --from-oci-layout ./to-upload.tar:v1 localhost:5000/net-monitor:v1
 
+Example - Copy an artifact between OCI image layouts without a registry, into a tar archive:
+  oras cp --from-oci-layout ./layout-dir:v1 --to-oci-layout ./airgap.tar:v1
+
 Example - Copy an artifact and its referrers:
   oras cp -r localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
 
+Example - Copy an artifact and its referrers except attestation bundles:
+  oras cp -r --exclude-artifact-type application/vnd.dev.sigstore.bundle.v0.3+json localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact and only its signatures and SBOMs:
+  oras cp -r --include-artifact-type application/vnd.cncf.notary.signature --include-artifact-type application/spdx+json \
+    localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
 Example - Copy an artifact and referrers using specific methods for the Referrers API:
   oras cp -r --from-distribution-spec v1.1-referrers-api --to-distribution-spec v1.1-referrers-tag \
     localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
 
+Example - Copy an artifact and its referrers to another registry, replacing signatures bound to the source repository:
+  oras cp -r --resign --key cosign.key localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
 Example - Copy certain platform of an artifact:
   oras cp --platform linux/arm/v5 localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
 
+Example - Copy certain platforms of a multi-arch image as a pruned index:
+  oras cp --platform linux/amd64 --platform linux/arm64 localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
 Example - Copy an artifact with multiple tags:
   oras cp localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:tag1,tag2,tag3
 
+Example - Copy the artifacts of multiple tags to the same tags in another repository:
+  oras cp localhost:5000/net-monitor:v1,v2,v3 localhost:6000/net-monitor-copy
+
+Example - Copy the artifacts of all tags matching a regular expression:
+  oras cp --all-tags --tag-regex '^v1\.' localhost:5000/net-monitor localhost:6000/net-monitor-copy
+
+Example - [Experimental] Mirror only the latest release of each major version, e.g. the latest 1.x and 2.x:
+  oras cp --all-tags --keep-policy latest-per-major localhost:5000/net-monitor localhost:6000/net-monitor-mirror
+
+Example - [Experimental] Copy the artifacts of all tags, continuing with the other tags when a tag fails and reporting the failed tags at the end:
+  oras cp --all-tags --continue-on-error localhost:5000/net-monitor localhost:6000/net-monitor-copy
+
+Example - Copy artifacts and write a promotion record of the copied digests:
+  oras cp --output-record promote.json localhost:5000/net-monitor:v1,v2 localhost:6000/net-monitor-prod
+
+Example - Replay a promotion from a promotion record:
+  oras cp --from-record promote.json
+
+Example - [Experimental] Promote an artifact to the tag 'prod' and record the movement of the tag, shown by "oras history":
+  oras cp --record-history localhost:5000/net-monitor:v1 localhost:6000/net-monitor:prod
+
+Example - [Experimental] Copy an artifact only if allowed by the Rego policy at 'https://policies.example.com/oras.rego':
+  oras cp --policy https://policies.example.com/oras.rego localhost:5000/net-monitor:v1 localhost:6000/net-monitor-prod:v1
+
+Example - Copy only the manifests and configs of an artifact whose layers are replicated to the destination out-of-band:
+  oras cp --manifests-only localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Verify that an artifact and its referrers were copied to another registry, without copying:
+  oras cp -r --verify-only localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
+Example - Copy an artifact only if less than 500MB are to be transferred:
+  oras cp --max-transfer 500MB localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
+
 Example - Copy an artifact with multiple tags with concurrency tuned:
   oras cp --concurrency 10 localhost:5000/net-monitor:v1 localhost:5000/net-monitor-copy:tag1,tag2,tag3
+
+Example - [Experimental] Copy an artifact and report the status, size and timing of each copied blob and manifest in JSON:
+  oras cp --format json localhost:5000/net-monitor:v1 localhost:6000/net-monitor-copy:v1
 `,
-		Args: oerrors.CheckArgs(argument.Exactly(2), "the source and destination for copying"),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("from-record") {
+				return oerrors.CheckArgs(argument.Exactly(0), "no source or destination with --from-record")(cmd, args)
+			}
+			return oerrors.CheckArgs(argument.Exactly(2), "the source and destination for copying")(cmd, args)
+		},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			opts.From.RawReference = args[0]
-			refs := strings.Split(args[1], ",")
-			opts.To.RawReference = refs[0]
-			opts.extraRefs = refs[1:]
+			var srcTags []string
+			if opts.fromRecord != "" {
+				if err := parseFromRecord(cmd, &opts); err != nil {
+					return err
+				}
+			} else {
+				srcRefs := strings.Split(args[0], ",")
+				opts.From.RawReference = srcRefs[0]
+				srcTags = srcRefs[1:]
+				refs := strings.Split(args[1], ",")
+				opts.To.RawReference = refs[0]
+				opts.extraRefs = refs[1:]
+			}
 			err := option.Parse(cmd, &opts)
 			if err != nil {
 				return err
 			}
+			if err := parseTagSet(&opts, srcTags); err != nil {
+				return err
+			}
+			if opts.outputRecord != "" {
+				opts.record = &promotion.Record{
+					Tool:    "oras",
+					Version: version.GetVersion(),
+					Created: time.Now().UTC(),
+				}
+			}
+			if err := parseResign(&opts); err != nil {
+				return err
+			}
+			for _, flag := range []string{"manifests-only", "resign", "output-record", "from-record", "all-tags"} {
+				if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "verify-only", flag); err != nil {
+					return err
+				}
+			}
+			if opts.Format.Type != option.FormatTypeText.Name && (opts.verifyOnly || opts.fromRecord != "") {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("--format %s is not supported with --verify-only or --from-record", opts.Format.Type),
+					Recommendation: "remove --format to print the text output",
+				}
+			}
+			if (len(opts.includeArtifactTypes) > 0 || len(opts.excludeArtifactTypes) > 0) && !opts.recursive {
+				return &oerrors.Error{
+					Err:            errors.New("--include-artifact-type and --exclude-artifact-type can only be used with --recursive"),
+					Recommendation: "add --recursive to copy the referrers of the artifact",
+				}
+			}
 			opts.DisableTTY(opts.Debug, false)
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Printer.Verbose = opts.verbose
-			return runCopy(cmd, &opts)
+			var err error
+			if opts.replay != nil {
+				err = replayRecord(cmd, &opts)
+			} else {
+				err = runCopy(cmd, &opts)
+			}
+			var partialErr *oerrors.PartialFailureError
+			if err != nil && !errors.As(err, &partialErr) {
+				return err
+			}
+			if opts.record != nil {
+				if err := opts.record.WriteFile(opts.outputRecord); err != nil {
+					return fmt.Errorf("failed to write the promotion record: %w", err)
+				}
+			}
+			return err
 		},
 	}
 	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "r", false, "[Preview] recursively copy the artifact and its referrer artifacts")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	cmd.Flags().BoolVarP(&opts.allTags, "all-tags", "", false, "[Experimental] copy the artifacts of all tags in the source repository to the same tags in the destination")
+	cmd.Flags().BoolVarP(&opts.continueOnError, "continue-on-error", "", false, "[Experimental] continue with the other tags when copying a tag fails, and report the failed tags at the end with exit code 2")
+	cmd.Flags().StringVarP(&opts.tagRegex, "tag-regex", "", "", "[Experimental] copy only the tags matching the regular `expression` with --all-tags")
+	cmd.Flags().StringVarP(&opts.keepPolicy, "keep-policy", "", "", "[Experimental] copy only the semantic version tags selected by the `policy` with --all-tags, options: latest, latest-per-major, latest-per-minor")
+	cmd.Flags().StringArrayVarP(&opts.includeArtifactTypes, "include-artifact-type", "", nil, "[Experimental] copy only the referrers of the artifact `type` with --recursive, can be used multiple times")
+	cmd.Flags().StringArrayVarP(&opts.excludeArtifactTypes, "exclude-artifact-type", "", nil, "[Experimental] skip the referrers of the artifact `type` and their referrers with --recursive, can be used multiple times")
+	cmd.Flags().StringVarP(&opts.outputRecord, "output-record", "", "", "[Experimental] write a promotion record listing the source and destination digests of the copied artifacts to `file`")
+	cmd.Flags().StringVarP(&opts.fromRecord, "from-record", "", "", "[Experimental] replay the copies listed in the promotion record `file` by digest, verifying the destination digests")
+	cmd.Flags().BoolVarP(&opts.resign, "resign", "", false, "[Experimental] replace the copied signatures not bound to the destination repository with signatures for the destination, and sign the copied artifact for the destination unless it is signed by the key there, requires --key")
+	cmd.Flags().StringVarP(&opts.key, "key", "", "", "[Experimental] `path` to the PEM encoded private key for signing with --resign")
+	cmd.Flags().BoolVarP(&opts.manifestsOnly, "manifests-only", "", false, "[Experimental] copy the manifests, indexes and configs but not the layers, which are expected to exist in the destination")
+	cmd.Flags().BoolVarP(&opts.verifyOnly, "verify-only", "", false, "[Experimental] check that every node of the source graph exists in the destination with the same digest and size instead of copying, and fail on any difference")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.EnableDistributionSpecFlag()
+	opts.EnableDryRunFlag()
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
-	return oerrors.Command(cmd, &opts.BinaryTarget)
+	return opts.GitHubActions.Command(oerrors.Command(cmd, &opts.BinaryTarget))
 }
 
 func runCopy(cmd *cobra.Command, opts *copyOptions) error {
@@ -126,18 +305,61 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 	if err != nil {
 		return err
 	}
-	if err := opts.EnsureSourceTargetReferenceNotEmpty(cmd); err != nil {
-		return err
+	tagSet := opts.allTags || len(opts.srcTags) > 0
+	if !tagSet {
+		if err := opts.EnsureSourceTargetReferenceNotEmpty(cmd); err != nil {
+			return err
+		}
 	}
 
 	// Prepare destination
-	dst, err := opts.To.NewTarget(opts.Common, logger)
+	dst, archive, err := newCopyDestination(opts, logger)
 	if err != nil {
 		return err
 	}
+	if archive != nil {
+		defer archive.cleanup(logger)
+	}
+	if opts.verifyOnly {
+		if tagSet || len(opts.Platforms.Platforms) > 1 {
+			return errors.New("--verify-only can only verify a single artifact")
+		}
+		return verifyCopy(ctx, src, dst, opts)
+	}
 	ctx = registryutil.WithScopeHint(ctx, dst, auth.ActionPull, auth.ActionPush)
-	statusHandler, metadataHandler := display.NewCopyHandler(opts.Printer, opts.TTY, dst)
+	statusHandler, metadataHandler, err := display.NewCopyHandler(opts.Printer, opts.Format, opts.TTY, dst)
+	if err != nil {
+		return err
+	}
+
+	if tagSet {
+		// the tags copied are committed and rendered even if other tags fail
+		copyErr := copyTagSet(ctx, statusHandler, metadataHandler, src, dst, opts)
+		var partialErr *oerrors.PartialFailureError
+		if copyErr != nil && !errors.As(copyErr, &partialErr) {
+			return copyErr
+		}
+		if archive != nil {
+			if err := archive.commit(); err != nil {
+				return err
+			}
+		}
+		if err := metadataHandler.Render(); err != nil {
+			return err
+		}
+		return copyErr
+	}
 
+	tagHistory := newTagHistory(&opts.History, "cp")
+	if err := tagHistory.resolve(ctx, dst, append([]string{opts.To.Reference}, opts.extraRefs...)...); err != nil {
+		return err
+	}
+	if opts.expectedDigest != "" {
+		// verify the replayed copy before pushing anything
+		if err := checkRecordedDigest(ctx, src, opts); err != nil {
+			return err
+		}
+	}
 	desc, err := doCopy(ctx, statusHandler, src, dst, opts)
 	if err != nil {
 		return err
@@ -151,6 +373,9 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 	if err := metadataHandler.OnCopied(&opts.BinaryTarget, desc); err != nil {
 		return err
 	}
+	if err := recordCopy(ctx, src, desc, opts); err != nil {
+		return err
+	}
 
 	if len(opts.extraRefs) != 0 {
 		tagNOpts := oras.DefaultTagNOptions
@@ -160,23 +385,442 @@ func runCopy(cmd *cobra.Command, opts *copyOptions) error {
 			return err
 		}
 	}
+	if err := tagHistory.record(ctx, dst, desc); err != nil {
+		return err
+	}
+	if err := opts.WriteDescriptor(desc); err != nil {
+		return err
+	}
+	if err := opts.ReportResult("Copied", opts.To.Path, desc); err != nil {
+		return err
+	}
 
+	if archive != nil {
+		if err := archive.commit(); err != nil {
+			return err
+		}
+	}
 	return metadataHandler.Render()
 }
 
+// parseTagSet validates the options of copying the artifacts of multiple
+// source tags, which are the source reference and extraTags, or all tags with
+// --all-tags.
+func parseTagSet(opts *copyOptions, extraTags []string) error {
+	if opts.tagRegex != "" && !opts.allTags {
+		return errors.New("--tag-regex can only be used with --all-tags")
+	}
+	if opts.keepPolicy != "" && !opts.allTags {
+		return errors.New("--keep-policy can only be used with --all-tags")
+	}
+	if !opts.allTags && len(extraTags) == 0 {
+		if opts.continueOnError {
+			return &oerrors.Error{
+				Err:            errors.New("--continue-on-error can only be used when copying multiple tags"),
+				Recommendation: "Specify multiple source tags, e.g. localhost:5000/net-monitor:v1,v2, or use --all-tags",
+			}
+		}
+		return nil
+	}
+	if opts.DescriptorFilePath != "" {
+		return &oerrors.Error{
+			Err:            errors.New("--descriptor-file cannot be used when copying multiple tags"),
+			Recommendation: "use --output-record to record the descriptors of the copied artifacts",
+		}
+	}
+	if opts.allTags {
+		if opts.From.Reference != "" || len(extraTags) > 0 {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("--all-tags cannot be used with the source reference %q", opts.From.RawReference),
+				Recommendation: "Remove the tags or digest from the source, or use --tag-regex to select the tags to copy",
+			}
+		}
+		if opts.tagRegex != "" {
+			var err error
+			if opts.tagPattern, err = regexp.Compile(opts.tagRegex); err != nil {
+				return fmt.Errorf("invalid --tag-regex %q: %w", opts.tagRegex, err)
+			}
+		}
+		if opts.keepPolicy != "" {
+			var err error
+			if opts.tagPolicy, err = tagfilter.ParsePolicy(opts.keepPolicy); err != nil {
+				return fmt.Errorf("invalid --keep-policy: %w", err)
+			}
+		}
+	} else {
+		if _, err := digest.Parse(opts.From.Reference); err == nil || opts.From.Reference == "" {
+			return fmt.Errorf("invalid source %q: multiple references must be tags", opts.From.RawReference)
+		}
+		for _, tag := range extraTags {
+			if tag == "" || strings.ContainsAny(tag, ":@") {
+				return fmt.Errorf("invalid source %q: invalid tag %q", opts.From.RawReference, tag)
+			}
+		}
+		opts.srcTags = append([]string{opts.From.Reference}, extraTags...)
+	}
+	if opts.To.Reference != "" || len(opts.extraRefs) > 0 {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("the destination %q cannot have tags or digests when copying multiple tags", opts.To.RawReference),
+			Recommendation: "Each tag is copied to the same tag in the destination. Remove the tags from the destination",
+		}
+	}
+	return nil
+}
+
+// copyTagSet copies the artifacts of the source tags to the same tags in the
+// destination. Blobs shared by the artifacts are found existing in the
+// destination after the first copy, and thus copied only once.
+// With --continue-on-error, the failed tags are skipped and returned in an
+// oerrors.PartialFailureError after copying the other tags.
+func copyTagSet(ctx context.Context, statusHandler status.CopyHandler, metadataHandler metadata.CopyHandler, src option.ReadOnlyGraphTagFinderTarget, dst oras.GraphTarget, opts *copyOptions) error {
+	tags := opts.srcTags
+	if opts.allTags {
+		var err error
+		if tags, err = listTags(ctx, src, opts.tagPattern); err != nil {
+			return err
+		}
+		if opts.tagPolicy != "" {
+			tags = opts.tagPolicy.Filter(tags)
+		}
+		if len(tags) == 0 {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("no tags to copy found in %s", opts.From.GetDisplayReference()),
+				Recommendation: "Check the tags in the source with \"oras repo tags\", or the regular expression specified by --tag-regex and the policy specified by --keep-policy",
+			}
+		}
+	}
+	var failures []oerrors.ItemError
+	for _, tag := range tags {
+		tagOpts := *opts
+		tagOpts.From.Reference = tag
+		tagOpts.From.RawReference = opts.From.Path + ":" + tag
+		tagOpts.To.Reference = tag
+		tagOpts.To.RawReference = opts.To.Path + ":" + tag
+		err := copyTag(ctx, statusHandler, metadataHandler, src, dst, &tagOpts)
+		if err == nil {
+			continue
+		}
+		if !opts.continueOnError || ctx.Err() != nil {
+			return err
+		}
+		err = oerrors.UnwrapCopyError(err)
+		if err := metadataHandler.OnCopyFailed(&tagOpts.BinaryTarget, err); err != nil {
+			return err
+		}
+		failures = append(failures, oerrors.ItemError{Item: tag, Err: err})
+	}
+	if len(failures) > 0 {
+		return &oerrors.PartialFailureError{
+			Operation: "copy",
+			Total:     len(tags),
+			Failures:  failures,
+		}
+	}
+	return nil
+}
+
+// copyTag copies the artifact of the source tag in opts to the same tag in the
+// destination.
+func copyTag(ctx context.Context, statusHandler status.CopyHandler, metadataHandler metadata.CopyHandler, src option.ReadOnlyGraphTagFinderTarget, dst oras.GraphTarget, opts *copyOptions) error {
+	tagHistory := newTagHistory(&opts.History, "cp")
+	if err := tagHistory.resolve(ctx, dst, opts.To.Reference); err != nil {
+		return err
+	}
+	desc, err := doCopy(ctx, statusHandler, src, dst, opts)
+	if err != nil {
+		return err
+	}
+	if err := tagHistory.record(ctx, dst, desc); err != nil {
+		return err
+	}
+	if err := metadataHandler.OnCopied(&opts.BinaryTarget, desc); err != nil {
+		return err
+	}
+	return recordCopy(ctx, src, desc, opts)
+}
+
+// listTags lists the tags in target matching pattern, or all the tags if
+// pattern is nil.
+func listTags(ctx context.Context, target registry.TagLister, pattern *regexp.Regexp) ([]string, error) {
+	var tags []string
+	if err := target.Tags(ctx, "", func(got []string) error {
+		for _, tag := range got {
+			if pattern == nil || pattern.MatchString(tag) {
+				tags = append(tags, tag)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	return tags, nil
+}
+
+// parseFromRecord loads the promotion record to replay and sets the source and
+// destination of its first entry for validating the options.
+func parseFromRecord(cmd *cobra.Command, opts *copyOptions) error {
+	for _, flag := range []string{"platform", "all-tags", "tag-regex", "keep-policy", "descriptor-file"} {
+		if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "from-record", flag); err != nil {
+			return err
+		}
+	}
+	var err error
+	if opts.replay, err = promotion.ReadFile(opts.fromRecord); err != nil {
+		return fmt.Errorf("failed to read the promotion record: %w", err)
+	}
+	setRecordEntry(opts, opts.replay.Entries[0])
+	return nil
+}
+
+// setRecordEntry sets the source and destination of opts to the ones of the
+// entry in a promotion record, with the source pinned by digest.
+func setRecordEntry(opts *copyOptions, entry promotion.Entry) {
+	opts.From.IsOCILayout = entry.SourceType == option.TargetTypeOCILayout
+	opts.From.Path = ""
+	opts.From.RawReference = entry.Source + "@" + entry.SourceDigest
+	opts.To.IsOCILayout = entry.DestinationType == option.TargetTypeOCILayout
+	opts.To.Path = ""
+	opts.extraRefs = nil
+	opts.Platforms.Platform = nil
+	opts.Platforms.Platforms = entry.Platforms
+	if len(entry.DestinationTags) == 0 {
+		opts.To.RawReference = entry.Destination + "@" + entry.DestinationDigest
+		return
+	}
+	opts.To.RawReference = entry.Destination + ":" + entry.DestinationTags[0]
+	opts.extraRefs = entry.DestinationTags[1:]
+}
+
+// replayRecord copies the artifacts listed in the promotion record by their
+// source digests, and verifies that the copied artifacts match the recorded
+// destination digests.
+func replayRecord(cmd *cobra.Command, opts *copyOptions) error {
+	for _, entry := range opts.replay.Entries {
+		setRecordEntry(opts, entry)
+		if err := opts.From.Parse(cmd); err != nil {
+			return err
+		}
+		if err := opts.To.Parse(cmd); err != nil {
+			return err
+		}
+		opts.expectedDigest = entry.DestinationDigest
+		if err := runCopy(cmd, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRecordedDigest checks that the artifact to be copied, pruned to the
+// recorded platforms if any, matches the recorded destination digest.
+func checkRecordedDigest(ctx context.Context, src oras.ReadOnlyGraphTarget, opts *copyOptions) error {
+	root, err := resolveSource(ctx, src, opts)
+	if err != nil {
+		return err
+	}
+	if len(opts.Platforms.Platforms) > 1 {
+		if root, _, err = pruneIndex(ctx, src, root, opts.Platforms.Platforms); err != nil {
+			return err
+		}
+	}
+	if root.Digest.String() != opts.expectedDigest {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("the artifact to copy %s does not match the recorded digest %s", root.Digest, opts.expectedDigest),
+			Recommendation: "check whether the promotion record is modified",
+		}
+	}
+	return nil
+}
+
+// recordCopy adds the copy of desc to the promotion record if requested.
+func recordCopy(ctx context.Context, src oras.ReadOnlyTarget, desc ocispec.Descriptor, opts *copyOptions) error {
+	if opts.record == nil {
+		return nil
+	}
+	srcDesc := desc
+	var platforms []ocispec.Platform
+	if len(opts.Platforms.Platforms) > 1 {
+		// the copied index is pruned
+		platforms = opts.Platforms.Platforms
+		var err error
+		if srcDesc, err = oras.Resolve(ctx, src, opts.From.Reference, oras.DefaultResolveOptions); err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+		}
+	}
+	entry := promotion.Entry{
+		Source:          opts.From.Path,
+		SourceType:      opts.From.Type,
+		SourceReference: opts.From.Reference,
+		SourceDigest:    srcDesc.Digest.String(),
+		Platforms:       platforms,
+		Destination:     opts.To.Path,
+		DestinationType: opts.To.Type,
+		Copied:          time.Now().UTC(),
+	}
+	for _, ref := range append([]string{opts.To.Reference}, opts.extraRefs...) {
+		if _, err := digest.Parse(ref); ref != "" && err != nil {
+			entry.DestinationTags = append(entry.DestinationTags, ref)
+		}
+	}
+	entry.SetDestination(desc)
+	opts.record.Add(entry)
+	return nil
+}
+
+// parseResign validates the re-signing options and loads the signing key.
+func parseResign(opts *copyOptions) error {
+	if !opts.resign {
+		if opts.key != "" {
+			return errors.New("--key can only be used with --resign")
+		}
+		return nil
+	}
+	if opts.key == "" {
+		return &oerrors.Error{
+			Err:            errors.New("--resign requires a signing key"),
+			Recommendation: "Specify the private key to sign with via --key",
+		}
+	}
+	if opts.To.Type != option.TargetTypeRemote {
+		return &oerrors.Error{
+			Err:            errors.New("--resign can only be used when copying to a registry"),
+			Recommendation: "Signatures are bound to registry repositories. Remove --resign to copy to an OCI image layout",
+		}
+	}
+	var err error
+	opts.signer, err = signature.LoadSigner(opts.key)
+	if err != nil {
+		return fmt.Errorf("failed to load signing key: %w", err)
+	}
+	return nil
+}
+
+// newCopyDestination returns the destination to copy to. An OCI layout tar
+// archive is staged as a directory, which is archived on commit.
+func newCopyDestination(opts *copyOptions, logger logrus.FieldLogger) (oras.GraphTarget, *layoutArchive, error) {
+	if opts.To.Type != option.TargetTypeOCILayout || !isLayoutArchive(opts.To.Path) {
+		dst, err := opts.To.NewTarget(opts.Common, logger)
+		return dst, nil, err
+	}
+	archive, err := openLayoutArchive(opts.To.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	staging := opts.To
+	staging.Path = archive.dir
+	dst, err := staging.NewTarget(opts.Common, logger)
+	if err != nil {
+		archive.cleanup(logger)
+		return nil, nil, err
+	}
+	return dst, archive, nil
+}
+
+// isLayoutArchive returns true if path refers to an OCI layout tar archive,
+// existing or to be created.
+func isLayoutArchive(path string) bool {
+	fi, err := os.Stat(path)
+	switch {
+	case err == nil && fi.IsDir():
+		return false
+	case err == nil:
+		isTar, _ := orasio.IsTarFile(path)
+		return isTar
+	default:
+		return strings.EqualFold(filepath.Ext(path), ".tar")
+	}
+}
+
+// layoutArchive is an OCI layout tar archive staged in a directory.
+type layoutArchive struct {
+	path string
+	dir  string
+}
+
+// openLayoutArchive stages the OCI layout tar archive at path, extracting the
+// existing content so that copied artifacts are added to it.
+func openLayoutArchive(path string) (*layoutArchive, error) {
+	dir, err := os.MkdirTemp("", "oras-layout-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	archive := &layoutArchive{path: path, dir: dir}
+	fp, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return archive, nil
+	}
+	if err == nil {
+		err = orasio.UntarDirectory(fp, dir)
+		_ = fp.Close()
+	}
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return archive, nil
+}
+
+// commit archives the staged layout, replacing the archive atomically.
+func (a *layoutArchive) commit() (err error) {
+	if err := os.RemoveAll(filepath.Join(a.dir, "ingest")); err != nil {
+		return err
+	}
+	fp, err := os.CreateTemp(filepath.Dir(a.path), "."+filepath.Base(a.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", a.path, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = fp.Close()
+			_ = os.Remove(fp.Name())
+		}
+	}()
+	if err := orasio.TarDirectory(fp, a.dir); err != nil {
+		return fmt.Errorf("failed to create tar archive at %s: %w", a.path, err)
+	}
+	if err := fp.Chmod(0644); err != nil {
+		return err
+	}
+	if err := fp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(fp.Name(), a.path)
+}
+
+// cleanup removes the staging directory.
+func (a *layoutArchive) cleanup(logger logrus.FieldLogger) {
+	if err := os.RemoveAll(a.dir); err != nil {
+		logger.Debugf("failed to remove temporary directory %s: %v", a.dir, err)
+	}
+}
+
 func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, opts *copyOptions) (desc ocispec.Descriptor, err error) {
 	// Prepare copy options
 	extendedCopyGraphOptions := oras.DefaultExtendedCopyGraphOptions
 	extendedCopyGraphOptions.Concurrency = opts.concurrency
-	extendedCopyGraphOptions.FindPredecessors = func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
-		return registry.Referrers(ctx, src, desc, "")
-	}
+	var stale staleSubjects
+	extendedCopyGraphOptions.FindPredecessors = findCopyReferrers(ctx, opts, &stale)
 
 	if mountRepo, canMount := getMountPoint(src, dst, opts); canMount {
 		extendedCopyGraphOptions.MountFrom = func(ctx context.Context, desc ocispec.Descriptor) ([]string, error) {
 			return []string{mountRepo}, nil
 		}
 	}
+	if opts.Policy.Enabled() {
+		root, err := resolveSource(ctx, src, opts)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		input := &policy.Input{Command: "cp", Reference: opts.From.RawReference, Destination: opts.To.RawReference}
+		if err := evaluatePolicy(ctx, &opts.Policy, input, src, root); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	if opts.TransferBudget.Enabled() {
+		if err := preflightCopy(ctx, src, dst, opts); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
 	dst, err = copyHandler.StartTracking(dst)
 	if err != nil {
 		return desc, err
@@ -191,36 +835,289 @@ func doCopy(ctx context.Context, copyHandler status.CopyHandler, src oras.ReadOn
 	extendedCopyGraphOptions.PreCopy = copyHandler.PreCopy
 	extendedCopyGraphOptions.PostCopy = copyHandler.PostCopy
 	extendedCopyGraphOptions.OnMounted = copyHandler.OnMounted
+	if opts.manifestsOnly {
+		extendedCopyGraphOptions.FindSuccessors = skipLayers(dst, copyHandler.OnCopySkipped, trace.Logger(ctx))
+	}
 
-	rOpts := oras.DefaultResolveOptions
-	rOpts.TargetPlatform = opts.Platform.Platform
-	if opts.recursive {
-		desc, err = oras.Resolve(ctx, src, opts.From.Reference, rOpts)
+	if len(opts.Platforms.Platforms) > 1 {
+		desc, err = copyPlatforms(ctx, src, dst, opts, extendedCopyGraphOptions)
+	} else if opts.recursive {
+		desc, err = resolveSource(ctx, src, opts)
 		if err != nil {
-			return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+			return ocispec.Descriptor{}, err
 		}
 		err = recursiveCopy(ctx, src, dst, opts.To.Reference, desc, extendedCopyGraphOptions)
 	} else {
 		if opts.To.Reference == "" {
-			desc, err = oras.Resolve(ctx, src, opts.From.Reference, rOpts)
+			desc, err = resolveSource(ctx, src, opts)
 			if err != nil {
-				return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+				return ocispec.Descriptor{}, err
 			}
 			err = oras.CopyGraph(ctx, src, dst, desc, extendedCopyGraphOptions.CopyGraphOptions)
 		} else {
 			copyOptions := oras.CopyOptions{
 				CopyGraphOptions: extendedCopyGraphOptions.CopyGraphOptions,
 			}
-			if opts.Platform.Platform != nil {
-				copyOptions.WithTargetPlatform(opts.Platform.Platform)
+			srcRef := opts.From.Reference
+			if opts.Platforms.Platform != nil {
+				selected, err := resolveSource(ctx, src, opts)
+				if err != nil {
+					return ocispec.Descriptor{}, err
+				}
+				srcRef = selected.Digest.String()
 			}
-			desc, err = oras.Copy(ctx, src, opts.From.Reference, dst, opts.To.Reference, copyOptions)
+			desc, err = oras.Copy(ctx, src, srcRef, dst, opts.To.Reference, copyOptions)
 		}
 	}
+	if err == nil && opts.resign {
+		err = pushSignatures(ctx, dst, opts.To.Path, desc, &stale, opts.signer, extendedCopyGraphOptions.CopyGraphOptions)
+	}
 	// leave the CopyError to oerrors.Modifier for prefix processing
 	return desc, err
 }
 
+// resolveSource resolves the source of the copy, selecting the manifest of
+// the requested platform if exactly one platform is requested.
+func resolveSource(ctx context.Context, src oras.ReadOnlyTarget, opts *copyOptions) (ocispec.Descriptor, error) {
+	root, err := platform.Resolve(ctx, src, opts.From.Reference, opts.Platforms.Platform)
+	if err != nil {
+		return ocispec.Descriptor{}, option.PlatformError(fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err), "")
+	}
+	return root, nil
+}
+
+// preflightCopy prints the estimated transfer of the copy, skipping the nodes
+// existing in dst, and checks it against the transfer budget.
+func preflightCopy(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.ReadOnlyTarget, opts *copyOptions) error {
+	root, err := resolveSource(ctx, src, opts)
+	if err != nil {
+		return err
+	}
+	if len(opts.Platforms.Platforms) > 1 {
+		if root, src, err = pruneIndex(ctx, src, root, opts.Platforms.Platforms); err != nil {
+			return err
+		}
+	}
+	var findReferrers func(context.Context, ocispec.Descriptor) ([]ocispec.Descriptor, error)
+	if opts.recursive {
+		findPredecessors := findCopyReferrers(ctx, opts, nil)
+		findReferrers = func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+			return findPredecessors(ctx, src, desc)
+		}
+	}
+	estimate, err := estimateTransfer(ctx, src, root, findReferrers, dst.Exists)
+	if err != nil {
+		return err
+	}
+	if opts.Format.Type == option.FormatTypeText.Name {
+		if err := opts.Printer.Println(estimate.String()); err != nil {
+			return err
+		}
+	}
+	return opts.TransferBudget.Check(estimate.Size)
+}
+
+// findCopyReferrers returns the function finding the referrers to copy with
+// --recursive. The subjects of the signatures dropped with --resign are
+// recorded in stale if it is not nil.
+func findCopyReferrers(ctx context.Context, opts *copyOptions, stale *staleSubjects) findPredecessorsFunc {
+	var findPredecessors findPredecessorsFunc = func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		return registry.Referrers(ctx, src, desc, "")
+	}
+	if len(opts.includeArtifactTypes) > 0 || len(opts.excludeArtifactTypes) > 0 {
+		findPredecessors = filterReferrers(findPredecessors, opts.includeArtifactTypes, opts.excludeArtifactTypes, trace.Logger(ctx))
+	}
+	if opts.resign {
+		findPredecessors = dropStaleSignatures(findPredecessors, opts.To.Path, stale, trace.Logger(ctx))
+	}
+	return findPredecessors
+}
+
+// filterReferrers filters the referrers found by findPredecessors by their
+// artifact types. If include is not empty, only the referrers of the included
+// artifact types are kept. The referrers of the excluded artifact types are
+// dropped along with their own referrers, as they are not traversed.
+func filterReferrers(findPredecessors findPredecessorsFunc, include, exclude []string, logger logrus.FieldLogger) findPredecessorsFunc {
+	return func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		predecessors, err := findPredecessors(ctx, src, desc)
+		if err != nil {
+			return nil, err
+		}
+		var kept []ocispec.Descriptor
+		for _, predecessor := range predecessors {
+			if (len(include) > 0 && !slices.Contains(include, predecessor.ArtifactType)) || slices.Contains(exclude, predecessor.ArtifactType) {
+				logger.Debugf("skipping referrer %s of %s with artifact type %q", predecessor.Digest, desc.Digest, predecessor.ArtifactType)
+				continue
+			}
+			kept = append(kept, predecessor)
+		}
+		return kept, nil
+	}
+}
+
+// skipLayers returns a FindSuccessors function that drops the layers of image
+// manifests from the successors, so that only manifests, indexes and configs
+// are copied. The dropped layers are reported as skipped if they exist in dst,
+// or logged as missing otherwise.
+func skipLayers(dst content.ReadOnlyStorage, onSkipped func(context.Context, ocispec.Descriptor) error, logger logrus.FieldLogger) func(context.Context, content.Fetcher, ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	return func(ctx context.Context, fetcher content.Fetcher, node ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		successors, err := content.Successors(ctx, fetcher, node)
+		if err != nil || !descriptor.IsImageManifest(node) {
+			return successors, err
+		}
+		layers, err := manifestLayers(ctx, fetcher, node)
+		if err != nil {
+			return nil, err
+		}
+		var kept []ocispec.Descriptor
+		for _, successor := range successors {
+			if !layers[successor.Digest] {
+				kept = append(kept, successor)
+				continue
+			}
+			exists, err := dst.Exists(ctx, successor)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				logger.Warnf("layer %s of %s is missing in the destination", successor.Digest, node.Digest)
+				continue
+			}
+			if err := onSkipped(ctx, successor); err != nil {
+				return nil, err
+			}
+		}
+		return kept, nil
+	}
+}
+
+// manifestLayers returns the digests of the layers of the image manifest.
+func manifestLayers(ctx context.Context, fetcher content.Fetcher, manifestDesc ocispec.Descriptor) (map[digest.Digest]bool, error) {
+	fetched, err := content.FetchAll(ctx, fetcher, manifestDesc)
+	if err != nil {
+		return nil, err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(fetched, &manifest); err != nil {
+		return nil, err
+	}
+	layers := make(map[digest.Digest]bool, len(manifest.Layers))
+	for _, layer := range manifest.Layers {
+		layers[layer.Digest] = true
+	}
+	// a layer sharing the digest of the config is copied as the config
+	delete(layers, manifest.Config.Digest)
+	return layers, nil
+}
+
+// copyPlatforms copies the manifests of the requested platforms in the source
+// index, and pushes the index pruned to these manifests to the destination.
+// The source index is copied as is if all of its manifests are requested.
+func copyPlatforms(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.GraphTarget, opts *copyOptions, copyOpts oras.ExtendedCopyGraphOptions) (ocispec.Descriptor, error) {
+	root, err := oras.Resolve(ctx, src, opts.From.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", opts.From.Reference, err)
+	}
+	pruned, prunedSrc, err := pruneIndex(ctx, src, root, opts.Platforms.Platforms)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if dgst, err := digest.Parse(opts.To.Reference); err == nil && dgst != pruned.Digest {
+		return ocispec.Descriptor{}, &oerrors.Error{
+			Err:            fmt.Errorf("the copied index %s does not match the destination digest %s", pruned.Digest, dgst),
+			Recommendation: "specify a tag or no reference for the destination",
+		}
+	}
+
+	if opts.recursive {
+		return pruned, recursiveCopy(ctx, prunedSrc, dst, opts.To.Reference, pruned, copyOpts)
+	}
+	if err := oras.CopyGraph(ctx, prunedSrc, dst, pruned, copyOpts.CopyGraphOptions); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if opts.To.Reference != "" && opts.To.Reference != pruned.Digest.String() {
+		if err := dst.Tag(ctx, pruned, opts.To.Reference); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	return pruned, nil
+}
+
+// pruneIndex returns the index root pruned to the manifests matching any of
+// the platforms, and a source serving the pruned index on top of src.
+func pruneIndex(ctx context.Context, src oras.ReadOnlyGraphTarget, root ocispec.Descriptor, platforms []ocispec.Platform) (ocispec.Descriptor, oras.ReadOnlyGraphTarget, error) {
+	if root.MediaType != ocispec.MediaTypeImageIndex && root.MediaType != docker.MediaTypeManifestList {
+		return ocispec.Descriptor{}, nil, &oerrors.Error{
+			Err:            fmt.Errorf("%s is not an image index and cannot be filtered by multiple platforms", root.Digest),
+			Recommendation: "specify at most one --platform when copying a single manifest",
+		}
+	}
+	fetched, err := content.FetchAll(ctx, src, root)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(fetched, &index); err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	selected := platform.Select(index.Manifests, platforms)
+	if len(selected) == 0 {
+		var available []string
+		for _, manifest := range index.Manifests {
+			if manifest.Platform != nil && manifest.Platform.OS != "unknown" {
+				available = append(available, platform.String(manifest.Platform))
+			}
+		}
+		recommendation := "run `oras manifest fetch` to list the platforms in the index"
+		if len(available) > 0 {
+			recommendation = "Use --platform to select any of the platforms " + strings.Join(slices.Compact(available), ", ")
+		}
+		return ocispec.Descriptor{}, nil, &oerrors.Error{
+			Err:            fmt.Errorf("no manifest in the index %s matches the requested platforms", root.Digest),
+			Recommendation: recommendation,
+		}
+	}
+	if len(selected) == len(index.Manifests) {
+		return root, src, nil
+	}
+
+	index.Manifests = selected
+	prunedContent, err := json.Marshal(index)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	pruned := content.NewDescriptorFromBytes(root.MediaType, prunedContent)
+	pruned.ArtifactType = index.ArtifactType
+	return pruned, &prunedIndexSource{
+		ReadOnlyGraphTarget: src,
+		index:               pruned,
+		content:             prunedContent,
+	}, nil
+}
+
+// prunedIndexSource serves a pruned index on top of the source target.
+type prunedIndexSource struct {
+	oras.ReadOnlyGraphTarget
+	index   ocispec.Descriptor
+	content []byte
+}
+
+// Fetch fetches the content identified by the descriptor.
+func (s *prunedIndexSource) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if content.Equal(target, s.index) {
+		return io.NopCloser(bytes.NewReader(s.content)), nil
+	}
+	return s.ReadOnlyGraphTarget.Fetch(ctx, target)
+}
+
+// Exists returns true if the described content exists.
+func (s *prunedIndexSource) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	if content.Equal(target, s.index) {
+		return true, nil
+	}
+	return s.ReadOnlyGraphTarget.Exists(ctx, target)
+}
+
 // recursiveCopy copies an artifact and its referrers from one target to another.
 // If the artifact is a manifest list or index, referrers of its manifests are copied as well.
 func recursiveCopy(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.Target, dstRef string, root ocispec.Descriptor, opts oras.ExtendedCopyGraphOptions) error {
@@ -20,6 +20,10 @@ package root
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,16 +32,30 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras/cmd/oras/internal/display"
 	"oras.land/oras/cmd/oras/internal/display/status"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/promotion"
+	"oras.land/oras/internal/signature"
+	"oras.land/oras/internal/tagfilter"
 	"oras.land/oras/internal/testutils"
 )
 
@@ -452,3 +470,729 @@ func Test_getMountPoint(t *testing.T) {
 		})
 	}
 }
+
+func Test_layoutArchive(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "layout.tar")
+	if !isLayoutArchive(path) {
+		t.Fatalf("isLayoutArchive(%q) = false, want true", path)
+	}
+	if isLayoutArchive(t.TempDir()) {
+		t.Fatal("isLayoutArchive() = true for a directory")
+	}
+
+	// copy twice to check that existing content is kept
+	for _, tag := range []string{"v1", "v2"} {
+		archive, err := openLayoutArchive(path)
+		if err != nil {
+			t.Fatalf("openLayoutArchive() error = %v", err)
+		}
+		store, err := oci.New(archive.dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := oras.Copy(ctx, memStore, memDesc.Digest.String(), store, tag, oras.DefaultCopyOptions); err != nil {
+			t.Fatal(err)
+		}
+		if err := archive.commit(); err != nil {
+			t.Fatalf("commit() error = %v", err)
+		}
+		archive.cleanup(logrus.New())
+	}
+
+	store, err := oci.NewFromTar(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range []string{"v1", "v2"} {
+		if _, err := store.Resolve(ctx, tag); err != nil {
+			t.Errorf("Resolve(%q) error = %v", tag, err)
+		}
+	}
+}
+
+func Test_pushSignature_existing(t *testing.T) {
+	ctx := context.Background()
+	dst := memory.New()
+	subject, err := oras.PackManifest(ctx, dst, oras.PackManifestVersion1_1, "test/artifact", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newSigner := func() *signature.Signer {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := signature.NewSigner(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signer
+	}
+	signer := newSigner()
+	first, err := pushSignature(ctx, dst, "localhost:5000/dst", subject, signer, oras.DefaultCopyGraphOptions)
+	if err != nil {
+		t.Fatalf("pushSignature() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		repository string
+		signer     *signature.Signer
+		wantSigned bool
+		wantTotal  int
+	}{
+		{"signed by the same key", "localhost:5000/dst", signer, false, 1},
+		{"signed by another key", "localhost:5000/dst", newSigner(), true, 2},
+		{"signed for another repository", "localhost:6000/dst", signer, true, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pushSignature(ctx, dst, tt.repository, subject, tt.signer, oras.DefaultCopyGraphOptions)
+			if err != nil {
+				t.Fatalf("pushSignature() error = %v", err)
+			}
+			if signed := got.Digest != first.Digest; signed != tt.wantSigned {
+				t.Errorf("pushSignature() signed = %v, want %v", signed, tt.wantSigned)
+			}
+			predecessors, err := dst.Predecessors(ctx, subject)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(predecessors) != tt.wantTotal {
+				t.Errorf("got %d signatures, want %d", len(predecessors), tt.wantTotal)
+			}
+		})
+	}
+}
+
+func Test_doCopy_resign(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	child, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "test/artifact", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{child},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, index)
+	if err := src.Push(ctx, root, bytes.NewReader(index)); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, root, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.NewSigner(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	staleSig, err := pushSignature(ctx, src, "localhost:5000/src", child, signer, oras.DefaultCopyGraphOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := memory.New()
+	var opts copyOptions
+	opts.recursive = true
+	opts.resign = true
+	opts.signer = signer
+	opts.From.Reference = "v1"
+	opts.To.Path = "localhost:6000/dst"
+	opts.To.Reference = "v1"
+	opts.Printer = output.NewPrinter(io.Discard, io.Discard)
+	statusHandler, _, _ := display.NewCopyHandler(opts.Printer, option.Format{Type: option.FormatTypeText.Name}, nil, dst)
+	if _, err := doCopy(ctx, statusHandler, src, dst, &opts); err != nil {
+		t.Fatalf("doCopy() error = %v", err)
+	}
+
+	// both the index and its child are signed for the destination
+	for _, subject := range []ocispec.Descriptor{root, child} {
+		referrers, err := registry.Referrers(ctx, dst, subject, signature.ArtifactType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(referrers) != 1 {
+			t.Fatalf("got %d signatures of %s, want 1", len(referrers), subject.Digest)
+		}
+		if referrers[0].Digest == staleSig.Digest {
+			t.Errorf("stale signature of %s is copied", subject.Digest)
+		}
+		bound, err := isSignatureBound(ctx, dst, referrers[0], opts.To.Path, subject)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bound {
+			t.Errorf("signature of %s is not bound to %s", subject.Digest, opts.To.Path)
+		}
+	}
+}
+
+func Test_dropStaleSignatures(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	subject, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "test/artifact", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.NewSigner(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigDesc, err := pushSignature(ctx, src, "localhost:5000/src", subject, signer, oras.DefaultCopyGraphOptions)
+	if err != nil {
+		t.Fatalf("pushSignature() error = %v", err)
+	}
+	sbomDesc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "test/sbom", oras.PackManifestOptions{Subject: &subject})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findPredecessors := func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		return src.Predecessors(ctx, desc)
+	}
+	tests := []struct {
+		name       string
+		repository string
+		want       []ocispec.Descriptor
+	}{
+		{"signature bound to the repository", "localhost:5000/src", []ocispec.Descriptor{sigDesc, sbomDesc}},
+		{"signature bound to another repository", "localhost:6000/dst", []ocispec.Descriptor{sbomDesc}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dropStaleSignatures(findPredecessors, tt.repository, nil, logrus.New())(ctx, src, subject)
+			if err != nil {
+				t.Fatalf("dropStaleSignatures() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("dropStaleSignatures() = %v, want %v", got, tt.want)
+			}
+			for _, want := range tt.want {
+				if !slices.ContainsFunc(got, func(desc ocispec.Descriptor) bool { return content.Equal(desc, want) }) {
+					t.Errorf("dropStaleSignatures() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	// verify the pushed signature
+	manifestJSON, err := content.FetchAll(ctx, src, sigDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	payload, err := content.FetchAll(ctx, src, manifest.Layers[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(manifest.Layers[0].Annotations[signature.AnnotationSignature])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signature.Verify(&key.PublicKey, payload, sig); err != nil {
+		t.Errorf("signature.Verify() error = %v", err)
+	}
+}
+
+func Test_parseTagSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      copyOptions
+		extraTags []string
+		wantTags  []string
+		wantErr   bool
+	}{
+		{
+			name: "single reference",
+			opts: copyOptions{},
+		},
+		{
+			name:      "multiple tags",
+			opts:      copyOptions{},
+			extraTags: []string{"v2", "v3"},
+			wantTags:  []string{"v1", "v2", "v3"},
+		},
+		{
+			name:      "multiple tags with destination tag",
+			opts:      copyOptions{extraRefs: []string{"extra"}},
+			extraTags: []string{"v2"},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid tag",
+			opts:      copyOptions{},
+			extraTags: []string{""},
+			wantErr:   true,
+		},
+		{
+			name: "all tags with regex",
+			opts: copyOptions{allTags: true, tagRegex: "^v1"},
+		},
+		{
+			name:    "all tags with invalid regex",
+			opts:    copyOptions{allTags: true, tagRegex: "("},
+			wantErr: true,
+		},
+		{
+			name:    "regex without all tags",
+			opts:    copyOptions{tagRegex: "^v1"},
+			wantErr: true,
+		},
+		{
+			name: "all tags with keep policy",
+			opts: copyOptions{allTags: true, keepPolicy: "latest-per-major"},
+		},
+		{
+			name:    "all tags with unknown keep policy",
+			opts:    copyOptions{allTags: true, keepPolicy: "newest"},
+			wantErr: true,
+		},
+		{
+			name:    "keep policy without all tags",
+			opts:    copyOptions{keepPolicy: "latest"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			if !opts.allTags {
+				opts.From.Reference = "v1"
+			}
+			err := parseTagSet(&opts, tt.extraTags)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTagSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !slices.Equal(opts.srcTags, tt.wantTags) {
+				t.Errorf("parseTagSet() srcTags = %v, want %v", opts.srcTags, tt.wantTags)
+			}
+		})
+	}
+
+	// all tags with a source tag
+	opts := copyOptions{allTags: true}
+	opts.From.Reference = "v1"
+	if err := parseTagSet(&opts, nil); err == nil {
+		t.Error("parseTagSet() error = nil, want error")
+	}
+}
+
+func Test_copyTagSet(t *testing.T) {
+	ctx := context.Background()
+	src, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer := []byte("shared")
+	layerDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, layer)
+	if err := src.Push(ctx, layerDesc, bytes.NewReader(layer)); err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range []string{"v1.0", "v1.1", "v2.0"} {
+		desc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+			Layers:              []ocispec.Descriptor{layerDesc},
+			ManifestAnnotations: map[string]string{"tag": tag},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := src.Tag(ctx, desc, tag); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dst, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var opts copyOptions
+	opts.allTags = true
+	opts.tagPattern = regexp.MustCompile(`^v1\.`)
+	opts.From.Path = "src"
+	opts.To.Path = "dst"
+	var out bytes.Buffer
+	opts.Printer = output.NewPrinter(&out, io.Discard)
+	statusHandler, metadataHandler, err := display.NewCopyHandler(opts.Printer, option.Format{Type: option.FormatTypeText.Name}, nil, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := copyTagSet(ctx, statusHandler, metadataHandler, src, dst, &opts); err != nil {
+		t.Fatalf("copyTagSet() error = %v", err)
+	}
+	tags, err := listTags(ctx, dst, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"v1.0", "v1.1"}; !slices.Equal(tags, want) {
+		t.Errorf("copied tags = %v, want %v", tags, want)
+	}
+	if got := strings.Count(out.String(), "Copied"); got != 2 {
+		t.Errorf("copyTagSet() reported %d copies, want 2:\n%s", got, out.String())
+	}
+
+	// copy the latest tag of each major version
+	if dst, err = oci.New(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	opts.tagPattern = nil
+	opts.tagPolicy = tagfilter.PolicyLatestPerMajor
+	if statusHandler, metadataHandler, err = display.NewCopyHandler(opts.Printer, option.Format{Type: option.FormatTypeText.Name}, nil, dst); err != nil {
+		t.Fatal(err)
+	}
+	if err := copyTagSet(ctx, statusHandler, metadataHandler, src, dst, &opts); err != nil {
+		t.Fatalf("copyTagSet() error = %v", err)
+	}
+	if tags, err = listTags(ctx, dst, nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"v1.1", "v2.0"}; !slices.Equal(tags, want) {
+		t.Errorf("copied tags = %v, want %v", tags, want)
+	}
+}
+
+func Test_doCopy_platforms(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	var manifests []ocispec.Descriptor
+	for _, arch := range []string{"amd64", "arm64", "s390x"} {
+		desc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+			ManifestAnnotations: map[string]string{"arch": arch},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		desc.Platform = &ocispec.Platform{OS: "linux", Architecture: arch}
+		manifests = append(manifests, desc)
+	}
+	index, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: manifests,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, index)
+	if err := src.Push(ctx, indexDesc, bytes.NewReader(index)); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, indexDesc, "multi"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		platforms []ocispec.Platform
+		want      []ocispec.Descriptor
+		wantErr   bool
+	}{
+		{
+			name:      "subset",
+			platforms: []ocispec.Platform{{OS: "linux", Architecture: "arm64"}, {OS: "linux", Architecture: "amd64"}},
+			want:      manifests[:2],
+		},
+		{
+			name:      "all",
+			platforms: []ocispec.Platform{{OS: "linux", Architecture: "amd64"}, {OS: "linux", Architecture: "arm64"}, {OS: "linux", Architecture: "s390x"}},
+			want:      manifests,
+		},
+		{
+			name:      "no match",
+			platforms: []ocispec.Platform{{OS: "windows", Architecture: "amd64"}, {OS: "linux", Architecture: "riscv64"}},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := memory.New()
+			var opts copyOptions
+			opts.From.Reference = "multi"
+			opts.To.Reference = "pruned"
+			opts.Platforms.Platforms = tt.platforms
+			opts.Printer = output.NewPrinter(io.Discard, io.Discard)
+			statusHandler, _, _ := display.NewCopyHandler(opts.Printer, option.Format{Type: option.FormatTypeText.Name}, nil, dst)
+			desc, err := doCopy(ctx, statusHandler, src, dst, &opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("doCopy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			tagged, err := dst.Resolve(ctx, "pruned")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !content.Equal(tagged, desc) {
+				t.Errorf("tagged %v, want %v", tagged, desc)
+			}
+			fetched, err := content.FetchAll(ctx, dst, desc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got ocispec.Index
+			if err := json.Unmarshal(fetched, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Manifests, tt.want) {
+				t.Errorf("copied index manifests = %v, want %v", got.Manifests, tt.want)
+			}
+			for _, manifest := range manifests {
+				exists, err := dst.Exists(ctx, manifest)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := slices.ContainsFunc(tt.want, func(desc ocispec.Descriptor) bool {
+					return content.Equal(desc, manifest)
+				}); exists != want {
+					t.Errorf("manifest of %s copied = %v, want %v", manifest.Platform.Architecture, exists, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_checkRecordedDigest(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	var manifests []ocispec.Descriptor
+	for _, arch := range []string{"amd64", "arm64", "s390x"} {
+		desc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+			ManifestAnnotations: map[string]string{"arch": arch},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		desc.Platform = &ocispec.Platform{OS: "linux", Architecture: arch}
+		manifests = append(manifests, desc)
+	}
+	index, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: manifests,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, index)
+	if err := src.Push(ctx, indexDesc, bytes.NewReader(index)); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, indexDesc, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	platforms := []ocispec.Platform{{OS: "linux", Architecture: "amd64"}, {OS: "linux", Architecture: "arm64"}}
+	pruned, _, err := pruneIndex(ctx, src, indexDesc, platforms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		platforms []ocispec.Platform
+		expected  digest.Digest
+		wantErr   bool
+	}{
+		{name: "full index", expected: indexDesc.Digest},
+		{name: "pruned index", platforms: platforms, expected: pruned.Digest},
+		{name: "pruned index recorded without platforms", expected: pruned.Digest, wantErr: true},
+		{name: "full index recorded with platforms", platforms: platforms, expected: indexDesc.Digest, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts copyOptions
+			opts.From.Reference = "v1"
+			opts.Platforms.Platforms = tt.platforms
+			opts.expectedDigest = tt.expected.String()
+			if err := checkRecordedDigest(ctx, src, &opts); (err != nil) != tt.wantErr {
+				t.Errorf("checkRecordedDigest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_filterReferrers(t *testing.T) {
+	subject := ocispec.Descriptor{Digest: "sha256:subject"}
+	referrers := []ocispec.Descriptor{
+		{Digest: "sha256:signature", ArtifactType: "application/vnd.cncf.notary.signature"},
+		{Digest: "sha256:sbom", ArtifactType: "application/spdx+json"},
+		{Digest: "sha256:attestation", ArtifactType: "application/vnd.dev.sigstore.bundle.v0.3+json"},
+	}
+	find := func(context.Context, content.ReadOnlyGraphStorage, ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		return referrers, nil
+	}
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []ocispec.Descriptor
+	}{
+		{
+			name:    "exclude",
+			exclude: []string{"application/vnd.dev.sigstore.bundle.v0.3+json"},
+			want:    referrers[:2],
+		},
+		{
+			name:    "include",
+			include: []string{"application/spdx+json", "application/vnd.cncf.notary.signature"},
+			want:    referrers[:2],
+		},
+		{
+			name:    "include and exclude",
+			include: []string{"application/spdx+json", "application/vnd.cncf.notary.signature"},
+			exclude: []string{"application/spdx+json"},
+			want:    referrers[:1],
+		},
+		{
+			name:    "no match",
+			include: []string{"application/vnd.unknown"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterReferrers(find, tt.include, tt.exclude, logrus.New())(context.Background(), nil, subject)
+			if err != nil {
+				t.Fatalf("filterReferrers() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterReferrers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_doCopy_manifestsOnly(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	var layers []ocispec.Descriptor
+	for _, blob := range []string{"replicated", "missing"} {
+		desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, []byte(blob))
+		if err := src.Push(ctx, desc, strings.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		layers = append(layers, desc)
+	}
+	config := []byte(`{"key":"value"}`)
+	configDesc := content.NewDescriptorFromBytes("application/vnd.test.config+json", config)
+	if err := src.Push(ctx, configDesc, bytes.NewReader(config)); err != nil {
+		t.Fatal(err)
+	}
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+		ConfigDescriptor: &configDesc,
+		Layers:           layers,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, root, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	dst := memory.New()
+	if err := dst.Push(ctx, layers[0], strings.NewReader("replicated")); err != nil {
+		t.Fatal(err)
+	}
+
+	var opts copyOptions
+	opts.manifestsOnly = true
+	opts.From.Reference = "v1"
+	opts.Printer = output.NewPrinter(io.Discard, io.Discard)
+	statusHandler, _, _ := display.NewCopyHandler(opts.Printer, option.Format{Type: option.FormatTypeText.Name}, nil, dst)
+	if _, err := doCopy(ctx, statusHandler, src, dst, &opts); err != nil {
+		t.Fatalf("doCopy() error = %v", err)
+	}
+	for _, tt := range []struct {
+		desc ocispec.Descriptor
+		want bool
+	}{
+		{root, true},
+		{configDesc, true},
+		{layers[0], true},
+		{layers[1], false},
+	} {
+		if exists, err := dst.Exists(ctx, tt.desc); err != nil || exists != tt.want {
+			t.Errorf("dst.Exists(%s) = %v, %v, want %v", tt.desc.Digest, exists, err, tt.want)
+		}
+	}
+}
+
+func Test_setRecordEntry(t *testing.T) {
+	entry := promotion.Entry{
+		Source:            "localhost:5000/staging",
+		SourceType:        option.TargetTypeRemote,
+		SourceDigest:      manifestDigest,
+		Destination:       "./prod",
+		DestinationType:   option.TargetTypeOCILayout,
+		DestinationDigest: manifestDigest,
+	}
+	var opts copyOptions
+	opts.To.Path = "localhost:6000/prod"
+	setRecordEntry(&opts, entry)
+	if want := "localhost:5000/staging@" + manifestDigest; opts.From.RawReference != want || opts.From.IsOCILayout {
+		t.Errorf("source = %q, OCI layout %v, want %q", opts.From.RawReference, opts.From.IsOCILayout, want)
+	}
+	if want := "./prod@" + manifestDigest; opts.To.RawReference != want || !opts.To.IsOCILayout || opts.To.Path != "" {
+		t.Errorf("destination = %q, OCI layout %v, want %q", opts.To.RawReference, opts.To.IsOCILayout, want)
+	}
+
+	entry.Platforms = []ocispec.Platform{{OS: "linux", Architecture: "amd64"}, {OS: "linux", Architecture: "arm64"}}
+	setRecordEntry(&opts, entry)
+	if !reflect.DeepEqual(opts.Platforms.Platforms, entry.Platforms) || opts.Platforms.Platform != nil {
+		t.Errorf("platforms = %v, want %v", opts.Platforms.Platforms, entry.Platforms)
+	}
+
+	entry.DestinationTags = []string{"v1", "latest"}
+	setRecordEntry(&opts, entry)
+	if want := "./prod:v1"; opts.To.RawReference != want {
+		t.Errorf("destination = %q, want %q", opts.To.RawReference, want)
+	}
+	if want := []string{"latest"}; !slices.Equal(opts.extraRefs, want) {
+		t.Errorf("extra destination tags = %v, want %v", opts.extraRefs, want)
+	}
+}
+
+func Test_recordCopy(t *testing.T) {
+	var opts copyOptions
+	opts.From.Path = "localhost:5000/staging"
+	opts.From.Type = option.TargetTypeRemote
+	opts.From.Reference = "v1"
+	opts.To.Path = "localhost:6000/prod"
+	opts.To.Type = option.TargetTypeRemote
+	opts.To.Reference = "v1"
+	opts.extraRefs = []string{"latest"}
+	opts.record = &promotion.Record{}
+	if err := recordCopy(context.Background(), memStore, memDesc, &opts); err != nil {
+		t.Fatalf("recordCopy() error = %v", err)
+	}
+	if len(opts.record.Entries) != 1 {
+		t.Fatalf("recorded %d entries, want 1", len(opts.record.Entries))
+	}
+	got := opts.record.Entries[0]
+	if got.SourceDigest != memDesc.Digest.String() || got.DestinationDigest != memDesc.Digest.String() {
+		t.Errorf("recorded digests %s => %s, want %s", got.SourceDigest, got.DestinationDigest, memDesc.Digest)
+	}
+	if want := []string{"v1", "latest"}; !slices.Equal(got.DestinationTags, want) {
+		t.Errorf("recorded destination tags = %v, want %v", got.DestinationTags, want)
+	}
+	if got.Source != opts.From.Path || got.Destination != opts.To.Path || got.SourceReference != "v1" {
+		t.Errorf("recorded %+v, want source %s:v1 and destination %s", got, opts.From.Path, opts.To.Path)
+	}
+	if got.Platforms != nil {
+		t.Errorf("recorded platforms = %v, want none", got.Platforms)
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/descriptor"
+)
+
+// copyDiff is a difference between the source and the destination found by
+// cp --verify-only.
+type copyDiff struct {
+	// Status is either "Missing" or "Mismatch".
+	Status string
+	// Node is the source node, or the tagged root if Tag is set.
+	Node ocispec.Descriptor
+	// Tag is the destination tag not pointing to the root.
+	Tag string
+	// Got is the node found in the destination for a mismatch.
+	Got ocispec.Descriptor
+}
+
+// String returns the line reporting the difference.
+func (d copyDiff) String() string {
+	if d.Tag != "" {
+		if d.Status == "Missing" {
+			return fmt.Sprintf("%s tag %s", d.Status, d.Tag)
+		}
+		return fmt.Sprintf("%s tag %s: %s, want %s", d.Status, d.Tag, d.Got.Digest, d.Node.Digest)
+	}
+	if d.Status == "Missing" {
+		return fmt.Sprintf("%s %s %s", d.Status, d.Node.Digest, d.Node.MediaType)
+	}
+	return fmt.Sprintf("%s %s %s: size %d, want %d", d.Status, d.Node.Digest, d.Node.MediaType, d.Got.Size, d.Node.Size)
+}
+
+// verifyCopy checks that the artifact to copy, along with its referrers with
+// --recursive, exists in the destination as in the source without copying
+// anything, and reports the differences found.
+func verifyCopy(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.ReadOnlyTarget, opts *copyOptions) error {
+	root, err := resolveSource(ctx, src, opts)
+	if err != nil {
+		return err
+	}
+	var findPredecessors findPredecessorsFunc
+	if opts.recursive {
+		findPredecessors = findCopyReferrers(ctx, opts, nil)
+	}
+	count, diffs, err := diffGraph(ctx, src, dst, root, findPredecessors)
+	if err != nil {
+		return err
+	}
+	tagDiffs, err := diffTags(ctx, dst, root, append([]string{opts.To.Reference}, opts.extraRefs...))
+	if err != nil {
+		return err
+	}
+	diffs = append(diffs, tagDiffs...)
+	for _, diff := range diffs {
+		if err := opts.Printer.Println(diff.String()); err != nil {
+			return err
+		}
+	}
+	if len(diffs) > 0 {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("found %d differences between %s and %s", len(diffs), opts.From.GetDisplayReference(), opts.To.GetDisplayReference()),
+			Recommendation: "Copy the artifact again without --verify-only",
+		}
+	}
+	return opts.Printer.Println("Verified", count, "nodes of", root.Digest, "in", opts.To.GetDisplayReference())
+}
+
+// diffGraph walks the graph of src rooted at root, along with the
+// predecessors found by findPredecessors if not nil, and returns the number
+// of nodes walked and the ones missing or differing in dst.
+func diffGraph(ctx context.Context, src oras.ReadOnlyGraphTarget, dst oras.ReadOnlyTarget, root ocispec.Descriptor, findPredecessors findPredecessorsFunc) (int, []copyDiff, error) {
+	var diffs []copyDiff
+	visited := map[digest.Digest]bool{root.Digest: true}
+	queue := []ocispec.Descriptor{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		got, exists, err := statDestination(ctx, dst, node)
+		if err != nil {
+			return 0, nil, err
+		}
+		switch {
+		case !exists:
+			diffs = append(diffs, copyDiff{Status: "Missing", Node: node})
+		case got.Size != node.Size:
+			diffs = append(diffs, copyDiff{Status: "Mismatch", Node: node, Got: got})
+		}
+
+		next, err := content.Successors(ctx, src, node)
+		if err != nil {
+			return 0, nil, err
+		}
+		if findPredecessors != nil && descriptor.IsManifest(node) {
+			predecessors, err := findPredecessors(ctx, src, node)
+			if err != nil {
+				return 0, nil, err
+			}
+			next = append(next, predecessors...)
+		}
+		for _, desc := range next {
+			if !visited[desc.Digest] {
+				visited[desc.Digest] = true
+				queue = append(queue, desc)
+			}
+		}
+	}
+	return len(visited), diffs, nil
+}
+
+// diffTags returns the tags in refs not pointing to root in dst. Empty
+// references and digests are skipped.
+func diffTags(ctx context.Context, dst oras.ReadOnlyTarget, root ocispec.Descriptor, refs []string) ([]copyDiff, error) {
+	var diffs []copyDiff
+	for _, ref := range refs {
+		if _, err := digest.Parse(ref); ref == "" || err == nil {
+			continue
+		}
+		got, err := dst.Resolve(ctx, ref)
+		if err != nil {
+			if !errors.Is(err, errdef.ErrNotFound) {
+				return nil, err
+			}
+			diffs = append(diffs, copyDiff{Status: "Missing", Node: root, Tag: ref})
+			continue
+		}
+		if got.Digest != root.Digest {
+			diffs = append(diffs, copyDiff{Status: "Mismatch", Node: root, Tag: ref, Got: got})
+		}
+	}
+	return diffs, nil
+}
+
+// statDestination returns the descriptor of desc in dst, resolved from the
+// registry to compare its size if dst is a repository.
+func statDestination(ctx context.Context, dst oras.ReadOnlyTarget, desc ocispec.Descriptor) (ocispec.Descriptor, bool, error) {
+	repo, ok := dst.(interface {
+		Blobs() registry.BlobStore
+		Manifests() registry.ManifestStore
+	})
+	if !ok {
+		exists, err := dst.Exists(ctx, desc)
+		return desc, exists, err
+	}
+	var resolver content.Resolver = repo.Blobs()
+	if descriptor.IsManifest(desc) {
+		resolver = repo.Manifests()
+	}
+	got, err := resolver.Resolve(ctx, desc.Digest.String())
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return ocispec.Descriptor{}, false, nil
+		}
+		return ocispec.Descriptor{}, false, err
+	}
+	return got, true, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func Test_diffGraph(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	push := func(target oras.Target, desc ocispec.Descriptor, blob string) {
+		if err := target.Push(ctx, desc, strings.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	layer := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, []byte("layer"))
+	push(src, layer, "layer")
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	referrer, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.sbom", oras.PackManifestOptions{
+		Subject: &root,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := ocispec.DescriptorEmptyJSON
+	fetched, err := content.FetchAll(ctx, src, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		recursive bool
+		prepare   func(dst *memory.Store)
+		wantCount int
+		want      []copyDiff
+	}{
+		{
+			name: "complete copy",
+			prepare: func(dst *memory.Store) {
+				push(dst, layer, "layer")
+				push(dst, config, string(config.Data))
+				push(dst, root, string(fetched))
+			},
+			wantCount: 3,
+		},
+		{
+			name: "missing layer",
+			prepare: func(dst *memory.Store) {
+				push(dst, config, string(config.Data))
+				push(dst, root, string(fetched))
+			},
+			wantCount: 3,
+			want:      []copyDiff{{Status: "Missing", Node: layer}},
+		},
+		{
+			name:      "missing referrer",
+			recursive: true,
+			prepare: func(dst *memory.Store) {
+				push(dst, layer, "layer")
+				push(dst, config, string(config.Data))
+				push(dst, root, string(fetched))
+			},
+			wantCount: 4,
+			want:      []copyDiff{{Status: "Missing", Node: referrer}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := memory.New()
+			tt.prepare(dst)
+			var findPredecessors findPredecessorsFunc
+			if tt.recursive {
+				findPredecessors = findCopyReferrers(ctx, &copyOptions{}, nil)
+			}
+			count, got, err := diffGraph(ctx, src, dst, root, findPredecessors)
+			if err != nil {
+				t.Fatalf("diffGraph() error = %v", err)
+			}
+			if count != tt.wantCount {
+				t.Errorf("diffGraph() count = %d, want %d", count, tt.wantCount)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffGraph() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_diffTags(t *testing.T) {
+	ctx := context.Background()
+	dst := memory.New()
+	root, err := oras.PackManifest(ctx, dst, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := oras.PackManifest(ctx, dst, oras.PackManifestVersion1_1, "application/vnd.other", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.Tag(ctx, root, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.Tag(ctx, other, "v2"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := diffTags(ctx, dst, root, []string{"", root.Digest.String(), "v1", "v2", "v3"})
+	if err != nil {
+		t.Fatalf("diffTags() error = %v", err)
+	}
+	want := []copyDiff{
+		{Status: "Mismatch", Node: root, Tag: "v2", Got: other},
+		{Status: "Missing", Node: root, Tag: "v3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffTags() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,314 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status"
+	"oras.land/oras/internal/delta"
+	"oras.land/oras/internal/descriptor"
+)
+
+// errDeltaTooLarge is returned if a delta is not smaller than the content it
+// reconstructs.
+var errDeltaTooLarge = errors.New("delta is too large")
+
+// limitedWriter is a writer refusing to write beyond limit bytes.
+type limitedWriter struct {
+	io.Writer
+	n     int64
+	limit int64
+}
+
+// Write writes p to the underlying writer.
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.n+int64(len(p)) >= w.limit {
+		return 0, errDeltaTooLarge
+	}
+	n, err := w.Writer.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// deltaStore is a read-only target of the delta layers spooled to files in a
+// temporary directory, so that large deltas are not held in memory.
+type deltaStore struct {
+	dir   string
+	lock  sync.Mutex
+	paths map[digest.Digest]string
+}
+
+// newDeltaStore creates a delta store in the temporary directory. The store
+// must be closed to remove the spooled files.
+func newDeltaStore() (*deltaStore, error) {
+	dir, err := os.MkdirTemp("", "oras-delta-")
+	if err != nil {
+		return nil, err
+	}
+	return &deltaStore{dir: dir, paths: make(map[digest.Digest]string)}, nil
+}
+
+// Fetch fetches the spooled delta of target.
+func (s *deltaStore) Fetch(_ context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	s.lock.Lock()
+	path, ok := s.paths[target.Digest]
+	s.lock.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", target.Digest, errdef.ErrNotFound)
+	}
+	return os.Open(path)
+}
+
+// Exists returns true if the delta of target is spooled.
+func (s *deltaStore) Exists(_ context.Context, target ocispec.Descriptor) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, ok := s.paths[target.Digest]
+	return ok, nil
+}
+
+// Resolve returns ErrNotFound as the store has no tags.
+func (s *deltaStore) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	return ocispec.Descriptor{}, fmt.Errorf("%s: %w", reference, errdef.ErrNotFound)
+}
+
+// Close removes the spooled files.
+func (s *deltaStore) Close() error {
+	return os.RemoveAll(s.dir)
+}
+
+// spool writes the delta produced by write to a file, returning its digest
+// and size.
+func (s *deltaStore) spool(write func(w io.Writer) error) (digest.Digest, int64, error) {
+	fp, err := os.CreateTemp(s.dir, "delta-*")
+	if err != nil {
+		return "", 0, err
+	}
+	digester := digest.Canonical.Digester()
+	w := &countingWriter{w: io.MultiWriter(fp, digester.Hash())}
+	err = write(w)
+	if closeErr := fp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(fp.Name())
+		return "", 0, err
+	}
+	dgst := digester.Digest()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if path, ok := s.paths[dgst]; ok {
+		// the same delta is spooled already
+		_ = os.Remove(path)
+	}
+	s.paths[dgst] = fp.Name()
+	return dgst, w.n, nil
+}
+
+// deltaLayers replaces the named layers with delta layers against the files
+// of the same names in the manifest from, if smaller. The deltas are spooled
+// to dst. The base manifest is returned if any delta layer is created.
+func deltaLayers(ctx context.Context, base oras.ReadOnlyTarget, from string, src content.Fetcher, dst *deltaStore, layers []ocispec.Descriptor) ([]ocispec.Descriptor, *ocispec.Descriptor, error) {
+	baseManifest, err := base.Resolve(ctx, from)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve %s: %w", from, err)
+	}
+	if baseManifest.MediaType != ocispec.MediaTypeImageManifest {
+		return nil, nil, fmt.Errorf("%s: delta base must be an OCI image manifest, got %s", from, baseManifest.MediaType)
+	}
+	tempDir, err := os.MkdirTemp("", "oras-delta-")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+	resolver := &delta.Resolver{Source: base, TempDir: tempDir}
+
+	var based bool
+	ret := make([]ocispec.Descriptor, 0, len(layers))
+	for _, layer := range layers {
+		name := layer.Annotations[ocispec.AnnotationTitle]
+		if name == "" {
+			ret = append(ret, layer)
+			continue
+		}
+		layerDelta, err := newDeltaLayer(ctx, resolver, baseManifest, src, dst, layer)
+		switch {
+		case errors.Is(err, delta.ErrFileNotFound), errors.Is(err, errDeltaTooLarge):
+			ret = append(ret, layer)
+			continue
+		case err != nil:
+			return nil, nil, err
+		case layerDelta == nil:
+			// unchanged since the base
+			ret = append(ret, layer)
+			continue
+		}
+		ret = append(ret, *layerDelta)
+		based = true
+	}
+	if !based {
+		return ret, nil, nil
+	}
+	return ret, &baseManifest, nil
+}
+
+// newDeltaLayer returns the delta layer of layer against the file of the same
+// name in baseManifest, spooling the delta to deltas. No layer is returned if
+// the file is unchanged and fully stored in the base.
+func newDeltaLayer(ctx context.Context, resolver *delta.Resolver, baseManifest ocispec.Descriptor, src content.Fetcher, deltas *deltaStore, layer ocispec.Descriptor) (*ocispec.Descriptor, error) {
+	name := layer.Annotations[ocispec.AnnotationTitle]
+	basePath, baseDesc, err := resolver.Reconstruct(ctx, baseManifest, name)
+	if err != nil {
+		return nil, err
+	}
+	if baseDesc.Digest == layer.Digest {
+		if exists, err := resolver.Source.Exists(ctx, layer); err == nil && exists {
+			return nil, nil
+		}
+	}
+	baseFile, err := os.Open(basePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = baseFile.Close() }()
+	rc, err := src.Fetch(ctx, layer)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	dgst, size, err := deltas.spool(func(w io.Writer) error {
+		return delta.Diff(baseFile, rc, &limitedWriter{Writer: w, limit: layer.Size})
+	})
+	if err != nil {
+		return nil, err
+	}
+	desc := delta.NewLayer(dgst, size, name, baseDesc.Digest, layer)
+	return &desc, nil
+}
+
+// deltaFile is a file to be reconstructed from a delta layer.
+type deltaFile struct {
+	manifest ocispec.Descriptor
+	name     string
+}
+
+// applyDeltas reconstructs the files of delta layers into the output
+// directory, using local files of the base versions when available.
+func applyDeltas(ctx context.Context, src oras.ReadOnlyTarget, files []deltaFile, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, po *pullOptions) error {
+	tempDir, err := os.MkdirTemp("", "oras-delta-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+	resolver := &delta.Resolver{
+		Source:  &statusTarget{ReadOnlyTarget: src, statusHandler: statusHandler},
+		TempDir: tempDir,
+		Local: func(name string, dgst digest.Digest) (string, bool) {
+			path, err := outputPath(name, po)
+			if err != nil {
+				return "", false
+			}
+			return path, fileDigestEquals(path, dgst)
+		},
+	}
+	done := make(map[string]bool)
+	for _, f := range files {
+		key := f.manifest.Digest.String() + "/" + f.name
+		if done[key] {
+			continue
+		}
+		done[key] = true
+		path, target, err := resolver.Reconstruct(ctx, f.manifest, f.name)
+		if err != nil {
+			return err
+		}
+		if target.Annotations == nil {
+			target.Annotations = map[string]string{}
+		}
+		target.Annotations[ocispec.AnnotationTitle] = f.name
+		if outPath, err := outputPath(f.name, po); err != nil || outPath != path {
+			if err := writeOutputFile(f.name, po, func(w io.Writer) error {
+				r, err := os.Open(path)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = r.Close() }()
+				_, err = io.Copy(w, r)
+				return err
+			}); err != nil {
+				return err
+			}
+		}
+		if err := metadataHandler.OnFilePulled(f.name, po.Output, target, po.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileDigestEquals returns true if the regular file at path has the content
+// of dgst.
+func fileDigestEquals(path string, dgst digest.Digest) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	if fi, err := f.Stat(); err != nil || !fi.Mode().IsRegular() {
+		return false
+	}
+	verifier := dgst.Verifier()
+	if _, err := io.Copy(verifier, f); err != nil {
+		return false
+	}
+	return verifier.Verified()
+}
+
+// statusTarget reports the status of fetching blobs.
+type statusTarget struct {
+	oras.ReadOnlyTarget
+	statusHandler status.PullHandler
+}
+
+// Fetch fetches the content and reports its status.
+func (t *statusTarget) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if descriptor.IsManifest(target) {
+		return t.ReadOnlyTarget.Fetch(ctx, target)
+	}
+	if err := t.statusHandler.OnNodeDownloading(target); err != nil {
+		return nil, err
+	}
+	rc, err := t.ReadOnlyTarget.Fetch(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	return &chunkReadCloser{ReadCloser: rc, onClose: func() error {
+		return t.statusHandler.OnNodeDownloaded(target)
+	}}, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"os"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras/internal/delta"
+)
+
+func Test_deltaLayers(t *testing.T) {
+	ctx := context.Background()
+	base := make([]byte, 1<<20)
+	rand.New(rand.NewSource(1)).Read(base)
+	target := bytes.Clone(base)
+	copy(target[1000:], "changed")
+
+	store := memory.New()
+	newLayer := func(data []byte) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, data)
+		if err := store.Push(ctx, desc, bytes.NewReader(data)); err != nil {
+			t.Fatal(err)
+		}
+		desc.Annotations = map[string]string{ocispec.AnnotationTitle: "app.bin"}
+		return desc
+	}
+	baseLayer := newLayer(base)
+	baseManifest, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{baseLayer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Tag(ctx, baseManifest, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	targetLayer := newLayer(target)
+
+	deltas, err := newDeltaStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	layers, gotBase, err := deltaLayers(ctx, store, "v1", store, deltas, []ocispec.Descriptor{targetLayer})
+	if err != nil {
+		t.Fatalf("deltaLayers() error = %v", err)
+	}
+	if gotBase == nil || gotBase.Digest != baseManifest.Digest {
+		t.Fatalf("deltaLayers() base = %v, want %v", gotBase, baseManifest)
+	}
+	if len(layers) != 1 || !delta.IsDelta(layers[0]) {
+		t.Fatalf("deltaLayers() layers = %v, want a delta layer", layers)
+	}
+
+	// the spooled delta reconstructs the target
+	rc, err := deltas.Fetch(ctx, layers[0])
+	if err != nil {
+		t.Fatalf("deltaStore.Fetch() error = %v", err)
+	}
+	defer rc.Close()
+	verified := content.NewVerifyReader(rc, layers[0])
+	var got bytes.Buffer
+	if err := delta.Apply(bytes.NewReader(base), verified, &got); err != nil {
+		t.Fatalf("delta.Apply() error = %v", err)
+	}
+	if err := verified.Verify(); err != nil {
+		t.Errorf("spooled delta mismatches its descriptor: %v", err)
+	}
+	if !bytes.Equal(got.Bytes(), target) {
+		t.Error("reconstructed content mismatches the target")
+	}
+
+	if err := deltas.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(deltas.dir); !os.IsNotExist(err) {
+		t.Errorf("spooled deltas are not removed: %v", err)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/registry"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
@@ -40,8 +41,11 @@ type discoverOptions struct {
 	option.Format
 	option.Terminal
 
-	artifactType string
-	depth        int
+	artifactType     string
+	depth            int
+	includeManifests bool
+	wide             bool
+	showAnnotations  []string
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -67,12 +71,18 @@ Example - Discover referrers via tag scheme:
 Example - [Experimental] Discover referrers and display in a table view:
   oras discover localhost:5000/hello:v1 --format table
 
+Example - [Experimental] Discover referrers and list their artifact types, creation times, sizes and the 'org.opencontainers.image.source' annotations in a table view:
+  oras discover localhost:5000/hello:v1 --wide --show-annotation org.opencontainers.image.source
+
 Example - [Experimental] Discover referrers and format output with Go template:
   oras discover localhost:5000/hello:v1 --format go-template --template "{{.referrers}}"
 
 Example - [Experimental] Discover only direct referrers, displayed in json view:
   oras discover localhost:5000/hello:v1 --format json --depth 1
 
+Example - [Experimental] Discover referrers with their manifests, including annotations and layers, displayed in json view:
+  oras discover localhost:5000/hello:v1 --format json --include-manifests
+
 Example - Discover referrers with type 'test-artifact' of manifest 'hello:v1' in registry 'localhost:5000':
   oras discover --artifact-type test-artifact localhost:5000/hello:v1
 
@@ -90,6 +100,15 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 			if cmd.Flags().Changed("depth") && opts.depth < 1 {
 				return errors.New("depth value should be at least 1")
 			}
+			if opts.wide {
+				if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "wide", "format", "output"); err != nil {
+					return err
+				}
+				// only show direct referrers for the wide table view
+				opts.depth = 1
+			} else if cmd.Flags().Changed("show-annotation") {
+				return errors.New("--show-annotation can only be used with --wide")
+			}
 			// only show direct referrers for table format
 			if opts.FormatFlag == option.FormatTypeTable.Name {
 				opts.depth = 1
@@ -106,6 +125,12 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 					return errors.New("output type can only be tree, table or json")
 				}
 			}
+			if opts.includeManifests && opts.Format.Type != option.FormatTypeJSON.Name && opts.Format.Type != option.FormatTypeGoTemplate.Name {
+				return &oerrors.Error{
+					Err:            fmt.Errorf("--include-manifests cannot be used with the %s format", opts.Format.Type),
+					Recommendation: `Use "--format json" or "--format go-template" to include the referrer manifests`,
+				}
+			}
 			opts.DisableTTY(opts.Debug, false)
 			return nil
 		},
@@ -117,6 +142,9 @@ Example - Discover referrers of the manifest tagged 'example.com:v1' in an OCI i
 	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "artifact type")
 	cmd.Flags().StringVarP(&opts.FormatFlag, "output", "o", "tree", "[Deprecated] format in which to display referrers (table, json, or tree).")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "display full metadata of referrers")
+	cmd.Flags().BoolVar(&opts.includeManifests, "include-manifests", false, "[Experimental] include the manifest of each referrer in the json or go-template output")
+	cmd.Flags().BoolVar(&opts.wide, "wide", false, "[Experimental] list the artifact type, creation time, size and digest of each direct referrer in a table view")
+	cmd.Flags().StringArrayVar(&opts.showAnnotations, "show-annotation", nil, "[Experimental] annotation `key` to show as a column in the --wide table view, can be repeated")
 	cmd.Flags().IntVarP(&opts.depth, "depth", "", 0, "[Experimental] level of referrers to display, if unused shows referrers of all levels")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.SetTypes(
@@ -149,10 +177,22 @@ func runDiscover(cmd *cobra.Command, opts *discoverOptions) error {
 		return err
 	}
 
-	handler, err := display.NewDiscoverHandler(opts.Printer, opts.Format, opts.Path, opts.RawReference, desc, opts.verbose, opts.TTY)
-	if err != nil {
+	var handler metadata.DiscoverHandler
+	if opts.wide {
+		handler = display.NewWideDiscoverHandler(opts.Printer, opts.RawReference, desc, opts.showAnnotations)
+	} else if handler, err = display.NewDiscoverHandler(opts.Printer, opts.Format, opts.Path, opts.RawReference, desc, opts.verbose, opts.TTY); err != nil {
 		return err
 	}
+	if opts.includeManifests {
+		if manifestHandler, ok := handler.(metadata.DiscoverManifestHandler); ok {
+			handler = &referrerManifestFetcher{
+				DiscoverHandler: handler,
+				manifestHandler: manifestHandler,
+				ctx:             ctx,
+				fetcher:         repo,
+			}
+		}
+	}
 	if err := fetchAllReferrers(ctx, repo, desc, opts.artifactType, handler, opts.depth); err != nil {
 		return err
 	}
@@ -186,3 +226,24 @@ func fetchAllReferrers(ctx context.Context, repo oras.ReadOnlyGraphTarget, desc
 	}
 	return nil
 }
+
+// referrerManifestFetcher is a discover handler fetching the manifest of each
+// discovered referrer for the manifest handler.
+type referrerManifestFetcher struct {
+	metadata.DiscoverHandler
+	manifestHandler metadata.DiscoverManifestHandler
+	ctx             context.Context
+	fetcher         content.Fetcher
+}
+
+// OnDiscovered implements metadata.DiscoverHandler.
+func (f *referrerManifestFetcher) OnDiscovered(referrer, subject ocispec.Descriptor) error {
+	if err := f.DiscoverHandler.OnDiscovered(referrer, subject); err != nil {
+		return err
+	}
+	manifest, err := content.FetchAll(f.ctx, f.fetcher, referrer)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the manifest of referrer %s: %w", referrer.Digest, err)
+	}
+	return f.manifestHandler.OnManifestFetched(referrer, manifest)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/fileref"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+// checkPackingSpace warns if the temporary directory does not have enough space
+// for packing the directories referenced by fileRefs into archives. The
+// archives are compressed, so the size of the directories is only an upper
+// bound of the space required.
+func checkPackingSpace(ctx context.Context, tempDir *option.TempDir, fileRefs []string, logger logrus.FieldLogger) error {
+	var required int64
+	for _, fileRef := range fileRefs {
+		filename, _, err := fileref.Parse(fileRef, "")
+		if err != nil {
+			return err
+		}
+		if fi, err := os.Stat(filename); err != nil || !fi.IsDir() {
+			// files are not packed, and missing files are reported later
+			continue
+		}
+		size, err := dirSize(filename)
+		if err != nil {
+			return err
+		}
+		required += size
+	}
+	if err := tempDir.CheckSpace(ctx, os.TempDir(), required, "packing directories"); err != nil {
+		var oerr *oerrors.Error
+		if errors.As(err, &oerr) {
+			err = oerr.Err
+		}
+		logger.Warnf("%v uncompressed, the compressed archives may need less space", err)
+	}
+	return nil
+}
+
+// dirSize returns the total size of the regular files under dir.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,57 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+func Test_dirSize(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "b"), []byte("world!"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := dirSize(dir)
+	if err != nil {
+		t.Fatalf("dirSize() error = %v", err)
+	}
+	if want := int64(11); got != want {
+		t.Errorf("dirSize() = %d, want %d", got, want)
+	}
+}
+
+func Test_checkPackingSpace(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fileRefs := []string{dir, filepath.Join(dir, "a") + ":text/plain", filepath.Join(dir, "missing")}
+	if err := checkPackingSpace(context.Background(), &option.TempDir{}, fileRefs, logrus.New()); err != nil {
+		t.Errorf("checkPackingSpace() error = %v", err)
+	}
+}
@@ -16,20 +16,39 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"io/fs"
 	"maps"
+	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
 	"oras.land/oras/cmd/oras/internal/display/status"
 	"oras.land/oras/cmd/oras/internal/fileref"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/split"
 )
 
-func loadFiles(ctx context.Context, store *file.Store, annotations map[string]map[string]string, fileRefs []string, displayStatus status.PushHandler) ([]ocispec.Descriptor, error) {
+// loadFiles adds the referenced files to store and returns their descriptors.
+// If chunks is not nil, files are split into chunks served by chunks instead
+// when needed. If packer is not nil, directories are packed by packer instead
+// of store when needed. A file referenced multiple times under different
+// names, such as via hard links, is hashed once.
+func loadFiles(ctx context.Context, store *file.Store, chunks *split.Store, packer *dirPacker, annotations map[string]map[string]string, fileRefs []string, displayStatus status.PushHandler) ([]ocispec.Descriptor, error) {
 	var files []ocispec.Descriptor
+	var loaded []loadedFile
+	names := make(map[string]bool)
 	for _, fileRef := range fileRefs {
 		filename, mediaType, err := fileref.Parse(fileRef, "")
 		if err != nil {
@@ -52,10 +71,58 @@ func loadFiles(ctx context.Context, store *file.Store, annotations map[string]ma
 		if err != nil {
 			return nil, err
 		}
-		file, err := addFile(ctx, store, name, mediaType, filename)
+		if chunks != nil {
+			descs, err := chunks.Add(name, mediaType, filename)
+			if err != nil {
+				var pathErr *fs.PathError
+				if errors.As(err, &pathErr) {
+					err = pathErr
+				}
+				return nil, err
+			}
+			if descs != nil {
+				for i := range descs {
+					if descs[i].Annotations == nil {
+						// content-defined chunks may be shared by files
+						continue
+					}
+					// chunks are not titled so that they are not pulled as files
+					for k, v := range annotations[filename] {
+						if k != ocispec.AnnotationTitle {
+							descs[i].Annotations[k] = v
+						}
+					}
+				}
+				files = append(files, descs...)
+				continue
+			}
+		}
+		if names[name] {
+			return nil, fmt.Errorf("%s: %w", name, file.ErrDuplicateName)
+		}
+		names[name] = true
+		info, err := os.Stat(filename)
 		if err != nil {
+			var pathErr *fs.PathError
+			if errors.As(err, &pathErr) {
+				err = pathErr
+			}
 			return nil, err
 		}
+		file, ok := findLoadedFile(loaded, info, name, mediaType)
+		if !ok && packer != nil && info.IsDir() {
+			if file, ok, err = packer.add(ctx, store, name, mediaType, filename); err != nil {
+				return nil, err
+			}
+		}
+		if !ok {
+			if file, err = addFile(ctx, store, name, mediaType, filename); err != nil {
+				return nil, err
+			}
+			if info.Mode().IsRegular() {
+				loaded = append(loaded, loadedFile{info: info, desc: file})
+			}
+		}
 		if value, ok := annotations[filename]; ok {
 			if file.Annotations == nil {
 				file.Annotations = value
@@ -73,6 +140,164 @@ func loadFiles(ctx context.Context, store *file.Store, annotations map[string]ma
 	return files, nil
 }
 
+// loadedFile is a regular file loaded into a file store.
+type loadedFile struct {
+	info os.FileInfo
+	desc ocispec.Descriptor
+}
+
+// findLoadedFile returns the descriptor of the file described by info named
+// name if the same file has been loaded, without hashing the file again.
+func findLoadedFile(loaded []loadedFile, info os.FileInfo, name string, mediaType string) (ocispec.Descriptor, bool) {
+	for _, f := range loaded {
+		if !os.SameFile(f.info, info) {
+			continue
+		}
+		if mediaType == "" {
+			mediaType = ocispec.MediaTypeImageLayer
+		}
+		return ocispec.Descriptor{
+			MediaType: mediaType,
+			Digest:    f.desc.Digest,
+			Size:      f.desc.Size,
+			Annotations: map[string]string{
+				ocispec.AnnotationTitle: name,
+			},
+		}, true
+	}
+	return ocispec.Descriptor{}, false
+}
+
+// blobDeduplicator uploads each unique blob once across a command, even if it
+// is referenced by multiple layers with different titles or media types.
+type blobDeduplicator struct {
+	committed sync.Map // digest -> struct{}
+}
+
+// track records the blobs committed by copying with opts.
+func (d *blobDeduplicator) track(opts *oras.CopyGraphOptions) {
+	postCopy, onCopySkipped := opts.PostCopy, opts.OnCopySkipped
+	opts.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		d.committed.Store(desc.Digest, struct{}{})
+		if postCopy == nil {
+			return nil
+		}
+		return postCopy(ctx, desc)
+	}
+	opts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+		d.committed.Store(desc.Digest, struct{}{})
+		if onCopySkipped == nil {
+			return nil
+		}
+		return onCopySkipped(ctx, desc)
+	}
+}
+
+// findSuccessors returns a function finding the successors with find, or
+// content.Successors if find is nil, excluding the successors of the same
+// digest as a preceding sibling or a committed blob.
+func (d *blobDeduplicator) findSuccessors(find func(context.Context, content.Fetcher, ocispec.Descriptor) ([]ocispec.Descriptor, error)) func(context.Context, content.Fetcher, ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	if find == nil {
+		find = content.Successors
+	}
+	return func(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		successors, err := find(ctx, fetcher, desc)
+		if err != nil {
+			return nil, err
+		}
+		var unique []ocispec.Descriptor
+		seen := make(map[digest.Digest]bool)
+		for _, s := range successors {
+			if _, ok := d.committed.Load(s.Digest); ok || seen[s.Digest] {
+				continue
+			}
+			seen[s.Digest] = true
+			unique = append(unique, s)
+		}
+		return unique, nil
+	}
+}
+
+// configMediaTypes maps the file extensions to the media types of the config
+// files, if not specified. JSON files other than image configs keep the
+// unknown config media type, which is JSON as well.
+var configMediaTypes = map[string]string{
+	".yaml": "application/yaml",
+	".yml":  "application/yaml",
+	".toml": "application/toml",
+}
+
+// maxConfigSniffSize is the maximum size of a config file whose content is
+// inspected to infer its media type.
+const maxConfigSniffSize = 4 * 1024 * 1024
+
+// loadConfig adds the config file referenced by configRef, which is read from
+// stdin if the path is "-", to store and returns its descriptor. The media type
+// is inferred from the content and the file extension if not specified.
+func loadConfig(ctx context.Context, store *file.Store, configRef string, stdin io.Reader) (ocispec.Descriptor, error) {
+	path, mediaType, err := fileref.Parse(configRef, "")
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if path != "-" {
+		if mediaType == "" {
+			mediaType, err = inferConfigFileMediaType(path)
+			if err != nil {
+				return ocispec.Descriptor{}, err
+			}
+		}
+		return addFile(ctx, store, option.AnnotationConfig, mediaType, path)
+	}
+
+	blob, err := io.ReadAll(stdin)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to read config from stdin: %w", err)
+	}
+	if mediaType == "" {
+		mediaType = inferConfigMediaType("", blob)
+	}
+	desc := content.NewDescriptorFromBytes(mediaType, blob)
+	if err := store.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// inferConfigFileMediaType infers the media type of the config file at path.
+func inferConfigFileMediaType(path string) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	var blob []byte
+	if fi.Mode().IsRegular() && fi.Size() <= maxConfigSniffSize {
+		if blob, err = os.ReadFile(path); err != nil {
+			return "", err
+		}
+	}
+	return inferConfigMediaType(path, blob), nil
+}
+
+// inferConfigMediaType infers the media type of the config blob named name.
+// An OCI image config is recognized by its content, and the other configs by
+// the file extension. The unknown config media type is returned if nothing
+// is recognized.
+func inferConfigMediaType(name string, blob []byte) string {
+	var imageConfig struct {
+		Architecture string          `json:"architecture"`
+		OS           string          `json:"os"`
+		RootFS       *ocispec.RootFS `json:"rootfs"`
+	}
+	if len(blob) > 0 && json.Unmarshal(blob, &imageConfig) == nil &&
+		imageConfig.Architecture != "" && imageConfig.OS != "" && imageConfig.RootFS != nil {
+		return ocispec.MediaTypeImageConfig
+	}
+	if mediaType, ok := configMediaTypes[strings.ToLower(filepath.Ext(name))]; ok {
+		return mediaType
+	}
+	return oras.MediaTypeUnknownConfig
+}
+
 func addFile(ctx context.Context, store *file.Store, name string, mediaType string, filename string) (ocispec.Descriptor, error) {
 	file, err := store.Add(ctx, name, mediaType, filename)
 	if err != nil {
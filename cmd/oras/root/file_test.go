@@ -0,0 +1,238 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras/cmd/oras/internal/display/status"
+	"oras.land/oras/internal/sparse"
+)
+
+func Test_loadFiles_sameFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a")
+	if err := os.WriteFile(a, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Link(a, filepath.Join(dir, "b")); err != nil {
+		t.Skipf("hard links not supported: %v", err)
+	}
+	store, err := file.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	fileRefs := []string{a, filepath.Join(dir, "b") + ":text/plain"}
+	descs, err := loadFiles(ctx, store, nil, nil, nil, fileRefs, status.NewDiscardHandler())
+	if err != nil {
+		t.Fatalf("loadFiles() error = %v", err)
+	}
+	if len(descs) != 2 {
+		t.Fatalf("loadFiles() got %d descriptors, want 2", len(descs))
+	}
+	if descs[0].Digest != descs[1].Digest || descs[0].Size != descs[1].Size {
+		t.Errorf("loadFiles() got different content for the same file: %v, %v", descs[0], descs[1])
+	}
+	if got := descs[1].MediaType; got != "text/plain" {
+		t.Errorf("loadFiles() media type = %q, want %q", got, "text/plain")
+	}
+	if got, want := descs[1].Annotations[ocispec.AnnotationTitle], filepath.ToSlash(filepath.Join(dir, "b")); got != want {
+		t.Errorf("loadFiles() title = %q, want %q", got, want)
+	}
+
+	// the same name cannot be used twice
+	if _, err := loadFiles(ctx, store, nil, nil, nil, []string{filepath.Join(dir, "b"), filepath.Join(dir, "b")}, status.NewDiscardHandler()); err == nil {
+		t.Error("loadFiles() error = nil, want error for duplicate names")
+	}
+}
+
+func Test_loadFiles_sparse(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	disk := filepath.Join(dir, "disk")
+	if err := os.Mkdir(disk, 0755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(filepath.Join(disk, "disk.img"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = f.Truncate(16 << 20)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found, err := sparse.Detect(disk); err != nil || !found {
+		t.Skip("holes are not supported by the file system")
+	}
+	store, err := file.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	var packer dirPacker
+	defer func() { _ = packer.Close() }()
+	descs, err := loadFiles(ctx, store, nil, &packer, nil, []string{disk}, status.NewDiscardHandler())
+	if err != nil {
+		t.Fatalf("loadFiles() error = %v", err)
+	}
+	if len(descs) != 1 || !sparse.IsLayer(descs[0]) {
+		t.Fatalf("loadFiles() = %v, want a sparse directory layer", descs)
+	}
+	if descs[0].Size >= 16<<20 || descs[0].MediaType != ocispec.MediaTypeImageLayerGzip {
+		t.Errorf("loadFiles() = %v, want a small gzipped layer", descs[0])
+	}
+	rc, err := store.Fetch(ctx, descs[0])
+	if err != nil {
+		t.Fatalf("Store.Fetch() error = %v", err)
+	}
+	defer rc.Close()
+	if err := sparse.Extract(mustGunzip(t, rc), filepath.Join(dir, "out"), disk, nil); err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if fi, err := os.Stat(filepath.Join(dir, "out", "disk.img")); err != nil || fi.Size() != 16<<20 {
+		t.Errorf("extracted file = %v, %v, want %d bytes", fi, err, 16<<20)
+	}
+}
+
+// mustGunzip returns the decompressed content of r.
+func mustGunzip(t *testing.T, r io.Reader) io.Reader {
+	t.Helper()
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gzr
+}
+
+func Test_blobDeduplicator(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	blob := []byte("hello")
+	var layers []ocispec.Descriptor
+	for i, mediaType := range []string{"text/plain", ocispec.MediaTypeImageLayer} {
+		desc := content.NewDescriptorFromBytes(mediaType, blob)
+		desc.Annotations = map[string]string{ocispec.AnnotationTitle: []string{"a", "b"}[i]}
+		layers = append(layers, desc)
+	}
+	if err := src.Push(ctx, layers[0], bytes.NewReader(blob)); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Push(ctx, layers[1], bytes.NewReader(blob)); err != nil {
+		t.Fatal(err)
+	}
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{Layers: layers})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dedup blobDeduplicator
+	opts := oras.DefaultCopyGraphOptions
+	var copied []ocispec.Descriptor
+	opts.PostCopy = func(_ context.Context, desc ocispec.Descriptor) error {
+		copied = append(copied, desc)
+		return nil
+	}
+	dedup.track(&opts)
+	opts.FindSuccessors = dedup.findSuccessors(nil)
+	opts.Concurrency = 1
+	if err := oras.CopyGraph(ctx, src, memory.New(), root, opts); err != nil {
+		t.Fatalf("CopyGraph() error = %v", err)
+	}
+	// config, one of the layers and the manifest
+	if len(copied) != 3 {
+		t.Fatalf("copied %d nodes, want 3: %v", len(copied), copied)
+	}
+
+	// committed blobs are excluded
+	successors, err := dedup.findSuccessors(nil)(ctx, src, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(successors) != 0 {
+		t.Errorf("findSuccessors() = %v, want none", successors)
+	}
+}
+
+func Test_inferConfigMediaType(t *testing.T) {
+	imageConfig := []byte(`{"architecture":"amd64","os":"linux","rootfs":{"type":"layers","diff_ids":[]}}`)
+	tests := []struct {
+		name     string
+		fileName string
+		blob     []byte
+		want     string
+	}{
+		{name: "image config", fileName: "config.json", blob: imageConfig, want: ocispec.MediaTypeImageConfig},
+		{name: "image config from stdin", blob: imageConfig, want: ocispec.MediaTypeImageConfig},
+		{name: "json", fileName: "config.json", blob: []byte(`{"foo":"bar"}`), want: oras.MediaTypeUnknownConfig},
+		{name: "yaml", fileName: "config.YML", blob: []byte("foo: bar"), want: "application/yaml"},
+		{name: "toml", fileName: "config.toml", want: "application/toml"},
+		{name: "unknown", fileName: "config", blob: []byte("foo"), want: oras.MediaTypeUnknownConfig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inferConfigMediaType(tt.fileName, tt.blob); got != tt.want {
+				t.Errorf("inferConfigMediaType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadConfig(t *testing.T) {
+	ctx := context.Background()
+	store, err := file.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	blob := []byte(`{"architecture":"amd64","os":"linux","rootfs":{"type":"layers","diff_ids":[]}}`)
+
+	desc, err := loadConfig(ctx, store, "-", bytes.NewReader(blob))
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	if want := content.NewDescriptorFromBytes(ocispec.MediaTypeImageConfig, blob); !content.Equal(desc, want) {
+		t.Errorf("loadConfig() = %v, want %v", desc, want)
+	}
+	if got, err := content.FetchAll(ctx, store, desc); err != nil || !bytes.Equal(got, blob) {
+		t.Errorf("content.FetchAll() = %s, %v, want %s", got, err, blob)
+	}
+
+	desc, err = loadConfig(ctx, store, "-:application/vnd.me.config", bytes.NewReader(blob))
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+	if desc.MediaType != "application/vnd.me.config" {
+		t.Errorf("loadConfig() media type = %v, want %v", desc.MediaType, "application/vnd.me.config")
+	}
+}
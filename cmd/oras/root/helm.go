@@ -0,0 +1,229 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.yaml.in/yaml/v4"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+)
+
+// Media types of Helm charts.
+// Reference: https://helm.sh/docs/topics/registries/
+const (
+	helmConfigMediaType     = "application/vnd.cncf.helm.config.v1+json"
+	helmChartMediaType      = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	helmProvenanceMediaType = "application/vnd.cncf.helm.chart.provenance.v1.prov"
+)
+
+// maxChartYAMLSize is the maximum size of Chart.yaml read from a chart.
+const maxChartYAMLSize = 1024 * 1024
+
+// helmMaintainer is a maintainer of a Helm chart.
+type helmMaintainer struct {
+	Name  string `yaml:"name" json:"name,omitempty"`
+	Email string `yaml:"email" json:"email,omitempty"`
+	URL   string `yaml:"url" json:"url,omitempty"`
+}
+
+// helmDependency is a dependency of a Helm chart.
+type helmDependency struct {
+	Name         string   `yaml:"name" json:"name"`
+	Version      string   `yaml:"version" json:"version,omitempty"`
+	Repository   string   `yaml:"repository" json:"repository"`
+	Condition    string   `yaml:"condition" json:"condition,omitempty"`
+	Tags         []string `yaml:"tags" json:"tags,omitempty"`
+	Enabled      bool     `yaml:"enabled" json:"enabled,omitempty"`
+	ImportValues []any    `yaml:"import-values" json:"import-values,omitempty"`
+	Alias        string   `yaml:"alias" json:"alias,omitempty"`
+}
+
+// helmChartMetadata is the content of Chart.yaml, which is pushed in JSON as
+// the config of a Helm chart.
+type helmChartMetadata struct {
+	Name         string            `yaml:"name" json:"name,omitempty"`
+	Home         string            `yaml:"home" json:"home,omitempty"`
+	Sources      []string          `yaml:"sources" json:"sources,omitempty"`
+	Version      string            `yaml:"version" json:"version,omitempty"`
+	Description  string            `yaml:"description" json:"description,omitempty"`
+	Keywords     []string          `yaml:"keywords" json:"keywords,omitempty"`
+	Maintainers  []helmMaintainer  `yaml:"maintainers" json:"maintainers,omitempty"`
+	Icon         string            `yaml:"icon" json:"icon,omitempty"`
+	APIVersion   string            `yaml:"apiVersion" json:"apiVersion,omitempty"`
+	Condition    string            `yaml:"condition" json:"condition,omitempty"`
+	Tags         string            `yaml:"tags" json:"tags,omitempty"`
+	AppVersion   string            `yaml:"appVersion" json:"appVersion,omitempty"`
+	Deprecated   bool              `yaml:"deprecated" json:"deprecated,omitempty"`
+	Annotations  map[string]string `yaml:"annotations" json:"annotations,omitempty"`
+	KubeVersion  string            `yaml:"kubeVersion" json:"kubeVersion,omitempty"`
+	Dependencies []helmDependency  `yaml:"dependencies" json:"dependencies,omitempty"`
+	Type         string            `yaml:"type" json:"type,omitempty"`
+}
+
+// readHelmChart reads the metadata in Chart.yaml of the packaged Helm chart at
+// chartPath.
+func readHelmChart(chartPath string) (*helmChartMetadata, error) {
+	f, err := os.Open(chartPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a packaged Helm chart: %w", chartPath, err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s is not a packaged Helm chart: Chart.yaml not found", chartPath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", chartPath, err)
+		}
+		// Chart.yaml is in the top level directory named after the chart
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if dir, file := path.Split(name); file != "Chart.yaml" || dir == "" || strings.Count(dir, "/") != 1 {
+			continue
+		}
+		content, err := io.ReadAll(io.LimitReader(tr, maxChartYAMLSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", chartPath, err)
+		}
+		if len(content) > maxChartYAMLSize {
+			return nil, fmt.Errorf("%s: Chart.yaml exceeds %d bytes", chartPath, maxChartYAMLSize)
+		}
+		var metadata helmChartMetadata
+		if err := yaml.Unmarshal(content, &metadata); err != nil {
+			return nil, fmt.Errorf("%s: invalid Chart.yaml: %w", chartPath, err)
+		}
+		if metadata.Name == "" || metadata.Version == "" {
+			return nil, fmt.Errorf("%s: name and version are required in Chart.yaml", chartPath)
+		}
+		return &metadata, nil
+	}
+}
+
+// loadHelmChart prepares pushing the Helm chart of opts: the chart and its
+// provenance file, if any, are set as the files to push, the chart metadata is
+// added to the manifest annotations, and the tag defaults to the chart version.
+// The config descriptor of the chart pushed to store is returned.
+func loadHelmChart(ctx context.Context, store *file.Store, opts *pushOptions) (*ocispec.Descriptor, error) {
+	metadata, err := readHelmChart(opts.helmChart)
+	if err != nil {
+		return nil, err
+	}
+	switch tag := metadata.tag(); opts.Reference {
+	case "":
+		opts.Reference = tag
+		opts.RawReference = fmt.Sprintf("%s:%s", opts.RawReference, tag)
+	case tag:
+	default:
+		return nil, &oerrors.Error{
+			Err:            fmt.Errorf("tag %s does not match the version %s of chart %s", opts.Reference, metadata.Version, metadata.Name),
+			Recommendation: fmt.Sprintf("Helm requires charts to be tagged with their versions, use the tag %s or omit the tag", tag),
+		}
+	}
+
+	if opts.Annotations == nil {
+		opts.Annotations = make(map[string]map[string]string)
+	}
+	manifestAnnotations := metadata.annotations()
+	maps.Copy(manifestAnnotations, opts.Annotations[option.AnnotationManifest])
+	opts.Annotations[option.AnnotationManifest] = manifestAnnotations
+
+	opts.FileRefs = []string{opts.helmChart + ":" + helmChartMediaType}
+	if _, ok := opts.Annotations[opts.helmChart]; !ok {
+		opts.Annotations[opts.helmChart] = map[string]string{ocispec.AnnotationTitle: filepath.Base(opts.helmChart)}
+	}
+	if provenance := opts.helmChart + ".prov"; isRegularFile(provenance) {
+		opts.FileRefs = append(opts.FileRefs, provenance+":"+helmProvenanceMediaType)
+		if _, ok := opts.Annotations[provenance]; !ok {
+			opts.Annotations[provenance] = map[string]string{ocispec.AnnotationTitle: filepath.Base(provenance)}
+		}
+	}
+
+	configJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	desc := content.NewDescriptorFromBytes(helmConfigMediaType, configJSON)
+	if err := store.Push(ctx, desc, bytes.NewReader(configJSON)); err != nil {
+		return nil, err
+	}
+	return &desc, nil
+}
+
+// isRegularFile returns true if path is a regular file.
+func isRegularFile(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+// tag returns the tag of the chart, which is the chart version with '+'
+// replaced by '_' as '+' is not allowed in tags.
+func (m *helmChartMetadata) tag() string {
+	return strings.ReplaceAll(m.Version, "+", "_")
+}
+
+// annotations returns the manifest annotations describing the chart, the same
+// as the ones set by helm push.
+func (m *helmChartMetadata) annotations() map[string]string {
+	annotations := map[string]string{
+		ocispec.AnnotationTitle:   m.Name,
+		ocispec.AnnotationVersion: m.Version,
+	}
+	if m.Description != "" {
+		annotations[ocispec.AnnotationDescription] = m.Description
+	}
+	if m.Home != "" {
+		annotations[ocispec.AnnotationURL] = m.Home
+	}
+	if len(m.Sources) > 0 {
+		annotations[ocispec.AnnotationSource] = m.Sources[0]
+	}
+	var authors []string
+	for _, maintainer := range m.Maintainers {
+		if maintainer.Email != "" {
+			authors = append(authors, fmt.Sprintf("%s (%s)", maintainer.Name, maintainer.Email))
+		} else {
+			authors = append(authors, maintainer.Name)
+		}
+	}
+	if len(authors) > 0 {
+		annotations[ocispec.AnnotationAuthors] = strings.Join(authors, ", ")
+	}
+	return annotations
+}
@@ -0,0 +1,125 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// writeHelmChart writes a packaged chart with the files to a temporary file.
+func writeHelmChart(t *testing.T, files map[string]string) string {
+	t.Helper()
+	chartPath := filepath.Join(t.TempDir(), "chart.tgz")
+	f, err := os.Create(chartPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return chartPath
+}
+
+func Test_readHelmChart(t *testing.T) {
+	chartYAML := `apiVersion: v2
+name: mychart
+version: 0.1.0+build.1
+description: A Helm chart
+home: https://example.com
+sources:
+  - https://github.com/example/mychart
+maintainers:
+  - name: alice
+    email: alice@example.com
+  - name: bob
+`
+	t.Run("valid chart", func(t *testing.T) {
+		chartPath := writeHelmChart(t, map[string]string{
+			"mychart/values.yaml":               "",
+			"mychart/charts/dep/Chart.yaml":     "name: dep\nversion: 1.0.0\n",
+			"mychart/Chart.yaml":                chartYAML,
+			"mychart/templates/deployment.yaml": "",
+		})
+		got, err := readHelmChart(chartPath)
+		if err != nil {
+			t.Fatalf("readHelmChart() error = %v", err)
+		}
+		if got.Name != "mychart" || got.Version != "0.1.0+build.1" || got.APIVersion != "v2" {
+			t.Fatalf("readHelmChart() = %+v", got)
+		}
+		if tag := got.tag(); tag != "0.1.0_build.1" {
+			t.Errorf("tag() = %v, want %v", tag, "0.1.0_build.1")
+		}
+		want := map[string]string{
+			ocispec.AnnotationTitle:       "mychart",
+			ocispec.AnnotationVersion:     "0.1.0+build.1",
+			ocispec.AnnotationDescription: "A Helm chart",
+			ocispec.AnnotationURL:         "https://example.com",
+			ocispec.AnnotationSource:      "https://github.com/example/mychart",
+			ocispec.AnnotationAuthors:     "alice (alice@example.com), bob",
+		}
+		if annotations := got.annotations(); !reflect.DeepEqual(annotations, want) {
+			t.Errorf("annotations() = %v, want %v", annotations, want)
+		}
+	})
+
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{name: "missing Chart.yaml", files: map[string]string{"mychart/values.yaml": ""}},
+		{name: "Chart.yaml not in chart directory", files: map[string]string{"Chart.yaml": chartYAML}},
+		{name: "missing version", files: map[string]string{"mychart/Chart.yaml": "name: mychart\n"}},
+		{name: "invalid Chart.yaml", files: map[string]string{"mychart/Chart.yaml": "name: [\n"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := readHelmChart(writeHelmChart(t, tt.files)); err == nil {
+				t.Error("readHelmChart() error = nil, want error")
+			}
+		})
+	}
+	t.Run("not gzip", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "chart.tgz")
+		if err := os.WriteFile(path, []byte("not a chart"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readHelmChart(path); err == nil {
+			t.Error("readHelmChart() error = nil, want error")
+		}
+	})
+}
@@ -0,0 +1,184 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/history"
+)
+
+type historyOptions struct {
+	option.Common
+	option.Target
+	option.Format
+
+	limit int
+}
+
+func historyCmd() *cobra.Command {
+	var opts historyOptions
+	cmd := &cobra.Command{
+		Use:   "history [flags] <name>:<tag>",
+		Short: "[Experimental] Show the recorded history of a tag",
+		Long: `[Experimental] Show the recorded history of a tag
+
+The history is reconstructed from the referrers attached by "oras push",
+"oras tag" and "oras cp" with --record-history to the manifests the tag is
+moved to. It ends at the creation of the tag, or at the first movement not
+recorded.
+
+Example - Show the history of the tag 'latest':
+  oras history localhost:5000/hello:latest
+
+Example - Show the last 3 movements of the tag 'latest' in JSON format:
+  oras history --limit 3 --format json localhost:5000/hello:latest
+
+Example - Show the history of the tag 'v1' in an OCI image layout folder 'layout-dir':
+  oras history --oci-layout layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the tag to show the history of"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			if contentutil.IsDigest(opts.Reference) {
+				return fmt.Errorf("%q: a tag is required to show its history", opts.RawReference)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHistory(cmd, &opts)
+		},
+	}
+	cmd.Flags().IntVarP(&opts.limit, "limit", "", 0, "show at most `n` movements of the tag, 0 for all")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runHistory(cmd *cobra.Command, opts *historyOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewHistoryHandler(opts.Printer, opts.Format, opts.RawReference)
+	if err != nil {
+		return err
+	}
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	head, err := target.Resolve(ctx, opts.Reference)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.RawReference, err)
+	}
+	records, err := history.Timeline(ctx, target, opts.Reference, head, opts.limit)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if err := handler.OnRecordFound(r); err != nil {
+			return err
+		}
+	}
+	return handler.Render()
+}
+
+// tagHistory records the movements of tags with --record-history.
+type tagHistory struct {
+	opts    *option.History
+	command string
+	// previous are the digests the tags pointed to before being moved.
+	previous map[string]string
+}
+
+// newTagHistory creates a tagHistory recording the tags moved by command.
+func newTagHistory(opts *option.History, command string) *tagHistory {
+	return &tagHistory{
+		opts:     opts,
+		command:  command,
+		previous: make(map[string]string),
+	}
+}
+
+// resolve resolves the digests the tags point to in target before they are
+// moved. Digest references are skipped.
+func (h *tagHistory) resolve(ctx context.Context, target content.Resolver, tags ...string) error {
+	if !h.opts.RecordHistory {
+		return nil
+	}
+	for _, tag := range tags {
+		if tag == "" || contentutil.IsDigest(tag) {
+			continue
+		}
+		previous, err := history.Resolve(ctx, target, tag)
+		if err != nil {
+			return fmt.Errorf("failed to resolve tag %q to record its history: %w", tag, err)
+		}
+		h.previous[tag] = previous
+	}
+	return nil
+}
+
+// record attaches a history record to desc for each resolved tag moved to
+// desc from another manifest or created.
+func (h *tagHistory) record(ctx context.Context, target oras.Target, desc ocispec.Descriptor) error {
+	if !h.opts.RecordHistory {
+		return nil
+	}
+	now := time.Now().UTC()
+	var errs []error
+	tags := make([]string, 0, len(h.previous))
+	for tag := range h.previous {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	for _, tag := range tags {
+		previous := h.previous[tag]
+		delete(h.previous, tag)
+		if previous == desc.Digest.String() {
+			continue
+		}
+		r := history.Record{
+			Tag:      tag,
+			Previous: previous,
+			Actor:    h.opts.HistoryActor,
+			Command:  h.command,
+			Time:     now,
+		}
+		if _, err := history.Attach(ctx, target, desc, r); err != nil {
+			errs = append(errs, fmt.Errorf("failed to record the history of tag %q: %w", tag, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -16,14 +16,18 @@ limitations under the License.
 package root
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
 	"oras.land/oras-go/v2/registry/remote/credentials"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
@@ -36,7 +40,9 @@ import (
 type loginOptions struct {
 	option.Common
 	option.Remote
-	Hostname string
+	Hostname          string
+	storeRefreshToken bool
+	fromK8sSecret     string
 }
 
 func loginCmd() *cobra.Command {
@@ -58,6 +64,12 @@ Example - Log in with identity token from command line flags:
 Example - Log in with identity token from stdin:
   oras login --identity-token-stdin localhost:5000
 
+Example - [Experimental] Log in with username and password, storing a refresh token issued by the registry instead of the password:
+  oras login -u username --password-stdin --store-refresh-token localhost:5000
+
+Example - [Experimental] Log in with the credential in the Kubernetes image pull secret 'regcred' in namespace 'default':
+  oras login --from-k8s-secret default/regcred localhost:5000
+
 Example - Log in with username and password in an interactive terminal:
   oras login localhost:5000
 
@@ -66,6 +78,11 @@ Example - Log in with username and password in an interactive terminal and no TL
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the registry to log in to"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			for _, flag := range []string{"username", "password", "password-stdin", "identity-token", "identity-token-stdin"} {
+				if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "from-k8s-secret", flag); err != nil {
+					return err
+				}
+			}
 			return option.Parse(cmd, &opts)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -74,6 +91,8 @@ Example - Log in with username and password in an interactive terminal and no TL
 		},
 	}
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
+	cmd.Flags().StringVar(&opts.fromK8sSecret, "from-k8s-secret", "", "[Experimental] log in with the credential of the registry in the Kubernetes image pull secret `[namespace/]name`, read with kubectl")
+	cmd.Flags().BoolVar(&opts.storeRefreshToken, "store-refresh-token", false, "[Experimental] store a refresh token issued by the registry instead of the password if supported, which is exchanged for access tokens by later commands")
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Remote)
 }
@@ -81,8 +100,12 @@ Example - Log in with username and password in an interactive terminal and no TL
 func runLogin(cmd *cobra.Command, opts loginOptions) (err error) {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 
-	// prompt for credential
-	if opts.Secret == "" {
+	// import or prompt for credential
+	if opts.fromK8sSecret != "" {
+		if err := opts.readK8sSecret(ctx); err != nil {
+			return err
+		}
+	} else if opts.Secret == "" {
 		if opts.Username == "" {
 			// prompt for username
 			username, err := readLine(opts.Printer, "Username: ", false)
@@ -116,13 +139,71 @@ func runLogin(cmd *cobra.Command, opts loginOptions) (err error) {
 	if err != nil {
 		return err
 	}
-	if err = credentials.Login(ctx, store, remote, opts.Credential()); err != nil {
+	cred := opts.Credential()
+	if opts.storeRefreshToken && cred.Username != "" {
+		if cred, err = refreshTokenCredential(ctx, cmd.ErrOrStderr(), remote, cred); err != nil {
+			return err
+		}
+	}
+	if err = credentials.Login(ctx, store, remote, cred); err != nil {
 		return err
 	}
 	_ = opts.Printer.Println("Login Succeeded")
 	return nil
 }
 
+// readK8sSecret reads the credential of the registry from the Kubernetes
+// image pull secret.
+func (opts *loginOptions) readK8sSecret(ctx context.Context) error {
+	namespace, name, err := credential.ParseSecretName(opts.fromK8sSecret)
+	if err != nil {
+		return err
+	}
+	creds, err := credential.ReadK8sSecret(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	cred, ok := credential.LookupK8sCredential(creds, opts.Hostname)
+	if !ok {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("no credential of %s found in Kubernetes secret %s", opts.Hostname, opts.fromK8sSecret),
+			Recommendation: "Please make sure the registry matches a server address in the secret",
+		}
+	}
+	opts.Username = cred.Username
+	opts.Secret = cred.Password
+	if cred.RefreshToken != "" {
+		opts.Username, opts.Secret = "", cred.RefreshToken
+	}
+	return nil
+}
+
+// refreshTokenCredential exchanges the username and password in cred for a
+// refresh token issued by the registry. cred is returned as is if the
+// registry does not issue refresh tokens.
+func refreshTokenCredential(ctx context.Context, warnWriter io.Writer, reg *remote.Registry, cred auth.Credential) (auth.Credential, error) {
+	client := http.DefaultClient
+	if authClient, ok := reg.Client.(*auth.Client); ok && authClient.Client != nil {
+		client = authClient.Client
+	}
+	scheme := "https"
+	if reg.PlainHTTP {
+		scheme = "http"
+	}
+	token, err := credential.FetchRefreshToken(ctx, client, scheme+"://"+reg.Reference.Registry, cred)
+	if err != nil {
+		if errors.Is(err, credential.ErrRefreshTokenNotIssued) {
+			_, _ = fmt.Fprintf(warnWriter, "WARNING! %s does not issue refresh tokens, the password is stored instead.\n", reg.Reference.Registry)
+			return cred, nil
+		}
+		return auth.EmptyCredential, fmt.Errorf("failed to fetch a refresh token: %w", err)
+	}
+	return auth.Credential{
+		Username:     cred.Username,
+		RefreshToken: token,
+	}, nil
+}
+
 func readLine(outWriter io.Writer, prompt string, silent bool) (string, error) {
 	_, _ = fmt.Fprint(outWriter, prompt)
 	fd := int(os.Stdin.Fd())
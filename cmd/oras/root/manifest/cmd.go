@@ -30,6 +30,7 @@ func Cmd() *cobra.Command {
 		deleteCmd(),
 		fetchCmd(),
 		fetchConfigCmd(),
+		platformsCmd(),
 		pushCmd(),
 		index.Cmd(),
 	)
@@ -53,6 +53,9 @@ Example - Delete a manifest tagged with 'v1' from repository 'localhost:5000/hel
 Example - Delete a manifest without prompting confirmation:
   oras manifest delete --force localhost:5000/hello:v1
 
+Example - Delete a manifest, answering yes to the confirmation prompt:
+  oras manifest delete --yes localhost:5000/hello:v1
+
 Example - Delete a manifest and print its descriptor:
   oras manifest delete --descriptor localhost:5000/hello:v1
 
@@ -73,6 +76,7 @@ Example - Delete a manifest by digest 'sha256:99e4703fbf30916f549cd6bfa9cdbab614
 	}
 
 	opts.EnableDistributionSpecFlag()
+	opts.EnableDryRunFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
 	return oerrors.Command(cmd, &opts.Target)
@@ -109,7 +113,7 @@ func deleteManifest(cmd *cobra.Command, opts *deleteOptions) error {
 	}
 
 	prompt := fmt.Sprintf("Are you sure you want to delete the manifest %q and all tags associated with it?", desc.Digest)
-	confirmed, err := opts.AskForConfirmation(os.Stdin, prompt)
+	confirmed, err := opts.AskForTypedConfirmation(os.Stdin, prompt, opts.Path)
 	if err != nil {
 		return err
 	}
@@ -27,6 +27,7 @@ import (
 	"oras.land/oras/cmd/oras/internal/display"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/platform"
 )
 
 type fetchOptions struct {
@@ -142,17 +143,21 @@ func fetchManifest(cmd *cobra.Command, opts *fetchOptions) (fetchErr error) {
 	var content []byte
 	if opts.OutputDescriptor && opts.outputPath == "" {
 		// fetch manifest descriptor only
-		fetchOpts := oras.DefaultResolveOptions
-		fetchOpts.TargetPlatform = opts.Platform.Platform
-		desc, err = oras.Resolve(ctx, src, opts.Reference, fetchOpts)
+		desc, err = platform.Resolve(ctx, src, opts.Reference, opts.Platform.Platform)
 		if err != nil {
-			return fmt.Errorf("failed to find %q: %w", opts.RawReference, err)
+			return option.PlatformError(fmt.Errorf("failed to find %q: %w", opts.RawReference, err), "")
 		}
 	} else {
 		// fetch manifest descriptor and content
-		fetchOpts := oras.DefaultFetchBytesOptions
-		fetchOpts.TargetPlatform = opts.Platform.Platform
-		desc, content, err = oras.FetchBytes(ctx, src, opts.Reference, fetchOpts)
+		reference := opts.Reference
+		if opts.Platform.Platform != nil {
+			selected, err := platform.Resolve(ctx, src, opts.Reference, opts.Platform.Platform)
+			if err != nil {
+				return option.PlatformError(fmt.Errorf("failed to find %q: %w", opts.RawReference, err), "")
+			}
+			reference = selected.Digest.String()
+		}
+		desc, content, err = oras.FetchBytes(ctx, src, reference, oras.DefaultFetchBytesOptions)
 		if err != nil {
 			return fmt.Errorf("failed to fetch the content of %q: %w", opts.RawReference, err)
 		}
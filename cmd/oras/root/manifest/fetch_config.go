@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
@@ -31,6 +32,7 @@ import (
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/platform"
 )
 
 type fetchConfigOptions struct {
@@ -58,6 +60,9 @@ Example - Fetch the config:
 Example - Fetch the config of certain platform:
   oras manifest fetch-config --platform 'linux/arm/v5' localhost:5000/hello:v1
 
+Example - Fetch the config of the first arm64 platform in an index:
+  oras manifest fetch-config --platform '*/arm64' localhost:5000/hello:v1
+
 Example - Fetch and print the prettified config:
   oras manifest fetch-config --pretty localhost:5000/hello:v1
 
@@ -141,13 +146,32 @@ func fetchConfig(cmd *cobra.Command, opts *fetchConfigOptions) (fetchErr error)
 }
 
 func fetchConfigDesc(ctx context.Context, src oras.ReadOnlyTarget, reference string, targetPlatform *ocispec.Platform) (ocispec.Descriptor, error) {
+	if targetPlatform != nil && !platform.HasWildcard(targetPlatform) {
+		selected, err := platform.Resolve(ctx, src, reference, targetPlatform)
+		if err != nil {
+			return ocispec.Descriptor{}, option.PlatformError(err, "")
+		}
+		reference = selected.Digest.String()
+	}
 	// fetch manifest descriptor and content
-	fetchOpts := oras.DefaultFetchBytesOptions
-	fetchOpts.TargetPlatform = targetPlatform
-	manifestDesc, manifestContent, err := oras.FetchBytes(ctx, src, reference, fetchOpts)
+	manifestDesc, manifestContent, err := oras.FetchBytes(ctx, src, reference, oras.DefaultFetchBytesOptions)
 	if err != nil {
 		return ocispec.Descriptor{}, err
 	}
+	if descriptor.IsIndex(manifestDesc) {
+		// select the platform manifest in the index
+		var index ocispec.Index
+		if err := json.Unmarshal(manifestContent, &index); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		manifest, err := selectPlatformManifest(manifestDesc, index, targetPlatform)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if manifestDesc, manifestContent, err = oras.FetchBytes(ctx, src, manifest.Digest.String(), oras.DefaultFetchBytesOptions); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
 
 	if !descriptor.IsImageManifest(manifestDesc) {
 		return ocispec.Descriptor{}, fmt.Errorf("%q is not an image manifest and does not have a config", manifestDesc.Digest)
@@ -160,3 +184,38 @@ func fetchConfigDesc(ctx context.Context, src oras.ReadOnlyTarget, reference str
 	}
 	return manifest.Config, nil
 }
+
+// selectPlatformManifest selects the first manifest in the index matching the
+// target platform, which may have wildcards.
+func selectPlatformManifest(indexDesc ocispec.Descriptor, index ocispec.Index, targetPlatform *ocispec.Platform) (ocispec.Descriptor, error) {
+	if targetPlatform == nil {
+		var platforms []string
+		for _, manifest := range index.Manifests {
+			if manifest.Platform != nil {
+				platforms = append(platforms, platform.String(manifest.Platform))
+			}
+		}
+		recommendation := "The index does not list the platforms of its manifests, fetch the config of a manifest in the index by digest"
+		if len(platforms) > 0 {
+			recommendation = fmt.Sprintf("Use --platform to select one of the platforms: %s", strings.Join(platforms, ", "))
+		}
+		return ocispec.Descriptor{}, &oerrors.Error{
+			Err:            fmt.Errorf("%q is an image index and does not have a config", indexDesc.Digest),
+			Recommendation: recommendation,
+		}
+	}
+	var available []ocispec.Platform
+	for _, manifest := range index.Manifests {
+		if platform.Match(manifest.Platform, targetPlatform) {
+			return manifest, nil
+		}
+		if manifest.Platform != nil {
+			available = append(available, *manifest.Platform)
+		}
+	}
+	return ocispec.Descriptor{}, option.PlatformError(&platform.NoMatchError{
+		Root:      indexDesc.Digest.String(),
+		Want:      *targetPlatform,
+		Available: available,
+	}, "")
+}
@@ -0,0 +1,64 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func Test_selectPlatformManifest(t *testing.T) {
+	indexDesc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex, Digest: "sha256:index"}
+	index := ocispec.Index{
+		Manifests: []ocispec.Descriptor{
+			{Digest: "sha256:amd64", Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"}},
+			{Digest: "sha256:arm64", Platform: &ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}},
+		},
+	}
+	tests := []struct {
+		name     string
+		platform *ocispec.Platform
+		want     string
+		wantErr  string
+	}{
+		{name: "exact", platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"}, want: "sha256:amd64"},
+		{name: "wildcard", platform: &ocispec.Platform{OS: "*", Architecture: "arm64"}, want: "sha256:arm64"},
+		{name: "no platform", wantErr: "linux/amd64, linux/arm64/v8"},
+		{name: "no match", platform: &ocispec.Platform{OS: "windows", Architecture: "*"}, wantErr: "windows/*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectPlatformManifest(indexDesc, index, tt.platform)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("selectPlatformManifest() error = nil, want error")
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("selectPlatformManifest() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectPlatformManifest() error = %v", err)
+			}
+			if got.Digest.String() != tt.want {
+				t.Errorf("selectPlatformManifest() = %s, want %s", got.Digest, tt.want)
+			}
+		})
+	}
+}
@@ -100,6 +100,7 @@ Example - Update an index to remove any existing artifact type:
 			return updateIndex(cmd, opts)
 		},
 	}
+	opts.EnableDryRunFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
 	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "new artifact type for overall index")
 	cmd.Flags().StringArrayVarP(&opts.addArguments, "add", "", nil, "manifests to add to the index")
@@ -0,0 +1,133 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/platform"
+)
+
+type platformsOptions struct {
+	option.Cache
+	option.Common
+	option.Target
+	option.Format
+}
+
+func platformsCmd() *cobra.Command {
+	var opts platformsOptions
+	cmd := &cobra.Command{
+		Use:   "platforms [flags] <name>{:<tag>|@<digest>}",
+		Short: "[Experimental] List the platforms of the target artifact",
+		Long: `[Experimental] List the platforms of the target artifact
+
+The platform, digest and size of each platform-specific manifest in an image
+index are listed. Manifests not for a platform, such as attestations, are
+skipped. For an image manifest, the platform in its config is listed.
+
+Example - List the platforms of a multi-arch image:
+  oras manifest platforms localhost:5000/hello:v1
+
+Example - List the platforms of a multi-arch image in JSON format:
+  oras manifest platforms localhost:5000/hello:v1 --format json
+
+Example - Print the digest of each platform using the given Go template:
+  oras manifest platforms localhost:5000/hello:v1 --format go-template --template '{{range .manifests}}{{println .platform .digest}}{{end}}'
+
+Example - List the platforms of a multi-arch image in an OCI image layout folder 'layout-dir':
+  oras manifest platforms --oci-layout layout-dir:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the artifact to list the platforms of"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listPlatforms(cmd, &opts)
+		},
+	}
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func listPlatforms(cmd *cobra.Command, opts *platformsOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	handler, err := display.NewManifestPlatformsHandler(opts.Printer, opts.Format, opts.RawReference)
+	if err != nil {
+		return err
+	}
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	src, err := opts.CachedTarget(target)
+	if err != nil {
+		return err
+	}
+	root, err := oras.Resolve(ctx, src, opts.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.RawReference, err)
+	}
+	switch {
+	case descriptor.IsIndex(root):
+		fetched, err := content.FetchAll(ctx, src, root)
+		if err != nil {
+			return err
+		}
+		var index ocispec.Index
+		if err := json.Unmarshal(fetched, &index); err != nil {
+			return err
+		}
+		for _, manifest := range index.Manifests {
+			if manifest.Platform == nil || manifest.Platform.OS == "unknown" {
+				continue
+			}
+			if err := handler.OnPlatformFound(*manifest.Platform, manifest); err != nil {
+				return err
+			}
+		}
+	case descriptor.IsImageManifest(root):
+		p, err := platform.FromManifest(ctx, src, root)
+		if err != nil {
+			return &oerrors.Error{
+				Err:            err,
+				Recommendation: fmt.Sprintf("%s is not for a platform, list the platforms of an image index or an image instead", opts.RawReference),
+			}
+		}
+		if err := handler.OnPlatformFound(*p, root); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%s is not an image index or an image manifest: %s", opts.RawReference, root.MediaType)
+	}
+	return handler.Render()
+}
@@ -41,8 +41,10 @@ import (
 type pushOptions struct {
 	option.Common
 	option.Descriptor
+	option.DescriptorFile
 	option.Pretty
 	option.Target
+	option.GitHubActions
 
 	concurrency int
 	extraRefs   []string
@@ -110,12 +112,13 @@ Example - Push a manifest to an OCI image layout folder 'layout-dir' and tag wit
 	}
 
 	opts.EnableDistributionSpecFlag()
+	opts.EnableDryRunFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
 	cmd.Flags().StringVarP(&opts.mediaType, "media-type", "", "", "media type of manifest")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
-	return oerrors.Command(cmd, &opts.Target)
+	return opts.GitHubActions.Command(oerrors.Command(cmd, &opts.Target))
 }
 
 func pushManifest(cmd *cobra.Command, opts pushOptions) error {
@@ -180,6 +183,13 @@ func pushManifest(cmd *cobra.Command, opts pushOptions) error {
 		}
 	}
 
+	if err := opts.WriteDescriptor(desc); err != nil {
+		return err
+	}
+	if err := opts.ReportResult("Pushed", opts.Path, desc); err != nil {
+		return err
+	}
+
 	tagBytesNOpts := oras.DefaultTagBytesNOptions
 	tagBytesNOpts.Concurrency = opts.concurrency
 
@@ -0,0 +1,122 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"fmt"
+	"maps"
+	"os"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/fileref"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/mlmodel"
+)
+
+// annotateModelFiles adds the annotations describing the model files among
+// the referenced files to annotations, keyed by the file names, and the
+// summary of the model with its license from the model card or the model
+// files to the manifest annotations. The annotations specified by users take
+// precedence. A warning is logged if the license is missing.
+func annotateModelFiles(fileRefs []string, annotations map[string]map[string]string, logger logrus.FieldLogger) (map[string]map[string]string, error) {
+	if annotations == nil {
+		annotations = make(map[string]map[string]string)
+	}
+	var infos []*mlmodel.Info
+	var cardLicense, fileLicense string
+	for _, fileRef := range fileRefs {
+		path, _, err := fileref.Parse(fileRef, "")
+		if err != nil {
+			return nil, err
+		}
+		if fi, err := os.Stat(path); err != nil || !fi.Mode().IsRegular() {
+			// directories and missing files are left to the file loader
+			continue
+		}
+		if mlmodel.IsModelCard(path) {
+			if cardLicense == "" {
+				if cardLicense, err = mlmodel.ReadModelCardLicense(path); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		info, err := mlmodel.Inspect(path)
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			continue
+		}
+		infos = append(infos, info)
+		if fileLicense == "" {
+			fileLicense = info.License
+		}
+		fileAnnotations := info.Annotations()
+		maps.Copy(fileAnnotations, annotations[path])
+		annotations[path] = fileAnnotations
+	}
+	if len(infos) == 0 {
+		return nil, &oerrors.Error{
+			Err:            fmt.Errorf("no model file recognized"),
+			Recommendation: "Push model files in the GGUF, safetensors or ONNX format, or remove --model",
+		}
+	}
+
+	manifestAnnotations := summarizeModel(infos)
+	license := cardLicense
+	if license == "" {
+		license = fileLicense
+	}
+	if license != "" {
+		manifestAnnotations[ocispec.AnnotationLicenses] = license
+	}
+	maps.Copy(manifestAnnotations, annotations[option.AnnotationManifest])
+	annotations[option.AnnotationManifest] = manifestAnnotations
+	if manifestAnnotations[ocispec.AnnotationLicenses] == "" {
+		logger.Warnf("no license found in the model card or the model files, specify one with --annotation %s=<SPDX license expression>", ocispec.AnnotationLicenses)
+	}
+	return annotations, nil
+}
+
+// summarizeModel returns the manifest annotations summarizing the model of
+// the model files described by infos. The format and the quantization are
+// included only if the same for all the files.
+func summarizeModel(infos []*mlmodel.Info) map[string]string {
+	var parameters int64
+	format, quantization := infos[0].Format, infos[0].Quantization
+	for _, info := range infos {
+		parameters += info.Parameters
+		if info.Format != format {
+			format = ""
+		}
+		if info.Quantization != quantization {
+			quantization = ""
+		}
+	}
+	summary := &mlmodel.Info{
+		Format:       format,
+		Parameters:   parameters,
+		Quantization: quantization,
+	}
+	annotations := summary.Annotations()
+	if format == "" {
+		delete(annotations, mlmodel.AnnotationFormat)
+	}
+	return annotations
+}
@@ -0,0 +1,74 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/mlmodel"
+)
+
+func Test_annotateModelFiles(t *testing.T) {
+	dir := t.TempDir()
+	header := []byte(`{"w":{"dtype":"F16","shape":[3,4],"data_offsets":[0,24]}}`)
+	model := filepath.Join(dir, "model.safetensors")
+	if err := os.WriteFile(model, append(binary.LittleEndian.AppendUint64(nil, uint64(len(header))), header...), 0600); err != nil {
+		t.Fatal(err)
+	}
+	card := filepath.Join(dir, "README.md")
+	if err := os.WriteFile(card, []byte("---\nlicense: mit\n---\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	other := filepath.Join(dir, "hi.txt")
+	if err := os.WriteFile(other, []byte("hi"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	annotations := map[string]map[string]string{
+		model: {mlmodel.AnnotationQuantization: "custom"},
+	}
+	got, err := annotateModelFiles([]string{model, card, other, filepath.Join(dir, "missing")}, annotations, logrus.New())
+	if err != nil {
+		t.Fatalf("annotateModelFiles() error = %v", err)
+	}
+	want := map[string]map[string]string{
+		model: {
+			mlmodel.AnnotationFormat:       mlmodel.FormatSafetensors,
+			mlmodel.AnnotationParameters:   "12",
+			mlmodel.AnnotationQuantization: "custom",
+		},
+		option.AnnotationManifest: {
+			mlmodel.AnnotationFormat:       mlmodel.FormatSafetensors,
+			mlmodel.AnnotationParameters:   "12",
+			mlmodel.AnnotationQuantization: "F16",
+			ocispec.AnnotationLicenses:     "mit",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("annotateModelFiles() = %v, want %v", got, want)
+	}
+
+	if _, err := annotateModelFiles([]string{card, other}, nil, logrus.New()); err == nil {
+		t.Error("annotateModelFiles() error = nil, want error for no model file")
+	}
+}
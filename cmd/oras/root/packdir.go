@@ -0,0 +1,269 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/status"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/fileattr"
+	"oras.land/oras/internal/sparse"
+)
+
+// xattrPAXPrefix is the prefix of the PAX records holding the extended
+// attributes of the archived files.
+const xattrPAXPrefix = "SCHILY.xattr."
+
+// dirPacker packs the directories containing sparse files into archives
+// keeping the holes, as the file store archives the holes as zeros. With
+// xattrs, all directories are packed along with the extended attributes of
+// their files. The zero value is ready to use.
+type dirPacker struct {
+	xattrs bool
+	dir    string
+}
+
+// add adds the directory at path to store as name if it is to be packed by p.
+// ok is false if the directory is left to the file store.
+func (p *dirPacker) add(ctx context.Context, store *file.Store, name, mediaType, path string) (_ ocispec.Descriptor, ok bool, err error) {
+	hasHoles, err := sparse.Detect(path)
+	if err != nil || (!hasHoles && !p.xattrs) {
+		return ocispec.Descriptor{}, false, err
+	}
+	if p.dir == "" {
+		if p.dir, err = os.MkdirTemp("", "oras-pack-"); err != nil {
+			return ocispec.Descriptor{}, false, err
+		}
+	}
+	fp, err := os.CreateTemp(p.dir, "*.tar.gz")
+	if err != nil {
+		return ocispec.Descriptor{}, false, err
+	}
+	tarDigester := digest.Canonical.Digester()
+	gzw := gzip.NewWriter(fp)
+	var annotate func(string, *tar.Header) error
+	if p.xattrs {
+		annotate = captureXattrs
+	}
+	err = sparse.TarDirectory(ctx, io.MultiWriter(gzw, tarDigester.Hash()), path, name, annotate)
+	if closeErr := gzw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := fp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return ocispec.Descriptor{}, false, fmt.Errorf("failed to tar %s: %w", path, err)
+	}
+
+	if mediaType == "" {
+		mediaType = ocispec.MediaTypeImageLayerGzip
+	}
+	desc, err := addFile(ctx, store, name, mediaType, fp.Name())
+	if err != nil {
+		return ocispec.Descriptor{}, false, err
+	}
+	desc.Annotations[file.AnnotationDigest] = tarDigester.Digest().String()
+	desc.Annotations[file.AnnotationUnpack] = "true"
+	if hasHoles {
+		desc.Annotations[sparse.AnnotationSparse] = "true"
+	}
+	if p.xattrs {
+		desc.Annotations[fileattr.AnnotationXattrs] = "true"
+	}
+	return desc, true, nil
+}
+
+// captureXattrs adds the extended attributes of the file at path to header.
+func captureXattrs(path string, header *tar.Header) error {
+	attrs, err := fileattr.Xattrs(path)
+	if err != nil {
+		return err
+	}
+	for name, value := range attrs {
+		if header.PAXRecords == nil {
+			header.PAXRecords = make(map[string]string)
+		}
+		header.PAXRecords[xattrPAXPrefix+name] = value
+	}
+	return nil
+}
+
+// Close removes the packed archives.
+func (p *dirPacker) Close() error {
+	if p.dir == "" {
+		return nil
+	}
+	return os.RemoveAll(p.dir)
+}
+
+// isPackedDir returns true if the layer desc is a directory packed by
+// dirPacker to be extracted by extractDirs instead of the file store, as it
+// contains sparse files or extended attributes to be restored.
+func isPackedDir(desc ocispec.Descriptor, po *pullOptions) bool {
+	if desc.Annotations[ocispec.AnnotationTitle] == "" {
+		return false
+	}
+	return sparse.IsLayer(desc) || (po.Xattrs && desc.Annotations[fileattr.AnnotationXattrs] == "true")
+}
+
+// extractDirs extracts the directory layers packed by dirPacker to the output
+// directory, writing the holes as holes instead of zeros as the file store
+// does.
+func extractDirs(ctx context.Context, src content.Fetcher, layers []ocispec.Descriptor, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, po *pullOptions) error {
+	done := make(map[string]bool)
+	for _, desc := range layers {
+		name := desc.Annotations[ocispec.AnnotationTitle]
+		key := descriptor.GenerateContentKey(desc) + "/" + name
+		if done[key] {
+			continue
+		}
+		done[key] = true
+		if err := statusHandler.OnNodeDownloading(desc); err != nil {
+			return err
+		}
+		if err := extractDir(ctx, src, desc, name, po); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if err := statusHandler.OnNodeDownloaded(desc); err != nil {
+			return err
+		}
+		if err := metadataHandler.OnFilePulled(name, po.Output, desc, po.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractDir fetches the directory layer desc and extracts it as name. The
+// extended attributes are recorded to po.xattrs, if set, to be restored once
+// the files are in place.
+func extractDir(ctx context.Context, src content.Fetcher, desc ocispec.Descriptor, name string, po *pullOptions) (err error) {
+	path, err := outputPath(name, po)
+	if err != nil {
+		return err
+	}
+	if po.KeepOldFiles {
+		if _, err := os.Lstat(path); err == nil {
+			return file.ErrOverwriteDisallowed
+		}
+	}
+	rc, err := src.Fetch(ctx, desc)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+	vr := content.NewVerifyReader(rc, desc)
+	gzr, err := gzip.NewReader(vr)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = gzr.Close() }()
+	var r io.Reader = gzr
+	var verifier digest.Verifier
+	if checksum, err := digest.Parse(desc.Annotations[file.AnnotationDigest]); err == nil {
+		verifier = checksum.Verifier()
+		r = io.TeeReader(r, verifier)
+	}
+	staged := stagedPath(path, po)
+	var restore func(string, *tar.Header) error
+	if po.xattrs != nil {
+		restore = func(extracted string, header *tar.Header) error {
+			rel, err := filepath.Rel(staged, extracted)
+			if err != nil {
+				return err
+			}
+			po.xattrs.add(filepath.Join(path, rel), header)
+			return nil
+		}
+	}
+	if err := sparse.Extract(r, staged, name, restore); err != nil {
+		return fmt.Errorf("failed to extract: %w", err)
+	}
+	// read the end of the archive for the verification
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return err
+	}
+	if err := vr.Verify(); err != nil {
+		return err
+	}
+	if verifier != nil && !verifier.Verified() {
+		return errors.New("content digest mismatch")
+	}
+	return nil
+}
+
+// xattrRestorer records the extended attributes of the extracted files, which
+// are restored after the files are moved into place and their owner is
+// changed, as changing the owner clears the file capabilities.
+type xattrRestorer struct {
+	mu      sync.Mutex
+	pending map[string]map[string]string
+}
+
+// add records the extended attributes in the PAX records of header for the
+// file at path.
+func (r *xattrRestorer) add(path string, header *tar.Header) {
+	for key, value := range header.PAXRecords {
+		name, ok := strings.CutPrefix(key, xattrPAXPrefix)
+		if !ok {
+			continue
+		}
+		r.mu.Lock()
+		if r.pending == nil {
+			r.pending = make(map[string]map[string]string)
+		}
+		if r.pending[path] == nil {
+			r.pending[path] = make(map[string]string)
+		}
+		r.pending[path][name] = value
+		r.mu.Unlock()
+	}
+}
+
+// restore sets the recorded extended attributes. The SELinux security context
+// is skipped if keepLabel is true, as it is set by --selinux-label.
+func (r *xattrRestorer) restore(keepLabel bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, path := range slices.Sorted(maps.Keys(r.pending)) {
+		attrs := r.pending[path]
+		if keepLabel {
+			delete(attrs, fileattr.SELinuxXattr)
+		}
+		if err := fileattr.SetXattrs(path, attrs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
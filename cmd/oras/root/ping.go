@@ -0,0 +1,164 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/graph"
+	"oras.land/oras/internal/probe"
+)
+
+type pingOptions struct {
+	option.Common
+	option.Remote
+	option.Format
+
+	rawReference string
+	count        int
+	write        bool
+}
+
+func pingCmd() *cobra.Command {
+	var opts pingOptions
+	cmd := &cobra.Command{
+		Use:   "ping [flags] <registry>[/<repository>[:<tag>|@<digest>]]",
+		Short: "[Experimental] Check registry health and capabilities",
+		Long: `[Experimental] Check registry health and capabilities
+
+Reports the API version and latency of a registry. If a repository is given,
+also reports whether the Referrers API is supported. If a tag or digest of a
+manifest is given, also reports whether range requests are supported. With
+--write, chunked uploads and blob mounting are probed as well, which requires
+push permission to the repository. Upload sessions created by the probes are
+cancelled. Blob mounting is probed within the repository, so a successful probe
+is reported as likely supported only.
+
+Example - Check the health of a registry:
+  oras ping localhost:5000
+
+Example - Check the capabilities used to pull from a repository:
+  oras ping localhost:5000/hello:v1
+
+Example - Check the capabilities used to pull from and push to a repository:
+  oras ping --write localhost:5000/hello:v1
+
+Example - Measure the latency of a registry with 10 requests:
+  oras ping --count 10 localhost:5000
+
+Example - Check the capabilities of a registry in JSON format:
+  oras ping --format json localhost:5000/hello:v1
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the registry to check"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.rawReference = args[0]
+			if opts.count < 1 {
+				return fmt.Errorf("invalid count %d, must be at least 1", opts.count)
+			}
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPing(cmd, &opts)
+		},
+	}
+	cmd.Flags().IntVarP(&opts.count, "count", "c", 3, "number of requests sent to measure latency")
+	cmd.Flags().BoolVar(&opts.write, "write", false, "also probe capabilities requiring push permission")
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Remote)
+}
+
+func runPing(cmd *cobra.Command, opts *pingOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	prober := &probe.Prober{
+		Write: opts.write,
+		Count: opts.count,
+	}
+	var ref registry.Reference
+	if raw := strings.TrimSuffix(opts.rawReference, "/"); strings.Contains(raw, "/") {
+		repo, err := opts.NewRepository(raw, opts.Common, logger)
+		if err != nil {
+			return err
+		}
+		ref = repo.Reference
+		actions := []string{auth.ActionPull}
+		if opts.write {
+			actions = append(actions, auth.ActionPush)
+		}
+		ctx = auth.AppendRepositoryScope(ctx, ref, actions...)
+		if ref.Reference != "" {
+			if prober.Blob, err = findProbeBlob(ctx, repo); err != nil {
+				return err
+			}
+		}
+		prober.Client, prober.PlainHTTP, prober.Repository = repo.Client, repo.PlainHTTP, ref.Repository
+	} else {
+		reg, err := opts.NewRegistry(raw, opts.Common, logger)
+		if err != nil {
+			return err
+		}
+		ref = reg.Reference
+		prober.Client, prober.PlainHTTP = reg.Client, reg.PlainHTTP
+	}
+	prober.Host = ref.Host()
+
+	handler, err := display.NewPingHandler(opts.Printer, opts.Format, ref.Registry, ref.Repository, opts.write)
+	if err != nil {
+		return err
+	}
+	result, err := prober.Probe(ctx)
+	if err != nil {
+		return err
+	}
+	if err := handler.OnProbed(result); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+// findProbeBlob returns a blob referenced by the manifest of the repository
+// reference, or nil if the manifest references no blob, e.g. an image index.
+func findProbeBlob(ctx context.Context, repo *remote.Repository) (*ocispec.Descriptor, error) {
+	desc, err := repo.Resolve(ctx, repo.Reference.Reference)
+	if err != nil {
+		return nil, err
+	}
+	successors, _, config, err := graph.Successors(ctx, repo, desc)
+	if err != nil {
+		return nil, err
+	}
+	if config != nil {
+		return config, nil
+	}
+	for _, s := range successors {
+		if s.MediaType != ocispec.MediaTypeImageManifest && s.MediaType != ocispec.MediaTypeImageIndex {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"encoding/json"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/policy"
+)
+
+// evaluatePolicy completes input with the manifest, the annotations and the
+// referrers of the artifact root in storage, and evaluates the policy on it.
+// Referrers are summarized only if storage is a graph storage.
+func evaluatePolicy(ctx context.Context, p *option.Policy, input *policy.Input, storage content.ReadOnlyStorage, root ocispec.Descriptor) error {
+	if !p.Enabled() {
+		return nil
+	}
+	input.Descriptor = root
+	if descriptor.IsManifest(root) {
+		manifestJSON, err := content.FetchAll(ctx, storage, root)
+		if err != nil {
+			return err
+		}
+		var manifest struct {
+			Annotations map[string]string `json:"annotations"`
+		}
+		if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+			return err
+		}
+		input.Manifest = manifestJSON
+		input.Annotations = manifest.Annotations
+	}
+	input.Referrers = []policy.ReferrerSummary{}
+	if graphStorage, ok := storage.(content.ReadOnlyGraphStorage); ok {
+		referrers, err := registry.Referrers(ctx, graphStorage, root, "")
+		if err != nil {
+			return err
+		}
+		input.Referrers = policy.Summarize(referrers)
+	}
+	return p.Evaluate(ctx, input)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/config"
+	"oras.land/oras/internal/policy"
+)
+
+func Test_evaluatePolicy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake OPA command is a shell script")
+	}
+	dir := t.TempDir()
+	// the fake OPA command saves the input and allows the operation
+	inputPath := filepath.Join(dir, "input.json")
+	opa := filepath.Join(dir, "opa")
+	script := "#!/bin/sh\ncat > '" + inputPath + "'\necho '{\"result\":[{\"expressions\":[{\"value\":[]}]}]}'\n"
+	if err := os.WriteFile(opa, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	defer func(command string) { policy.OPACommand = command }(policy.OPACommand)
+	policy.OPACommand = opa
+	policyPath := filepath.Join(dir, "policy.rego")
+	if err := os.WriteFile(policyPath, []byte("package oras\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.EnvConfigPath, filepath.Join(dir, "config.json"))
+	var opts option.Policy
+	cmd := &cobra.Command{}
+	opts.ApplyFlags(cmd.Flags())
+	if err := cmd.Flags().Parse([]string{"--policy", policyPath}); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.Parse(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	store := memory.New()
+	root, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+		ManifestAnnotations: map[string]string{"key": "value"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"sbom1", "sbom2"} {
+		if _, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test.sbom", oras.PackManifestOptions{
+			Subject:             &root,
+			ManifestAnnotations: map[string]string{"name": name},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	input := &policy.Input{Command: "cp", Reference: "localhost:5000/test:v1", Destination: "localhost:5000/prod:v1"}
+	if err := evaluatePolicy(ctx, &opts, input, store, root); err != nil {
+		t.Fatalf("evaluatePolicy() error = %v", err)
+	}
+	inputJSON, err := os.ReadFile(inputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Command     string                   `json:"command"`
+		Reference   string                   `json:"reference"`
+		Destination string                   `json:"destination"`
+		Descriptor  ocispec.Descriptor       `json:"descriptor"`
+		Manifest    ocispec.Manifest         `json:"manifest"`
+		Annotations map[string]string        `json:"annotations"`
+		Referrers   []policy.ReferrerSummary `json:"referrers"`
+	}
+	if err := json.Unmarshal(inputJSON, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Command != "cp" || got.Reference != input.Reference || got.Destination != input.Destination {
+		t.Errorf("input operation = %s %s %s, want cp %s %s", got.Command, got.Reference, got.Destination, input.Reference, input.Destination)
+	}
+	if got.Descriptor.Digest != root.Digest || got.Manifest.ArtifactType != "application/vnd.test" {
+		t.Errorf("input artifact = %s %q, want %s %q", got.Descriptor.Digest, got.Manifest.ArtifactType, root.Digest, "application/vnd.test")
+	}
+	if got.Annotations["key"] != "value" {
+		t.Errorf("input annotations = %v, want key=value", got.Annotations)
+	}
+	if want := []policy.ReferrerSummary{{ArtifactType: "application/vnd.test.sbom", Count: 2}}; len(got.Referrers) != 1 || got.Referrers[0] != want[0] {
+		t.Errorf("input referrers = %v, want %v", got.Referrers, want)
+	}
+}
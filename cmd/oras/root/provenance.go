@@ -0,0 +1,49 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/internal/provenance"
+)
+
+// pushProvenance packs a SLSA provenance statement about subject into store
+// and copies it as a referrer of subject to dst.
+func pushProvenance(ctx context.Context, store content.Storage, dst oras.Target, name string, subject ocispec.Descriptor, info provenance.Info, opts oras.CopyGraphOptions) (ocispec.Descriptor, error) {
+	statement, err := info.Statement(name, subject)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	layer := content.NewDescriptorFromBytes(provenance.MediaTypeInToto, statement)
+	if err := store.Push(ctx, layer, bytes.NewReader(statement)); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	packOpts := oras.PackManifestOptions{
+		Subject:             &subject,
+		Layers:              []ocispec.Descriptor{layer},
+		ManifestAnnotations: info.Annotations(),
+	}
+	root, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, provenance.MediaTypeInToto, packOpts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return root, oras.CopyGraph(ctx, store, dst, root, opts)
+}
@@ -0,0 +1,235 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/expiry"
+	"oras.land/oras/internal/registryutil"
+)
+
+type pruneOptions struct {
+	option.Common
+	option.Confirmation
+	option.Target
+}
+
+// pruneTarget is a target whose tags can be listed and manifests deleted.
+type pruneTarget interface {
+	option.ReadOnlyGraphTagFinderTarget
+	content.Deleter
+}
+
+// expiredManifest is a manifest whose expiry time has passed.
+type expiredManifest struct {
+	desc      ocispec.Descriptor
+	expiresAt time.Time
+}
+
+func pruneCmd() *cobra.Command {
+	var opts pruneOptions
+	cmd := &cobra.Command{
+		Use:   "prune [flags] <name>",
+		Short: "[Experimental] Delete expired artifacts from a repository",
+		Long: `[Experimental] Delete expired artifacts from a repository
+
+Artifacts are considered expired if the "` + expiry.AnnotationExpires + `" manifest annotation, set by
+"oras push --expires-in" or "oras attach --expires-in", holds a time in the past.
+Tagged manifests and their referrers are examined.
+
+Example - Delete expired artifacts from repository 'localhost:5000/hello':
+  oras prune localhost:5000/hello
+
+Example - List expired artifacts without deleting them:
+  oras prune --dry-run localhost:5000/hello
+
+Example - Delete expired artifacts without prompting confirmation:
+  oras prune --force localhost:5000/hello
+
+Example - Delete expired artifacts, answering yes to the confirmation prompt:
+  oras prune --yes localhost:5000/hello
+
+Example - Delete expired artifacts from an OCI image layout folder 'layout-dir':
+  oras prune --oci-layout layout-dir
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the repository to prune"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			if opts.Reference != "" {
+				return fmt.Errorf("%q: prune applies to a whole repository, tag or digest is not allowed", opts.RawReference)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPrune(cmd, &opts)
+		},
+	}
+	opts.EnableDryRunFlag()
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runPrune(cmd *cobra.Command, opts *pruneOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	target, err := newPruneTarget(ctx, opts, logger)
+	if err != nil {
+		return err
+	}
+	ctx = registryutil.WithScopeHint(ctx, target, auth.ActionPull, auth.ActionDelete)
+	handler := display.NewPruneHandler(opts.Printer, opts.Path, opts.DryRun)
+
+	expired, err := findExpired(ctx, target, time.Now(), logger)
+	if err != nil {
+		return err
+	}
+	for _, m := range expired {
+		if err := handler.OnExpiredFound(m.desc, m.expiresAt); err != nil {
+			return err
+		}
+	}
+	if opts.DryRun || len(expired) == 0 {
+		if err := handler.OnPruneCompleted(len(expired)); err != nil {
+			return err
+		}
+		return handler.Render()
+	}
+
+	prompt := fmt.Sprintf("Are you sure you want to delete %d expired manifest(s) and all tags associated with them?", len(expired))
+	confirmed, err := opts.AskForTypedConfirmation(os.Stdin, prompt, opts.Path)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+	for _, m := range expired {
+		if err := target.(content.Deleter).Delete(ctx, m.desc); err != nil {
+			if errors.Is(err, errdef.ErrNotFound) {
+				// already removed, e.g. by a concurrent prune
+				continue
+			}
+			return fmt.Errorf("failed to delete %s@%s: %w", opts.Path, m.desc.Digest, err)
+		}
+		if err := handler.OnManifestPruned(m.desc); err != nil {
+			return err
+		}
+	}
+	if err := handler.OnPruneCompleted(len(expired)); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+// newPruneTarget returns the target to prune. A read-only target is returned
+// in dry run mode since expired manifests are only listed.
+func newPruneTarget(ctx context.Context, opts *pruneOptions, logger logrus.FieldLogger) (option.ReadOnlyGraphTagFinderTarget, error) {
+	if opts.DryRun {
+		return opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	}
+	target, err := opts.NewTarget(opts.Common, logger)
+	if err != nil {
+		return nil, err
+	}
+	pt, ok := target.(pruneTarget)
+	if !ok {
+		return nil, fmt.Errorf("%s does not support pruning", opts.GetDisplayReference())
+	}
+	return pt, nil
+}
+
+// findExpired walks the tagged manifests in target and their referrers and
+// returns the manifests expired at now. Referrers of an expired manifest are
+// not examined. Manifests with invalid expiries are skipped with a warning.
+func findExpired(ctx context.Context, target option.ReadOnlyGraphTagFinderTarget, now time.Time, logger logrus.FieldLogger) ([]expiredManifest, error) {
+	var expired []expiredManifest
+	visited := make(map[string]bool)
+	var visit func(desc ocispec.Descriptor) error
+	visit = func(desc ocispec.Descriptor) error {
+		key := desc.Digest.String()
+		if visited[key] || !descriptor.IsManifest(desc) {
+			return nil
+		}
+		visited[key] = true
+		manifestJSON, err := content.FetchAll(ctx, target, desc)
+		if err != nil {
+			return err
+		}
+		var manifest struct {
+			Annotations map[string]string `json:"annotations"`
+		}
+		if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+			return fmt.Errorf("failed to parse manifest %s: %w", desc.Digest, err)
+		}
+		expiresAt, ok, err := expiry.ExpiresAt(manifest.Annotations)
+		if err != nil {
+			// keep the manifest rather than aborting the whole prune
+			logger.Warnf("skipping manifest %s with an invalid expiry: %v", desc.Digest, err)
+			ok = false
+		}
+		if ok && !expiresAt.After(now) {
+			expired = append(expired, expiredManifest{desc: desc, expiresAt: expiresAt})
+			return nil
+		}
+		referrers, err := registry.Referrers(ctx, target, desc, "")
+		if err != nil {
+			return err
+		}
+		for _, r := range referrers {
+			if err := visit(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := target.Tags(ctx, "", func(tags []string) error {
+		for _, tag := range tags {
+			desc, err := target.Resolve(ctx, tag)
+			if err != nil {
+				return fmt.Errorf("failed to resolve tag %q: %w", tag, err)
+			}
+			if err := visit(desc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
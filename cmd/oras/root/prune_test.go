@@ -0,0 +1,68 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras/internal/expiry"
+)
+
+func Test_findExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tagged := func(tag, expires string) ocispec.Descriptor {
+		desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "test/"+tag, oras.PackManifestOptions{
+			ManifestAnnotations: map[string]string{expiry.AnnotationExpires: expires},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Tag(ctx, desc, tag); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	expired := tagged("expired", "2023-12-31T00:00:00Z")
+	tagged("valid", "2024-01-02T00:00:00Z")
+	tagged("malformed", "tomorrow")
+
+	var logs bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&logs)
+	got, err := findExpired(ctx, store, now, logger)
+	if err != nil {
+		t.Fatalf("findExpired() error = %v", err)
+	}
+	if len(got) != 1 || got[0].desc.Digest != expired.Digest {
+		t.Errorf("findExpired() = %v, want %v", got, expired)
+	}
+	if !strings.Contains(logs.String(), "invalid expiry") {
+		t.Errorf("findExpired() does not warn about the invalid expiry, got logs %q", logs.String())
+	}
+}
@@ -16,16 +16,31 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"sync"
+	"text/template"
 
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
@@ -34,8 +49,19 @@ import (
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/fileref"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/config"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/delta"
 	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/docker"
+	"oras.land/oras/internal/fileattr"
 	"oras.land/oras/internal/graph"
+	"oras.land/oras/internal/platform"
+	"oras.land/oras/internal/policy"
+	"oras.land/oras/internal/sparse"
+	"oras.land/oras/internal/split"
+	"oras.land/oras/internal/trace"
 )
 
 type pullOptions struct {
@@ -44,14 +70,50 @@ type pullOptions struct {
 	option.Platform
 	option.Target
 	option.Format
+	option.TempDir
 	option.Terminal
+	option.Policy
+	option.GitHubActions
+	option.OutputFD
+	option.TransferBudget
 
-	concurrency       int
-	KeepOldFiles      bool
-	IncludeSubject    bool
-	PathTraversal     bool
-	Output            string
-	ManifestConfigRef string
+	concurrency        int
+	extractConcurrency int
+	KeepOldFiles       bool
+	IncludeSubject     bool
+	PathTraversal      bool
+	ApplyDelta         bool
+	NoAtomic           bool
+	Sync               string
+	Delete             bool
+	Output             string
+	ManifestConfigRef  string
+	AllPlatforms       bool
+	Wasm               bool
+	Depth              int
+	ScanCommand        string
+	OutputTemplate     string
+	Chown              string
+	SELinuxLabel       string
+	Xattrs             bool
+	// depthLimited is true if the files are pulled only down to Depth.
+	depthLimited bool
+	// owner is the parsed Chown, or nil if the owner is not changed.
+	owner *fileattr.Owner
+	// xattrs restores the extended attributes of the extracted files if
+	// Xattrs is set.
+	xattrs *xattrRestorer
+	// staging is the hidden directory in Output the files are written to
+	// before being moved into Output, or empty if not pulling atomically.
+	staging string
+	// scanOutput receives the output of the scan command.
+	scanOutput io.Writer
+	// artifactConcurrency is the number of artifacts pulled concurrently
+	// when pulling multiple references.
+	artifactConcurrency int
+	// pulls are the artifacts to pull, each into its own output directory,
+	// if --output-template is specified.
+	pulls []*pullOptions
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -59,10 +121,15 @@ type pullOptions struct {
 func pullCmd() *cobra.Command {
 	var opts pullOptions
 	cmd := &cobra.Command{
-		Use:   "pull [flags] <name>{:<tag>|@<digest>}",
+		Use:   "pull [flags] <name>{:<tag>|@<digest>} [...]",
 		Short: "Pull files from a registry or an OCI image layout",
 		Long: `Pull files from a registry or an OCI image layout
 
+When pulling an image index of platform-specific manifests without --platform, the manifest of the host platform is selected.
+The OS, architecture and variant of --platform can be '*' to select the first manifest matching the others.
+Files are written into a hidden staging directory in the output directory first, and moved into place only after all of them are verified, unless --no-atomic or --allow-path-traversal is specified or files are named with absolute paths in the output directory.
+Blocks of zeros in reassembled files and sparse files in directories pushed by "oras push" are written as holes.
+
 Example - Pull artifact files from a registry:
   oras pull localhost:5000/hello:v1
 
@@ -75,22 +142,73 @@ Example - Pull files from an insecure registry:
 Example - Pull files from the HTTP registry:
   oras pull --plain-http localhost:5000/hello:v1
 
+Example - [Experimental] Pull files in place, for output directories on file systems not supporting renaming:
+  oras pull --no-atomic localhost:5000/hello:v1
+
+Example - [Experimental] Pull files into a system directory, owned by user "app" and group "app" with an SELinux label:
+  sudo oras pull --chown app:app --selinux-label system_u:object_r:usr_t:s0 -o /opt/hello localhost:5000/hello:v1
+
+Example - [Experimental] Pull directories pushed with --xattrs, restoring the extended attributes and file capabilities of their files:
+  sudo oras pull --xattrs -o /opt/hello localhost:5000/hello:v1
+
 Example - Pull files from a registry with local cache:
   export ORAS_CACHE=~/.oras/cache
   oras pull localhost:5000/hello:v1
 
+Example - [Experimental] Pull the WASM component pushed by "oras push --wasm" and verify its header:
+  oras pull --wasm localhost:5000/hello:v1
+
+Example - [Experimental] Pull the manifests of hello:v1 and its subjects only, without the files:
+  oras pull --include-subject --depth 0 localhost:5000/hello:v1
+
 Example - Pull files from a registry with certain platform:
   oras pull --platform linux/arm/v5 localhost:5000/hello:v1
 
+Example - Pull files of a Windows platform of any build of the OS version 10.0.17763:
+  oras pull --platform windows/amd64:10.0.17763 localhost:5000/hello:v1
+
+Example - Pull files of the first linux platform in a multi-arch artifact:
+  oras pull --platform 'linux/*' localhost:5000/hello:v1
+
+Example - [Experimental] Pull files of all platforms of a multi-arch artifact into subdirectories like 'linux-arm-v7':
+  oras pull --all-platforms localhost:5000/hello:v1
+
 Example - Pull all files with concurrency level tuned:
   oras pull --concurrency 6 localhost:5000/hello:v1
 
+Example - [Experimental] Pull all files, decompressing and writing them on 8 workers separately from downloading:
+  oras pull --extract-concurrency 8 localhost:5000/hello:v1
+
 Example - [Experimental] Pull files and format output in JSON:
   oras pull localhost:5000/hello:v1 --format json
 
 Example - [Experimental] Pull files and format output with Go template:
   oras pull localhost:5000/hello:v1 --format go-template="{{.reference}}"
 
+Example - [Experimental] Pull files pushed as binary deltas by "oras push --delta-from", reusing the local base versions:
+  oras pull --apply-delta localhost:5000/hello:v2
+
+Example - [Experimental] Sync directory 'model' with the artifact files, downloading changed files only and deleting files not in the artifact:
+  oras pull --sync model --delete localhost:5000/hello:v2
+
+Example - [Experimental] Pull files and reject them if the scanner 'clamscan' fails on the pulled files:
+  oras pull --scan-cmd 'clamscan -r {dir}' localhost:5000/hello:v1
+
+Example - [Experimental] Pull multiple artifacts concurrently, each into a directory like 'hello-v1' named after its repository and tag:
+  oras pull --output-template '{{.Repo}}-{{.Tag}}' localhost:5000/hello:v1 localhost:5000/world:v2
+
+Example - [Experimental] Pull files over HTTP/1.1 through a proxy mishandling HTTP/2, with at most 4 connections to the registry:
+  oras pull --http2 off --max-conns-per-host 4 localhost:5000/hello:v1
+
+Example - [Experimental] Pull files over IPv6 only from a registry at a link-local address on interface 'eth0':
+  oras pull --ip-version 6 '[fe80::1%eth0]:5000/hello:v1'
+
+Example - [Experimental] Pull files only if less than 2GB are to be downloaded, skipping the files in sync:
+  oras pull --sync model --max-transfer 2GB localhost:5000/hello:v2
+
+Example - [Experimental] Pull files from a public mirror, retrying anonymously if the stored credential has expired:
+  oras pull --allow-anonymous-fallback localhost:5000/hello:v1
+
 Example - Pull artifact files from an OCI image layout folder 'layout-dir':
   oras pull --oci-layout layout-dir:v1
 
@@ -100,7 +218,7 @@ Example - Pull artifact files from an OCI layout archive 'layout.tar':
 Example - Pull artifact files tagged 'example.com:v1' from an OCI image layout folder 'layout-dir':
   oras pull example.com:v1 --oci-layout-path layout-dir
 `,
-		Args: oerrors.CheckArgs(argument.Exactly(1), "the artifact reference you want to pull"),
+		Args: oerrors.CheckArgs(argument.AtLeast(1), "the artifact reference you want to pull"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			opts.RawReference = args[0]
 			err := option.Parse(cmd, &opts)
@@ -108,7 +226,26 @@ Example - Pull artifact files tagged 'example.com:v1' from an OCI image layout f
 				return err
 			}
 			opts.DisableTTY(opts.Debug, false)
-			return nil
+
+			for _, flag := range []string{"platform", "sync"} {
+				if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "all-platforms", flag); err != nil {
+					return err
+				}
+			}
+			if err := opts.parseScanCommand(cmd); err != nil {
+				return err
+			}
+			opts.depthLimited = opts.Depth >= 0 && cmd.Flags().Changed("depth")
+			if err := opts.parseSync(cmd); err != nil {
+				return err
+			}
+			if opts.extractConcurrency < 0 {
+				return fmt.Errorf("invalid --extract-concurrency %d: must not be negative", opts.extractConcurrency)
+			}
+			if err := opts.parseFileAttributes(); err != nil {
+				return err
+			}
+			return opts.parseOutputTemplate(cmd, args)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Printer.Verbose = opts.verbose
@@ -119,28 +256,267 @@ Example - Pull artifact files tagged 'example.com:v1' from an OCI image layout f
 	cmd.Flags().BoolVarP(&opts.KeepOldFiles, "keep-old-files", "k", false, "do not replace existing files when pulling, treat them as errors")
 	cmd.Flags().BoolVarP(&opts.PathTraversal, "allow-path-traversal", "T", false, "allow storing files out of the output directory")
 	cmd.Flags().BoolVarP(&opts.IncludeSubject, "include-subject", "", false, "recursively pull the subject of artifacts")
+	cmd.Flags().BoolVarP(&opts.ApplyDelta, "apply-delta", "", false, "[Experimental] reconstruct files from binary delta layers, following the chain of base artifacts")
 	cmd.Flags().StringVarP(&opts.Output, "output", "o", ".", "output directory")
+	cmd.Flags().BoolVarP(&opts.NoAtomic, "no-atomic", "", false, "[Experimental] write the files into the output directory in place, instead of into a hidden staging directory moved into the output directory once all files are verified")
+	cmd.Flags().StringVarP(&opts.Sync, "sync", "", "", "[Experimental] sync the `directory` with the artifact files, only downloading files whose local digests differ")
+	cmd.Flags().BoolVarP(&opts.Delete, "delete", "", false, "[Experimental] delete local files not in the artifact when used with --sync")
+	cmd.Flags().IntVarP(&opts.Depth, "depth", "", -1, "[Experimental] pull the files only down to `level` of the graph, where the root manifest is at level 0, and only the manifests below it; negative for no limit")
+	cmd.Flags().BoolVarP(&opts.Wasm, "wasm", "", false, "[Experimental] verify the headers of the pulled WASM modules or components, failing if none is pulled")
+	cmd.Flags().BoolVarP(&opts.AllPlatforms, "all-platforms", "", false, "[Experimental] pull the files of every platform in an image index into a subdirectory of the output directory named after the platform")
+	cmd.Flags().StringVarP(&opts.ScanCommand, "scan-cmd", "", "", "[Experimental] `command` scanning the pulled files, where {dir} is replaced by the directory of the pulled files; the pulled files are discarded and the pull fails if the command fails")
+	cmd.Flags().StringVarP(&opts.OutputTemplate, "output-template", "", "", "[Experimental] Go `template` of the output directory of each artifact under --output, with the fields .Registry, .Repo, .Tag, .Digest and .Reference; required for pulling multiple artifacts")
+	cmd.Flags().IntVarP(&opts.artifactConcurrency, "artifact-concurrency", "", 3, "[Experimental] number of artifacts to pull concurrently when pulling multiple artifacts")
 	cmd.Flags().StringVarP(&opts.ManifestConfigRef, "config", "", "", "output manifest config file")
+	cmd.Flags().StringVarP(&opts.Chown, "chown", "", "", "[Experimental] change the owner of the pulled files to `user[:group]`, given as names or numeric IDs, which usually requires privileges")
+	cmd.Flags().StringVarP(&opts.SELinuxLabel, "selinux-label", "", "", "[Experimental] set the SELinux security context of the pulled files to `label`, e.g. system_u:object_r:usr_t:s0, which usually requires privileges")
+	cmd.Flags().BoolVarP(&opts.Xattrs, "xattrs", "", false, "[Experimental] restore the extended attributes, such as POSIX ACLs and file capabilities, of the files in the directories pushed with --xattrs, which usually requires privileges")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 3, "concurrency level")
+	cmd.Flags().IntVarP(&opts.extractConcurrency, "extract-concurrency", "", 0, "[Experimental] number of workers writing and extracting the downloaded files, separately from downloading; 0 to write the files while downloading")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	opts.EnableAnonymousFallbackFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
-	return oerrors.Command(cmd, &opts.Target)
+	return opts.GitHubActions.Command(oerrors.Command(cmd, &opts.Target))
+}
+
+// parseSync validates the sync flags and syncs into the output directory.
+func (opts *pullOptions) parseSync(cmd *cobra.Command) error {
+	if opts.Delete && opts.Sync == "" {
+		return &oerrors.Error{
+			Err:            errors.New("--delete can only be used with --sync"),
+			Recommendation: "specify the directory to sync with --sync",
+		}
+	}
+	for _, flags := range [][]string{{"sync", "output"}, {"sync", "keep-old-files"}} {
+		if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), flags...); err != nil {
+			return err
+		}
+	}
+	if opts.Sync != "" {
+		opts.Output = opts.Sync
+	}
+	return nil
 }
 
-func runPull(cmd *cobra.Command, opts *pullOptions) (pullError error) {
+// pullOutputFields are the fields of the output directory template.
+type pullOutputFields struct {
+	Registry  string
+	Repo      string
+	Tag       string
+	Digest    string
+	Reference string
+}
+
+// parseOutputTemplate prepares the pull of each artifact referenced by args
+// into its own output directory, rendered from --output-template.
+func (opts *pullOptions) parseOutputTemplate(cmd *cobra.Command, args []string) error {
+	if opts.OutputTemplate == "" {
+		if len(args) > 1 {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("%d artifacts to pull but no output directory template specified", len(args)),
+				Recommendation: `Use --output-template to pull each artifact into its own directory, such as --output-template '{{.Repo}}-{{.Tag}}'`,
+			}
+		}
+		return nil
+	}
+	for _, flag := range []string{"sync", "config", "all-platforms", "status-fd", "metadata-fd"} {
+		if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "output-template", flag); err != nil {
+			return err
+		}
+	}
+	if opts.Target.Type != option.TargetTypeRemote {
+		return &oerrors.Error{
+			Err:            errors.New("--output-template is not supported for OCI image layouts"),
+			Recommendation: "pull the artifacts from an OCI image layout one by one with --output",
+		}
+	}
+	if opts.Format.Type != option.FormatTypeText.Name {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("--format %s is not supported with --output-template", opts.Format.Type),
+			Recommendation: "remove --format to print the text output",
+		}
+	}
+	if opts.artifactConcurrency < 1 {
+		return fmt.Errorf("invalid --artifact-concurrency %d: must be positive", opts.artifactConcurrency)
+	}
+	tmpl, err := template.New("output-template").Option("missingkey=error").Parse(opts.OutputTemplate)
+	if err != nil {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid --output-template %q: %w", opts.OutputTemplate, err),
+			Recommendation: "Use the fields .Registry, .Repo, .Tag, .Digest and .Reference, such as --output-template '{{.Repo}}-{{.Tag}}'",
+		}
+	}
+	outputs := make(map[string]string)
+	for _, arg := range args {
+		target, err := opts.Target.WithReference(arg)
+		if err != nil {
+			return err
+		}
+		output, err := renderPullOutput(tmpl, target)
+		if err != nil {
+			return err
+		}
+		output = filepath.Join(opts.Output, output)
+		if prev, ok := outputs[output]; ok {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("%s and %s would be pulled into the same directory %q", prev, arg, output),
+				Recommendation: "Use an --output-template with more fields, such as --output-template '{{.Repo}}-{{.Tag}}'",
+			}
+		}
+		outputs[output] = arg
+		pull := *opts
+		pull.Target = *target
+		pull.Output = output
+		pull.pulls = nil
+		opts.pulls = append(opts.pulls, &pull)
+		host, _, _ := strings.Cut(target.Path, "/")
+		if err := pull.CheckCredentialExpiry(cmd, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderPullOutput renders the output directory of the artifact referenced by
+// target from tmpl.
+func renderPullOutput(tmpl *template.Template, target *option.Target) (string, error) {
+	ref, err := registry.ParseReference(target.RawReference)
+	if err != nil {
+		return "", err
+	}
+	fields := pullOutputFields{
+		Registry:  ref.Registry,
+		Repo:      ref.Repository,
+		Reference: ref.Reference,
+	}
+	if _, err := ref.Digest(); err == nil {
+		fields.Digest = ref.Reference
+	} else {
+		fields.Tag = ref.Reference
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, fields); err != nil {
+		return "", fmt.Errorf("failed to render --output-template for %s: %w", target.RawReference, err)
+	}
+	output := buf.String()
+	if output == "" || !filepath.IsLocal(output) {
+		return "", &oerrors.Error{
+			Err:            fmt.Errorf("invalid output directory %q rendered for %s", output, target.RawReference),
+			Recommendation: "Make sure --output-template renders a non-empty relative path without '..'",
+		}
+	}
+	return output, nil
+}
+
+// atomic returns true if the files are written into a staging directory
+// before being moved into the output directory. Files outside of the output
+// directory cannot be staged.
+func (opts *pullOptions) atomic() bool {
+	return !opts.NoAtomic && !opts.PathTraversal
+}
+
+// parseScanCommand falls back to the scan command in the oras config if
+// --scan-cmd is not specified.
+func (opts *pullOptions) parseScanCommand(cmd *cobra.Command) error {
+	if !cmd.Flags().Changed("scan-cmd") {
+		cfg, err := config.LoadDefault()
+		if err != nil {
+			return err
+		}
+		opts.ScanCommand = cfg.Pull.ScanCommand
+	}
+	if opts.ScanCommand != "" && len(strings.Fields(opts.ScanCommand)) == 0 {
+		return &oerrors.Error{
+			Err:            errors.New("the scan command is empty"),
+			Recommendation: "specify the scanner to run with --scan-cmd, such as --scan-cmd 'clamscan -r {dir}'",
+		}
+	}
+	return nil
+}
+
+// parseFileAttributes validates the attributes to apply to the pulled files.
+func (opts *pullOptions) parseFileAttributes() error {
+	if opts.Chown != "" {
+		if runtime.GOOS == "windows" {
+			return errors.New("--chown is not supported on Windows")
+		}
+		owner, err := fileattr.ParseOwner(opts.Chown)
+		if err != nil {
+			return fmt.Errorf("invalid --chown: %w", err)
+		}
+		opts.owner = &owner
+	}
+	if opts.SELinuxLabel != "" && runtime.GOOS != "linux" {
+		return errors.New("--selinux-label is only supported on Linux")
+	}
+	if opts.Xattrs && runtime.GOOS != "linux" {
+		return errors.New("--xattrs is only supported on Linux")
+	}
+	return nil
+}
+
+func runPull(cmd *cobra.Command, opts *pullOptions) error {
+	if len(opts.pulls) > 0 {
+		return runPullArtifacts(cmd, opts)
+	}
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
 	statusHandler, metadataHandler, err := display.NewPullHandler(opts.Printer, opts.Format, opts.Path, opts.TTY)
 	if err != nil {
 		return err
 	}
+	statusHandler, metadataHandler = display.WithPullOutputFD(statusHandler, metadataHandler, opts.Path, &opts.OutputFD)
+	return pullArtifact(ctx, cmd, logger, opts, statusHandler, metadataHandler)
+}
+
+// runPullArtifacts pulls multiple artifacts concurrently, each into its own
+// output directory.
+func runPullArtifacts(cmd *cobra.Command, opts *pullOptions) (returnErr error) {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	var group *status.PullGroup
+	outputs := make([]bytes.Buffer, len(opts.pulls))
+	if opts.TTY != nil {
+		var err error
+		if group, err = status.NewPullGroup(opts.TTY); err != nil {
+			return err
+		}
+		// hold back the text output until the progress rendering stops
+		for i, pull := range opts.pulls {
+			pull.Printer = output.NewPrinter(&outputs[i], opts.Printer)
+			pull.Printer.Verbose = opts.Printer.Verbose
+		}
+		defer func() {
+			if err := group.Close(); err != nil && returnErr == nil {
+				returnErr = err
+			}
+			for i := range outputs {
+				if _, err := opts.Printer.Write(outputs[i].Bytes()); err != nil && returnErr == nil {
+					returnErr = err
+				}
+			}
+		}()
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(opts.artifactConcurrency)
+	for _, pull := range opts.pulls {
+		eg.Go(func() error {
+			statusHandler, metadataHandler := display.NewPullGroupHandler(pull.Printer, group, pull.RawReference)
+			if err := pullArtifact(egCtx, cmd, logger, pull, statusHandler, metadataHandler); err != nil {
+				return fmt.Errorf("failed to pull %s: %w", pull.RawReference, err)
+			}
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// pullArtifact pulls the artifact referenced by opts.
+func pullArtifact(ctx context.Context, cmd *cobra.Command, logger logrus.FieldLogger, opts *pullOptions, statusHandler status.PullHandler, metadataHandler metadata.PullHandler) error {
 	// Copy Options
 	copyOptions := oras.DefaultCopyOptions
 	copyOptions.Concurrency = opts.concurrency
-	if opts.Platform.Platform != nil {
-		copyOptions.WithTargetPlatform(opts.Platform.Platform)
-	}
 	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
 	if err != nil {
 		return err
@@ -152,10 +528,245 @@ func runPull(cmd *cobra.Command, opts *pullOptions) (pullError error) {
 	if err != nil {
 		return err
 	}
-	dst, err := file.New(opts.Output)
+	if !opts.AllPlatforms {
+		if err := selectPlatform(ctx, src, cmd.ErrOrStderr(), opts); err != nil {
+			return err
+		}
+	}
+	if opts.Policy.Enabled() {
+		root, err := oras.Resolve(ctx, src, opts.Reference, oras.DefaultResolveOptions)
+		if err != nil {
+			return err
+		}
+		if err := evaluatePolicy(ctx, &opts.Policy, &policy.Input{Command: "pull", Reference: opts.RawReference}, src, root); err != nil {
+			return err
+		}
+	}
+	if opts.TransferBudget.Enabled() {
+		if err := preflightPull(ctx, src, opts); err != nil {
+			return err
+		}
+	}
+	var wasm *wasmVerifier
+	if opts.Wasm {
+		wasm = &wasmVerifier{PullHandler: metadataHandler}
+		metadataHandler = wasm
+	}
+	if opts.Xattrs {
+		opts.xattrs = &xattrRestorer{}
+	}
+	var attributed *pulledFileRecorder
+	if opts.owner != nil || opts.SELinuxLabel != "" {
+		attributed = &pulledFileRecorder{PullHandler: metadataHandler}
+		metadataHandler = attributed
+	}
+	var scanned *pulledFileRecorder
+	if opts.ScanCommand != "" {
+		opts.scanOutput = cmd.ErrOrStderr()
+		if !opts.atomic() {
+			// staged files are scanned before being moved into place
+			scanned = &pulledFileRecorder{PullHandler: metadataHandler}
+			metadataHandler = scanned
+		}
+	}
+	var desc ocispec.Descriptor
+	if opts.AllPlatforms {
+		desc, err = pullAllPlatforms(ctx, src, copyOptions, metadataHandler, statusHandler, opts)
+	} else {
+		desc, err = pullFiles(ctx, src, copyOptions, metadataHandler, statusHandler, opts)
+	}
 	if err != nil {
 		return err
 	}
+	if wasm != nil && wasm.verified.Load() == 0 {
+		return fmt.Errorf("no WASM module or component found in %s", opts.RawReference)
+	}
+	if attributed != nil {
+		if err := applyFileAttributes(attributed.paths, opts); err != nil {
+			return err
+		}
+	}
+	if opts.xattrs != nil {
+		if err := opts.xattrs.restore(opts.SELinuxLabel != ""); err != nil {
+			return fileAttributeError(err, "restore the extended attributes of")
+		}
+	}
+	if scanned != nil {
+		if err := scanPulledFiles(ctx, opts.ScanCommand, opts.Output, scanned.paths, cmd.ErrOrStderr()); err != nil {
+			return err
+		}
+	}
+	metadataHandler.OnPulled(&opts.Target, desc)
+	if err := opts.ReportResult("Pulled", opts.Path, desc); err != nil {
+		return err
+	}
+	return metadataHandler.Render()
+}
+
+// applyFileAttributes changes the owner and the SELinux label of the pulled
+// files at paths, including the files in the pulled directories.
+func applyFileAttributes(paths []string, opts *pullOptions) error {
+	for _, path := range paths {
+		if opts.owner != nil {
+			if err := fileattr.Chown(path, *opts.owner); err != nil {
+				return fileAttributeError(err, "change the owner of")
+			}
+		}
+		if opts.SELinuxLabel != "" {
+			if err := fileattr.SetSELinuxLabel(path, opts.SELinuxLabel); err != nil {
+				return fileAttributeError(err, "set the SELinux label of")
+			}
+		}
+	}
+	return nil
+}
+
+// fileAttributeError returns the error of failing to apply an attribute to
+// the pulled files.
+func fileAttributeError(err error, action string) error {
+	err = fmt.Errorf("failed to %s the pulled files: %w", action, err)
+	if errors.Is(err, fs.ErrPermission) {
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: "Pull the files with the required privileges, such as running as root",
+		}
+	}
+	return err
+}
+
+// preflightPull prints the estimated transfer of the pull and checks it
+// against the transfer budget. The nodes in the local cache, the unnamed
+// layers, which are not pulled, and the files already in sync are skipped.
+func preflightPull(ctx context.Context, src oras.ReadOnlyTarget, opts *pullOptions) error {
+	root, err := oras.Resolve(ctx, src, opts.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
+	}
+	var cached content.ReadOnlyStorage
+	if opts.Cache.Root != "" {
+		if store, err := oci.NewFromFS(ctx, os.DirFS(opts.Cache.Root)); err == nil {
+			cached = store
+		}
+	}
+	estimate, err := estimateTransfer(ctx, src, root, nil, func(ctx context.Context, desc ocispec.Descriptor) (bool, error) {
+		if cached != nil {
+			if exists, err := cached.Exists(ctx, desc); err != nil || exists {
+				return exists, err
+			}
+		}
+		switch {
+		case descriptor.IsManifest(desc), split.IsChunk(desc), delta.IsDelta(desc):
+			return false, nil
+		case desc.Annotations[ocispec.AnnotationTitle] == "":
+			return true, nil
+		}
+		return opts.Sync != "" && isSynced(desc, opts), nil
+	})
+	if err != nil {
+		return err
+	}
+	if opts.Format.Type == option.FormatTypeText.Name {
+		if err := opts.Printer.Println(estimate.String()); err != nil {
+			return err
+		}
+	}
+	return opts.TransferBudget.Check(estimate.Size)
+}
+
+// selectPlatform selects the manifest of the requested platform, which may
+// have wildcards, or of the host platform if no platform is requested, when
+// pulling an image index of platform-specific manifests. The reference to pull
+// is set to the selected manifest, and the selection is reported to w unless
+// the platform is fully specified.
+func selectPlatform(ctx context.Context, src oras.ReadOnlyTarget, w io.Writer, opts *pullOptions) error {
+	if want := opts.Platform.Platform; want != nil && !platform.HasWildcard(want) {
+		selected, err := platform.Resolve(ctx, src, opts.Reference, want)
+		if err != nil {
+			return option.PlatformError(err, "--all-platforms to pull all of them")
+		}
+		opts.Reference = selected.Digest.String()
+		return nil
+	}
+	root, err := oras.Resolve(ctx, src, opts.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return err
+	}
+	if root.MediaType != ocispec.MediaTypeImageIndex && root.MediaType != docker.MediaTypeManifestList {
+		return nil
+	}
+	manifests, err := content.Successors(ctx, src, root)
+	if err != nil {
+		return err
+	}
+	var platforms []ocispec.Platform
+	manifests = slices.DeleteFunc(manifests, func(manifest ocispec.Descriptor) bool {
+		if manifest.Platform == nil || manifest.Platform.OS == "unknown" {
+			return true
+		}
+		platforms = append(platforms, *manifest.Platform)
+		return false
+	})
+	if len(manifests) == 0 {
+		// pull the whole index if it is not for platforms
+		return nil
+	}
+	want := opts.Platform.Platform
+	if want == nil {
+		want = &ocispec.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH}
+	}
+	selected := platform.Select(manifests, []ocispec.Platform{*want})
+	if len(selected) == 0 {
+		return option.PlatformError(&platform.NoMatchError{
+			Root:      opts.RawReference,
+			Want:      *want,
+			Available: platforms,
+		}, "--all-platforms to pull all of them")
+	}
+	opts.Reference = selected[0].Digest.String()
+	_, err = fmt.Fprintf(w, "Selected platform %s (%s) of %s, use --platform to select another platform\n", platform.String(selected[0].Platform), selected[0].Digest, opts.RawReference)
+	return err
+}
+
+// pullFiles pulls the files of the artifact into the output directory.
+func pullFiles(ctx context.Context, src oras.ReadOnlyTarget, copyOptions oras.CopyOptions, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, opts *pullOptions) (_ ocispec.Descriptor, pullError error) {
+	root := opts.Output
+	atomic := opts.atomic()
+	if atomic {
+		desc, err := oras.Resolve(ctx, src, opts.Reference, oras.DefaultResolveOptions)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		found, err := hasOutputAbsPath(ctx, src, desc, opts)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if found {
+			trace.Logger(ctx).Debugf("writing the files of %s in place as they are named with absolute paths in %s", opts.Reference, opts.Output)
+			atomic = false
+		}
+	}
+	if atomic {
+		if err := os.MkdirAll(opts.Output, 0777); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		staging, err := os.MkdirTemp(opts.Output, stagingPattern)
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to create the staging directory: %w", err)
+		}
+		defer func() {
+			if err := os.RemoveAll(staging); err != nil {
+				trace.Logger(ctx).Debugf("failed to remove the staging directory %s: %v", staging, err)
+			}
+		}()
+		stagedOpts := *opts
+		stagedOpts.staging = staging
+		opts = &stagedOpts
+		root = staging
+	}
+	dst, err := file.New(root)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
 	defer func() {
 		if err := dst.Close(); pullError == nil {
 			pullError = err
@@ -164,19 +775,97 @@ func runPull(cmd *cobra.Command, opts *pullOptions) (pullError error) {
 	dst.AllowPathTraversalOnWrite = opts.PathTraversal
 	dst.DisableOverwrite = opts.KeepOldFiles
 
-	desc, err := doPull(ctx, src, dst, copyOptions, metadataHandler, statusHandler, opts)
+	var pulled *pulledFileRecorder
+	if opts.Delete {
+		pulled = &pulledFileRecorder{PullHandler: metadataHandler}
+		metadataHandler = pulled
+	}
+	desc, err := doPull(ctx, src, &partialFileCleaner{GraphTarget: dst, root: root, po: opts}, copyOptions, metadataHandler, statusHandler, opts)
 	if err != nil {
 		if !errors.Is(err, file.ErrPathTraversalDisallowed) {
-			return err
+			return ocispec.Descriptor{}, err
 		}
 		// customize friendly message for path traversal error
-		return &oerrors.Error{
+		return ocispec.Descriptor{}, &oerrors.Error{
 			Err:            err,
 			Recommendation: `Pulling files outside of working directory is insecure and blocked by default. If you trust the content producer, use --allow-path-traversal to bypass this check.`,
 		}
 	}
-	metadataHandler.OnPulled(&opts.Target, desc)
-	return metadataHandler.Render()
+	if opts.staging != "" {
+		// all files are verified, move them into place
+		if err := dst.Close(); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if opts.ScanCommand != "" {
+			// rejected files are discarded with the staging directory,
+			// leaving the output directory untouched
+			if err := scanStagedFiles(ctx, opts.ScanCommand, opts.staging, opts.scanOutput); err != nil {
+				return ocispec.Descriptor{}, err
+			}
+		}
+		if err := commitStaging(opts.staging, opts.Output); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	if pulled != nil {
+		if err := deleteExtraneousFiles(opts.Output, pulled.names, statusHandler, opts); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	return desc, nil
+}
+
+// pullAllPlatforms pulls the files of each platform in the image index into a
+// subdirectory of the output directory named after the platform.
+func pullAllPlatforms(ctx context.Context, src oras.ReadOnlyTarget, copyOptions oras.CopyOptions, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, opts *pullOptions) (ocispec.Descriptor, error) {
+	root, err := oras.Resolve(ctx, src, opts.Reference, oras.DefaultResolveOptions)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if root.MediaType != ocispec.MediaTypeImageIndex && root.MediaType != docker.MediaTypeManifestList {
+		return ocispec.Descriptor{}, &oerrors.Error{
+			Err:            fmt.Errorf("%s is not an image index", opts.RawReference),
+			Recommendation: "remove --all-platforms to pull the files of a single artifact",
+		}
+	}
+	manifests, err := content.Successors(ctx, src, root)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	dirs := make(map[string]bool)
+	for _, manifest := range manifests {
+		if manifest.Platform == nil || manifest.Platform.OS == "unknown" {
+			// skip manifests not for a platform, such as attestations
+			continue
+		}
+		dir := platformDirName(manifest.Platform)
+		if dirs[dir] {
+			return ocispec.Descriptor{}, fmt.Errorf("multiple manifests in %s are for the platform %s", opts.RawReference, dir)
+		}
+		dirs[dir] = true
+		platformOpts := *opts
+		platformOpts.Output = filepath.Join(opts.Output, dir)
+		platformOpts.Reference = manifest.Digest.String()
+		if _, err := pullFiles(ctx, src, copyOptions, metadataHandler, statusHandler, &platformOpts); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to pull the platform %s: %w", dir, err)
+		}
+	}
+	if len(dirs) == 0 {
+		return ocispec.Descriptor{}, fmt.Errorf("no platform-specific manifest found in %s", opts.RawReference)
+	}
+	return root, nil
+}
+
+// platformDirName returns the directory name for the platform p, in the form
+// of os-arch[-variant][-os_version].
+func platformDirName(p *ocispec.Platform) string {
+	parts := []string{p.OS, p.Architecture}
+	for _, part := range []string{p.Variant, p.OSVersion} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.ReplaceAll(strings.Join(parts, "-"), string(filepath.Separator), "_")
 }
 
 func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget, opts oras.CopyOptions, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, po *pullOptions) (ocispec.Descriptor, error) {
@@ -189,6 +878,15 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 			return ocispec.Descriptor{}, err
 		}
 	}
+	var deferred *contentutil.DeferredTarget
+	if po.extractConcurrency > 0 {
+		// write and extract files separately from downloading
+		deferred = contentutil.NewDeferredTarget(ctx, dst, po.extractConcurrency, "")
+		dst = deferred
+		defer func() {
+			_ = deferred.Wait()
+		}()
+	}
 	dst, stopTrack, err := statusHandler.TrackTarget(dst)
 	if err != nil {
 		return ocispec.Descriptor{}, err
@@ -197,7 +895,11 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 		_ = stopTrack()
 	}()
 	var printed sync.Map
+	var levels graphLevels
 	var getConfigOnce sync.Once
+	var deferredLock sync.Mutex
+	var chunks, chunkIndexes, packedDirs []ocispec.Descriptor
+	var deltaFiles []deltaFile
 	opts.FindSuccessors = func(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
 		statusFetcher := content.FetcherFunc(func(ctx context.Context, target ocispec.Descriptor) (fetched io.ReadCloser, fetchErr error) {
 			if _, ok := printed.LoadOrStore(descriptor.GenerateContentKey(target), true); ok {
@@ -222,6 +924,15 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 		if err != nil {
 			return nil, err
 		}
+		if config != nil && config.MediaType == split.MediaTypeChunkIndex {
+			// content-defined chunks are reassembled after copying
+			deferredLock.Lock()
+			chunkIndexes = append(chunkIndexes, *config)
+			deferredLock.Unlock()
+			nodes = slices.DeleteFunc(nodes, func(s ocispec.Descriptor) bool {
+				return s.Annotations[ocispec.AnnotationTitle] == ""
+			})
+		}
 		if subject != nil && po.IncludeSubject {
 			nodes = append(nodes, *subject)
 		}
@@ -234,13 +945,44 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 					config.Annotations[ocispec.AnnotationTitle] = configPath
 				}
 			})
-			if config.Size != ocispec.DescriptorEmptyJSON.Size || config.Digest != ocispec.DescriptorEmptyJSON.Digest || config.Annotations[ocispec.AnnotationTitle] != "" {
+			if config.Annotations[ocispec.AnnotationTitle] != "" ||
+				(config.MediaType != split.MediaTypeChunkIndex && (config.Size != ocispec.DescriptorEmptyJSON.Size || config.Digest != ocispec.DescriptorEmptyJSON.Digest)) {
 				nodes = append(nodes, *config)
 			}
 		}
 
 		var ret []ocispec.Descriptor
+		level := levels.successorLevel(desc, nodes)
 		for _, s := range nodes {
+			if po.depthLimited && level > po.Depth && !descriptor.IsManifest(s) {
+				// only manifests are pulled below the depth
+				if err := notifyOnce(&printed, s, statusHandler.OnNodeSkipped); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if po.ApplyDelta && delta.IsDelta(s) {
+				// files of delta layers are reconstructed after copying
+				deferredLock.Lock()
+				deltaFiles = append(deltaFiles, deltaFile{manifest: desc, name: s.Annotations[delta.AnnotationName]})
+				deferredLock.Unlock()
+				continue
+			}
+			if split.IsChunk(s) {
+				// chunks of split files are reassembled after copying
+				deferredLock.Lock()
+				chunks = append(chunks, s)
+				deferredLock.Unlock()
+				continue
+			}
+			if isPackedDir(s, po) {
+				// directories with sparse files or extended attributes are
+				// extracted after copying
+				deferredLock.Lock()
+				packedDirs = append(packedDirs, s)
+				deferredLock.Unlock()
+				continue
+			}
 			if s.Annotations[ocispec.AnnotationTitle] == "" {
 				if content.Equal(s, ocispec.DescriptorEmptyJSON) {
 					// empty layer
@@ -263,6 +1005,12 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 					}
 					continue
 				}
+			} else if po.Sync != "" && isSynced(s, po) {
+				// the local file is up to date
+				if err := notifyOnce(&printed, s, statusHandler.OnNodeSkipped); err != nil {
+					return nil, err
+				}
+				continue
 			}
 			ret = append(ret, s)
 		}
@@ -279,6 +1027,9 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 			return err
 		}
 		for _, s := range successors {
+			if isPackedDir(s, po) {
+				continue
+			}
 			if name, ok := s.Annotations[ocispec.AnnotationTitle]; ok {
 				if err = metadataHandler.OnFilePulled(name, po.Output, s, po.Path); err != nil {
 					return err
@@ -294,7 +1045,206 @@ func doPull(ctx context.Context, src oras.ReadOnlyTarget, dst oras.GraphTarget,
 
 	// Copy
 	desc, err := oras.Copy(ctx, src, po.Reference, dst, po.Reference, opts)
-	return desc, oerrors.UnwrapCopyError(err) // we don't need the CopyError information so we unwrap it here
+	if err != nil {
+		return ocispec.Descriptor{}, oerrors.UnwrapCopyError(err) // we don't need the CopyError information so we unwrap it here
+	}
+	if deferred != nil {
+		if err := deferred.Wait(); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	if len(deltaFiles) > 0 {
+		if err := applyDeltas(ctx, src, deltaFiles, metadataHandler, statusHandler, po); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	if len(packedDirs) > 0 {
+		if err := extractDirs(ctx, src, packedDirs, metadataHandler, statusHandler, po); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	if len(chunks) > 0 || len(chunkIndexes) > 0 {
+		files, err := split.Group(chunks)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		indexed, err := loadChunkIndexes(ctx, src, chunkIndexes)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		files = append(files, indexed...)
+		var required int64
+		for _, f := range files {
+			required += f.Descriptor().Size
+		}
+		if err := po.TempDir.CheckSpace(ctx, po.Output, required, "reassembling split files"); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if err := assembleFiles(ctx, src, files, metadataHandler, statusHandler, po); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+	}
+	return desc, nil
+}
+
+// loadChunkIndexes fetches the chunk indexes of the artifacts pushed with
+// "oras push --cdc-chunk-size" and returns the files to be reassembled.
+func loadChunkIndexes(ctx context.Context, src content.Fetcher, indexes []ocispec.Descriptor) ([]split.File, error) {
+	var files []split.File
+	loaded := make(map[string]bool)
+	for _, desc := range indexes {
+		if loaded[desc.Digest.String()] {
+			continue
+		}
+		loaded[desc.Digest.String()] = true
+		data, err := content.FetchAll(ctx, src, desc)
+		if err != nil {
+			return nil, err
+		}
+		index, err := split.ParseIndex(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", desc.Digest, err)
+		}
+		files = append(files, index.Expand()...)
+	}
+	return files, nil
+}
+
+// assembleFiles reassembles the files split into chunks by
+// "oras push --split-size" or "oras push --cdc-chunk-size" into the output
+// directory.
+func assembleFiles(ctx context.Context, src content.Fetcher, files []split.File, metadataHandler metadata.PullHandler, statusHandler status.PullHandler, po *pullOptions) error {
+	remote := content.FetcherFunc(func(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+		if err := statusHandler.OnNodeDownloading(target); err != nil {
+			return nil, err
+		}
+		rc, err := src.Fetch(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		return &chunkReadCloser{ReadCloser: rc, onClose: func() error {
+			return statusHandler.OnNodeDownloaded(target)
+		}}, nil
+	})
+	for _, f := range files {
+		var fetcher content.Fetcher = remote
+		if local := scanLocalChunks(f, po); local != nil {
+			// only download the chunks changed since the local version
+			fetcher = content.FetcherFunc(func(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+				if exists, _ := local.Exists(ctx, target); !exists {
+					return remote.Fetch(ctx, target)
+				}
+				if err := statusHandler.OnNodeRestored(target); err != nil {
+					return nil, err
+				}
+				return local.Fetch(ctx, target)
+			})
+		}
+		if err := assembleFile(ctx, fetcher, f, po); err != nil {
+			return err
+		}
+		if err := metadataHandler.OnFilePulled(f.Name, po.Output, f.Descriptor(), po.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanLocalChunks returns the chunks of the existing local version of a file
+// chunked by content, or nil if there are none.
+func scanLocalChunks(f split.File, po *pullOptions) *split.Store {
+	if f.Chunking == nil || po.KeepOldFiles {
+		return nil
+	}
+	path, err := outputPath(f.Name, po)
+	if err != nil {
+		return nil
+	}
+	if fi, err := os.Stat(path); err != nil || !fi.Mode().IsRegular() {
+		return nil
+	}
+	local := split.NewStore(0)
+	if err := local.Scan(path, *f.Chunking); err != nil {
+		return nil
+	}
+	return local
+}
+
+// outputPath returns the path to write the file named name to.
+func outputPath(name string, po *pullOptions) (string, error) {
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(po.Output, path)
+	}
+	if !po.PathTraversal && !isWithin(po.Output, path) {
+		return "", fmt.Errorf("%s: %w", name, file.ErrPathTraversalDisallowed)
+	}
+	return path, nil
+}
+
+// isWithin returns true if path is dir or under dir.
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// assembleFile writes the reassembled content of f to the output directory.
+func assembleFile(ctx context.Context, fetcher content.Fetcher, f split.File, po *pullOptions) error {
+	return writeOutputFile(f.Name, po, func(w io.Writer) error {
+		return split.Assemble(ctx, fetcher, f, w)
+	})
+}
+
+// writeOutputFile writes the file named name to the output directory. The
+// content is written to a temporary file first and renamed once complete.
+// Blocks of zeros are written as holes.
+func writeOutputFile(name string, po *pullOptions, write func(w io.Writer) error) error {
+	path, err := outputPath(name, po)
+	if err != nil {
+		return err
+	}
+	if po.KeepOldFiles {
+		if _, err := os.Lstat(path); err == nil {
+			return fmt.Errorf("%s: %w", name, file.ErrOverwriteDisallowed)
+		}
+	}
+	path = stagedPath(path, po)
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	err = tmp.Chmod(0644)
+	if err == nil {
+		w := sparse.NewWriter(tmp)
+		if err = write(w); err == nil {
+			err = w.Flush()
+		}
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// chunkReadCloser notifies when fetched content is closed.
+type chunkReadCloser struct {
+	io.ReadCloser
+	onClose func() error
+}
+
+// Close closes the chunk and notifies.
+func (rc *chunkReadCloser) Close() error {
+	if err := rc.ReadCloser.Close(); err != nil {
+		return err
+	}
+	return rc.onClose()
 }
 
 func notifyOnce(notified *sync.Map, s ocispec.Descriptor, notify func(ocispec.Descriptor) error) error {
@@ -303,3 +1253,219 @@ func notifyOnce(notified *sync.Map, s ocispec.Descriptor, notify func(ocispec.De
 	}
 	return nil
 }
+
+// partialFileCleaner removes the files partially written to a file store when
+// pulling is interrupted.
+type partialFileCleaner struct {
+	oras.GraphTarget
+	root string
+	po   *pullOptions
+}
+
+// Push pushes the content, removing the file written if the push is
+// interrupted. Files existing before the push are kept.
+func (t *partialFileCleaner) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	name := expected.Annotations[ocispec.AnnotationTitle]
+	if name == "" {
+		return t.GraphTarget.Push(ctx, expected, content)
+	}
+	if t.po.staging != "" && t.po.KeepOldFiles {
+		// the files in the output directory are not seen by the file store
+		if path, err := outputPath(name, t.po); err == nil {
+			if _, err := os.Lstat(path); err == nil {
+				return fmt.Errorf("%s: %w", name, file.ErrOverwriteDisallowed)
+			}
+		}
+	}
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(t.root, name)
+	}
+	_, statErr := os.Lstat(path)
+	err := t.GraphTarget.Push(ctx, expected, content)
+	if err != nil && ctx.Err() != nil && errors.Is(statErr, fs.ErrNotExist) && !errors.Is(err, file.ErrDuplicateName) {
+		_ = os.RemoveAll(path)
+	}
+	return err
+}
+
+// isSynced returns true if the local file of the named node desc has the same
+// content.
+func isSynced(desc ocispec.Descriptor, po *pullOptions) bool {
+	path, err := outputPath(desc.Annotations[ocispec.AnnotationTitle], po)
+	if err != nil {
+		return false
+	}
+	if fi, err := os.Lstat(path); err != nil || !fi.Mode().IsRegular() || fi.Size() != desc.Size {
+		return false
+	}
+	return fileDigestEquals(path, desc.Digest)
+}
+
+// graphLevels records the levels of the nodes in a graph, where the root is
+// at level 0.
+type graphLevels struct {
+	lock   sync.Mutex
+	levels map[digest.Digest]int
+}
+
+// successorLevel records the level of the successors of node and returns it.
+// A node reachable via multiple paths is at the lowest level.
+func (g *graphLevels) successorLevel(node ocispec.Descriptor, successors []ocispec.Descriptor) int {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.levels == nil {
+		g.levels = make(map[digest.Digest]int)
+	}
+	level := g.levels[node.Digest] + 1
+	for _, s := range successors {
+		if current, ok := g.levels[s.Digest]; !ok || level < current {
+			g.levels[s.Digest] = level
+		}
+	}
+	return level
+}
+
+// pulledFileRecorder records the names and paths of the pulled files.
+type pulledFileRecorder struct {
+	metadata.PullHandler
+	lock  sync.Mutex
+	names []string
+	paths []string
+}
+
+// OnFilePulled records the file name and path.
+func (r *pulledFileRecorder) OnFilePulled(name string, outputDir string, desc ocispec.Descriptor, descPath string) error {
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(outputDir, name)
+	}
+	r.lock.Lock()
+	r.names = append(r.names, name)
+	r.paths = append(r.paths, path)
+	r.lock.Unlock()
+	return r.PullHandler.OnFilePulled(name, outputDir, desc, descPath)
+}
+
+// deleteExtraneousFiles deletes the files in the output directory other than
+// the named files and the manifest config file.
+func deleteExtraneousFiles(root string, names []string, statusHandler status.PullHandler, po *pullOptions) error {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	if po.ManifestConfigRef != "" {
+		configPath, _, err := fileref.Parse(po.ManifestConfigRef, "")
+		if err != nil {
+			return err
+		}
+		names = append(names, configPath)
+	}
+	kept := make(map[string]bool)
+	parents := make(map[string]bool)
+	for _, name := range names {
+		path, err := outputPath(name, po)
+		if err != nil {
+			continue
+		}
+		if path, err = filepath.Abs(path); err != nil {
+			return err
+		}
+		kept[path] = true
+		for dir := filepath.Dir(path); dir != root && isWithin(root, dir); dir = filepath.Dir(dir) {
+			parents[dir] = true
+		}
+	}
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch {
+		case path == root || parents[path]:
+			return nil
+		case kept[path]:
+			if d.IsDir() {
+				// pulled directory
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			rel = path
+		}
+		if err := statusHandler.OnFileDeleted(rel); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+}
+
+// scanCommand returns the scan command for the directory dir. The placeholder
+// {dir} in the arguments of the command is replaced by dir, or dir is appended
+// as the last argument if there is no placeholder. The command is not run in a
+// shell.
+func scanCommand(ctx context.Context, command string, dir string) *exec.Cmd {
+	fields := strings.Fields(command)
+	args := make([]string, 0, len(fields))
+	replaced := false
+	for _, arg := range fields[1:] {
+		if strings.Contains(arg, "{dir}") {
+			arg = strings.ReplaceAll(arg, "{dir}", dir)
+			replaced = true
+		}
+		args = append(args, arg)
+	}
+	if !replaced {
+		args = append(args, dir)
+	}
+	return exec.CommandContext(ctx, fields[0], args...)
+}
+
+// scanStagedFiles runs the scan command on the staging directory dir, writing
+// the output of the command to w.
+func scanStagedFiles(ctx context.Context, command string, dir string, w io.Writer) error {
+	scanner := scanCommand(ctx, command, dir)
+	scanner.Stdout = w
+	scanner.Stderr = w
+	if err := scanner.Run(); err != nil {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("the scan command %q failed: %w", command, err),
+			Recommendation: "The pulled files are discarded. Check the output of the scan command above for the findings",
+		}
+	}
+	return nil
+}
+
+// scanPulledFiles runs the scan command on the output directory dir the files
+// are written to in place, writing the output of the command to w. The pulled
+// files at paths are removed if the command fails, so that rejected content is
+// not delivered.
+func scanPulledFiles(ctx context.Context, command string, dir string, paths []string, w io.Writer) error {
+	scanner := scanCommand(ctx, command, dir)
+	scanner.Stdout = w
+	scanner.Stderr = w
+	scanErr := scanner.Run()
+	if scanErr == nil {
+		return nil
+	}
+	var removeErrs []error
+	for _, path := range paths {
+		if err := os.RemoveAll(path); err != nil {
+			removeErrs = append(removeErrs, err)
+		}
+	}
+	if err := errors.Join(removeErrs...); err != nil {
+		return fmt.Errorf("failed to remove the files rejected by the scan command %q: %w", command, errors.Join(scanErr, err))
+	}
+	return &oerrors.Error{
+		Err:            fmt.Errorf("the scan command %q failed: %w", command, scanErr),
+		Recommendation: "The pulled files are removed. Check the output of the scan command above for the findings",
+	}
+}
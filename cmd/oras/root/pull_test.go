@@ -16,12 +16,32 @@ limitations under the License.
 package root
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras/cmd/oras/internal/display/metadata/text"
+	"oras.land/oras/cmd/oras/internal/display/status"
 	"oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/cmd/oras/internal/output"
+	"oras.land/oras/internal/split"
 )
 
 func Test_runPull_errType(t *testing.T) {
@@ -41,3 +61,504 @@ func Test_runPull_errType(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func Test_partialFileCleaner_Push(t *testing.T) {
+	root := t.TempDir()
+	store, err := file.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	existing := filepath.Join(root, "existing.txt")
+	if err := os.WriteFile(existing, []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cleaner := &partialFileCleaner{GraphTarget: store, root: root, po: &pullOptions{}}
+
+	data := "hello world"
+	newDesc := func(name string) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes("text/plain", []byte(data))
+		desc.Annotations = map[string]string{ocispec.AnnotationTitle: name}
+		return desc
+	}
+	// interrupted with truncated content
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := cleaner.Push(ctx, newDesc("partial.txt"), strings.NewReader(data[:5])); err == nil {
+		t.Fatal("partialFileCleaner.Push() error = nil, want error")
+	}
+	if _, err := os.Stat(filepath.Join(root, "partial.txt")); !os.IsNotExist(err) {
+		t.Errorf("partial file is not removed: %v", err)
+	}
+	if err := cleaner.Push(ctx, newDesc("existing.txt"), strings.NewReader(data[:5])); err == nil {
+		t.Fatal("partialFileCleaner.Push() error = nil, want error")
+	}
+	if _, err := os.Stat(existing); err != nil {
+		t.Errorf("existing file is removed: %v", err)
+	}
+}
+
+func Test_assembleFile(t *testing.T) {
+	ctx := context.Background()
+	data := []byte("hello split world")
+	src := filepath.Join(t.TempDir(), "src.bin")
+	if err := os.WriteFile(src, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	chunks := split.NewStore(5)
+	descs, err := chunks.Split("dir/model.bin", "", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := split.Group(descs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := t.TempDir()
+	po := &pullOptions{Output: output}
+	if err := assembleFile(ctx, chunks, files[0], po); err != nil {
+		t.Fatalf("assembleFile() error = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(output, "dir", "model.bin"))
+	if err != nil || string(got) != string(data) {
+		t.Fatalf("reassembled file = %q, %v, want %q", got, err, data)
+	}
+
+	po.KeepOldFiles = true
+	if err := assembleFile(ctx, chunks, files[0], po); !stderrors.Is(err, file.ErrOverwriteDisallowed) {
+		t.Errorf("assembleFile() error = %v, want ErrOverwriteDisallowed", err)
+	}
+
+	outside := files[0]
+	outside.Name = "../model.bin"
+	if err := assembleFile(ctx, chunks, outside, &pullOptions{Output: output}); !stderrors.Is(err, file.ErrPathTraversalDisallowed) {
+		t.Errorf("assembleFile() error = %v, want ErrPathTraversalDisallowed", err)
+	}
+}
+
+func Test_deleteExtraneousFiles(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"keep.txt", "dir/keep.txt", "dir/extra.txt", "pulled/any.txt", "old/extra.txt", "extra.txt", "config.json"} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	po := &pullOptions{Output: root, ManifestConfigRef: "config.json"}
+	names := []string{"keep.txt", "dir/keep.txt", "pulled", "../outside.txt"}
+	if err := deleteExtraneousFiles(root, names, status.NewTextPullHandler(output.NewPrinter(io.Discard, io.Discard)), po); err != nil {
+		t.Fatalf("deleteExtraneousFiles() error = %v", err)
+	}
+	var got []string
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if !d.IsDir() {
+			rel, _ := filepath.Rel(root, path)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	want := []string{"config.json", "dir/keep.txt", "keep.txt", "pulled/any.txt"}
+	if !slices.Equal(got, want) {
+		t.Errorf("remaining files = %v, want %v", got, want)
+	}
+}
+
+func Test_platformDirName(t *testing.T) {
+	tests := []struct {
+		platform ocispec.Platform
+		want     string
+	}{
+		{platform: ocispec.Platform{OS: "linux", Architecture: "amd64"}, want: "linux-amd64"},
+		{platform: ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, want: "linux-arm-v7"},
+		{platform: ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763.1040"}, want: "windows-amd64-10.0.17763.1040"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := platformDirName(&tt.platform); got != tt.want {
+				t.Errorf("platformDirName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// newPlatformIndexLayout returns an OCI image layout with an index tagged v1
+// of the manifests for linux/amd64, linux/arm/v7 and attestations.
+func newPlatformIndexLayout(t *testing.T) (*oci.Store, ocispec.Descriptor) {
+	t.Helper()
+	ctx := context.Background()
+	src, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifests []ocispec.Descriptor
+	for _, p := range []ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm", Variant: "v7"},
+		{OS: "unknown", Architecture: "unknown"},
+	} {
+		data := []byte(p.Architecture)
+		layer := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, data)
+		layer.Annotations = map[string]string{ocispec.AnnotationTitle: "firmware.bin"}
+		if err := src.Push(ctx, layer, bytes.NewReader(data)); err != nil {
+			t.Fatal(err)
+		}
+		desc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+			Layers: []ocispec.Descriptor{layer},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		desc.Platform = &p
+		manifests = append(manifests, desc)
+	}
+	index, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: manifests,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageIndex, index)
+	if err := src.Push(ctx, indexDesc, bytes.NewReader(index)); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, indexDesc, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	return src, indexDesc
+}
+
+func Test_pullAllPlatforms(t *testing.T) {
+	ctx := context.Background()
+	src, indexDesc := newPlatformIndexLayout(t)
+	root := t.TempDir()
+	opts := &pullOptions{Output: root}
+	opts.Reference = "v1"
+	opts.Printer = output.NewPrinter(io.Discard, io.Discard)
+	statusHandler := status.NewTextPullHandler(opts.Printer)
+	metadataHandler := text.NewPullHandler(opts.Printer)
+	got, err := pullAllPlatforms(ctx, src, oras.DefaultCopyOptions, metadataHandler, statusHandler, opts)
+	if err != nil {
+		t.Fatalf("pullAllPlatforms() error = %v", err)
+	}
+	if !content.Equal(got, indexDesc) {
+		t.Errorf("pullAllPlatforms() = %v, want %v", got, indexDesc)
+	}
+	for dir, want := range map[string]string{"linux-amd64": "amd64", "linux-arm-v7": "arm"} {
+		data, err := os.ReadFile(filepath.Join(root, dir, "firmware.bin"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Errorf("%s/firmware.bin = %q, want %q", dir, data, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "unknown-unknown")); !stderrors.Is(err, fs.ErrNotExist) {
+		t.Errorf("attestation manifests should not be pulled, got error %v", err)
+	}
+}
+
+func Test_pullFiles_scan(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the scan commands are POSIX commands")
+	}
+	ctx := context.Background()
+	src, _ := newPlatformIndexLayout(t)
+	root := t.TempDir()
+	existing := filepath.Join(root, "linux-amd64", "existing.txt")
+	if err := os.MkdirAll(filepath.Dir(existing), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("existing"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	pull := func(scanCommand string) error {
+		opts := &pullOptions{Output: root, ScanCommand: scanCommand}
+		opts.Reference = "v1"
+		opts.Printer = output.NewPrinter(io.Discard, io.Discard)
+		statusHandler := status.NewTextPullHandler(opts.Printer)
+		metadataHandler := text.NewPullHandler(opts.Printer)
+		_, err := pullAllPlatforms(ctx, src, oras.DefaultCopyOptions, metadataHandler, statusHandler, opts)
+		return err
+	}
+
+	// the rejected files are discarded without touching the existing files
+	if err := pull("test -f {dir}/missing"); err == nil {
+		t.Fatal("pullAllPlatforms() error = nil, want scan failure")
+	}
+	if _, err := os.Stat(existing); err != nil {
+		t.Errorf("existing file is removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "linux-amd64", "firmware.bin")); !stderrors.Is(err, fs.ErrNotExist) {
+		t.Errorf("rejected file is delivered, got error %v", err)
+	}
+	if staged, _ := filepath.Glob(filepath.Join(root, "*", stagingPattern)); len(staged) != 0 {
+		t.Errorf("staging directories are left behind: %v", staged)
+	}
+
+	// the staged files are scanned before being moved into place
+	if err := pull("test -f {dir}/firmware.bin"); err != nil {
+		t.Fatalf("pullAllPlatforms() error = %v", err)
+	}
+	for _, path := range []string{existing, filepath.Join(root, "linux-amd64", "firmware.bin")} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("failed to stat %s: %v", path, err)
+		}
+	}
+}
+
+func Test_pullFiles_absoluteTitle(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	src, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("hello world")
+	name := filepath.Join(root, "dir", "f.txt")
+	layer := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, data)
+	layer.Annotations = map[string]string{ocispec.AnnotationTitle: name}
+	if err := src.Push(ctx, layer, bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	desc, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Tag(ctx, desc, "v1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// absolute paths in the output directory are written via the staging
+	// directory
+	opts := &pullOptions{Output: root}
+	opts.Reference = "v1"
+	opts.Printer = output.NewPrinter(io.Discard, io.Discard)
+	statusHandler := status.NewTextPullHandler(opts.Printer)
+	metadataHandler := text.NewPullHandler(opts.Printer)
+	if _, err := pullFiles(ctx, src, oras.DefaultCopyOptions, metadataHandler, statusHandler, opts); err != nil {
+		t.Fatalf("pullFiles() error = %v", err)
+	}
+	got, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("pulled content = %q, want %q", got, data)
+	}
+	if staged, _ := filepath.Glob(filepath.Join(root, stagingPattern)); len(staged) != 0 {
+		t.Errorf("staging directories are left behind: %v", staged)
+	}
+
+	// absolute paths outside the output directory are still rejected
+	opts.Output = t.TempDir()
+	if _, err := pullFiles(ctx, src, oras.DefaultCopyOptions, metadataHandler, statusHandler, opts); !stderrors.Is(err, file.ErrPathTraversalDisallowed) {
+		t.Errorf("pullFiles() error = %v, want %v", err, file.ErrPathTraversalDisallowed)
+	}
+}
+
+func Test_selectPlatform(t *testing.T) {
+	src, _ := newPlatformIndexLayout(t)
+	tests := []struct {
+		name     string
+		platform *ocispec.Platform
+		want     string
+		wantErr  bool
+	}{
+		{name: "wildcard", platform: &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "*"}, want: "linux/arm/v7"},
+		{name: "wildcard OS", platform: &ocispec.Platform{OS: "*", Architecture: "amd64"}, want: "linux/amd64"},
+		{name: "no match", platform: &ocispec.Platform{OS: "windows", Architecture: "*"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &pullOptions{}
+			opts.Reference = "v1"
+			opts.Platform.Platform = tt.platform
+			var out strings.Builder
+			err := selectPlatform(context.Background(), src, &out, opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectPlatform() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if want := "Selected platform " + tt.want + " (" + opts.Reference + ")"; !strings.Contains(out.String(), want) {
+				t.Errorf("selectPlatform() reported %q, want the selected manifest %s", out.String(), opts.Reference)
+			}
+		})
+	}
+}
+
+func Test_scanCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    []string
+	}{
+		{
+			name:    "placeholder",
+			command: "scan -r {dir} --quiet",
+			want:    []string{"scan", "-r", "out", "--quiet"},
+		},
+		{
+			name:    "placeholder in argument",
+			command: "scan --path={dir}",
+			want:    []string{"scan", "--path=out"},
+		},
+		{
+			name:    "no placeholder",
+			command: "  scan  -r ",
+			want:    []string{"scan", "-r", "out"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scanCommand(context.Background(), tt.command, "out").Args; !slices.Equal(got, tt.want) {
+				t.Errorf("scanCommand() args = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_scanPulledFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("scan commands are unix commands")
+	}
+	newPulled := func(t *testing.T) (string, []string) {
+		dir := t.TempDir()
+		pulled := filepath.Join(dir, "pulled")
+		if err := os.WriteFile(pulled, []byte("pulled"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "existing"), []byte("existing"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return dir, []string{pulled}
+	}
+
+	t.Run("scan passed", func(t *testing.T) {
+		dir, paths := newPulled(t)
+		if err := scanPulledFiles(context.Background(), "test -f {dir}/pulled", dir, paths, io.Discard); err != nil {
+			t.Fatalf("scanPulledFiles() error = %v", err)
+		}
+		if _, err := os.Stat(paths[0]); err != nil {
+			t.Errorf("pulled file should be kept: %v", err)
+		}
+	})
+
+	t.Run("scan failed", func(t *testing.T) {
+		dir, paths := newPulled(t)
+		var out bytes.Buffer
+		err := scanPulledFiles(context.Background(), "ls {dir}/missing", dir, paths, &out)
+		var cmdErr *errors.Error
+		if !stderrors.As(err, &cmdErr) {
+			t.Fatalf("scanPulledFiles() error = %v, want %T", err, cmdErr)
+		}
+		if out.Len() == 0 {
+			t.Error("output of the scan command is not written")
+		}
+		if _, err := os.Stat(paths[0]); !stderrors.Is(err, fs.ErrNotExist) {
+			t.Errorf("pulled file should be removed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "existing")); err != nil {
+			t.Errorf("existing file should be kept: %v", err)
+		}
+	})
+}
+
+func Test_pullOptions_parseOutputTemplate(t *testing.T) {
+	newCmd := func(t *testing.T, args ...string) (*cobra.Command, *pullOptions) {
+		opts := &pullOptions{}
+		cmd := &cobra.Command{}
+		option.ApplyFlags(opts, cmd.Flags())
+		cmd.Flags().StringVarP(&opts.OutputTemplate, "output-template", "", "", "")
+		cmd.Flags().StringVarP(&opts.Output, "output", "o", "out", "")
+		cmd.Flags().IntVarP(&opts.artifactConcurrency, "artifact-concurrency", "", 3, "")
+		for _, flag := range []string{"sync", "config"} {
+			cmd.Flags().String(flag, "", "")
+		}
+		cmd.Flags().Bool("all-platforms", false, "")
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		opts.RawReference = "localhost:5000/hello:v1"
+		opts.Format.Type = option.FormatTypeText.Name
+		if err := opts.Target.Parse(cmd); err != nil {
+			t.Fatal(err)
+		}
+		return cmd, opts
+	}
+	refs := []string{"localhost:5000/hello:v1", "localhost:5000/team/world@sha256:2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834"}
+
+	cmd, opts := newCmd(t, "--output-template", "{{.Repo}}-{{.Tag}}{{.Digest}}")
+	if err := opts.parseOutputTemplate(cmd, refs); err != nil {
+		t.Fatalf("parseOutputTemplate() error = %v", err)
+	}
+	want := []string{
+		filepath.Join("out", "hello-v1"),
+		filepath.Join("out", "team", "world-sha256:2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834"),
+	}
+	if len(opts.pulls) != len(want) {
+		t.Fatalf("parseOutputTemplate() got %d pulls, want %d", len(opts.pulls), len(want))
+	}
+	for i, pull := range opts.pulls {
+		if pull.Output != want[i] || pull.RawReference != refs[i] {
+			t.Errorf("pulls[%d] = %q into %q, want %q into %q", i, pull.RawReference, pull.Output, refs[i], want[i])
+		}
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no template for multiple artifacts", nil},
+		{"same output directory", []string{"--output-template", "{{.Registry}}"}},
+		{"empty output directory", []string{"--output-template", "{{.Tag}}"}},
+		{"output directory out of --output", []string{"--output-template", "../{{.Repo}}"}},
+		{"unknown field", []string{"--output-template", "{{.Unknown}}"}},
+		{"invalid template", []string{"--output-template", "{{.Repo"}},
+		{"with --sync", []string{"--output-template", "{{.Repo}}", "--sync", "dir"}},
+		{"invalid concurrency", []string{"--output-template", "{{.Repo}}", "--artifact-concurrency", "0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, opts := newCmd(t, tt.args...)
+			if err := opts.parseOutputTemplate(cmd, refs); err == nil {
+				t.Error("parseOutputTemplate() error = nil, want error")
+			}
+		})
+	}
+}
+
+func Test_graphLevels_successorLevel(t *testing.T) {
+	node := func(s string) ocispec.Descriptor {
+		return content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte(s))
+	}
+	root, child, grandchild, shared := node("root"), node("child"), node("grandchild"), node("shared")
+	var levels graphLevels
+	if got := levels.successorLevel(root, []ocispec.Descriptor{child}); got != 1 {
+		t.Errorf("successorLevel(root) = %d, want 1", got)
+	}
+	if got := levels.successorLevel(child, []ocispec.Descriptor{grandchild, shared}); got != 2 {
+		t.Errorf("successorLevel(child) = %d, want 2", got)
+	}
+	// shared is also a successor of root
+	if got := levels.successorLevel(root, []ocispec.Descriptor{shared}); got != 1 {
+		t.Errorf("successorLevel(root) = %d, want 1", got)
+	}
+	if got := levels.successorLevel(shared, nil); got != 2 {
+		t.Errorf("successorLevel(shared) = %d, want 2", got)
+	}
+	if got := levels.successorLevel(grandchild, nil); got != 3 {
+		t.Errorf("successorLevel(grandchild) = %d, want 3", got)
+	}
+}
@@ -17,10 +17,15 @@ package root
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
@@ -32,27 +37,56 @@ import (
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
 	"oras.land/oras/cmd/oras/internal/display/status"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/fileref"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/bytesize"
 	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/delta"
+	"oras.land/oras/internal/fastcdc"
+	"oras.land/oras/internal/graph"
 	"oras.land/oras/internal/listener"
+	"oras.land/oras/internal/policy"
 	"oras.land/oras/internal/registryutil"
+	"oras.land/oras/internal/split"
 )
 
 type pushOptions struct {
 	option.Common
+	option.DescriptorFile
 	option.Packer
 	option.ArtifactPlatform
 	option.ImageSpec
 	option.Target
 	option.Format
+	option.TempDir
 	option.Terminal
+	option.Provenance
+	option.History
+	option.Expiry
+	option.Policy
+	option.GitHubActions
+	option.OutputFD
 
 	extraRefs         []string
 	manifestConfigRef string
 	artifactType      string
 	concurrency       int
+	splitSize         string
+	chunkSize         int64
+	cdcChunkSize      string
+	chunking          *split.Chunking
+	deltaFrom         string
+	appendTo          string
+	helmChart         string
+	wasm              bool
+	model             bool
+	force             bool
+	maxSize           string
+	maxBlobSize       string
+	maxSizeBytes      int64
+	maxBlobSizeBytes  int64
 	// Deprecated: verbose is deprecated and will be removed in the future.
 	verbose bool
 }
@@ -64,6 +98,8 @@ func pushCmd() *cobra.Command {
 		Short: "Push files to a registry or an OCI image layout",
 		Long: `Push files to a registry or an OCI image layout
 
+Directories are packed into gzipped tar archives. Sparse files in directories, such as disk images, are archived in the GNU sparse format so that their holes are neither uploaded nor filled by "oras pull".
+
 Example - Push file "hi.txt" with media type "application/vnd.oci.image.layer.v1.tar" (default):
   oras push localhost:5000/hello:v1 hi.txt
 
@@ -82,12 +118,18 @@ Example - Push file with colon in name "hi:txt" with the default media type:
 Example - Push file "hi.txt" with artifact type "application/vnd.example+type":
   oras push --artifact-type application/vnd.example+type localhost:5000/hello:v1 hi.txt
 
+Example - [Experimental] Push file "hi.txt" although its artifact type or media type violates the validation policy of the registry in the configuration file:
+  oras push --force localhost:5000/hello:v1 hi.txt
+
 Example - Push file "hi.txt" with config type "application/vnd.me.config":
   oras push --image-spec v1.0 --artifact-type application/vnd.me.config localhost:5000/hello:v1 hi.txt
 
 Example - Push file "hi.txt" with the custom manifest config "config.json" of the custom media type "application/vnd.me.config":
   oras push --config config.json:application/vnd.me.config localhost:5000/hello:v1 hi.txt
 
+Example - Push file "hi.txt" with the manifest config read from stdin, whose media type is inferred if not specified:
+  generate-config | oras push --config - localhost:5000/hello:v1 hi.txt
+
 Example - [Experimental] Push file "hi.txt" and format output in JSON:
   oras push localhost:5000/hello:v1 hi.txt --format json
 
@@ -109,12 +151,63 @@ Example - Push repository with manifest annotation file:
 Example - [Experimental] Push artifact to repository with platform:
   oras push --artifact-platform linux/arm/v5 localhost:5000/hello:v1
 
+Example - [Experimental] Push file "hi.txt" with source, revision and created annotations detected from git or CI:
+  oras push --provenance localhost:5000/hello:v1 hi.txt
+
+Example - [Experimental] Push file "hi.txt" and attach a SLSA provenance referrer:
+  oras push --provenance-referrer localhost:5000/hello:v1 hi.txt
+
+Example - [Experimental] Push file "hi.txt" to the tag "latest" and record the movement of the tag, shown by "oras history":
+  oras push --record-history localhost:5000/hello:latest hi.txt
+
+Example - [Experimental] Push file "hi.txt" which expires in 30 days and can be removed by "oras prune":
+  oras push --expires-in 30d localhost:5000/hello:v1 hi.txt
+
+Example - [Experimental] Push file "model.bin" split into blobs of at most 4GB, reassembled by "oras pull":
+  oras push --split-size 4GB localhost:5000/hello:v1 model.bin
+
+Example - [Experimental] Push files only if no file exceeds 5GB and the artifact does not exceed 20GB in total:
+  oras push --max-blob-size 5GB --max-size 20GB localhost:5000/hello:v1 model-1.bin model-2.bin
+
+Example - [Experimental] Push files in a GitHub Actions workflow, writing the digest and reference of the artifact to the step outputs:
+  oras push --gha localhost:5000/hello:v1 hi.txt
+
+Example - [Experimental] Push files with the status events written to file descriptor 3 and the JSON metadata to file descriptor 4:
+  oras push --status-fd 3 --metadata-fd 4 localhost:5000/hello:v1 hi.txt 3>status.jsonl 4>metadata.json
+
+Example - [Experimental] Push files only if allowed by the Rego policy 'policy.rego', evaluated with the OPA command line tool:
+  oras push --policy policy.rego localhost:5000/hello:v1 hi.txt
+
+Example - [Experimental] Push file "data.bin" as content-defined chunks of 4MB on average, shared with other versions:
+  oras push --cdc-chunk-size 4MB localhost:5000/hello:v2 data.bin
+
+Example - [Experimental] Push file "app.bin" as a binary delta against the same file in 'localhost:5000/hello:v1':
+  oras push --delta-from v1 localhost:5000/hello:v2 app.bin
+
+Example - [Experimental] Add file "bye.txt" to the artifact 'localhost:5000/hello:v1' and move the tag 'v1' to the result, without uploading the existing files again:
+  oras push --append v1 localhost:5000/hello:v1 bye.txt
+
+Example - [Experimental] Push the Helm chart "mychart-0.1.0.tgz" with the Helm media types, tagged with the chart version:
+  oras push --helm-chart mychart-0.1.0.tgz localhost:5000/charts/mychart
+
+Example - [Experimental] Push the WASM component "app.wasm" with the WASM artifact media types:
+  oras push --wasm localhost:5000/hello:v1 app.wasm
+
+Example - [Experimental] Push the model "model.gguf" and its model card, annotated with the format, parameter count, quantization and license:
+  oras push --model localhost:5000/models/llama:v1 model.gguf README.md
+
 Example - Push file "hi.txt" with multiple tags:
   oras push localhost:5000/hello:tag1,tag2,tag3 hi.txt
 
 Example - Push file "hi.txt" with multiple tags and concurrency level tuned:
   oras push --concurrency 6 localhost:5000/hello:tag1,tag2,tag3 hi.txt
 
+Example - [Experimental] Push directory "data", packing it in the directory 'scratch' instead of the system temporary directory:
+  oras push --tmpdir scratch localhost:5000/hello:v1 data
+
+Example - [Experimental] Push directory "app" with the extended attributes of its files, such as the file capabilities of binaries:
+  oras push --xattrs localhost:5000/hello:v1 app
+
 Example - Push file "hi.txt" into an OCI image layout folder 'layout-dir' with tag 'test':
   oras push --oci-layout layout-dir:test hi.txt
 
@@ -127,7 +220,8 @@ Example - Push file "hi.txt" into an OCI image layout folder 'layout-dir' with t
 			opts.RawReference = refs[0]
 			opts.extraRefs = refs[1:]
 			opts.FileRefs = args[1:]
-			if err := option.Parse(cmd, &opts); err != nil {
+			err := option.Parse(cmd, &opts)
+			if err != nil {
 				return err
 			}
 			opts.DisableTTY(opts.Debug, false)
@@ -145,10 +239,102 @@ Example - Push file "hi.txt" into an OCI image layout folder 'layout-dir' with t
 					}
 				}
 			}
+			if opts.splitSize != "" {
+				if opts.chunkSize, err = bytesize.Parse(opts.splitSize); err != nil {
+					return &oerrors.Error{
+						Err:            err,
+						Recommendation: `specify the split size in bytes or with a unit, e.g. "4GB"`,
+					}
+				}
+			}
+			for _, limit := range []struct {
+				flag  string
+				value string
+				bytes *int64
+			}{
+				{"max-size", opts.maxSize, &opts.maxSizeBytes},
+				{"max-blob-size", opts.maxBlobSize, &opts.maxBlobSizeBytes},
+			} {
+				if limit.value == "" {
+					continue
+				}
+				if *limit.bytes, err = bytesize.Parse(limit.value); err != nil {
+					return &oerrors.Error{
+						Err:            fmt.Errorf("invalid --%s: %w", limit.flag, err),
+						Recommendation: `specify the size limit in bytes or with a unit, e.g. "4GB"`,
+					}
+				}
+			}
+			if opts.cdcChunkSize != "" {
+				avgSize, err := bytesize.Parse(opts.cdcChunkSize)
+				if err == nil && avgSize > fastcdc.MaxAvgSize {
+					err = fmt.Errorf("average chunk size %s exceeds 256MB", opts.cdcChunkSize)
+				}
+				if err == nil {
+					var chunking split.Chunking
+					chunking, err = split.NewChunking(int(avgSize))
+					opts.chunking = &chunking
+				}
+				if err != nil {
+					return &oerrors.Error{
+						Err:            err,
+						Recommendation: `specify a power of 2 as the average chunk size, e.g. "4MB"`,
+					}
+				}
+			}
+			if path, _, _ := fileref.Parse(opts.manifestConfigRef, ""); path == "-" {
+				if err := option.CheckStdinConflict(cmd.Flags()); err != nil {
+					return err
+				}
+			}
+			if opts.helmChart != "" {
+				if len(opts.FileRefs) != 0 {
+					return &oerrors.Error{
+						Err:            errors.New("files cannot be pushed with a Helm chart"),
+						Recommendation: "Remove the file arguments or --helm-chart",
+					}
+				}
+				for _, flag := range []string{"config", "artifact-type", "artifact-platform", "image-spec", "cdc-chunk-size", "split-size", "append", "delta-from"} {
+					if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "helm-chart", flag); err != nil {
+						return err
+					}
+				}
+				// Helm charts are image-spec v1.0 manifests with the Helm config
+				opts.Flag = option.ImageSpecV1_0
+				opts.PackVersion = oras.PackManifestVersion1_0
+			}
+			if opts.wasm {
+				for _, flag := range []string{"config", "artifact-type", "artifact-platform", "image-spec", "cdc-chunk-size", "split-size", "append", "delta-from", "helm-chart"} {
+					if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "wasm", flag); err != nil {
+						return err
+					}
+				}
+				// WASM artifacts are image-spec v1.0 manifests with the WASM config
+				opts.Flag = option.ImageSpecV1_0
+				opts.PackVersion = oras.PackManifestVersion1_0
+			}
+			for _, flag := range []string{"helm-chart", "wasm"} {
+				if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "model", flag); err != nil {
+					return err
+				}
+			}
 			configAndPlatform := []string{"config", "artifact-platform"}
 			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), configAndPlatform...); err != nil {
 				return err
 			}
+			for _, flags := range [][]string{
+				{"split-size", "cdc-chunk-size"},
+				{"config", "cdc-chunk-size"},
+				{"artifact-platform", "cdc-chunk-size"},
+				{"append", "config"},
+				{"append", "artifact-platform"},
+				{"append", "cdc-chunk-size"},
+				{"append", "delta-from"},
+			} {
+				if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), flags...); err != nil {
+					return err
+				}
+			}
 
 			switch opts.PackVersion {
 			case oras.PackManifestVersion1_0:
@@ -167,20 +353,44 @@ Example - Push file "hi.txt" into an OCI image layout folder 'layout-dir' with t
 			return runPush(cmd, &opts)
 		},
 	}
-	cmd.Flags().StringVarP(&opts.manifestConfigRef, "config", "", "", "`path` of image config file")
+	cmd.Flags().StringVarP(&opts.manifestConfigRef, "config", "", "", "`path` of image config file, use - to read from stdin, the media type is inferred from the content and extension if not specified")
 	cmd.Flags().StringVarP(&opts.artifactType, "artifact-type", "", "", "artifact type")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	cmd.Flags().BoolVarP(&opts.force, "force", "", false, "[Experimental] push the artifact even if it violates the validation policy configured for the registry")
+	cmd.Flags().StringVarP(&opts.cdcChunkSize, "cdc-chunk-size", "", "", "[Experimental] split files into content-defined chunks of `size` on average (e.g. 4MB), shared across versions and reassembled by oras pull")
+	cmd.Flags().StringVarP(&opts.deltaFrom, "delta-from", "", "", "[Experimental] push files as binary deltas against the files of the same names in the artifact of the `tag or digest` in the same repository, applied by oras pull --apply-delta")
+	cmd.Flags().StringVarP(&opts.appendTo, "append", "", "", "[Experimental] push the files appended to the layers of the artifact of the `tag or digest` in the same repository, keeping its config, subject and annotations")
+	cmd.Flags().StringVarP(&opts.helmChart, "helm-chart", "", "", "[Experimental] push the packaged Helm chart at `path` with the Helm media types and the annotations from its Chart.yaml, tagged with the chart version if no tag is specified")
+	cmd.Flags().BoolVarP(&opts.wasm, "wasm", "", false, "[Experimental] push the files as WASM modules or components with the media types of the CNCF WASM OCI artifact layout")
+	cmd.Flags().BoolVarP(&opts.model, "model", "", false, "[Experimental] annotate the GGUF, safetensors and ONNX model files with the format, parameter count and quantization, and the artifact with the license from the model card README.md or the model files")
+	cmd.Flags().StringVarP(&opts.maxSize, "max-size", "", "", "[Experimental] abort the push before uploading if the artifact exceeds `size` (e.g. 20GB) in total")
+	cmd.Flags().StringVarP(&opts.maxBlobSize, "max-blob-size", "", "", "[Experimental] abort the push before uploading if any file or blob exceeds `size` (e.g. 5GB)")
+	cmd.Flags().StringVarP(&opts.splitSize, "split-size", "", "", "[Experimental] split files larger than `size` (e.g. 4GB) into multiple blobs, which are reassembled by oras pull")
 	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", true, "print status output for unnamed blobs")
 	_ = cmd.Flags().MarkDeprecated("verbose", "and will be removed in a future release.")
 	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	opts.EnableDryRunFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
-	return oerrors.Command(cmd, &opts.Target)
+	return opts.GitHubActions.Command(oerrors.Command(cmd, &opts.Target))
 }
 
 func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	if cmd.Flags().Changed("artifact-type") {
+		if err := validateArtifactType(opts.artifactType, logger); err != nil {
+			return err
+		}
+	}
 
 	// prepare pack
+	opts.Annotations = opts.ApplyAnnotations(opts.Annotations)
+	opts.Annotations = opts.ApplyExpiry(opts.Annotations, time.Now())
+	if opts.model {
+		var err error
+		if opts.Annotations, err = annotateModelFiles(opts.FileRefs, opts.Annotations, logger); err != nil {
+			return err
+		}
+	}
 	packOpts := oras.PackManifestOptions{
 		ConfigAnnotations:   opts.Annotations[option.AnnotationConfig],
 		ManifestAnnotations: opts.Annotations[option.AnnotationManifest],
@@ -190,12 +400,13 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 		return err
 	}
 	defer func() { _ = store.Close() }()
-	if opts.manifestConfigRef != "" {
-		path, cfgMediaType, err := fileref.Parse(opts.manifestConfigRef, oras.MediaTypeUnknownConfig)
-		if err != nil {
+	if opts.helmChart != "" {
+		if packOpts.ConfigDescriptor, err = loadHelmChart(ctx, store, opts); err != nil {
 			return err
 		}
-		desc, err := addFile(ctx, store, option.AnnotationConfig, cfgMediaType, path)
+		packOpts.ManifestAnnotations = opts.Annotations[option.AnnotationManifest]
+	} else if opts.manifestConfigRef != "" {
+		desc, err := loadConfig(ctx, store, opts.manifestConfigRef, os.Stdin)
 		if err != nil {
 			return err
 		}
@@ -221,16 +432,79 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 		packOpts.ConfigDescriptor = &desc
 	}
 	memoryStore := memory.New()
-	union := contentutil.MultiReadOnlyTarget(memoryStore, store)
+	var chunks *split.Store
+	sources := []oras.ReadOnlyTarget{memoryStore, store}
+	switch {
+	case opts.chunking != nil:
+		chunks = split.NewChunkingStore(*opts.chunking)
+		sources = append(sources, chunks)
+	case opts.chunkSize > 0:
+		chunks = split.NewStore(opts.chunkSize)
+		sources = append(sources, chunks)
+	}
+	var deltas *deltaStore
+	if opts.deltaFrom != "" {
+		if deltas, err = newDeltaStore(); err != nil {
+			return err
+		}
+		defer func() { _ = deltas.Close() }()
+		sources = append(sources, deltas)
+	}
+	union := contentutil.MultiReadOnlyTarget(sources...)
 	statusHandler, metadataHandler, err := display.NewPushHandler(opts.Printer, opts.Format, opts.TTY, union)
 	if err != nil {
 		return err
 	}
-	descs, err := loadFiles(ctx, store, opts.Annotations, opts.FileRefs, statusHandler)
+	statusHandler, metadataHandler = display.WithPushOutputFD(statusHandler, metadataHandler, &opts.OutputFD)
+	var wasmTargetOS string
+	if opts.wasm {
+		if opts.FileRefs, wasmTargetOS, err = prepareWasmFiles(opts.FileRefs); err != nil {
+			return err
+		}
+	}
+	if err := checkPackingSpace(ctx, &opts.TempDir, opts.FileRefs, logger); err != nil {
+		return err
+	}
+	packer := dirPacker{xattrs: opts.Xattrs}
+	defer func() { _ = packer.Close() }()
+	descs, err := loadFiles(ctx, store, chunks, &packer, opts.Annotations, opts.FileRefs, statusHandler)
 	if err != nil {
 		return err
 	}
 	packOpts.Layers = descs
+	if opts.wasm {
+		if packOpts.ManifestAnnotations == nil {
+			packOpts.ManifestAnnotations = make(map[string]string)
+		}
+		if _, ok := packOpts.ManifestAnnotations[ocispec.AnnotationTitle]; !ok {
+			path, _, _ := fileref.Parse(opts.FileRefs[0], "")
+			packOpts.ManifestAnnotations[ocispec.AnnotationTitle] = wasmModuleName(path)
+		}
+		desc, err := pushWasmConfig(ctx, store, descs, wasmTargetOS, packOpts.ManifestAnnotations)
+		if err != nil {
+			return err
+		}
+		packOpts.ConfigDescriptor = &desc
+	}
+	if opts.chunking != nil {
+		// store the chunk index as the manifest config
+		if opts.Flag == option.ImageSpecV1_0 && opts.artifactType != "" {
+			return &oerrors.Error{
+				Err:            errors.New(`artifact type cannot be customized for OCI image-spec v1.0 when content-defined chunking is enabled`),
+				Recommendation: "consider using image spec v1.1 or remove --artifact-type",
+			}
+		}
+		blob, err := json.Marshal(chunks.Index())
+		if err != nil {
+			return err
+		}
+		desc := content.NewDescriptorFromBytes(split.MediaTypeChunkIndex, blob)
+		if err := store.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+			return err
+		}
+		desc.Annotations = packOpts.ConfigAnnotations
+		packOpts.ConfigDescriptor = &desc
+	}
 	pack := func() (ocispec.Descriptor, error) {
 		root, err := oras.PackManifest(ctx, memoryStore, opts.PackVersion, opts.artifactType, packOpts)
 		if err != nil {
@@ -247,6 +521,42 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	if err != nil {
 		return err
 	}
+	if opts.appendTo != "" {
+		base, err := fetchAppendBase(ctx, originalDst, opts.appendTo)
+		if err != nil {
+			return err
+		}
+		if packOpts.Layers, err = appendLayers(base, packOpts.Layers); err != nil {
+			return err
+		}
+		packOpts.ManifestAnnotations = appendAnnotations(base, packOpts.ManifestAnnotations)
+		packOpts.ConfigDescriptor = &base.Config
+		packOpts.Subject = base.Subject
+		if !cmd.Flags().Changed("artifact-type") {
+			opts.artifactType = base.ArtifactType
+		}
+		if !cmd.Flags().Changed("image-spec") && base.ArtifactType == "" && base.Subject == nil && base.Config.MediaType != ocispec.MediaTypeEmptyJSON {
+			// keep the image-spec v1.0 artifact without artifact type
+			opts.PackVersion = oras.PackManifestVersion1_0
+		}
+	}
+	if opts.deltaFrom != "" {
+		layers, baseManifest, err := deltaLayers(ctx, originalDst, opts.deltaFrom, union, deltas, packOpts.Layers)
+		if err != nil {
+			return err
+		}
+		packOpts.Layers = layers
+		if baseManifest != nil {
+			if packOpts.ManifestAnnotations == nil {
+				packOpts.ManifestAnnotations = make(map[string]string)
+			}
+			packOpts.ManifestAnnotations[delta.AnnotationFrom] = baseManifest.Digest.String()
+		}
+	}
+	tagHistory := newTagHistory(&opts.History, "push")
+	if err := tagHistory.resolve(ctx, originalDst, append([]string{opts.Reference}, opts.extraRefs...)...); err != nil {
+		return err
+	}
 	dst, stopTrack, err := statusHandler.TrackTarget(originalDst)
 	if err != nil {
 		return err
@@ -256,7 +566,19 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	copyOptions.OnCopySkipped = statusHandler.OnCopySkipped
 	copyOptions.PreCopy = statusHandler.PreCopy
 	copyOptions.PostCopy = statusHandler.PostCopy
+	var dedup blobDeduplicator
+	dedup.track(&copyOptions.CopyGraphOptions)
+	copyOptions.FindSuccessors = dedup.findSuccessors(nil)
 	copyWithScopeHint := func(root ocispec.Descriptor) error {
+		if err := checkSizeLimits(ctx, memoryStore, root, opts.maxBlobSizeBytes, opts.maxSizeBytes); err != nil {
+			return err
+		}
+		if err := validateArtifact(ctx, memoryStore, root, &opts.Target, opts.force, logger); err != nil {
+			return err
+		}
+		if err := evaluatePolicy(ctx, &opts.Policy, &policy.Input{Command: "push", Reference: opts.RawReference}, memoryStore, root); err != nil {
+			return err
+		}
 		// add both pull and push scope hints for dst repository
 		// to save potential push-scope token requests during copy
 		ctx = registryutil.WithScopeHint(ctx, originalDst, auth.ActionPull, auth.ActionPush)
@@ -266,6 +588,9 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 		} else {
 			_, err = oras.Copy(ctx, union, root.Digest.String(), dst, tag, copyOptions)
 		}
+		if err == nil && opts.ProvenanceReferrer {
+			_, err = pushProvenance(ctx, memoryStore, dst, opts.Path, root, opts.Info, copyOptions.CopyGraphOptions)
+		}
 		return oerrors.UnwrapCopyError(err) // we don't need the CopyError information so we unwrap it here
 	}
 
@@ -291,6 +616,15 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 			return err
 		}
 	}
+	if err := tagHistory.record(ctx, originalDst, root); err != nil {
+		return err
+	}
+	if err := opts.WriteDescriptor(root); err != nil {
+		return err
+	}
+	if err := opts.ReportResult("Pushed", opts.Path, root); err != nil {
+		return err
+	}
 
 	err = metadataHandler.Render()
 	if err != nil {
@@ -301,6 +635,50 @@ func runPush(cmd *cobra.Command, opts *pushOptions) error {
 	return opts.ExportManifest(ctx, memoryStore, root)
 }
 
+// checkSizeLimits checks the sizes of the manifest root and the blobs it
+// references against the per blob limit maxBlobSize and the total limit
+// maxSize, which are not checked if not positive. All the violations are
+// reported in the returned error.
+func checkSizeLimits(ctx context.Context, fetcher content.Fetcher, root ocispec.Descriptor, maxBlobSize, maxSize int64) error {
+	if maxBlobSize <= 0 && maxSize <= 0 {
+		return nil
+	}
+	nodes, _, config, err := graph.Successors(ctx, fetcher, root)
+	if err != nil {
+		return err
+	}
+	if config != nil {
+		nodes = append(nodes, *config)
+	}
+	var violations []string
+	total := root.Size
+	counted := make(map[digest.Digest]bool)
+	for _, node := range nodes {
+		if counted[node.Digest] {
+			continue
+		}
+		counted[node.Digest] = true
+		total += node.Size
+		if maxBlobSize > 0 && node.Size > maxBlobSize {
+			name := node.Annotations[ocispec.AnnotationTitle]
+			if name == "" {
+				name = node.Digest.String()
+			}
+			violations = append(violations, fmt.Sprintf("%s is %s, exceeding the blob size limit %s", name, humanize.ToBytes(node.Size), humanize.ToBytes(maxBlobSize)))
+		}
+	}
+	if maxSize > 0 && total > maxSize {
+		violations = append(violations, fmt.Sprintf("the artifact is %s in total, exceeding the size limit %s", humanize.ToBytes(total), humanize.ToBytes(maxSize)))
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &oerrors.Error{
+		Err:            fmt.Errorf("the artifact exceeds the size limits:\n  %s", strings.Join(violations, "\n  ")),
+		Recommendation: "Remove the large files, split them with --split-size, or raise the limits",
+	}
+}
+
 func doPush(dst oras.Target, stopTrack status.StopTrackTargetFunc, pack packFunc, copy copyFunc) (ocispec.Descriptor, error) {
 	defer func() {
 		_ = stopTrack()
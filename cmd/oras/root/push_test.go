@@ -17,9 +17,14 @@ package root
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"testing"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 )
@@ -41,3 +46,87 @@ func Test_runPush_errType(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func Test_checkSizeLimits(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	layers := []ocispec.Descriptor{
+		{MediaType: ocispec.MediaTypeImageLayer, Digest: "sha256:0000000000000000000000000000000000000000000000000000000000000001", Size: 1000, Annotations: map[string]string{ocispec.AnnotationTitle: "large.bin"}},
+		{MediaType: ocispec.MediaTypeImageLayer, Digest: "sha256:0000000000000000000000000000000000000000000000000000000000000002", Size: 100, Annotations: map[string]string{ocispec.AnnotationTitle: "small.bin"}},
+	}
+	root, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{Layers: layers})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		maxBlobSize int64
+		maxSize     int64
+		wantErr     []string
+	}{
+		{name: "no limits"},
+		{name: "within limits", maxBlobSize: 1000, maxSize: 10000},
+		{name: "blob too large", maxBlobSize: 500, wantErr: []string{"large.bin"}},
+		{name: "total too large", maxSize: 1000, wantErr: []string{"in total"}},
+		{name: "both", maxBlobSize: 50, maxSize: 1000, wantErr: []string{"large.bin", "small.bin", "in total"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSizeLimits(ctx, store, root, tt.maxBlobSize, tt.maxSize)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("checkSizeLimits() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("checkSizeLimits() error = nil, want error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("checkSizeLimits() error = %v, want %q reported", err, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_appendLayers(t *testing.T) {
+	layer := func(name string) ocispec.Descriptor {
+		return ocispec.Descriptor{
+			MediaType:   ocispec.MediaTypeImageLayer,
+			Digest:      "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+			Size:        3,
+			Annotations: map[string]string{ocispec.AnnotationTitle: name},
+		}
+	}
+	base := ocispec.Manifest{Layers: []ocispec.Descriptor{layer("hi.txt")}}
+
+	got, err := appendLayers(base, []ocispec.Descriptor{layer("bye.txt")})
+	if err != nil {
+		t.Fatalf("appendLayers() error = %v", err)
+	}
+	if want := []ocispec.Descriptor{layer("hi.txt"), layer("bye.txt")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("appendLayers() = %v, want %v", got, want)
+	}
+	if _, err := appendLayers(base, []ocispec.Descriptor{layer("hi.txt")}); err == nil {
+		t.Error("appendLayers() error = nil, want error for duplicated file name")
+	}
+}
+
+func Test_appendAnnotations(t *testing.T) {
+	base := ocispec.Manifest{
+		Annotations: map[string]string{
+			"a":                       "1",
+			"b":                       "2",
+			ocispec.AnnotationCreated: "2000-01-01T00:00:00Z",
+		},
+	}
+	got := appendAnnotations(base, map[string]string{"b": "3"})
+	if want := map[string]string{"a": "1", "b": "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("appendAnnotations() = %v, want %v", got, want)
+	}
+	if got := appendAnnotations(ocispec.Manifest{}, nil); got != nil {
+		t.Errorf("appendAnnotations() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/uploadsession"
+)
+
+type cleanupUploadsOptions struct {
+	option.Common
+	option.Remote
+
+	repository string
+	olderThan  time.Duration
+	sessions   []string
+}
+
+func cleanupUploadsCmd() *cobra.Command {
+	var opts cleanupUploadsOptions
+	cmd := &cobra.Command{
+		Use:   "cleanup-uploads [flags] <name>",
+		Short: "[Experimental] Cancel stale blob upload sessions of a repository",
+		Long: `[Experimental] Cancel stale blob upload sessions of a repository
+
+Registries do not provide an API to list blob upload sessions. The sessions
+opened by oras on this machine and left open, e.g. by crashed or killed
+processes, are recorded in a journal under the user cache directory and
+cancelled by this command. Other sessions can be specified by --session with
+their upload URLs or IDs.
+
+Example - Cancel the stale upload sessions of repository 'localhost:5000/hello':
+  oras repo cleanup-uploads localhost:5000/hello
+
+Example - Cancel the upload sessions opened more than 10 minutes ago:
+  oras repo cleanup-uploads --older-than 10m localhost:5000/hello
+
+Example - Cancel a specific upload session by its ID:
+  oras repo cleanup-uploads --session 0c6a9ab6-2b4e-4b7d-bd4e-4a5b4c3f7e21 localhost:5000/hello
+
+Example - Show the upload sessions to be cancelled without cancelling them:
+  oras repo cleanup-uploads --dry-run localhost:5000/hello
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the repository to clean up"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.repository = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cleanupUploads(cmd, &opts)
+		},
+	}
+	cmd.Flags().DurationVar(&opts.olderThan, "older-than", time.Hour, "only cancel the recorded upload sessions opened at least `duration` ago")
+	cmd.Flags().StringArrayVar(&opts.sessions, "session", nil, "upload session `url` or ID to cancel in addition to the recorded ones")
+	opts.EnableDryRunFlag()
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Remote)
+}
+
+func cleanupUploads(cmd *cobra.Command, opts *cleanupUploadsOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	repo, err := opts.NewRepository(opts.repository, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if repo.Reference.Reference != "" {
+		return fmt.Errorf("%q: cleanup-uploads applies to a whole repository, tag or digest is not allowed", opts.repository)
+	}
+	name := repo.Reference.Registry + "/" + repo.Reference.Repository
+	ctx = auth.AppendRepositoryScope(ctx, repo.Reference, auth.ActionPull, auth.ActionPush)
+
+	journal, err := uploadsession.DefaultJournal()
+	if err != nil {
+		return err
+	}
+	entries, err := journal.List(name)
+	if err != nil {
+		return err
+	}
+	var locations []*url.URL
+	recorded := make(map[*url.URL]bool)
+	deadline := time.Now().Add(-opts.olderThan)
+	for _, entry := range entries {
+		if entry.CreatedAt.After(deadline) {
+			logger.Debugf("Skipping upload session %s opened at %s", entry.URL, entry.CreatedAt)
+			continue
+		}
+		location, err := url.Parse(entry.URL)
+		if err != nil {
+			_ = journal.Remove(entry.URL)
+			continue
+		}
+		locations = append(locations, location)
+		recorded[location] = true
+	}
+	for _, session := range opts.sessions {
+		location, err := sessionURL(repo, session)
+		if err != nil {
+			return err
+		}
+		locations = append(locations, location)
+	}
+
+	var cancelled int
+	var errs []error
+	for _, location := range locations {
+		status, err := cancelUpload(ctx, repo.Client, location)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if recorded[location] && !opts.DryRun {
+			_ = journal.Remove(location.String())
+		}
+		if status == uploadsession.StatusExpired {
+			_ = opts.Printer.Println("Already closed", location.Path)
+			continue
+		}
+		cancelled++
+		if !opts.DryRun {
+			_ = opts.Printer.Println("Cancelled", location.Path)
+		}
+	}
+	if opts.DryRun {
+		_ = opts.Printer.Printf("Would reclaim %d upload session(s) of %s\n", cancelled, name)
+	} else {
+		_ = opts.Printer.Printf("Reclaimed %d upload session(s) of %s\n", cancelled, name)
+	}
+	return errors.Join(errs...)
+}
+
+// sessionURL returns the URL of session, which is either an upload session
+// URL or ID of repo.
+func sessionURL(repo *remote.Repository, session string) (*url.URL, error) {
+	if strings.Contains(session, "/") {
+		location, err := url.Parse(session)
+		if err != nil {
+			return nil, fmt.Errorf("invalid upload session %q: %w", session, err)
+		}
+		if location.Host == "" {
+			location.Scheme, location.Host = scheme(repo), repo.Reference.Host()
+		}
+		return location, nil
+	}
+	return &url.URL{
+		Scheme: scheme(repo),
+		Host:   repo.Reference.Host(),
+		Path:   fmt.Sprintf("/v2/%s/blobs/uploads/%s", repo.Reference.Repository, session),
+	}, nil
+}
+
+func scheme(repo *remote.Repository) string {
+	if repo.PlainHTTP {
+		return "http"
+	}
+	return "https"
+}
+
+// cancelUpload cancels the upload session at location with client.
+func cancelUpload(ctx context.Context, client remote.Client, location *url.URL) (uploadsession.Status, error) {
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return client.Do(req)
+	})
+	return uploadsession.Cancel(ctx, transport, location, "")
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -29,6 +29,8 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(
 		listCmd(),
 		showTagsCmd(),
+		diskUsageCmd(),
+		cleanupUploadsCmd(),
 	)
 	return cmd
 }
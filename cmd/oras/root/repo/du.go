@@ -0,0 +1,153 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	"oras.land/oras/cmd/oras/internal/display"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/graph"
+)
+
+type diskUsageOptions struct {
+	option.Common
+	option.Target
+	option.Format
+}
+
+func diskUsageCmd() *cobra.Command {
+	var opts diskUsageOptions
+	cmd := &cobra.Command{
+		Use:   "du [flags] <name>",
+		Short: "[Experimental] Show storage usage of the target repository",
+		Long: `[Experimental] Show storage usage of the target repository
+
+The size of each tag is the total size of all manifests and blobs reachable from
+the tagged manifest. The total size counts blobs shared by multiple tags only once.
+
+Example - Show storage usage of the target repository:
+  oras repo du localhost:5000/hello
+
+Example - Show storage usage of the target repository in JSON format:
+  oras repo du localhost:5000/hello --format json
+
+Example - Show the total storage usage of the target repository using the given Go template:
+  oras repo du localhost:5000/hello --format go-template --template "{{.totalSize}}"
+
+Example - Show storage usage of the target OCI image layout folder 'layout-dir':
+  oras repo du --oci-layout layout-dir
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the target repository to measure"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.RawReference = args[0]
+			if err := option.Parse(cmd, &opts); err != nil {
+				return err
+			}
+			if opts.Reference != "" {
+				return fmt.Errorf("%q: tag or digest is not allowed, storage usage is computed for the whole repository", opts.RawReference)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diskUsage(cmd, &opts)
+		},
+	}
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func diskUsage(cmd *cobra.Command, opts *diskUsageOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	handler, err := display.NewRepoDiskUsageHandler(opts.Printer, opts.Format, opts.Path)
+	if err != nil {
+		return err
+	}
+
+	// sizes of all blobs and manifests in the repository, indexed by digest
+	total := make(map[string]int64)
+	err = target.Tags(ctx, "", func(tags []string) error {
+		for _, tag := range tags {
+			desc, err := target.Resolve(ctx, tag)
+			if err != nil {
+				return fmt.Errorf("failed to resolve tag %q: %w", tag, err)
+			}
+			nodes, err := reachableNodes(ctx, target, desc)
+			if err != nil {
+				return err
+			}
+			var size int64
+			for dgst, n := range nodes {
+				size += n
+				total[dgst] = n
+			}
+			if err := handler.OnTagMeasured(tag, desc, size, len(nodes)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var totalSize int64
+	for _, n := range total {
+		totalSize += n
+	}
+	if err := handler.OnTotalMeasured(totalSize, len(total)); err != nil {
+		return err
+	}
+	return handler.Render()
+}
+
+// reachableNodes returns the sizes of root and all the nodes reachable from it,
+// indexed by digest. Subjects are not followed as they are not owned by the
+// referring manifest.
+func reachableNodes(ctx context.Context, fetcher content.Fetcher, root ocispec.Descriptor) (map[string]int64, error) {
+	nodes := make(map[string]int64)
+	stack := []ocispec.Descriptor{root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		key := node.Digest.String()
+		if _, ok := nodes[key]; ok {
+			continue
+		}
+		nodes[key] = node.Size
+		successors, _, config, err := graph.Successors(ctx, fetcher, node)
+		if err != nil {
+			return nil, err
+		}
+		if config != nil {
+			stack = append(stack, *config)
+		}
+		stack = append(stack, successors...)
+	}
+	return nodes, nil
+}
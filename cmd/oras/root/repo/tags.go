@@ -16,11 +16,17 @@ limitations under the License.
 package repo
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/registry"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
@@ -28,6 +34,7 @@ import (
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
 	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/descriptor"
 )
 
 type showTagsOptions struct {
@@ -37,6 +44,10 @@ type showTagsOptions struct {
 
 	last             string
 	excludeDigestTag bool
+	showDigest       bool
+	showCreated      bool
+	groupByDigest    bool
+	concurrency      int
 }
 
 func showTagsCmd() *cobra.Command {
@@ -67,6 +78,15 @@ Example - [Experimental] Show tags associated with a particular tagged resource:
 Example - [Experimental] Show tags associated with a digest:
   oras repo tags localhost:5000/hello@sha256:c551125a624189cece9135981621f3f3144564ddabe14b523507bf74c2281d9b
 
+Example - [Experimental] Show tags of the target repository with the digests of the manifests they point to:
+  oras repo tags --show-digest localhost:5000/hello
+
+Example - [Experimental] Show tags of the target repository with the digests and the creation times of the manifests in JSON format:
+  oras repo tags --show-digest --show-created --format json localhost:5000/hello
+
+Example - [Experimental] Show which movable tags, such as 'latest', point to the same manifests as which version tags:
+  oras repo tags --group-by-digest localhost:5000/hello
+
 Example - [Experimental] Show tags of the target repository in JSON format:
   oras repo tags localhost:5000/hello --format json
 
@@ -79,6 +99,9 @@ Example - [Experimental] Show tags of a specific repository in OCI layout:
 		Args:    oerrors.CheckArgs(argument.Exactly(1), "the target repository to list tags from"),
 		Aliases: []string{"show-tags"},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "group-by-digest", "show-created"); err != nil {
+				return err
+			}
 			opts.RawReference = args[0]
 			return option.Parse(cmd, &opts)
 		},
@@ -88,6 +111,10 @@ Example - [Experimental] Show tags of a specific repository in OCI layout:
 	}
 	cmd.Flags().StringVar(&opts.last, "last", "", "start after the tag specified by `last`")
 	cmd.Flags().BoolVar(&opts.excludeDigestTag, "exclude-digest-tags", false, "[Preview] exclude all digest-like tags such as 'sha256-aaaa...'")
+	cmd.Flags().BoolVar(&opts.showDigest, "show-digest", false, "[Experimental] show the digest of the manifest each tag points to")
+	cmd.Flags().BoolVar(&opts.showCreated, "show-created", false, "[Experimental] show the creation time of the manifest each tag points to, read from its \""+ocispec.AnnotationCreated+"\" annotation")
+	cmd.Flags().BoolVar(&opts.groupByDigest, "group-by-digest", false, "[Experimental] group the tags by the digests of the manifests they point to, separating the tags of full semantic versions from the movable tags such as 'latest'")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level of resolving the tags")
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
 	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
 	option.ApplyFlags(&opts, cmd.Flags())
@@ -125,12 +152,14 @@ func showTags(cmd *cobra.Command, opts *showTagsOptions) error {
 		logger.Warnf("[Experimental] querying tags associated to %s, it may take a while...\n", targetDigest)
 	}
 
-	handler, err := display.NewRepoTagsHandler(opts.Printer, opts.Format)
+	handler, err := display.NewRepoTagsHandler(opts.Printer, opts.Format, opts.showDigest, opts.showCreated, opts.groupByDigest)
 	if err != nil {
 		return err
 	}
 	err = finder.Tags(ctx, opts.last, func(tags []string) error {
+		var listed, refs []string
 		for _, tag := range tags {
+			ref := tag
 			// if --oci-layout-path is used with a repository path, filter the
 			// tags under the repository.
 			if targetPrefix != "" {
@@ -145,26 +174,40 @@ func showTags(cmd *cobra.Command, opts *showTagsOptions) error {
 			if opts.excludeDigestTag && isDigestTag(tag) {
 				continue
 			}
+			listed = append(listed, tag)
+			refs = append(refs, ref)
+		}
+		showManifest := opts.showDigest || opts.showCreated || opts.groupByDigest
+		if targetDigest == "" && !showManifest {
+			// show the tags in the repository
+			for _, tag := range listed {
+				if err := handler.OnTagListed(tag); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
 
+		manifests, err := resolveTags(ctx, finder, refs, opts.showCreated, opts.concurrency)
+		if err != nil {
+			return err
+		}
+		for i, tag := range listed {
 			// if a tag or digest is given, show the associated tags
-			if targetDigest != "" {
-				if tag == opts.Reference {
-					if err := handler.OnTagListed(tag); err != nil {
-						return err
-					}
-					continue
-				}
-				desc, err := finder.Resolve(ctx, tag)
-				if err != nil {
+			if targetDigest != "" && manifests[i].digest != targetDigest {
+				continue
+			}
+			if !showManifest {
+				if err := handler.OnTagListed(tag); err != nil {
 					return err
 				}
-				if desc.Digest.String() != targetDigest {
-					continue
-				}
+				continue
 			}
-
-			// show the tags in the repository
-			if err := handler.OnTagListed(tag); err != nil {
+			var dgst string
+			if opts.showDigest || opts.groupByDigest {
+				dgst = manifests[i].digest
+			}
+			if err := handler.OnTagResolved(tag, dgst, manifests[i].created); err != nil {
 				return err
 			}
 		}
@@ -177,6 +220,51 @@ func showTags(cmd *cobra.Command, opts *showTagsOptions) error {
 	return handler.Render()
 }
 
+// tagManifest is the manifest a tag points to.
+type tagManifest struct {
+	digest  string
+	created string
+}
+
+// resolveTags resolves the tags concurrently and returns the manifests they
+// point to in the order of the tags. The creation time of a manifest is read
+// from its annotations if withCreated is set.
+func resolveTags(ctx context.Context, target oras.ReadOnlyTarget, tags []string, withCreated bool, concurrency int) ([]tagManifest, error) {
+	manifests := make([]tagManifest, len(tags))
+	eg, egCtx := errgroup.WithContext(ctx)
+	if concurrency > 0 {
+		eg.SetLimit(concurrency)
+	}
+	for i, tag := range tags {
+		eg.Go(func() error {
+			desc, err := target.Resolve(egCtx, tag)
+			if err != nil {
+				return fmt.Errorf("failed to resolve tag %q: %w", tag, err)
+			}
+			manifests[i].digest = desc.Digest.String()
+			if !withCreated || !descriptor.IsManifest(desc) {
+				return nil
+			}
+			fetched, err := content.FetchAll(egCtx, target, desc)
+			if err != nil {
+				return fmt.Errorf("failed to fetch the manifest of tag %q: %w", tag, err)
+			}
+			var manifest struct {
+				Annotations map[string]string `json:"annotations"`
+			}
+			if err := json.Unmarshal(fetched, &manifest); err != nil {
+				return fmt.Errorf("failed to parse the manifest of tag %q: %w", tag, err)
+			}
+			manifests[i].created = manifest.Annotations[ocispec.AnnotationCreated]
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return manifests, nil
+}
+
 func isDigestTag(tag string) bool {
 	dgst := strings.Replace(tag, "-", ":", 1)
 	_, err := digest.Parse(dgst)
@@ -0,0 +1,210 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/internal/signature"
+	"oras.land/oras/internal/trace"
+)
+
+// findPredecessorsFunc finds the predecessors of a node.
+type findPredecessorsFunc func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error)
+
+// staleSubjects records the subjects whose signatures are dropped, so that
+// they are signed again for the destination.
+type staleSubjects struct {
+	lock     sync.Mutex
+	subjects []ocispec.Descriptor
+}
+
+// add records subject unless it is recorded already.
+func (s *staleSubjects) add(subject ocispec.Descriptor) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !slices.ContainsFunc(s.subjects, func(desc ocispec.Descriptor) bool { return desc.Digest == subject.Digest }) {
+		s.subjects = append(s.subjects, subject)
+	}
+}
+
+// dropStaleSignatures wraps findPredecessors to leave out the signatures of a
+// node that are not bound to repository, since they will not validate once
+// copied there. The nodes of the dropped signatures are recorded in stale if
+// it is not nil.
+func dropStaleSignatures(findPredecessors findPredecessorsFunc, repository string, stale *staleSubjects, logger logrus.FieldLogger) findPredecessorsFunc {
+	return func(ctx context.Context, src content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		predecessors, err := findPredecessors(ctx, src, desc)
+		if err != nil {
+			return nil, err
+		}
+		var kept []ocispec.Descriptor
+		for _, predecessor := range predecessors {
+			if signature.IsSignature(predecessor) {
+				bound, err := isSignatureBound(ctx, src, predecessor, repository, desc)
+				if err != nil {
+					return nil, err
+				}
+				if !bound {
+					logger.Warnf("replacing signature %s of %s as it is not bound to %s", predecessor.Digest, desc.Digest, repository)
+					if stale != nil {
+						stale.add(desc)
+					}
+					continue
+				}
+			}
+			kept = append(kept, predecessor)
+		}
+		return kept, nil
+	}
+}
+
+// isSignatureBound returns true if the signature manifest sigDesc signs
+// subject in repository.
+func isSignatureBound(ctx context.Context, fetcher content.Fetcher, sigDesc ocispec.Descriptor, repository string, subject ocispec.Descriptor) (bool, error) {
+	return matchSignature(ctx, fetcher, sigDesc, repository, subject, nil)
+}
+
+// matchSignature returns true if the signature manifest sigDesc signs subject
+// in repository with the private key of key. The key is not checked if it is
+// nil.
+func matchSignature(ctx context.Context, fetcher content.Fetcher, sigDesc ocispec.Descriptor, repository string, subject ocispec.Descriptor, key crypto.PublicKey) (bool, error) {
+	manifestJSON, err := content.FetchAll(ctx, fetcher, sigDesc)
+	if err != nil {
+		return false, err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return false, err
+	}
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != signature.MediaTypePayload {
+			continue
+		}
+		payloadJSON, err := content.FetchAll(ctx, fetcher, layer)
+		if err != nil {
+			return false, err
+		}
+		payload, err := signature.ParsePayload(payloadJSON)
+		if err != nil {
+			// not a signature we can tell the binding of
+			continue
+		}
+		if !payload.Matches(repository, subject.Digest) {
+			continue
+		}
+		if key == nil {
+			return true, nil
+		}
+		sig, err := base64.StdEncoding.DecodeString(layer.Annotations[signature.AnnotationSignature])
+		if err != nil {
+			continue
+		}
+		if signature.Verify(key, payloadJSON, sig) == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// findSignature returns the signature of subject in dst bound to repository
+// and signed by signer, if any.
+func findSignature(ctx context.Context, dst content.ReadOnlyGraphStorage, repository string, subject ocispec.Descriptor, signer *signature.Signer) (ocispec.Descriptor, bool, error) {
+	referrers, err := registry.Referrers(ctx, dst, subject, signature.ArtifactType)
+	if err != nil {
+		return ocispec.Descriptor{}, false, err
+	}
+	for _, referrer := range referrers {
+		matched, err := matchSignature(ctx, dst, referrer, repository, subject, signer.Public())
+		if err != nil {
+			return ocispec.Descriptor{}, false, err
+		}
+		if matched {
+			return referrer, true, nil
+		}
+	}
+	return ocispec.Descriptor{}, false, nil
+}
+
+// pushSignatures signs root and the subjects of the dropped signatures bound
+// to repository in dst.
+func pushSignatures(ctx context.Context, dst oras.GraphTarget, repository string, root ocispec.Descriptor, stale *staleSubjects, signer *signature.Signer, opts oras.CopyGraphOptions) error {
+	subjects := []ocispec.Descriptor{root}
+	for _, subject := range stale.subjects {
+		if subject.Digest != root.Digest {
+			subjects = append(subjects, subject)
+		}
+	}
+	for _, subject := range subjects {
+		if _, err := pushSignature(ctx, dst, repository, subject, signer, opts); err != nil {
+			return fmt.Errorf("failed to sign %s: %w", subject.Digest, err)
+		}
+	}
+	return nil
+}
+
+// pushSignature signs subject bound to repository and copies the signature as
+// a referrer of subject to dst. Signing is skipped if subject is already
+// signed by signer in dst, since the signatures are not deterministic and
+// would pile up on repeated copies.
+func pushSignature(ctx context.Context, dst oras.GraphTarget, repository string, subject ocispec.Descriptor, signer *signature.Signer, opts oras.CopyGraphOptions) (ocispec.Descriptor, error) {
+	existing, found, err := findSignature(ctx, dst, repository, subject, signer)
+	switch {
+	case err != nil:
+		trace.Logger(ctx).Debugf("failed to look up the signatures of %s: %v", subject.Digest, err)
+	case found:
+		trace.Logger(ctx).Debugf("skipped signing %s as it is signed by %s", subject.Digest, existing.Digest)
+		return existing, nil
+	}
+	payload, err := signature.NewPayload(repository, subject.Digest)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	sig, err := signer.Sign(payload)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	store := memory.New()
+	layer := content.NewDescriptorFromBytes(signature.MediaTypePayload, payload)
+	if err := store.Push(ctx, layer, bytes.NewReader(payload)); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	layer.Annotations = map[string]string{
+		signature.AnnotationSignature: base64.StdEncoding.EncodeToString(sig),
+	}
+	packOpts := oras.PackManifestOptions{
+		Subject: &subject,
+		Layers:  []ocispec.Descriptor{layer},
+	}
+	root, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, signature.ArtifactType, packOpts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return root, oras.CopyGraph(ctx, store, dst, root, opts)
+}
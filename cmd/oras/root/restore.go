@@ -19,64 +19,143 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras/cmd/oras/internal/argument"
 	"oras.land/oras/cmd/oras/internal/command"
 	"oras.land/oras/cmd/oras/internal/display"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/display/prompt"
+	"oras.land/oras/cmd/oras/internal/display/status"
 	oerrors "oras.land/oras/cmd/oras/internal/errors"
 	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/backupcatalog"
+	"oras.land/oras/internal/compression"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/httpfile"
 	orasio "oras.land/oras/internal/io"
+	"oras.land/oras/internal/objectstore"
+	"oras.land/oras/internal/restorestate"
+	"oras.land/oras/internal/trace"
 )
 
 type restoreOptions struct {
 	option.Common
+	option.Format
+	option.LedgerReader
+	option.Prompt
 	option.Remote
+	option.TempDir
 	option.Terminal
 
 	// flags
 	input            string
 	excludeReferrers bool
-	dryRun           bool
+	referrersOnly    bool
 	concurrency      int
+	onConflict       string
+	includeRepos     []string
+	excludeRepos     []string
+	includeTags      []string
+	noResume         bool
+	skipCorrupt      bool
+	continueOnError  bool
 
 	// derived options
 	repository string
 	tags       []string
+	// catalog is the catalog of the input backup if it contains multiple
+	// repositories, which are restored under registry and namespace.
+	catalog   *backupcatalog.Catalog
+	registry  string
+	namespace string
 }
 
 func restoreCmd() *cobra.Command {
 	var opts restoreOptions
 	cmd := &cobra.Command{
-		Use:   "restore [flags] --input <path> <registry>/<repository>[:<ref1>[,<ref2>...]]",
+		Use:   "restore [flags] --input <path> {<registry>/<repository>[:<ref1>[,<ref2>...]] | <registry>[/<namespace>]}",
 		Short: "[Experimental] Restore artifacts to a registry from an OCI image layout",
 		Long: `[Experimental] Restore artifacts to a registry from an OCI image layout, which can be either a directory or a tar archive. 
+The tar archive can also be downloaded from an HTTP(S) URL or from S3 (s3://<bucket>/<key>), and is extracted into a temporary directory while downloading. An interrupted HTTP download is resumed with range requests, if supported by the server, unless the archive is changed on the server.
+The progress of restoring is saved under the user cache directory, so that re-running an interrupted restore skips the content already restored.
+When run in a terminal, overwriting existing tags requires typing the name of the target repository to confirm, unless --yes is specified.
+If the input is a backup of multiple repositories, each repository is restored to the target registry under the same name, prefixed with the namespace if specified.
 
 Example - Restore a single artifact from a tar archive:
   oras restore --input hello.tar localhost:5000/hello:v1
 
+Example - Restore from a tar archive compressed by "oras backup --compress":
+  oras restore --input hello.tar.zst localhost:5000/hello
+
+Example - Restore from a tar archive split into volumes hello.tar.001, hello.tar.002, ... by "oras backup --split-size":
+  oras restore --input hello.tar localhost:5000/hello
+
+Example - Restore from a tar archive in an S3 bucket created by "oras backup", with the credentials and region configured as for the AWS CLI:
+  oras restore --input s3://my-bucket/backups/hello.tar.zst localhost:5000/hello
+
+Example - Restore from a tar archive downloaded over HTTPS, resuming the download if the connection is interrupted:
+  oras restore --input https://artifacts.example.com/backup-2024.tar localhost:5000/hello
+
 Example - Restore a single artifact from a directory:
   oras restore --input hello localhost:5000/hello:v1
 
-Example - Perform a dry run without actually uploading artifacts:
+Example - Perform a dry run listing the tags that would be created or overwritten without actually uploading artifacts:
   oras restore --input hello --dry-run localhost:5000/hello:v1
 
+Example - Restore all tagged artifacts, keeping the tags already existing in the target repository with different digests:
+  oras restore --input hello --on-conflict skip localhost:5000/hello
+
+Example - Restore all tagged artifacts only if none of the tags exists in the target repository with a different digest:
+  oras restore --input hello --on-conflict fail localhost:5000/hello
+
+Example - Restore all tagged artifacts, overwriting existing tags without prompting for confirmation in a terminal:
+  oras restore --input hello --yes localhost:5000/hello
+
 Example - Restore multiple specific tags:
   oras restore --input hello localhost:5000/hello:v1,v2
 
 Example - Restore all tagged artifacts:
   oras restore --input hello localhost:5000/hello
 
+Example - Restore only the tags matching a pattern:
+  oras restore --input hello --include-tag "v1.*" localhost:5000/hello
+
+Example - Restore the repositories under "team" from a backup of multiple repositories, except "team/legacy":
+  oras restore --input backups --include-repo "team/*" --exclude-repo team/legacy localhost:5000
+
+Example - Restore all repositories from a backup of multiple repositories under the namespace "mirror":
+  oras restore --input backups localhost:5000/mirror
+
+Example - Restore all tagged artifacts with intact content, skipping the ones corrupted in the backup:
+  oras restore --input hello.tar --skip-corrupt localhost:5000/hello
+
+Example - Restore from the beginning, ignoring the progress saved by an interrupted restore:
+  oras restore --input hello --no-resume localhost:5000/hello
+
 Example - Exclude referrers when restoring artifacts:
   oras restore --input hello --exclude-referrers localhost:5000/hello
 
+Example - Restore only the referrers of artifacts already existing in the target registry:
+  oras restore --input hello --referrers-only localhost:5000/hello:v1
+
 Example - Use Referrers API for discovering referrers:
   oras restore --input hello --distribution-spec v1.1-referrers-api localhost:5000/hello
 
@@ -91,6 +170,15 @@ Example - Restore to a plain HTTP registry (no TLS):
 
 Example - Set custom concurrency level:
   oras restore --input hello --concurrency 6 localhost:5000/hello:v1
+
+Example - Restore only the content listed in a transfer ledger signed by the key 'signing.pub':
+  oras restore --input hello.tar --ledger hello.ledger.json --ledger-key signing.pub localhost:5000/hello
+
+Example - [Experimental] Restore all tagged artifacts, continuing with the other tags when one fails and reporting the failures at the end:
+  oras restore --input hello.tar --continue-on-error localhost:5000/hello
+
+Example - [Experimental] Restore all tagged artifacts and report the status, size and timing of each uploaded blob and manifest in JSON:
+  oras restore --input hello.tar --format json localhost:5000/hello
 `,
 		Args: oerrors.CheckArgs(argument.Exactly(1), "the targets to restore to"),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -99,11 +187,56 @@ Example - Set custom concurrency level:
 			}
 
 			// parse repo and tags
-			var err error
-			opts.repository, opts.tags, err = parseArtifactReferences(args[0])
-			if err != nil {
+			if err := parseRestoreInput(&opts); err != nil {
+				return err
+			}
+			if opts.catalog != nil {
+				if opts.Format.Type != option.FormatTypeText.Name {
+					return &oerrors.Error{
+						Err:            fmt.Errorf("--format %s is not supported when restoring a backup of multiple repositories", opts.Format.Type),
+						Recommendation: "Remove --format to print the text output, or restore the repositories one at a time",
+					}
+				}
+				var err error
+				if opts.registry, opts.namespace, err = parseRestoreNamespace(args[0]); err != nil {
+					return err
+				}
+			} else {
+				if len(opts.includeRepos) > 0 || len(opts.excludeRepos) > 0 {
+					return &oerrors.Error{
+						Err:            errors.New("--include-repo and --exclude-repo can only be used when restoring a backup of multiple repositories"),
+						Recommendation: "Restore from the output directory of backing up multiple repositories",
+					}
+				}
+				var err error
+				opts.repository, opts.tags, err = parseArtifactReferences(args[0])
+				if err != nil {
+					return err
+				}
+			}
+			host := opts.registry
+			if opts.catalog == nil {
+				host, _, _ = strings.Cut(opts.repository, "/")
+			}
+			if err := opts.CheckCredentialExpiry(cmd, host); err != nil {
 				return err
 			}
+			for _, pattern := range slices.Concat(opts.includeRepos, opts.excludeRepos, opts.includeTags) {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+				}
+			}
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "exclude-referrers", "referrers-only"); err != nil {
+				return err
+			}
+			if err := oerrors.CheckMutuallyExclusiveFlags(cmd.Flags(), "on-conflict", "referrers-only"); err != nil {
+				return err
+			}
+			switch opts.onConflict {
+			case conflictPolicyOverwrite, conflictPolicySkip, conflictPolicyFail:
+			default:
+				return fmt.Errorf("invalid --on-conflict %q: must be one of %q, %q or %q", opts.onConflict, conflictPolicyOverwrite, conflictPolicySkip, conflictPolicyFail)
+			}
 
 			opts.DisableTTY(opts.Debug, false)
 			return nil
@@ -115,14 +248,23 @@ Example - Set custom concurrency level:
 	}
 
 	// required flag
-	cmd.Flags().StringVar(&opts.input, "input", "", "path to the OCI layout, either a tar archive (*.tar) or a directory")
+	cmd.Flags().StringVar(&opts.input, "input", "", "path to the OCI layout, either a tar archive (*.tar, or compressed with gzip or zstd), a set of tar archive volumes (*.tar.001, *.tar.002, ...), a directory, or the URL of a tar archive over HTTP(S) or in S3 (s3://<bucket>/<key>)")
 	_ = cmd.MarkFlagRequired("input")
 	// optional flags
 	cmd.Flags().BoolVar(&opts.excludeReferrers, "exclude-referrers", false, "restore artifacts excluding their referrers")
-	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "simulate the restore process without actually uploading any artifacts")
+	cmd.Flags().BoolVarP(&opts.referrersOnly, "referrers-only", "", false, "[Experimental] restore only the referrers of the artifacts, which must already exist in the target repository")
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
+	cmd.Flags().StringArrayVar(&opts.includeRepos, "include-repo", nil, "[Experimental] restore only the repositories with names, without the registry, matching the glob `pattern` from a backup of multiple repositories, can be used multiple times")
+	cmd.Flags().StringArrayVar(&opts.excludeRepos, "exclude-repo", nil, "[Experimental] skip the repositories with names, without the registry, matching the glob `pattern` from a backup of multiple repositories, can be used multiple times")
+	cmd.Flags().StringArrayVar(&opts.includeTags, "include-tag", nil, "[Experimental] restore only the tags matching the glob `pattern`, can be used multiple times")
+	cmd.Flags().BoolVar(&opts.noResume, "no-resume", false, "[Experimental] start over instead of resuming from the progress saved by an interrupted restore")
+	cmd.Flags().BoolVar(&opts.continueOnError, "continue-on-error", false, "[Experimental] continue with the other tags and repositories when restoring one fails, and report the failures at the end with exit code 2")
+	cmd.Flags().BoolVar(&opts.skipCorrupt, "skip-corrupt", false, "[Experimental] skip the tags with content not matching the digests and sizes recorded in the backup instead of failing")
+	cmd.Flags().StringVar(&opts.onConflict, "on-conflict", conflictPolicyOverwrite, "[Experimental] `policy` for tags already existing in the target repository with different digests, options: overwrite, skip, fail")
 	opts.EnableDistributionSpecFlag()
+	opts.SetTypes(option.FormatTypeText, option.FormatTypeJSON, option.FormatTypeGoTemplate)
 	// apply flags
+	opts.EnableDryRunFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
 	return oerrors.Command(cmd, &opts.Remote)
 }
@@ -133,22 +275,81 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 	}
 	startTime := time.Now() // start timing the restore process
 	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	if opts.catalog != nil {
+		return restoreRepositories(ctx, opts, logger)
+	}
 
 	// prepare the target registry
 	dstRepo, err := opts.NewRepository(opts.repository, opts.Common, logger)
 	if err != nil {
 		return fmt.Errorf("failed to prepare target repository %q: %w", opts.repository, err)
 	}
-	statusHandler, metadataHandler := display.NewRestoreHandler(opts.Printer, opts.TTY, dstRepo, opts.dryRun)
+	statusHandler, metadataHandler, err := display.NewRestoreHandler(opts.Printer, opts.Format, opts.TTY, dstRepo, opts.DryRun)
+	if err != nil {
+		return err
+	}
 
 	// prepare the source OCI store
 	var srcOCI oras.ReadOnlyGraphTarget
-	fi, err := os.Stat(opts.input)
-	if err != nil {
-		return fmt.Errorf("failed to access input path %q: %w", opts.input, err)
+	// layout is the directory of the OCI layout, or empty if the tar archive
+	// is read in place
+	var layout string
+	if objectstore.IsURL(opts.input) || httpfile.IsURL(opts.input) {
+		store, dir, size, err := loadRemoteArchive(ctx, opts.input, &opts.TempDir)
+		if err != nil {
+			return fmt.Errorf("failed to prepare OCI store from tar archive %q: %w", opts.input, err)
+		}
+		defer func() {
+			if err := os.RemoveAll(dir); err != nil {
+				logger.Debugf("failed to remove temporary directory %s: %v", dir, err)
+			}
+		}()
+		if err := metadataHandler.OnTarLoaded(opts.input, size); err != nil {
+			return err
+		}
+		srcOCI, layout = store, dir
+	} else if volumeSet, ok := findVolumeSet(opts.input); ok {
+		store, dir, size, err := loadVolumeSet(ctx, volumeSet, &opts.TempDir)
+		if err != nil {
+			return fmt.Errorf("failed to prepare OCI store from tar archive volumes %q: %w", volumeSet, err)
+		}
+		defer func() {
+			if err := os.RemoveAll(dir); err != nil {
+				logger.Debugf("failed to remove temporary directory %s: %v", dir, err)
+			}
+		}()
+		if err := metadataHandler.OnTarLoaded(volumeSet, size); err != nil {
+			return err
+		}
+		srcOCI, layout = store, dir
 	}
+	fi, err := os.Stat(opts.input)
 	switch {
+	case srcOCI != nil:
+		// loaded from a URL or volumes
+	case err != nil:
+		return fmt.Errorf("failed to access input path %q: %w", opts.input, err)
 	case fi.Mode().IsRegular():
+		algorithm, err := compression.Detect(opts.input)
+		if err != nil {
+			return fmt.Errorf("unable to determine the compression of %q: %w", opts.input, err)
+		}
+		if algorithm != compression.None {
+			store, dir, err := loadCompressedArchive(ctx, opts.input, fi.Size(), &opts.TempDir)
+			if err != nil {
+				return fmt.Errorf("failed to prepare OCI store from compressed tar archive %q: %w", opts.input, err)
+			}
+			defer func() {
+				if err := os.RemoveAll(dir); err != nil {
+					logger.Debugf("failed to remove temporary directory %s: %v", dir, err)
+				}
+			}()
+			if err := metadataHandler.OnTarLoaded(opts.input, fi.Size()); err != nil {
+				return err
+			}
+			srcOCI, layout = store, dir
+			break
+		}
 		isTar, err := orasio.IsTarFile(opts.input)
 		if err != nil {
 			return fmt.Errorf("unable to determine if %q is a tar archive: %w", opts.input, err)
@@ -168,23 +369,250 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 		if err != nil {
 			return fmt.Errorf("failed to prepare OCI store from directory %q: %w", opts.input, err)
 		}
+		layout = opts.input
 	default:
 		return fmt.Errorf("input path %q must be a directory or a tar archive", opts.input)
 	}
 
-	// resolve tags to restore
-	tags, roots, err := resolveTags(ctx, srcOCI, opts.tags)
+	catalogRepo, err := readBackupRepository(opts.input, layout)
 	if err != nil {
 		return err
 	}
-	if len(tags) == 0 {
+	if err := checkBackupLayout(opts, layout, catalogRepo, logger); err != nil {
+		return err
+	}
+	tagsCount, restoreErr := restoreRepository(ctx, opts, srcOCI, opts.input, catalogRepo, dstRepo, opts.repository, opts.tags, statusHandler, metadataHandler)
+	var partialErr *oerrors.PartialFailureError
+	if restoreErr != nil && !errors.As(restoreErr, &partialErr) {
+		return restoreErr
+	}
+	duration := time.Since(startTime)
+	if err := metadataHandler.OnRestoreCompleted(tagsCount, opts.repository, duration); err != nil {
+		return err
+	}
+	if err := metadataHandler.Render(); err != nil {
+		return err
+	}
+	return restoreErr
+}
+
+// restoreRepositories restores the selected repositories in a backup of
+// multiple repositories. With --continue-on-error, the failed repositories and
+// tags are returned in an oerrors.PartialFailureError after restoring the
+// others.
+func restoreRepositories(ctx context.Context, opts *restoreOptions, logger logrus.FieldLogger) error {
+	var restored int
+	// failures of the tags and repositories with --continue-on-error
+	failures := &oerrors.PartialFailureError{Operation: "restore"}
+	for _, repo := range opts.catalog.Repositories {
+		name, tags, ok := selectRestoreRepository(repo, opts.includeRepos, opts.excludeRepos, opts.includeTags)
+		if !ok {
+			continue
+		}
+		restored++
+		repository := opts.registry + "/" + path.Join(opts.namespace, name)
+		tagsCount, err := restoreBackupRepository(ctx, opts, repo, repository, tags, logger)
+		var partialErr *oerrors.PartialFailureError
+		if err != nil && !errors.As(err, &partialErr) {
+			if !opts.continueOnError || ctx.Err() != nil {
+				return err
+			}
+			// skip the repository failed as a whole
+			failures.Failures = append(failures.Failures, oerrors.ItemError{Item: repository, Err: err, Repository: true})
+			continue
+		}
+		failures.Total += tagsCount
+		if partialErr != nil {
+			failures.Total += len(partialErr.Failures)
+			for _, failure := range partialErr.Failures {
+				failure.Item = repository + ":" + failure.Item
+				failures.Failures = append(failures.Failures, failure)
+			}
+		}
+	}
+	if restored == 0 {
 		return &oerrors.Error{
-			Err:            fmt.Errorf("no tags found in OCI layout %q", opts.input),
-			Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags --oci-layout"`, opts.input),
+			Err:            fmt.Errorf("no repositories with matching tags found in %q", opts.input),
+			Recommendation: fmt.Sprintf(`If you want to list the repositories and tags in %q, use "oras backup inspect"`, opts.input),
+		}
+	}
+	if len(failures.Failures) > 0 {
+		return failures
+	}
+	return nil
+}
+
+// restoreBackupRepository restores the tags of repo, a repository in a backup
+// of multiple repositories, to repository and returns the number of tags
+// restored.
+func restoreBackupRepository(ctx context.Context, opts *restoreOptions, repo backupcatalog.Repository, repository string, tags []string, logger logrus.FieldLogger) (int, error) {
+	startTime := time.Now()
+	dstRepo, err := opts.NewRepository(repository, opts.Common, logger)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare target repository %q: %w", repository, err)
+	}
+	statusHandler, metadataHandler, err := display.NewRestoreHandler(opts.Printer, opts.Format, opts.TTY, dstRepo, opts.DryRun)
+	if err != nil {
+		return 0, err
+	}
+	input := filepath.Join(opts.input, filepath.FromSlash(repo.Path))
+	srcOCI, err := oci.NewWithContext(ctx, input)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare OCI store from directory %q: %w", input, err)
+	}
+	if err := checkBackupLayout(opts, input, &repo, logger); err != nil {
+		return 0, err
+	}
+	tagsCount, restoreErr := restoreRepository(ctx, opts, srcOCI, input, &repo, dstRepo, repository, tags, statusHandler, metadataHandler)
+	var partialErr *oerrors.PartialFailureError
+	if restoreErr != nil && !errors.As(restoreErr, &partialErr) {
+		return 0, restoreErr
+	}
+	if err := metadataHandler.OnRestoreCompleted(tagsCount, repository, time.Since(startTime)); err != nil {
+		return 0, err
+	}
+	return tagsCount, restoreErr
+}
+
+// restoreRepository restores the tagged artifacts in src, the OCI layout at
+// input, to dstRepo and returns the number of tags restored. All tags in src
+// are restored if specifiedTags is empty. The tags are checked against
+// catalogRepo, the catalog entry of the backup, if it is not nil. With
+// --continue-on-error, the failed tags are returned in an
+// oerrors.PartialFailureError after restoring the other tags.
+func restoreRepository(ctx context.Context, opts *restoreOptions, src oras.ReadOnlyGraphTarget, input string, catalogRepo *backupcatalog.Repository, dstRepo *remote.Repository, repository string, specifiedTags []string, statusHandler status.RestoreHandler, metadataHandler metadata.RestoreHandler) (_ int, returnErr error) {
+	// the fetched content is verified so that corrupted content is never
+	// pushed
+	if l := opts.LedgerReader.Ledger; l != nil {
+		// content not listed in the transfer ledger is not restored
+		src = l.Target(src)
+	} else {
+		src = contentutil.VerifiedTarget(src)
+	}
+
+	// resolve tags to restore
+	tags, roots, err := resolveTags(ctx, src, specifiedTags)
+	if err != nil {
+		return 0, err
+	}
+	tags, roots = filterTags(tags, roots, opts.includeTags)
+	if len(tags) == 0 && len(opts.includeTags) > 0 {
+		return 0, &oerrors.Error{
+			Err:            fmt.Errorf("no tags matching %q found in OCI layout %q", opts.includeTags, input),
+			Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags --oci-layout"`, input),
+		}
+	}
+	if len(tags) == 0 {
+		return 0, &oerrors.Error{
+			Err:            fmt.Errorf("no tags found in OCI layout %q", input),
+			Recommendation: fmt.Sprintf(`If you want to list available tags in %q, use "oras repo tags --oci-layout"`, input),
 		}
 	}
 	if err := metadataHandler.OnTagsFound(tags); err != nil {
-		return err
+		return 0, err
+	}
+
+	// onFailed returns err on the failure of tag caused by cause, or reports
+	// cause and continues with the other tags with --continue-on-error
+	var failures []oerrors.ItemError
+	onFailed := func(tag string, cause, err error) error {
+		if !opts.continueOnError || ctx.Err() != nil {
+			return err
+		}
+		failures = append(failures, oerrors.ItemError{Item: tag, Err: cause})
+		return metadataHandler.OnArtifactFailed(tag, cause)
+	}
+	// skip or fail on the tags with corrupted content
+	onCorrupted := func(tag string, err error) error {
+		if !opts.skipCorrupt {
+			return onFailed(tag, err, &oerrors.Error{
+				Err:            fmt.Errorf("failed to restore tag %q from %q to %q: %w", tag, input, repository, err),
+				Recommendation: "The backup is corrupted. Use --skip-corrupt to restore only the tags with intact content",
+			})
+		}
+		return metadataHandler.OnTagCorrupted(tag, err)
+	}
+	if catalogRepo != nil {
+		var intact int
+		for i, tag := range tags {
+			if err := checkCatalogTag(catalogRepo, tag, roots[i]); err != nil {
+				if err := onCorrupted(tag, err); err != nil {
+					return 0, err
+				}
+				continue
+			}
+			tags[intact], roots[intact] = tag, roots[i]
+			intact++
+		}
+		tags, roots = tags[:intact], roots[:intact]
+	}
+
+	// check the tags against the target repository
+	var actions []restoreAction
+	if !opts.referrersOnly {
+		var existing []ocispec.Descriptor
+		actions, existing, err = planRestore(ctx, dstRepo, tags, roots)
+		if err != nil {
+			return 0, err
+		}
+		if opts.onConflict == conflictPolicyFail {
+			if err := checkRestoreConflicts(tags, actions, repository); err != nil {
+				return 0, err
+			}
+		}
+		if opts.onConflict == conflictPolicyOverwrite && !opts.DryRun && opts.Interactive(os.Stdin) {
+			if conflicts := restoreConflicts(tags, actions); len(conflicts) > 0 {
+				msg := fmt.Sprintf("%d tag(s) in %q will be overwritten: %s", len(conflicts), repository, strings.Join(conflicts, ", "))
+				confirmed, err := prompt.ConfirmByTyping(os.Stdin, os.Stderr, msg, repository)
+				if err != nil {
+					return 0, err
+				}
+				if !confirmed {
+					return 0, &oerrors.Error{
+						Err:            fmt.Errorf("restore to %q cancelled", repository),
+						Recommendation: `Use "--on-conflict skip" to keep the existing tags, or "--yes" to overwrite them without prompting`,
+					}
+				}
+			}
+		}
+		if opts.onConflict == conflictPolicySkip {
+			var restoring int
+			for i, action := range actions {
+				if action != restoreActionOverwrite {
+					tags[restoring], roots[restoring], actions[restoring] = tags[i], roots[i], action
+					restoring++
+					continue
+				}
+				if err := metadataHandler.OnTagSkipped(tags[i], existing[i]); err != nil {
+					return 0, err
+				}
+			}
+			tags, roots, actions = tags[:restoring], roots[:restoring], actions[:restoring]
+		}
+	}
+
+	// resume from the progress saved by an interrupted restore
+	var state *restorestate.State
+	if !opts.DryRun {
+		state = openRestoreState(ctx, input, repository, opts.noResume)
+	}
+	if state != nil {
+		defer func() {
+			var err error
+			if returnErr == nil {
+				err = state.Remove()
+			} else {
+				err = state.Close()
+			}
+			if err != nil {
+				trace.Logger(ctx).Debugf("failed to save the restore progress: %v", err)
+			}
+		}()
+		if count := state.Count(); count > 0 {
+			if err := metadataHandler.OnRestoreResumed(count); err != nil {
+				return 0, err
+			}
+		}
 	}
 
 	// prepare copy options
@@ -199,19 +627,67 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 			return registry.Referrers(ctx, src, desc, "")
 		},
 	}
+	var restored int
 	for i, tag := range tags {
 		var referrerCount int
-		if !opts.excludeReferrers {
+		var subjects, referrers []ocispec.Descriptor
+		switch {
+		case opts.referrersOnly:
+			exists, err := dstRepo.Exists(ctx, roots[i])
+			if err != nil {
+				if err := onFailed(tag, err, fmt.Errorf("failed to check the existence of tag %q in %q: %w", tag, repository, err)); err != nil {
+					return 0, err
+				}
+				continue
+			}
+			if !exists {
+				err := fmt.Errorf("the artifact of tag %q, digest %q, is not found in %q", tag, roots[i].Digest, repository)
+				if err := onFailed(tag, err, &oerrors.Error{
+					Err:            err,
+					Recommendation: "Referrers can only be restored to existing artifacts. Copy the artifact to the target repository first, or restore without --referrers-only",
+				}); err != nil {
+					return 0, err
+				}
+				continue
+			}
+			subjects, referrers, err = findReferrers(ctx, src, tag, roots[i], extCopyGraphOpts)
+			if err != nil {
+				if errors.Is(err, contentutil.ErrCorrupted) {
+					err = onCorrupted(tag, err)
+				} else {
+					err = onFailed(tag, err, err)
+				}
+				if err != nil {
+					return 0, err
+				}
+				continue
+			}
+			referrerCount = len(referrers)
+		case !opts.excludeReferrers:
 			// count referrers from source
-			referrerCount, err = countReferrers(ctx, srcOCI, tag, roots[i], extCopyGraphOpts)
+			referrerCount, err = countReferrers(ctx, src, tag, roots[i], extCopyGraphOpts)
 			if err != nil {
-				return fmt.Errorf("failed to count referrers for tag %q: %w", tag, err)
+				if errors.Is(err, contentutil.ErrCorrupted) {
+					err = onCorrupted(tag, err)
+				} else {
+					err = onFailed(tag, err, fmt.Errorf("failed to count referrers for tag %q: %w", tag, err))
+				}
+				if err != nil {
+					return 0, err
+				}
+				continue
 			}
 		}
-		if opts.dryRun {
-			if err := metadataHandler.OnArtifactPushed(tag, referrerCount); err != nil {
-				return err
+		if opts.DryRun {
+			if opts.referrersOnly {
+				err = metadataHandler.OnArtifactPushed(tag, referrerCount)
+			} else {
+				err = metadataHandler.OnArtifactPlanned(tag, string(actions[i]), referrerCount)
+			}
+			if err != nil {
+				return 0, err
 			}
+			restored++
 			// dry run, skip actual copy
 			continue
 		}
@@ -221,6 +697,9 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 			if err != nil {
 				return err
 			}
+			if state != nil {
+				trackedDst = state.Target(trackedDst)
+			}
 			defer func() {
 				stopErr := statusHandler.StopTracking()
 				if retErr == nil {
@@ -228,20 +707,439 @@ func runRestore(cmd *cobra.Command, opts *restoreOptions) error {
 				}
 			}()
 
-			if opts.excludeReferrers {
-				_, err := oras.Copy(ctx, srcOCI, tag, trackedDst, tag, copyOpts)
+			switch {
+			case opts.referrersOnly:
+				return copyReferrers(ctx, src, trackedDst, subjects, referrers, copyOpts.CopyGraphOptions)
+			case opts.excludeReferrers:
+				_, err := oras.Copy(ctx, src, tag, trackedDst, tag, copyOpts)
 				return err
+			default:
+				return recursiveCopy(ctx, src, trackedDst, tag, roots[i], extCopyGraphOpts)
 			}
-			return recursiveCopy(ctx, srcOCI, trackedDst, tag, roots[i], extCopyGraphOpts)
 		}(); err != nil {
-			return fmt.Errorf("failed to restore tag %q from %q to %q: %w", tag, opts.input, opts.repository, oerrors.UnwrapCopyError(err))
+			err = oerrors.UnwrapCopyError(err)
+			if errors.Is(err, contentutil.ErrCorrupted) {
+				err = onCorrupted(tag, err)
+			} else {
+				err = onFailed(tag, err, fmt.Errorf("failed to restore tag %q from %q to %q: %w", tag, input, repository, err))
+			}
+			if err != nil {
+				return 0, err
+			}
+			continue
 		}
 
 		if err := metadataHandler.OnArtifactPushed(tag, referrerCount); err != nil {
-			return err
+			return 0, err
 		}
+		restored++
 	}
+	if len(failures) > 0 {
+		return restored, &oerrors.PartialFailureError{
+			Operation: "restore",
+			Total:     restored + len(failures),
+			Failures:  failures,
+		}
+	}
+	return restored, nil
+}
 
-	duration := time.Since(startTime)
-	return metadataHandler.OnRestoreCompleted(len(tags), opts.repository, duration)
+// readBackupRepository reads the catalog entry of the backup of a single
+// repository in the OCI layout at layout, or in the tar archive at input if
+// layout is empty. Nil is returned if the backup has no catalog.
+func readBackupRepository(input, layout string) (*backupcatalog.Repository, error) {
+	var catalog *backupcatalog.Catalog
+	var err error
+	if layout != "" {
+		catalog, err = backupcatalog.ReadDir(layout)
+	} else {
+		var f *os.File
+		if f, err = os.Open(input); err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		catalog, err = backupcatalog.ReadTar(f)
+	}
+	if err != nil {
+		if errors.Is(err, backupcatalog.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the catalog of %q: %w", input, err)
+	}
+	for _, repo := range catalog.Repositories {
+		if repo.Path == "" {
+			return &repo, nil
+		}
+	}
+	return nil, nil
+}
+
+// checkBackupLayout checks the number and total size of the blobs in the OCI
+// layout at layout against catalogRepo. The mismatch is logged as a warning if
+// opts.skipCorrupt is set, as the tags with corrupted content are skipped
+// when they are restored.
+func checkBackupLayout(opts *restoreOptions, layout string, catalogRepo *backupcatalog.Repository, logger logrus.FieldLogger) error {
+	if layout == "" || catalogRepo == nil {
+		return nil
+	}
+	count, size, err := backupcatalog.MeasureLayout(layout)
+	if err != nil {
+		return fmt.Errorf("failed to measure the OCI layout %q: %w", layout, err)
+	}
+	if count == catalogRepo.BlobCount && size == catalogRepo.Size {
+		return nil
+	}
+	err = fmt.Errorf("the backup of %q has %d blob(s) of %d bytes, but its catalog records %d blob(s) of %d bytes", catalogRepo.Name, count, size, catalogRepo.BlobCount, catalogRepo.Size)
+	if opts.skipCorrupt {
+		logger.Warnf("%v, skipping the tags with corrupted content", err)
+		return nil
+	}
+	return &oerrors.Error{
+		Err:            err,
+		Recommendation: "The backup is corrupted or incomplete. Use --skip-corrupt to restore only the tags with intact content",
+	}
+}
+
+// checkCatalogTag checks the manifest root tagged with tag against the digest
+// and size recorded in catalogRepo. Tags not in the catalog are not checked.
+func checkCatalogTag(catalogRepo *backupcatalog.Repository, tag string, root ocispec.Descriptor) error {
+	for _, t := range catalogRepo.Tags {
+		if t.Name != tag {
+			continue
+		}
+		if t.Digest != root.Digest.String() || t.Size != root.Size {
+			return fmt.Errorf("%w: the tag resolves to %s of %d bytes, but the backup catalog records %s of %d bytes", contentutil.ErrCorrupted, root.Digest, root.Size, t.Digest, t.Size)
+		}
+		return nil
+	}
+	return nil
+}
+
+// openRestoreState opens the saved progress of restoring input to repository,
+// discarding it if noResume is set. Nil is returned if the progress cannot be
+// saved.
+func openRestoreState(ctx context.Context, input, repository string, noResume bool) *restorestate.State {
+	logger := trace.Logger(ctx)
+	dir, err := restorestate.DefaultDir()
+	if err != nil {
+		logger.Debugf("restore progress is not saved: %v", err)
+		return nil
+	}
+	key, err := restorestate.Key(input, repository)
+	if err != nil {
+		logger.Debugf("restore progress is not saved: %v", err)
+		return nil
+	}
+	state, err := restorestate.Open(dir, key)
+	if err != nil {
+		logger.Debugf("restore progress is not saved: %v", err)
+		return nil
+	}
+	if noResume {
+		if err := state.Remove(); err != nil {
+			logger.Debugf("restore progress is not saved: %v", err)
+			return nil
+		}
+		if state, err = restorestate.Open(dir, key); err != nil {
+			logger.Debugf("restore progress is not saved: %v", err)
+			return nil
+		}
+	}
+	return state
+}
+
+// parseRestoreInput reads the catalog of the input if it is a backup of
+// multiple repositories.
+func parseRestoreInput(opts *restoreOptions) error {
+	fi, err := os.Stat(opts.input)
+	if err != nil || !fi.IsDir() {
+		// a tar archive contains the backup of a single repository
+		return nil
+	}
+	catalog, err := backupcatalog.ReadDir(opts.input)
+	if err != nil {
+		if errors.Is(err, backupcatalog.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to read the catalog of %q: %w", opts.input, err)
+	}
+	for _, repo := range catalog.Repositories {
+		if repo.Path != "" {
+			opts.catalog = catalog
+			return nil
+		}
+	}
+	return nil
+}
+
+// parseRestoreNamespace parses the target of restoring a backup of multiple
+// repositories in the form of <registry>[/<namespace>].
+func parseRestoreNamespace(target string) (string, string, error) {
+	host, namespace, _ := strings.Cut(target, "/")
+	ref := registry.Reference{
+		Registry:   host,
+		Repository: namespace,
+	}
+	if err := ref.ValidateRegistry(); err != nil {
+		return "", "", &oerrors.Error{
+			Err:            fmt.Errorf("invalid target %q: %w", target, err),
+			Recommendation: "To restore a backup of multiple repositories, specify the target in the form of <registry>[/<namespace>]",
+		}
+	}
+	if namespace != "" {
+		if err := ref.ValidateRepository(); err != nil {
+			return "", "", &oerrors.Error{
+				Err:            fmt.Errorf("invalid target %q: %w", target, err),
+				Recommendation: "To restore a backup of multiple repositories, specify the target in the form of <registry>[/<namespace>] without tags or digests",
+			}
+		}
+	}
+	return host, namespace, nil
+}
+
+// selectRestoreRepository returns the name of repo without the registry and
+// the tags of it matching includeTags if repo is selected by includeRepos and
+// excludeRepos, and has any matching tag.
+func selectRestoreRepository(repo backupcatalog.Repository, includeRepos, excludeRepos, includeTags []string) (string, []string, bool) {
+	name := repo.Name
+	if ref, err := registry.ParseReference(repo.Name); err == nil {
+		name = ref.Repository
+	}
+	if len(includeRepos) > 0 && !matchAny(includeRepos, name) {
+		return "", nil, false
+	}
+	if matchAny(excludeRepos, name) {
+		return "", nil, false
+	}
+	var tags []string
+	for _, tag := range repo.Tags {
+		if len(includeTags) == 0 || matchAny(includeTags, tag.Name) {
+			tags = append(tags, tag.Name)
+		}
+	}
+	return name, tags, len(tags) > 0
+}
+
+// filterTags returns the tags matching any of patterns along with their
+// descriptors. All tags are returned if there is no pattern.
+func filterTags(tags []string, roots []ocispec.Descriptor, patterns []string) ([]string, []ocispec.Descriptor) {
+	if len(patterns) == 0 {
+		return tags, roots
+	}
+	var filteredTags []string
+	var filteredRoots []ocispec.Descriptor
+	for i, tag := range tags {
+		if matchAny(patterns, tag) {
+			filteredTags = append(filteredTags, tag)
+			filteredRoots = append(filteredRoots, roots[i])
+		}
+	}
+	return filteredTags, filteredRoots
+}
+
+// matchAny reports whether name matches any of the glob patterns.
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// policies for tags already existing in the target repository with different
+// digests.
+const (
+	conflictPolicyOverwrite = "overwrite"
+	conflictPolicySkip      = "skip"
+	conflictPolicyFail      = "fail"
+)
+
+// restoreAction is the action taken on a tag by restore.
+type restoreAction string
+
+const (
+	// restoreActionCreate indicates the tag does not exist in the target
+	// repository.
+	restoreActionCreate restoreAction = "create"
+	// restoreActionOverwrite indicates the tag exists in the target repository
+	// with a different digest.
+	restoreActionOverwrite restoreAction = "overwrite"
+	// restoreActionKeep indicates the tag exists in the target repository with
+	// the same digest.
+	restoreActionKeep restoreAction = "keep"
+)
+
+// planRestore resolves the tags in the target repository and returns the
+// action to take on each tag, along with the descriptors the tags currently
+// point to.
+func planRestore(ctx context.Context, target content.Resolver, tags []string, roots []ocispec.Descriptor) ([]restoreAction, []ocispec.Descriptor, error) {
+	actions := make([]restoreAction, len(tags))
+	existing := make([]ocispec.Descriptor, len(tags))
+	for i, tag := range tags {
+		desc, err := target.Resolve(ctx, tag)
+		switch {
+		case errors.Is(err, errdef.ErrNotFound):
+			actions[i] = restoreActionCreate
+		case err != nil:
+			return nil, nil, fmt.Errorf("failed to resolve tag %q in the target repository: %w", tag, err)
+		case desc.Digest == roots[i].Digest:
+			actions[i] = restoreActionKeep
+		default:
+			actions[i] = restoreActionOverwrite
+		}
+		existing[i] = desc
+	}
+	return actions, existing, nil
+}
+
+// restoreConflicts returns the tags that would be overwritten.
+func restoreConflicts(tags []string, actions []restoreAction) []string {
+	var conflicts []string
+	for i, action := range actions {
+		if action == restoreActionOverwrite {
+			conflicts = append(conflicts, tags[i])
+		}
+	}
+	return conflicts
+}
+
+// checkRestoreConflicts returns an error if any tag would be overwritten.
+func checkRestoreConflicts(tags []string, actions []restoreAction, repository string) error {
+	conflicts := restoreConflicts(tags, actions)
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return &oerrors.Error{
+		Err:            fmt.Errorf("%d tag(s) already exist in %q with different digests: %s", len(conflicts), repository, strings.Join(conflicts, ", ")),
+		Recommendation: `Use "--on-conflict skip" to keep the existing tags, or "--on-conflict overwrite" to replace them`,
+	}
+}
+
+// findVolumeSet returns the path of the tar archive volume set referred to by
+// input, which is either the path of the first volume or the path of the
+// volume set without the volume number.
+func findVolumeSet(input string) (string, bool) {
+	if volumeSet, ok := orasio.VolumeSetPath(input); ok {
+		return volumeSet, true
+	}
+	if _, err := os.Stat(input); !errors.Is(err, fs.ErrNotExist) {
+		return "", false
+	}
+	if _, err := os.Stat(orasio.VolumePath(input, 1)); err != nil {
+		return "", false
+	}
+	return input, true
+}
+
+// loadVolumeSet extracts the tar archive volume set at path into a temporary
+// directory and returns the OCI store on it, the directory and the size of the
+// volumes. The extraction fails early if the temporary directory does not have
+// enough space for the volumes.
+func loadVolumeSet(ctx context.Context, path string, tempDir *option.TempDir) (*oci.Store, string, int64, error) {
+	rc, _, size, err := orasio.OpenVolumes(path)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer rc.Close()
+	store, dir, err := extractArchive(ctx, rc, size, tempDir, "extracting the tar archive volumes")
+	if err != nil {
+		return nil, "", 0, err
+	}
+	return store, dir, size, nil
+}
+
+// loadRemoteArchive downloads the tar archive at the object storage or HTTP
+// URL and extracts it into a temporary directory while downloading, and
+// returns the OCI store on it, the directory and the size of the archive.
+func loadRemoteArchive(ctx context.Context, url string, tempDir *option.TempDir) (*oci.Store, string, int64, error) {
+	rc, size, err := openRemoteArchive(ctx, url)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer rc.Close()
+	cr := &countingReader{r: rc}
+	store, dir, err := extractArchive(ctx, cr, size, tempDir, "extracting the downloaded tar archive")
+	if err != nil {
+		return nil, "", 0, err
+	}
+	if size < 0 {
+		size = cr.n
+	}
+	return store, dir, size, nil
+}
+
+// openRemoteArchive opens the tar archive at the object storage or HTTP URL
+// for streaming its content, and returns the content and its size, which is
+// -1 if unknown. Interrupted HTTP downloads are resumed with range requests.
+func openRemoteArchive(ctx context.Context, url string) (io.ReadCloser, int64, error) {
+	if httpfile.IsURL(url) {
+		r, err := httpfile.Open(ctx, http.DefaultClient, url)
+		if err != nil {
+			return nil, 0, err
+		}
+		return r, r.Size(), nil
+	}
+	loc, err := objectstore.ParseURL(url)
+	if err != nil {
+		return nil, 0, err
+	}
+	client, err := objectstore.NewClient(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return client.Open(ctx, loc)
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader.
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// loadCompressedArchive extracts the compressed tar archive of size at path
+// into a temporary directory and returns the OCI store on it and the directory.
+func loadCompressedArchive(ctx context.Context, path string, size int64, tempDir *option.TempDir) (*oci.Store, string, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer fp.Close()
+	return extractArchive(ctx, fp, size, tempDir, "extracting the compressed tar archive")
+}
+
+// extractArchive extracts the tar archive of size read from r, decompressed if
+// compressed, into a temporary directory and returns the OCI store on it and
+// the directory. The extraction fails early if the temporary directory does not
+// have enough space for the archive.
+func extractArchive(ctx context.Context, r io.Reader, size int64, tempDir *option.TempDir, purpose string) (*oci.Store, string, error) {
+	if err := tempDir.CheckSpace(ctx, os.TempDir(), size, purpose); err != nil {
+		return nil, "", err
+	}
+	zr, err := compression.NewReader(r)
+	if err != nil {
+		return nil, "", err
+	}
+	defer zr.Close()
+	dir, err := os.MkdirTemp("", "oras-restore-*")
+	if err != nil {
+		return nil, "", err
+	}
+	if err := orasio.UntarDirectory(zr, dir); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, "", err
+	}
+	store, err := oci.NewWithContext(ctx, dir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, "", err
+	}
+	return store, dir, nil
 }
@@ -0,0 +1,262 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras/internal/backupcatalog"
+	"oras.land/oras/internal/contentutil"
+)
+
+func Test_planRestore(t *testing.T) {
+	ctx := context.Background()
+	target := memory.New()
+	push := func(data string) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte(data))
+		if err := target.Push(ctx, desc, bytes.NewReader([]byte(data))); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	old := push(`{"old":true}`)
+	same := push(`{"same":true}`)
+	if err := target.Tag(ctx, old, "changed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.Tag(ctx, same, "same"); err != nil {
+		t.Fatal(err)
+	}
+	updated := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte(`{"new":true}`))
+
+	tags := []string{"new", "changed", "same"}
+	roots := []ocispec.Descriptor{updated, updated, same}
+	actions, existing, err := planRestore(ctx, target, tags, roots)
+	if err != nil {
+		t.Fatalf("planRestore() error = %v", err)
+	}
+	wantActions := []restoreAction{restoreActionCreate, restoreActionOverwrite, restoreActionKeep}
+	if !reflect.DeepEqual(actions, wantActions) {
+		t.Errorf("planRestore() actions = %v, want %v", actions, wantActions)
+	}
+	wantExisting := []ocispec.Descriptor{{}, old, same}
+	if !reflect.DeepEqual(existing, wantExisting) {
+		t.Errorf("planRestore() existing = %v, want %v", existing, wantExisting)
+	}
+
+	if err := checkRestoreConflicts(tags, actions, "localhost:5000/test"); err == nil {
+		t.Error("checkRestoreConflicts() error = nil, want error")
+	}
+	if err := checkRestoreConflicts(tags[2:], actions[2:], "localhost:5000/test"); err != nil {
+		t.Errorf("checkRestoreConflicts() error = %v, want nil", err)
+	}
+}
+
+func Test_selectRestoreRepository(t *testing.T) {
+	repo := backupcatalog.Repository{
+		Name: "localhost:5000/team/app",
+		Path: "localhost_5000/team/app",
+		Tags: []backupcatalog.Tag{{Name: "v1.0"}, {Name: "v1.1"}, {Name: "v2.0"}},
+	}
+	tests := []struct {
+		name         string
+		includeRepos []string
+		excludeRepos []string
+		includeTags  []string
+		wantTags     []string
+		wantOK       bool
+	}{
+		{
+			name:     "no filter",
+			wantTags: []string{"v1.0", "v1.1", "v2.0"},
+			wantOK:   true,
+		},
+		{
+			name:         "included repository",
+			includeRepos: []string{"other", "team/*"},
+			wantTags:     []string{"v1.0", "v1.1", "v2.0"},
+			wantOK:       true,
+		},
+		{
+			name:         "not included repository",
+			includeRepos: []string{"other/*"},
+		},
+		{
+			name:         "excluded repository",
+			includeRepos: []string{"team/*"},
+			excludeRepos: []string{"team/app"},
+		},
+		{
+			name:        "included tags",
+			includeTags: []string{"v1.*"},
+			wantTags:    []string{"v1.0", "v1.1"},
+			wantOK:      true,
+		},
+		{
+			name:        "no included tags",
+			includeTags: []string{"v3.*"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, tags, ok := selectRestoreRepository(repo, tt.includeRepos, tt.excludeRepos, tt.includeTags)
+			if ok != tt.wantOK {
+				t.Fatalf("selectRestoreRepository() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if name != "team/app" {
+				t.Errorf("selectRestoreRepository() name = %v, want %v", name, "team/app")
+			}
+			if !reflect.DeepEqual(tags, tt.wantTags) {
+				t.Errorf("selectRestoreRepository() tags = %v, want %v", tags, tt.wantTags)
+			}
+		})
+	}
+}
+
+func Test_filterTags(t *testing.T) {
+	tags := []string{"v1.0", "latest", "v2.0"}
+	roots := []ocispec.Descriptor{{Digest: "sha256:a"}, {Digest: "sha256:b"}, {Digest: "sha256:c"}}
+	gotTags, gotRoots := filterTags(tags, roots, []string{"v*", "none"})
+	if want := []string{"v1.0", "v2.0"}; !reflect.DeepEqual(gotTags, want) {
+		t.Errorf("filterTags() tags = %v, want %v", gotTags, want)
+	}
+	if want := []ocispec.Descriptor{roots[0], roots[2]}; !reflect.DeepEqual(gotRoots, want) {
+		t.Errorf("filterTags() roots = %v, want %v", gotRoots, want)
+	}
+	if gotTags, _ := filterTags(tags, roots, nil); !reflect.DeepEqual(gotTags, tags) {
+		t.Errorf("filterTags() tags = %v, want %v", gotTags, tags)
+	}
+}
+
+func Test_parseRestoreNamespace(t *testing.T) {
+	tests := []struct {
+		target        string
+		wantRegistry  string
+		wantNamespace string
+		wantErr       bool
+	}{
+		{target: "localhost:5000", wantRegistry: "localhost:5000"},
+		{target: "localhost:5000/mirror/team", wantRegistry: "localhost:5000", wantNamespace: "mirror/team"},
+		{target: "localhost:5000/mirror:v1", wantErr: true},
+		{target: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			gotRegistry, gotNamespace, err := parseRestoreNamespace(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRestoreNamespace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotRegistry != tt.wantRegistry || gotNamespace != tt.wantNamespace {
+				t.Errorf("parseRestoreNamespace() = (%v, %v), want (%v, %v)", gotRegistry, gotNamespace, tt.wantRegistry, tt.wantNamespace)
+			}
+		})
+	}
+}
+
+func Test_checkCatalogTag(t *testing.T) {
+	root := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte(`{}`))
+	catalogRepo := &backupcatalog.Repository{
+		Tags: []backupcatalog.Tag{
+			backupcatalog.NewTag("v1", root, 0),
+			{Name: "v2", Digest: root.Digest.String(), Size: root.Size + 1},
+			{Name: "v3", Digest: "sha256:0000000000000000000000000000000000000000000000000000000000000000", Size: root.Size},
+		},
+	}
+	tests := []struct {
+		tag     string
+		wantErr bool
+	}{
+		{tag: "v1"},
+		{tag: "v2", wantErr: true},
+		{tag: "v3", wantErr: true},
+		{tag: "uncataloged"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			err := checkCatalogTag(catalogRepo, tt.tag, root)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkCatalogTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, contentutil.ErrCorrupted) {
+				t.Errorf("checkCatalogTag() error = %v, want %v", err, contentutil.ErrCorrupted)
+			}
+		})
+	}
+}
+
+func Test_checkBackupLayout(t *testing.T) {
+	layout := t.TempDir()
+	blobDir := filepath.Join(layout, ocispec.ImageBlobsDir, "sha256")
+	if err := os.MkdirAll(blobDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(blobDir, "aaaa"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		catalogRepo *backupcatalog.Repository
+		skipCorrupt bool
+		wantErr     bool
+	}{
+		{
+			name:        "matched",
+			catalogRepo: &backupcatalog.Repository{BlobCount: 1, Size: 5},
+		},
+		{
+			name: "no catalog",
+		},
+		{
+			name:        "missing blob",
+			catalogRepo: &backupcatalog.Repository{BlobCount: 2, Size: 10},
+			wantErr:     true,
+		},
+		{
+			name:        "truncated blob",
+			catalogRepo: &backupcatalog.Repository{BlobCount: 1, Size: 6},
+			wantErr:     true,
+		},
+		{
+			name:        "skip corrupt",
+			catalogRepo: &backupcatalog.Repository{BlobCount: 2, Size: 10},
+			skipCorrupt: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &restoreOptions{skipCorrupt: tt.skipCorrupt}
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+			if err := checkBackupLayout(opts, layout, tt.catalogRepo, logger); (err != nil) != tt.wantErr {
+				t.Errorf("checkBackupLayout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
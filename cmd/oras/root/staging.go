@@ -0,0 +1,141 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/fileref"
+	"oras.land/oras/internal/descriptor"
+)
+
+// stagingPattern is the pattern of the hidden staging directories created in
+// the output directory by atomic pulls.
+const stagingPattern = ".oras-pull-*"
+
+// stagedPath returns the path in the staging directory of po to write the file
+// at path, which is in the output directory, to. path is returned if po does
+// not pull atomically.
+func stagedPath(path string, po *pullOptions) string {
+	if po.staging == "" {
+		return path
+	}
+	rel, err := filepath.Rel(po.Output, path)
+	if err != nil {
+		return path
+	}
+	return filepath.Join(po.staging, rel)
+}
+
+// hasOutputAbsPath returns true if a file in the graph rooted at root is named
+// with an absolute path in the output directory of po. The file store rooted
+// at the staging directory rejects such names, so the files are written in
+// place instead.
+func hasOutputAbsPath(ctx context.Context, src content.Fetcher, root ocispec.Descriptor, po *pullOptions) (bool, error) {
+	output, err := filepath.Abs(po.Output)
+	if err != nil {
+		return false, err
+	}
+	inOutput := func(name string) bool {
+		return filepath.IsAbs(name) && isWithin(output, name)
+	}
+	if po.ManifestConfigRef != "" {
+		if configPath, _, err := fileref.Parse(po.ManifestConfigRef, ""); err == nil && inOutput(configPath) {
+			return true, nil
+		}
+	}
+	visited := make(map[digest.Digest]bool)
+	nodes := []ocispec.Descriptor{root}
+	for len(nodes) > 0 {
+		node := nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+		if inOutput(node.Annotations[ocispec.AnnotationTitle]) {
+			return true, nil
+		}
+		if !descriptor.IsManifest(node) || visited[node.Digest] {
+			continue
+		}
+		visited[node.Digest] = true
+		successors, err := content.Successors(ctx, src, node)
+		if err != nil {
+			return false, err
+		}
+		nodes = append(nodes, successors...)
+	}
+	return false, nil
+}
+
+// commitStaging moves the content of the staging directory into the output
+// directory, replacing the existing files of the same names, and removes the
+// staging directory. Existing directories are merged.
+func commitStaging(staging, output string) error {
+	if err := mergeDir(staging, output); err != nil {
+		if errors.Is(err, syscall.EXDEV) {
+			return &oerrors.Error{
+				Err:            fmt.Errorf("failed to move the pulled files into %s: %w", output, err),
+				Recommendation: "The output directory spans multiple file systems. Pull again with --no-atomic to write the files in place",
+			}
+		}
+		return fmt.Errorf("failed to move the pulled files into %s: %w", output, err)
+	}
+	return os.RemoveAll(staging)
+}
+
+// mergeDir moves the entries of the directory src into the directory dst.
+// Each file is renamed into place so that it is never observed partially
+// written.
+func mergeDir(src, dst string) error {
+	if err := os.MkdirAll(dst, 0777); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		srcPath := filepath.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+		fi, err := os.Lstat(dstPath)
+		switch {
+		case err == nil && entry.IsDir() && fi.IsDir():
+			if err := mergeDir(srcPath, dstPath); err != nil {
+				return err
+			}
+			continue
+		case err == nil && (entry.IsDir() || fi.IsDir()):
+			// a file cannot be renamed over a directory or the reverse
+			if err := os.RemoveAll(dstPath); err != nil {
+				return err
+			}
+		case err != nil && !errors.Is(err, fs.ErrNotExist):
+			return err
+		}
+		if err := os.Rename(srcPath, dstPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_commitStaging(t *testing.T) {
+	output := t.TempDir()
+	staging, err := os.MkdirTemp(output, stagingPattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// existing files
+	write(filepath.Join(output, "a.txt"), "old")
+	write(filepath.Join(output, "dir", "kept.txt"), "kept")
+	write(filepath.Join(output, "dir", "b.txt"), "old")
+	write(filepath.Join(output, "file-to-dir"), "old")
+	// staged files
+	write(filepath.Join(staging, "a.txt"), "new")
+	write(filepath.Join(staging, "dir", "b.txt"), "new")
+	write(filepath.Join(staging, "file-to-dir", "c.txt"), "new")
+	write(filepath.Join(staging, "new", "d.txt"), "new")
+
+	if err := commitStaging(staging, output); err != nil {
+		t.Fatalf("commitStaging() error = %v", err)
+	}
+	want := map[string]string{
+		"a.txt":             "new",
+		"dir/kept.txt":      "kept",
+		"dir/b.txt":         "new",
+		"file-to-dir/c.txt": "new",
+		"new/d.txt":         "new",
+	}
+	for name, content := range want {
+		got, err := os.ReadFile(filepath.Join(output, filepath.FromSlash(name)))
+		if err != nil {
+			t.Errorf("failed to read %s: %v", name, err)
+			continue
+		}
+		if string(got) != content {
+			t.Errorf("%s = %q, want %q", name, got, content)
+		}
+	}
+	if _, err := os.Stat(staging); !os.IsNotExist(err) {
+		t.Errorf("staging directory is not removed: %v", err)
+	}
+}
+
+func Test_stagedPath(t *testing.T) {
+	output := filepath.Join("out", "dir")
+	path := filepath.Join(output, "sub", "a.txt")
+	if got := stagedPath(path, &pullOptions{Output: output}); got != path {
+		t.Errorf("stagedPath() = %q, want %q", got, path)
+	}
+	staging := filepath.Join(output, ".oras-pull-1")
+	want := filepath.Join(staging, "sub", "a.txt")
+	if got := stagedPath(path, &pullOptions{Output: output, staging: staging}); got != want {
+		t.Errorf("stagedPath() = %q, want %q", got, want)
+	}
+}
@@ -35,6 +35,7 @@ import (
 type tagOptions struct {
 	option.Common
 	option.Target
+	option.History
 
 	concurrency int
 	targetRefs  []string
@@ -61,6 +62,9 @@ Example - Tag the manifest 'v1.0.1' in 'localhost:5000/hello' to 'v1.0.1', 'v1.0
 
 Example - Tag the manifest 'v1.0.1' to 'v1.0.2' in an OCI image layout folder 'layout-dir':
   oras tag --oci-layout layout-dir:v1.0.1 v1.0.2
+
+Example - [Experimental] Move the tag 'latest' to the manifest 'v1.0.2' and record the movement, shown by "oras history":
+  oras tag --record-history localhost:5000/hello:v1.0.2 latest
 `,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 && (args[0] == "list" || args[0] == "ls") {
@@ -95,6 +99,7 @@ Example - Tag the manifest 'v1.0.1' to 'v1.0.2' in an OCI image layout folder 'l
 		},
 	}
 
+	opts.EnableDryRunFlag()
 	option.ApplyFlags(&opts, cmd.Flags())
 	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 5, "concurrency level")
 	option.AddDeprecatedVerboseFlag(cmd.Flags())
@@ -116,16 +121,23 @@ func tagManifest(cmd *cobra.Command, opts *tagOptions) error {
 		return err
 	}
 
+	tagHistory := newTagHistory(&opts.History, "tag")
+	if err := tagHistory.resolve(ctx, target, opts.targetRefs...); err != nil {
+		return err
+	}
 	tagNOpts := oras.DefaultTagNOptions
 	tagNOpts.Concurrency = opts.concurrency
 	tagHandler := display.NewTagHandler(opts.Printer, opts.Target)
 	tagListener := listener.NewTagListener(target, tagHandler.OnTagging, tagHandler.OnTagged)
-	_, err = oras.TagN(
+	desc, err := oras.TagN(
 		ctx,
 		tagListener,
 		opts.Reference,
 		opts.targetRefs,
 		tagNOpts,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	return tagHistory.record(ctx, target, desc)
 }
@@ -0,0 +1,91 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/cmd/oras/internal/display/status/progress/humanize"
+	"oras.land/oras/internal/descriptor"
+)
+
+// transferEstimate is the estimated transfer of a graph before copying or
+// pulling it.
+type transferEstimate struct {
+	// Size is the number of bytes to transfer.
+	Size int64
+	// Count is the number of nodes to transfer.
+	Count int
+	// Skipped is the number of nodes existing at the destination, or not
+	// transferred otherwise.
+	Skipped int
+}
+
+// String returns the line reporting the estimate.
+func (e transferEstimate) String() string {
+	b := humanize.ToBytes(e.Size)
+	return fmt.Sprintf("Estimated transfer: %g %s in %d blobs, %d skipped", b.Size, b.Unit, e.Count, e.Skipped)
+}
+
+// estimateTransfer walks the graph rooted at root in src, along with the
+// referrers of its manifests found by findReferrers if not nil, and sums the
+// sizes of the nodes to transfer. The nodes for which skip returns true are
+// not transferred, and neither are the successors of a skipped manifest as
+// they are not copied either.
+func estimateTransfer(ctx context.Context, src content.Fetcher, root ocispec.Descriptor, findReferrers func(context.Context, ocispec.Descriptor) ([]ocispec.Descriptor, error), skip func(context.Context, ocispec.Descriptor) (bool, error)) (transferEstimate, error) {
+	var estimate transferEstimate
+	visited := map[digest.Digest]bool{root.Digest: true}
+	queue := []ocispec.Descriptor{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		skipped, err := skip(ctx, node)
+		if err != nil {
+			return transferEstimate{}, err
+		}
+		if skipped {
+			estimate.Skipped++
+		} else {
+			estimate.Size += node.Size
+			estimate.Count++
+		}
+
+		var next []ocispec.Descriptor
+		if !skipped || !descriptor.IsManifest(node) {
+			if next, err = content.Successors(ctx, src, node); err != nil {
+				return transferEstimate{}, err
+			}
+		}
+		if findReferrers != nil && descriptor.IsManifest(node) {
+			referrers, err := findReferrers(ctx, node)
+			if err != nil {
+				return transferEstimate{}, err
+			}
+			next = append(next, referrers...)
+		}
+		for _, desc := range next {
+			if !visited[desc.Digest] {
+				visited[desc.Digest] = true
+				queue = append(queue, desc)
+			}
+		}
+	}
+	return estimate, nil
+}
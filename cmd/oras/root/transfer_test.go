@@ -0,0 +1,103 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func Test_estimateTransfer(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	var layers []ocispec.Descriptor
+	for _, blob := range []string{"foo", "foobar"} {
+		desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, []byte(blob))
+		if err := src.Push(ctx, desc, strings.NewReader(blob)); err != nil {
+			t.Fatal(err)
+		}
+		layers = append(layers, desc)
+	}
+	root, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+		Layers: layers,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	referrer, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.sbom", oras.PackManifestOptions{
+		Subject: &root,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	empty := ocispec.DescriptorEmptyJSON.Size
+	findReferrers := func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		return src.Predecessors(ctx, desc)
+	}
+
+	tests := []struct {
+		name          string
+		findReferrers func(context.Context, ocispec.Descriptor) ([]ocispec.Descriptor, error)
+		existing      []ocispec.Descriptor
+		want          transferEstimate
+	}{
+		{
+			name: "nothing existing",
+			want: transferEstimate{Size: root.Size + empty + 9, Count: 4},
+		},
+		{
+			name:     "existing layer",
+			existing: []ocispec.Descriptor{layers[1]},
+			want:     transferEstimate{Size: root.Size + empty + 3, Count: 3, Skipped: 1},
+		},
+		{
+			name:     "existing manifest",
+			existing: []ocispec.Descriptor{root},
+			want:     transferEstimate{Skipped: 1},
+		},
+		{
+			name:          "referrers",
+			findReferrers: findReferrers,
+			existing:      []ocispec.Descriptor{layers[0], layers[1]},
+			want:          transferEstimate{Size: root.Size + empty + referrer.Size, Count: 3, Skipped: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skip := func(_ context.Context, desc ocispec.Descriptor) (bool, error) {
+				for _, existing := range tt.existing {
+					if content.Equal(desc, existing) {
+						return true, nil
+					}
+				}
+				return false, nil
+			}
+			got, err := estimateTransfer(ctx, src, root, tt.findReferrers, skip)
+			if err != nil {
+				t.Fatalf("estimateTransfer() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("estimateTransfer() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
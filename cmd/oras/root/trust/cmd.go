@@ -0,0 +1,74 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trust
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/trust"
+)
+
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trust [command]",
+		Short: "[Experimental] Manage trusted signing roots and trust policies",
+		Long: `[Experimental] Manage trusted signing roots and trust policies
+
+Trusted roots are X.509 certificates or public keys verifying the signatures of
+artifacts. Trust policies select the roots trusted for the artifacts of the
+repositories matching their scopes. They are stored in the directory 'trust'
+next to the oras config file.`,
+	}
+
+	cmd.AddCommand(
+		rootCmd(),
+		policyCmd(),
+	)
+	return cmd
+}
+
+// defaultStore returns the trust store in the default directory.
+func defaultStore() (*trust.Store, error) {
+	dir, err := trust.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return trust.NewStore(dir), nil
+}
+
+// storeError adds recommendations to the errors of the trust store.
+func storeError(err error) error {
+	switch {
+	case errors.Is(err, trust.ErrAlreadyExists):
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: "use --force to replace it",
+		}
+	case errors.Is(err, trust.ErrInUse):
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: `remove the policies with "oras trust policy remove" or update them with "oras trust policy add --force" first`,
+		}
+	case errors.Is(err, trust.ErrNotFound):
+		return &oerrors.Error{
+			Err:            err,
+			Recommendation: `list the existing ones with "oras trust root list" or "oras trust policy list"`,
+		}
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trust
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/argument"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/internal/trust"
+)
+
+func policyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy [command]",
+		Short: "[Experimental] Manage trust policies",
+	}
+
+	cmd.AddCommand(
+		policyAddCmd(),
+		policyListCmd(),
+		policyRemoveCmd(),
+	)
+	return cmd
+}
+
+type policyAddOptions struct {
+	policy trust.Policy
+	force  bool
+}
+
+func policyAddCmd() *cobra.Command {
+	var opts policyAddOptions
+	cmd := &cobra.Command{
+		Use:   "add [flags] --scope <repository> <name>",
+		Short: "[Experimental] Add a trust policy",
+		Long: `[Experimental] Add a trust policy selecting the roots trusted for the artifacts of repositories
+
+Scopes are patterns of repositories in the form of <registry>/<repository>,
+where '*' matches any sequence of characters other than '/'. The scope '*'
+matches all repositories. The first policy matching a repository applies.
+
+Example - Trust the root 'acme-ca' for the repositories under 'registry.example.com/prod':
+  oras trust policy add --scope 'registry.example.com/prod/*' --trusted-root acme-ca prod
+
+Example - Only log the verification failures of the repositories under 'registry.example.com/dev':
+  oras trust policy add --scope 'registry.example.com/dev/*' --trusted-root acme-ca --verification audit dev
+
+Example - Skip the verification of all other repositories:
+  oras trust policy add --scope '*' --verification skip default
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the name of the policy"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.policy.Name = args[0]
+			return runPolicyAdd(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.policy.Scopes, "scope", "", nil, "`repository` pattern applying the policy, can be specified multiple times")
+	cmd.Flags().StringArrayVarP(&opts.policy.TrustedRoots, "trusted-root", "", nil, "`name` of a trusted root, can be specified multiple times")
+	cmd.Flags().StringVarP(&opts.policy.Verification, "verification", "", trust.VerificationEnforce, fmt.Sprintf("verification `level`, options: %s, %s, %s", trust.VerificationEnforce, trust.VerificationAudit, trust.VerificationSkip))
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "replace the policy if it exists")
+	_ = cmd.MarkFlagRequired("scope")
+	return cmd
+}
+
+func runPolicyAdd(cmd *cobra.Command, opts *policyAddOptions) error {
+	store, err := defaultStore()
+	if err != nil {
+		return err
+	}
+	if err := store.AddPolicy(opts.policy, opts.force); err != nil {
+		return storeError(err)
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), "Added trust policy", opts.policy.Name)
+	return err
+}
+
+func policyListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list [flags]",
+		Aliases: []string{"ls"},
+		Short:   "[Experimental] List the trust policies",
+		Long: `[Experimental] List the trust policies in the order of matching
+
+Example - List the trust policies:
+  oras trust policy list
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(0), "no arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPolicyList(cmd)
+		},
+	}
+}
+
+func runPolicyList(cmd *cobra.Command) error {
+	store, err := defaultStore()
+	if err != nil {
+		return err
+	}
+	policies, err := store.Policies()
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tSCOPES\tTRUSTED ROOTS\tVERIFICATION")
+	for _, p := range policies {
+		roots := "-"
+		if len(p.TrustedRoots) > 0 {
+			roots = strings.Join(p.TrustedRoots, ",")
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", p.Name, strings.Join(p.Scopes, ","), roots, p.Verification)
+	}
+	return tw.Flush()
+}
+
+func policyRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove [flags] <name>...",
+		Aliases: []string{"rm"},
+		Short:   "[Experimental] Remove trust policies",
+		Long: `[Experimental] Remove trust policies
+
+Example - Remove the trust policy 'dev':
+  oras trust policy remove dev
+`,
+		Args: oerrors.CheckArgs(argument.AtLeast(1), "the names of the policies to remove"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPolicyRemove(cmd, args)
+		},
+	}
+}
+
+func runPolicyRemove(cmd *cobra.Command, names []string) error {
+	store, err := defaultStore()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := store.RemovePolicy(name); err != nil {
+			return storeError(err)
+		}
+		if _, err := fmt.Fprintln(cmd.OutOrStdout(), "Removed trust policy", name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trust
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"oras.land/oras/cmd/oras/internal/argument"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+)
+
+func rootCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "root [command]",
+		Short: "[Experimental] Manage trusted signing roots",
+	}
+
+	cmd.AddCommand(
+		rootAddCmd(),
+		rootListCmd(),
+		rootRemoveCmd(),
+	)
+	return cmd
+}
+
+type rootAddOptions struct {
+	name  string
+	path  string
+	force bool
+}
+
+func rootAddCmd() *cobra.Command {
+	var opts rootAddOptions
+	cmd := &cobra.Command{
+		Use:   "add [flags] <name> <file>",
+		Short: "[Experimental] Add a trusted signing root",
+		Long: `[Experimental] Add a PEM encoded X.509 certificate or public key as a trusted signing root
+
+Example - Add the certificate 'ca.crt' as the trusted root 'acme-ca':
+  oras trust root add acme-ca ca.crt
+
+Example - Replace the trusted root 'release-key' with the public key 'cosign.pub':
+  oras trust root add --force release-key cosign.pub
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(2), "the name of the root and the certificate or public key file"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.name = args[0]
+			opts.path = args[1]
+			return runRootAdd(cmd, &opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "replace the root if it exists")
+	return cmd
+}
+
+func runRootAdd(cmd *cobra.Command, opts *rootAddOptions) error {
+	store, err := defaultStore()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(opts.path)
+	if err != nil {
+		return err
+	}
+	root, err := store.AddRoot(opts.name, data, opts.force)
+	if err != nil {
+		return storeError(err)
+	}
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Added %s root %s: %s\n", root.Type, root.Name, root.Fingerprint)
+	return err
+}
+
+func rootListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list [flags]",
+		Aliases: []string{"ls"},
+		Short:   "[Experimental] List the trusted signing roots",
+		Long: `[Experimental] List the trusted signing roots
+
+Example - List the trusted roots:
+  oras trust root list
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(0), "no arguments"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRootList(cmd)
+		},
+	}
+}
+
+func runRootList(cmd *cobra.Command) error {
+	store, err := defaultStore()
+	if err != nil {
+		return err
+	}
+	roots, err := store.Roots()
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tTYPE\tFINGERPRINT\tSUBJECT\tEXPIRES")
+	for _, root := range roots {
+		subject, expires := "-", "-"
+		if root.Subject != "" {
+			subject = root.Subject
+		}
+		if root.NotAfter != nil {
+			expires = root.NotAfter.UTC().Format(time.RFC3339)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", root.Name, root.Type, root.Fingerprint, subject, expires)
+	}
+	return tw.Flush()
+}
+
+func rootRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove [flags] <name>...",
+		Aliases: []string{"rm"},
+		Short:   "[Experimental] Remove trusted signing roots",
+		Long: `[Experimental] Remove trusted signing roots not trusted by any trust policy
+
+Example - Remove the trusted root 'acme-ca':
+  oras trust root remove acme-ca
+`,
+		Args: oerrors.CheckArgs(argument.AtLeast(1), "the names of the roots to remove"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRootRemove(cmd, args)
+		},
+	}
+}
+
+func runRootRemove(cmd *cobra.Command, names []string) error {
+	store, err := defaultStore()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := store.RemoveRoot(name); err != nil {
+			return storeError(err)
+		}
+		if _, err := fmt.Fprintln(cmd.OutOrStdout(), "Removed root", name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
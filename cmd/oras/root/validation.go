@@ -0,0 +1,98 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2/content"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/config"
+	"oras.land/oras/internal/mediatype"
+)
+
+// validateArtifact validates the artifact type and layer media types of the
+// packed manifest root against the validation policy configured for the
+// registry of target. With force, violations are logged as warnings instead.
+func validateArtifact(ctx context.Context, fetcher content.Fetcher, root ocispec.Descriptor, target *option.Target, force bool, logger logrus.FieldLogger) error {
+	policy := target.Validation()
+	if len(policy.ArtifactTypes) == 0 && len(policy.LayerMediaTypes) == 0 {
+		return nil
+	}
+	err := validateManifest(ctx, fetcher, root, policy)
+	if err == nil {
+		return nil
+	}
+	if force {
+		logger.Warnf("pushing %s violating the validation policy: %v", root.Digest, err)
+		return nil
+	}
+	return &oerrors.Error{
+		Err:            fmt.Errorf("%s violates the validation policy of the registry: %w", target.GetDisplayReference(), err),
+		Recommendation: "Fix the artifact type or the layer media types, or use --force to push the artifact anyway",
+	}
+}
+
+// validateArtifactType validates the syntax of artifactType and warns about
+// the ones likely mistyped from well-known artifact types. Types similar to a
+// well-known type may be intended, such as a new version of it, so they are
+// not declined.
+func validateArtifactType(artifactType string, logger logrus.FieldLogger) error {
+	if err := mediatype.Validate(artifactType); err != nil {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("invalid artifact type %q: %w", artifactType, err),
+			Recommendation: `Specify the artifact type in the form of "<type>/<subtype>", e.g. "application/vnd.example+json"`,
+		}
+	}
+	suggestions := mediatype.Suggest(artifactType)
+	if len(suggestions) == 0 {
+		return nil
+	}
+	candidates := make([]string, 0, len(suggestions))
+	for _, s := range suggestions {
+		candidates = append(candidates, fmt.Sprintf("%q (%s)", s.MediaType, s.Name))
+	}
+	logger.Warnf("artifact type %q is similar to the well-known type %s, did you mean it?", artifactType, strings.Join(candidates, " or "))
+	return nil
+}
+
+// validateManifest validates the manifest root against policy.
+func validateManifest(ctx context.Context, fetcher content.Fetcher, root ocispec.Descriptor, policy config.Validation) error {
+	manifestJSON, err := content.FetchAll(ctx, fetcher, root)
+	if err != nil {
+		return err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return err
+	}
+	artifactType := manifest.ArtifactType
+	if artifactType == "" && manifest.Config.MediaType != ocispec.MediaTypeEmptyJSON {
+		// the config media type is the artifact type if not specified
+		artifactType = manifest.Config.MediaType
+	}
+	layerMediaTypes := make([]string, 0, len(manifest.Layers))
+	for _, layer := range manifest.Layers {
+		layerMediaTypes = append(layerMediaTypes, layer.MediaType)
+	}
+	return policy.Validate(artifactType, layerMediaTypes)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras/internal/config"
+)
+
+func Test_validateManifest(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	layer := ocispec.Descriptor{MediaType: "application/vnd.mycorp.layer", Digest: ocispec.DescriptorEmptyJSON.Digest, Size: ocispec.DescriptorEmptyJSON.Size}
+	pack := func(version oras.PackManifestVersion, artifactType string) ocispec.Descriptor {
+		desc, err := oras.PackManifest(ctx, store, version, artifactType, oras.PackManifestOptions{Layers: []ocispec.Descriptor{layer}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	policy := config.Validation{
+		ArtifactTypes:   []string{"application/vnd.mycorp.*"},
+		LayerMediaTypes: []string{"application/vnd.mycorp.*"},
+	}
+	tests := []struct {
+		name    string
+		root    ocispec.Descriptor
+		wantErr bool
+	}{
+		{name: "allowed", root: pack(oras.PackManifestVersion1_1, "application/vnd.mycorp.firmware")},
+		{name: "allowed config media type", root: pack(oras.PackManifestVersion1_0, "application/vnd.mycorp.config")},
+		{name: "not allowed", root: pack(oras.PackManifestVersion1_1, "application/vnd.other.firmware"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateManifest(ctx, store, tt.root, policy); (err != nil) != tt.wantErr {
+				t.Errorf("validateManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateArtifactType(t *testing.T) {
+	tests := []struct {
+		name         string
+		artifactType string
+		wantErr      bool
+		wantWarning  string
+	}{
+		{name: "known", artifactType: "application/vnd.cyclonedx+json"},
+		{name: "custom", artifactType: "doc/example"},
+		{name: "invalid", artifactType: "application/spdx json", wantErr: true},
+		{name: "invalid parameter", artifactType: "application/spdx+json; version=2.3", wantErr: true},
+		{name: "mistyped", artifactType: "application/vnd.cyclonedx+jsn", wantWarning: `did you mean it?`},
+		{name: "new version", artifactType: "application/vnd.cncf.helm.config.v2+json", wantWarning: `"application/vnd.cncf.helm.config.v1+json"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&logs)
+			if err := validateArtifactType(tt.artifactType, logger); (err != nil) != tt.wantErr {
+				t.Errorf("validateArtifactType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantWarning == "" && logs.Len() != 0 {
+				t.Errorf("validateArtifactType() warned %q, want no warning", logs.String())
+			}
+			if !strings.Contains(logs.String(), strings.ReplaceAll(tt.wantWarning, `"`, `\"`)) {
+				t.Errorf("validateArtifactType() warned %q, want %q", logs.String(), tt.wantWarning)
+			}
+		})
+	}
+}
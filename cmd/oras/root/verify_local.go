@@ -0,0 +1,236 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras/cmd/oras/internal/argument"
+	"oras.land/oras/cmd/oras/internal/command"
+	oerrors "oras.land/oras/cmd/oras/internal/errors"
+	"oras.land/oras/cmd/oras/internal/option"
+	"oras.land/oras/internal/delta"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/graph"
+	"oras.land/oras/internal/split"
+)
+
+// Results of verifying a local file.
+const (
+	localFileVerified = "Verified"
+	localFileModified = "Modified"
+	localFileMissing  = "Missing"
+	localFileSkipped  = "Skipped"
+)
+
+type verifyLocalOptions struct {
+	option.Common
+	option.Platform
+	option.Target
+
+	dir           string
+	pathTraversal bool
+}
+
+func verifyLocalCmd() *cobra.Command {
+	var opts verifyLocalOptions
+	cmd := &cobra.Command{
+		Use:   "verify-local [flags] <dir> --against <name>{:<tag>|@<digest>}",
+		Short: "[Experimental] Verify pulled files against the artifact they were pulled from",
+		Long: `[Experimental] Verify pulled files against the artifact they were pulled from
+
+The files named by the title annotations of the artifact layers are re-hashed
+in the directory and compared with the layer digests and sizes, detecting files
+tampered with or corrupted after being pulled. Files reassembled from split
+chunks or delta layers are compared with the digests of the whole files.
+Directories pulled from compressed layers cannot be verified and are skipped.
+
+Example - Verify the files pulled into the current directory:
+  oras verify-local . --against localhost:5000/hello:v1
+
+Example - Verify the files pulled for a specific platform of a multi-arch artifact:
+  oras verify-local ./files --against localhost:5000/hello:v1 --platform linux/arm64
+
+Example - Verify the files pulled from an OCI image layout folder 'layout-dir':
+  oras verify-local ./files --against layout-dir:v1 --oci-layout
+`,
+		Args: oerrors.CheckArgs(argument.Exactly(1), "the directory of the pulled files to verify"),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			opts.dir = args[0]
+			return option.Parse(cmd, &opts)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerifyLocal(cmd, &opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.RawReference, "against", "", "", "`reference` of the artifact the files were pulled from")
+	cmd.Flags().BoolVarP(&opts.pathTraversal, "allow-path-traversal", "T", false, "allow verifying files out of the directory")
+	_ = cmd.MarkFlagRequired("against")
+	option.ApplyFlags(&opts, cmd.Flags())
+	return oerrors.Command(cmd, &opts.Target)
+}
+
+func runVerifyLocal(cmd *cobra.Command, opts *verifyLocalOptions) error {
+	ctx, logger := command.GetLogger(cmd, &opts.Common)
+	target, err := opts.NewReadonlyTarget(ctx, opts.Common, logger)
+	if err != nil {
+		return err
+	}
+	if err := opts.EnsureReferenceNotEmpty(cmd, true); err != nil {
+		return err
+	}
+	resolveOpts := oras.DefaultResolveOptions
+	resolveOpts.TargetPlatform = opts.Platform.Platform
+	desc, err := oras.Resolve(ctx, target, opts.Reference, resolveOpts)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", opts.Reference, err)
+	}
+	if !descriptor.IsImageManifest(desc) {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%s is not an image manifest and has no files to verify", desc.Digest),
+			Recommendation: "Use --platform to select a manifest of a multi-arch artifact",
+		}
+	}
+	files, err := pulledFiles(ctx, target, desc)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no named files found in %s", opts.GetDisplayReference())
+	}
+
+	var failed int
+	for _, f := range files {
+		name := f.Annotations[ocispec.AnnotationTitle]
+		result, err := verifyLocalFile(opts.dir, f, opts.pathTraversal)
+		if err != nil {
+			return err
+		}
+		if result == localFileModified || result == localFileMissing {
+			failed++
+		}
+		if err := opts.Printer.Println(result, name); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return &oerrors.Error{
+			Err:            fmt.Errorf("%d of %d files in %s do not match %s", failed, len(files), opts.dir, opts.GetDisplayReference()),
+			Recommendation: "Pull the artifact again to restore the files",
+		}
+	}
+	return nil
+}
+
+// pulledFiles returns the descriptors of the files pulled from the manifest,
+// named by their title annotations. The whole files of split chunks and delta
+// layers are returned instead of the layers.
+func pulledFiles(ctx context.Context, fetcher content.Fetcher, manifestDesc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	nodes, _, config, err := graph.Successors(ctx, fetcher, manifestDesc)
+	if err != nil {
+		return nil, err
+	}
+	var files, chunks []ocispec.Descriptor
+	var splitFiles []split.File
+	if config != nil {
+		if config.MediaType == split.MediaTypeChunkIndex {
+			if splitFiles, err = loadChunkIndexes(ctx, fetcher, []ocispec.Descriptor{*config}); err != nil {
+				return nil, err
+			}
+		} else if config.Annotations[ocispec.AnnotationTitle] != "" {
+			files = append(files, *config)
+		}
+	}
+	for _, node := range nodes {
+		switch {
+		case delta.IsDelta(node):
+			target, err := delta.Target(node)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, target)
+		case split.IsChunk(node):
+			chunks = append(chunks, node)
+		case node.Annotations[ocispec.AnnotationTitle] != "":
+			files = append(files, node)
+		}
+	}
+	if len(chunks) > 0 {
+		grouped, err := split.Group(chunks)
+		if err != nil {
+			return nil, err
+		}
+		splitFiles = append(grouped, splitFiles...)
+	}
+	for _, f := range splitFiles {
+		files = append(files, f.Descriptor())
+	}
+	return files, nil
+}
+
+// verifyLocalFile re-hashes the file pulled into dir for desc and returns
+// whether it is verified, modified, missing or skipped.
+func verifyLocalFile(dir string, desc ocispec.Descriptor, pathTraversal bool) (string, error) {
+	name := desc.Annotations[ocispec.AnnotationTitle]
+	if desc.Annotations[file.AnnotationUnpack] == "true" {
+		// the digest is of the compressed directory
+		return localFileSkipped, nil
+	}
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if !pathTraversal && !isWithin(dir, path) {
+		return "", fmt.Errorf("%s: %w", name, file.ErrPathTraversalDisallowed)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return localFileMissing, nil
+		}
+		return "", err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if !fi.Mode().IsRegular() || fi.Size() != desc.Size {
+		return localFileModified, nil
+	}
+	if err := desc.Digest.Validate(); err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	digester := desc.Digest.Algorithm().Digester()
+	if _, err := io.Copy(digester.Hash(), f); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if digester.Digest() != desc.Digest {
+		return localFileModified, nil
+	}
+	return localFileVerified, nil
+}
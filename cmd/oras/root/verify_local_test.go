@@ -0,0 +1,74 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+)
+
+func Test_verifyLocalFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "intact.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tampered.txt"), []byte("HELLO"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "truncated.txt"), []byte("hell"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	named := func(name string, annotations ...string) ocispec.Descriptor {
+		desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, []byte("hello"))
+		desc.Annotations = map[string]string{ocispec.AnnotationTitle: name}
+		for i := 0; i+1 < len(annotations); i += 2 {
+			desc.Annotations[annotations[i]] = annotations[i+1]
+		}
+		return desc
+	}
+	tests := []struct {
+		name          string
+		desc          ocispec.Descriptor
+		pathTraversal bool
+		want          string
+		wantErr       error
+	}{
+		{name: "verified", desc: named("intact.txt"), want: localFileVerified},
+		{name: "modified content", desc: named("tampered.txt"), want: localFileModified},
+		{name: "modified size", desc: named("truncated.txt"), want: localFileModified},
+		{name: "missing", desc: named("deleted.txt"), want: localFileMissing},
+		{name: "directory", desc: named("intact.txt", file.AnnotationUnpack, "true"), want: localFileSkipped},
+		{name: "path traversal", desc: named("../intact.txt"), wantErr: file.ErrPathTraversalDisallowed},
+		{name: "path traversal allowed", desc: named("../intact.txt"), pathTraversal: true, want: localFileMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifyLocalFile(dir, tt.desc, tt.pathTraversal)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("verifyLocalFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("verifyLocalFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras/cmd/oras/internal/display/metadata"
+	"oras.land/oras/cmd/oras/internal/fileref"
+)
+
+// Media types of WASM artifacts.
+// Reference: https://tag-runtime.cncf.io/wgs/wasm/deliverables/wasm-oci-artifact/
+const (
+	wasmConfigMediaType = "application/vnd.wasm.config.v0+json"
+	wasmLayerMediaType  = "application/wasm"
+)
+
+// WASM binary headers: the magic number followed by the version and layer.
+var (
+	wasmMagic           = []byte{0x00, 0x61, 0x73, 0x6d}
+	wasmModuleVersion   = []byte{0x01, 0x00, 0x00, 0x00}
+	wasmComponentHeader = []byte{0x0d, 0x00, 0x01, 0x00}
+)
+
+// Target OSes of WASM artifacts.
+const (
+	wasmOSModule    = "wasip1"
+	wasmOSComponent = "wasip2"
+)
+
+// wasmConfig is the config of a WASM artifact.
+type wasmConfig struct {
+	Created      *time.Time      `json:"created,omitempty"`
+	Author       string          `json:"author,omitempty"`
+	Architecture string          `json:"architecture"`
+	OS           string          `json:"os"`
+	LayerDigests []digest.Digest `json:"layerDigests"`
+}
+
+// wasmOS returns the target OS of the WASM binary with header, or an error if
+// header is not of a WASM core module or component.
+func wasmOS(header []byte) (string, error) {
+	if len(header) < 8 || !bytes.Equal(header[:4], wasmMagic) {
+		return "", fmt.Errorf("missing the WASM magic number")
+	}
+	switch version := header[4:8]; {
+	case bytes.Equal(version, wasmModuleVersion):
+		return wasmOSModule, nil
+	case bytes.Equal(version, wasmComponentHeader):
+		return wasmOSComponent, nil
+	default:
+		return "", fmt.Errorf("unsupported WASM binary version %x", version)
+	}
+}
+
+// readWasmOS returns the target OS of the WASM binary at path.
+func readWasmOS(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(f, header); err != nil && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	targetOS, err := wasmOS(header)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a WASM module or component: %w", path, err)
+	}
+	return targetOS, nil
+}
+
+// prepareWasmFiles sets the WASM layer media type to the referenced files,
+// which must be WASM binaries of the same target OS. The file references with
+// the media type and the target OS are returned.
+func prepareWasmFiles(fileRefs []string) ([]string, string, error) {
+	if len(fileRefs) == 0 {
+		return nil, "", fmt.Errorf("no WASM module or component provided")
+	}
+	var targetOS string
+	refs := make([]string, 0, len(fileRefs))
+	for _, fileRef := range fileRefs {
+		path, mediaType, err := fileref.Parse(fileRef, "")
+		if err != nil {
+			return nil, "", err
+		}
+		if mediaType != "" && mediaType != wasmLayerMediaType {
+			return nil, "", fmt.Errorf("%s: media type %s is not allowed for WASM artifacts", path, mediaType)
+		}
+		fileOS, err := readWasmOS(path)
+		if err != nil {
+			return nil, "", err
+		}
+		if targetOS != "" && fileOS != targetOS {
+			return nil, "", fmt.Errorf("%s: WASM core modules and components cannot be mixed", path)
+		}
+		targetOS = fileOS
+		refs = append(refs, path+":"+wasmLayerMediaType)
+	}
+	return refs, targetOS, nil
+}
+
+// pushWasmConfig pushes the config of the WASM artifact of the layers for
+// targetOS to store.
+func pushWasmConfig(ctx context.Context, store *file.Store, layers []ocispec.Descriptor, targetOS string, annotations map[string]string) (ocispec.Descriptor, error) {
+	created := time.Now().UTC().Truncate(time.Second)
+	if value, ok := annotations[ocispec.AnnotationCreated]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			created = t
+		}
+	}
+	config := wasmConfig{
+		Created:      &created,
+		Author:       annotations[ocispec.AnnotationAuthors],
+		Architecture: "wasm",
+		OS:           targetOS,
+	}
+	for _, layer := range layers {
+		config.LayerDigests = append(config.LayerDigests, layer.Digest)
+	}
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	desc := content.NewDescriptorFromBytes(wasmConfigMediaType, configJSON)
+	if err := store.Push(ctx, desc, bytes.NewReader(configJSON)); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// wasmModuleName returns the name of the WASM module in the file at path.
+func wasmModuleName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
+// wasmVerifier verifies the headers of the pulled WASM binaries.
+type wasmVerifier struct {
+	metadata.PullHandler
+	verified atomic.Int64
+}
+
+// OnFilePulled verifies the pulled file if it is a WASM binary.
+func (v *wasmVerifier) OnFilePulled(name string, outputDir string, desc ocispec.Descriptor, descPath string) error {
+	if desc.MediaType == wasmLayerMediaType {
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(outputDir, name)
+		}
+		if _, err := readWasmOS(path); err != nil {
+			return err
+		}
+		v.verified.Add(1)
+	}
+	return v.PullHandler.OnFilePulled(name, outputDir, desc, descPath)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package root
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_wasmOS(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  []byte
+		want    string
+		wantErr bool
+	}{
+		{name: "core module", header: []byte("\x00asm\x01\x00\x00\x00"), want: wasmOSModule},
+		{name: "component", header: []byte("\x00asm\x0d\x00\x01\x00"), want: wasmOSComponent},
+		{name: "unsupported version", header: []byte("\x00asm\x02\x00\x00\x00"), wantErr: true},
+		{name: "not wasm", header: []byte("#!/bin/sh"), wantErr: true},
+		{name: "too short", header: []byte("\x00asm"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wasmOS(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wasmOS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("wasmOS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_prepareWasmFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	module := write("module.wasm", "\x00asm\x01\x00\x00\x00")
+	module2 := write("module2.wasm", "\x00asm\x01\x00\x00\x00")
+	component := write("component.wasm", "\x00asm\x0d\x00\x01\x00")
+	text := write("hi.txt", "hi")
+
+	got, targetOS, err := prepareWasmFiles([]string{module, module2 + ":" + wasmLayerMediaType})
+	if err != nil {
+		t.Fatalf("prepareWasmFiles() error = %v", err)
+	}
+	if want := []string{module + ":" + wasmLayerMediaType, module2 + ":" + wasmLayerMediaType}; !reflect.DeepEqual(got, want) {
+		t.Errorf("prepareWasmFiles() = %v, want %v", got, want)
+	}
+	if targetOS != wasmOSModule {
+		t.Errorf("prepareWasmFiles() target OS = %v, want %v", targetOS, wasmOSModule)
+	}
+
+	for name, fileRefs := range map[string][]string{
+		"no file":            nil,
+		"not wasm":           {text},
+		"mixed":              {module, component},
+		"invalid media type": {component + ":application/octet-stream"},
+	} {
+		if _, _, err := prepareWasmFiles(fileRefs); err == nil {
+			t.Errorf("prepareWasmFiles() of %s error = nil, want error", name)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backupcatalog provides the catalog describing the content of a
+// backup created by "oras backup".
+package backupcatalog
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// FileName is the name of the catalog file at the root of a backup.
+const FileName = "backup.json"
+
+// ErrNotFound is returned when a backup has no catalog.
+var ErrNotFound = errors.New("backup catalog not found")
+
+// Catalog lists the repositories and tags in a backup.
+type Catalog struct {
+	// Tool is the name of the tool creating the backup.
+	Tool string `json:"tool"`
+	// Version is the version of the tool creating the backup.
+	Version string `json:"version"`
+	// Created is the time the backup is created.
+	Created time.Time `json:"created"`
+	// Repositories are the repositories backed up.
+	Repositories []Repository `json:"repositories"`
+}
+
+// Repository describes a repository in a backup.
+type Repository struct {
+	// Name is the name of the repository, in the form of
+	// <registry>/<repository>.
+	Name string `json:"name"`
+	// Path is the slash separated path of the OCI image layout of the
+	// repository, relative to the backup root. It is empty if the backup root
+	// is the OCI image layout.
+	Path string `json:"path,omitempty"`
+	// Tags are the tags backed up.
+	Tags []Tag `json:"tags"`
+	// BlobCount is the number of blobs, including manifests, in the OCI image
+	// layout.
+	BlobCount int `json:"blobCount"`
+	// Size is the total size of the blobs in the OCI image layout.
+	Size int64 `json:"size"`
+}
+
+// Tag describes a tagged artifact in a backup.
+type Tag struct {
+	// Name is the tag.
+	Name string `json:"name"`
+	// MediaType is the media type of the tagged manifest.
+	MediaType string `json:"mediaType"`
+	// Digest is the digest of the tagged manifest.
+	Digest string `json:"digest"`
+	// Size is the size of the tagged manifest.
+	Size int64 `json:"size"`
+	// Referrers is the number of referrers backed up with the artifact.
+	Referrers int `json:"referrers,omitempty"`
+}
+
+// NewTag returns a Tag describing the manifest desc tagged with name.
+func NewTag(name string, desc ocispec.Descriptor, referrers int) Tag {
+	return Tag{
+		Name:      name,
+		MediaType: desc.MediaType,
+		Digest:    desc.Digest.String(),
+		Size:      desc.Size,
+		Referrers: referrers,
+	}
+}
+
+// MeasureLayout returns the number and total size of the blobs in the OCI
+// image layout at root.
+func MeasureLayout(root string) (int, int64, error) {
+	var count int
+	var size int64
+	err := filepath.WalkDir(filepath.Join(root, ocispec.ImageBlobsDir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		count++
+		size += info.Size()
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return 0, 0, err
+	}
+	return count, size, nil
+}
+
+// Marshal returns the content of the catalog file.
+func (c *Catalog) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// WriteFile writes the catalog into the directory dir.
+func (c *Catalog) WriteFile(dir string) error {
+	data, err := c.Marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, FileName), data, 0644)
+}
+
+// Read reads a catalog from r.
+func Read(r io.Reader) (*Catalog, error) {
+	var c Catalog
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return nil, fmt.Errorf("invalid backup catalog: %w", err)
+	}
+	return &c, nil
+}
+
+// ReadDir reads the catalog of the backup in the directory dir.
+// ErrNotFound is returned if there is no catalog in dir.
+func ReadDir(dir string) (*Catalog, error) {
+	fp, err := os.Open(filepath.Join(dir, FileName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	defer fp.Close()
+	return Read(fp)
+}
+
+// ReadTar reads the catalog of the backup in the tar archive read from r
+// without extracting the archive. ErrNotFound is returned if there is no
+// catalog in the archive.
+func ReadTar(r io.Reader) (*Catalog, error) {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeReg && path.Clean(header.Name) == FileName {
+			return Read(tr)
+		}
+	}
+}
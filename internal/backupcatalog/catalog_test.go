@@ -0,0 +1,114 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backupcatalog
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	orasio "oras.land/oras/internal/io"
+)
+
+func TestCatalog(t *testing.T) {
+	root := t.TempDir()
+	blob := []byte("hello")
+	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, blob)
+	blobDir := filepath.Join(root, ocispec.ImageBlobsDir, "sha256")
+	if err := os.MkdirAll(blobDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(blobDir, desc.Digest.Encoded()), blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	count, size, err := MeasureLayout(root)
+	if err != nil {
+		t.Fatalf("MeasureLayout() error = %v", err)
+	}
+	if count != 1 || size != int64(len(blob)) {
+		t.Fatalf("MeasureLayout() = (%d, %d), want (1, %d)", count, size, len(blob))
+	}
+	want := &Catalog{
+		Tool:    "oras",
+		Version: "1.3.0",
+		Created: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Repositories: []Repository{{
+			Name:      "localhost:5000/hello",
+			Tags:      []Tag{NewTag("v1", desc, 2)},
+			BlobCount: count,
+			Size:      size,
+		}},
+	}
+	if err := want.WriteFile(root); err != nil {
+		t.Fatalf("Catalog.WriteFile() error = %v", err)
+	}
+
+	// read from the directory
+	got, err := ReadDir(root)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadDir() = %v, want %v", got, want)
+	}
+
+	// read from the tar archive
+	var buf bytes.Buffer
+	if err := orasio.TarDirectory(&buf, root); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ReadTar(&buf)
+	if err != nil {
+		t.Fatalf("ReadTar() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadTar() = %v, want %v", got, want)
+	}
+}
+
+func TestRead_notFound(t *testing.T) {
+	root := t.TempDir()
+	if _, err := ReadDir(root); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ReadDir() error = %v, want %v", err, ErrNotFound)
+	}
+	if err := os.WriteFile(filepath.Join(root, ocispec.ImageIndexFile), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := orasio.TarDirectory(&buf, root); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadTar(&buf); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ReadTar() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestMeasureLayout_empty(t *testing.T) {
+	count, size, err := MeasureLayout(t.TempDir())
+	if err != nil {
+		t.Fatalf("MeasureLayout() error = %v", err)
+	}
+	if count != 0 || size != 0 {
+		t.Errorf("MeasureLayout() = (%d, %d), want (0, 0)", count, size)
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bench benchmarks blob transfers against a storage.
+package bench
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/sync/errgroup"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// Operation is a benchmarked transfer operation.
+type Operation string
+
+// benchmarked operations
+const (
+	OperationPush Operation = "push"
+	OperationPull Operation = "pull"
+)
+
+// MediaType is the media type of the generated blobs.
+const MediaType = "application/vnd.oras.bench.blob"
+
+// Config configures a benchmark.
+type Config struct {
+	// Operation is the operation to measure.
+	Operation Operation
+	// Sizes are the sizes in bytes of the generated blobs.
+	Sizes []int64
+	// Count is the number of blobs transferred per run.
+	Count int
+	// Concurrencies are the numbers of concurrent transfers.
+	// A run is performed for every combination of size and concurrency.
+	Concurrencies []int
+	// Seed seeds the generated blob content. Blobs generated with the same
+	// seed are identical across benchmarks.
+	Seed uint64
+	// Keep keeps the generated blobs in the storage after the benchmark.
+	Keep bool
+}
+
+// Latency contains latency percentiles of single blob transfers.
+type Latency struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// Result is the measurement of a single run.
+type Result struct {
+	Operation   Operation
+	Size        int64
+	Count       int
+	Concurrency int
+	// Duration is the wall time of the run.
+	Duration time.Duration
+	// Throughput is the number of bytes transferred per second.
+	Throughput float64
+	Latency    Latency
+}
+
+// Run runs the benchmark against storage and calls onResult after each run.
+// Blobs are generated in memory before each run so that generation is not
+// measured. Benchmarking pulls pushes the blobs first without measuring.
+func Run(ctx context.Context, storage content.Storage, cfg Config, onResult func(Result) error) error {
+	if cfg.Count < 1 {
+		return fmt.Errorf("invalid blob count %d", cfg.Count)
+	}
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed))
+	for _, size := range cfg.Sizes {
+		for _, concurrency := range cfg.Concurrencies {
+			blobs := generate(rng, size, cfg.Count)
+			result, err := run(ctx, storage, cfg.Operation, blobs, concurrency)
+			if !cfg.Keep {
+				cleanup(ctx, storage, blobs)
+			}
+			if err != nil {
+				return err
+			}
+			result.Size = size
+			if err := onResult(result); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type blob struct {
+	desc    ocispec.Descriptor
+	content []byte
+}
+
+func generate(rng *rand.Rand, size int64, count int) []blob {
+	blobs := make([]blob, count)
+	for i := range blobs {
+		b := make([]byte, size)
+		for j := 0; j < len(b); j += 8 {
+			v := rng.Uint64()
+			for k := 0; k < 8 && j+k < len(b); k++ {
+				b[j+k] = byte(v >> (8 * k))
+			}
+		}
+		blobs[i] = blob{
+			desc:    content.NewDescriptorFromBytes(MediaType, b),
+			content: b,
+		}
+	}
+	return blobs
+}
+
+func run(ctx context.Context, storage content.Storage, op Operation, blobs []blob, concurrency int) (Result, error) {
+	if concurrency < 1 {
+		return Result{}, fmt.Errorf("invalid concurrency %d", concurrency)
+	}
+	var transfer func(ctx context.Context, b blob) error
+	switch op {
+	case OperationPush:
+		transfer = func(ctx context.Context, b blob) error {
+			return push(ctx, storage, b)
+		}
+	case OperationPull:
+		for _, b := range blobs {
+			if err := push(ctx, storage, b); err != nil {
+				return Result{}, err
+			}
+		}
+		transfer = func(ctx context.Context, b blob) error {
+			fetched, err := content.FetchAll(ctx, storage, b.desc)
+			if err != nil {
+				return err
+			}
+			if len(fetched) != len(b.content) {
+				return fmt.Errorf("%s: fetched %d bytes, expected %d", b.desc.Digest, len(fetched), len(b.content))
+			}
+			return nil
+		}
+	default:
+		return Result{}, fmt.Errorf("unsupported operation %q", op)
+	}
+
+	var mu sync.Mutex
+	latencies := make([]time.Duration, 0, len(blobs))
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(concurrency)
+	start := time.Now()
+	for _, b := range blobs {
+		eg.Go(func() error {
+			blobStart := time.Now()
+			if err := transfer(egCtx, b); err != nil {
+				return err
+			}
+			elapsed := time.Since(blobStart)
+			mu.Lock()
+			latencies = append(latencies, elapsed)
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return Result{}, err
+	}
+	duration := time.Since(start)
+
+	var total int64
+	for _, b := range blobs {
+		total += int64(len(b.content))
+	}
+	result := Result{
+		Operation:   op,
+		Count:       len(blobs),
+		Concurrency: concurrency,
+		Duration:    duration,
+		Latency:     percentiles(latencies),
+	}
+	if duration > 0 {
+		result.Throughput = float64(total) / duration.Seconds()
+	}
+	return result, nil
+}
+
+func push(ctx context.Context, storage content.Storage, b blob) error {
+	err := storage.Push(ctx, b.desc, bytes.NewReader(b.content))
+	if errors.Is(err, errdef.ErrAlreadyExists) {
+		return nil
+	}
+	return err
+}
+
+// cleanup deletes the blobs on a best effort basis.
+func cleanup(ctx context.Context, storage content.Storage, blobs []blob) {
+	deleter, ok := storage.(content.Deleter)
+	if !ok {
+		return
+	}
+	for _, b := range blobs {
+		_ = deleter.Delete(ctx, b.desc)
+	}
+}
+
+// percentiles computes the latency percentiles using the nearest-rank method.
+func percentiles(latencies []time.Duration) Latency {
+	if len(latencies) == 0 {
+		return Latency{}
+	}
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+	rank := func(p int) time.Duration {
+		i := (p*len(sorted)+99)/100 - 1
+		return sorted[max(i, 0)]
+	}
+	return Latency{
+		P50: rank(50),
+		P90: rank(90),
+		P99: rank(99),
+		Max: sorted[len(sorted)-1],
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bench
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestRun(t *testing.T) {
+	for _, op := range []Operation{OperationPush, OperationPull} {
+		t.Run(string(op), func(t *testing.T) {
+			cfg := Config{
+				Operation:     op,
+				Sizes:         []int64{10, 1024},
+				Count:         4,
+				Concurrencies: []int{1, 2},
+			}
+			var results []Result
+			err := Run(context.Background(), memory.New(), cfg, func(r Result) error {
+				results = append(results, r)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if len(results) != 4 {
+				t.Fatalf("Run() got %d results, want 4", len(results))
+			}
+			want := []struct {
+				size        int64
+				concurrency int
+			}{{10, 1}, {10, 2}, {1024, 1}, {1024, 2}}
+			for i, r := range results {
+				if r.Operation != op || r.Size != want[i].size || r.Concurrency != want[i].concurrency || r.Count != 4 {
+					t.Errorf("result %d = %+v, want size %d concurrency %d", i, r, want[i].size, want[i].concurrency)
+				}
+			}
+		})
+	}
+}
+
+func TestRun_invalid(t *testing.T) {
+	noop := func(Result) error { return nil }
+	if err := Run(context.Background(), memory.New(), Config{Operation: OperationPush, Sizes: []int64{1}, Concurrencies: []int{1}}, noop); err == nil {
+		t.Error("Run() with zero count error = nil, want error")
+	}
+	if err := Run(context.Background(), memory.New(), Config{Operation: "copy", Sizes: []int64{1}, Count: 1, Concurrencies: []int{1}}, noop); err == nil {
+		t.Error("Run() with unknown operation error = nil, want error")
+	}
+}
+
+func Test_generate_seeded(t *testing.T) {
+	a := generate(rand.New(rand.NewPCG(42, 42)), 33, 2)
+	b := generate(rand.New(rand.NewPCG(42, 42)), 33, 2)
+	for i := range a {
+		if a[i].desc.Digest != b[i].desc.Digest {
+			t.Errorf("blob %d: digest %s != %s", i, a[i].desc.Digest, b[i].desc.Digest)
+		}
+		if a[i].desc.Size != 33 {
+			t.Errorf("blob %d: size = %d, want 33", i, a[i].desc.Size)
+		}
+	}
+	if a[0].desc.Digest == a[1].desc.Digest {
+		t.Error("generated blobs are identical")
+	}
+}
+
+func Test_percentiles(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	got := percentiles(latencies)
+	want := Latency{P50: 50 * time.Millisecond, P90: 90 * time.Millisecond, P99: 99 * time.Millisecond, Max: 100 * time.Millisecond}
+	if got != want {
+		t.Errorf("percentiles() = %+v, want %+v", got, want)
+	}
+	if got := percentiles(nil); got != (Latency{}) {
+		t.Errorf("percentiles(nil) = %+v, want zero", got)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package blobpool shares the blobs of OCI image layouts in a content
+// addressed directory via hard links, so that each blob is stored once.
+package blobpool
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras/internal/descriptor"
+)
+
+// Pool is a content addressed directory of blobs shared by OCI image layouts.
+type Pool struct {
+	root string
+}
+
+// New returns a pool rooted at root, creating the directory if needed.
+func New(root string) (*Pool, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+	return &Pool{root: root}, nil
+}
+
+// Target wraps target, an OCI image layout rooted at layoutRoot, to share its
+// blobs with the pool. Blobs found in the pool are linked into the layout
+// instead of being pushed, and pushed blobs are linked into the pool.
+// Manifests are not shared so that they are indexed by the layout on push.
+func (p *Pool) Target(target oras.GraphTarget, layoutRoot string) *Target {
+	return &Target{
+		GraphTarget: target,
+		pool:        p,
+		layoutRoot:  layoutRoot,
+	}
+}
+
+// path returns the path of the blob described by desc under root.
+func path(root string, desc ocispec.Descriptor) (string, error) {
+	if err := desc.Digest.Validate(); err != nil {
+		return "", err
+	}
+	return filepath.Join(root, desc.Digest.Algorithm().String(), desc.Digest.Encoded()), nil
+}
+
+// Target is an OCI image layout target sharing its blobs with a pool.
+type Target struct {
+	oras.GraphTarget
+	pool       *Pool
+	layoutRoot string
+
+	lock  sync.Mutex
+	count int
+	size  int64
+}
+
+// Exists returns true if the described content exists in the layout, linking
+// it from the pool if possible.
+func (t *Target) Exists(ctx context.Context, desc ocispec.Descriptor) (bool, error) {
+	exists, err := t.GraphTarget.Exists(ctx, desc)
+	if err != nil || exists || descriptor.IsManifest(desc) {
+		return exists, err
+	}
+	poolPath, err := path(t.pool.root, desc)
+	if err != nil {
+		return false, err
+	}
+	if fi, err := os.Stat(poolPath); err != nil || fi.Size() != desc.Size {
+		// not in the pool
+		return false, nil
+	}
+	layoutPath, err := path(filepath.Join(t.layoutRoot, ocispec.ImageBlobsDir), desc)
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(layoutPath), 0755); err != nil {
+		return false, err
+	}
+	if err := os.Link(poolPath, layoutPath); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return true, nil
+		}
+		// fall back to pushing the blob, e.g. across file systems
+		return false, nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.count++
+	t.size += desc.Size
+	return true, nil
+}
+
+// Push pushes the content to the layout and links blobs into the pool.
+func (t *Target) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	if err := t.GraphTarget.Push(ctx, expected, content); err != nil {
+		return err
+	}
+	if descriptor.IsManifest(expected) {
+		return nil
+	}
+	layoutPath, err := path(filepath.Join(t.layoutRoot, ocispec.ImageBlobsDir), expected)
+	if err != nil {
+		return err
+	}
+	poolPath, err := path(t.pool.root, expected)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(poolPath), 0755); err != nil {
+		return err
+	}
+	// sharing is best effort: the blob is kept in the layout only if hard
+	// links are not supported, e.g. across file systems
+	_ = os.Link(layoutPath, poolPath)
+	return nil
+}
+
+// Deduplicated returns the number and the total size of the blobs linked from
+// the pool instead of being pushed.
+func (t *Target) Deduplicated() (int, int64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.count, t.size
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobpool
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+func TestTarget(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	pool, err := New(filepath.Join(root, "blobs"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	newTarget := func(name string) *Target {
+		layoutRoot := filepath.Join(root, name)
+		store, err := oci.New(layoutRoot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pool.Target(store, layoutRoot)
+	}
+	blob := []byte("shared layer")
+	blobDesc := content.NewDescriptorFromBytes("application/vnd.test.layer", blob)
+
+	// push the blob to the first layout
+	hello := newTarget("hello")
+	if err := hello.Push(ctx, blobDesc, bytes.NewReader(blob)); err != nil {
+		t.Fatalf("Target.Push() error = %v", err)
+	}
+	manifestDesc, err := oras.PackManifest(ctx, hello, oras.PackManifestVersion1_1, "test/artifact", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{blobDesc},
+	})
+	if err != nil {
+		t.Fatalf("oras.PackManifest() error = %v", err)
+	}
+
+	// the blob is linked from the pool to the second layout
+	world := newTarget("world")
+	exists, err := world.Exists(ctx, blobDesc)
+	if err != nil || !exists {
+		t.Fatalf("Target.Exists() = %v, %v, want true, nil", exists, err)
+	}
+	helloInfo, err := os.Stat(filepath.Join(root, "hello", "blobs", "sha256", blobDesc.Digest.Encoded()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	worldInfo, err := os.Stat(filepath.Join(root, "world", "blobs", "sha256", blobDesc.Digest.Encoded()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !os.SameFile(helloInfo, worldInfo) {
+		t.Error("blob is not shared between layouts")
+	}
+	if count, size := world.Deduplicated(); count != 1 || size != blobDesc.Size {
+		t.Errorf("Target.Deduplicated() = %d, %d, want 1, %d", count, size, blobDesc.Size)
+	}
+
+	// manifests are not shared
+	if exists, err := world.Exists(ctx, manifestDesc); err != nil || exists {
+		t.Errorf("Target.Exists() = %v, %v, want false, nil", exists, err)
+	}
+	if count, _ := hello.Deduplicated(); count != 0 {
+		t.Errorf("Target.Deduplicated() count = %d, want 0", count)
+	}
+}
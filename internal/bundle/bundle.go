@@ -0,0 +1,273 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bundle provides the single-file bundle format of "oras bundle",
+// which is an OCI image layout tar archive, optionally compressed with gzip,
+// starting with a manifest listing the checksums of its blobs.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/internal/compression"
+	orasio "oras.land/oras/internal/io"
+)
+
+// FileName is the name of the bundle manifest, which is the first entry of a
+// bundle.
+const FileName = "bundle.json"
+
+// Manifest describes the content of a bundle.
+type Manifest struct {
+	// Tool is the name of the tool creating the bundle.
+	Tool string `json:"tool"`
+	// Version is the version of the tool creating the bundle.
+	Version string `json:"version"`
+	// Created is the time the bundle is created.
+	Created time.Time `json:"created"`
+	// Source is the reference of the bundled artifact.
+	Source string `json:"source"`
+	// Tag is the tag of the bundled artifact in the OCI image layout, if any.
+	Tag string `json:"tag,omitempty"`
+	// Root is the descriptor of the bundled artifact.
+	Root ocispec.Descriptor `json:"root"`
+	// Blobs are the blobs in the OCI image layout, including manifests.
+	Blobs []Blob `json:"blobs"`
+	// Size is the total size of the blobs.
+	Size int64 `json:"size"`
+}
+
+// Blob is the checksum of a blob in a bundle.
+type Blob struct {
+	Digest digest.Digest `json:"digest"`
+	Size   int64         `json:"size"`
+}
+
+// IsCompressed returns true if the bundle at path is to be compressed with
+// gzip according to its extension. Extensions of unsupported compression
+// formats are rejected.
+func IsCompressed(path string) (bool, error) {
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return true, nil
+	case strings.HasSuffix(lower, ".tar"):
+		return false, nil
+	}
+	return false, fmt.Errorf("unsupported bundle file name %q: the extension must be .tar.gz, .tgz or .tar", filepath.Base(path))
+}
+
+// ListBlobs lists the blobs in the OCI image layout at root.
+func ListBlobs(root string) ([]Blob, int64, error) {
+	var blobs []Blob
+	var size int64
+	blobsDir := filepath.Join(root, ocispec.ImageBlobsDir)
+	err := filepath.WalkDir(blobsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(blobsDir, path)
+		if err != nil {
+			return err
+		}
+		algorithm, encoded, ok := strings.Cut(filepath.ToSlash(rel), "/")
+		if !ok {
+			return fmt.Errorf("unexpected file %s in the OCI image layout", path)
+		}
+		dgst := digest.NewDigestFromEncoded(digest.Algorithm(algorithm), encoded)
+		if err := dgst.Validate(); err != nil {
+			return fmt.Errorf("unexpected file %s in the OCI image layout: %w", path, err)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, Blob{Digest: dgst, Size: info.Size()})
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return blobs, size, nil
+}
+
+// Write writes the bundle of the OCI image layout at root described by m to
+// w, compressed with gzip if compress is true.
+func Write(w io.Writer, root string, m *Manifest, compress bool) (err error) {
+	if compress {
+		zw := gzip.NewWriter(w)
+		defer func() {
+			if closeErr := zw.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		w = zw
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	tw := tar.NewWriter(w)
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     FileName,
+		Size:     int64(len(data)),
+		Mode:     0644,
+		ModTime:  m.Created,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return err
+	}
+	if err := tw.AddFS(os.DirFS(root)); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// Extract extracts the bundle read from r into the directory dir, which
+// becomes an OCI image layout, and returns its manifest. Compressed bundles
+// are detected by their content.
+func Extract(r io.Reader, dir string) (*Manifest, error) {
+	r, closeFn, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFn()
+	if err := orasio.UntarDirectory(r, dir); err != nil {
+		return nil, err
+	}
+	fp, err := os.Open(filepath.Join(dir, FileName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("invalid bundle: %s not found", FileName)
+		}
+		return nil, err
+	}
+	defer fp.Close()
+	return decodeManifest(fp)
+}
+
+// ReadManifest reads the manifest of the bundle read from r without extracting
+// the bundle.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	r, closeFn, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFn()
+	hdr, err := tar.NewReader(r).Next()
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+	if hdr.Name != FileName {
+		return nil, fmt.Errorf("invalid bundle: %s is not the first entry", FileName)
+	}
+	return decodeManifest(io.LimitReader(r, hdr.Size))
+}
+
+// decompress returns the decompressed content of r if it is compressed, and a
+// function releasing the decompressor.
+func decompress(r io.Reader) (io.Reader, func(), error) {
+	zr, err := compression.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decompress bundle: %w", err)
+	}
+	return zr, func() { _ = zr.Close() }, nil
+}
+
+// decodeManifest decodes the bundle manifest read from r.
+func decodeManifest(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("invalid bundle: invalid %s: %w", FileName, err)
+	}
+	if err := m.Root.Digest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid bundle: invalid root digest: %w", err)
+	}
+	return &m, nil
+}
+
+// Verify verifies that the OCI image layout at root contains exactly the
+// blobs listed in the manifest with matching checksums. onVerified, if not
+// nil, is called for each verified blob.
+func (m *Manifest) Verify(root string, onVerified func(Blob) error) error {
+	found, _, err := ListBlobs(root)
+	if err != nil {
+		return err
+	}
+	listed := make(map[digest.Digest]bool, len(m.Blobs))
+	for _, blob := range m.Blobs {
+		listed[blob.Digest] = true
+	}
+	for _, blob := range found {
+		if !listed[blob.Digest] {
+			return fmt.Errorf("blob %s is not listed in %s", blob.Digest, FileName)
+		}
+	}
+	for _, blob := range m.Blobs {
+		if err := verifyBlob(root, blob); err != nil {
+			return err
+		}
+		if onVerified != nil {
+			if err := onVerified(blob); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// verifyBlob verifies the checksum of a blob in the OCI image layout at root.
+func verifyBlob(root string, blob Blob) error {
+	if err := blob.Digest.Validate(); err != nil {
+		return fmt.Errorf("invalid blob digest %q: %w", blob.Digest, err)
+	}
+	fp, err := os.Open(filepath.Join(root, ocispec.ImageBlobsDir, blob.Digest.Algorithm().String(), blob.Digest.Encoded()))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("blob %s is missing", blob.Digest)
+		}
+		return err
+	}
+	defer fp.Close()
+	verifier := blob.Digest.Verifier()
+	n, err := io.Copy(verifier, fp)
+	if err != nil {
+		return fmt.Errorf("failed to read blob %s: %w", blob.Digest, err)
+	}
+	if n != blob.Size {
+		return fmt.Errorf("blob %s has size %d, want %d", blob.Digest, n, blob.Size)
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("blob %s does not match its digest", blob.Digest)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+)
+
+func TestIsCompressed(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    bool
+		wantErr bool
+	}{
+		{path: "bundle.oci.tar.gz", want: true},
+		{path: "bundle.TGZ", want: true},
+		{path: "bundle.tar"},
+		{path: "bundle.oci.tar.zst", wantErr: true},
+		{path: "bundle", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := IsCompressed(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IsCompressed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("IsCompressed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBundle(t *testing.T) {
+	root := t.TempDir()
+	blob := []byte("hello")
+	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, blob)
+	blobDir := filepath.Join(root, ocispec.ImageBlobsDir, "sha256")
+	if err := os.MkdirAll(blobDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(blobDir, desc.Digest.Encoded()), blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ocispec.ImageLayoutFile), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	blobs, size, err := ListBlobs(root)
+	if err != nil {
+		t.Fatalf("ListBlobs() error = %v", err)
+	}
+	if want := []Blob{{Digest: desc.Digest, Size: desc.Size}}; !reflect.DeepEqual(blobs, want) || size != desc.Size {
+		t.Fatalf("ListBlobs() = %v, %d, want %v, %d", blobs, size, want, desc.Size)
+	}
+	want := &Manifest{
+		Tool:    "oras",
+		Version: "1.3.0",
+		Created: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Source:  "localhost:5000/hello:v1",
+		Tag:     "v1",
+		Root:    desc,
+		Blobs:   blobs,
+		Size:    size,
+	}
+
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := Write(&buf, root, want, compress); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		manifest, err := ReadManifest(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("ReadManifest() error = %v", err)
+		}
+		if !reflect.DeepEqual(manifest, want) {
+			t.Errorf("ReadManifest() = %v, want %v", manifest, want)
+		}
+		dir := t.TempDir()
+		got, err := Extract(&buf, dir)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Extract() = %v, want %v", got, want)
+		}
+		var verified []Blob
+		if err := got.Verify(dir, func(b Blob) error {
+			verified = append(verified, b)
+			return nil
+		}); err != nil {
+			t.Fatalf("Manifest.Verify() error = %v", err)
+		}
+		if !reflect.DeepEqual(verified, blobs) {
+			t.Errorf("Manifest.Verify() verified %v, want %v", verified, blobs)
+		}
+
+		// tamper with the blob
+		if err := os.WriteFile(filepath.Join(dir, ocispec.ImageBlobsDir, "sha256", desc.Digest.Encoded()), []byte("HELLO"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := got.Verify(dir, nil); err == nil {
+			t.Error("Manifest.Verify() error = nil, want error for a tampered blob")
+		}
+	}
+
+	// unlisted blob
+	m := *want
+	m.Blobs = nil
+	if err := m.Verify(root, nil); err == nil {
+		t.Error("Manifest.Verify() error = nil, want error for an unlisted blob")
+	}
+}
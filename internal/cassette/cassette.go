@@ -0,0 +1,346 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cassette records registry interactions to a file and replays them
+// without network access.
+package cassette
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// redacted replaces sensitive values in recorded interactions.
+const redacted = "REDACTED"
+
+// sensitiveHeaders are the request headers not recorded.
+var sensitiveHeaders = []string{"Authorization", "Cookie"}
+
+// sensitiveFields are the JSON and form fields redacted from message bodies,
+// e.g. the credentials posted to and the tokens issued by authorization
+// servers.
+var sensitiveFields = []string{"token", "access_token", "refresh_token", "identity_token", "password", "client_secret"}
+
+// maxBodySize is the maximum number of bytes recorded of a message body.
+// Larger bodies, e.g. blobs, are passed through and recorded truncated.
+const maxBodySize = 4 << 20
+
+// Body is a recorded message body.
+type Body struct {
+	Data string `json:"body,omitempty"`
+	// Encoding is "base64" if the body is not valid UTF-8.
+	Encoding string `json:"encoding,omitempty"`
+	// Truncated is true if only the first bytes of the body are recorded.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+func newBody(b []byte, truncated bool) Body {
+	if utf8.Valid(b) {
+		return Body{Data: string(b), Truncated: truncated}
+	}
+	return Body{Data: base64.StdEncoding.EncodeToString(b), Encoding: "base64", Truncated: truncated}
+}
+
+func (b Body) bytes() ([]byte, error) {
+	if b.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(b.Data)
+	}
+	return []byte(b.Data), nil
+}
+
+// Writer appends interactions to a cassette file, one JSON object per line.
+type Writer struct {
+	path string
+	lock sync.Mutex
+}
+
+// Create creates the cassette file at path, truncating it if it exists.
+func Create(path string) (*Writer, error) {
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		return nil, err
+	}
+	return &Writer{path: path}, nil
+}
+
+// Recorder returns a transport recording the interactions sent through base.
+func (w *Writer) Recorder(base http.RoundTripper) *Recorder {
+	return &Recorder{Base: base, writer: w}
+}
+
+func (w *Writer) append(interaction Interaction) error {
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Recorder is an http.RoundTripper recording every interaction to a
+// cassette. Up to maxBodySize bytes of each message body are kept in memory
+// while being recorded.
+type Recorder struct {
+	// Base is the transport sending the requests.
+	Base http.RoundTripper
+
+	writer *Writer
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	var reqTruncated bool
+	if req.Body != nil && req.Body != http.NoBody {
+		var body io.ReadCloser
+		var err error
+		if reqBody, reqTruncated, body, err = capture(req.Body); err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	resp, err := r.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, respTruncated, body, err := capture(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = body
+
+	header := req.Header.Clone()
+	for _, h := range sensitiveHeaders {
+		if header.Get(h) != "" {
+			header.Set(h, redacted)
+		}
+	}
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.Redacted(),
+			Header: header,
+			Body:   newBody(redactBody(req.Header, reqBody), reqTruncated),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       newBody(redactBody(resp.Header, respBody), respTruncated),
+		},
+	}
+	if err := r.writer.append(interaction); err != nil {
+		return nil, fmt.Errorf("failed to record interaction: %w", err)
+	}
+	return resp, nil
+}
+
+// capture reads up to maxBodySize bytes of body to be recorded and returns
+// a replacement of body yielding the whole content.
+func capture(body io.ReadCloser) (recorded []byte, truncated bool, replaced io.ReadCloser, err error) {
+	recorded, err = io.ReadAll(io.LimitReader(body, maxBodySize+1))
+	if err != nil {
+		_ = body.Close()
+		return nil, false, nil, err
+	}
+	if len(recorded) <= maxBodySize {
+		_ = body.Close()
+		return recorded, false, io.NopCloser(bytes.NewReader(recorded)), nil
+	}
+	replaced = struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(recorded), body),
+		Closer: body,
+	}
+	return recorded[:maxBodySize], true, replaced, nil
+}
+
+// redactBody redacts the sensitive fields of a form-encoded or JSON body.
+func redactBody(header http.Header, body []byte) []byte {
+	if mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type")); mediaType == "application/x-www-form-urlencoded" {
+		return redactForm(body)
+	}
+	return redactFields(body)
+}
+
+// redactForm redacts the sensitive fields of a form-encoded body.
+func redactForm(body []byte) []byte {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		// not recorded as the credentials cannot be told apart
+		return []byte(redacted)
+	}
+	changed := false
+	for _, f := range sensitiveFields {
+		if form.Has(f) {
+			form.Set(f, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return body
+	}
+	return []byte(form.Encode())
+}
+
+// redactFields redacts the sensitive fields of a JSON object.
+func redactFields(body []byte) []byte {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return body
+	}
+	changed := false
+	for _, f := range sensitiveFields {
+		if _, ok := obj[f]; ok {
+			obj[f] = json.RawMessage(strconv.Quote(redacted))
+			changed = true
+		}
+	}
+	if !changed {
+		return body
+	}
+	redactedBody, err := json.Marshal(obj)
+	if err != nil {
+		return body
+	}
+	return redactedBody
+}
+
+// Player is an http.RoundTripper serving responses from a cassette.
+// A request is served by the first interaction not served yet with the same
+// method and URL, regardless of headers and body.
+type Player struct {
+	lock         sync.Mutex
+	interactions []Interaction
+	played       []bool
+}
+
+// Load loads the cassette file at path.
+func Load(path string) (*Player, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var interactions []Interaction
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<30)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid interaction: %w", path, line, err)
+		}
+		interactions = append(interactions, interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &Player{
+		interactions: interactions,
+		played:       make([]bool, len(interactions)),
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (p *Player) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+	}
+	url := req.URL.Redacted()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i, interaction := range p.interactions {
+		if p.played[i] || interaction.Request.Method != req.Method || interaction.Request.URL != url {
+			continue
+		}
+		if interaction.Response.Truncated {
+			return nil, fmt.Errorf("recorded response body for %s %s is truncated to %d bytes", req.Method, url, maxBodySize)
+		}
+		body, err := interaction.Response.bytes()
+		if err != nil {
+			return nil, fmt.Errorf("invalid recorded response body for %s %s: %w", req.Method, url, err)
+		}
+		p.played[i] = true
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		resp := &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			ContentLength: int64(len(body)),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			Request:       req,
+		}
+		if req.Method == http.MethodHead {
+			if n, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64); err == nil {
+				resp.ContentLength = n
+			}
+			resp.Body = http.NoBody
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction for %s %s", req.Method, url)
+}
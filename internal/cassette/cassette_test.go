@@ -0,0 +1,202 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cassette
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			_, _ = w.Write([]byte(`{"token":"secret","expires_in":60}`))
+		case "/blob":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte{0xff, 0x00, 0xfe})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	writer, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordClient := &http.Client{Transport: writer.Recorder(http.DefaultTransport)}
+	get := func(c *http.Client, path string) (int, string) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+path, nil)
+		req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		resp, err := c.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s error = %v", path, err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+	if status, body := get(recordClient, "/token"); status != http.StatusOK || !strings.Contains(body, "secret") {
+		t.Errorf("recorded GET /token = %d %q, want the live response", status, body)
+	}
+	get(recordClient, "/blob")
+	get(recordClient, "/missing")
+
+	recorded, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret", "dXNlcjpwYXNz"} {
+		if strings.Contains(string(recorded), secret) {
+			t.Errorf("cassette contains sensitive value %q", secret)
+		}
+	}
+
+	ts.Close()
+	player, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	replayClient := &http.Client{Transport: player}
+	if status, body := get(replayClient, "/token"); status != http.StatusOK || !strings.Contains(body, `"token":"REDACTED"`) {
+		t.Errorf("replayed GET /token = %d %q", status, body)
+	}
+	if status, body := get(replayClient, "/blob"); status != http.StatusOK || body != "\xff\x00\xfe" {
+		t.Errorf("replayed GET /blob = %d %q", status, body)
+	}
+	if status, _ := get(replayClient, "/missing"); status != http.StatusNotFound {
+		t.Errorf("replayed GET /missing = %d, want %d", status, http.StatusNotFound)
+	}
+	// every interaction is replayed once
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/blob", nil)
+	if _, err := replayClient.Do(req); err == nil {
+		t.Error("replaying an exhausted interaction error = nil, want error")
+	}
+}
+
+func TestRecorder_credentials(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"issued-access","refresh_token":"issued-refresh"}`))
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	writer, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: writer.Recorder(http.DefaultTransport)}
+	posts := []struct {
+		contentType string
+		body        string
+	}{
+		{"application/x-www-form-urlencoded", url.Values{
+			"grant_type": {"password"},
+			"username":   {"user"},
+			"password":   {"posted-password"},
+		}.Encode()},
+		{"application/x-www-form-urlencoded; charset=utf-8", url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {"posted-refresh"},
+		}.Encode()},
+		{"application/json", `{"username":"user","password":"json-password"}`},
+	}
+	for _, post := range posts {
+		resp, err := client.Post(ts.URL+"/token", post.contentType, strings.NewReader(post.body))
+		if err != nil {
+			t.Fatalf("POST /token error = %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !strings.Contains(string(body), "issued-refresh") {
+			t.Errorf("POST /token = %q, want the live response", body)
+		}
+	}
+
+	recorded, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"posted-password", "posted-refresh", "json-password", "issued-access", "issued-refresh"} {
+		if strings.Contains(string(recorded), secret) {
+			t.Errorf("cassette contains credential %q", secret)
+		}
+	}
+	if !strings.Contains(string(recorded), "grant_type=password") {
+		t.Error("cassette does not contain the non-sensitive form fields")
+	}
+}
+
+func TestRecorder_largeBody(t *testing.T) {
+	blob := bytes.Repeat([]byte("a"), maxBodySize+10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(blob)
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	writer, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: writer.Recorder(http.DefaultTransport)}
+	resp, err := client.Get(ts.URL + "/blob")
+	if err != nil {
+		t.Fatalf("GET /blob error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !bytes.Equal(body, blob) {
+		t.Errorf("recorded GET /blob = %d bytes, want %d", len(body), len(blob))
+	}
+
+	player, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !player.interactions[0].Response.Truncated || len(player.interactions[0].Response.Data) != maxBodySize {
+		t.Errorf("recorded body = %d bytes, truncated %v, want %d bytes truncated", len(player.interactions[0].Response.Data), player.interactions[0].Response.Truncated, maxBodySize)
+	}
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/blob", nil)
+	if _, err := player.RoundTrip(req); err == nil {
+		t.Error("replaying a truncated body error = nil, want error")
+	}
+}
+
+func TestLoad_invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.jsonl")
+	if err := os.WriteFile(path, []byte("not json\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() error = nil, want error")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Load() missing file error = nil, want error")
+	}
+}
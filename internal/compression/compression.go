@@ -0,0 +1,176 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compression compresses and decompresses tar archives with gzip or
+// zstd.
+package compression
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression algorithms.
+const (
+	None = "none"
+	Gzip = "gzip"
+	Zstd = "zstd"
+)
+
+var (
+	// magicGzip is the magic number of gzip streams.
+	magicGzip = []byte{0x1f, 0x8b}
+	// magicZstd is the magic number of zstd frames.
+	magicZstd = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// FromExtension returns the compression of the tar archive at path according
+// to its extension, which is .tar.gz or .tgz for gzip, .tar.zst or .tzst for
+// zstd and .tar for no compression. ok is false for other extensions.
+func FromExtension(path string) (algorithm string, ok bool) {
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return Gzip, true
+	case strings.HasSuffix(lower, ".tar.zst"), strings.HasSuffix(lower, ".tzst"):
+		return Zstd, true
+	case strings.HasSuffix(lower, ".tar"):
+		return None, true
+	}
+	return "", false
+}
+
+// Extension returns the extension of the tar archives compressed with
+// algorithm.
+func Extension(algorithm string) string {
+	switch algorithm {
+	case Gzip:
+		return ".tar.gz"
+	case Zstd:
+		return ".tar.zst"
+	}
+	return ".tar"
+}
+
+// Validate returns an error if algorithm is not supported or level is out of
+// the range of algorithm. Level 0 is the default level of algorithm, and an
+// empty algorithm is the same as None.
+func Validate(algorithm string, level int) error {
+	var minLevel, maxLevel int
+	switch algorithm {
+	case None, "":
+		if level != 0 {
+			return fmt.Errorf("compression level %d cannot be set without compression", level)
+		}
+		return nil
+	case Gzip:
+		minLevel, maxLevel = gzip.BestSpeed, gzip.BestCompression
+	case Zstd:
+		minLevel, maxLevel = 1, 22
+	default:
+		return fmt.Errorf("unsupported compression %q: must be %q, %q or %q", algorithm, Gzip, Zstd, None)
+	}
+	if level != 0 && (level < minLevel || level > maxLevel) {
+		return fmt.Errorf("invalid %s compression level %d: must be from %d to %d", algorithm, level, minLevel, maxLevel)
+	}
+	return nil
+}
+
+// NewWriter returns a writer compressing the content written to w with
+// algorithm at level, or the default level if level is 0. The writer must be
+// closed to flush the compressed content, which does not close w.
+func NewWriter(w io.Writer, algorithm string, level int) (io.WriteCloser, error) {
+	if err := Validate(algorithm, level); err != nil {
+		return nil, err
+	}
+	switch algorithm {
+	case Gzip:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		return gzip.NewWriterLevel(w, level)
+	case Zstd:
+		var opts []zstd.EOption
+		if level != 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		return zstd.NewWriter(w, opts...)
+	}
+	return nopWriteCloser{w}, nil
+}
+
+// nopWriteCloser is a writer with a no-op Close method.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close implements io.Closer.
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// NewReader returns a reader decompressing the content read from r, of which
+// the compression is detected by its magic number. The reader must be closed
+// to release the decompressor, which does not close r.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(len(magicZstd))
+	switch {
+	case bytes.HasPrefix(magic, magicGzip):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress with gzip: %w", err)
+		}
+		return zr, nil
+	case bytes.HasPrefix(magic, magicZstd):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress with zstd: %w", err)
+		}
+		return zr.IOReadCloser(), nil
+	}
+	return io.NopCloser(br), nil
+}
+
+// Detect returns the compression of the file at path detected by its magic
+// number.
+func Detect(path string) (string, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer fp.Close()
+	magic := make([]byte, len(magicZstd))
+	n, err := io.ReadFull(fp, magic)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	magic = magic[:n]
+	switch {
+	case bytes.HasPrefix(magic, magicGzip):
+		return Gzip, nil
+	case bytes.HasPrefix(magic, magicZstd):
+		return Zstd, nil
+	}
+	return None, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compression
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFromExtension(t *testing.T) {
+	tests := []struct {
+		path          string
+		wantAlgorithm string
+		wantOK        bool
+	}{
+		{"backup.tar", None, true},
+		{"backup.TAR", None, true},
+		{"backup.tar.gz", Gzip, true},
+		{"backup.tgz", Gzip, true},
+		{"backup.tar.zst", Zstd, true},
+		{"backup.tzst", Zstd, true},
+		{"backup.zip", "", false},
+		{"backup", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			algorithm, ok := FromExtension(tt.path)
+			if algorithm != tt.wantAlgorithm || ok != tt.wantOK {
+				t.Errorf("FromExtension() = %q, %v, want %q, %v", algorithm, ok, tt.wantAlgorithm, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		level     int
+		wantErr   bool
+	}{
+		{"none", None, 0, false},
+		{"none with level", None, 1, true},
+		{"gzip default", Gzip, 0, false},
+		{"gzip best", Gzip, 9, false},
+		{"gzip out of range", Gzip, 10, true},
+		{"zstd default", Zstd, 0, false},
+		{"zstd max", Zstd, 22, false},
+		{"zstd out of range", Zstd, 23, true},
+		{"negative level", Zstd, -1, true},
+		{"unsupported", "brotli", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.algorithm, tt.level); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriterReader(t *testing.T) {
+	content := bytes.Repeat([]byte("hello world "), 1024)
+	for _, tt := range []struct {
+		algorithm string
+		level     int
+	}{
+		{None, 0},
+		{Gzip, 0},
+		{Gzip, 1},
+		{Zstd, 0},
+		{Zstd, 3},
+	} {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			var buf bytes.Buffer
+			zw, err := NewWriter(&buf, tt.algorithm, tt.level)
+			if err != nil {
+				t.Fatalf("NewWriter() error = %v", err)
+			}
+			if _, err := zw.Write(content); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if err := zw.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if tt.algorithm != None && buf.Len() >= len(content) {
+				t.Errorf("compressed size = %d, want less than %d", buf.Len(), len(content))
+			}
+
+			path := filepath.Join(t.TempDir(), "archive")
+			if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := Detect(path); err != nil || got != tt.algorithm {
+				t.Errorf("Detect() = %q, %v, want %q", got, err, tt.algorithm)
+			}
+
+			zr, err := NewReader(&buf)
+			if err != nil {
+				t.Fatalf("NewReader() error = %v", err)
+			}
+			defer zr.Close()
+			got, err := io.ReadAll(zr)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if !bytes.Equal(got, content) {
+				t.Errorf("decompressed content mismatch")
+			}
+		})
+	}
+}
+
+func TestDetect_short(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive")
+	if err := os.WriteFile(path, []byte{0x1f}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Detect(path); err != nil || got != None {
+		t.Errorf("Detect() = %q, %v, want %q", got, err, None)
+	}
+}
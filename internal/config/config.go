@@ -0,0 +1,231 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the oras configuration file.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// EnvConfigPath is the environment variable overriding the path of the
+// configuration file.
+const EnvConfigPath = "ORAS_CONFIG"
+
+// Config is the oras configuration.
+//
+// Example:
+//
+//	{
+//	  "registries": {
+//	    "registry.example.com": {
+//	      "headers": {
+//	        "X-Tenant-ID": "my-tenant",
+//	        "X-API-Key": "${API_KEY}"
+//	      },
+//	      "tls": {
+//	        "minVersion": "1.2",
+//	        "cipherSuites": ["TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"]
+//	      },
+//	      "validation": {
+//	        "artifactTypes": ["application/vnd.mycorp.*"],
+//	        "layerMediaTypes": ["application/vnd.mycorp.*", "application/vnd.oci.image.layer.v1.tar*"]
+//	      }
+//	    }
+//	  },
+//	  "resolve": [
+//	    "*.internal.example.com:443:10.0.0.5",
+//	    "registry.example.com:443:[2001:db8::1]:5000"
+//	  ],
+//	  "userAgent": "pipeline/1.0",
+//	  "pull": {
+//	    "scanCommand": "clamscan -r {dir}"
+//	  },
+//	  "policy": "https://policies.example.com/oras.rego"
+//	}
+type Config struct {
+	// Registries contains the configuration per registry, indexed by the
+	// registry host, e.g. "localhost:5000".
+	Registries map[string]Registry `json:"registries,omitempty"`
+	// Resolve contains the DNS resolve entries in the same form as the
+	// --resolve flag, host:port:address[:address_port], where host may be a
+	// wildcard pattern. The --resolve flags take precedence.
+	Resolve []string `json:"resolve,omitempty"`
+	// UserAgent is the suffix appended to the User-Agent header sent to
+	// registries, e.g. "pipeline/1.0". The --user-agent flag takes
+	// precedence.
+	UserAgent string `json:"userAgent,omitempty"`
+	// Pull is the configuration of oras pull.
+	Pull Pull `json:"pull,omitzero"`
+	// Policy is the file path or HTTPS URL of the Rego policy evaluated for push,
+	// pull and copy operations.
+	Policy string `json:"policy,omitempty"`
+}
+
+// Pull is the configuration of oras pull.
+type Pull struct {
+	// ScanCommand is the command scanning the pulled files, where {dir} is
+	// replaced by the directory of the pulled files. Pulls fail if the
+	// command fails.
+	ScanCommand string `json:"scanCommand,omitempty"`
+}
+
+// Registry is the configuration of a registry.
+type Registry struct {
+	// Headers are added to every request sent to the registry. Environment
+	// variables such as ${API_KEY} in the values are expanded.
+	Headers map[string]string `json:"headers,omitempty"`
+	// TLS is the TLS configuration for connections to the registry.
+	TLS TLS `json:"tls,omitzero"`
+	// Validation is the policy validating the artifacts pushed or attached
+	// to the registry.
+	Validation Validation `json:"validation,omitzero"`
+}
+
+// Validation is the policy of the artifacts pushed to a registry. The
+// patterns are matched with path.Match, where '*' matches any sequence of
+// characters except '/'.
+type Validation struct {
+	// ArtifactTypes are the patterns of the allowed artifact types. If not
+	// empty, artifacts without an artifact type are rejected.
+	ArtifactTypes []string `json:"artifactTypes,omitempty"`
+	// LayerMediaTypes are the patterns of the allowed layer media types.
+	LayerMediaTypes []string `json:"layerMediaTypes,omitempty"`
+}
+
+// TLS is the TLS configuration of a registry.
+type TLS struct {
+	// MinVersion is the minimum TLS version, e.g. "1.2".
+	MinVersion string `json:"minVersion,omitempty"`
+	// CipherSuites are the names of the allowed TLS 1.2 cipher suites, e.g.
+	// "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256". TLS 1.3 cipher suites are not
+	// configurable.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// Path returns the path of the configuration file, which is $ORAS_CONFIG if
+// set, or oras/config.json under the user configuration directory.
+func Path() (string, error) {
+	if p := os.Getenv(EnvConfigPath); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oras", "config.json"), nil
+}
+
+// Load loads the configuration file at path. An empty configuration is
+// returned if the file does not exist.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// LoadDefault loads the configuration file at the default path.
+func LoadDefault() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		// no configuration directory, e.g. $HOME is not set
+		return &Config{}, nil
+	}
+	return Load(path)
+}
+
+// Registry returns the configuration of the registry host.
+func (c *Config) Registry(host string) Registry {
+	if c == nil {
+		return Registry{}
+	}
+	return c.Registries[host]
+}
+
+// ResolveEntries returns the configured DNS resolve entries.
+func (c *Config) ResolveEntries() []string {
+	if c == nil {
+		return nil
+	}
+	return c.Resolve
+}
+
+// UserAgentSuffix returns the configured suffix of the User-Agent header.
+func (c *Config) UserAgentSuffix() string {
+	if c == nil {
+		return ""
+	}
+	return c.UserAgent
+}
+
+// ExpandedHeaders returns the headers with environment variables expanded.
+func (r Registry) ExpandedHeaders() map[string]string {
+	if len(r.Headers) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(r.Headers))
+	for k, v := range r.Headers {
+		headers[k] = os.ExpandEnv(v)
+	}
+	return headers
+}
+
+// Validate validates the artifact type and layer media types of an artifact
+// against the policy. All the violations are joined in the returned error.
+func (v Validation) Validate(artifactType string, layerMediaTypes []string) error {
+	var errs []error
+	if len(v.ArtifactTypes) > 0 {
+		if artifactType == "" {
+			errs = append(errs, errors.New("artifact type is missing"))
+		} else if !matchAny(v.ArtifactTypes, artifactType) {
+			errs = append(errs, fmt.Errorf("artifact type %q is not allowed", artifactType))
+		}
+	}
+	if len(v.LayerMediaTypes) > 0 {
+		for _, mediaType := range layerMediaTypes {
+			if mediaType == "" {
+				errs = append(errs, errors.New("layer media type is missing"))
+			} else if !matchAny(v.LayerMediaTypes, mediaType) {
+				errs = append(errs, fmt.Errorf("layer media type %q is not allowed", mediaType))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// matchAny reports whether s matches any of the patterns.
+func matchAny(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, s); matched {
+			return true
+		}
+	}
+	return false
+}
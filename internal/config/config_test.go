@@ -0,0 +1,139 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	content := `{"registries":{"localhost:5000":{"headers":{"X-Tenant":"t1","X-Key":"${TEST_ORAS_KEY}"},"tls":{"minVersion":"1.3"}}},"resolve":["*.example.com:443:10.0.0.5"],"pull":{"scanCommand":"scan {dir}"},"policy":"policy.rego"}`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_ORAS_KEY", "secret")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	got := cfg.Registry("localhost:5000").ExpandedHeaders()
+	want := map[string]string{"X-Tenant": "t1", "X-Key": "secret"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandedHeaders() = %v, want %v", got, want)
+	}
+	if got := cfg.Registry("localhost:5000").TLS.MinVersion; got != "1.3" {
+		t.Errorf("TLS.MinVersion = %q, want %q", got, "1.3")
+	}
+	if got := cfg.Pull.ScanCommand; got != "scan {dir}" {
+		t.Errorf("Pull.ScanCommand = %q, want %q", got, "scan {dir}")
+	}
+	if got, want := cfg.ResolveEntries(), []string{"*.example.com:443:10.0.0.5"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveEntries() = %v, want %v", got, want)
+	}
+	if got := (*Config)(nil).ResolveEntries(); got != nil {
+		t.Errorf("ResolveEntries() of nil configuration = %v, want nil", got)
+	}
+	if got := cfg.Policy; got != "policy.rego" {
+		t.Errorf("Policy = %q, want %q", got, "policy.rego")
+	}
+	if got := cfg.Registry("example.com").ExpandedHeaders(); got != nil {
+		t.Errorf("ExpandedHeaders() of unknown registry = %v, want nil", got)
+	}
+}
+
+func TestLoad_notExist(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Registries) != 0 {
+		t.Errorf("Load() = %v, want empty config", cfg)
+	}
+}
+
+func TestLoad_invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() error = nil, want error")
+	}
+}
+
+func TestPath_env(t *testing.T) {
+	t.Setenv(EnvConfigPath, "/tmp/oras.json")
+	if got, err := Path(); err != nil || got != "/tmp/oras.json" {
+		t.Errorf("Path() = %q, %v, want %q", got, err, "/tmp/oras.json")
+	}
+}
+
+func TestValidation_Validate(t *testing.T) {
+	v := Validation{
+		ArtifactTypes:   []string{"application/vnd.mycorp.*"},
+		LayerMediaTypes: []string{"application/vnd.mycorp.*", "application/vnd.oci.image.layer.v1.tar*"},
+	}
+	tests := []struct {
+		name            string
+		artifactType    string
+		layerMediaTypes []string
+		wantErr         string
+	}{
+		{
+			name:            "allowed",
+			artifactType:    "application/vnd.mycorp.firmware",
+			layerMediaTypes: []string{"application/vnd.mycorp.image", "application/vnd.oci.image.layer.v1.tar+gzip"},
+		},
+		{
+			name:         "missing artifact type",
+			artifactType: "",
+			wantErr:      "artifact type is missing",
+		},
+		{
+			name:         "artifact type not allowed",
+			artifactType: "application/vnd.other.firmware",
+			wantErr:      `artifact type "application/vnd.other.firmware" is not allowed`,
+		},
+		{
+			name:            "layer media type not allowed",
+			artifactType:    "application/vnd.mycorp.firmware",
+			layerMediaTypes: []string{"application/vnd.mycorp.image", "text/plain"},
+			wantErr:         `layer media type "text/plain" is not allowed`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.artifactType, tt.layerMediaTypes)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+	if err := (Validation{}).Validate("", []string{""}); err != nil {
+		t.Errorf("Validate() of empty policy error = %v", err)
+	}
+}
@@ -0,0 +1,464 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance checks a repository against the OCI distribution
+// specification workflows.
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+)
+
+// Suite is a category of distribution specification workflows.
+type Suite string
+
+// conformance suites
+const (
+	SuitePull       Suite = "pull"
+	SuitePush       Suite = "push"
+	SuiteDiscovery  Suite = "discovery"
+	SuiteManagement Suite = "management"
+)
+
+// Suites lists all suites in execution order.
+var Suites = []Suite{SuitePush, SuitePull, SuiteDiscovery, SuiteManagement}
+
+// Status is the outcome of a check.
+type Status string
+
+// check status
+const (
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip"
+)
+
+// Result is the result of a single check.
+type Result struct {
+	Suite  Suite
+	Name   string
+	Status Status
+	// Err describes the failure or the reason of skipping.
+	Err error
+}
+
+// ArtifactType is the artifact type of the test content.
+const ArtifactType = "application/vnd.oras.conformance.test"
+
+// check is a single conformance check.
+type check struct {
+	suite Suite
+	name  string
+	// needs lists the names of the checks this check depends on.
+	needs []string
+	run   func(ctx context.Context, r *runner) error
+}
+
+// runner holds the test content shared by checks.
+type runner struct {
+	repo        registry.Repository
+	tag         string
+	config      ocispec.Descriptor
+	configBytes []byte
+	layer       ocispec.Descriptor
+	layerBytes  []byte
+	manifest    ocispec.Descriptor
+	referrer    ocispec.Descriptor
+	missing     ocispec.Descriptor
+}
+
+// Run runs the checks of the given suites against repo and calls onResult
+// with the result of each check. Test content is pushed to repo as required
+// by the selected suites and deleted afterwards on a best effort basis.
+// Failed checks are reported through onResult rather than returned as errors.
+func Run(ctx context.Context, repo registry.Repository, suites []Suite, onResult func(Result) error) error {
+	r, err := newRunner(repo)
+	if err != nil {
+		return err
+	}
+	// the content left by failed or skipped checks is deleted as well
+	defer r.cleanup(ctx)
+	passed := make(map[string]bool)
+	for _, c := range checks {
+		selected := slices.Contains(suites, c.suite)
+		if c.suite == SuiteManagement && !selected {
+			continue
+		}
+		result := Result{Suite: c.suite, Name: c.name}
+		if missing := firstMissing(c.needs, passed); missing != "" {
+			result.Status = StatusSkip
+			result.Err = fmt.Errorf("prerequisite %q did not pass", missing)
+		} else if err := c.run(ctx, r); err != nil {
+			result.Status = StatusFail
+			result.Err = err
+		} else {
+			result.Status = StatusPass
+			passed[c.name] = true
+		}
+		if !selected {
+			// prerequisite of a selected suite, not reported
+			continue
+		}
+		if err := onResult(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func firstMissing(needs []string, passed map[string]bool) string {
+	for _, n := range needs {
+		if !passed[n] {
+			return n
+		}
+	}
+	return ""
+}
+
+func newRunner(repo registry.Repository) (*runner, error) {
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	id := hex.EncodeToString(nonce)
+	r := &runner{
+		repo:        repo,
+		tag:         "oras-conformance-" + id,
+		configBytes: []byte(`{"conformance":"` + id + `"}`),
+		layerBytes:  []byte("oras conformance test layer " + id),
+	}
+	r.config = content.NewDescriptorFromBytes(ocispec.MediaTypeImageConfig, r.configBytes)
+	r.layer = content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, r.layerBytes)
+	r.missing = content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte("oras conformance missing "+id))
+	return r, nil
+}
+
+// cleanup deletes the test content ignoring errors.
+func (r *runner) cleanup(ctx context.Context) {
+	for _, desc := range []ocispec.Descriptor{r.referrer, r.manifest} {
+		if desc.Digest != "" {
+			_ = r.repo.Delete(ctx, desc)
+		}
+	}
+	for _, desc := range []ocispec.Descriptor{r.layer, r.config} {
+		_ = r.repo.Blobs().Delete(ctx, desc)
+	}
+}
+
+func (r *runner) packManifest(subject *ocispec.Descriptor) ([]byte, error) {
+	manifest := ocispec.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: ArtifactType,
+		Config:       r.config,
+		Layers:       []ocispec.Descriptor{r.layer},
+		Subject:      subject,
+	}
+	return json.Marshal(manifest)
+}
+
+// verifyFetched checks the content returned by fetch matches expected.
+func verifyFetched(desc ocispec.Descriptor, expected []byte, fetch func() ([]byte, error)) error {
+	got, err := fetch()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(got, expected) {
+		return fmt.Errorf("content of %s mismatch", desc.Digest)
+	}
+	return nil
+}
+
+func expectNotFound(err error) error {
+	if err == nil {
+		return errors.New("expected not found error, got success")
+	}
+	if !errors.Is(err, errdef.ErrNotFound) {
+		return fmt.Errorf("expected not found error, got: %w", err)
+	}
+	return nil
+}
+
+func expectExists(exists bool, err error, desc ocispec.Descriptor) error {
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%s reported as not existing", desc.Digest)
+	}
+	return nil
+}
+
+// check names referenced as prerequisites
+const (
+	checkPushConfig   = "push config blob"
+	checkPushLayer    = "push layer blob"
+	checkPushManifest = "push manifest by tag"
+	checkPushReferrer = "push manifest with subject"
+	checkDeleteMfst   = "delete manifest"
+)
+
+var checks = []check{
+	{
+		suite: SuitePush,
+		name:  checkPushConfig,
+		run: func(ctx context.Context, r *runner) error {
+			return r.repo.Push(ctx, r.config, bytes.NewReader(r.configBytes))
+		},
+	},
+	{
+		suite: SuitePush,
+		name:  checkPushLayer,
+		run: func(ctx context.Context, r *runner) error {
+			return r.repo.Push(ctx, r.layer, bytes.NewReader(r.layerBytes))
+		},
+	},
+	{
+		suite: SuitePush,
+		name:  checkPushManifest,
+		needs: []string{checkPushConfig, checkPushLayer},
+		run: func(ctx context.Context, r *runner) error {
+			manifestJSON, err := r.packManifest(nil)
+			if err != nil {
+				return err
+			}
+			desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifestJSON)
+			desc.ArtifactType = ArtifactType
+			if err := r.repo.PushReference(ctx, desc, bytes.NewReader(manifestJSON), r.tag); err != nil {
+				return err
+			}
+			r.manifest = desc
+			return nil
+		},
+	},
+	{
+		suite: SuitePush,
+		name:  checkPushReferrer,
+		needs: []string{checkPushManifest},
+		run: func(ctx context.Context, r *runner) error {
+			subject := ocispec.Descriptor{
+				MediaType: r.manifest.MediaType,
+				Digest:    r.manifest.Digest,
+				Size:      r.manifest.Size,
+			}
+			manifestJSON, err := r.packManifest(&subject)
+			if err != nil {
+				return err
+			}
+			desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifestJSON)
+			desc.ArtifactType = ArtifactType
+			if err := r.repo.Push(ctx, desc, bytes.NewReader(manifestJSON)); err != nil {
+				return err
+			}
+			r.referrer = desc
+			return nil
+		},
+	},
+	{
+		suite: SuitePull,
+		name:  "resolve tag",
+		needs: []string{checkPushManifest},
+		run: func(ctx context.Context, r *runner) error {
+			desc, err := r.repo.Resolve(ctx, r.tag)
+			if err != nil {
+				return err
+			}
+			if desc.Digest != r.manifest.Digest {
+				return fmt.Errorf("tag %q resolved to %s, expected %s", r.tag, desc.Digest, r.manifest.Digest)
+			}
+			return nil
+		},
+	},
+	{
+		suite: SuitePull,
+		name:  "fetch manifest by tag",
+		needs: []string{checkPushManifest},
+		run: func(ctx context.Context, r *runner) error {
+			expected, err := r.packManifest(nil)
+			if err != nil {
+				return err
+			}
+			return verifyFetched(r.manifest, expected, func() ([]byte, error) {
+				desc, rc, err := r.repo.FetchReference(ctx, r.tag)
+				if err != nil {
+					return nil, err
+				}
+				defer rc.Close()
+				return content.ReadAll(rc, desc)
+			})
+		},
+	},
+	{
+		suite: SuitePull,
+		name:  "fetch manifest by digest",
+		needs: []string{checkPushManifest},
+		run: func(ctx context.Context, r *runner) error {
+			expected, err := r.packManifest(nil)
+			if err != nil {
+				return err
+			}
+			return verifyFetched(r.manifest, expected, func() ([]byte, error) {
+				return content.FetchAll(ctx, r.repo, r.manifest)
+			})
+		},
+	},
+	{
+		suite: SuitePull,
+		name:  "check manifest existence",
+		needs: []string{checkPushManifest},
+		run: func(ctx context.Context, r *runner) error {
+			exists, err := r.repo.Exists(ctx, r.manifest)
+			return expectExists(exists, err, r.manifest)
+		},
+	},
+	{
+		suite: SuitePull,
+		name:  "fetch blob",
+		needs: []string{checkPushLayer},
+		run: func(ctx context.Context, r *runner) error {
+			return verifyFetched(r.layer, r.layerBytes, func() ([]byte, error) {
+				return content.FetchAll(ctx, r.repo, r.layer)
+			})
+		},
+	},
+	{
+		suite: SuitePull,
+		name:  "check blob existence",
+		needs: []string{checkPushLayer},
+		run: func(ctx context.Context, r *runner) error {
+			exists, err := r.repo.Exists(ctx, r.layer)
+			return expectExists(exists, err, r.layer)
+		},
+	},
+	{
+		suite: SuitePull,
+		name:  "fetch missing manifest",
+		run: func(ctx context.Context, r *runner) error {
+			_, err := content.FetchAll(ctx, r.repo, r.missing)
+			return expectNotFound(err)
+		},
+	},
+	{
+		suite: SuitePull,
+		name:  "fetch missing blob",
+		run: func(ctx context.Context, r *runner) error {
+			missing := r.missing
+			missing.MediaType = ocispec.MediaTypeImageLayer
+			_, err := content.FetchAll(ctx, r.repo, missing)
+			return expectNotFound(err)
+		},
+	},
+	{
+		suite: SuiteDiscovery,
+		name:  "list tags",
+		needs: []string{checkPushManifest},
+		run: func(ctx context.Context, r *runner) error {
+			found := false
+			err := r.repo.Tags(ctx, "", func(tags []string) error {
+				found = found || slices.Contains(tags, r.tag)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("tag %q not listed", r.tag)
+			}
+			return nil
+		},
+	},
+	{
+		suite: SuiteDiscovery,
+		name:  "list referrers",
+		needs: []string{checkPushReferrer},
+		run: func(ctx context.Context, r *runner) error {
+			found := false
+			err := r.repo.Referrers(ctx, r.manifest, ArtifactType, func(referrers []ocispec.Descriptor) error {
+				for _, ref := range referrers {
+					found = found || ref.Digest == r.referrer.Digest
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("referrer %s not listed", r.referrer.Digest)
+			}
+			return nil
+		},
+	},
+	{
+		suite: SuiteManagement,
+		name:  "delete manifest with subject",
+		needs: []string{checkPushReferrer},
+		run: func(ctx context.Context, r *runner) error {
+			return r.repo.Delete(ctx, r.referrer)
+		},
+	},
+	{
+		suite: SuiteManagement,
+		name:  checkDeleteMfst,
+		needs: []string{checkPushManifest},
+		run: func(ctx context.Context, r *runner) error {
+			return r.repo.Delete(ctx, r.manifest)
+		},
+	},
+	{
+		suite: SuiteManagement,
+		name:  "fetch deleted manifest",
+		needs: []string{checkDeleteMfst},
+		run: func(ctx context.Context, r *runner) error {
+			_, err := content.FetchAll(ctx, r.repo, r.manifest)
+			return expectNotFound(err)
+		},
+	},
+	{
+		suite: SuiteManagement,
+		name:  "delete blob",
+		needs: []string{checkPushLayer},
+		run: func(ctx context.Context, r *runner) error {
+			if err := r.repo.Blobs().Delete(ctx, r.layer); err != nil {
+				return err
+			}
+			// the config blob is removed on a best effort basis
+			_ = r.repo.Blobs().Delete(ctx, r.config)
+			return nil
+		},
+	},
+}
+
+// ParseSuite parses the name of a suite.
+func ParseSuite(name string) (Suite, error) {
+	for _, s := range Suites {
+		if string(s) == name {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unknown conformance suite %q", name)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+)
+
+// fakeRepository is an in-memory registry.Repository.
+type fakeRepository struct {
+	blobs         map[string][]byte
+	tags          map[string]ocispec.Descriptor
+	referrers     map[string][]ocispec.Descriptor
+	failDelete    bool
+	failReferrers bool
+	// deleteFailures is the number of deletes failing before deletes succeed
+	deleteFailures int
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		blobs:     make(map[string][]byte),
+		tags:      make(map[string]ocispec.Descriptor),
+		referrers: make(map[string][]ocispec.Descriptor),
+	}
+}
+
+func (f *fakeRepository) Fetch(_ context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	b, ok := f.blobs[target.Digest.String()]
+	if !ok {
+		return nil, errdef.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (f *fakeRepository) Push(_ context.Context, expected ocispec.Descriptor, r io.Reader) error {
+	b, err := content.ReadAll(r, expected)
+	if err != nil {
+		return err
+	}
+	f.blobs[expected.Digest.String()] = b
+	if expected.MediaType == ocispec.MediaTypeImageManifest {
+		var manifest ocispec.Manifest
+		if err := json.Unmarshal(b, &manifest); err != nil {
+			return err
+		}
+		if manifest.Subject != nil {
+			key := manifest.Subject.Digest.String()
+			f.referrers[key] = append(f.referrers[key], expected)
+		}
+	}
+	return nil
+}
+
+func (f *fakeRepository) Exists(_ context.Context, target ocispec.Descriptor) (bool, error) {
+	_, ok := f.blobs[target.Digest.String()]
+	return ok, nil
+}
+
+func (f *fakeRepository) Delete(_ context.Context, target ocispec.Descriptor) error {
+	if f.failDelete {
+		return errdef.ErrUnsupported
+	}
+	if f.deleteFailures > 0 {
+		f.deleteFailures--
+		return errors.New("delete failed")
+	}
+	if _, ok := f.blobs[target.Digest.String()]; !ok {
+		return errdef.ErrNotFound
+	}
+	delete(f.blobs, target.Digest.String())
+	return nil
+}
+
+func (f *fakeRepository) Resolve(_ context.Context, reference string) (ocispec.Descriptor, error) {
+	desc, ok := f.tags[reference]
+	if !ok {
+		return ocispec.Descriptor{}, errdef.ErrNotFound
+	}
+	return desc, nil
+}
+
+func (f *fakeRepository) Tag(_ context.Context, desc ocispec.Descriptor, reference string) error {
+	f.tags[reference] = desc
+	return nil
+}
+
+func (f *fakeRepository) FetchReference(ctx context.Context, reference string) (ocispec.Descriptor, io.ReadCloser, error) {
+	desc, err := f.Resolve(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	rc, err := f.Fetch(ctx, desc)
+	return desc, rc, err
+}
+
+func (f *fakeRepository) PushReference(ctx context.Context, expected ocispec.Descriptor, r io.Reader, reference string) error {
+	if err := f.Push(ctx, expected, r); err != nil {
+		return err
+	}
+	return f.Tag(ctx, expected, reference)
+}
+
+func (f *fakeRepository) Referrers(_ context.Context, desc ocispec.Descriptor, _ string, fn func([]ocispec.Descriptor) error) error {
+	if f.failReferrers {
+		return errors.New("referrers unavailable")
+	}
+	return fn(f.referrers[desc.Digest.String()])
+}
+
+func (f *fakeRepository) Tags(_ context.Context, _ string, fn func([]string) error) error {
+	var tags []string
+	for tag := range f.tags {
+		tags = append(tags, tag)
+	}
+	return fn(tags)
+}
+
+func (f *fakeRepository) Blobs() registry.BlobStore { return f }
+
+func (f *fakeRepository) Manifests() registry.ManifestStore { return f }
+
+func runAll(t *testing.T, repo registry.Repository, suites []Suite) map[string]Result {
+	t.Helper()
+	results := make(map[string]Result)
+	err := Run(context.Background(), repo, suites, func(r Result) error {
+		results[r.Name] = r
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	return results
+}
+
+func TestRun(t *testing.T) {
+	repo := newFakeRepository()
+	results := runAll(t, repo, Suites)
+	if len(results) != len(checks) {
+		t.Errorf("Run() reported %d results, want %d", len(results), len(checks))
+	}
+	for name, r := range results {
+		if r.Status != StatusPass {
+			t.Errorf("check %q: status = %s, err = %v", name, r.Status, r.Err)
+		}
+	}
+	if len(repo.blobs) != 0 {
+		t.Errorf("Run() left %d blobs in the repository", len(repo.blobs))
+	}
+}
+
+func TestRun_failures(t *testing.T) {
+	repo := newFakeRepository()
+	repo.failDelete = true
+	repo.failReferrers = true
+	results := runAll(t, repo, Suites)
+	want := map[string]Status{
+		"push manifest by tag":   StatusPass,
+		"list referrers":         StatusFail,
+		"delete manifest":        StatusFail,
+		"fetch deleted manifest": StatusSkip,
+	}
+	for name, status := range want {
+		if got := results[name].Status; got != status {
+			t.Errorf("check %q: status = %s, want %s", name, got, status)
+		}
+	}
+}
+
+func TestRun_cleanupAfterFailures(t *testing.T) {
+	repo := newFakeRepository()
+	repo.deleteFailures = 1
+	results := runAll(t, repo, Suites)
+	if got := results["delete manifest with subject"].Status; got != StatusFail {
+		t.Errorf("check %q: status = %s, want %s", "delete manifest with subject", got, StatusFail)
+	}
+	if len(repo.blobs) != 0 {
+		t.Errorf("Run() left %d blobs in the repository", len(repo.blobs))
+	}
+
+	// aborted runs are cleaned up as well
+	repo = newFakeRepository()
+	wantErr := errors.New("aborted")
+	if err := Run(context.Background(), repo, Suites, func(Result) error { return wantErr }); !errors.Is(err, wantErr) {
+		t.Fatalf("Run() error = %v, want %v", err, wantErr)
+	}
+	if len(repo.blobs) != 0 {
+		t.Errorf("Run() left %d blobs in the repository", len(repo.blobs))
+	}
+}
+
+func TestRun_selectedSuites(t *testing.T) {
+	repo := newFakeRepository()
+	results := runAll(t, repo, []Suite{SuitePull})
+	for name, r := range results {
+		if r.Suite != SuitePull {
+			t.Errorf("check %q of suite %s reported", name, r.Suite)
+		}
+		if r.Status != StatusPass {
+			t.Errorf("check %q: status = %s, err = %v", name, r.Status, r.Err)
+		}
+	}
+	if len(repo.blobs) != 0 {
+		t.Errorf("Run() left %d blobs in the repository", len(repo.blobs))
+	}
+}
+
+func TestParseSuite(t *testing.T) {
+	if got, err := ParseSuite("pull"); err != nil || got != SuitePull {
+		t.Errorf("ParseSuite(pull) = %v, %v", got, err)
+	}
+	if _, err := ParseSuite("foo"); err == nil {
+		t.Error("ParseSuite(foo) error = nil, want error")
+	}
+}
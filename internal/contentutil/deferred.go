@@ -0,0 +1,152 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+)
+
+// DeferredTarget is a GraphTarget decoupling the download of named blobs,
+// which are files to be written or extracted, from pushing them to the
+// underlying target. The content of a named blob is spooled into a temporary
+// file and verified by Push, and then pushed to the underlying target by one
+// of the workers in the background, so that decompression and disk writes do
+// not hold back downloading.
+// Other content is pushed once all the pending named blobs are pushed, so that
+// manifests are pushed after their files.
+type DeferredTarget struct {
+	oras.GraphTarget
+	ctx     context.Context
+	tempDir string
+	workers chan struct{}
+
+	lock    sync.Mutex
+	done    *sync.Cond
+	pending int
+	err     error
+}
+
+// NewDeferredTarget returns a DeferredTarget pushing named blobs to target
+// with at most concurrency workers. The content is spooled into tempDir, or
+// the default directory for temporary files if tempDir is empty. The
+// background pushes run with ctx.
+func NewDeferredTarget(ctx context.Context, target oras.GraphTarget, concurrency int, tempDir string) *DeferredTarget {
+	t := &DeferredTarget{
+		GraphTarget: target,
+		ctx:         ctx,
+		tempDir:     tempDir,
+		workers:     make(chan struct{}, max(concurrency, 1)),
+	}
+	t.done = sync.NewCond(&t.lock)
+	return t
+}
+
+// Push spools the content of a named blob and pushes it in the background.
+// Other content is pushed once the pending pushes complete.
+func (t *DeferredTarget) Push(ctx context.Context, expected ocispec.Descriptor, r io.Reader) error {
+	if expected.Annotations[ocispec.AnnotationTitle] == "" {
+		if err := t.Wait(); err != nil {
+			return err
+		}
+		return t.GraphTarget.Push(ctx, expected, r)
+	}
+	if err := t.failed(); err != nil {
+		return err
+	}
+
+	spooled, err := t.spool(expected, r)
+	if err != nil {
+		return err
+	}
+	select {
+	case t.workers <- struct{}{}:
+	case <-ctx.Done():
+		closeAndRemove(spooled)
+		return ctx.Err()
+	}
+	t.lock.Lock()
+	t.pending++
+	t.lock.Unlock()
+	go func() {
+		defer func() { <-t.workers }()
+		defer closeAndRemove(spooled)
+		err := t.GraphTarget.Push(t.ctx, expected, spooled)
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		if err != nil && t.err == nil {
+			t.err = fmt.Errorf("failed to write %q: %w", expected.Annotations[ocispec.AnnotationTitle], err)
+		}
+		t.pending--
+		t.done.Broadcast()
+	}()
+	return nil
+}
+
+// Wait waits for the pending pushes and returns the first error occurred.
+func (t *DeferredTarget) Wait() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for t.pending > 0 {
+		t.done.Wait()
+	}
+	return t.err
+}
+
+// failed returns the first error occurred in the background pushes.
+func (t *DeferredTarget) failed() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.err
+}
+
+// spool writes the verified content of expected to a temporary file, which is
+// rewound for reading.
+func (t *DeferredTarget) spool(expected ocispec.Descriptor, r io.Reader) (_ *os.File, spoolErr error) {
+	f, err := os.CreateTemp(t.tempDir, "oras_spool_*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if spoolErr != nil {
+			closeAndRemove(f)
+		}
+	}()
+	vr := content.NewVerifyReader(r, expected)
+	if _, err := io.Copy(f, vr); err != nil {
+		return nil, err
+	}
+	if err := vr.Verify(); err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// closeAndRemove closes and removes the temporary file f.
+func closeAndRemove(f *os.File) {
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+}
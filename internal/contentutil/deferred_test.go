@@ -0,0 +1,143 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content/file"
+)
+
+func TestDeferredTarget_Push(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := file.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	target := NewDeferredTarget(ctx, store, 2, t.TempDir())
+
+	blob := []byte("hello")
+	var layers []ocispec.Descriptor
+	for _, name := range []string{"a.txt", "b.txt"} {
+		desc := ocispec.Descriptor{
+			MediaType:   "text/plain",
+			Digest:      digest.FromBytes(blob),
+			Size:        int64(len(blob)),
+			Annotations: map[string]string{ocispec.AnnotationTitle: name},
+		}
+		layers = append(layers, desc)
+		if err := target.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+			t.Fatalf("DeferredTarget.Push() error = %v", err)
+		}
+	}
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    ocispec.DescriptorEmptyJSON,
+		Layers:    layers,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(manifest),
+		Size:      int64(len(manifest)),
+	}
+	// the manifest is pushed after the files
+	if err := target.Push(ctx, manifestDesc, bytes.NewReader(manifest)); err != nil {
+		t.Fatalf("DeferredTarget.Push() error = %v", err)
+	}
+	if err := target.Wait(); err != nil {
+		t.Fatalf("DeferredTarget.Wait() error = %v", err)
+	}
+	for _, name := range []string{"a.txt", "b.txt"} {
+		got, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, blob) {
+			t.Errorf("file %s = %q, want %q", name, got, blob)
+		}
+	}
+}
+
+func TestDeferredTarget_Push_verify(t *testing.T) {
+	ctx := context.Background()
+	store, err := file.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	tempDir := t.TempDir()
+	target := NewDeferredTarget(ctx, store, 1, tempDir)
+
+	desc := ocispec.Descriptor{
+		MediaType:   "text/plain",
+		Digest:      digest.FromBytes([]byte("hello")),
+		Size:        5,
+		Annotations: map[string]string{ocispec.AnnotationTitle: "a.txt"},
+	}
+	if err := target.Push(ctx, desc, strings.NewReader("world")); err == nil {
+		t.Error("DeferredTarget.Push() error = nil, want error for mismatched content")
+	}
+	if entries, _ := os.ReadDir(tempDir); len(entries) != 0 {
+		t.Errorf("DeferredTarget.Push() left %d spooled files", len(entries))
+	}
+}
+
+func TestDeferredTarget_Wait(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "a.txt"), []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := file.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	store.DisableOverwrite = true
+	target := NewDeferredTarget(ctx, store, 1, t.TempDir())
+
+	blob := []byte("hello")
+	desc := ocispec.Descriptor{
+		MediaType:   "text/plain",
+		Digest:      digest.FromBytes(blob),
+		Size:        int64(len(blob)),
+		Annotations: map[string]string{ocispec.AnnotationTitle: "a.txt"},
+	}
+	if err := target.Push(ctx, desc, bytes.NewReader(blob)); err != nil {
+		t.Fatalf("DeferredTarget.Push() error = %v", err)
+	}
+	if err := target.Wait(); err == nil {
+		t.Error("DeferredTarget.Wait() error = nil, want error for the failed push")
+	}
+	if err := target.Push(ctx, ocispec.DescriptorEmptyJSON, bytes.NewReader(ocispec.DescriptorEmptyJSON.Data)); err == nil {
+		t.Error("DeferredTarget.Push() error = nil, want the error of the failed push")
+	}
+}
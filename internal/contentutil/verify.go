@@ -0,0 +1,104 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+)
+
+// ErrCorrupted is returned when the fetched content does not match its
+// descriptor.
+var ErrCorrupted = errors.New("content does not match its descriptor")
+
+// NewVerifyReadCloser wraps rc for reading the content described by desc,
+// verifying its size and digest as soon as desc.Size bytes are read. The last
+// bytes are not returned if the verification fails, so that the corrupted
+// content is never completely written to a destination. The returned error
+// wraps ErrCorrupted if the content does not match desc.
+func NewVerifyReadCloser(rc io.ReadCloser, desc ocispec.Descriptor) io.ReadCloser {
+	return &verifyReadCloser{
+		VerifyReader: content.NewVerifyReader(rc, desc),
+		Closer:       rc,
+		desc:         desc,
+	}
+}
+
+// verifyReadCloser reads the content verified by a content.VerifyReader.
+type verifyReadCloser struct {
+	*content.VerifyReader
+	io.Closer
+	desc ocispec.Descriptor
+	read int64
+}
+
+// Read reads the content and verifies it once all of it is read.
+func (r *verifyReadCloser) Read(p []byte) (int, error) {
+	n, err := r.VerifyReader.Read(p)
+	r.read += int64(n)
+	switch {
+	case err == io.ErrUnexpectedEOF:
+		return 0, fmt.Errorf("%s: %w: content is shorter than %d bytes", r.desc.Digest, ErrCorrupted, r.desc.Size)
+	case err == io.EOF, err == nil && r.read == r.desc.Size:
+		if verifyErr := r.VerifyReader.Verify(); verifyErr != nil {
+			return 0, fmt.Errorf("%s: %w: %w", r.desc.Digest, ErrCorrupted, verifyErr)
+		}
+	}
+	return n, err
+}
+
+// VerifiedTarget returns a target verifying the content fetched from target
+// against the descriptors. The returned target lists tags if target does.
+func VerifiedTarget(target oras.ReadOnlyGraphTarget) oras.ReadOnlyGraphTarget {
+	verified := &verifiedTarget{
+		ReadOnlyGraphTarget: target,
+	}
+	if lister, ok := target.(registry.TagLister); ok {
+		return &verifiedTagTarget{
+			verifiedTarget: verified,
+			TagLister:      lister,
+		}
+	}
+	return verified
+}
+
+// verifiedTagTarget is a verifiedTarget listing tags.
+type verifiedTagTarget struct {
+	*verifiedTarget
+	registry.TagLister
+}
+
+// verifiedTarget is a target verifying the fetched content.
+type verifiedTarget struct {
+	oras.ReadOnlyGraphTarget
+}
+
+// Fetch fetches the content identified by target and verifies it as it is
+// read.
+func (t *verifiedTarget) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	rc, err := t.ReadOnlyGraphTarget.Fetch(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	return NewVerifyReadCloser(rc, target), nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contentutil
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
+)
+
+func TestNewVerifyReadCloser(t *testing.T) {
+	desc := ocispec.Descriptor{
+		MediaType: "text/plain",
+		Digest:    digest.FromBytes([]byte("hello")),
+		Size:      5,
+	}
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "matched", content: "hello"},
+		{name: "mismatched digest", content: "world", wantErr: true},
+		{name: "short", content: "hell", wantErr: true},
+		{name: "trailing data", content: "hello world", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := NewVerifyReadCloser(io.NopCloser(strings.NewReader(tt.content)), desc)
+			defer rc.Close()
+			_, err := io.ReadAll(rc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadAll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrCorrupted) {
+				t.Errorf("ReadAll() error = %v, want %v", err, ErrCorrupted)
+			}
+		})
+	}
+}
+
+func TestNewVerifyReadCloser_withholdLastBytes(t *testing.T) {
+	desc := ocispec.Descriptor{
+		MediaType: "text/plain",
+		Digest:    digest.FromBytes([]byte("hello")),
+		Size:      5,
+	}
+	rc := NewVerifyReadCloser(io.NopCloser(strings.NewReader("world")), desc)
+	defer rc.Close()
+	var read int
+	p := make([]byte, 2)
+	for {
+		n, err := rc.Read(p)
+		read += n
+		if err != nil {
+			if !errors.Is(err, ErrCorrupted) {
+				t.Fatalf("Read() error = %v, want %v", err, ErrCorrupted)
+			}
+			break
+		}
+	}
+	if read >= int(desc.Size) {
+		t.Errorf("Read() returned %d bytes of corrupted content, want less than %d", read, desc.Size)
+	}
+}
+
+func TestVerifiedTarget(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := oci.New(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob := []byte("hello")
+	desc := ocispec.Descriptor{
+		MediaType: "text/plain",
+		Digest:    digest.FromBytes(blob),
+		Size:      int64(len(blob)),
+	}
+	if err := store.Push(ctx, desc, strings.NewReader(string(blob))); err != nil {
+		t.Fatal(err)
+	}
+	target := VerifiedTarget(store)
+	if _, ok := target.(registry.TagLister); !ok {
+		t.Error("VerifiedTarget() does not list tags")
+	}
+
+	rc, err := target.Fetch(ctx, desc)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if _, err := io.ReadAll(rc); err != nil {
+		t.Errorf("ReadAll() error = %v", err)
+	}
+	rc.Close()
+
+	// corrupt the blob on disk
+	path := filepath.Join(root, "blobs", desc.Digest.Algorithm().String(), desc.Digest.Encoded())
+	if err := os.WriteFile(path, []byte("world"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rc, err = target.Fetch(ctx, desc)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	defer rc.Close()
+	if _, err := io.ReadAll(rc); !errors.Is(err, ErrCorrupted) {
+		t.Errorf("ReadAll() error = %v, want %v", err, ErrCorrupted)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// Expiry returns the earliest expiry time of the secrets in cred, if known.
+// The expiry is known for JSON web tokens with an exp claim, such as OIDC
+// identity tokens, and for ECR authorization tokens.
+func Expiry(cred auth.Credential) (time.Time, bool) {
+	var earliest time.Time
+	for _, secret := range []string{cred.Password, cred.RefreshToken, cred.AccessToken} {
+		if secret == "" {
+			continue
+		}
+		expiry, ok := jwtExpiry(secret)
+		if !ok {
+			expiry, ok = ecrExpiry(secret)
+		}
+		if ok && (earliest.IsZero() || expiry.Before(earliest)) {
+			earliest = expiry
+		}
+	}
+	return earliest, !earliest.IsZero()
+}
+
+// jwtExpiry returns the expiry time in the exp claim of the JSON web token.
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Expiry json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Expiry == "" {
+		return time.Time{}, false
+	}
+	exp, err := claims.Expiry.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(exp), 0), true
+}
+
+// ecrExpiry returns the expiry time of the ECR authorization token, which is
+// a base64-encoded JSON object with an expiration field in Unix seconds.
+func ecrExpiry(token string) (time.Time, bool) {
+	decoded, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, false
+	}
+	var payload struct {
+		Expiration int64 `json:"expiration"`
+	}
+	if err := json.Unmarshal(decoded, &payload); err != nil || payload.Expiration == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(payload.Expiration, 0), true
+}
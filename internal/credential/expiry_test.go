@@ -0,0 +1,58 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestExpiry(t *testing.T) {
+	jwt := func(exp int64) string {
+		payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"alice","exp":%d}`, exp)))
+		return "eyJhbGciOiJSUzI1NiJ9." + payload + ".c2lnbmF0dXJl"
+	}
+	ecr := func(exp int64) string {
+		return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(`{"payload":"p","datakey":"k","version":"2","type":"DATA_KEY","expiration":%d}`, exp)))
+	}
+	tests := []struct {
+		name   string
+		cred   auth.Credential
+		want   int64
+		wantOK bool
+	}{
+		{name: "password", cred: auth.Credential{Username: "alice", Password: "secret"}},
+		{name: "OIDC identity token", cred: auth.Credential{RefreshToken: jwt(1700000000)}, want: 1700000000, wantOK: true},
+		{name: "ECR token", cred: auth.Credential{Username: "AWS", Password: ecr(1700000100)}, want: 1700000100, wantOK: true},
+		{name: "earliest", cred: auth.Credential{Password: ecr(1700000100), AccessToken: jwt(1700000050)}, want: 1700000050, wantOK: true},
+		{name: "JWT without exp", cred: auth.Credential{RefreshToken: "a." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"alice"}`)) + ".b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Expiry(tt.cred)
+			if ok != tt.wantOK {
+				t.Fatalf("Expiry() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !got.Equal(time.Unix(tt.want, 0)) {
+				t.Errorf("Expiry() = %v, want %v", got, time.Unix(tt.want, 0))
+			}
+		})
+	}
+}
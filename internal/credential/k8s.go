@@ -0,0 +1,207 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+)
+
+// SecretTypeDockerConfigJSON is the type of the Kubernetes secrets containing
+// a docker config file, also known as image pull secrets.
+const SecretTypeDockerConfigJSON = "kubernetes.io/dockerconfigjson"
+
+// dockerConfigJSONKey is the data key of the docker config file in the
+// Kubernetes secrets.
+const dockerConfigJSONKey = ".dockerconfigjson"
+
+// kubectl is the program used to read Kubernetes secrets.
+var kubectl = "kubectl"
+
+// dockerConfigJSON is the docker config file in a Kubernetes secret.
+type dockerConfigJSON struct {
+	Auths map[string]dockerAuth `json:"auths"`
+}
+
+// dockerAuth is the credential of a registry in a docker config file.
+type dockerAuth struct {
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	Auth          string `json:"auth,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+// secret is a Kubernetes secret.
+type secret struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   secretMetadata    `json:"metadata"`
+	Type       string            `json:"type"`
+	Data       map[string]string `json:"data"`
+}
+
+// secretMetadata is the metadata of a Kubernetes secret.
+type secretMetadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ParseSecretName parses the Kubernetes secret name in the form of
+// [<namespace>/]<name>.
+func ParseSecretName(s string) (namespace, name string, err error) {
+	namespace, name, found := strings.Cut(s, "/")
+	if !found {
+		namespace, name = "", s
+	}
+	if name == "" || (found && namespace == "") || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid Kubernetes secret %q, expecting [<namespace>/]<name>", s)
+	}
+	return namespace, name, nil
+}
+
+// ReadK8sSecret reads the credentials of the Kubernetes image pull secret name
+// in namespace with kubectl, indexed by the server addresses. The current
+// namespace is used if namespace is empty.
+func ReadK8sSecret(ctx context.Context, namespace, name string) (map[string]auth.Credential, error) {
+	args := []string{"get", "secret", name, "--output", "json"}
+	if namespace != "" {
+		args = append(args, "--namespace", namespace)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, kubectl, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to read Kubernetes secret %s: %w: %s", name, err, msg)
+		}
+		return nil, fmt.Errorf("failed to read Kubernetes secret %s: %w", name, err)
+	}
+	return ParseK8sSecret(stdout.Bytes())
+}
+
+// ParseK8sSecret parses the credentials of a Kubernetes image pull secret in
+// JSON, indexed by the server addresses.
+func ParseK8sSecret(content []byte) (map[string]auth.Credential, error) {
+	var s secret
+	if err := json.Unmarshal(content, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse Kubernetes secret: %w", err)
+	}
+	if s.Type != SecretTypeDockerConfigJSON {
+		return nil, fmt.Errorf("Kubernetes secret %s is of type %q, expecting %q", s.Metadata.Name, s.Type, SecretTypeDockerConfigJSON)
+	}
+	encoded, ok := s.Data[dockerConfigJSONKey]
+	if !ok {
+		return nil, fmt.Errorf("Kubernetes secret %s has no %s", s.Metadata.Name, dockerConfigJSONKey)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s of Kubernetes secret %s: %w", dockerConfigJSONKey, s.Metadata.Name, err)
+	}
+	var cfg dockerConfigJSON
+	if err := json.Unmarshal(decoded, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s of Kubernetes secret %s: %w", dockerConfigJSONKey, s.Metadata.Name, err)
+	}
+	creds := make(map[string]auth.Credential, len(cfg.Auths))
+	for server, a := range cfg.Auths {
+		cred := auth.Credential{
+			Username:     a.Username,
+			Password:     a.Password,
+			RefreshToken: a.IdentityToken,
+			AccessToken:  a.RegistryToken,
+		}
+		if a.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(a.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode the auth of %s: %w", server, err)
+			}
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid auth of %s: expecting <username>:<password>", server)
+			}
+			cred.Username, cred.Password = username, password
+		}
+		creds[server] = cred
+	}
+	return creds, nil
+}
+
+// LookupK8sCredential returns the credential of registry in creds, where the
+// server addresses may be registry host names or URLs.
+func LookupK8sCredential(creds map[string]auth.Credential, registry string) (auth.Credential, bool) {
+	serverAddress := credentials.ServerAddressFromRegistry(registry)
+	if cred, ok := creds[serverAddress]; ok {
+		return cred, true
+	}
+	for server, cred := range creds {
+		host := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+		host, _, _ = strings.Cut(host, "/")
+		if host == registry {
+			return cred, true
+		}
+	}
+	return auth.EmptyCredential, false
+}
+
+// NewK8sSecret returns the Kubernetes image pull secret name in namespace in
+// JSON containing creds indexed by the server addresses.
+func NewK8sSecret(namespace, name string, creds map[string]auth.Credential) ([]byte, error) {
+	cfg := dockerConfigJSON{
+		Auths: make(map[string]dockerAuth, len(creds)),
+	}
+	for server, cred := range creds {
+		a := dockerAuth{
+			IdentityToken: cred.RefreshToken,
+			RegistryToken: cred.AccessToken,
+		}
+		if cred.Username != "" || cred.Password != "" {
+			a.Username = cred.Username
+			a.Password = cred.Password
+			a.Auth = base64.StdEncoding.EncodeToString([]byte(cred.Username + ":" + cred.Password))
+		}
+		if a == (dockerAuth{}) {
+			return nil, errors.New("empty credential for " + server)
+		}
+		cfg.Auths[server] = a
+	}
+	content, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s := secret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata: secretMetadata{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: SecretTypeDockerConfigJSON,
+		Data: map[string]string{
+			dockerConfigJSONKey: base64.StdEncoding.EncodeToString(content),
+		},
+	}
+	return json.MarshalIndent(s, "", "  ")
+}
@@ -0,0 +1,148 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestParseSecretName(t *testing.T) {
+	tests := []struct {
+		input         string
+		wantNamespace string
+		wantName      string
+		wantErr       bool
+	}{
+		{input: "default/regcred", wantNamespace: "default", wantName: "regcred"},
+		{input: "regcred", wantName: "regcred"},
+		{input: "", wantErr: true},
+		{input: "/regcred", wantErr: true},
+		{input: "default/", wantErr: true},
+		{input: "a/b/c", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			namespace, name, err := ParseSecretName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSecretName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if namespace != tt.wantNamespace || name != tt.wantName {
+				t.Errorf("ParseSecretName() = %q, %q, want %q, %q", namespace, name, tt.wantNamespace, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestK8sSecret_roundTrip(t *testing.T) {
+	creds := map[string]auth.Credential{
+		"localhost:5000":              {Username: "alice", Password: "secret"},
+		"https://index.docker.io/v1/": {Username: "bob", RefreshToken: "refresh"},
+	}
+	content, err := NewK8sSecret("default", "regcred", creds)
+	if err != nil {
+		t.Fatalf("NewK8sSecret() error = %v", err)
+	}
+	got, err := ParseK8sSecret(content)
+	if err != nil {
+		t.Fatalf("ParseK8sSecret() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, creds) {
+		t.Errorf("ParseK8sSecret() = %v, want %v", got, creds)
+	}
+	if _, err := NewK8sSecret("", "regcred", map[string]auth.Credential{"localhost:5000": {}}); err == nil {
+		t.Error("NewK8sSecret() error = nil, want error for empty credential")
+	}
+}
+
+func TestParseK8sSecret_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "invalid JSON", content: "{"},
+		{name: "opaque secret", content: `{"type":"Opaque","data":{}}`},
+		{name: "no docker config", content: `{"type":"kubernetes.io/dockerconfigjson","data":{}}`},
+		{name: "invalid base64", content: `{"type":"kubernetes.io/dockerconfigjson","data":{".dockerconfigjson":"!"}}`},
+		// {"auths":{"localhost:5000":{"auth":"YWxpY2U="}}} without password separator
+		{name: "invalid auth", content: `{"type":"kubernetes.io/dockerconfigjson","data":{".dockerconfigjson":"eyJhdXRocyI6eyJsb2NhbGhvc3Q6NTAwMCI6eyJhdXRoIjoiWVd4cFkyVT0ifX19"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseK8sSecret([]byte(tt.content)); err == nil {
+				t.Error("ParseK8sSecret() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestLookupK8sCredential(t *testing.T) {
+	creds := map[string]auth.Credential{
+		"https://registry.example.com/v1/": {Username: "alice", Password: "secret"},
+		"https://index.docker.io/v1/":      {Username: "bob", Password: "secret"},
+	}
+	for registry, want := range map[string]string{
+		"registry.example.com": "alice",
+		"docker.io":            "bob",
+		"localhost:5000":       "",
+	} {
+		cred, ok := LookupK8sCredential(creds, registry)
+		if ok != (want != "") || cred.Username != want {
+			t.Errorf("LookupK8sCredential(%q) = %v, %v, want username %q", registry, cred, ok, want)
+		}
+	}
+}
+
+func TestReadK8sSecret(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not supported")
+	}
+	content, err := NewK8sSecret("default", "regcred", map[string]auth.Credential{
+		"localhost:5000": {Username: "alice", Password: "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "secret.json")
+	if err := os.WriteFile(secretPath, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\n[ \"$*\" = \"get secret regcred --output json --namespace default\" ] || { echo unexpected args >&2; exit 1; }\ncat " + secretPath + "\n"
+	fakeKubectl := filepath.Join(dir, "kubectl")
+	if err := os.WriteFile(fakeKubectl, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	defer func(original string) { kubectl = original }(kubectl)
+	kubectl = fakeKubectl
+
+	got, err := ReadK8sSecret(context.Background(), "default", "regcred")
+	if err != nil {
+		t.Fatalf("ReadK8sSecret() error = %v", err)
+	}
+	if want := (auth.Credential{Username: "alice", Password: "secret"}); got["localhost:5000"] != want {
+		t.Errorf("ReadK8sSecret() = %v, want %v", got, want)
+	}
+	if _, err := ReadK8sSecret(context.Background(), "", "regcred"); err == nil {
+		t.Error("ReadK8sSecret() error = nil, want kubectl failure")
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ErrRefreshTokenNotIssued is returned by FetchRefreshToken if the registry
+// does not issue refresh tokens.
+var ErrRefreshTokenNotIssued = errors.New("the registry does not issue refresh tokens")
+
+// clientID is the client identifier sent to the token services.
+const clientID = "oras"
+
+// maxTokenResponseBytes limits the size of the token responses read.
+const maxTokenResponseBytes = 128 * 1024
+
+// challengeParamRegexp matches the auth-params of a challenge, e.g.
+// realm="https://auth.example.com/token".
+var challengeParamRegexp = regexp.MustCompile(`([a-zA-Z_]+)="([^"]*)"`)
+
+// FetchRefreshToken exchanges the username and password in cred for a
+// refresh token issued by the bearer token service of the registry at
+// registryURL, e.g. https://localhost:5000. The token is requested as an
+// offline token as described in the distribution token authentication
+// specification, falling back to the OAuth2 password grant.
+func FetchRefreshToken(ctx context.Context, client *http.Client, registryURL string, cred auth.Credential) (string, error) {
+	realm, service, err := bearerChallenge(ctx, client, registryURL)
+	if err != nil {
+		return "", err
+	}
+	token, err := fetchOfflineToken(ctx, client, realm, service, cred)
+	if err != nil || token != "" {
+		return token, err
+	}
+	token, err = fetchOAuth2RefreshToken(ctx, client, realm, service, cred)
+	if err != nil || token != "" {
+		return token, err
+	}
+	return "", ErrRefreshTokenNotIssued
+}
+
+// bearerChallenge returns the realm and the service of the bearer challenge
+// of the registry.
+func bearerChallenge(ctx context.Context, client *http.Client, registryURL string) (realm, service string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, registryURL+"/v2/", nil)
+	if err != nil {
+		return "", "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		return "", "", ErrRefreshTokenNotIssued
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", "", ErrRefreshTokenNotIssued
+	}
+	for _, match := range challengeParamRegexp.FindAllStringSubmatch(params, -1) {
+		switch strings.ToLower(match[1]) {
+		case "realm":
+			realm = match[2]
+		case "service":
+			service = match[2]
+		}
+	}
+	if realm == "" {
+		return "", "", fmt.Errorf("no realm in the bearer challenge of %s", registryURL)
+	}
+	return realm, service, nil
+}
+
+// fetchOfflineToken requests an offline token with the basic credential and
+// returns the refresh token in the response, if any.
+func fetchOfflineToken(ctx context.Context, client *http.Client, realm, service string, cred auth.Credential) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm, nil)
+	if err != nil {
+		return "", err
+	}
+	query := req.URL.Query()
+	if service != "" {
+		query.Set("service", service)
+	}
+	query.Set("client_id", clientID)
+	query.Set("offline_token", "true")
+	req.URL.RawQuery = query.Encode()
+	req.SetBasicAuth(cred.Username, cred.Password)
+	return refreshTokenFromResponse(client, req)
+}
+
+// fetchOAuth2RefreshToken requests a token with the OAuth2 password grant
+// and returns the refresh token in the response, if any.
+func fetchOAuth2RefreshToken(ctx context.Context, client *http.Client, realm, service string, cred auth.Credential) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "password")
+	form.Set("username", cred.Username)
+	form.Set("password", cred.Password)
+	form.Set("access_type", "offline")
+	form.Set("client_id", clientID)
+	if service != "" {
+		form.Set("service", service)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, realm, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return refreshTokenFromResponse(client, req)
+}
+
+// refreshTokenFromResponse sends req and returns the refresh token in the
+// response. An empty token is returned if the request is not supported.
+func refreshTokenFromResponse(client *http.Client, req *http.Request) (string, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return "", fmt.Errorf("%s %q: credential rejected by the token service", req.Method, req.URL.Redacted())
+	case resp.StatusCode != http.StatusOK:
+		return "", nil
+	}
+	var result struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxTokenResponseBytes)).Decode(&result); err != nil {
+		return "", nil
+	}
+	return result.RefreshToken, nil
+}
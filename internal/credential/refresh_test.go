@@ -0,0 +1,105 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestFetchRefreshToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+		offline   bool
+		oauth2    bool
+		want      string
+		wantErr   error
+	}{
+		{name: "offline token", challenge: "Bearer", offline: true, want: "offline-refresh-token"},
+		{name: "OAuth2 password grant", challenge: "Bearer", oauth2: true, want: "oauth2-refresh-token"},
+		{name: "not issued", challenge: "Bearer", wantErr: ErrRefreshTokenNotIssued},
+		{name: "basic auth", challenge: "Basic", wantErr: ErrRefreshTokenNotIssued},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts *httptest.Server
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v2/":
+					w.Header().Set("WWW-Authenticate", fmt.Sprintf(`%s realm="%s/token",service="registry.test"`, tt.challenge, ts.URL))
+					w.WriteHeader(http.StatusUnauthorized)
+				case "/token":
+					if r.Method == http.MethodGet {
+						if username, password, ok := r.BasicAuth(); !ok || username != "alice" || password != "secret" {
+							w.WriteHeader(http.StatusUnauthorized)
+							return
+						}
+						if r.URL.Query().Get("offline_token") != "true" || r.URL.Query().Get("service") != "registry.test" {
+							t.Errorf("unexpected token query %q", r.URL.RawQuery)
+						}
+						if tt.offline {
+							_, _ = w.Write([]byte(`{"token":"access","refresh_token":"offline-refresh-token"}`))
+						} else {
+							_, _ = w.Write([]byte(`{"token":"access"}`))
+						}
+						return
+					}
+					if !tt.oauth2 {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					if r.PostFormValue("grant_type") != "password" || r.PostFormValue("password") != "secret" {
+						t.Errorf("unexpected token form %v", r.PostForm)
+					}
+					_, _ = w.Write([]byte(`{"access_token":"access","refresh_token":"oauth2-refresh-token"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer ts.Close()
+			cred := auth.Credential{Username: "alice", Password: "secret"}
+			got, err := FetchRefreshToken(context.Background(), ts.Client(), ts.URL, cred)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FetchRefreshToken() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FetchRefreshToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchRefreshToken_rejected(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token"`, ts.URL))
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+	cred := auth.Credential{Username: "alice", Password: "wrong"}
+	if _, err := FetchRefreshToken(context.Background(), ts.Client(), ts.URL, cred); err == nil || errors.Is(err, ErrRefreshTokenNotIssued) {
+		t.Errorf("FetchRefreshToken() error = %v, want rejection", err)
+	}
+}
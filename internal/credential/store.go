@@ -16,15 +16,40 @@ limitations under the License.
 package credential
 
 import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
 	"oras.land/oras-go/v2/registry/remote/credentials"
 )
 
 // NewStore generates a store based on the passed-in config file paths.
+// If no path is passed in, the docker config file is used with the podman
+// auth.json files found as read-only fallbacks.
 func NewStore(configPaths ...string) (credentials.Store, error) {
 	opts := credentials.StoreOptions{AllowPlaintextPut: true}
 	if len(configPaths) == 0 {
 		// use default docker config file path
-		return credentials.NewStoreFromDocker(opts)
+		store, err := credentials.NewStoreFromDocker(opts)
+		if err != nil {
+			return nil, err
+		}
+		var fallbacks []credentials.Store
+		for _, path := range existingFiles(PodmanConfigPaths()) {
+			fallback, err := credentials.NewStore(path, credentials.StoreOptions{})
+			if err != nil {
+				return nil, err
+			}
+			fallbacks = append(fallbacks, fallback)
+		}
+		if len(fallbacks) == 0 {
+			return store, nil
+		}
+		return &storeWithFallbacks{
+			Store:      credentials.NewStoreWithFallbacks(store, fallbacks...),
+			configPath: store.ConfigPath(),
+		}, nil
 	}
 
 	var stores []credentials.Store
@@ -37,3 +62,66 @@ func NewStore(configPaths ...string) (credentials.Store, error) {
 	}
 	return credentials.NewStoreWithFallbacks(stores[0], stores[1:]...), nil
 }
+
+// storeWithFallbacks is a store with fallbacks keeping the config path of the
+// primary store.
+type storeWithFallbacks struct {
+	credentials.Store
+	configPath string
+}
+
+// ConfigPath returns the path of the config file of the primary store.
+func (s *storeWithFallbacks) ConfigPath() string {
+	return s.configPath
+}
+
+// DockerConfigPath returns the path of the docker config file, which is
+// config.json under $DOCKER_CONFIG or ~/.docker.
+func DockerConfigPath() (string, error) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// PodmanConfigPaths returns the paths of the podman auth.json files in the
+// order they are searched by podman, see containers-auth.json(5).
+func PodmanConfigPaths() []string {
+	if path := os.Getenv("REGISTRY_AUTH_FILE"); path != "" {
+		return []string{path}
+	}
+	var paths []string
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		paths = append(paths, filepath.Join(dir, "containers", "auth.json"))
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, "containers", "auth.json"))
+	}
+	return paths
+}
+
+// DefaultConfigPaths returns the paths of the config files searched for
+// credentials when no config file is specified.
+func DefaultConfigPaths() ([]string, error) {
+	docker, err := DockerConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{docker}, existingFiles(PodmanConfigPaths())...), nil
+}
+
+// existingFiles returns the paths of the files that exist.
+func existingFiles(paths []string) []string {
+	var existing []string
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil || !errors.Is(err, fs.ErrNotExist) {
+			existing = append(existing, path)
+		}
+	}
+	return existing
+}
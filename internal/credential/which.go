@@ -0,0 +1,123 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+)
+
+// Mechanism is how a config file provides credentials.
+type Mechanism string
+
+const (
+	// MechanismFile means the credential is stored in the "auths" section of
+	// the config file.
+	MechanismFile Mechanism = "auths"
+	// MechanismHelper means the credential is provided by the credential
+	// helper configured for the registry in the "credHelpers" section.
+	MechanismHelper Mechanism = "credHelpers"
+	// MechanismStore means the credential is provided by the default
+	// credential store configured in the "credsStore" section.
+	MechanismStore Mechanism = "credsStore"
+)
+
+// Source describes where the credential of a registry is found.
+type Source struct {
+	// ConfigPath is the path of the config file providing the credential.
+	ConfigPath string
+	// Mechanism is how the config file provides the credential.
+	Mechanism Mechanism
+	// Helper is the name of the credential helper program, e.g.
+	// docker-credential-pass, if the credential comes from a helper.
+	Helper string
+	// Credential is the credential found.
+	Credential auth.Credential
+}
+
+// configFile contains the fields of a config file deciding where credentials
+// are stored.
+type configFile struct {
+	CredentialHelpers map[string]string `json:"credHelpers,omitempty"`
+	CredentialsStore  string            `json:"credsStore,omitempty"`
+}
+
+// Which returns where the credential of registry is found by searching the
+// config files at configPaths in order, or the default config files if no path
+// is passed in. Nil is returned if no credential is found.
+func Which(ctx context.Context, registry string, configPaths ...string) (*Source, error) {
+	if len(configPaths) == 0 {
+		var err error
+		if configPaths, err = DefaultConfigPaths(); err != nil {
+			return nil, err
+		}
+	}
+	serverAddress := credentials.ServerAddressFromRegistry(registry)
+	for _, path := range configPaths {
+		store, err := credentials.NewStore(path, credentials.StoreOptions{})
+		if err != nil {
+			return nil, err
+		}
+		cred, err := store.Get(ctx, serverAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get credential from %s: %w", path, err)
+		}
+		if cred == auth.EmptyCredential {
+			continue
+		}
+		source := &Source{
+			ConfigPath: path,
+			Mechanism:  MechanismFile,
+			Credential: cred,
+		}
+		cfg, err := readConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if helper := cfg.CredentialHelpers[serverAddress]; helper != "" {
+			source.Mechanism = MechanismHelper
+			source.Helper = "docker-credential-" + helper
+		} else if cfg.CredentialsStore != "" {
+			source.Mechanism = MechanismStore
+			source.Helper = "docker-credential-" + cfg.CredentialsStore
+		}
+		return source, nil
+	}
+	return nil, nil
+}
+
+// readConfigFile reads the credential settings of the config file at path.
+func readConfigFile(path string) (configFile, error) {
+	var cfg configFile
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credential
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWhich(t *testing.T) {
+	dir := t.TempDir()
+	docker := filepath.Join(dir, "config.json")
+	// "YWxpY2U6c2VjcmV0" is base64 of "alice:secret"
+	if err := os.WriteFile(docker, []byte(`{"auths":{"localhost:5000":{"auth":"YWxpY2U6c2VjcmV0"}},"credHelpers":{"helper.example.com":"oras-test-missing"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	podman := filepath.Join(dir, "auth.json")
+	// "Ym9iOnNlY3JldA==" is base64 of "bob:secret"
+	if err := os.WriteFile(podman, []byte(`{"auths":{"localhost:5000":{"auth":"Ym9iOnNlY3JldA=="},"podman.example.com":{"auth":"Ym9iOnNlY3JldA=="}}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		registry     string
+		wantPath     string
+		wantUsername string
+	}{
+		{
+			name:         "first config file wins",
+			registry:     "localhost:5000",
+			wantPath:     docker,
+			wantUsername: "alice",
+		},
+		{
+			name:         "fallback config file",
+			registry:     "podman.example.com",
+			wantPath:     podman,
+			wantUsername: "bob",
+		},
+		{
+			name:     "not found",
+			registry: "example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Which(context.Background(), tt.registry, docker, podman)
+			if err != nil {
+				t.Fatalf("Which() error = %v", err)
+			}
+			if tt.wantPath == "" {
+				if got != nil {
+					t.Fatalf("Which() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("Which() = nil, want source")
+			}
+			if got.ConfigPath != tt.wantPath || got.Mechanism != MechanismFile || got.Credential.Username != tt.wantUsername {
+				t.Errorf("Which() = %+v, want path %s, username %s", got, tt.wantPath, tt.wantUsername)
+			}
+		})
+	}
+
+	// the helper configured for the registry is not installed
+	if _, err := Which(context.Background(), "helper.example.com", docker); err == nil {
+		t.Error("Which() error = nil, want error of missing helper")
+	}
+}
+
+func TestPodmanConfigPaths(t *testing.T) {
+	t.Setenv("REGISTRY_AUTH_FILE", "/tmp/auth.json")
+	if got := PodmanConfigPaths(); len(got) != 1 || got[0] != "/tmp/auth.json" {
+		t.Errorf("PodmanConfigPaths() = %v, want [/tmp/auth.json]", got)
+	}
+	t.Setenv("REGISTRY_AUTH_FILE", "")
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	if got := PodmanConfigPaths(); len(got) == 0 || got[0] != "/run/user/1000/containers/auth.json" {
+		t.Errorf("PodmanConfigPaths() = %v, want runtime dir first", got)
+	}
+}
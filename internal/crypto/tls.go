@@ -0,0 +1,109 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// tlsVersions maps the supported version names to TLS versions.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// fipsCipherSuites are the TLS 1.2 cipher suites approved by FIPS 140-3.
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// fipsCurves are the key exchange curves approved by FIPS 140-3.
+var fipsCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+// ParseTLSVersion parses a TLS version such as "1.2".
+func ParseTLSVersion(version string) (uint16, error) {
+	if v, ok := tlsVersions[strings.TrimPrefix(strings.ToLower(version), "tls")]; ok {
+		return v, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS version %q, expecting one of 1.0, 1.1, 1.2 or 1.3", version)
+}
+
+// ParseCipherSuites parses cipher suite names such as
+// "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256". Cipher suites considered insecure
+// by Go are rejected.
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+	insecure := make(map[string]bool)
+	for _, suite := range tls.InsecureCipherSuites() {
+		insecure[suite.Name] = true
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		id, ok := known[name]
+		if !ok {
+			if insecure[name] {
+				return nil, fmt.Errorf("cipher suite %s is insecure and not allowed", name)
+			}
+			return nil, fmt.Errorf("unknown cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ApplyFIPS restricts config to FIPS 140-3 approved TLS versions, cipher
+// suites and key exchanges. An error is returned if config explicitly allows
+// algorithms that are not approved.
+// TLS 1.3 cipher suites cannot be configured, so TLS 1.3 is disabled unless
+// the Go FIPS 140-3 module is enabled, e.g. with GODEBUG=fips140=on.
+func ApplyFIPS(config *tls.Config) error {
+	if config.MinVersion != 0 && config.MinVersion < tls.VersionTLS12 {
+		return fmt.Errorf("TLS version %s is not allowed in FIPS mode", tls.VersionName(config.MinVersion))
+	}
+	config.MinVersion = max(config.MinVersion, tls.VersionTLS12)
+	if !fips140.Enabled() {
+		if config.MinVersion > tls.VersionTLS12 {
+			return fmt.Errorf("TLS 1.3 requires the Go FIPS 140-3 module in FIPS mode, set GODEBUG=fips140=on to enable it")
+		}
+		config.MaxVersion = tls.VersionTLS12
+	}
+	for _, id := range config.CipherSuites {
+		if !slices.Contains(fipsCipherSuites, id) {
+			return fmt.Errorf("cipher suite %s is not allowed in FIPS mode", tls.CipherSuiteName(id))
+		}
+	}
+	if len(config.CipherSuites) == 0 {
+		config.CipherSuites = slices.Clone(fipsCipherSuites)
+	}
+	config.CurvePreferences = slices.Clone(fipsCurves)
+	return nil
+}
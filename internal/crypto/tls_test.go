@@ -0,0 +1,129 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"reflect"
+	"testing"
+)
+
+func TestParseTLSVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    uint16
+		wantErr bool
+	}{
+		{version: "1.2", want: tls.VersionTLS12},
+		{version: "1.3", want: tls.VersionTLS13},
+		{version: "TLS1.2", want: tls.VersionTLS12},
+		{version: "1.4", wantErr: true},
+		{version: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := ParseTLSVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTLSVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTLSVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []uint16
+		wantErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "secure suites",
+			names: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "tls_ecdhe_ecdsa_with_aes_256_gcm_sha384"},
+			want:  []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+		},
+		{
+			name:    "insecure suite",
+			names:   []string{"TLS_RSA_WITH_RC4_128_SHA"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown suite",
+			names:   []string{"TLS_FOO"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCipherSuites(tt.names)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCipherSuites() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCipherSuites() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyFIPS(t *testing.T) {
+	config := &tls.Config{}
+	if err := ApplyFIPS(config); err != nil {
+		t.Fatalf("ApplyFIPS() error = %v", err)
+	}
+	if config.MinVersion != tls.VersionTLS12 {
+		t.Errorf("MinVersion = %v, want %v", config.MinVersion, tls.VersionTLS12)
+	}
+	if !fips140.Enabled() && config.MaxVersion != tls.VersionTLS12 {
+		t.Errorf("MaxVersion = %v, want %v", config.MaxVersion, tls.VersionTLS12)
+	}
+	if !reflect.DeepEqual(config.CipherSuites, fipsCipherSuites) {
+		t.Errorf("CipherSuites = %v, want %v", config.CipherSuites, fipsCipherSuites)
+	}
+	if !reflect.DeepEqual(config.CurvePreferences, fipsCurves) {
+		t.Errorf("CurvePreferences = %v, want %v", config.CurvePreferences, fipsCurves)
+	}
+}
+
+func TestApplyFIPS_notAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *tls.Config
+	}{
+		{
+			name:   "TLS 1.1",
+			config: &tls.Config{MinVersion: tls.VersionTLS11},
+		},
+		{
+			name:   "ChaCha20",
+			config: &tls.Config{CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ApplyFIPS(tt.config); err == nil {
+				t.Errorf("ApplyFIPS() error = nil, want error")
+			}
+		})
+	}
+}
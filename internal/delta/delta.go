@@ -0,0 +1,218 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package delta computes and applies binary deltas between file versions.
+package delta
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"oras.land/oras/internal/fastcdc"
+)
+
+// magic starts every delta.
+var magic = []byte("ORASDLT1")
+
+// operations of a delta.
+const (
+	// opCopy copies a range of the base: uvarint offset, uvarint length.
+	opCopy byte = 'C'
+	// opInsert inserts literal data: uvarint length, data.
+	opInsert byte = 'I'
+)
+
+// maxPendingInsert is the size of literal data buffered before writing.
+const maxPendingInsert = 1 << 20
+
+// chunking splits both versions into small content-defined chunks so that
+// unchanged ranges are found regardless of insertions and deletions.
+var chunking = fastcdc.NewOptions(4 << 10)
+
+// ErrInvalidDelta is returned when applying a malformed delta.
+var ErrInvalidDelta = errors.New("invalid delta")
+
+// extent is a range of the base.
+type extent struct {
+	offset int64
+	length int64
+}
+
+// Diff writes to w a delta turning base into target.
+func Diff(base io.Reader, target io.Reader, w io.Writer) error {
+	// index the chunks of the base
+	extents := make(map[[sha256.Size]byte]extent)
+	chunker, err := fastcdc.NewChunker(base, chunking)
+	if err != nil {
+		return err
+	}
+	for {
+		c, err := chunker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		key := sha256.Sum256(c.Data)
+		if _, ok := extents[key]; !ok {
+			extents[key] = extent{offset: c.Offset, length: int64(len(c.Data))}
+		}
+	}
+
+	enc := &encoder{w: bufio.NewWriter(w)}
+	if _, err := enc.w.Write(magic); err != nil {
+		return err
+	}
+	chunker, err = fastcdc.NewChunker(target, chunking)
+	if err != nil {
+		return err
+	}
+	for {
+		c, err := chunker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if e, ok := extents[sha256.Sum256(c.Data)]; ok {
+			err = enc.copy(e)
+		} else {
+			err = enc.insert(c.Data)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := enc.flush(); err != nil {
+		return err
+	}
+	return enc.w.Flush()
+}
+
+// encoder encodes delta operations, merging adjacent ones.
+type encoder struct {
+	w       *bufio.Writer
+	pending extent
+	literal []byte
+}
+
+// copy adds a copy operation.
+func (e *encoder) copy(ext extent) error {
+	if e.pending.length > 0 && e.pending.offset+e.pending.length == ext.offset {
+		e.pending.length += ext.length
+		return nil
+	}
+	if err := e.flush(); err != nil {
+		return err
+	}
+	e.pending = ext
+	return nil
+}
+
+// insert adds an insert operation.
+func (e *encoder) insert(data []byte) error {
+	if e.pending.length > 0 {
+		if err := e.flush(); err != nil {
+			return err
+		}
+	}
+	e.literal = append(e.literal, data...)
+	if len(e.literal) >= maxPendingInsert {
+		return e.flush()
+	}
+	return nil
+}
+
+// flush writes the pending operation.
+func (e *encoder) flush() error {
+	var buf [2 * binary.MaxVarintLen64]byte
+	switch {
+	case e.pending.length > 0:
+		n := binary.PutUvarint(buf[:], uint64(e.pending.offset))
+		n += binary.PutUvarint(buf[n:], uint64(e.pending.length))
+		e.pending = extent{}
+		if err := e.w.WriteByte(opCopy); err != nil {
+			return err
+		}
+		_, err := e.w.Write(buf[:n])
+		return err
+	case len(e.literal) > 0:
+		n := binary.PutUvarint(buf[:], uint64(len(e.literal)))
+		if err := e.w.WriteByte(opInsert); err != nil {
+			return err
+		}
+		if _, err := e.w.Write(buf[:n]); err != nil {
+			return err
+		}
+		_, err := e.w.Write(e.literal)
+		e.literal = e.literal[:0]
+		return err
+	}
+	return nil
+}
+
+// Apply applies delta to base and writes the result to w.
+func Apply(base io.ReaderAt, delta io.Reader, w io.Writer) error {
+	r := bufio.NewReader(delta)
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(r, header); err != nil || !bytes.Equal(header, magic) {
+		return fmt.Errorf("%w: bad header", ErrInvalidDelta)
+	}
+	for {
+		op, err := r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch op {
+		case opCopy:
+			offset, err1 := binary.ReadUvarint(r)
+			length, err2 := binary.ReadUvarint(r)
+			if err := errors.Join(err1, err2); err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidDelta, err)
+			}
+			n, err := io.Copy(w, io.NewSectionReader(base, int64(offset), int64(length)))
+			if err != nil {
+				return err
+			}
+			if n != int64(length) {
+				return fmt.Errorf("%w: copy beyond the end of the base", ErrInvalidDelta)
+			}
+		case opInsert:
+			length, err := binary.ReadUvarint(r)
+			if err != nil {
+				return fmt.Errorf("%w: %v", ErrInvalidDelta, err)
+			}
+			n, err := io.CopyN(w, r, int64(length))
+			if err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+			if n != int64(length) {
+				return fmt.Errorf("%w: truncated insert", ErrInvalidDelta)
+			}
+		default:
+			return fmt.Errorf("%w: unknown operation %q", ErrInvalidDelta, op)
+		}
+	}
+}
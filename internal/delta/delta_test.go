@@ -0,0 +1,87 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delta
+
+import (
+	"bytes"
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+func randomBytes(seed uint64, n int) []byte {
+	r := rand.New(rand.NewPCG(seed, seed))
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(r.Uint32())
+	}
+	return data
+}
+
+func TestDiffApply(t *testing.T) {
+	base := randomBytes(1, 256*1024)
+	tests := []struct {
+		name   string
+		target []byte
+		// maxDelta is the maximum expected delta size
+		maxDelta int
+	}{
+		{"identical", base, 64},
+		{"insertion", append(append(bytes.Clone(base[:100000]), "inserted"...), base[100000:]...), 40 * 1024},
+		{"deletion", append(bytes.Clone(base[:50000]), base[60000:]...), 40 * 1024},
+		{"unrelated", randomBytes(2, 64*1024), 65 * 1024},
+		{"empty", nil, 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var delta bytes.Buffer
+			if err := Diff(bytes.NewReader(base), bytes.NewReader(tt.target), &delta); err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if delta.Len() > tt.maxDelta {
+				t.Errorf("delta size = %d, want at most %d", delta.Len(), tt.maxDelta)
+			}
+			var got bytes.Buffer
+			if err := Apply(bytes.NewReader(base), &delta, &got); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if !bytes.Equal(got.Bytes(), tt.target) {
+				t.Error("Apply() did not reconstruct the target")
+			}
+		})
+	}
+}
+
+func TestApply_invalid(t *testing.T) {
+	base := []byte("base content")
+	tests := []struct {
+		name  string
+		delta []byte
+	}{
+		{"bad header", []byte("NOTDELTA")},
+		{"unknown operation", append(bytes.Clone(magic), 'X')},
+		{"copy beyond base", append(bytes.Clone(magic), opCopy, 4, 100)},
+		{"truncated insert", append(bytes.Clone(magic), opInsert, 10, 'a')},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := Apply(bytes.NewReader(base), bytes.NewReader(tt.delta), &out); !errors.Is(err, ErrInvalidDelta) {
+				t.Errorf("Apply() error = %v, want ErrInvalidDelta", err)
+			}
+		})
+	}
+}
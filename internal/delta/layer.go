@@ -0,0 +1,223 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+)
+
+// MediaTypeDelta is the media type of delta layers.
+const MediaTypeDelta = "application/vnd.oras.delta.v1"
+
+// Annotations of delta layers and of the manifests containing them.
+const (
+	// AnnotationFrom is the digest of the manifest the deltas of a manifest
+	// are based on.
+	AnnotationFrom = "land.oras.delta.from"
+	// AnnotationName is the name of the file a delta layer reconstructs.
+	AnnotationName = "land.oras.delta.name"
+	// AnnotationBase is the digest of the base content of a delta layer.
+	AnnotationBase = "land.oras.delta.base"
+	// AnnotationDigest is the digest of the reconstructed file.
+	AnnotationDigest = "land.oras.delta.digest"
+	// AnnotationSize is the size of the reconstructed file.
+	AnnotationSize = "land.oras.delta.size"
+	// AnnotationMediaType is the media type of the reconstructed file.
+	AnnotationMediaType = "land.oras.delta.mediaType"
+)
+
+// ErrFileNotFound is returned if a file is not found in a manifest.
+var ErrFileNotFound = errors.New("file not found")
+
+// IsDelta returns true if desc is a delta layer.
+func IsDelta(desc ocispec.Descriptor) bool {
+	return desc.MediaType == MediaTypeDelta && desc.Annotations[AnnotationName] != ""
+}
+
+// NewLayer returns the descriptor of a delta layer of the given digest and
+// size, reconstructing target from the content of base.
+func NewLayer(dgst digest.Digest, size int64, name string, base digest.Digest, target ocispec.Descriptor) ocispec.Descriptor {
+	desc := ocispec.Descriptor{
+		MediaType: MediaTypeDelta,
+		Digest:    dgst,
+		Size:      size,
+	}
+	desc.Annotations = map[string]string{
+		AnnotationName:      name,
+		AnnotationBase:      base.String(),
+		AnnotationDigest:    target.Digest.String(),
+		AnnotationSize:      strconv.FormatInt(target.Size, 10),
+		AnnotationMediaType: target.MediaType,
+	}
+	return desc
+}
+
+// Target returns the descriptor of the file reconstructed by a delta layer.
+func Target(desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+	name := desc.Annotations[AnnotationName]
+	dgst, err := digest.Parse(desc.Annotations[AnnotationDigest])
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("delta layer %s of %s: invalid digest: %w", desc.Digest, name, err)
+	}
+	size, err := strconv.ParseInt(desc.Annotations[AnnotationSize], 10, 64)
+	if err != nil || size < 0 {
+		return ocispec.Descriptor{}, fmt.Errorf("delta layer %s of %s: invalid size %q", desc.Digest, name, desc.Annotations[AnnotationSize])
+	}
+	return ocispec.Descriptor{
+		MediaType: desc.Annotations[AnnotationMediaType],
+		Digest:    dgst,
+		Size:      size,
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: name,
+		},
+	}, nil
+}
+
+// Resolver reconstructs files of artifacts, following chains of delta layers
+// back to full layers.
+type Resolver struct {
+	// Source is where the manifests and layers are fetched from.
+	Source oras.ReadOnlyTarget
+	// TempDir is where the reconstructed files are stored. The caller is
+	// responsible for removing it.
+	TempDir string
+	// Local optionally returns the path of a local file named name with the
+	// content of dgst, which is used instead of fetching or reconstructing it.
+	Local func(name string, dgst digest.Digest) (string, bool)
+}
+
+// Reconstruct returns the path to the content of the file named name in the
+// manifest described by manifestDesc, and the descriptor of the content.
+func (r *Resolver) Reconstruct(ctx context.Context, manifestDesc ocispec.Descriptor, name string) (string, ocispec.Descriptor, error) {
+	manifestJSON, err := content.FetchAll(ctx, r.Source, manifestDesc)
+	if err != nil {
+		return "", ocispec.Descriptor{}, err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return "", ocispec.Descriptor{}, fmt.Errorf("failed to parse manifest %s: %w", manifestDesc.Digest, err)
+	}
+	for _, layer := range manifest.Layers {
+		switch {
+		case layer.Annotations[ocispec.AnnotationTitle] == name:
+			if path, ok := r.local(name, layer.Digest); ok {
+				return path, layer, nil
+			}
+			path, err := r.fetch(ctx, layer, func(rc io.Reader, w io.Writer) error {
+				_, err := io.Copy(w, rc)
+				return err
+			})
+			return path, layer, err
+		case IsDelta(layer) && layer.Annotations[AnnotationName] == name:
+			target, err := Target(layer)
+			if err != nil {
+				return "", ocispec.Descriptor{}, err
+			}
+			if path, ok := r.local(name, target.Digest); ok {
+				return path, target, nil
+			}
+			path, err := r.applyLayer(ctx, manifest, layer, target)
+			return path, target, err
+		}
+	}
+	return "", ocispec.Descriptor{}, fmt.Errorf("%s in manifest %s: %w", name, manifestDesc.Digest, ErrFileNotFound)
+}
+
+// applyLayer reconstructs the target of a delta layer.
+func (r *Resolver) applyLayer(ctx context.Context, manifest ocispec.Manifest, layer, target ocispec.Descriptor) (string, error) {
+	name := layer.Annotations[AnnotationName]
+	from := manifest.Annotations[AnnotationFrom]
+	if from == "" {
+		return "", fmt.Errorf("delta layer %s of %s: missing %q manifest annotation", layer.Digest, name, AnnotationFrom)
+	}
+	baseManifest, err := r.Source.Resolve(ctx, from)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the base manifest %s of %s: %w", from, name, err)
+	}
+	basePath, baseDesc, err := r.Reconstruct(ctx, baseManifest, name)
+	if err != nil {
+		return "", err
+	}
+	if baseDesc.Digest.String() != layer.Annotations[AnnotationBase] {
+		return "", fmt.Errorf("delta layer %s of %s: base %s does not match %s", layer.Digest, name, baseDesc.Digest, layer.Annotations[AnnotationBase])
+	}
+	base, err := os.Open(basePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = base.Close() }()
+
+	verifier := target.Digest.Verifier()
+	path, err := r.fetch(ctx, layer, func(delta io.Reader, w io.Writer) error {
+		return Apply(base, delta, io.MultiWriter(w, verifier))
+	})
+	if err != nil {
+		return "", err
+	}
+	if !verifier.Verified() {
+		return "", fmt.Errorf("%s: reconstructed content does not match %s", name, target.Digest)
+	}
+	return path, nil
+}
+
+// fetch fetches and verifies desc, and writes the content transformed by
+// transform to a temporary file.
+func (r *Resolver) fetch(ctx context.Context, desc ocispec.Descriptor, transform func(io.Reader, io.Writer) error) (path string, err error) {
+	rc, err := r.Source.Fetch(ctx, desc)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rc.Close() }()
+	f, err := os.CreateTemp(r.TempDir, "delta-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+	vr := content.NewVerifyReader(rc, desc)
+	if err := transform(vr, f); err != nil {
+		return "", err
+	}
+	if err := vr.Verify(); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// local returns the local file named name with the content of dgst.
+func (r *Resolver) local(name string, dgst digest.Digest) (string, bool) {
+	if r.Local == nil {
+		return "", false
+	}
+	return r.Local(name, dgst)
+}
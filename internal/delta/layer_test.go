@@ -0,0 +1,119 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delta
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+// pushManifest pushes a manifest of layers to store.
+func pushManifest(t *testing.T, store *memory.Store, layers []ocispec.Descriptor, annotations map[string]string) ocispec.Descriptor {
+	t.Helper()
+	manifest := ocispec.Manifest{
+		MediaType:   ocispec.MediaTypeImageManifest,
+		Config:      ocispec.DescriptorEmptyJSON,
+		Layers:      layers,
+		Annotations: annotations,
+	}
+	manifest.SchemaVersion = 2
+	blob, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, blob)
+	if err := store.Push(context.Background(), desc, bytes.NewReader(blob)); err != nil {
+		t.Fatal(err)
+	}
+	// resolvable by digest as in a repository
+	if err := store.Tag(context.Background(), desc, desc.Digest.String()); err != nil {
+		t.Fatal(err)
+	}
+	return desc
+}
+
+// pushBlob pushes a blob to store.
+func pushBlob(t *testing.T, store *memory.Store, desc ocispec.Descriptor, data []byte) {
+	t.Helper()
+	if err := store.Push(context.Background(), desc, bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolver_Reconstruct(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	v1 := randomBytes(3, 128*1024)
+	v2 := append(bytes.Clone(v1), "v2"...)
+	v3 := append(bytes.Clone(v2), "v3"...)
+
+	// v1 is a full layer
+	v1Desc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, v1)
+	v1Desc.Annotations = map[string]string{ocispec.AnnotationTitle: "app.bin"}
+	pushBlob(t, store, v1Desc, v1)
+	m1 := pushManifest(t, store, []ocispec.Descriptor{v1Desc}, nil)
+
+	// v2 and v3 are deltas
+	newDelta := func(base, target []byte) ocispec.Descriptor {
+		var buf bytes.Buffer
+		if err := Diff(bytes.NewReader(base), bytes.NewReader(target), &buf); err != nil {
+			t.Fatal(err)
+		}
+		desc := NewLayer(digest.FromBytes(buf.Bytes()), int64(buf.Len()), "app.bin", digest.FromBytes(base), content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, target))
+		pushBlob(t, store, desc, buf.Bytes())
+		return desc
+	}
+	m2 := pushManifest(t, store, []ocispec.Descriptor{newDelta(v1, v2)}, map[string]string{AnnotationFrom: m1.Digest.String()})
+	m3 := pushManifest(t, store, []ocispec.Descriptor{newDelta(v2, v3)}, map[string]string{AnnotationFrom: m2.Digest.String()})
+
+	r := &Resolver{Source: store, TempDir: t.TempDir()}
+	path, desc, err := r.Reconstruct(ctx, m3, "app.bin")
+	if err != nil {
+		t.Fatalf("Reconstruct() error = %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, v3) || desc.Digest != digest.FromBytes(v3) {
+		t.Error("Reconstruct() returned wrong content")
+	}
+
+	// a local base version short-cuts the chain
+	local := r.TempDir + "/local.bin"
+	if err := os.WriteFile(local, v2, 0600); err != nil {
+		t.Fatal(err)
+	}
+	r.Local = func(name string, dgst digest.Digest) (string, bool) {
+		return local, dgst == digest.FromBytes(v2)
+	}
+	if _, _, err := r.Reconstruct(ctx, m3, "app.bin"); err != nil {
+		t.Errorf("Reconstruct() with local base error = %v", err)
+	}
+
+	if _, _, err := r.Reconstruct(ctx, m3, "missing.bin"); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("Reconstruct() error = %v, want ErrFileNotFound", err)
+	}
+}
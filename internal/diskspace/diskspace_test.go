@@ -0,0 +1,45 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diskspace
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestAvailable(t *testing.T) {
+	dir := t.TempDir()
+	want, err := Available(dir)
+	if errors.Is(err, ErrUnsupported) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("Available() error = %v", err)
+	}
+	if want <= 0 {
+		t.Fatalf("Available() = %d, want positive", want)
+	}
+
+	// a missing path is resolved to its nearest existing parent
+	got, err := Available(filepath.Join(dir, "missing", "file"))
+	if err != nil {
+		t.Fatalf("Available() error = %v", err)
+	}
+	if got <= 0 {
+		t.Errorf("Available() = %d, want positive", got)
+	}
+}
@@ -0,0 +1,305 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dryrun suppresses the registry requests changing the content of
+// registries while reporting the planned operations.
+package dryrun
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// Operation is a registry request suppressed in dry run mode.
+type Operation struct {
+	// Method is the HTTP method of the request.
+	Method string
+	// URL is the URL of the request.
+	URL string
+	// Action describes the planned registry operation. It is empty for
+	// requests that are intermediate steps of an operation, such as starting
+	// a blob upload session.
+	Action string
+}
+
+// manifest is a manifest put in dry run mode.
+type manifest struct {
+	mediaType string
+	digest    digest.Digest
+	content   []byte
+}
+
+// Transport is an http.RoundTripper which suppresses mutating requests and
+// responds as a registry accepting them would. Manifests and blobs put and
+// deleted through the transport are tracked so that subsequent reads observe
+// the planned state of the registry.
+type Transport struct {
+	// Base is the underlying transport serving non-mutating requests.
+	Base http.RoundTripper
+	// OnOperation is called for each suppressed request.
+	OnOperation func(Operation)
+
+	lock      sync.Mutex
+	sessions  int
+	manifests map[string]*manifest
+	blobs     map[string]int64
+	deleted   map[string]bool
+}
+
+// NewTransport creates a dry run transport.
+func NewTransport(base http.RoundTripper, onOperation func(Operation)) *Transport {
+	return &Transport{
+		Base:        base,
+		OnOperation: onOperation,
+		manifests:   make(map[string]*manifest),
+		blobs:       make(map[string]int64),
+		deleted:     make(map[string]bool),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	repo, kind, ref, ok := parsePath(req.URL.Path)
+	if !ok {
+		return t.Base.RoundTrip(req)
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		if resp := t.read(req, repo, kind, ref); resp != nil {
+			return resp, nil
+		}
+		return t.Base.RoundTrip(req)
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return t.write(req, repo, kind, ref)
+	}
+	return t.Base.RoundTrip(req)
+}
+
+// resource kinds of registry API paths
+const (
+	kindManifest = "manifests"
+	kindBlob     = "blobs"
+	kindUpload   = "uploads"
+)
+
+// parsePath parses a registry API path such as /v2/<repo>/manifests/<ref>,
+// /v2/<repo>/blobs/<digest> or /v2/<repo>/blobs/uploads/<session>.
+func parsePath(p string) (repo, kind, ref string, ok bool) {
+	rest, ok := strings.CutPrefix(p, "/v2/")
+	if !ok {
+		return "", "", "", false
+	}
+	if i := strings.LastIndex(rest, "/blobs/uploads"); i > 0 {
+		return rest[:i], kindUpload, strings.TrimPrefix(rest[i+len("/blobs/uploads"):], "/"), true
+	}
+	for _, k := range []string{kindManifest, kindBlob} {
+		if i := strings.LastIndex(rest, "/"+k+"/"); i > 0 {
+			return rest[:i], k, rest[i+len(k)+2:], true
+		}
+	}
+	return "", "", "", false
+}
+
+func (t *Transport) key(req *http.Request, repo, ref string) string {
+	return req.URL.Host + "/" + repo + "@" + ref
+}
+
+// read serves reads of manifests and blobs changed in dry run mode. It returns
+// nil if the request should be served by the base transport.
+func (t *Transport) read(req *http.Request, repo, kind, ref string) *http.Response {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	key := t.key(req, repo, ref)
+	switch kind {
+	case kindManifest:
+		if m, ok := t.manifests[key]; ok {
+			header := http.Header{
+				"Content-Type":          {m.mediaType},
+				"Docker-Content-Digest": {m.digest.String()},
+			}
+			return respond(req, http.StatusOK, header, m.content)
+		}
+	case kindBlob:
+		if size, ok := t.blobs[key]; ok && req.Method == http.MethodHead {
+			resp := respond(req, http.StatusOK, http.Header{"Docker-Content-Digest": {ref}}, nil)
+			if size >= 0 {
+				resp.ContentLength = size
+				resp.Header.Set("Content-Length", strconv.FormatInt(size, 10))
+			} else {
+				resp.ContentLength = -1
+				resp.Header.Del("Content-Length")
+			}
+			return resp
+		}
+	default:
+		return nil
+	}
+	if t.deleted[key] {
+		return respond(req, http.StatusNotFound, nil, nil)
+	}
+	return nil
+}
+
+// write suppresses a mutating request.
+func (t *Transport) write(req *http.Request, repo, kind, ref string) (*http.Response, error) {
+	var body []byte
+	var size int64
+	if req.Body != nil {
+		var err error
+		if kind == kindManifest {
+			// manifests are kept to be served to later requests
+			body, err = io.ReadAll(req.Body)
+			size = int64(len(body))
+		} else {
+			// blobs are only counted, not held in memory
+			size, err = io.Copy(io.Discard, req.Body)
+		}
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	name := req.URL.Host + "/" + repo
+	op := Operation{Method: req.Method, URL: redact(req.URL)}
+	var resp *http.Response
+	switch {
+	case kind == kindUpload && req.Method == http.MethodPost:
+		query := req.URL.Query()
+		if mount := query.Get("mount"); mount != "" {
+			op.Action = fmt.Sprintf("mount blob %s from %s to %s", mount, query.Get("from"), name)
+			t.addBlob(req, repo, mount, -1)
+			resp = respond(req, http.StatusCreated, http.Header{
+				"Docker-Content-Digest": {mount},
+				"Location":              {"/v2/" + repo + "/blobs/" + mount},
+			}, nil)
+			break
+		}
+		if dgst := query.Get("digest"); dgst != "" {
+			// monolithic upload in a single POST request
+			op.Action = fmt.Sprintf("upload blob %s to %s", dgst, name)
+			t.addBlob(req, repo, dgst, size)
+			resp = respond(req, http.StatusCreated, http.Header{"Docker-Content-Digest": {dgst}}, nil)
+			break
+		}
+		t.sessions++
+		resp = respond(req, http.StatusAccepted, http.Header{
+			"Location": {fmt.Sprintf("/v2/%s/blobs/uploads/dry-run-%d", repo, t.sessions)},
+			"Range":    {"0-0"},
+		}, nil)
+	case kind == kindUpload && req.Method == http.MethodPatch:
+		resp = respond(req, http.StatusAccepted, http.Header{
+			"Location": {req.URL.Path},
+			"Range":    {fmt.Sprintf("0-%d", max(size-1, 0))},
+		}, nil)
+	case kind == kindUpload && req.Method == http.MethodPut:
+		dgst := req.URL.Query().Get("digest")
+		op.Action = fmt.Sprintf("upload blob %s to %s", dgst, name)
+		t.addBlob(req, repo, dgst, size)
+		resp = respond(req, http.StatusCreated, http.Header{
+			"Docker-Content-Digest": {dgst},
+			"Location":              {"/v2/" + repo + "/blobs/" + dgst},
+		}, nil)
+	case kind == kindUpload && req.Method == http.MethodDelete:
+		_, session, _ := strings.Cut(req.URL.Path, "/blobs/uploads/")
+		op.Action = fmt.Sprintf("cancel upload session %s of %s", session, name)
+		resp = respond(req, http.StatusNoContent, nil, nil)
+	case kind == kindManifest && req.Method == http.MethodPut:
+		m := &manifest{
+			mediaType: req.Header.Get("Content-Type"),
+			digest:    digest.FromBytes(body),
+			content:   body,
+		}
+		if _, err := digest.Parse(ref); err == nil {
+			op.Action = fmt.Sprintf("push manifest %s to %s", m.digest, name)
+		} else {
+			op.Action = fmt.Sprintf("push manifest %s to %s and tag it as %q", m.digest, name, ref)
+		}
+		t.manifests[t.key(req, repo, ref)] = m
+		t.manifests[t.key(req, repo, m.digest.String())] = m
+		delete(t.deleted, t.key(req, repo, ref))
+		delete(t.deleted, t.key(req, repo, m.digest.String()))
+		resp = respond(req, http.StatusCreated, http.Header{
+			"Docker-Content-Digest": {m.digest.String()},
+			"Location":              {"/v2/" + repo + "/manifests/" + m.digest.String()},
+		}, nil)
+	case req.Method == http.MethodDelete:
+		op.Action = fmt.Sprintf("delete %s %s@%s", strings.TrimSuffix(kind, "s"), name, ref)
+		key := t.key(req, repo, ref)
+		if kind == kindManifest {
+			for k, m := range t.manifests {
+				if m.digest.String() == ref && strings.HasPrefix(k, name+"@") {
+					delete(t.manifests, k)
+				}
+			}
+		} else {
+			delete(t.blobs, key)
+		}
+		t.deleted[key] = true
+		resp = respond(req, http.StatusAccepted, nil, nil)
+	default:
+		resp = respond(req, http.StatusMethodNotAllowed, nil, nil)
+	}
+	if t.OnOperation != nil {
+		t.OnOperation(op)
+	}
+	return resp, nil
+}
+
+// addBlob tracks an uploaded blob. Negative size means unknown.
+func (t *Transport) addBlob(req *http.Request, repo, dgst string, size int64) {
+	key := t.key(req, repo, dgst)
+	t.blobs[key] = size
+	delete(t.deleted, key)
+}
+
+func respond(req *http.Request, status int, header http.Header, body []byte) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		ContentLength: int64(len(body)),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		Request:       req,
+	}
+	if req.Method == http.MethodHead {
+		resp.Body = http.NoBody
+	}
+	return resp
+}
+
+// redact removes credentials from the URL.
+func redact(u *url.URL) string {
+	c := *u
+	c.User = nil
+	return c.String()
+}
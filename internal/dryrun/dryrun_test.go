@@ -0,0 +1,137 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+func TestTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			t.Errorf("mutating request sent to registry: %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+
+	var ops []Operation
+	transport := NewTransport(http.DefaultTransport, func(op Operation) {
+		ops = append(ops, op)
+	})
+	repo, err := remote.NewRepository(u.Host + "/test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.PlainHTTP = true
+	repo.Client = &http.Client{Transport: transport}
+	ctx := context.Background()
+
+	// push a blob and a manifest
+	blob := []byte("hello")
+	blobDesc := content.NewDescriptorFromBytes("application/octet-stream", blob)
+	if err := repo.Push(ctx, blobDesc, bytes.NewReader(blob)); err != nil {
+		t.Fatalf("Push() blob error = %v", err)
+	}
+	manifestDesc, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{blobDesc},
+	})
+	if err != nil {
+		t.Fatalf("PackManifest() error = %v", err)
+	}
+	if err := repo.Tag(ctx, manifestDesc, "v1"); err != nil {
+		t.Fatalf("Tag() error = %v", err)
+	}
+
+	// reads observe the planned state
+	if exists, err := repo.Exists(ctx, blobDesc); err != nil || !exists {
+		t.Errorf("Exists() blob = %v, %v, want true", exists, err)
+	}
+	got, err := repo.Resolve(ctx, "v1")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got.Digest != manifestDesc.Digest {
+		t.Errorf("Resolve() = %s, want %s", got.Digest, manifestDesc.Digest)
+	}
+
+	// delete the manifest
+	if err := repo.Delete(ctx, manifestDesc); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.Resolve(ctx, manifestDesc.Digest.String()); !errors.Is(err, errdef.ErrNotFound) {
+		t.Errorf("Resolve() deleted manifest error = %v, want %v", err, errdef.ErrNotFound)
+	}
+
+	var actions []string
+	for _, op := range ops {
+		if op.Action != "" {
+			actions = append(actions, op.Action)
+		}
+	}
+	want := []string{
+		"upload blob " + blobDesc.Digest.String(),
+		"upload blob " + ocispec.DescriptorEmptyJSON.Digest.String(),
+		"push manifest " + manifestDesc.Digest.String(),
+		"push manifest " + manifestDesc.Digest.String(),
+		"delete manifest " + u.Host + "/test@" + manifestDesc.Digest.String(),
+	}
+	if len(actions) != len(want) {
+		t.Fatalf("got actions %q, want %d actions", actions, len(want))
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(actions[i], w) {
+			t.Errorf("action %d = %q, want prefix %q", i, actions[i], w)
+		}
+	}
+}
+
+func Test_parsePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		repo     string
+		kind     string
+		ref      string
+		wantOkay bool
+	}{
+		{"/v2/", "", "", "", false},
+		{"/v2/a/b/manifests/v1", "a/b", kindManifest, "v1", true},
+		{"/v2/a/blobs/sha256:abc", "a", kindBlob, "sha256:abc", true},
+		{"/v2/a/blobs/uploads/", "a", kindUpload, "", true},
+		{"/v2/a/blobs/uploads/123", "a", kindUpload, "123", true},
+		{"/v2/a/tags/list", "", "", "", false},
+	}
+	for _, tt := range tests {
+		repo, kind, ref, ok := parsePath(tt.path)
+		if repo != tt.repo || kind != tt.kind || ref != tt.ref || ok != tt.wantOkay {
+			t.Errorf("parsePath(%q) = %q, %q, %q, %v", tt.path, repo, kind, ref, ok)
+		}
+	}
+}
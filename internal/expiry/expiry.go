@@ -0,0 +1,76 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AnnotationExpires is the annotation key for the time, in RFC 3339 format,
+// after which an artifact is considered expired and can be pruned.
+const AnnotationExpires = "land.oras.artifact.expires"
+
+// units are the day-based duration units not supported by time.ParseDuration.
+var units = map[string]time.Duration{
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// ParseDuration parses a duration string such as "30d", "2w" or any format
+// accepted by time.ParseDuration, e.g. "12h".
+func ParseDuration(s string) (time.Duration, error) {
+	for suffix, unit := range units {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			value, err := strconv.Atoi(n)
+			if err != nil || value < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(value) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q: expecting a positive number with a unit of w, d, h, m or s", s)
+	}
+	return d, nil
+}
+
+// ExpiresAt returns the expiry time recorded in the annotations.
+// ok is false if no expiry annotation is found.
+func ExpiresAt(annotations map[string]string) (expiresAt time.Time, ok bool, err error) {
+	value, ok := annotations[AnnotationExpires]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	expiresAt, err = time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, true, fmt.Errorf("invalid %s annotation %q: %w", AnnotationExpires, value, err)
+	}
+	return expiresAt, true, nil
+}
+
+// Expired returns true if the annotations carry an expiry time which is not
+// after now.
+func Expired(annotations map[string]string, now time.Time) (bool, error) {
+	expiresAt, ok, err := ExpiresAt(annotations)
+	if err != nil || !ok {
+		return false, err
+	}
+	return !expiresAt.After(now), nil
+}
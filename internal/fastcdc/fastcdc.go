@@ -0,0 +1,181 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fastcdc implements the FastCDC content-defined chunking algorithm.
+//
+// Reference: Wen Xia et al., "FastCDC: a Fast and Efficient Content-Defined
+// Chunking Approach for Data Deduplication", USENIX ATC 2016.
+package fastcdc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// MaxAvgSize is the largest supported average chunk size.
+const MaxAvgSize = 256 << 20
+
+// Options configures the sizes of the chunks.
+type Options struct {
+	// MinSize is the minimum size of a chunk except the last one.
+	MinSize int
+	// AvgSize is the expected size of a chunk. It must be a power of 2.
+	AvgSize int
+	// MaxSize is the maximum size of a chunk.
+	MaxSize int
+}
+
+// NewOptions returns the options for chunks of avgSize bytes on average,
+// ranging from a quarter to four times the average size.
+func NewOptions(avgSize int) Options {
+	return Options{
+		MinSize: avgSize / 4,
+		AvgSize: avgSize,
+		MaxSize: avgSize * 4,
+	}
+}
+
+// Validate validates the options.
+func (o Options) Validate() error {
+	if o.AvgSize < 64 || o.AvgSize > MaxAvgSize || o.AvgSize&(o.AvgSize-1) != 0 {
+		return fmt.Errorf("average chunk size %d is not a power of 2 between 64B and 256MB", o.AvgSize)
+	}
+	if o.MinSize <= 0 || o.MinSize > o.AvgSize || o.MaxSize < o.AvgSize {
+		return fmt.Errorf("invalid chunk sizes: min %d, avg %d, max %d", o.MinSize, o.AvgSize, o.MaxSize)
+	}
+	return nil
+}
+
+// gear is the table of random values rolled into the fingerprint. It is
+// generated deterministically so that chunk boundaries are stable.
+var gear = func() (table [256]uint64) {
+	// splitmix64
+	state := uint64(0x6f72617363646321)
+	for i := range table {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+	return table
+}()
+
+// Chunker splits a stream into content-defined chunks.
+type Chunker struct {
+	r      io.Reader
+	opts   Options
+	maskS  uint64
+	maskL  uint64
+	buf    []byte
+	start  int
+	end    int
+	offset int64
+	eof    bool
+}
+
+// NewChunker returns a chunker reading from r.
+func NewChunker(r io.Reader, opts Options) (*Chunker, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	n := bits.TrailingZeros(uint(opts.AvgSize))
+	return &Chunker{
+		r:    r,
+		opts: opts,
+		// normalized chunking: a harder mask before the average size and an
+		// easier one after it, taken from the most mixed high bits
+		maskS: mask(n + 1),
+		maskL: mask(n - 1),
+		buf:   make([]byte, 2*opts.MaxSize),
+	}, nil
+}
+
+// mask returns a mask of the n highest bits.
+func mask(n int) uint64 {
+	return ^uint64(0) << (64 - n)
+}
+
+// Chunk is a chunk of the stream.
+type Chunk struct {
+	// Offset is the offset of the chunk in the stream.
+	Offset int64
+	// Data is the content of the chunk, valid until the next call of Next.
+	Data []byte
+}
+
+// Next returns the next chunk or io.EOF at the end of the stream.
+func (c *Chunker) Next() (Chunk, error) {
+	if err := c.fill(); err != nil {
+		return Chunk{}, err
+	}
+	if c.start == c.end {
+		return Chunk{}, io.EOF
+	}
+	n := c.cut(c.buf[c.start:c.end])
+	chunk := Chunk{
+		Offset: c.offset,
+		Data:   c.buf[c.start : c.start+n],
+	}
+	c.start += n
+	c.offset += int64(n)
+	return chunk, nil
+}
+
+// fill buffers at least MaxSize bytes unless the stream ends.
+func (c *Chunker) fill() error {
+	if c.eof || c.end-c.start >= c.opts.MaxSize {
+		return nil
+	}
+	// move the remaining data to the front
+	c.end = copy(c.buf, c.buf[c.start:c.end])
+	c.start = 0
+	n, err := io.ReadFull(c.r, c.buf[c.end:])
+	c.end += n
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		c.eof = true
+	case err != nil:
+		return err
+	}
+	return nil
+}
+
+// cut returns the size of the chunk at the beginning of data.
+func (c *Chunker) cut(data []byte) int {
+	n := len(data)
+	if n <= c.opts.MinSize {
+		return n
+	}
+	n = min(n, c.opts.MaxSize)
+	normal := min(n, c.opts.AvgSize)
+	var fp uint64
+	i := c.opts.MinSize
+	for ; i < normal; i++ {
+		fp = (fp << 1) + gear[data[i]]
+		if fp&c.maskS == 0 {
+			return i + 1
+		}
+	}
+	for ; i < n; i++ {
+		fp = (fp << 1) + gear[data[i]]
+		if fp&c.maskL == 0 {
+			return i + 1
+		}
+	}
+	return n
+}
@@ -0,0 +1,120 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fastcdc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"testing"
+)
+
+func randomBytes(n int) []byte {
+	r := rand.New(rand.NewPCG(1, 2))
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(r.Uint32())
+	}
+	return data
+}
+
+func chunks(t *testing.T, data []byte, opts Options) [][]byte {
+	t.Helper()
+	c, err := NewChunker(bytes.NewReader(data), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ret [][]byte
+	var offset int64
+	for {
+		chunk, err := c.Next()
+		if errors.Is(err, io.EOF) {
+			return ret
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if chunk.Offset != offset {
+			t.Fatalf("chunk offset = %d, want %d", chunk.Offset, offset)
+		}
+		offset += int64(len(chunk.Data))
+		ret = append(ret, bytes.Clone(chunk.Data))
+	}
+}
+
+func TestChunker(t *testing.T) {
+	opts := NewOptions(1024)
+	data := randomBytes(256 * 1024)
+	got := chunks(t, data, opts)
+	if !bytes.Equal(bytes.Join(got, nil), data) {
+		t.Fatal("chunks do not reassemble to the original data")
+	}
+	for i, chunk := range got {
+		if len(chunk) > opts.MaxSize || (len(chunk) < opts.MinSize && i != len(got)-1) {
+			t.Errorf("chunk %d size %d is out of range", i, len(chunk))
+		}
+	}
+	if avg := len(data) / len(got); avg < opts.AvgSize/2 || avg > opts.AvgSize*2 {
+		t.Errorf("average chunk size = %d, want about %d", avg, opts.AvgSize)
+	}
+	if got := chunks(t, nil, opts); len(got) != 0 {
+		t.Errorf("got %d chunks for empty data", len(got))
+	}
+}
+
+func TestChunker_shiftResistant(t *testing.T) {
+	opts := NewOptions(1024)
+	data := randomBytes(128 * 1024)
+	original := make(map[string]bool)
+	for _, chunk := range chunks(t, data, opts) {
+		original[string(chunk)] = true
+	}
+	// insert bytes in the middle
+	modified := append(bytes.Clone(data[:50000]), append([]byte("inserted"), data[50000:]...)...)
+	got := chunks(t, modified, opts)
+	var changed int
+	for _, chunk := range got {
+		if !original[string(chunk)] {
+			changed++
+		}
+	}
+	if changed > 3 {
+		t.Errorf("%d of %d chunks changed after an insertion, want at most 3", changed, len(got))
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{"default", NewOptions(4 << 20), false},
+		{"not power of 2", NewOptions(1000), true},
+		{"too small", NewOptions(32), true},
+		{"too large", NewOptions(512 << 20), true},
+		{"min above avg", Options{MinSize: 2048, AvgSize: 1024, MaxSize: 4096}, true},
+		{"max below avg", Options{MinSize: 256, AvgSize: 1024, MaxSize: 512}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fileattr applies the ownership, the SELinux labels and the extended
+// attributes of files.
+package fileattr
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// AnnotationXattrs marks the directory layers archiving the extended
+// attributes of their files.
+const AnnotationXattrs = "land.oras.xattrs"
+
+// SELinuxXattr is the extended attribute holding the SELinux security context.
+const SELinuxXattr = "security.selinux"
+
+// ErrUnsupported is returned when an attribute cannot be applied on the
+// current platform.
+var ErrUnsupported = errors.New("not supported on this platform")
+
+// Owner is the owner of files. A negative ID is left unchanged.
+type Owner struct {
+	UID int
+	GID int
+}
+
+// ParseOwner parses an owner in the form of <user>[:<group>], where the user
+// and the group are either names or numeric IDs. The group is left unchanged
+// if omitted, and the user is left unchanged if empty, e.g. ":wheel".
+func ParseOwner(s string) (Owner, error) {
+	userPart, groupPart, hasGroup := strings.Cut(s, ":")
+	if userPart == "" && groupPart == "" {
+		return Owner{}, fmt.Errorf("invalid owner %q: expecting <user>[:<group>]", s)
+	}
+	owner := Owner{UID: -1, GID: -1}
+	if userPart != "" {
+		uid, err := lookupID(userPart, func(name string) (string, error) {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", err
+			}
+			return u.Uid, nil
+		})
+		if err != nil {
+			return Owner{}, fmt.Errorf("invalid owner %q: %w", s, err)
+		}
+		owner.UID = uid
+	}
+	if hasGroup && groupPart != "" {
+		gid, err := lookupID(groupPart, func(name string) (string, error) {
+			g, err := user.LookupGroup(name)
+			if err != nil {
+				return "", err
+			}
+			return g.Gid, nil
+		})
+		if err != nil {
+			return Owner{}, fmt.Errorf("invalid owner %q: %w", s, err)
+		}
+		owner.GID = gid
+	}
+	return owner, nil
+}
+
+// lookupID returns the numeric ID of s, looking up s by name with lookup if it
+// is not numeric.
+func lookupID(s string, lookup func(string) (string, error)) (int, error) {
+	if id, err := strconv.Atoi(s); err == nil {
+		if id < 0 {
+			return 0, fmt.Errorf("negative ID %d", id)
+		}
+		return id, nil
+	}
+	idStr, err := lookup(s)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(idStr)
+}
+
+// Chown changes the owner of the file at path, and of all the files under it
+// if it is a directory. Symbolic links are changed themselves instead of their
+// targets.
+func Chown(path string, owner Owner) error {
+	return walk(path, func(path string) error {
+		return lchown(path, owner.UID, owner.GID)
+	})
+}
+
+// SetSELinuxLabel sets the SELinux security context of the file at path, and
+// of all the files under it if it is a directory, to label.
+func SetSELinuxLabel(path string, label string) error {
+	return walk(path, func(path string) error {
+		return setSELinuxLabel(path, label)
+	})
+}
+
+// Xattrs returns the extended attributes of the file at path without following
+// symbolic links, including the POSIX ACLs and the file capabilities stored as
+// extended attributes.
+func Xattrs(path string) (map[string]string, error) {
+	return listXattrs(path)
+}
+
+// SetXattrs sets the extended attributes of the file at path without
+// following symbolic links.
+func SetXattrs(path string, attrs map[string]string) error {
+	for name, value := range attrs {
+		if err := setXattr(path, name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walk calls fn for path and all the files under it without following
+// symbolic links.
+func walk(root string, fn func(path string) error) error {
+	return filepath.WalkDir(root, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return fn(path)
+	})
+}
@@ -0,0 +1,99 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileattr
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+)
+
+func TestParseOwner(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Owner
+		wantErr bool
+	}{
+		{name: "user and group", s: "1000:1001", want: Owner{UID: 1000, GID: 1001}},
+		{name: "user only", s: "1000", want: Owner{UID: 1000, GID: -1}},
+		{name: "user with empty group", s: "1000:", want: Owner{UID: 1000, GID: -1}},
+		{name: "group only", s: ":1001", want: Owner{UID: -1, GID: 1001}},
+		{name: "empty", s: "", wantErr: true},
+		{name: "colon only", s: ":", wantErr: true},
+		{name: "negative", s: "-1:0", wantErr: true},
+		{name: "unknown user", s: "no-such-user-for-oras", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOwner(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOwner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseOwner() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChown(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file ownership is not supported on Windows")
+	}
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "a.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("missing", filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	// changing to the current owner needs no privileges
+	owner := Owner{UID: os.Getuid(), GID: os.Getgid()}
+	if err := Chown(dir, owner); err != nil {
+		t.Errorf("Chown() error = %v", err)
+	}
+	if err := Chown(filepath.Join(dir, "missing"), owner); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Chown() error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestXattrs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := SetXattrs(path, map[string]string{"user.oras.test": "value"})
+	if errors.Is(err, ErrUnsupported) || errors.Is(err, syscall.ENOTSUP) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("SetXattrs() error = %v", err)
+	}
+	got, err := Xattrs(path)
+	if err != nil {
+		t.Fatalf("Xattrs() error = %v", err)
+	}
+	if got["user.oras.test"] != "value" {
+		t.Errorf("Xattrs() = %v, want user.oras.test=value", got)
+	}
+}
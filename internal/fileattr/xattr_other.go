@@ -0,0 +1,32 @@
+//go:build !linux
+
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileattr
+
+import "fmt"
+
+// listXattrs returns ErrUnsupported as extended attributes are only supported
+// on Linux.
+func listXattrs(path string) (map[string]string, error) {
+	return nil, fmt.Errorf("%s: reading extended attributes is %w", path, ErrUnsupported)
+}
+
+// setXattr returns ErrUnsupported as extended attributes are only supported
+// on Linux.
+func setXattr(path, _, _ string) error {
+	return fmt.Errorf("%s: setting extended attributes is %w", path, ErrUnsupported)
+}
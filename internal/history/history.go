@@ -0,0 +1,180 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package history records the movements of tags as referrers of the tagged
+// manifests, since registries rarely keep the history of tags.
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry"
+)
+
+const (
+	// ArtifactType is the artifact type of the tag history referrers.
+	ArtifactType = "application/vnd.oras.tag.history.v1"
+	// AnnotationTag is the annotation of the moved tag.
+	AnnotationTag = "land.oras.history.tag"
+	// AnnotationPrevious is the annotation of the digest the tag pointed to
+	// before it was moved to the subject, absent if the tag was created.
+	AnnotationPrevious = "land.oras.history.previous"
+	// AnnotationActor is the annotation of the actor moving the tag.
+	AnnotationActor = "land.oras.history.actor"
+	// AnnotationCommand is the annotation of the command moving the tag.
+	AnnotationCommand = "land.oras.history.command"
+)
+
+// Record is a movement of a tag to a manifest.
+type Record struct {
+	// Tag is the moved tag.
+	Tag string `json:"tag"`
+	// Digest is the digest of the manifest the tag is moved to.
+	Digest string `json:"digest"`
+	// Previous is the digest of the manifest the tag pointed to before, empty
+	// if the tag was created.
+	Previous string `json:"previous,omitempty"`
+	// Actor is the actor moving the tag.
+	Actor string `json:"actor,omitempty"`
+	// Command is the command moving the tag, such as "push".
+	Command string `json:"command,omitempty"`
+	// Time is the time the tag is moved.
+	Time time.Time `json:"time"`
+}
+
+// Annotations returns the manifest annotations of the referrer of r.
+func (r Record) Annotations() map[string]string {
+	annotations := map[string]string{
+		AnnotationTag:             r.Tag,
+		ocispec.AnnotationCreated: r.Time.UTC().Format(time.RFC3339Nano),
+	}
+	for key, value := range map[string]string{
+		AnnotationPrevious: r.Previous,
+		AnnotationActor:    r.Actor,
+		AnnotationCommand:  r.Command,
+	} {
+		if value != "" {
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
+
+// parseRecord parses the record of the tag history referrer desc of the
+// manifest subject. ok is false if desc is not a valid record.
+func parseRecord(subject ocispec.Descriptor, desc ocispec.Descriptor) (r Record, ok bool) {
+	if desc.ArtifactType != ArtifactType || desc.Annotations[AnnotationTag] == "" {
+		return Record{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, desc.Annotations[ocispec.AnnotationCreated])
+	if err != nil {
+		return Record{}, false
+	}
+	return Record{
+		Tag:      desc.Annotations[AnnotationTag],
+		Digest:   subject.Digest.String(),
+		Previous: desc.Annotations[AnnotationPrevious],
+		Actor:    desc.Annotations[AnnotationActor],
+		Command:  desc.Annotations[AnnotationCommand],
+		Time:     t,
+	}, true
+}
+
+// DefaultActor returns the name of the current user, or an empty string if it
+// is unknown.
+func DefaultActor() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	return os.Getenv("USER")
+}
+
+// Resolve returns the digest tag points to in target, or an empty string if
+// tag does not exist.
+func Resolve(ctx context.Context, target content.Resolver, tag string) (string, error) {
+	desc, err := target.Resolve(ctx, tag)
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return desc.Digest.String(), nil
+}
+
+// Attach pushes the record r to target as a referrer of the manifest subject
+// the tag is moved to.
+func Attach(ctx context.Context, target oras.Target, subject ocispec.Descriptor, r Record) (ocispec.Descriptor, error) {
+	packOpts := oras.PackManifestOptions{
+		Subject:             &subject,
+		ManifestAnnotations: r.Annotations(),
+	}
+	return oras.PackManifest(ctx, target, oras.PackManifestVersion1_1, ArtifactType, packOpts)
+}
+
+// Timeline reconstructs the history of tag, which points to the manifest
+// head, from the records attached to the manifests it pointed to. The records
+// are returned from the latest, and at most limit records are returned if
+// limit is positive. The timeline ends at the creation of the tag, or at the
+// first movement not recorded.
+func Timeline(ctx context.Context, target oras.ReadOnlyGraphTarget, tag string, head ocispec.Descriptor, limit int) ([]Record, error) {
+	var records []Record
+	current := head
+	var before time.Time
+	for limit <= 0 || len(records) < limit {
+		referrers, err := registry.Referrers(ctx, target, current, ArtifactType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the history of %s: %w", current.Digest, err)
+		}
+		// the same tag may be moved to the manifest multiple times, take the
+		// latest movement before the one already in the timeline
+		var latest *Record
+		for _, referrer := range referrers {
+			r, ok := parseRecord(current, referrer)
+			if !ok || r.Tag != tag || (!before.IsZero() && !r.Time.Before(before)) {
+				continue
+			}
+			if latest == nil || r.Time.After(latest.Time) {
+				latest = &r
+			}
+		}
+		if latest == nil {
+			break
+		}
+		records = append(records, *latest)
+		if latest.Previous == "" {
+			break
+		}
+		current, err = target.Resolve(ctx, latest.Previous)
+		if err != nil {
+			if errors.Is(err, errdef.ErrNotFound) {
+				// the previous manifest is deleted along with its history
+				break
+			}
+			return nil, err
+		}
+		before = latest.Time
+	}
+	return records, nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+func TestTimeline(t *testing.T) {
+	ctx := context.Background()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifests []ocispec.Descriptor
+	for _, artifactType := range []string{"application/vnd.test.a", "application/vnd.test.b"} {
+		desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, artifactType, oras.PackManifestOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifests = append(manifests, desc)
+	}
+	a, b := manifests[0], manifests[1]
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	moves := []struct {
+		tag     string
+		to      ocispec.Descriptor
+		from    string
+		command string
+	}{
+		{tag: "latest", to: a, command: "push"},
+		{tag: "latest", to: b, from: a.Digest.String(), command: "push"},
+		{tag: "stable", to: b, command: "tag"},
+		{tag: "latest", to: a, from: b.Digest.String(), command: "tag"},
+	}
+	var want []Record
+	for i, move := range moves {
+		r := Record{
+			Tag:      move.tag,
+			Digest:   move.to.Digest.String(),
+			Previous: move.from,
+			Actor:    "tester",
+			Command:  move.command,
+			Time:     start.Add(time.Duration(i) * time.Minute),
+		}
+		if _, err := Attach(ctx, store, move.to, r); err != nil {
+			t.Fatalf("Attach() error = %v", err)
+		}
+		if move.tag == "latest" {
+			want = append([]Record{r}, want...)
+		}
+	}
+
+	got, err := Timeline(ctx, store, "latest", a, 0)
+	if err != nil {
+		t.Fatalf("Timeline() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Timeline() = %v, want %v", got, want)
+	}
+
+	got, err = Timeline(ctx, store, "latest", a, 2)
+	if err != nil {
+		t.Fatalf("Timeline() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want[:2]) {
+		t.Errorf("Timeline() with limit = %v, want %v", got, want[:2])
+	}
+
+	got, err = Timeline(ctx, store, "v1", a, 0)
+	if err != nil {
+		t.Fatalf("Timeline() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Timeline() of unrecorded tag = %v, want none", got)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Tag(ctx, desc, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Resolve(ctx, store, "v1"); err != nil || got != desc.Digest.String() {
+		t.Errorf("Resolve() = %q, %v, want %q", got, err, desc.Digest)
+	}
+	if got, err := Resolve(ctx, store, "v2"); err != nil || got != "" {
+		t.Errorf("Resolve() of missing tag = %q, %v, want empty", got, err)
+	}
+}
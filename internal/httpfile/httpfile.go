@@ -0,0 +1,182 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httpfile streams files over HTTP and HTTPS, resuming interrupted
+// downloads with range requests.
+package httpfile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MaxRetries is the maximum number of retries to resume a download without
+// making progress.
+const MaxRetries = 5
+
+// retryDelay is the delay before the first retry, doubled for each of the
+// following retries.
+var retryDelay = time.Second
+
+// IsURL reports whether s is an HTTP or HTTPS URL.
+func IsURL(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
+
+// Reader reads a file downloaded over HTTP. If the connection is interrupted
+// and the server supports range requests, the download is resumed from where
+// it is interrupted, as long as the file is not changed on the server.
+type Reader struct {
+	ctx       context.Context
+	client    *http.Client
+	url       string
+	body      io.ReadCloser
+	size      int64
+	offset    int64
+	rangeable bool
+	validator string
+	retries   int
+}
+
+// Open starts downloading the file at url with client.
+func Open(ctx context.Context, client *http.Client, url string) (*Reader, error) {
+	r := &Reader{
+		ctx:    ctx,
+		client: client,
+		url:    url,
+	}
+	resp, err := r.get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: unexpected status %s", url, resp.Status)
+	}
+	r.body = resp.Body
+	r.size = resp.ContentLength
+	r.rangeable = resp.Header.Get("Accept-Ranges") == "bytes"
+	// a weak entity tag cannot be used to resume downloads
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		r.validator = etag
+	} else {
+		r.validator = resp.Header.Get("Last-Modified")
+	}
+	return r, nil
+}
+
+// Size returns the size of the file, or -1 if unknown.
+func (r *Reader) Size() int64 {
+	return r.size
+}
+
+// Offset returns the number of bytes read.
+func (r *Reader) Offset() int64 {
+	return r.offset
+}
+
+// Read implements io.Reader.
+func (r *Reader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.body.Read(p)
+		r.offset += int64(n)
+		if n > 0 {
+			r.retries = 0
+		}
+		switch {
+		case err == nil:
+			return n, nil
+		case errors.Is(err, io.EOF):
+			if r.size < 0 || r.offset == r.size {
+				return n, io.EOF
+			}
+			err = io.ErrUnexpectedEOF
+		}
+		if n > 0 {
+			// the error is returned again by the next read
+			return n, nil
+		}
+		if err := r.resume(err); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Close closes the connection.
+func (r *Reader) Close() error {
+	return r.body.Close()
+}
+
+// resume resumes the download from the current offset after it is interrupted
+// by cause, retrying with exponential backoff.
+func (r *Reader) resume(cause error) error {
+	r.body.Close()
+	r.body = http.NoBody
+	if r.ctx.Err() != nil {
+		return r.ctx.Err()
+	}
+	if !r.rangeable || r.validator == "" {
+		return fmt.Errorf("failed to download %s: %w", r.url, cause)
+	}
+	for ; r.retries < MaxRetries; r.retries++ {
+		select {
+		case <-r.ctx.Done():
+			return r.ctx.Err()
+		case <-time.After(retryDelay << r.retries):
+		}
+		resp, err := r.get()
+		if err != nil {
+			cause = err
+			continue
+		}
+		switch resp.StatusCode {
+		case http.StatusPartialContent:
+			if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", r.offset)) {
+				resp.Body.Close()
+				return fmt.Errorf("failed to resume downloading %s: unexpected content range %q", r.url, resp.Header.Get("Content-Range"))
+			}
+			r.body = resp.Body
+			return nil
+		case http.StatusOK:
+			// the file is changed on the server
+			resp.Body.Close()
+			return fmt.Errorf("failed to resume downloading %s: the file is changed on the server", r.url)
+		}
+		resp.Body.Close()
+		cause = fmt.Errorf("unexpected status %s", resp.Status)
+		if resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests {
+			break
+		}
+	}
+	return fmt.Errorf("failed to download %s after %d retries: %w", r.url, r.retries, cause)
+}
+
+// get requests the file from the current offset.
+func (r *Reader) get() (*http.Response, error) {
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if r.offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", r.offset))
+		req.Header.Set("If-Range", r.validator)
+	}
+	return r.client.Do(req)
+}
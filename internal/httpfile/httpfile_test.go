@@ -0,0 +1,162 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpfile
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// interruptedServer serves content, interrupting the responses to the requests
+// without a range after half of the content.
+func interruptedServer(t *testing.T, content []byte, etag func(requests int) string, acceptRanges bool) (*httptest.Server, *int) {
+	t.Helper()
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", etag(requests))
+		if r.Header.Get("Range") == "" {
+			if acceptRanges {
+				w.Header().Set("Accept-Ranges", "bytes")
+			}
+			w.Header().Set("Content-Length", "10")
+			_, _ = w.Write(content[:len(content)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestReader(t *testing.T) {
+	retryDelay = time.Millisecond
+	content := []byte("0123456789")
+	tests := []struct {
+		name         string
+		etag         func(requests int) string
+		acceptRanges bool
+		wantErr      string
+	}{
+		{
+			name:         "resumed",
+			etag:         func(int) string { return `"v1"` },
+			acceptRanges: true,
+		},
+		{
+			name:         "ranges not supported",
+			etag:         func(int) string { return `"v1"` },
+			acceptRanges: false,
+			wantErr:      "unexpected EOF",
+		},
+		{
+			name:         "changed on the server",
+			etag:         func(requests int) string { return `"v` + string(rune('0'+requests)) + `"` },
+			acceptRanges: true,
+			wantErr:      "the file is changed on the server",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := interruptedServer(t, content, tt.etag, tt.acceptRanges)
+			r, err := Open(context.Background(), server.Client(), server.URL)
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			defer r.Close()
+			if got := r.Size(); got != int64(len(content)) {
+				t.Errorf("Reader.Size() = %d, want %d", got, len(content))
+			}
+			got, err := io.ReadAll(r)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("io.ReadAll() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("io.ReadAll() error = %v", err)
+			}
+			if !bytes.Equal(got, content) {
+				t.Errorf("io.ReadAll() = %q, want %q", got, content)
+			}
+			if r.Offset() != int64(len(content)) {
+				t.Errorf("Reader.Offset() = %d, want %d", r.Offset(), len(content))
+			}
+			if *requests != 2 {
+				t.Errorf("requests = %d, want 2", *requests)
+			}
+		})
+	}
+}
+
+func TestReader_retries(t *testing.T) {
+	retryDelay = time.Millisecond
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("Range") != "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Accept-Ranges", "bytes")
+		w.Header().Set("Content-Length", "10")
+		_, _ = w.Write([]byte("01234"))
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}))
+	defer server.Close()
+	r, err := Open(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer r.Close()
+	if _, err := io.ReadAll(r); err == nil || !strings.Contains(err.Error(), "503 Service Unavailable") {
+		t.Fatalf("io.ReadAll() error = %v, want the status of the last retry", err)
+	}
+	if want := 1 + MaxRetries; requests != want {
+		t.Errorf("requests = %d, want %d", requests, want)
+	}
+}
+
+func TestOpen_notFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	if _, err := Open(context.Background(), server.Client(), server.URL); err == nil {
+		t.Error("Open() error = nil, want error")
+	}
+}
+
+func TestIsURL(t *testing.T) {
+	for s, want := range map[string]bool{
+		"https://example.com/backup.tar": true,
+		"http://localhost/backup.tar":    true,
+		"backup.tar":                     false,
+		"s3://bucket/backup.tar":         false,
+	} {
+		if got := IsURL(s); got != want {
+			t.Errorf("IsURL(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
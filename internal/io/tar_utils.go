@@ -49,6 +49,59 @@ func TarDirectory(writer io.Writer, sourceDir string) (tarErr error) {
 	return tw.AddFS(os.DirFS(sourceDir))
 }
 
+// UntarDirectory extracts the directories and regular files of the tar
+// archive read from reader into dir. Entries escaping dir are rejected.
+func UntarDirectory(reader io.Reader, dir string) error {
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar archive: %w", err)
+		}
+		name := filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(name) {
+			if filepath.Clean(name) == "." {
+				continue
+			}
+			return fmt.Errorf("invalid tar entry %q: path escapes the target directory", header.Name)
+		}
+		path := filepath.Join(dir, name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			if err := writeFile(path, tr); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("invalid tar entry %q: unsupported type %q", header.Name, header.Typeflag)
+		}
+	}
+}
+
+// writeFile writes the content read from r to a new file at path.
+func writeFile(path string, r io.Reader) (err error) {
+	fp, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := fp.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	_, err = io.Copy(fp, r)
+	return err
+}
+
 // IsTarFile loosely checks whether the given file path refers to a tar archive
 // by examining its extension and magic number.
 func IsTarFile(path string) (bool, error) {
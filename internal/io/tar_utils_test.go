@@ -139,6 +139,63 @@ func TestTarDirectory_InvalidSource(t *testing.T) {
 	})
 }
 
+func TestUntarDirectory(t *testing.T) {
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "blobs", "sha256"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"oci-layout":        `{"imageLayoutVersion":"1.0.0"}`,
+		"blobs/sha256/abcd": "blob",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(src, filepath.FromSlash(name)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := iotest.TarDirectory(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := t.TempDir()
+	if err := iotest.UntarDirectory(&buf, dst); err != nil {
+		t.Fatalf("UntarDirectory() error = %v", err)
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dst, filepath.FromSlash(name)))
+		if err != nil || string(got) != want {
+			t.Errorf("extracted %s = %q, %v, want %q", name, got, err, want)
+		}
+	}
+}
+
+func TestUntarDirectory_invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		header tar.Header
+	}{
+		{"path traversal", tar.Header{Name: "../escape", Typeflag: tar.TypeReg, Mode: 0644}},
+		{"absolute path", tar.Header{Name: "/etc/escape", Typeflag: tar.TypeReg, Mode: 0644}},
+		{"symlink", tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			if err := tw.WriteHeader(&tt.header); err != nil {
+				t.Fatal(err)
+			}
+			if err := tw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err := iotest.UntarDirectory(&buf, t.TempDir()); err == nil {
+				t.Error("UntarDirectory() error = nil, want error")
+			}
+		})
+	}
+}
+
 func TestIsTarFile(t *testing.T) {
 	// Test case 1: File with .tar extension
 	t.Run("File with .tar extension", func(t *testing.T) {
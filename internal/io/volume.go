@@ -0,0 +1,173 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// volumeSuffix is the suffix of the first volume of a volume set.
+const volumeSuffix = ".001"
+
+// VolumePath returns the path of the n-th volume, counting from 1, of the
+// volume set at path.
+func VolumePath(path string, n int) string {
+	return fmt.Sprintf("%s.%03d", path, n)
+}
+
+// VolumeSetPath returns the path of the volume set if path refers to its
+// first volume.
+func VolumeSetPath(path string) (string, bool) {
+	return strings.CutSuffix(path, volumeSuffix)
+}
+
+// VolumeWriter writes a stream into numbered volumes of a fixed size.
+type VolumeWriter struct {
+	path    string
+	size    int64
+	count   int
+	file    *os.File
+	written int64
+}
+
+// NewVolumeWriter returns a writer writing volumes of size bytes at most to
+// the volume set at path.
+func NewVolumeWriter(path string, size int64) *VolumeWriter {
+	return &VolumeWriter{
+		path: path,
+		size: size,
+	}
+}
+
+// Write writes p across volumes.
+func (w *VolumeWriter) Write(p []byte) (int, error) {
+	var n int
+	for len(p) > 0 {
+		if w.file == nil || w.written == w.size {
+			if err := w.next(); err != nil {
+				return n, err
+			}
+		}
+		chunk := p[:min(int64(len(p)), w.size-w.written)]
+		written, err := w.file.Write(chunk)
+		n += written
+		w.written += int64(written)
+		if err != nil {
+			return n, err
+		}
+		p = p[written:]
+	}
+	return n, nil
+}
+
+// next closes the current volume and creates the next one.
+func (w *VolumeWriter) next() error {
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+	}
+	w.count++
+	file, err := os.Create(VolumePath(w.path, w.count))
+	if err != nil {
+		return err
+	}
+	w.file = file
+	w.written = 0
+	return nil
+}
+
+// Close closes the last volume and removes the stale volumes of a previous
+// volume set at the same path.
+func (w *VolumeWriter) Close() error {
+	if w.file == nil {
+		// always write at least one volume
+		if err := w.next(); err != nil {
+			return err
+		}
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	for n := w.count + 1; ; n++ {
+		if err := os.Remove(VolumePath(w.path, n)); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// Count returns the number of volumes written.
+func (w *VolumeWriter) Count() int {
+	return w.count
+}
+
+// OpenVolumes opens the volume set at path as a single stream and returns the
+// number of volumes and their total size. An error wrapping fs.ErrNotExist is
+// returned if the first volume does not exist.
+func OpenVolumes(path string) (io.ReadCloser, int, int64, error) {
+	var files []*os.File
+	var size int64
+	closeAll := func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}
+	for n := 1; ; n++ {
+		f, err := os.Open(VolumePath(path, n))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && n > 1 {
+				break
+			}
+			closeAll()
+			return nil, 0, 0, err
+		}
+		files = append(files, f)
+		fi, err := f.Stat()
+		if err != nil {
+			closeAll()
+			return nil, 0, 0, err
+		}
+		size += fi.Size()
+	}
+	readers := make([]io.Reader, len(files))
+	for i, f := range files {
+		readers[i] = f
+	}
+	return &volumeReader{
+		Reader: io.MultiReader(readers...),
+		close:  closeAll,
+	}, len(files), size, nil
+}
+
+// volumeReader reads the volumes of a volume set in sequence.
+type volumeReader struct {
+	io.Reader
+	close func()
+}
+
+// Close closes all volumes.
+func (r *volumeReader) Close() error {
+	r.close()
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package io_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	orasio "oras.land/oras/internal/io"
+)
+
+func TestVolumeWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.tar")
+	// a stale volume of a previous volume set
+	if err := os.WriteFile(orasio.VolumePath(path, 4), []byte("stale"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	w := orasio.NewVolumeWriter(path, 16)
+	if _, err := w.Write(data[:10]); err != nil {
+		t.Fatalf("VolumeWriter.Write() error = %v", err)
+	}
+	if _, err := w.Write(data[10:]); err != nil {
+		t.Fatalf("VolumeWriter.Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("VolumeWriter.Close() error = %v", err)
+	}
+	if got, want := w.Count(), 3; got != want {
+		t.Errorf("VolumeWriter.Count() = %d, want %d", got, want)
+	}
+	for n, want := range []string{"", "0123456789abcdef", "ghijklmnopqrstuv", "wxyz"} {
+		if n == 0 {
+			continue
+		}
+		got, err := os.ReadFile(orasio.VolumePath(path, n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("volume %d = %q, want %q", n, got, want)
+		}
+	}
+	if _, err := os.Stat(orasio.VolumePath(path, 4)); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("stale volume is not removed: %v", err)
+	}
+
+	rc, count, size, err := orasio.OpenVolumes(path)
+	if err != nil {
+		t.Fatalf("orasio.OpenVolumes() error = %v", err)
+	}
+	defer rc.Close()
+	if count != 3 || size != int64(len(data)) {
+		t.Errorf("orasio.OpenVolumes() = %d, %d, want 3, %d", count, size, len(data))
+	}
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("orasio.OpenVolumes() read %q, want %q", got, data)
+	}
+
+	if _, _, _, err := orasio.OpenVolumes(filepath.Join(t.TempDir(), "missing.tar")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("orasio.OpenVolumes() error = %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestVolumeSetPath(t *testing.T) {
+	if got, ok := orasio.VolumeSetPath("backup.tar.001"); !ok || got != "backup.tar" {
+		t.Errorf("orasio.VolumeSetPath() = %q, %v, want %q, true", got, ok, "backup.tar")
+	}
+	if _, ok := orasio.VolumeSetPath("backup.tar.002"); ok {
+		t.Error("orasio.VolumeSetPath() = true, want false for a later volume")
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ledger implements transfer ledgers, which list every blob exported
+// by bundles and backups so that the content imported at another site can be
+// traced back to its source.
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/internal/contentutil"
+	"oras.land/oras/internal/signature"
+	"oras.land/oras/internal/trust"
+)
+
+var (
+	// ErrNotListed is returned when a blob is not listed in the ledger.
+	ErrNotListed = errors.New("not listed in the transfer ledger")
+	// ErrUnsigned is returned when a ledger is expected to be signed but is
+	// not.
+	ErrUnsigned = errors.New("the transfer ledger is not signed")
+	// ErrKeyRequired is returned when a signed ledger is read without a key
+	// to verify its signature.
+	ErrKeyRequired = errors.New("the transfer ledger is signed but no key is provided to verify it")
+)
+
+// Ledger lists the blobs transferred by an operation.
+type Ledger struct {
+	// Tool is the name of the tool creating the ledger.
+	Tool string `json:"tool"`
+	// Version is the version of the tool creating the ledger.
+	Version string `json:"version"`
+	// Created is the time the ledger is created.
+	Created time.Time `json:"created"`
+	// Operation is the operation creating the ledger, e.g. "bundle create".
+	Operation string `json:"operation"`
+	// Entries are the blobs transferred.
+	Entries []Entry `json:"entries"`
+
+	index map[digest.Digest]Entry
+}
+
+// Entry is a blob in the ledger.
+type Entry struct {
+	Digest digest.Digest `json:"digest"`
+	Size   int64         `json:"size"`
+	// Source is the artifact or repository the blob is exported from.
+	Source string `json:"source"`
+}
+
+// Signature is the signature of a ledger.
+type Signature struct {
+	// KeyFingerprint is the SHA-256 digest of the DER encoded public key
+	// verifying the signature.
+	KeyFingerprint string `json:"keyFingerprint"`
+	// Value is the signature of the ledger.
+	Value []byte `json:"value"`
+}
+
+// file is the content of a ledger file.
+type file struct {
+	Ledger    json.RawMessage `json:"ledger"`
+	Signature *Signature      `json:"signature,omitempty"`
+}
+
+// Write writes the ledger to path, signed by signer if it is not nil.
+func Write(path string, l *Ledger, signer *signature.Signer) error {
+	payload, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	f := file{Ledger: payload}
+	if signer != nil {
+		fingerprint, err := Fingerprint(signer.Public())
+		if err != nil {
+			return err
+		}
+		sig, err := signer.Sign(payload)
+		if err != nil {
+			return fmt.Errorf("failed to sign the transfer ledger: %w", err)
+		}
+		f.Signature = &Signature{
+			KeyFingerprint: fingerprint,
+			Value:          sig,
+		}
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Read reads the ledger at path. If key is not nil, the ledger must be signed
+// by the private key of key. Signed ledgers cannot be read without a key.
+func Read(path string, key crypto.PublicKey) (*Ledger, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse the transfer ledger %s: %w", path, err)
+	}
+	if len(f.Ledger) == 0 {
+		return nil, fmt.Errorf("failed to parse the transfer ledger %s: missing ledger", path)
+	}
+	switch {
+	case key == nil && f.Signature != nil:
+		return nil, ErrKeyRequired
+	case key != nil && f.Signature == nil:
+		return nil, ErrUnsigned
+	case key != nil:
+		fingerprint, err := Fingerprint(key)
+		if err != nil {
+			return nil, err
+		}
+		if fingerprint != f.Signature.KeyFingerprint {
+			return nil, fmt.Errorf("the transfer ledger is signed by key %s, not the provided key %s", f.Signature.KeyFingerprint, fingerprint)
+		}
+		// the indentation of the file is not part of the signed payload
+		var payload bytes.Buffer
+		if err := json.Compact(&payload, f.Ledger); err != nil {
+			return nil, err
+		}
+		if err := signature.Verify(key, payload.Bytes(), f.Signature.Value); err != nil {
+			return nil, fmt.Errorf("failed to verify the transfer ledger %s: %w", path, err)
+		}
+	}
+	var l Ledger
+	if err := json.Unmarshal(f.Ledger, &l); err != nil {
+		return nil, fmt.Errorf("failed to parse the transfer ledger %s: %w", path, err)
+	}
+	for _, e := range l.Entries {
+		if err := e.Digest.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid digest %q in the transfer ledger %s: %w", e.Digest, path, err)
+		}
+	}
+	return &l, nil
+}
+
+// LoadPublicKey loads the PEM encoded public key or certificate at path.
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	root, err := trust.ParseRoot("ledger", data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return root.PublicKey, nil
+}
+
+// Fingerprint returns the SHA-256 digest of the DER encoded public key.
+func Fingerprint(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// Check returns an error if desc is not listed in the ledger with the same
+// size.
+func (l *Ledger) Check(desc ocispec.Descriptor) error {
+	if l.index == nil {
+		l.buildIndex()
+	}
+	e, ok := l.index[desc.Digest]
+	if !ok {
+		return fmt.Errorf("%s: %w", desc.Digest, ErrNotListed)
+	}
+	if e.Size != desc.Size {
+		return fmt.Errorf("%s: size %d does not match the size %d in the transfer ledger", desc.Digest, desc.Size, e.Size)
+	}
+	return nil
+}
+
+// buildIndex indexes the entries by digest.
+func (l *Ledger) buildIndex() {
+	l.index = make(map[digest.Digest]Entry, len(l.Entries))
+	for _, e := range l.Entries {
+		l.index[e.Digest] = e
+	}
+}
+
+// Target returns a target fetching only the content of target listed in the
+// ledger, and verifying the fetched content against its digest. The returned
+// target lists tags if target does.
+func (l *Ledger) Target(target oras.ReadOnlyGraphTarget) oras.ReadOnlyGraphTarget {
+	// build the index before the target is used concurrently
+	l.buildIndex()
+	verified := &verifiedTarget{
+		ReadOnlyGraphTarget: target,
+		ledger:              l,
+	}
+	if lister, ok := target.(registry.TagLister); ok {
+		return &verifiedTagTarget{
+			verifiedTarget: verified,
+			TagLister:      lister,
+		}
+	}
+	return verified
+}
+
+// verifiedTagTarget is a verifiedTarget listing tags.
+type verifiedTagTarget struct {
+	*verifiedTarget
+	registry.TagLister
+}
+
+// verifiedTarget is a target fetching only the content listed in a ledger.
+type verifiedTarget struct {
+	oras.ReadOnlyGraphTarget
+	ledger *Ledger
+}
+
+// Fetch fetches the content identified by target if it is listed in the
+// ledger.
+func (t *verifiedTarget) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if err := t.ledger.Check(target); err != nil {
+		return nil, err
+	}
+	rc, err := t.ReadOnlyGraphTarget.Fetch(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	return contentutil.NewVerifyReadCloser(rc, target), nil
+}
@@ -0,0 +1,202 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras/internal/signature"
+)
+
+func testLedger() *Ledger {
+	return &Ledger{
+		Tool:      "oras",
+		Operation: "bundle create",
+		Entries: []Entry{
+			{Digest: digest.FromString("foo"), Size: 3, Source: "localhost:5000/test:v1"},
+			{Digest: digest.FromString("hello"), Size: 5, Source: "localhost:5000/test:v1"},
+		},
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := signature.NewSigner(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	signed := filepath.Join(dir, "signed.json")
+	if err := Write(signed, testLedger(), signer); err != nil {
+		t.Fatal(err)
+	}
+	unsigned := filepath.Join(dir, "unsigned.json")
+	if err := Write(unsigned, testLedger(), nil); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := filepath.Join(dir, "tampered.json")
+	if err := os.WriteFile(tampered, bytes.Replace(data, []byte(`"size": 3`), []byte(`"size": 4`), 1), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		key     any
+		wantErr error
+		wantAny bool
+	}{
+		{name: "signed", path: signed, key: pub},
+		{name: "unsigned", path: unsigned},
+		{name: "signed without key", path: signed, wantErr: ErrKeyRequired},
+		{name: "unsigned with key", path: unsigned, key: pub, wantErr: ErrUnsigned},
+		{name: "signed by another key", path: signed, key: otherPub, wantAny: true},
+		{name: "tampered", path: tampered, key: pub, wantErr: signature.ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := Read(tt.path, tt.key)
+			if tt.wantErr != nil || tt.wantAny {
+				if err == nil {
+					t.Fatal("Read() error = nil, want error")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Read() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if len(l.Entries) != 2 || l.Entries[0].Size != 3 || l.Operation != "bundle create" {
+				t.Errorf("Read() = %+v", l)
+			}
+		})
+	}
+}
+
+func TestLoadPublicKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadPublicKey(path)
+	if err != nil {
+		t.Fatalf("LoadPublicKey() error = %v", err)
+	}
+	if !pub.Equal(got) {
+		t.Errorf("LoadPublicKey() = %v, want %v", got, pub)
+	}
+}
+
+func TestLedger_Check(t *testing.T) {
+	l := testLedger()
+	tests := []struct {
+		name    string
+		desc    ocispec.Descriptor
+		wantErr bool
+	}{
+		{name: "listed", desc: ocispec.Descriptor{Digest: digest.FromString("foo"), Size: 3}},
+		{name: "not listed", desc: ocispec.Descriptor{Digest: digest.FromString("bar"), Size: 3}, wantErr: true},
+		{name: "size mismatch", desc: ocispec.Descriptor{Digest: digest.FromString("foo"), Size: 4}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := l.Check(tt.desc); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLedger_Target(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	listed := content.NewDescriptorFromBytes("application/octet-stream", []byte("hello"))
+	unlisted := content.NewDescriptorFromBytes("application/octet-stream", []byte("world"))
+	for desc, data := range map[*ocispec.Descriptor]string{&listed: "hello", &unlisted: "world"} {
+		if err := store.Push(ctx, *desc, bytes.NewReader([]byte(data))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	target := testLedger().Target(store)
+
+	rc, err := target.Fetch(ctx, listed)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if err := rc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hello" {
+		t.Errorf("Fetch() = %q, want %q", got, "hello")
+	}
+	if _, err := target.Fetch(ctx, unlisted); !errors.Is(err, ErrNotListed) {
+		t.Errorf("Fetch() error = %v, want %v", err, ErrNotListed)
+	}
+}
+
+func TestLedger_Target_tagLister(t *testing.T) {
+	store, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := testLedger().Target(store).(registry.TagLister); !ok {
+		t.Error("Target() does not list tags of an OCI store")
+	}
+	if _, ok := testLedger().Target(memory.New()).(registry.TagLister); ok {
+		t.Error("Target() lists tags of a memory store")
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mediatype validates media types used as artifact types.
+package mediatype
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// maxNameLength is the maximum length of the type or subtype names.
+// Reference: https://datatracker.ietf.org/doc/html/rfc6838#section-4.2
+const maxNameLength = 127
+
+// maxSuggestDistance is the maximum edit distance of a known media type
+// suggested for a mistyped one.
+const maxSuggestDistance = 2
+
+// KnownType is a well-known artifact type.
+type KnownType struct {
+	// Name is the name of the format.
+	Name string
+	// MediaType is the media type of the format.
+	MediaType string
+}
+
+// KnownTypes are the well-known artifact types suggested for mistyped ones.
+var KnownTypes = []KnownType{
+	{Name: "SPDX", MediaType: "application/spdx+json"},
+	{Name: "SPDX", MediaType: "text/spdx"},
+	{Name: "CycloneDX", MediaType: "application/vnd.cyclonedx+json"},
+	{Name: "CycloneDX", MediaType: "application/vnd.cyclonedx+xml"},
+	{Name: "Helm chart", MediaType: "application/vnd.cncf.helm.config.v1+json"},
+	{Name: "WASM module", MediaType: "application/vnd.wasm.config.v0+json"},
+	{Name: "in-toto attestation", MediaType: "application/vnd.in-toto+json"},
+	{Name: "ORAS artifact", MediaType: "application/vnd.unknown.artifact.v1"},
+}
+
+// Validate checks if mediaType is a media type in the form of
+// "<type>/<subtype>" as defined by RFC 6838. Parameters are rejected since they
+// are not allowed in the descriptors of the OCI image spec.
+func Validate(mediaType string) error {
+	if mediaType == "" {
+		return errors.New("media type is empty")
+	}
+	base, params, hasParams := strings.Cut(mediaType, ";")
+	base = strings.TrimSpace(base)
+	if hasParams {
+		if _, _, err := mime.ParseMediaType(mediaType); err != nil {
+			return fmt.Errorf("invalid parameters %q: %w", strings.TrimSpace(params), err)
+		}
+		return fmt.Errorf("parameters %q are not allowed by the OCI image spec, use %q instead", strings.TrimSpace(params), base)
+	}
+	typ, subtype, ok := strings.Cut(base, "/")
+	if !ok {
+		return fmt.Errorf("missing subtype, expecting the form of <type>/<subtype>")
+	}
+	if err := validateName(typ); err != nil {
+		return fmt.Errorf("invalid type %q: %w", typ, err)
+	}
+	if err := validateName(subtype); err != nil {
+		return fmt.Errorf("invalid subtype %q: %w", subtype, err)
+	}
+	return nil
+}
+
+// validateName checks if name is a restricted-name of RFC 6838.
+func validateName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("name is empty")
+	case len(name) > maxNameLength:
+		return fmt.Errorf("name exceeds %d characters", maxNameLength)
+	case !isAlphaNumeric(name[0]):
+		return fmt.Errorf("name must start with a letter or a digit, got %q", name[0])
+	}
+	for i := 1; i < len(name); i++ {
+		if c := name[i]; !isAlphaNumeric(c) && !strings.ContainsRune("!#$&-^_.+", rune(c)) {
+			return fmt.Errorf("invalid character %q", c)
+		}
+	}
+	return nil
+}
+
+func isAlphaNumeric(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// Suggest returns the known types that mediaType is likely a typo of, which
+// differ in cases or by a few characters. No type is returned if mediaType is
+// a known type.
+func Suggest(mediaType string) []KnownType {
+	for _, known := range KnownTypes {
+		if known.MediaType == mediaType {
+			return nil
+		}
+	}
+	folded := strings.ToLower(strings.TrimSpace(mediaType))
+	var suggestions []KnownType
+	for _, known := range KnownTypes {
+		if distance(folded, known.MediaType) <= maxSuggestDistance {
+			suggestions = append(suggestions, known)
+		}
+	}
+	return suggestions
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
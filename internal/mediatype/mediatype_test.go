@@ -0,0 +1,90 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mediatype
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		mediaType string
+		wantErr   bool
+	}{
+		{name: "valid", mediaType: "application/vnd.example+json"},
+		{name: "valid with special characters", mediaType: "application/vnd.a-b_c!#$&^.d+json"},
+		{name: "unregistered type", mediaType: "doc/example"},
+		{name: "empty", mediaType: "", wantErr: true},
+		{name: "missing subtype", mediaType: "application", wantErr: true},
+		{name: "empty subtype", mediaType: "application/", wantErr: true},
+		{name: "empty type", mediaType: "/json", wantErr: true},
+		{name: "invalid first character", mediaType: "application/.json", wantErr: true},
+		{name: "space", mediaType: "application/spdx json", wantErr: true},
+		{name: "extra slash", mediaType: "application/spdx/json", wantErr: true},
+		{name: "too long", mediaType: "application/" + strings.Repeat("a", 128), wantErr: true},
+		{name: "parameters", mediaType: "application/spdx+json; version=2.3", wantErr: true},
+		{name: "invalid parameters", mediaType: "application/spdx+json; version", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.mediaType); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	spdx := KnownType{Name: "SPDX", MediaType: "application/spdx+json"}
+	tests := []struct {
+		name      string
+		mediaType string
+		want      []KnownType
+	}{
+		{name: "known", mediaType: "application/spdx+json"},
+		{name: "unrelated", mediaType: "doc/example"},
+		{name: "typo", mediaType: "aplication/spdx+json", want: []KnownType{spdx}},
+		{name: "case", mediaType: "application/SPDX+json", want: []KnownType{spdx}},
+		{name: "wrong suffix", mediaType: "application/spdx+jsn", want: []KnownType{spdx}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Suggest(tt.mediaType); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_distance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"text/spdx", "text/spdx", 0},
+	}
+	for _, tt := range tests {
+		if got := distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
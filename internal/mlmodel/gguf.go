@@ -0,0 +1,233 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mlmodel
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ggufMagic is the magic number of GGUF files.
+// Reference: https://github.com/ggml-org/ggml/blob/master/docs/gguf.md
+const ggufMagic = "GGUF"
+
+// GGUF metadata value types.
+const (
+	ggufTypeUint8 uint32 = iota
+	ggufTypeInt8
+	ggufTypeUint16
+	ggufTypeInt16
+	ggufTypeUint32
+	ggufTypeInt32
+	ggufTypeFloat32
+	ggufTypeBool
+	ggufTypeString
+	ggufTypeArray
+	ggufTypeUint64
+	ggufTypeInt64
+	ggufTypeFloat64
+)
+
+// ggufFileTypes are the names of the values of general.file_type, which
+// describes the quantization of the majority of the tensors.
+var ggufFileTypes = map[uint64]string{
+	0:  "F32",
+	1:  "F16",
+	2:  "Q4_0",
+	3:  "Q4_1",
+	7:  "Q8_0",
+	8:  "Q5_0",
+	9:  "Q5_1",
+	10: "Q2_K",
+	11: "Q3_K_S",
+	12: "Q3_K_M",
+	13: "Q3_K_L",
+	14: "Q4_K_S",
+	15: "Q4_K_M",
+	16: "Q5_K_S",
+	17: "Q5_K_M",
+	18: "Q6_K",
+	19: "IQ2_XXS",
+	20: "IQ2_XS",
+	21: "Q2_K_S",
+	22: "IQ3_XS",
+	23: "IQ3_XXS",
+	24: "IQ1_S",
+	25: "IQ4_NL",
+	26: "IQ3_S",
+	27: "IQ3_M",
+	28: "IQ2_S",
+	29: "IQ2_M",
+	30: "IQ4_XS",
+	31: "IQ1_M",
+	32: "BF16",
+}
+
+// ggufReader reads the little-endian values of a GGUF file.
+type ggufReader struct {
+	r *bufio.Reader
+}
+
+func (g *ggufReader) uint32() (uint32, error) {
+	var v uint32
+	err := binary.Read(g.r, binary.LittleEndian, &v)
+	return v, err
+}
+
+func (g *ggufReader) uint64() (uint64, error) {
+	var v uint64
+	err := binary.Read(g.r, binary.LittleEndian, &v)
+	return v, err
+}
+
+func (g *ggufReader) string() (string, error) {
+	n, err := g.uint64()
+	if err != nil {
+		return "", err
+	}
+	if n > maxStringLength {
+		return "", fmt.Errorf("%w: string of %d bytes", errMalformed, n)
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(g.r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// value reads a value of typ. Only the unsigned integers and strings are
+// returned, and the other values are skipped.
+func (g *ggufReader) value(typ uint32) (any, error) {
+	var size int64
+	switch typ {
+	case ggufTypeUint8, ggufTypeInt8, ggufTypeBool:
+		size = 1
+	case ggufTypeUint16, ggufTypeInt16:
+		size = 2
+	case ggufTypeUint32:
+		v, err := g.uint32()
+		return uint64(v), err
+	case ggufTypeInt32, ggufTypeFloat32:
+		size = 4
+	case ggufTypeUint64:
+		return g.uint64()
+	case ggufTypeInt64, ggufTypeFloat64:
+		size = 8
+	case ggufTypeString:
+		return g.string()
+	case ggufTypeArray:
+		elemType, err := g.uint32()
+		if err != nil {
+			return nil, err
+		}
+		count, err := g.uint64()
+		if err != nil {
+			return nil, err
+		}
+		for range count {
+			if _, err := g.value(elemType); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown value type %d", errMalformed, typ)
+	}
+	_, err := g.r.Discard(int(size))
+	return nil, err
+}
+
+// inspectGGUF inspects the header of a GGUF file. The number of parameters is
+// the total number of elements of the tensors.
+func inspectGGUF(r *bufio.Reader) (*Info, error) {
+	g := &ggufReader{r: r}
+	if _, err := r.Discard(len(ggufMagic)); err != nil {
+		return nil, err
+	}
+	version, err := g.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if version < 2 {
+		return nil, fmt.Errorf("unsupported GGUF version %d", version)
+	}
+	tensorCount, err := g.uint64()
+	if err != nil {
+		return nil, err
+	}
+	kvCount, err := g.uint64()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &Info{Format: FormatGGUF}
+	for range kvCount {
+		key, err := g.string()
+		if err != nil {
+			return nil, err
+		}
+		typ, err := g.uint32()
+		if err != nil {
+			return nil, err
+		}
+		value, err := g.value(typ)
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "general.file_type":
+			if fileType, ok := value.(uint64); ok {
+				if name, ok := ggufFileTypes[fileType]; ok {
+					info.Quantization = name
+				}
+			}
+		case "general.license":
+			if license, ok := value.(string); ok {
+				info.License = license
+			}
+		}
+	}
+
+	for range tensorCount {
+		if _, err := g.string(); err != nil {
+			return nil, err
+		}
+		dimCount, err := g.uint32()
+		if err != nil {
+			return nil, err
+		}
+		elements := int64(1)
+		for range dimCount {
+			dim, err := g.uint64()
+			if err != nil {
+				return nil, err
+			}
+			if dim > math.MaxInt64/uint64(max(elements, 1)) {
+				return nil, fmt.Errorf("%w: tensor too large", errMalformed)
+			}
+			elements *= int64(dim)
+		}
+		info.Parameters += elements
+		// skip the tensor type and offset
+		if _, err := r.Discard(4 + 8); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mlmodel inspects machine learning model files for their metadata.
+package mlmodel
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Formats of model files.
+const (
+	FormatGGUF        = "gguf"
+	FormatSafetensors = "safetensors"
+	FormatONNX        = "onnx"
+)
+
+// Annotations describing model files.
+const (
+	// AnnotationFormat is the annotation key for the format of the model.
+	AnnotationFormat = "land.oras.model.format"
+	// AnnotationParameters is the annotation key for the number of parameters
+	// of the model.
+	AnnotationParameters = "land.oras.model.parameters"
+	// AnnotationQuantization is the annotation key for the quantization or the
+	// precision of the model weights.
+	AnnotationQuantization = "land.oras.model.quantization"
+)
+
+// maxStringLength is the maximum length of the strings read from model
+// files, protecting from allocating excessive memory for malformed files.
+const maxStringLength = 16 * 1024 * 1024
+
+// errMalformed is returned if a model file is malformed.
+var errMalformed = errors.New("malformed model file")
+
+// Info is the metadata of a model file.
+type Info struct {
+	// Format is the format of the model file.
+	Format string
+	// Parameters is the number of parameters, or 0 if unknown.
+	Parameters int64
+	// Quantization is the quantization or the precision of the weights, or
+	// empty if unknown.
+	Quantization string
+	// License is the license declared in the model file, or empty if unknown.
+	License string
+}
+
+// Annotations returns the annotations describing the model file.
+func (info *Info) Annotations() map[string]string {
+	annotations := map[string]string{
+		AnnotationFormat: info.Format,
+	}
+	if info.Parameters > 0 {
+		annotations[AnnotationParameters] = fmt.Sprint(info.Parameters)
+	}
+	if info.Quantization != "" {
+		annotations[AnnotationQuantization] = info.Quantization
+	}
+	return annotations
+}
+
+// Inspect returns the metadata of the model file at path, or nil if the file
+// is not of a recognized model format.
+func Inspect(path string) (*Info, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	r := bufio.NewReader(f)
+	magic, err := r.Peek(8)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	var info *Info
+	switch ext := strings.ToLower(filepath.Ext(path)); {
+	case strings.HasPrefix(string(magic), ggufMagic):
+		info, err = inspectGGUF(r)
+	case ext == ".safetensors":
+		info, err = inspectSafetensors(r)
+	case ext == ".onnx":
+		info, err = inspectONNX(r)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect %s: %w", path, err)
+	}
+	return info, nil
+}
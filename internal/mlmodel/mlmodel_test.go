@@ -0,0 +1,214 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mlmodel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// ggufBuilder builds GGUF files for testing.
+type ggufBuilder struct {
+	bytes.Buffer
+}
+
+func (b *ggufBuilder) u32(v uint32) { _ = binary.Write(b, binary.LittleEndian, v) }
+func (b *ggufBuilder) u64(v uint64) { _ = binary.Write(b, binary.LittleEndian, v) }
+func (b *ggufBuilder) str(s string) { b.u64(uint64(len(s))); b.WriteString(s) }
+
+func newGGUF() []byte {
+	var b ggufBuilder
+	b.WriteString("GGUF")
+	b.u32(3) // version
+	b.u64(2) // tensor count
+	b.u64(4) // metadata count
+	b.str("general.architecture")
+	b.u32(ggufTypeString)
+	b.str("llama")
+	b.str("tokenizer.ggml.tokens")
+	b.u32(ggufTypeArray)
+	b.u32(ggufTypeString)
+	b.u64(2)
+	b.str("a")
+	b.str("b")
+	b.str("general.file_type")
+	b.u32(ggufTypeUint32)
+	b.u32(15)
+	b.str("general.license")
+	b.u32(ggufTypeString)
+	b.str("apache-2.0")
+	// tensors
+	b.str("token_embd.weight")
+	b.u32(2)
+	b.u64(4)
+	b.u64(8)
+	b.u32(12) // type
+	b.u64(0)  // offset
+	b.str("output_norm.weight")
+	b.u32(1)
+	b.u64(8)
+	b.u32(0)
+	b.u64(32)
+	return b.Bytes()
+}
+
+func newSafetensors() []byte {
+	header := []byte(`{"__metadata__":{"format":"pt"},"a":{"dtype":"BF16","shape":[2,3],"data_offsets":[0,12]},"b":{"dtype":"F32","shape":[2],"data_offsets":[12,20]}}`)
+	var b bytes.Buffer
+	_ = binary.Write(&b, binary.LittleEndian, uint64(len(header)))
+	b.Write(header)
+	b.Write(make([]byte, 20))
+	return b.Bytes()
+}
+
+// protoField encodes a protobuf field for testing.
+func protoField(field uint64, wireType int, value []byte) []byte {
+	b := binary.AppendUvarint(nil, field<<3|uint64(wireType))
+	if wireType == wireBytes {
+		b = binary.AppendUvarint(b, uint64(len(value)))
+	}
+	return append(b, value...)
+}
+
+func protoVarint(field uint64, v uint64) []byte {
+	return protoField(field, wireVarint, binary.AppendUvarint(nil, v))
+}
+
+func newONNX() []byte {
+	// tensor with unpacked dims 2x3 of FLOAT
+	tensor1 := append(append(protoVarint(onnxTensorDims, 2), protoVarint(onnxTensorDims, 3)...), protoVarint(onnxTensorDataType, 1)...)
+	tensor1 = append(tensor1, protoField(9, wireBytes, make([]byte, 24))...) // raw_data
+	// tensor with packed dims 4 of FLOAT16
+	packed := binary.AppendUvarint(nil, 4)
+	tensor2 := append(protoField(onnxTensorDims, wireBytes, packed), protoVarint(onnxTensorDataType, 10)...)
+	graph := append(protoField(1, wireBytes, []byte("node")), protoField(onnxGraphInitializer, wireBytes, tensor1)...)
+	graph = append(graph, protoField(onnxGraphInitializer, wireBytes, tensor2)...)
+	model := append(protoVarint(onnxModelIRVersion, 8), protoField(2, wireBytes, []byte("producer"))...)
+	return append(model, protoField(onnxModelGraph, wireBytes, graph)...)
+}
+
+func TestInspect(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content []byte
+		want    *Info
+		wantErr bool
+	}{
+		{
+			name:    "gguf",
+			file:    "model.gguf",
+			content: newGGUF(),
+			want:    &Info{Format: FormatGGUF, Parameters: 40, Quantization: "Q4_K_M", License: "apache-2.0"},
+		},
+		{
+			name:    "safetensors",
+			file:    "model.safetensors",
+			content: newSafetensors(),
+			want:    &Info{Format: FormatSafetensors, Parameters: 8, Quantization: "BF16"},
+		},
+		{
+			name:    "onnx",
+			file:    "model.onnx",
+			content: newONNX(),
+			want:    &Info{Format: FormatONNX, Parameters: 10, Quantization: "FLOAT"},
+		},
+		{
+			name:    "unknown",
+			file:    "model.bin",
+			content: []byte("hello"),
+		},
+		{
+			name:    "truncated gguf",
+			file:    "model.gguf",
+			content: newGGUF()[:40],
+			wantErr: true,
+		},
+		{
+			name:    "invalid safetensors",
+			file:    "model.safetensors",
+			content: append(binary.LittleEndian.AppendUint64(nil, 2), "{]"...),
+			wantErr: true,
+		},
+		{
+			name:    "not onnx",
+			file:    "model.onnx",
+			content: protoField(2, wireBytes, []byte("producer")),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, tt.content, 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := Inspect(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Inspect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Inspect() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfo_Annotations(t *testing.T) {
+	info := &Info{Format: FormatGGUF, Parameters: 7000000000, Quantization: "Q4_0"}
+	want := map[string]string{
+		AnnotationFormat:       FormatGGUF,
+		AnnotationParameters:   "7000000000",
+		AnnotationQuantization: "Q4_0",
+	}
+	if got := info.Annotations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Annotations() = %v, want %v", got, want)
+	}
+}
+
+func TestReadModelCardLicense(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "license", content: "---\nlicense: mit\ntags:\n  - llm\n---\n# Model\n", want: "mit"},
+		{name: "other license", content: "---\nlicense: other\nlicense_name: llama3\n---\n", want: "llama3"},
+		{name: "multiple licenses", content: "---\nlicense:\n  - mit\n  - apache-2.0\n---\n", want: "mit AND apache-2.0"},
+		{name: "no license", content: "---\ntags: [llm]\n---\n"},
+		{name: "no front matter", content: "# Model\nlicense: mit\n"},
+		{name: "unterminated front matter", content: "---\nlicense: mit\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "README.md")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ReadModelCardLicense(path)
+			if err != nil {
+				t.Fatalf("ReadModelCardLicense() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ReadModelCardLicense() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
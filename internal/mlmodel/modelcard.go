@@ -0,0 +1,93 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mlmodel
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// maxModelCardMetadataSize is the maximum size of the metadata of model
+// cards.
+const maxModelCardMetadataSize = 1024 * 1024
+
+// IsModelCard returns true if the file at path is a model card, which is a
+// README.md file with the YAML metadata as the front matter.
+// Reference: https://huggingface.co/docs/hub/model-cards
+func IsModelCard(path string) bool {
+	return strings.EqualFold(filepath.Base(path), "README.md")
+}
+
+// ReadModelCardLicense reads the license in the metadata of the model card at
+// path. An empty license is returned if not declared.
+func ReadModelCardLicense(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != "---" {
+		return "", scanner.Err()
+	}
+	var front strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "---" {
+			var metadata struct {
+				License     any    `yaml:"license"`
+				LicenseName string `yaml:"license_name"`
+			}
+			if err := yaml.Unmarshal([]byte(front.String()), &metadata); err != nil {
+				return "", nil
+			}
+			return modelCardLicense(metadata.License, metadata.LicenseName), nil
+		}
+		if front.Len()+len(line) > maxModelCardMetadataSize {
+			break
+		}
+		front.WriteString(line)
+		front.WriteByte('\n')
+	}
+	return "", scanner.Err()
+}
+
+// modelCardLicense returns the license identifier in the model card metadata,
+// where license may be a list of licenses and license_name names the "other"
+// license.
+func modelCardLicense(license any, licenseName string) string {
+	switch license := license.(type) {
+	case string:
+		if license == "other" && licenseName != "" {
+			return licenseName
+		}
+		return license
+	case []any:
+		var licenses []string
+		for _, l := range license {
+			if s, ok := l.(string); ok && s != "" {
+				licenses = append(licenses, s)
+			}
+		}
+		return strings.Join(licenses, " AND ")
+	}
+	return ""
+}
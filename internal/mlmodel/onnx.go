@@ -0,0 +1,280 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mlmodel
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Field numbers of the ONNX protobuf messages.
+// Reference: https://github.com/onnx/onnx/blob/main/onnx/onnx.proto
+const (
+	onnxModelIRVersion   = 1
+	onnxModelGraph       = 7
+	onnxGraphInitializer = 5
+	onnxTensorDims       = 1
+	onnxTensorDataType   = 2
+)
+
+// onnxDataTypes are the names of the values of TensorProto.DataType.
+var onnxDataTypes = map[uint64]string{
+	1:  "FLOAT",
+	2:  "UINT8",
+	3:  "INT8",
+	5:  "INT16",
+	6:  "INT32",
+	7:  "INT64",
+	10: "FLOAT16",
+	11: "DOUBLE",
+	16: "BFLOAT16",
+	17: "FLOAT8E4M3FN",
+	19: "FLOAT8E5M2",
+	22: "INT4",
+	21: "UINT4",
+}
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// protoReader reads protobuf messages in a stream without loading the
+// potentially large tensors into memory.
+type protoReader struct {
+	r *bufio.Reader
+}
+
+// next reads the next field of the message ending at limit bytes, and
+// returns io.EOF at the end of the message.
+func (p *protoReader) next(limit *int64) (field uint64, wireType int, err error) {
+	if *limit == 0 {
+		return 0, 0, io.EOF
+	}
+	key, err := p.varint(limit)
+	if err != nil {
+		return 0, 0, err
+	}
+	return key >> 3, int(key & 7), nil
+}
+
+func (p *protoReader) varint(limit *int64) (uint64, error) {
+	var v uint64
+	for shift := 0; shift < 64; shift += 7 {
+		b, err := p.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if *limit--; *limit < 0 {
+			return 0, errMalformed
+		}
+		v |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return v, nil
+		}
+	}
+	return 0, errMalformed
+}
+
+// length reads the length of a length-delimited field.
+func (p *protoReader) length(limit *int64) (int64, error) {
+	n, err := p.varint(limit)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(*limit) {
+		return 0, errMalformed
+	}
+	return int64(n), nil
+}
+
+// skip skips the value of a field of wireType.
+func (p *protoReader) skip(wireType int, limit *int64) error {
+	var n int64
+	switch wireType {
+	case wireVarint:
+		_, err := p.varint(limit)
+		return err
+	case wireFixed64:
+		n = 8
+	case wireFixed32:
+		n = 4
+	case wireBytes:
+		var err error
+		if n, err = p.length(limit); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: unsupported wire type %d", errMalformed, wireType)
+	}
+	if n > *limit {
+		return errMalformed
+	}
+	*limit -= n
+	for n > 0 {
+		discarded, err := p.r.Discard(int(min(n, math.MaxInt32)))
+		if err != nil {
+			return err
+		}
+		n -= int64(discarded)
+	}
+	return nil
+}
+
+// inspectONNX inspects an ONNX model. The number of parameters is the total
+// number of elements of the initializers, and the quantization is the data
+// type of the most parameters.
+func inspectONNX(r *bufio.Reader) (*Info, error) {
+	p := &protoReader{r: r}
+	info := &Info{Format: FormatONNX}
+	parametersByType := make(map[string]int64)
+	limit := int64(math.MaxInt64)
+	var recognized bool
+	for {
+		field, wireType, err := p.next(&limit)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case field == onnxModelIRVersion && wireType == wireVarint:
+			if _, err := p.varint(&limit); err != nil {
+				return nil, err
+			}
+			recognized = true
+		case field == onnxModelGraph && wireType == wireBytes:
+			n, err := p.length(&limit)
+			if err != nil {
+				return nil, err
+			}
+			limit -= n
+			if err := p.readGraph(n, parametersByType); err != nil {
+				return nil, err
+			}
+		default:
+			if err := p.skip(wireType, &limit); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !recognized {
+		return nil, fmt.Errorf("%w: missing ONNX IR version", errMalformed)
+	}
+	for dataType, parameters := range parametersByType {
+		info.Parameters += parameters
+		if current := parametersByType[info.Quantization]; parameters > current || parameters == current && dataType < info.Quantization {
+			info.Quantization = dataType
+		}
+	}
+	return info, nil
+}
+
+// readGraph reads the initializers of the GraphProto of n bytes.
+func (p *protoReader) readGraph(n int64, parametersByType map[string]int64) error {
+	for {
+		field, wireType, err := p.next(&n)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if field != onnxGraphInitializer || wireType != wireBytes {
+			if err := p.skip(wireType, &n); err != nil {
+				return err
+			}
+			continue
+		}
+		size, err := p.length(&n)
+		if err != nil {
+			return err
+		}
+		n -= size
+		elements, dataType, err := p.readTensor(size)
+		if err != nil {
+			return err
+		}
+		name, ok := onnxDataTypes[dataType]
+		if !ok {
+			name = fmt.Sprintf("TYPE_%d", dataType)
+		}
+		parametersByType[name] += elements
+	}
+}
+
+// readTensor reads the number of elements and the data type of the
+// TensorProto of n bytes.
+func (p *protoReader) readTensor(n int64) (elements int64, dataType uint64, err error) {
+	elements = 1
+	multiply := func(dim uint64) error {
+		if dim > math.MaxInt64/uint64(max(elements, 1)) {
+			return fmt.Errorf("%w: tensor too large", errMalformed)
+		}
+		elements *= int64(dim)
+		return nil
+	}
+	for {
+		field, wireType, err := p.next(&n)
+		if errors.Is(err, io.EOF) {
+			return elements, dataType, nil
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		switch {
+		case field == onnxTensorDims && wireType == wireVarint:
+			dim, err := p.varint(&n)
+			if err != nil {
+				return 0, 0, err
+			}
+			if err := multiply(dim); err != nil {
+				return 0, 0, err
+			}
+		case field == onnxTensorDims && wireType == wireBytes:
+			// packed dims
+			size, err := p.length(&n)
+			if err != nil {
+				return 0, 0, err
+			}
+			n -= size
+			for size > 0 {
+				dim, err := p.varint(&size)
+				if err != nil {
+					return 0, 0, err
+				}
+				if err := multiply(dim); err != nil {
+					return 0, 0, err
+				}
+			}
+		case field == onnxTensorDataType && wireType == wireVarint:
+			if dataType, err = p.varint(&n); err != nil {
+				return 0, 0, err
+			}
+		default:
+			if err := p.skip(wireType, &n); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+}
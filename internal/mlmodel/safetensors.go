@@ -0,0 +1,83 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mlmodel
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// maxSafetensorsHeaderSize is the maximum size of the JSON header of
+// safetensors files.
+// Reference: https://github.com/huggingface/safetensors#format
+const maxSafetensorsHeaderSize = 100 * 1024 * 1024
+
+// safetensorsTensor is a tensor described in the header of safetensors files.
+type safetensorsTensor struct {
+	DType string  `json:"dtype"`
+	Shape []int64 `json:"shape"`
+}
+
+// inspectSafetensors inspects the header of a safetensors file. The
+// quantization is the data type of the most parameters.
+func inspectSafetensors(r *bufio.Reader) (*Info, error) {
+	var headerSize uint64
+	if err := binary.Read(r, binary.LittleEndian, &headerSize); err != nil {
+		return nil, err
+	}
+	if headerSize > maxSafetensorsHeaderSize {
+		return nil, fmt.Errorf("%w: header of %d bytes", errMalformed, headerSize)
+	}
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(header, &entries); err != nil {
+		return nil, fmt.Errorf("%w: %v", errMalformed, err)
+	}
+
+	info := &Info{Format: FormatSafetensors}
+	parametersByDType := make(map[string]int64)
+	for name, entry := range entries {
+		if name == "__metadata__" {
+			continue
+		}
+		var tensor safetensorsTensor
+		if err := json.Unmarshal(entry, &tensor); err != nil {
+			return nil, fmt.Errorf("%w: tensor %s: %v", errMalformed, name, err)
+		}
+		elements := int64(1)
+		for _, dim := range tensor.Shape {
+			if dim < 0 || dim > 0 && elements > math.MaxInt64/dim {
+				return nil, fmt.Errorf("%w: invalid shape of tensor %s", errMalformed, name)
+			}
+			elements *= dim
+		}
+		info.Parameters += elements
+		parametersByDType[tensor.DType] += elements
+	}
+	for dtype, parameters := range parametersByDType {
+		if current := parametersByDType[info.Quantization]; parameters > current || parameters == current && dtype < info.Quantization {
+			info.Quantization = dtype
+		}
+	}
+	return info, nil
+}
@@ -19,6 +19,16 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// IP versions accepted by IPVersionDialContext.
+const (
+	IPVersionAuto = "auto"
+	IPVersion4    = "4"
+	IPVersion6    = "6"
 )
 
 // DialFunc is the function type for http.DialContext.
@@ -28,21 +38,138 @@ type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
 type Dialer struct {
 	BaseDialContext DialFunc
 	resolve         map[string]string
+	wildcards       []wildcardResolve
+	zones           map[string]string
+}
+
+// wildcardResolve is a DNS resolve entry for the hosts matching pattern.
+type wildcardResolve struct {
+	pattern string
+	port    int
+	to      string
 }
 
-// Add adds an entry for DNS resolve.
+// Add adds an entry for DNS resolve. from may be a wildcard pattern such as
+// "*.example.com", matched with path.Match. Entries added later take
+// precedence over the earlier ones, and entries for exact hosts take
+// precedence over wildcard entries.
 func (d *Dialer) Add(from string, fromPort int, to net.IP, toPort int) {
+	target := net.JoinHostPort(to.String(), fmt.Sprint(toPort))
+	if strings.ContainsAny(from, "*?[") {
+		d.wildcards = append(d.wildcards, wildcardResolve{
+			pattern: from,
+			port:    fromPort,
+			to:      target,
+		})
+		return
+	}
 	if d.resolve == nil {
 		d.resolve = make(map[string]string)
 	}
-	d.resolve[fmt.Sprintf("%s:%d", from, fromPort)] = fmt.Sprintf("%s:%d", to, toPort)
+	d.resolve[fmt.Sprintf("%s:%d", from, fromPort)] = target
+}
+
+// lookup returns the resolved address of addr.
+func (d *Dialer) lookup(addr string) (string, bool) {
+	if resolved, ok := d.resolve[addr]; ok {
+		return resolved, true
+	}
+	if len(d.wildcards) == 0 {
+		return "", false
+	}
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", false
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", false
+	}
+	for i := len(d.wildcards) - 1; i >= 0; i-- {
+		w := d.wildcards[i]
+		if w.port != port {
+			continue
+		}
+		if matched, _ := path.Match(w.pattern, host); matched {
+			return w.to, true
+		}
+	}
+	return "", false
+}
+
+// AddZone adds the IPv6 zone used to dial the link-local address ip.
+func (d *Dialer) AddZone(ip net.IP, zone string) {
+	if d.zones == nil {
+		d.zones = make(map[string]string)
+	}
+	d.zones[ip.String()] = zone
 }
 
 // DialContext connects to the addr on the named network using the provided
 // context.
 func (d *Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
-	if resolved, ok := d.resolve[addr]; ok {
+	if resolved, ok := d.lookup(addr); ok {
 		addr = resolved
 	}
+	if len(d.zones) != 0 {
+		if host, port, err := net.SplitHostPort(addr); err == nil {
+			if zone, ok := d.zones[host]; ok {
+				addr = net.JoinHostPort(host+"%"+zone, port)
+			}
+		}
+	}
 	return d.BaseDialContext(ctx, network, addr)
 }
+
+// UnixSocketDialContext returns a DialFunc connecting to the unix domain
+// socket at path regardless of the requested network and address.
+func UnixSocketDialContext(path string) DialFunc {
+	var d net.Dialer
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return d.DialContext(ctx, "unix", path)
+	}
+}
+
+// IPVersionDialContext returns a DialFunc restricting the TCP connections
+// dialed by base to the IP version, which is one of IPVersion4, IPVersion6 and
+// IPVersionAuto. With IPVersionAuto, base dials both IPv4 and IPv6 addresses
+// as described in RFC 6555 if it is a net.Dialer.
+func IPVersionDialContext(base DialFunc, version string) (DialFunc, error) {
+	var suffix string
+	switch version {
+	case "", IPVersionAuto:
+		return base, nil
+	case IPVersion4, IPVersion6:
+		suffix = version
+	default:
+		return nil, fmt.Errorf("invalid IP version %q, must be %q, %q or %q", version, IPVersion4, IPVersion6, IPVersionAuto)
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if network == "tcp" {
+			network += suffix
+		}
+		return base(ctx, network, addr)
+	}, nil
+}
+
+// SplitZone removes the zone of the IPv6 literal in host, which is in the form
+// of [<address>%<zone>] or [<address>%<zone>]:<port>, and returns the address
+// with the zone. ok is false if host does not contain an IPv6 literal with a zone.
+func SplitZone(host string) (stripped string, ip net.IP, zone string, ok bool) {
+	if !strings.HasPrefix(host, "[") {
+		return host, nil, "", false
+	}
+	end := strings.Index(host, "]")
+	if end < 0 {
+		return host, nil, "", false
+	}
+	literal, rest := host[1:end], host[end:]
+	address, zone, found := strings.Cut(literal, "%")
+	if !found {
+		return host, nil, "", false
+	}
+	if ip = net.ParseIP(address); ip == nil || ip.To4() != nil || zone == "" {
+		return host, nil, "", false
+	}
+	return "[" + address + rest, ip, zone, true
+}
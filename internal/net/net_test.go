@@ -16,8 +16,11 @@ limitations under the License.
 package net
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -39,3 +42,142 @@ func TestRemote_parseResolve_ipv4(t *testing.T) {
 		t.Fatalf("expecting %v  but got %v", want, d.resolve)
 	}
 }
+
+func TestUnixSocketDialContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unix domain socket is not supported: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte("ok"))
+		conn.Close()
+	}()
+
+	dial := UnixSocketDialContext(path)
+	conn, err := dial(context.Background(), "tcp", "localhost:5000")
+	if err != nil {
+		t.Fatalf("UnixSocketDialContext() error = %v", err)
+	}
+	defer conn.Close()
+	got, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatalf("failed to read from connection: %v", err)
+	}
+	if string(got) != "ok" {
+		t.Errorf("read %q, want %q", got, "ok")
+	}
+}
+
+func TestIPVersionDialContext(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     string
+		network     string
+		wantNetwork string
+		wantErr     bool
+	}{
+		{name: "auto", version: IPVersionAuto, network: "tcp", wantNetwork: "tcp"},
+		{name: "unset", version: "", network: "tcp", wantNetwork: "tcp"},
+		{name: "IPv4", version: IPVersion4, network: "tcp", wantNetwork: "tcp4"},
+		{name: "IPv6", version: IPVersion6, network: "tcp", wantNetwork: "tcp6"},
+		{name: "explicit network", version: IPVersion6, network: "tcp4", wantNetwork: "tcp4"},
+		{name: "invalid", version: "5", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotNetwork string
+			base := func(_ context.Context, network, _ string) (net.Conn, error) {
+				gotNetwork = network
+				return nil, nil
+			}
+			dial, err := IPVersionDialContext(base, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IPVersionDialContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			_, _ = dial(context.Background(), tt.network, "localhost:5000")
+			if gotNetwork != tt.wantNetwork {
+				t.Errorf("dialed network = %q, want %q", gotNetwork, tt.wantNetwork)
+			}
+		})
+	}
+}
+
+func TestSplitZone(t *testing.T) {
+	tests := []struct {
+		name         string
+		host         string
+		wantStripped string
+		wantIP       net.IP
+		wantZone     string
+		wantOK       bool
+	}{
+		{name: "zone and port", host: "[fe80::1%eth0]:5000", wantStripped: "[fe80::1]:5000", wantIP: net.ParseIP("fe80::1"), wantZone: "eth0", wantOK: true},
+		{name: "zone only", host: "[fe80::1%2]", wantStripped: "[fe80::1]", wantIP: net.ParseIP("fe80::1"), wantZone: "2", wantOK: true},
+		{name: "no zone", host: "[fe80::1]:5000", wantStripped: "[fe80::1]:5000"},
+		{name: "empty zone", host: "[fe80::1%]:5000", wantStripped: "[fe80::1%]:5000"},
+		{name: "host name", host: "localhost:5000", wantStripped: "localhost:5000"},
+		{name: "not an IPv6 address", host: "[host%eth0]:5000", wantStripped: "[host%eth0]:5000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stripped, ip, zone, ok := SplitZone(tt.host)
+			if stripped != tt.wantStripped || !ip.Equal(tt.wantIP) || zone != tt.wantZone || ok != tt.wantOK {
+				t.Errorf("SplitZone() = %q, %v, %q, %v, want %q, %v, %q, %v", stripped, ip, zone, ok, tt.wantStripped, tt.wantIP, tt.wantZone, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDialer_AddZone(t *testing.T) {
+	var gotAddr string
+	d := Dialer{
+		BaseDialContext: func(_ context.Context, _, addr string) (net.Conn, error) {
+			gotAddr = addr
+			return nil, nil
+		},
+	}
+	d.Add("registry.example", 443, net.ParseIP("fe80::1"), 5000)
+	d.AddZone(net.ParseIP("fe80::1"), "eth0")
+	_, _ = d.DialContext(context.Background(), "tcp", "registry.example:443")
+	if want := "[fe80::1%eth0]:5000"; gotAddr != want {
+		t.Errorf("dialed address = %q, want %q", gotAddr, want)
+	}
+}
+
+func TestDialer_DialContext_resolve(t *testing.T) {
+	var d Dialer
+	d.Add("*.internal.example.com", 443, net.ParseIP("10.0.0.5"), 443)
+	d.Add("*.example.com", 443, net.ParseIP("10.0.0.6"), 5000)
+	d.Add("registry.internal.example.com", 443, net.ParseIP("2001:db8::1"), 443)
+	var gotAddr string
+	d.BaseDialContext = func(_ context.Context, _, addr string) (net.Conn, error) {
+		gotAddr = addr
+		return nil, nil
+	}
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{addr: "registry.internal.example.com:443", want: "[2001:db8::1]:443"},
+		{addr: "mirror.internal.example.com:443", want: "10.0.0.6:5000"},
+		{addr: "example.com:443", want: "example.com:443"},
+		{addr: "mirror.internal.example.com:5000", want: "mirror.internal.example.com:5000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			_, _ = d.DialContext(context.Background(), "tcp", tt.addr)
+			if gotAddr != tt.want {
+				t.Errorf("dialed address = %q, want %q", gotAddr, tt.want)
+			}
+		})
+	}
+}
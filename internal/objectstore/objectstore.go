@@ -0,0 +1,217 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package objectstore streams archives to and from object storage, addressed
+// by URLs in the form of s3://<bucket>/<key>.
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// SchemeS3 is the URL scheme of the objects in S3 or S3 compatible storage.
+const SchemeS3 = "s3://"
+
+const (
+	// partSize is the size of the parts of multipart uploads. As an upload
+	// has 10,000 parts at most, archives of up to 1.25 TiB can be streamed.
+	partSize = 128 * 1024 * 1024
+	// uploadConcurrency is the number of parts uploaded concurrently, each
+	// buffered in memory.
+	uploadConcurrency = 2
+	// defaultRegion is the region used if none is configured.
+	defaultRegion = "us-east-1"
+)
+
+// Location is the location of an object.
+type Location struct {
+	Bucket string
+	Key    string
+}
+
+// String returns the URL of the object.
+func (l Location) String() string {
+	return SchemeS3 + l.Bucket + "/" + l.Key
+}
+
+// IsURL reports whether s is an object storage URL.
+func IsURL(s string) bool {
+	return strings.HasPrefix(s, SchemeS3)
+}
+
+// ParseURL parses an object storage URL in the form of s3://<bucket>/<key>.
+func ParseURL(s string) (Location, error) {
+	rest, ok := strings.CutPrefix(s, SchemeS3)
+	if !ok {
+		return Location{}, fmt.Errorf("invalid object storage URL %q: the scheme must be %q", s, SchemeS3)
+	}
+	bucket, key, _ := strings.Cut(rest, "/")
+	if bucket == "" || key == "" || strings.HasSuffix(key, "/") {
+		return Location{}, fmt.Errorf("invalid object storage URL %q: must be in the form of %s<bucket>/<key>", s, SchemeS3)
+	}
+	return Location{Bucket: bucket, Key: key}, nil
+}
+
+// ParsePrefix parses an object storage URL in the form of
+// s3://<bucket>[/<prefix>], referring to the objects under the prefix as a
+// directory. The key of the returned location is the prefix ending with "/",
+// or empty for the objects at the root of the bucket.
+func ParsePrefix(s string) (Location, error) {
+	rest, ok := strings.CutPrefix(s, SchemeS3)
+	if !ok {
+		return Location{}, fmt.Errorf("invalid object storage URL %q: the scheme must be %q", s, SchemeS3)
+	}
+	bucket, prefix, _ := strings.Cut(rest, "/")
+	if bucket == "" {
+		return Location{}, fmt.Errorf("invalid object storage URL %q: must be in the form of %s<bucket>[/<prefix>]", s, SchemeS3)
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return Location{Bucket: bucket, Key: prefix}, nil
+}
+
+// Object describes an object.
+type Object struct {
+	Location
+	Size         int64
+	LastModified time.Time
+}
+
+// Client accesses objects in S3 or S3 compatible storage.
+type Client struct {
+	s3 *s3.Client
+}
+
+// NewClient returns a client configured by the shared AWS configuration, such
+// as the environment variables AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY,
+// AWS_REGION and AWS_PROFILE. S3 compatible storage is accessed by setting its
+// endpoint in AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL.
+func NewClient(ctx context.Context) (*Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the object storage configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		cfg.Region = defaultRegion
+	}
+	return &Client{
+		s3: s3.NewFromConfig(cfg, func(o *s3.Options) {
+			if o.BaseEndpoint != nil {
+				// S3 compatible storage commonly serves buckets by path and
+				// does not support the optional checksums
+				o.UsePathStyle = true
+				o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+				o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+			}
+		}),
+	}, nil
+}
+
+// Upload uploads the content read from r to the object at loc with multipart
+// uploads, without buffering more than a few parts in memory, and sets the
+// user defined metadata of the object. The upload is aborted if reading r
+// fails.
+func (c *Client) Upload(ctx context.Context, loc Location, r io.Reader, metadata map[string]string) error {
+	uploader := manager.NewUploader(c.s3, func(u *manager.Uploader) {
+		u.PartSize = partSize
+		u.Concurrency = uploadConcurrency
+	})
+	if _, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket:   aws.String(loc.Bucket),
+		Key:      aws.String(loc.Key),
+		Body:     r,
+		Metadata: metadata,
+	}); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", loc, err)
+	}
+	return nil
+}
+
+// Open opens the object at loc for streaming its content, and returns the
+// content and its size.
+func (c *Client) Open(ctx context.Context, loc Location) (io.ReadCloser, int64, error) {
+	out, err := c.s3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(loc.Bucket),
+		Key:    aws.String(loc.Key),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download %s: %w", loc, err)
+	}
+	if out.ContentLength == nil {
+		out.Body.Close()
+		return nil, 0, errors.New("failed to download " + loc.String() + ": unknown size")
+	}
+	return out.Body, *out.ContentLength, nil
+}
+
+// List lists the objects directly under the prefix at loc, as returned by
+// ParsePrefix, treating "/" as the separator of directories.
+func (c *Client) List(ctx context.Context, prefix Location) ([]Object, error) {
+	var objects []Object
+	paginator := s3.NewListObjectsV2Paginator(c.s3, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(prefix.Bucket),
+		Prefix:    aws.String(prefix.Key),
+		Delimiter: aws.String("/"),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+		}
+		for _, object := range page.Contents {
+			objects = append(objects, Object{
+				Location:     Location{Bucket: prefix.Bucket, Key: aws.ToString(object.Key)},
+				Size:         aws.ToInt64(object.Size),
+				LastModified: aws.ToTime(object.LastModified),
+			})
+		}
+	}
+	return objects, nil
+}
+
+// Metadata returns the user defined metadata of the object at loc, with the
+// keys in lower case.
+func (c *Client) Metadata(ctx context.Context, loc Location) (map[string]string, error) {
+	out, err := c.s3.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(loc.Bucket),
+		Key:    aws.String(loc.Key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the metadata of %s: %w", loc, err)
+	}
+	return out.Metadata, nil
+}
+
+// Delete deletes the object at loc.
+func (c *Client) Delete(ctx context.Context, loc Location) error {
+	if _, err := c.s3.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(loc.Bucket),
+		Key:    aws.String(loc.Key),
+	}); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", loc, err)
+	}
+	return nil
+}
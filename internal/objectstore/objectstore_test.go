@@ -0,0 +1,261 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+// fakeS3 is an in-memory S3 server storing objects by path-style URL paths.
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	metadata map[string]http.Header
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.objects[r.URL.Path] = data
+		metadata := http.Header{}
+		for key, values := range r.Header {
+			if strings.HasPrefix(key, "X-Amz-Meta-") {
+				metadata[key] = values
+			}
+		}
+		s.metadata[r.URL.Path] = metadata
+		w.Header().Set("ETag", `"etag"`)
+	case http.MethodGet, http.MethodHead:
+		if r.URL.Query().Get("list-type") == "2" {
+			s.list(w, r)
+			return
+		}
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`)
+			return
+		}
+		for key, values := range s.metadata[r.URL.Path] {
+			w.Header()[key] = values
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// list serves ListObjectsV2 requests with "/" as the delimiter.
+func (s *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Path + "/" + r.URL.Query().Get("prefix")
+	var contents strings.Builder
+	var keys []string
+	for path := range s.objects {
+		if rest, ok := strings.CutPrefix(path, prefix); ok && !strings.Contains(rest, "/") {
+			keys = append(keys, path)
+		}
+	}
+	sort.Strings(keys)
+	for _, path := range keys {
+		fmt.Fprintf(&contents, "<Contents><Key>%s</Key><Size>%d</Size><LastModified>2024-01-01T00:00:00.000Z</LastModified></Contents>", strings.TrimPrefix(path, r.URL.Path+"/"), len(s.objects[path]))
+	}
+	fmt.Fprintf(w, "<ListBucketResult><KeyCount>%d</KeyCount><IsTruncated>false</IsTruncated>%s</ListBucketResult>", len(keys), contents.String())
+}
+
+func newTestClient(t *testing.T) (*Client, *fakeS3) {
+	t.Helper()
+	s3 := &fakeS3{
+		objects:  make(map[string][]byte),
+		metadata: make(map[string]http.Header),
+	}
+	server := httptest.NewServer(s3)
+	t.Cleanup(server.Close)
+	dir := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_ENDPOINT_URL", server.URL)
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	client, err := NewClient(context.Background())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, s3
+}
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    Location
+		wantErr bool
+	}{
+		{name: "object", url: "s3://bucket/hello.tar", want: Location{Bucket: "bucket", Key: "hello.tar"}},
+		{name: "object with prefix", url: "s3://bucket/backups/hello.tar.zst", want: Location{Bucket: "bucket", Key: "backups/hello.tar.zst"}},
+		{name: "no key", url: "s3://bucket", wantErr: true},
+		{name: "prefix only", url: "s3://bucket/backups/", wantErr: true},
+		{name: "no bucket", url: "s3:///hello.tar", wantErr: true},
+		{name: "other scheme", url: "gs://bucket/hello.tar", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseURL() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.url {
+				t.Errorf("Location.String() = %q, want %q", got.String(), tt.url)
+			}
+		})
+	}
+}
+
+func TestClient_UploadOpen(t *testing.T) {
+	ctx := context.Background()
+	client, s3 := newTestClient(t)
+	loc := Location{Bucket: "bucket", Key: "backups/hello.tar"}
+	if err := client.Upload(ctx, loc, strings.NewReader("hello world"), map[string]string{"created": "now"}); err != nil {
+		t.Fatalf("Client.Upload() error = %v", err)
+	}
+	if got := string(s3.objects["/bucket/backups/hello.tar"]); got != "hello world" {
+		t.Errorf("uploaded object = %q, want %q", got, "hello world")
+	}
+
+	rc, size, err := client.Open(ctx, loc)
+	if err != nil {
+		t.Fatalf("Client.Open() error = %v", err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello world" || size != int64(len(data)) {
+		t.Errorf("Client.Open() = %q, %d, want %q, %d", data, size, "hello world", len("hello world"))
+	}
+
+	if _, _, err := client.Open(ctx, Location{Bucket: "bucket", Key: "missing.tar"}); err == nil {
+		t.Error("Client.Open() error = nil, want error")
+	}
+
+	metadata, err := client.Metadata(ctx, loc)
+	if err != nil {
+		t.Fatalf("Client.Metadata() error = %v", err)
+	}
+	if want := map[string]string{"created": "now"}; !reflect.DeepEqual(metadata, want) {
+		t.Errorf("Client.Metadata() = %v, want %v", metadata, want)
+	}
+}
+
+func TestClient_ListDelete(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t)
+	for _, key := range []string{"backups/a.tar", "backups/b.tar", "backups/old/c.tar", "other.tar"} {
+		if err := client.Upload(ctx, Location{Bucket: "bucket", Key: key}, strings.NewReader(key), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prefix, err := ParsePrefix("s3://bucket/backups")
+	if err != nil {
+		t.Fatalf("ParsePrefix() error = %v", err)
+	}
+	if err := client.Delete(ctx, Location{Bucket: "bucket", Key: "backups/b.tar"}); err != nil {
+		t.Fatalf("Client.Delete() error = %v", err)
+	}
+	objects, err := client.List(ctx, prefix)
+	if err != nil {
+		t.Fatalf("Client.List() error = %v", err)
+	}
+	want := []Object{{
+		Location:     Location{Bucket: "bucket", Key: "backups/a.tar"},
+		Size:         int64(len("backups/a.tar")),
+		LastModified: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	if !reflect.DeepEqual(objects, want) {
+		t.Errorf("Client.List() = %v, want %v", objects, want)
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    Location
+		wantErr bool
+	}{
+		{url: "s3://bucket", want: Location{Bucket: "bucket"}},
+		{url: "s3://bucket/", want: Location{Bucket: "bucket"}},
+		{url: "s3://bucket/backups", want: Location{Bucket: "bucket", Key: "backups/"}},
+		{url: "s3://bucket/backups/daily/", want: Location{Bucket: "bucket", Key: "backups/daily/"}},
+		{url: "s3:///backups", wantErr: true},
+		{url: "backups", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := ParsePrefix(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePrefix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePrefix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_Upload_readError(t *testing.T) {
+	client, s3 := newTestClient(t)
+	errRead := errors.New("read failure")
+	loc := Location{Bucket: "bucket", Key: "hello.tar"}
+	if err := client.Upload(context.Background(), loc, iotest.ErrReader(errRead), nil); !errors.Is(err, errRead) {
+		t.Errorf("Client.Upload() error = %v, want %v", err, errRead)
+	}
+	if _, ok := s3.objects["/bucket/hello.tar"]; ok {
+		t.Error("object uploaded despite the read failure")
+	}
+}
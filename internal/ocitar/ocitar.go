@@ -0,0 +1,257 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ocitar writes OCI image layouts as tar archives on the fly, without
+// staging the layouts on the local file system.
+package ocitar
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras/internal/descriptor"
+)
+
+// Writer is an oras.GraphTarget writing the content pushed to it into a tar
+// archive of an OCI image layout. Blobs are written into the archive as they
+// are pushed, one at a time, and cannot be fetched back. Manifests are also
+// kept in memory for resolving, tagging and discovering predecessors.
+type Writer struct {
+	mu        sync.Mutex
+	tw        *tar.Writer
+	modTime   time.Time
+	manifests *memory.Store
+	blobs     []ocispec.Descriptor
+	exists    map[digest.Digest]bool
+	dirs      map[string]bool
+	pushed    []ocispec.Descriptor
+	tags      []string
+}
+
+// NewWriter returns a Writer writing the tar archive to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		tw:        tar.NewWriter(w),
+		modTime:   time.Now(),
+		manifests: memory.New(),
+		exists:    make(map[digest.Digest]bool),
+		dirs:      make(map[string]bool),
+	}
+}
+
+// Fetch fetches the manifest described by target. Other blobs are not
+// available as they are only written into the archive.
+func (w *Writer) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if !descriptor.IsManifest(target) {
+		return nil, fmt.Errorf("%s: %s: %w", target.Digest, target.MediaType, errdef.ErrNotFound)
+	}
+	return w.manifests.Fetch(ctx, target)
+}
+
+// Push writes the content read from r into the archive as the blob described
+// by expected, verifying the content against expected.
+func (w *Writer) Push(ctx context.Context, expected ocispec.Descriptor, r io.Reader) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.exists[expected.Digest] {
+		return fmt.Errorf("%s: %s: %w", expected.Digest, expected.MediaType, errdef.ErrAlreadyExists)
+	}
+	if err := expected.Digest.Validate(); err != nil {
+		return err
+	}
+	name := path.Join(ocispec.ImageBlobsDir, expected.Digest.Algorithm().String(), expected.Digest.Encoded())
+	if descriptor.IsManifest(expected) {
+		data, err := content.ReadAll(r, expected)
+		if err != nil {
+			return err
+		}
+		if err := w.writeFile(name, bytes.NewReader(data), expected.Size); err != nil {
+			return err
+		}
+		if err := w.manifests.Push(ctx, expected, bytes.NewReader(data)); err != nil {
+			return err
+		}
+		w.pushed = append(w.pushed, expected)
+	} else {
+		vr := content.NewVerifyReader(r, expected)
+		if err := w.writeFile(name, vr, expected.Size); err != nil {
+			return err
+		}
+		if err := vr.Verify(); err != nil {
+			return err
+		}
+	}
+	w.exists[expected.Digest] = true
+	w.blobs = append(w.blobs, expected)
+	return nil
+}
+
+// Exists returns true if the blob described by target has been written into
+// the archive.
+func (w *Writer) Exists(_ context.Context, target ocispec.Descriptor) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.exists[target.Digest], nil
+}
+
+// Predecessors returns the manifests directly referencing node.
+func (w *Writer) Predecessors(ctx context.Context, node ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+	return w.manifests.Predecessors(ctx, node)
+}
+
+// Resolve resolves the tag reference to a descriptor.
+func (w *Writer) Resolve(ctx context.Context, reference string) (ocispec.Descriptor, error) {
+	return w.manifests.Resolve(ctx, reference)
+}
+
+// Tag tags the manifest described by desc with reference, which is recorded
+// in the index of the layout.
+func (w *Writer) Tag(ctx context.Context, desc ocispec.Descriptor, reference string) error {
+	if reference == "" {
+		return errdef.ErrMissingReference
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.manifests.Tag(ctx, desc, reference); err != nil {
+		return err
+	}
+	for _, tag := range w.tags {
+		if tag == reference {
+			return nil
+		}
+	}
+	w.tags = append(w.tags, reference)
+	return nil
+}
+
+// Blobs returns the descriptors of the blobs, including the manifests, written
+// into the archive in the order they are written.
+func (w *Writer) Blobs() []ocispec.Descriptor {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]ocispec.Descriptor(nil), w.blobs...)
+}
+
+// WriteFile writes data into the archive as the file at the slash separated
+// path name, relative to the root of the layout.
+func (w *Writer) WriteFile(name string, data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writeFile(name, bytes.NewReader(data), int64(len(data)))
+}
+
+// Close writes the index and the layout file into the archive, and finishes
+// the archive. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	ctx := context.Background()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// tagged manifests come first as in the index saved by oci.Store
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{},
+	}
+	tagged := make(map[digest.Digest]bool)
+	for _, tag := range w.tags {
+		desc, err := w.manifests.Resolve(ctx, tag)
+		if err != nil {
+			return err
+		}
+		annotations := make(map[string]string, len(desc.Annotations)+1)
+		maps.Copy(annotations, desc.Annotations)
+		annotations[ocispec.AnnotationRefName] = tag
+		desc.Annotations = annotations
+		index.Manifests = append(index.Manifests, desc)
+		tagged[desc.Digest] = true
+	}
+	for _, desc := range w.pushed {
+		if !tagged[desc.Digest] {
+			index.Manifests = append(index.Manifests, desc)
+		}
+	}
+	indexJSON, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index file: %w", err)
+	}
+	if err := w.writeFile(ocispec.ImageIndexFile, bytes.NewReader(indexJSON), int64(len(indexJSON))); err != nil {
+		return err
+	}
+	layoutJSON, err := json.Marshal(ocispec.ImageLayout{Version: ocispec.ImageLayoutVersion})
+	if err != nil {
+		return fmt.Errorf("failed to marshal OCI layout file: %w", err)
+	}
+	if err := w.writeFile(ocispec.ImageLayoutFile, bytes.NewReader(layoutJSON), int64(len(layoutJSON))); err != nil {
+		return err
+	}
+	return w.tw.Close()
+}
+
+// writeFile writes the regular file of size read from r into the archive at
+// name, preceded by its parent directories if not written yet.
+func (w *Writer) writeFile(name string, r io.Reader, size int64) error {
+	if err := w.writeDir(path.Dir(name)); err != nil {
+		return err
+	}
+	if err := w.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0644,
+		ModTime:  w.modTime,
+	}); err != nil {
+		return fmt.Errorf("failed to write %s into the tar archive: %w", name, err)
+	}
+	if _, err := io.Copy(w.tw, r); err != nil {
+		return fmt.Errorf("failed to write %s into the tar archive: %w", name, err)
+	}
+	return nil
+}
+
+// writeDir writes the directory at name and its parent directories into the
+// archive if not written yet.
+func (w *Writer) writeDir(name string) error {
+	if name == "." || w.dirs[name] {
+		return nil
+	}
+	if err := w.writeDir(path.Dir(name)); err != nil {
+		return err
+	}
+	if err := w.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeDir,
+		Name:     name + "/",
+		Mode:     0755,
+		ModTime:  w.modTime,
+	}); err != nil {
+		return fmt.Errorf("failed to write %s into the tar archive: %w", name, err)
+	}
+	w.dirs[name] = true
+	return nil
+}
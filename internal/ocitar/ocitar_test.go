@@ -0,0 +1,137 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocitar
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func TestWriter(t *testing.T) {
+	ctx := context.Background()
+	src := memory.New()
+	layer := content.NewDescriptorFromBytes("application/vnd.test.layer", []byte("hello"))
+	if err := src.Push(ctx, layer, bytes.NewReader([]byte("hello"))); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	referrer, err := oras.PackManifest(ctx, src, oras.PackManifestVersion1_1, "application/vnd.test.sig", oras.PackManifestOptions{
+		Subject: &manifest,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := oras.CopyGraph(ctx, src, w, manifest, oras.DefaultCopyGraphOptions); err != nil {
+		t.Fatalf("CopyGraph() error = %v", err)
+	}
+	if err := oras.CopyGraph(ctx, src, w, referrer, oras.DefaultCopyGraphOptions); err != nil {
+		t.Fatalf("CopyGraph() error = %v", err)
+	}
+	if err := w.Tag(ctx, manifest, "v1"); err != nil {
+		t.Fatalf("Writer.Tag() error = %v", err)
+	}
+	if err := w.Push(ctx, layer, bytes.NewReader([]byte("hello"))); !errors.Is(err, errdef.ErrAlreadyExists) {
+		t.Errorf("Writer.Push() error = %v, want %v", err, errdef.ErrAlreadyExists)
+	}
+	if _, err := w.Fetch(ctx, layer); !errors.Is(err, errdef.ErrNotFound) {
+		t.Errorf("Writer.Fetch() error = %v, want %v", err, errdef.ErrNotFound)
+	}
+	predecessors, err := w.Predecessors(ctx, manifest)
+	if err != nil {
+		t.Fatalf("Writer.Predecessors() error = %v", err)
+	}
+	if len(predecessors) != 1 || predecessors[0].Digest != referrer.Digest {
+		t.Errorf("Writer.Predecessors() = %v, want %v", predecessors, referrer)
+	}
+	if err := w.WriteFile("backup.json", []byte("{}")); err != nil {
+		t.Fatalf("Writer.WriteFile() error = %v", err)
+	}
+	if got := len(w.Blobs()); got != 4 {
+		t.Errorf("len(Writer.Blobs()) = %d, want 4", got)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Writer.Close() error = %v", err)
+	}
+
+	// the archive is a valid OCI image layout
+	path := filepath.Join(t.TempDir(), "layout.tar")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := oci.NewFromTar(ctx, path)
+	if err != nil {
+		t.Fatalf("oci.NewFromTar() error = %v", err)
+	}
+	got, err := store.Resolve(ctx, "v1")
+	if err != nil {
+		t.Fatalf("Store.Resolve() error = %v", err)
+	}
+	if got.Digest != manifest.Digest {
+		t.Errorf("Store.Resolve() = %v, want %v", got.Digest, manifest.Digest)
+	}
+	data, err := content.FetchAll(ctx, store, layer)
+	if err != nil {
+		t.Fatalf("FetchAll() error = %v", err)
+	}
+	if !reflect.DeepEqual(data, []byte("hello")) {
+		t.Errorf("FetchAll() = %q, want %q", data, "hello")
+	}
+	referrers, err := store.Predecessors(ctx, manifest)
+	if err != nil {
+		t.Fatalf("Store.Predecessors() error = %v", err)
+	}
+	if len(referrers) != 1 || referrers[0].Digest != referrer.Digest {
+		t.Errorf("Store.Predecessors() = %v, want %v", referrers, referrer)
+	}
+}
+
+func TestWriter_Push_invalidContent(t *testing.T) {
+	ctx := context.Background()
+	desc := ocispec.Descriptor{
+		MediaType: "application/vnd.test.layer",
+		Digest:    digest.FromString("hello"),
+		Size:      5,
+	}
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.Push(ctx, desc, bytes.NewReader([]byte("world"))); !errors.Is(err, content.ErrMismatchedDigest) {
+		t.Errorf("Writer.Push() error = %v, want %v", err, content.ErrMismatchedDigest)
+	}
+	if exists, _ := w.Exists(ctx, desc); exists {
+		t.Error("Writer.Exists() = true, want false")
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package platform selects manifests by platform.
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras/internal/descriptor"
+	"oras.land/oras/internal/docker"
+)
+
+// Wildcard matches any value of the OS, architecture or variant of a platform.
+const Wildcard = "*"
+
+// Parse parses a platform in the form of os[/arch[/variant]][:os_version].
+// The architecture defaults to the one of the host if not specified.
+func Parse(s string) (*ocispec.Platform, error) {
+	var p ocispec.Platform
+	platformStr, osVersion, _ := strings.Cut(s, ":")
+	p.OSVersion = osVersion
+	parts := strings.Split(platformStr, "/")
+	switch len(parts) {
+	case 3:
+		p.Variant = parts[2]
+		fallthrough
+	case 2:
+		p.Architecture = parts[1]
+	case 1:
+		p.Architecture = runtime.GOARCH
+	default:
+		return nil, fmt.Errorf("failed to parse platform %q: expected format os[/arch[/variant]]", s)
+	}
+	p.OS = parts[0]
+	if p.OS == "" {
+		return nil, fmt.Errorf("invalid platform: OS cannot be empty")
+	}
+	if p.Architecture == "" {
+		return nil, fmt.Errorf("invalid platform: Architecture cannot be empty")
+	}
+	return &p, nil
+}
+
+// Match reports whether got satisfies want. The OS and architecture must be
+// equal, while the variant must be equal only if specified in want, and got
+// must have all the OS features in want. The OS version of want, if
+// specified, must be equal to or a prefix of the one of got, so that the
+// Windows version 10.0.17763 matches the build 10.0.17763.5458. The OS,
+// architecture and variant of want can be Wildcard to match any value.
+func Match(got *ocispec.Platform, want *ocispec.Platform) bool {
+	if got == nil || want == nil {
+		return false
+	}
+	if !matchField(got.OS, want.OS) || !matchField(got.Architecture, want.Architecture) {
+		return false
+	}
+	if want.Variant != "" && !matchField(got.Variant, want.Variant) {
+		return false
+	}
+	if want.OSVersion != "" && !matchOSVersion(got.OSVersion, want.OSVersion) {
+		return false
+	}
+	for _, feature := range want.OSFeatures {
+		if !slices.Contains(got.OSFeatures, feature) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchField reports whether the platform field got satisfies want.
+func matchField(got, want string) bool {
+	return want == Wildcard || got == want
+}
+
+// matchOSVersion reports whether the OS version got is want or one of its
+// builds.
+func matchOSVersion(got, want string) bool {
+	return got == want || strings.HasPrefix(got, want+".")
+}
+
+// HasWildcard reports whether p has Wildcard in its OS, architecture or
+// variant.
+func HasWildcard(p *ocispec.Platform) bool {
+	return p != nil && (p.OS == Wildcard || p.Architecture == Wildcard || p.Variant == Wildcard)
+}
+
+// String returns p in the form of os/arch[/variant][:os_version].
+func String(p *ocispec.Platform) string {
+	parts := []string{p.OS, p.Architecture}
+	if p.Variant != "" {
+		parts = append(parts, p.Variant)
+	}
+	s := strings.Join(parts, "/")
+	if p.OSVersion != "" {
+		s += ":" + p.OSVersion
+	}
+	return s
+}
+
+// MatchAny reports whether got satisfies any of wants.
+func MatchAny(got *ocispec.Platform, wants []ocispec.Platform) bool {
+	for i := range wants {
+		if Match(got, &wants[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Select returns the manifests with platforms satisfying any of wants.
+func Select(manifests []ocispec.Descriptor, wants []ocispec.Platform) []ocispec.Descriptor {
+	var selected []ocispec.Descriptor
+	for _, manifest := range manifests {
+		if MatchAny(manifest.Platform, wants) {
+			selected = append(selected, manifest)
+		}
+	}
+	return selected
+}
+
+// NoMatchError is returned by SelectManifest if no manifest satisfies the
+// requested platform.
+type NoMatchError struct {
+	// Root is the reference or the digest of the index or the manifest
+	// selected from.
+	Root string
+	// Want is the requested platform.
+	Want ocispec.Platform
+	// Available are the platforms of the manifests in the index, or the
+	// platform of the manifest.
+	Available []ocispec.Platform
+}
+
+// Error returns the error message.
+func (e *NoMatchError) Error() string {
+	return fmt.Sprintf("no manifest for the platform %s found in %s", String(&e.Want), e.Root)
+}
+
+// Closest returns the available platforms closest to the requested one: the
+// ones of the same OS and architecture if any, which differ in the variant or
+// the OS version, or else the ones of the same architecture or OS.
+func (e *NoMatchError) Closest() []ocispec.Platform {
+	for _, same := range []func(p ocispec.Platform) bool{
+		func(p ocispec.Platform) bool {
+			return matchField(p.OS, e.Want.OS) && matchField(p.Architecture, e.Want.Architecture)
+		},
+		func(p ocispec.Platform) bool { return matchField(p.Architecture, e.Want.Architecture) },
+		func(p ocispec.Platform) bool { return matchField(p.OS, e.Want.OS) },
+	} {
+		var closest []ocispec.Platform
+		for _, p := range e.Available {
+			if same(p) {
+				closest = append(closest, p)
+			}
+		}
+		if len(closest) > 0 {
+			return closest
+		}
+	}
+	return nil
+}
+
+// SelectManifest selects the manifest for the platform want from root. If root
+// is an image index, the first manifest in it satisfying want is selected; if
+// root is an image manifest, it is selected if the platform in its config
+// satisfies want. A *NoMatchError is returned if no manifest satisfies want.
+func SelectManifest(ctx context.Context, fetcher content.Fetcher, root ocispec.Descriptor, want *ocispec.Platform) (ocispec.Descriptor, error) {
+	switch {
+	case descriptor.IsIndex(root):
+		fetched, err := content.FetchAll(ctx, fetcher, root)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		var index ocispec.Index
+		if err := json.Unmarshal(fetched, &index); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		var available []ocispec.Platform
+		for _, manifest := range index.Manifests {
+			if Match(manifest.Platform, want) {
+				return manifest, nil
+			}
+			if manifest.Platform != nil && manifest.Platform.OS != "unknown" {
+				available = append(available, *manifest.Platform)
+			}
+		}
+		return ocispec.Descriptor{}, &NoMatchError{Root: root.Digest.String(), Want: *want, Available: available}
+	case descriptor.IsImageManifest(root):
+		got, err := FromManifest(ctx, fetcher, root)
+		if err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		if !Match(got, want) {
+			return ocispec.Descriptor{}, &NoMatchError{Root: root.Digest.String(), Want: *want, Available: []ocispec.Platform{*got}}
+		}
+		return root, nil
+	default:
+		return ocispec.Descriptor{}, fmt.Errorf("%s: %s is not for a platform", root.Digest, root.MediaType)
+	}
+}
+
+// FromManifest returns the platform in the config of the image manifest desc.
+func FromManifest(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) (*ocispec.Platform, error) {
+	fetched, err := content.FetchAll(ctx, fetcher, desc)
+	if err != nil {
+		return nil, err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(fetched, &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Config.MediaType != ocispec.MediaTypeImageConfig && manifest.Config.MediaType != docker.MediaTypeConfig {
+		return nil, fmt.Errorf("%s: the config %s does not have a platform", desc.Digest, manifest.Config.MediaType)
+	}
+	fetched, err = content.FetchAll(ctx, fetcher, manifest.Config)
+	if err != nil {
+		return nil, err
+	}
+	var p ocispec.Platform
+	if err := json.Unmarshal(fetched, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Resolve resolves reference in target and selects the manifest for the
+// platform want, if any, from it with SelectManifest.
+func Resolve(ctx context.Context, target oras.ReadOnlyTarget, reference string, want *ocispec.Platform) (ocispec.Descriptor, error) {
+	root, err := oras.Resolve(ctx, target, reference, oras.DefaultResolveOptions)
+	if err != nil || want == nil {
+		return root, err
+	}
+	return SelectManifest(ctx, target, root, want)
+}
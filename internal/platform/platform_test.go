@@ -0,0 +1,255 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"runtime"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    *ocispec.Platform
+		wantErr bool
+	}{
+		{s: "linux", want: &ocispec.Platform{OS: "linux", Architecture: runtime.GOARCH}},
+		{s: "linux/arm/v7", want: &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+		{s: "windows/amd64:10.0.17763", want: &ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763"}},
+		{s: "linux/*", want: &ocispec.Platform{OS: "linux", Architecture: Wildcard}},
+		{s: "/amd64", wantErr: true},
+		{s: "linux/", wantErr: true},
+		{s: "linux/arm/v7/extra", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := Parse(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		got  *ocispec.Platform
+		want *ocispec.Platform
+		ok   bool
+	}{
+		{
+			name: "equal",
+			got:  &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+			want: &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+			ok:   true,
+		},
+		{
+			name: "different architecture",
+			got:  &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+			want: &ocispec.Platform{OS: "linux", Architecture: "arm64"},
+		},
+		{
+			name: "variant not specified",
+			got:  &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+			want: &ocispec.Platform{OS: "linux", Architecture: "arm"},
+			ok:   true,
+		},
+		{
+			name: "different variant",
+			got:  &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+			want: &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v6"},
+		},
+		{
+			name: "different OS version",
+			got:  &ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763"},
+			want: &ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.20348"},
+		},
+		{
+			name: "OS version build",
+			got:  &ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763.5458"},
+			want: &ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763"},
+			ok:   true,
+		},
+		{
+			name: "OS version prefix of another version",
+			got:  &ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.177630"},
+			want: &ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763"},
+		},
+		{
+			name: "missing OS feature",
+			got:  &ocispec.Platform{OS: "windows", Architecture: "amd64"},
+			want: &ocispec.Platform{OS: "windows", Architecture: "amd64", OSFeatures: []string{"win32k"}},
+		},
+		{
+			name: "wildcard architecture",
+			got:  &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"},
+			want: &ocispec.Platform{OS: "linux", Architecture: Wildcard},
+			ok:   true,
+		},
+		{
+			name: "wildcard architecture with different OS",
+			got:  &ocispec.Platform{OS: "windows", Architecture: "amd64"},
+			want: &ocispec.Platform{OS: "linux", Architecture: Wildcard},
+		},
+		{
+			name: "wildcard variant",
+			got:  &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v6"},
+			want: &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: Wildcard},
+			ok:   true,
+		},
+		{
+			name: "no platform",
+			want: &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(tt.got, tt.want); got != tt.ok {
+				t.Errorf("Match() = %v, want %v", got, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	manifests := []ocispec.Descriptor{
+		{Digest: "sha256:amd64", Platform: &ocispec.Platform{OS: "linux", Architecture: "amd64"}},
+		{Digest: "sha256:arm64", Platform: &ocispec.Platform{OS: "linux", Architecture: "arm64"}},
+		{Digest: "sha256:s390x", Platform: &ocispec.Platform{OS: "linux", Architecture: "s390x"}},
+		{Digest: "sha256:attestation"},
+	}
+	wants := []ocispec.Platform{
+		{OS: "linux", Architecture: "arm64"},
+		{OS: "linux", Architecture: "amd64"},
+	}
+	want := manifests[:2]
+	if got := Select(manifests, wants); !reflect.DeepEqual(got, want) {
+		t.Errorf("Select() = %v, want %v", got, want)
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		platform ocispec.Platform
+		want     string
+	}{
+		{platform: ocispec.Platform{OS: "linux", Architecture: "amd64"}, want: "linux/amd64"},
+		{platform: ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, want: "linux/arm/v7"},
+		{platform: ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.20348"}, want: "windows/amd64:10.0.20348"},
+	}
+	for _, tt := range tests {
+		if got := String(&tt.platform); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestNoMatchError_Closest(t *testing.T) {
+	available := []ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm", Variant: "v6"},
+		{OS: "windows", Architecture: "arm64"},
+	}
+	tests := []struct {
+		name string
+		want ocispec.Platform
+		ok   []ocispec.Platform
+	}{
+		{name: "different variant", want: ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}, ok: available[1:2]},
+		{name: "same architecture", want: ocispec.Platform{OS: "linux", Architecture: "arm64"}, ok: available[2:]},
+		{name: "same OS", want: ocispec.Platform{OS: "windows", Architecture: "amd64"}, ok: available[:1]},
+		{name: "none", want: ocispec.Platform{OS: "darwin", Architecture: "s390x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &NoMatchError{Want: tt.want, Available: available}
+			if got := e.Closest(); !reflect.DeepEqual(got, tt.ok) {
+				t.Errorf("NoMatchError.Closest() = %v, want %v", got, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSelectManifest(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	push := func(mediaType string, v any) ocispec.Descriptor {
+		t.Helper()
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		desc := content.NewDescriptorFromBytes(mediaType, b)
+		if err := store.Push(ctx, desc, bytes.NewReader(b)); err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	config := push(ocispec.MediaTypeImageConfig, ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763.5458"})
+	manifest := push(ocispec.MediaTypeImageManifest, ocispec.Manifest{MediaType: ocispec.MediaTypeImageManifest, Config: config, Layers: []ocispec.Descriptor{}})
+	armDesc := manifest
+	armDesc.Platform = &ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}
+	index := push(ocispec.MediaTypeImageIndex, ocispec.Index{
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{armDesc},
+	})
+
+	tests := []struct {
+		name      string
+		root      ocispec.Descriptor
+		want      ocispec.Platform
+		available []ocispec.Platform
+	}{
+		{name: "index", root: index, want: ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+		{name: "index without match", root: index, want: ocispec.Platform{OS: "linux", Architecture: "arm", Variant: "v6"}, available: []ocispec.Platform{*armDesc.Platform}},
+		{name: "manifest", root: manifest, want: ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763"}},
+		{name: "manifest without match", root: manifest, want: ocispec.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.20348"}, available: []ocispec.Platform{{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763.5458"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectManifest(ctx, store, tt.root, &tt.want)
+			if tt.available != nil {
+				var noMatch *NoMatchError
+				if !errors.As(err, &noMatch) {
+					t.Fatalf("SelectManifest() error = %v, want NoMatchError", err)
+				}
+				if !reflect.DeepEqual(noMatch.Available, tt.available) {
+					t.Errorf("NoMatchError.Available = %v, want %v", noMatch.Available, tt.available)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelectManifest() error = %v", err)
+			}
+			if got.Digest != manifest.Digest {
+				t.Errorf("SelectManifest() = %s, want %s", got.Digest, manifest.Digest)
+			}
+		})
+	}
+}
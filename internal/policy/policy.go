@@ -0,0 +1,240 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy evaluates the Rego policies allowing or denying oras
+// operations. The policies are not evaluated in process but by the external
+// OPA command line tool, which must be installed to use policies.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Query is the Rego query evaluated for the deny messages of an operation.
+// Policies define the rule in the package "oras", such as
+//
+//	package oras
+//
+//	deny contains msg if {
+//		input.command == "push"
+//		not input.annotations["org.opencontainers.image.source"]
+//		msg := "pushed artifacts must have a source annotation"
+//	}
+const Query = "data.oras.deny"
+
+// OPACommand is the OPA command line tool evaluating the policies.
+var OPACommand = "opa"
+
+// ErrOPANotFound is returned when the OPA command line tool is not installed.
+var ErrOPANotFound = errors.New("the OPA command line tool is not found")
+
+// DownloadTimeout is the recommended timeout of the client downloading a
+// remote policy.
+const DownloadTimeout = 30 * time.Second
+
+// Input is the input of the policy describing an operation.
+type Input struct {
+	// Command is the oras command, such as "push", "pull" or "cp".
+	Command string `json:"command"`
+	// Reference is the reference of the artifact.
+	Reference string `json:"reference"`
+	// Destination is the reference of the copy destination.
+	Destination string `json:"destination,omitempty"`
+	// Descriptor is the descriptor of the artifact.
+	Descriptor ocispec.Descriptor `json:"descriptor"`
+	// Manifest is the manifest of the artifact.
+	Manifest json.RawMessage `json:"manifest,omitempty"`
+	// Annotations are the annotations of the manifest.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Referrers summarizes the referrers of the artifact.
+	Referrers []ReferrerSummary `json:"referrers"`
+}
+
+// ReferrerSummary is the number of referrers of an artifact type.
+type ReferrerSummary struct {
+	// ArtifactType is the artifact type of the referrers.
+	ArtifactType string `json:"artifactType"`
+	// Count is the number of referrers.
+	Count int `json:"count"`
+}
+
+// Summarize summarizes referrers by artifact type, in the order of first
+// appearance.
+func Summarize(referrers []ocispec.Descriptor) []ReferrerSummary {
+	summaries := []ReferrerSummary{}
+	index := make(map[string]int)
+	for _, r := range referrers {
+		i, ok := index[r.ArtifactType]
+		if !ok {
+			i = len(summaries)
+			index[r.ArtifactType] = i
+			summaries = append(summaries, ReferrerSummary{ArtifactType: r.ArtifactType})
+		}
+		summaries[i].Count++
+	}
+	return summaries
+}
+
+// DeniedError is returned when the policy denies an operation.
+type DeniedError struct {
+	// Messages are the reasons of the denial.
+	Messages []string
+}
+
+// Error returns the reasons of the denial.
+func (e *DeniedError) Error() string {
+	return "denied by policy: " + strings.Join(e.Messages, "; ")
+}
+
+// Policy is a Rego policy.
+type Policy struct {
+	// Location is the file path or URL of the policy.
+	Location string
+	module   []byte
+}
+
+// Load loads the policy from location, which is a file path or an HTTPS URL
+// downloaded with client.
+func Load(ctx context.Context, location string, client *http.Client) (*Policy, error) {
+	var module []byte
+	var err error
+	switch {
+	case strings.HasPrefix(location, "http://"):
+		return nil, fmt.Errorf("failed to load policy %s: only HTTPS URLs are allowed", location)
+	case strings.HasPrefix(location, "https://"):
+		module, err = download(ctx, location, client)
+	default:
+		module, err = os.ReadFile(location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load policy %s: %w", location, err)
+	}
+	return &Policy{Location: location, module: module}, nil
+}
+
+// download downloads the content at url.
+func download(ctx context.Context, url string, client *http.Client) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// LookOPA checks whether OPACommand is installed. ErrOPANotFound is returned
+// if not.
+func LookOPA() error {
+	if _, err := exec.LookPath(OPACommand); err != nil {
+		return fmt.Errorf("%w: %v", ErrOPANotFound, err)
+	}
+	return nil
+}
+
+// Evaluate evaluates the policy on input. A *DeniedError is returned if the
+// policy denies the operation.
+func (p *Policy) Evaluate(ctx context.Context, input *Input) error {
+	if err := LookOPA(); err != nil {
+		return fmt.Errorf("failed to evaluate policy %s: %w", p.Location, err)
+	}
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "oras-policy-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	modulePath := filepath.Join(dir, "policy.rego")
+	if err := os.WriteFile(modulePath, p.module, 0600); err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, OPACommand, "eval", "--format", "json", "--stdin-input", "--data", modulePath, Query)
+	cmd.Stdin = bytes.NewReader(inputJSON)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to evaluate policy %s: %w: %s", p.Location, err, strings.TrimSpace(stderr.String()))
+	}
+	messages, err := parseResult(output)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate policy %s: %w", p.Location, err)
+	}
+	if len(messages) > 0 {
+		return &DeniedError{Messages: messages}
+	}
+	return nil
+}
+
+// parseResult returns the deny messages in the JSON output of "opa eval".
+// The deny rule is either a set of messages or a boolean. An undefined rule
+// is an error rather than an empty set, so that a policy with a mistyped
+// package or rule name does not allow every operation.
+func parseResult(output []byte) ([]string, error) {
+	var result struct {
+		Result []struct {
+			Expressions []struct {
+				Value json.RawMessage `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("invalid evaluation result: %w", err)
+	}
+	if len(result.Result) == 0 {
+		return nil, fmt.Errorf("%s is undefined, the policy must define the rule deny in the package oras", Query)
+	}
+	var messages []string
+	for _, r := range result.Result {
+		for _, expr := range r.Expressions {
+			var denied bool
+			if err := json.Unmarshal(expr.Value, &denied); err == nil {
+				if denied {
+					messages = append(messages, "the operation is denied")
+				}
+				continue
+			}
+			var values []string
+			if err := json.Unmarshal(expr.Value, &values); err != nil {
+				return nil, fmt.Errorf("%s must be a set of strings or a boolean: %w", Query, err)
+			}
+			messages = append(messages, values...)
+		}
+	}
+	return messages, nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestSummarize(t *testing.T) {
+	referrers := []ocispec.Descriptor{
+		{ArtifactType: "application/vnd.example.sbom"},
+		{ArtifactType: "application/vnd.example.signature"},
+		{ArtifactType: "application/vnd.example.sbom"},
+	}
+	want := []ReferrerSummary{
+		{ArtifactType: "application/vnd.example.sbom", Count: 2},
+		{ArtifactType: "application/vnd.example.signature", Count: 1},
+	}
+	if got := Summarize(referrers); !reflect.DeepEqual(got, want) {
+		t.Errorf("Summarize() = %v, want %v", got, want)
+	}
+	if got := Summarize(nil); got == nil || len(got) != 0 {
+		t.Errorf("Summarize(nil) = %#v, want empty", got)
+	}
+}
+
+func Test_parseResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "messages",
+			output: `{"result":[{"expressions":[{"value":["no source","not signed"],"text":"data.oras.deny"}]}]}`,
+			want:   []string{"no source", "not signed"},
+		},
+		{
+			name:   "no messages",
+			output: `{"result":[{"expressions":[{"value":[],"text":"data.oras.deny"}]}]}`,
+		},
+		{
+			name:    "undefined",
+			output:  `{}`,
+			wantErr: true,
+		},
+		{
+			name:   "denied",
+			output: `{"result":[{"expressions":[{"value":true}]}]}`,
+			want:   []string{"the operation is denied"},
+		},
+		{
+			name:   "allowed",
+			output: `{"result":[{"expressions":[{"value":false}]}]}`,
+		},
+		{
+			name:    "invalid value",
+			output:  `{"result":[{"expressions":[{"value":{"msg":"no"}}]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid output",
+			output:  `not json`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseResult([]byte(tt.output))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	module := "package oras\n"
+	path := filepath.Join(t.TempDir(), "policy.rego")
+	if err := os.WriteFile(path, []byte(module), 0600); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/policy.rego" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(module))
+	}))
+	defer ts.Close()
+
+	for _, location := range []string{path, ts.URL + "/policy.rego"} {
+		p, err := Load(context.Background(), location, ts.Client())
+		if err != nil {
+			t.Fatalf("Load(%q) error = %v", location, err)
+		}
+		if string(p.module) != module {
+			t.Errorf("Load(%q) module = %q, want %q", location, p.module, module)
+		}
+	}
+	insecureURL := strings.Replace(ts.URL, "https://", "http://", 1) + "/policy.rego"
+	for _, location := range []string{filepath.Join(t.TempDir(), "missing.rego"), ts.URL + "/missing.rego", insecureURL} {
+		if _, err := Load(context.Background(), location, ts.Client()); err == nil {
+			t.Errorf("Load(%q) error = nil, want error", location)
+		}
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake OPA command is a shell script")
+	}
+	// the fake OPA command denies the operations of the command "push"
+	opa := filepath.Join(t.TempDir(), "opa")
+	script := `#!/bin/sh
+if grep -q '"command":"push"' ; then
+  echo '{"result":[{"expressions":[{"value":["push is not allowed"]}]}]}'
+else
+  echo '{"result":[{"expressions":[{"value":[]}]}]}'
+fi
+`
+	if err := os.WriteFile(opa, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	defer func(command string) { OPACommand = command }(OPACommand)
+	OPACommand = opa
+
+	p := &Policy{Location: "policy.rego", module: []byte("package oras\n")}
+	if err := p.Evaluate(context.Background(), &Input{Command: "pull"}); err != nil {
+		t.Errorf("Evaluate() error = %v, want nil", err)
+	}
+	err := p.Evaluate(context.Background(), &Input{Command: "push"})
+	var deniedErr *DeniedError
+	if !errors.As(err, &deniedErr) {
+		t.Fatalf("Evaluate() error = %v, want %T", err, deniedErr)
+	}
+	if want := []string{"push is not allowed"}; !reflect.DeepEqual(deniedErr.Messages, want) {
+		t.Errorf("DeniedError.Messages = %v, want %v", deniedErr.Messages, want)
+	}
+
+	OPACommand = filepath.Join(t.TempDir(), "missing")
+	if err := p.Evaluate(context.Background(), &Input{Command: "pull"}); !errors.Is(err, ErrOPANotFound) {
+		t.Errorf("Evaluate() error = %v, want %v", err, ErrOPANotFound)
+	}
+}
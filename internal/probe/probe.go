@@ -0,0 +1,285 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package probe detects the capabilities of a registry implementing the OCI
+// distribution specification.
+package probe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// Capability is the support state of a registry feature.
+type Capability string
+
+// capability states
+const (
+	Supported   Capability = "supported"
+	Unsupported Capability = "unsupported"
+	Unknown     Capability = "unknown"
+	// Likely is reported when a probe only hints at the support, e.g. blob
+	// mounting succeeded within a single repository.
+	Likely Capability = "likely"
+)
+
+// headerAPIVersion is the response header reporting the registry API version.
+const headerAPIVersion = "Docker-Distribution-API-Version"
+
+// Result is the outcome of probing a registry.
+type Result struct {
+	// APIVersion is the API version reported by the registry, if any.
+	APIVersion string
+	// Latencies are the round trip times of the API version checks.
+	Latencies []time.Duration
+	// Referrers reports whether the Referrers API is supported.
+	Referrers Capability
+	// BlobMount reports whether cross-repository blob mounting is supported.
+	// A successful mount is only reported as Likely since it is probed within
+	// the repository.
+	BlobMount Capability
+	// RangeRequests reports whether blobs can be fetched partially.
+	RangeRequests Capability
+	// ChunkedUpload reports whether blobs can be uploaded in chunks.
+	ChunkedUpload Capability
+}
+
+// Prober probes a registry.
+type Prober struct {
+	// Client is the HTTP client used to send requests.
+	Client remote.Client
+	// PlainHTTP signals the registry is accessed over plain HTTP.
+	PlainHTTP bool
+	// Host is the host of the registry, e.g. "localhost:5000".
+	Host string
+	// Repository is the repository used to probe repository scoped
+	// capabilities. Repository scoped capabilities are reported as Unknown
+	// if empty.
+	Repository string
+	// Blob is an existing blob in Repository used to probe range requests and
+	// blob mounting. Those capabilities are reported as Unknown if nil.
+	Blob *ocispec.Descriptor
+	// Write enables probes requiring push permission. Upload sessions created
+	// by the probes are cancelled.
+	Write bool
+	// Count is the number of API version checks sent to measure latency.
+	// At least one check is sent.
+	Count int
+}
+
+// Probe probes the registry.
+func (p *Prober) Probe(ctx context.Context) (*Result, error) {
+	result := &Result{
+		Referrers:     Unknown,
+		BlobMount:     Unknown,
+		RangeRequests: Unknown,
+		ChunkedUpload: Unknown,
+	}
+	count := max(p.Count, 1)
+	for range count {
+		start := time.Now()
+		resp, err := p.do(ctx, http.MethodGet, p.url("/v2/"), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		result.Latencies = append(result.Latencies, time.Since(start))
+		drain(resp)
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s %s: unexpected status %s", resp.Request.Method, resp.Request.URL, resp.Status)
+		}
+		result.APIVersion = resp.Header.Get(headerAPIVersion)
+	}
+	if p.Repository == "" {
+		return result, nil
+	}
+
+	var err error
+	if result.Referrers, err = p.probeReferrers(ctx); err != nil {
+		return nil, err
+	}
+	if p.Blob != nil {
+		if result.RangeRequests, err = p.probeRange(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if !p.Write {
+		return result, nil
+	}
+	if result.ChunkedUpload, err = p.probeChunkedUpload(ctx); err != nil {
+		return nil, err
+	}
+	if p.Blob != nil {
+		if result.BlobMount, err = p.probeMount(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// probeReferrers queries the referrers of a non-existing manifest. A
+// conforming registry responds with an empty image index.
+func (p *Prober) probeReferrers(ctx context.Context) (Capability, error) {
+	u := p.url("/v2/" + p.Repository + "/referrers/" + ocispec.DescriptorEmptyJSON.Digest.String())
+	resp, err := p.do(ctx, http.MethodGet, u, nil, http.Header{"Accept": {ocispec.MediaTypeImageIndex}})
+	if err != nil {
+		return Unknown, err
+	}
+	drain(resp)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		mediaType := strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+		if mediaType == ocispec.MediaTypeImageIndex {
+			return Supported, nil
+		}
+		return Unsupported, nil
+	case http.StatusNotFound:
+		return Unsupported, nil
+	}
+	return Unknown, nil
+}
+
+// probeRange fetches the first byte of the blob.
+func (p *Prober) probeRange(ctx context.Context) (Capability, error) {
+	u := p.url("/v2/" + p.Repository + "/blobs/" + p.Blob.Digest.String())
+	resp, err := p.do(ctx, http.MethodGet, u, nil, http.Header{"Range": {"bytes=0-0"}})
+	if err != nil {
+		return Unknown, err
+	}
+	drain(resp)
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return Supported, nil
+	case http.StatusOK, http.StatusRequestedRangeNotSatisfiable:
+		return Unsupported, nil
+	}
+	return Unknown, nil
+}
+
+// probeChunkedUpload starts an upload session, sends a single byte chunk and
+// cancels the session.
+func (p *Prober) probeChunkedUpload(ctx context.Context) (Capability, error) {
+	location, status, err := p.startUpload(ctx, "")
+	if err != nil {
+		return Unknown, err
+	}
+	if status != http.StatusAccepted {
+		return Unknown, nil
+	}
+	defer p.cancelUpload(ctx, location)
+
+	header := http.Header{
+		"Content-Type":  {"application/octet-stream"},
+		"Content-Range": {"0-0"},
+	}
+	resp, err := p.do(ctx, http.MethodPatch, location, strings.NewReader("0"), header)
+	if err != nil {
+		return Unknown, err
+	}
+	drain(resp)
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+		return Supported, nil
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented, http.StatusRequestedRangeNotSatisfiable:
+		return Unsupported, nil
+	}
+	return Unknown, nil
+}
+
+// probeMount mounts the blob from the repository to itself. Registries not
+// supporting mounting fall back to starting an upload session. A successful
+// mount is only a hint, since registries may treat mounting within a
+// repository as a no-op while refusing mounts across repositories, and mounting
+// into another repository would leave the blob behind there.
+func (p *Prober) probeMount(ctx context.Context) (Capability, error) {
+	query := url.Values{
+		"mount": {p.Blob.Digest.String()},
+		"from":  {p.Repository},
+	}
+	location, status, err := p.startUpload(ctx, query.Encode())
+	if err != nil {
+		return Unknown, err
+	}
+	switch status {
+	case http.StatusCreated:
+		return Likely, nil
+	case http.StatusAccepted:
+		p.cancelUpload(ctx, location)
+		return Unsupported, nil
+	}
+	return Unknown, nil
+}
+
+// startUpload starts a blob upload and returns the upload location and the
+// response status code.
+func (p *Prober) startUpload(ctx context.Context, rawQuery string) (string, int, error) {
+	u := p.url("/v2/" + p.Repository + "/blobs/uploads/")
+	if rawQuery != "" {
+		u += "?" + rawQuery
+	}
+	resp, err := p.do(ctx, http.MethodPost, u, nil, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	drain(resp)
+	location, err := resp.Location()
+	if err != nil {
+		return "", resp.StatusCode, nil
+	}
+	return location.String(), resp.StatusCode, nil
+}
+
+// cancelUpload cancels an upload session. Failures are ignored since
+// registries eventually expire abandoned sessions.
+func (p *Prober) cancelUpload(ctx context.Context, location string) {
+	if location == "" {
+		return
+	}
+	if resp, err := p.do(ctx, http.MethodDelete, location, nil, nil); err == nil {
+		drain(resp)
+	}
+}
+
+func (p *Prober) url(path string) string {
+	scheme := "https"
+	if p.PlainHTTP {
+		scheme = "http"
+	}
+	return scheme + "://" + p.Host + path
+}
+
+func (p *Prober) do(ctx context.Context, method, url string, body io.Reader, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	return p.Client.Do(req)
+}
+
+// drain discards the remaining response body and closes it.
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,157 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// newRegistry returns a fake registry either supporting all the probed
+// capabilities or none of them.
+func newRegistry(t *testing.T, full bool) (*httptest.Server, *[]string) {
+	var cancelled []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/":
+			w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v2/test/referrers/"):
+			if !full {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+			_, _ = w.Write([]byte(`{"schemaVersion":2,"manifests":[]}`))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v2/test/blobs/"):
+			if full && r.Header.Get("Range") == "bytes=0-0" {
+				w.WriteHeader(http.StatusPartialContent)
+			}
+			_, _ = w.Write([]byte("{}"))
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/test/blobs/uploads/":
+			if full && r.URL.Query().Get("mount") != "" {
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+			w.Header().Set("Location", "/v2/test/blobs/uploads/session")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPatch && r.URL.Path == "/v2/test/blobs/uploads/session":
+			if !full {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/test/blobs/uploads/session":
+			cancelled = append(cancelled, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &cancelled
+}
+
+func TestProber_Probe(t *testing.T) {
+	blob := ocispec.DescriptorEmptyJSON
+	tests := []struct {
+		name          string
+		full          bool
+		repository    string
+		blob          *ocispec.Descriptor
+		write         bool
+		want          Result
+		wantCancelled int
+	}{
+		{
+			name: "registry only",
+			full: true,
+			want: Result{Referrers: Unknown, BlobMount: Unknown, RangeRequests: Unknown, ChunkedUpload: Unknown},
+		},
+		{
+			name:       "read only",
+			full:       true,
+			repository: "test",
+			blob:       &blob,
+			want:       Result{Referrers: Supported, BlobMount: Unknown, RangeRequests: Supported, ChunkedUpload: Unknown},
+		},
+		{
+			name:          "all supported",
+			full:          true,
+			repository:    "test",
+			blob:          &blob,
+			write:         true,
+			want:          Result{Referrers: Supported, BlobMount: Likely, RangeRequests: Supported, ChunkedUpload: Supported},
+			wantCancelled: 1,
+		},
+		{
+			name:          "none supported",
+			repository:    "test",
+			blob:          &blob,
+			write:         true,
+			want:          Result{Referrers: Unsupported, BlobMount: Unsupported, RangeRequests: Unsupported, ChunkedUpload: Unsupported},
+			wantCancelled: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, cancelled := newRegistry(t, tt.full)
+			u, _ := url.Parse(ts.URL)
+			p := &Prober{
+				Client:     ts.Client(),
+				PlainHTTP:  true,
+				Host:       u.Host,
+				Repository: tt.repository,
+				Blob:       tt.blob,
+				Write:      tt.write,
+				Count:      2,
+			}
+			got, err := p.Probe(context.Background())
+			if err != nil {
+				t.Fatalf("Probe() error = %v", err)
+			}
+			if got.APIVersion != "registry/2.0" {
+				t.Errorf("Probe() APIVersion = %q, want %q", got.APIVersion, "registry/2.0")
+			}
+			if len(got.Latencies) != 2 {
+				t.Errorf("Probe() got %d latencies, want 2", len(got.Latencies))
+			}
+			if got.Referrers != tt.want.Referrers || got.BlobMount != tt.want.BlobMount ||
+				got.RangeRequests != tt.want.RangeRequests || got.ChunkedUpload != tt.want.ChunkedUpload {
+				t.Errorf("Probe() = %+v, want %+v", got, tt.want)
+			}
+			if len(*cancelled) != tt.wantCancelled {
+				t.Errorf("Probe() cancelled %d upload sessions, want %d", len(*cancelled), tt.wantCancelled)
+			}
+		})
+	}
+}
+
+func TestProber_Probe_notRegistry(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	p := &Prober{Client: ts.Client(), PlainHTTP: true, Host: u.Host}
+	if _, err := p.Probe(context.Background()); err == nil {
+		t.Fatal("Probe() error = nil, want error")
+	}
+}
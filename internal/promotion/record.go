@@ -0,0 +1,132 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package promotion provides the record of the artifacts promoted by
+// "oras cp".
+package promotion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Record lists the artifacts copied in a promotion, pinned by digests.
+type Record struct {
+	// Tool is the name of the tool creating the record.
+	Tool string `json:"tool"`
+	// Version is the version of the tool creating the record.
+	Version string `json:"version"`
+	// Created is the time the record is created.
+	Created time.Time `json:"created"`
+	// Entries are the copied artifacts in the order of copying.
+	Entries []Entry `json:"entries"`
+
+	lock sync.Mutex
+}
+
+// Entry describes an artifact copied from a source to a destination.
+type Entry struct {
+	// Source is the source repository in the form of <registry>/<repository>,
+	// or the path of the source OCI image layout.
+	Source string `json:"source"`
+	// SourceType is the type of the source, either "registry" or
+	// "oci-layout".
+	SourceType string `json:"sourceType"`
+	// SourceReference is the tag or digest of the artifact specified in the
+	// source.
+	SourceReference string `json:"sourceReference,omitempty"`
+	// SourceDigest is the digest of the copied manifest in the source.
+	SourceDigest string `json:"sourceDigest"`
+	// Platforms are the platforms the copied index is pruned to, if any.
+	Platforms []ocispec.Platform `json:"platforms,omitempty"`
+	// Destination is the destination repository in the form of
+	// <registry>/<repository>, or the path of the destination OCI image
+	// layout.
+	Destination string `json:"destination"`
+	// DestinationType is the type of the destination, either "registry" or
+	// "oci-layout".
+	DestinationType string `json:"destinationType"`
+	// DestinationTags are the tags of the artifact in the destination.
+	DestinationTags []string `json:"destinationTags,omitempty"`
+	// DestinationDigest is the digest of the manifest in the destination,
+	// which differs from SourceDigest if the copied index is pruned to
+	// certain platforms.
+	DestinationDigest string `json:"destinationDigest"`
+	// MediaType is the media type of the manifest in the destination.
+	MediaType string `json:"mediaType"`
+	// Size is the size of the manifest in the destination.
+	Size int64 `json:"size"`
+	// Copied is the time the artifact is copied.
+	Copied time.Time `json:"copied"`
+}
+
+// SetDestination sets the destination manifest of the entry to desc.
+func (e *Entry) SetDestination(desc ocispec.Descriptor) {
+	e.DestinationDigest = desc.Digest.String()
+	e.MediaType = desc.MediaType
+	e.Size = desc.Size
+}
+
+// Add appends an entry to the record. It is safe for concurrent use.
+func (r *Record) Add(entry Entry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.Entries = append(r.Entries, entry)
+}
+
+// WriteFile writes the record to the file at path.
+func (r *Record) WriteFile(path string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Read reads a record from r.
+func Read(r io.Reader) (*Record, error) {
+	var record Record
+	if err := json.NewDecoder(r).Decode(&record); err != nil {
+		return nil, fmt.Errorf("invalid promotion record: %w", err)
+	}
+	if len(record.Entries) == 0 {
+		return nil, errors.New("invalid promotion record: no entries")
+	}
+	for i, entry := range record.Entries {
+		if entry.Source == "" || entry.Destination == "" || entry.SourceDigest == "" || entry.DestinationDigest == "" {
+			return nil, fmt.Errorf("invalid promotion record: entry %d misses the source, destination or their digests", i)
+		}
+	}
+	return &record, nil
+}
+
+// ReadFile reads the record in the file at path.
+func ReadFile(path string) (*Record, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	return Read(fp)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promotion
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestRecord_WriteFile(t *testing.T) {
+	copied := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	entry := Entry{
+		Source:          "localhost:5000/staging",
+		SourceType:      "registry",
+		SourceReference: "v1",
+		SourceDigest:    "sha256:2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834",
+		Destination:     "localhost:6000/prod",
+		DestinationType: "registry",
+		DestinationTags: []string{"v1", "latest"},
+		Copied:          copied,
+	}
+	entry.SetDestination(ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    "sha256:2e0e0fe1fb3edbcdddad941c90d2b51e25a6bcd593e82545441a216de7bfa834",
+		Size:      553,
+	})
+	record := &Record{Tool: "oras", Version: "1.3.0", Created: copied}
+	record.Add(entry)
+
+	path := filepath.Join(t.TempDir(), "promote.json")
+	if err := record.WriteFile(path); err != nil {
+		t.Fatalf("Record.WriteFile() error = %v", err)
+	}
+	got, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Entries, record.Entries) {
+		t.Errorf("ReadFile() entries = %v, want %v", got.Entries, record.Entries)
+	}
+	if got.Tool != "oras" || got.Version != "1.3.0" || !got.Created.Equal(copied) {
+		t.Errorf("ReadFile() = %+v, want tool, version and created time of %+v", got, record)
+	}
+}
+
+func TestRead_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "malformed", content: `{"entries":`},
+		{name: "no entries", content: `{"tool":"oras","entries":[]}`},
+		{name: "no digest", content: `{"entries":[{"source":"localhost:5000/a","destination":"localhost:5000/b","sourceDigest":"sha256:a"}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Read(strings.NewReader(tt.content)); err == nil {
+				t.Errorf("Read() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// MediaTypeInToto is the media type of an in-toto statement.
+	MediaTypeInToto = "application/vnd.in-toto+json"
+	// StatementType is the type of an in-toto v1 statement.
+	StatementType = "https://in-toto.io/Statement/v1"
+	// PredicateTypeSLSA is the predicate type of SLSA provenance v1.
+	PredicateTypeSLSA = "https://slsa.dev/provenance/v1"
+	// BuildType is the SLSA build type recorded by oras.
+	BuildType = "https://oras.land/provenance/v1"
+)
+
+// Info contains build provenance of an artifact.
+type Info struct {
+	// Source is the URL of the source code repository.
+	Source string
+	// Revision is the source control revision, e.g. a git commit.
+	Revision string
+	// Created is the creation time of the artifact.
+	Created time.Time
+	// BuilderID identifies the build platform, e.g. a CI system.
+	BuilderID string
+	// InvocationID identifies the build run, e.g. a CI job URL.
+	InvocationID string
+}
+
+// GitFunc runs a git command with the given arguments and returns its
+// trimmed output.
+type GitFunc func(ctx context.Context, args ...string) (string, error)
+
+// Git runs the git binary found in PATH.
+func Git(ctx context.Context, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Detect collects provenance from the CI environment variables of GitHub
+// Actions and GitLab CI, falling back to the local git repository for the
+// fields not provided by the environment.
+// If SOURCE_DATE_EPOCH is set, it is used as the creation time instead of now.
+func Detect(ctx context.Context, getenv func(string) string, git GitFunc, now time.Time) Info {
+	info := Info{
+		Created: now.UTC(),
+	}
+	switch {
+	case getenv("GITHUB_ACTIONS") == "true":
+		server := strings.TrimSuffix(getenv("GITHUB_SERVER_URL"), "/")
+		if repo := getenv("GITHUB_REPOSITORY"); server != "" && repo != "" {
+			info.Source = server + "/" + repo
+			if runID := getenv("GITHUB_RUN_ID"); runID != "" {
+				info.InvocationID = info.Source + "/actions/runs/" + runID
+			}
+		}
+		info.Revision = getenv("GITHUB_SHA")
+		info.BuilderID = "https://github.com/actions/runner"
+	case getenv("GITLAB_CI") == "true":
+		info.Source = getenv("CI_PROJECT_URL")
+		info.Revision = getenv("CI_COMMIT_SHA")
+		info.InvocationID = getenv("CI_JOB_URL")
+		if server := getenv("CI_SERVER_URL"); server != "" {
+			info.BuilderID = strings.TrimSuffix(server, "/") + "/gitlab-runner"
+		}
+	}
+	if git != nil {
+		if info.Source == "" {
+			if remote, err := git(ctx, "config", "--get", "remote.origin.url"); err == nil {
+				info.Source = sanitizeURL(remote)
+			}
+		}
+		if info.Revision == "" {
+			if rev, err := git(ctx, "rev-parse", "HEAD"); err == nil {
+				info.Revision = rev
+			}
+		}
+	}
+	if epoch := getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if sec, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			info.Created = time.Unix(sec, 0).UTC()
+		}
+	}
+	return info
+}
+
+// sanitizeURL removes user credentials from a repository URL.
+func sanitizeURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		// scp-like git address such as git@github.com:org/repo.git
+		return raw
+	}
+	u.User = nil
+	return u.String()
+}
+
+// Annotations returns the standard OCI annotations describing the provenance.
+// Empty fields are omitted.
+func (info Info) Annotations() map[string]string {
+	annotations := make(map[string]string)
+	if info.Source != "" {
+		annotations[ocispec.AnnotationSource] = info.Source
+	}
+	if info.Revision != "" {
+		annotations[ocispec.AnnotationRevision] = info.Revision
+	}
+	if !info.Created.IsZero() {
+		annotations[ocispec.AnnotationCreated] = info.Created.Format(time.RFC3339)
+	}
+	return annotations
+}
+
+type statement struct {
+	Type          string             `json:"_type"`
+	Subject       []statementSubject `json:"subject"`
+	PredicateType string             `json:"predicateType"`
+	Predicate     predicate          `json:"predicate"`
+}
+
+type statementSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type predicate struct {
+	BuildDefinition buildDefinition `json:"buildDefinition"`
+	RunDetails      runDetails      `json:"runDetails"`
+}
+
+type buildDefinition struct {
+	BuildType          string            `json:"buildType"`
+	ExternalParameters map[string]string `json:"externalParameters"`
+}
+
+type runDetails struct {
+	Builder  builder  `json:"builder"`
+	Metadata metadata `json:"metadata"`
+}
+
+type builder struct {
+	ID string `json:"id"`
+}
+
+type metadata struct {
+	InvocationID string `json:"invocationId,omitempty"`
+	StartedOn    string `json:"startedOn,omitempty"`
+}
+
+// Statement generates a minimal in-toto statement with a SLSA provenance v1
+// predicate for the subject named name.
+func (info Info) Statement(name string, subject ocispec.Descriptor) ([]byte, error) {
+	params := make(map[string]string)
+	if info.Source != "" {
+		params["source"] = info.Source
+	}
+	if info.Revision != "" {
+		params["revision"] = info.Revision
+	}
+	builderID := info.BuilderID
+	if builderID == "" {
+		builderID = "https://oras.land/local"
+	}
+	s := statement{
+		Type: StatementType,
+		Subject: []statementSubject{{
+			Name: name,
+			Digest: map[string]string{
+				subject.Digest.Algorithm().String(): subject.Digest.Encoded(),
+			},
+		}},
+		PredicateType: PredicateTypeSLSA,
+		Predicate: predicate{
+			BuildDefinition: buildDefinition{
+				BuildType:          BuildType,
+				ExternalParameters: params,
+			},
+			RunDetails: runDetails{
+				Builder: builder{ID: builderID},
+				Metadata: metadata{
+					InvocationID: info.InvocationID,
+				},
+			},
+		},
+	}
+	if !info.Created.IsZero() {
+		s.Predicate.RunDetails.Metadata.StartedOn = info.Created.Format(time.RFC3339)
+	}
+	return json.Marshal(s)
+}
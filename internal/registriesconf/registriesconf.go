@@ -0,0 +1,252 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registriesconf reads the registries configuration shared by
+// container tools such as podman and skopeo, see
+// https://github.com/containers/image/blob/main/docs/containers-registries.conf.5.md
+package registriesconf
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// EnvPath is the environment variable overriding the path of registries.conf.
+const EnvPath = "CONTAINERS_REGISTRIES_CONF"
+
+// SystemPath is the path of the system wide registries.conf.
+const SystemPath = "/etc/containers/registries.conf"
+
+// Mirror is a mirror of a registry.
+type Mirror struct {
+	// Location is the location of the mirror, e.g. "mirror.example.com/library".
+	Location string `toml:"location"`
+	// Insecure allows connections to the mirror without TLS verification.
+	Insecure bool `toml:"insecure"`
+}
+
+// Registry is a [[registry]] entry.
+type Registry struct {
+	// Prefix selects the references the entry applies to. It is either a
+	// host[:port][/namespace...] prefix or a wildcard such as *.example.com.
+	Prefix string `toml:"prefix"`
+	// Location is the actual location of the references matching Prefix.
+	Location string `toml:"location"`
+	// Insecure allows connections to the registry without TLS verification.
+	Insecure bool `toml:"insecure"`
+	// Blocked forbids access to the references matching Prefix.
+	Blocked bool `toml:"blocked"`
+	// MirrorByDigestOnly restricts the mirrors to pulls by digest.
+	MirrorByDigestOnly bool `toml:"mirror-by-digest-only"`
+	// Mirrors are tried in order before Location when pulling.
+	Mirrors []Mirror `toml:"mirror"`
+}
+
+// Config is a registries.conf configuration.
+type Config struct {
+	Registries []Registry
+}
+
+// file is the content of a registries.conf file or drop-in. Keys not used by
+// oras, e.g. unqualified-search-registries, are ignored.
+type file struct {
+	// Registry are the [[registry]] entries of the v2 format.
+	Registry []Registry `toml:"registry"`
+	// Legacy are the [registries.insecure] and [registries.block] tables of
+	// the v1 format.
+	Legacy struct {
+		Insecure struct {
+			Registries []string `toml:"registries"`
+		} `toml:"insecure"`
+		Block struct {
+			Registries []string `toml:"registries"`
+		} `toml:"block"`
+	} `toml:"registries"`
+}
+
+// Path returns the path of the registries.conf to use: $CONTAINERS_REGISTRIES_CONF
+// if set, otherwise the per-user file if it exists, otherwise the system wide
+// file.
+func Path() string {
+	if p := os.Getenv(EnvPath); p != "" {
+		return p
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		p := filepath.Join(dir, "containers", "registries.conf")
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return SystemPath
+}
+
+// Load loads the registries.conf at path, followed by the drop-in *.conf
+// files in the path + ".d" directory, e.g. /etc/containers/registries.conf.d,
+// in lexical order. A [[registry]] entry of a drop-in replaces the entry with
+// the same prefix loaded before. Missing files are ignored.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	if err := cfg.load(path); err != nil {
+		return nil, err
+	}
+	dropIns, err := filepath.Glob(filepath.Join(path+".d", "*.conf"))
+	if err != nil {
+		return nil, err
+	}
+	// Glob returns the matches in lexical order
+	for _, dropIn := range dropIns {
+		if err := cfg.load(dropIn); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// load merges the registries.conf file or drop-in at path into c.
+func (c *Config) load(path string) error {
+	var f file
+	if _, err := toml.DecodeFile(path, &f); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := c.merge(&f); err != nil {
+		return fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return nil
+}
+
+// merge validates the entries of f and merges them into c, replacing the
+// entries with the same prefix. Both the v2 [[registry]] format and the
+// legacy v1 [registries.insecure] and [registries.block] tables are merged.
+func (c *Config) merge(f *file) error {
+	for _, reg := range f.Registry {
+		for _, mirror := range reg.Mirrors {
+			if mirror.Location == "" {
+				return fmt.Errorf("mirror of registry %q has no location", reg.Prefix)
+			}
+		}
+		if reg.Prefix == "" {
+			reg.Prefix = reg.Location
+		}
+		if reg.Prefix == "" {
+			return errors.New("registry entry has neither prefix nor location")
+		}
+		if strings.HasPrefix(reg.Prefix, "*.") && reg.Location != "" {
+			return fmt.Errorf("registry %q: location is not allowed with a wildcard prefix", reg.Prefix)
+		}
+		if reg.Location == "" && !strings.HasPrefix(reg.Prefix, "*.") {
+			reg.Location = reg.Prefix
+		}
+		*c.entry(reg.Prefix) = reg
+	}
+	for _, host := range f.Legacy.Insecure.Registries {
+		c.entry(host).Insecure = true
+	}
+	for _, host := range f.Legacy.Block.Registries {
+		c.entry(host).Blocked = true
+	}
+	return nil
+}
+
+// entry returns the entry with prefix, adding one if not found.
+func (c *Config) entry(prefix string) *Registry {
+	for i := range c.Registries {
+		if c.Registries[i].Prefix == prefix {
+			return &c.Registries[i]
+		}
+	}
+	c.Registries = append(c.Registries, Registry{Prefix: prefix, Location: prefix})
+	return &c.Registries[len(c.Registries)-1]
+}
+
+// Find returns the entry matching repository, e.g. "docker.io/library/alpine".
+// The entry with the longest matching prefix wins, and wildcard prefixes are
+// only considered if no other prefix matches. Nil is returned if no entry
+// matches.
+func (c *Config) Find(repository string) *Registry {
+	if c == nil {
+		return nil
+	}
+	var found *Registry
+	for i := range c.Registries {
+		reg := &c.Registries[i]
+		if !reg.matches(repository) {
+			continue
+		}
+		if found == nil || reg.specificity() > found.specificity() {
+			found = reg
+		}
+	}
+	return found
+}
+
+// Insecure reports whether TLS verification is disabled for host as a
+// registry location or a mirror location.
+func (c *Config) Insecure(host string) bool {
+	if c == nil {
+		return false
+	}
+	for _, reg := range c.Registries {
+		if reg.Insecure && (locationHost(reg.Location) == host || reg.Location == "" && reg.matches(host)) {
+			return true
+		}
+		for _, m := range reg.Mirrors {
+			if m.Insecure && locationHost(m.Location) == host {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matches reports whether repository matches the prefix of reg.
+func (reg *Registry) matches(repository string) bool {
+	if wildcard, ok := strings.CutPrefix(reg.Prefix, "*."); ok {
+		host := locationHost(repository)
+		return strings.HasSuffix(host, "."+wildcard)
+	}
+	return repository == reg.Prefix || strings.HasPrefix(repository, reg.Prefix+"/")
+}
+
+// specificity ranks prefixes so that longer ones win over shorter ones and
+// non-wildcard prefixes over wildcard prefixes.
+func (reg *Registry) specificity() int {
+	if strings.HasPrefix(reg.Prefix, "*.") {
+		return len(reg.Prefix) - 1<<16
+	}
+	return len(reg.Prefix)
+}
+
+// Rewrite rewrites repository, which must match reg, to location.
+func (reg *Registry) Rewrite(repository, location string) string {
+	if location == "" || strings.HasPrefix(reg.Prefix, "*.") {
+		return repository
+	}
+	return location + strings.TrimPrefix(repository, reg.Prefix)
+}
+
+// locationHost returns the host of a location such as example.com/namespace.
+func locationHost(location string) string {
+	host, _, _ := strings.Cut(location, "/")
+	return host
+}
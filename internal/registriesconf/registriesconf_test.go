@@ -0,0 +1,279 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registriesconf
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testConf = `
+# unqualified search is not used by oras
+unqualified-search-registries = [
+  "docker.io", # comment in array
+  "quay.io",
+]
+
+[[registry]]
+prefix = "docker.io"
+location = "docker.io"
+
+[[registry.mirror]]
+location = "mirror.example.com/dockerhub"
+insecure = true
+
+[[registry.mirror]]
+location = 'mirror2.example.com/dockerhub'
+
+[[registry]]
+prefix = "docker.io/library/busybox"
+location = "internal.example.com/busybox"
+mirror-by-digest-only = true
+
+[[registry]]
+location = "blocked.example.com"
+blocked = true
+
+[[registry]]
+prefix = "*.dev.example.com"
+insecure = true
+
+[registries.insecure]
+registries = ["legacy.example.com:5000"]
+
+[registries.block]
+registries = ['docker.io/evil']
+`
+
+func loadTestConf(t *testing.T) *Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "registries.conf")
+	if err := os.WriteFile(path, []byte(testConf), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return cfg
+}
+
+func TestLoad(t *testing.T) {
+	cfg := loadTestConf(t)
+	want := []Registry{
+		{
+			Prefix:   "docker.io",
+			Location: "docker.io",
+			Mirrors: []Mirror{
+				{Location: "mirror.example.com/dockerhub", Insecure: true},
+				{Location: "mirror2.example.com/dockerhub"},
+			},
+		},
+		{
+			Prefix:             "docker.io/library/busybox",
+			Location:           "internal.example.com/busybox",
+			MirrorByDigestOnly: true,
+		},
+		{Prefix: "blocked.example.com", Location: "blocked.example.com", Blocked: true},
+		{Prefix: "*.dev.example.com", Insecure: true},
+	}
+	got := cfg.Registries[:len(want)]
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() registries = %+v, want %+v", got, want)
+	}
+	legacy := cfg.Registries[len(want):]
+	if len(legacy) != 2 {
+		t.Fatalf("Load() legacy registries = %+v, want 2 entries", legacy)
+	}
+}
+
+func TestLoad_inlineTables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registries.conf")
+	content := `[[registry]]
+prefix = "docker.io"
+location = "docker.io"
+mirror = [{ location = "mirror.example.com/dockerhub", insecure = true }, { location = "mirror2.example.com/dockerhub" }]
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := []Registry{{
+		Prefix:   "docker.io",
+		Location: "docker.io",
+		Mirrors: []Mirror{
+			{Location: "mirror.example.com/dockerhub", Insecure: true},
+			{Location: "mirror2.example.com/dockerhub"},
+		},
+	}}
+	if !reflect.DeepEqual(cfg.Registries, want) {
+		t.Errorf("Load() = %+v, want %+v", cfg.Registries, want)
+	}
+}
+
+func TestLoad_dropIns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registries.conf")
+	files := map[string]string{
+		path: `[[registry]]
+prefix = "docker.io"
+location = "docker.io"
+
+[[registry]]
+location = "quay.io"
+`,
+		// loaded after 10-mirror.conf, replacing its docker.io entry
+		filepath.Join(path+".d", "20-override.conf"): `[[registry]]
+prefix = "docker.io"
+location = "internal.example.com/dockerhub"
+`,
+		filepath.Join(path+".d", "10-mirror.conf"): `[[registry]]
+prefix = "docker.io"
+location = "docker.io"
+mirror = [{ location = "mirror.example.com" }]
+
+[registries.block]
+registries = ["evil.example.com"]
+`,
+		// not a drop-in
+		filepath.Join(path+".d", "README"): "not toml",
+	}
+	if err := os.Mkdir(path+".d", 0700); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := []Registry{
+		{Prefix: "docker.io", Location: "internal.example.com/dockerhub"},
+		{Prefix: "quay.io", Location: "quay.io"},
+		{Prefix: "evil.example.com", Location: "evil.example.com", Blocked: true},
+	}
+	if !reflect.DeepEqual(cfg.Registries, want) {
+		t.Errorf("Load() = %+v, want %+v", cfg.Registries, want)
+	}
+}
+
+func TestLoad_notExist(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "registries.conf"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Registries) != 0 {
+		t.Errorf("Load() = %+v, want empty", cfg)
+	}
+}
+
+func TestLoad_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "bad header", content: "[[registry]\n"},
+		{name: "missing value", content: "[[registry]]\nprefix =\n"},
+		{name: "unterminated array", content: "a = [\n\"b\"\n"},
+		{name: "wrong type", content: "[[registry]]\nprefix = true\n"},
+		{name: "no location", content: "[[registry]]\ninsecure = true\n"},
+		{name: "wildcard with location", content: "[[registry]]\nprefix = \"*.example.com\"\nlocation = \"example.com\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "registries.conf")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil {
+				t.Error("Load() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestConfig_Find(t *testing.T) {
+	cfg := loadTestConf(t)
+	tests := []struct {
+		repository string
+		wantPrefix string
+	}{
+		{repository: "docker.io/library/alpine", wantPrefix: "docker.io"},
+		{repository: "docker.io/library/busybox", wantPrefix: "docker.io/library/busybox"},
+		{repository: "docker.io/library/busyboxes", wantPrefix: "docker.io"},
+		{repository: "docker.io/evil/app", wantPrefix: "docker.io/evil"},
+		{repository: "registry.dev.example.com/app", wantPrefix: "*.dev.example.com"},
+		{repository: "quay.io/app"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repository, func(t *testing.T) {
+			got := cfg.Find(tt.repository)
+			var gotPrefix string
+			if got != nil {
+				gotPrefix = got.Prefix
+			}
+			if gotPrefix != tt.wantPrefix {
+				t.Errorf("Config.Find() prefix = %q, want %q", gotPrefix, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestRegistry_Rewrite(t *testing.T) {
+	cfg := loadTestConf(t)
+	reg := cfg.Find("docker.io/library/busybox")
+	if got, want := reg.Rewrite("docker.io/library/busybox", reg.Location), "internal.example.com/busybox"; got != want {
+		t.Errorf("Registry.Rewrite() = %q, want %q", got, want)
+	}
+	reg = cfg.Find("docker.io/library/alpine")
+	if got, want := reg.Rewrite("docker.io/library/alpine", reg.Mirrors[0].Location), "mirror.example.com/dockerhub/library/alpine"; got != want {
+		t.Errorf("Registry.Rewrite() = %q, want %q", got, want)
+	}
+}
+
+func TestConfig_Insecure(t *testing.T) {
+	cfg := loadTestConf(t)
+	for host, want := range map[string]bool{
+		"mirror.example.com":      true,
+		"mirror2.example.com":     false,
+		"docker.io":               false,
+		"legacy.example.com:5000": true,
+		"a.dev.example.com":       true,
+	} {
+		if got := cfg.Insecure(host); got != want {
+			t.Errorf("Config.Insecure(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestPath_env(t *testing.T) {
+	t.Setenv(EnvPath, "/tmp/registries.conf")
+	if got := Path(); got != "/tmp/registries.conf" {
+		t.Errorf("Path() = %q, want %q", got, "/tmp/registries.conf")
+	}
+	t.Setenv(EnvPath, "")
+	if got := Path(); !strings.HasSuffix(got, "registries.conf") {
+		t.Errorf("Path() = %q, want a registries.conf path", got)
+	}
+}
@@ -0,0 +1,386 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report records what a command did on registries into a local JSON
+// report, which is never sent anywhere.
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+// Kinds of the descriptors in a report.
+const (
+	KindManifest = "manifest"
+	KindBlob     = "blob"
+)
+
+// headerDigest is the header carrying the digest of the manifests and blobs
+// returned or stored by registries.
+const headerDigest = "Docker-Content-Digest"
+
+// Report is the report of a command.
+type Report struct {
+	Command     string       `json:"command"`
+	Args        []string     `json:"args,omitempty"`
+	TraceID     string       `json:"traceId,omitempty"`
+	StartedAt   time.Time    `json:"startedAt"`
+	FinishedAt  time.Time    `json:"finishedAt"`
+	Duration    float64      `json:"durationMs"`
+	Succeeded   bool         `json:"succeeded"`
+	Error       string       `json:"error,omitempty"`
+	References  []string     `json:"references"`
+	Descriptors []Descriptor `json:"descriptors"`
+	Requests    Requests     `json:"requests"`
+}
+
+// Descriptor is the transfer record of a manifest or a blob.
+type Descriptor struct {
+	Repository string  `json:"repository"`
+	Digest     string  `json:"digest"`
+	Kind       string  `json:"kind"`
+	Requests   int     `json:"requests"`
+	Uploaded   int64   `json:"bytesUploaded"`
+	Downloaded int64   `json:"bytesDownloaded"`
+	Duration   float64 `json:"durationMs"`
+}
+
+// Requests is the summary of the HTTP requests sent to registries.
+type Requests struct {
+	Total       int            `json:"total"`
+	Retries     int            `json:"retries"`
+	Errors      int            `json:"errors"`
+	BytesSent   int64          `json:"bytesSent"`
+	BytesRecv   int64          `json:"bytesReceived"`
+	StatusCodes map[string]int `json:"statusCodes"`
+}
+
+// descriptorKey identifies a descriptor in a repository.
+type descriptorKey struct {
+	repository string
+	digest     string
+}
+
+// Recorder records the registry interactions of a command.
+type Recorder struct {
+	path      string
+	command   string
+	args      []string
+	traceID   string
+	startedAt time.Time
+
+	lock        sync.Mutex
+	references  map[string]struct{}
+	descriptors map[descriptorKey]*Descriptor
+	requests    Requests
+	// uploads maps the path of the open upload sessions to the bytes sent
+	// to them.
+	uploads map[string]int64
+}
+
+// NewRecorder returns a recorder of a command starting now.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		startedAt:   time.Now(),
+		references:  make(map[string]struct{}),
+		descriptors: make(map[descriptorKey]*Descriptor),
+		requests: Requests{
+			StatusCodes: make(map[string]int),
+		},
+		uploads: make(map[string]int64),
+	}
+}
+
+type contextKey struct{}
+
+// WithRecorder returns a context carrying r.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the recorder carried by ctx, or nil if none.
+func FromContext(ctx context.Context) *Recorder {
+	if ctx == nil {
+		return nil
+	}
+	r, _ := ctx.Value(contextKey{}).(*Recorder)
+	return r
+}
+
+// Enable makes r write the report of the command with the arguments args to
+// the file at path when the command finishes.
+func (r *Recorder) Enable(path, command string, args []string, traceID string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.path = path
+	r.command = command
+	r.args = args
+	r.traceID = traceID
+}
+
+// Enabled reports whether the report is written.
+func (r *Recorder) Enabled() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.path != ""
+}
+
+// Report returns the report of the command finishing with cmdErr.
+func (r *Recorder) Report(cmdErr error) Report {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	finishedAt := time.Now()
+	report := Report{
+		Command:     r.command,
+		Args:        r.args,
+		TraceID:     r.traceID,
+		StartedAt:   r.startedAt.UTC(),
+		FinishedAt:  finishedAt.UTC(),
+		Duration:    milliseconds(finishedAt.Sub(r.startedAt)),
+		Succeeded:   cmdErr == nil,
+		References:  make([]string, 0, len(r.references)),
+		Descriptors: make([]Descriptor, 0, len(r.descriptors)),
+		Requests:    r.requests,
+	}
+	if cmdErr != nil {
+		report.Error = cmdErr.Error()
+	}
+	for reference := range r.references {
+		report.References = append(report.References, reference)
+	}
+	sort.Strings(report.References)
+	for _, desc := range r.descriptors {
+		report.Descriptors = append(report.Descriptors, *desc)
+	}
+	sort.Slice(report.Descriptors, func(i, j int) bool {
+		a, b := report.Descriptors[i], report.Descriptors[j]
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		return a.Digest < b.Digest
+	})
+	report.Requests.StatusCodes = make(map[string]int, len(r.requests.StatusCodes))
+	for code, count := range r.requests.StatusCodes {
+		report.Requests.StatusCodes[code] = count
+	}
+	return report
+}
+
+// Write writes the report of the command finishing with cmdErr, if enabled.
+func (r *Recorder) Write(cmdErr error) error {
+	if !r.Enabled() {
+		return nil
+	}
+	content, err := json.MarshalIndent(r.Report(cmdErr), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(content, '\n'), 0644)
+}
+
+// RetryPolicy returns a retry policy counting the retries decided by base.
+func (r *Recorder) RetryPolicy(base retry.Policy) retry.Policy {
+	return &retryPolicy{recorder: r, base: base}
+}
+
+// retryPolicy counts the retries decided by the base policy.
+type retryPolicy struct {
+	recorder *Recorder
+	base     retry.Policy
+}
+
+// Retry implements retry.Policy.
+func (p *retryPolicy) Retry(attempt int, resp *http.Response, err error) (time.Duration, error) {
+	duration, err := p.base.Retry(attempt, resp, err)
+	if err == nil && duration >= 0 {
+		p.recorder.lock.Lock()
+		p.recorder.requests.Retries++
+		p.recorder.lock.Unlock()
+	}
+	return duration, err
+}
+
+// Transport returns a transport recording the requests sent through base.
+func (r *Recorder) Transport(base http.RoundTripper) http.RoundTripper {
+	return &transport{recorder: r, base: base}
+}
+
+// transport records the requests and the responses into the report.
+type transport struct {
+	recorder *Recorder
+	base     http.RoundTripper
+}
+
+// RoundTrip sends req and records it along with its response. The bytes
+// received are recorded when the response body is closed.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	var sent int64
+	if req.ContentLength > 0 {
+		sent = req.ContentLength
+	}
+	if err != nil {
+		t.recorder.record(req, nil, sent, 0, time.Since(start))
+		return resp, err
+	}
+	resp.Body = &countingBody{
+		ReadCloser: resp.Body,
+		onClose: func(received int64) {
+			t.recorder.record(req, resp, sent, received, time.Since(start))
+		},
+	}
+	return resp, nil
+}
+
+// countingBody counts the bytes read from a response body.
+type countingBody struct {
+	io.ReadCloser
+	n       int64
+	once    sync.Once
+	onClose func(n int64)
+}
+
+// Read implements io.Reader.
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+// Close implements io.Closer.
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.onClose(b.n)
+	})
+	return err
+}
+
+// record records a request, its response if any, and the bytes transferred.
+func (r *Recorder) record(req *http.Request, resp *http.Response, sent, received int64, duration time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.requests.Total++
+	r.requests.BytesSent += sent
+	r.requests.BytesRecv += received
+	if resp == nil {
+		r.requests.Errors++
+	} else {
+		r.requests.StatusCodes[strconv.Itoa(resp.StatusCode)]++
+	}
+
+	name, kind, reference, ok := parsePath(req.URL.Path)
+	if !ok {
+		return
+	}
+	repository := req.URL.Host + "/" + name
+	succeeded := resp != nil && resp.StatusCode < http.StatusBadRequest
+	var dgst string
+	switch kind {
+	case KindManifest:
+		if strings.Contains(reference, ":") {
+			dgst = reference
+		} else if succeeded {
+			r.references[repository+":"+reference] = struct{}{}
+			dgst = resp.Header.Get(headerDigest)
+		}
+	case KindBlob:
+		dgst = reference
+	case kindUpload:
+		// the bytes of an upload session are counted when it is closed with
+		// the digest of the blob
+		sent += r.uploads[req.URL.Path]
+		delete(r.uploads, req.URL.Path)
+		query := req.URL.Query()
+		if dgst = query.Get("digest"); dgst == "" {
+			// cross-repository mount
+			dgst = query.Get("mount")
+		}
+		if dgst == "" || !succeeded {
+			if succeeded && req.Method != http.MethodDelete {
+				if location, err := resp.Location(); err == nil {
+					r.uploads[location.Path] += sent
+				}
+			}
+			return
+		}
+		kind = KindBlob
+	default:
+		return
+	}
+	if dgst == "" || !succeeded {
+		return
+	}
+	r.references[repository+"@"+dgst] = struct{}{}
+	key := descriptorKey{repository: repository, digest: dgst}
+	desc, ok := r.descriptors[key]
+	if !ok {
+		desc = &Descriptor{
+			Repository: repository,
+			Digest:     dgst,
+			Kind:       kind,
+		}
+		r.descriptors[key] = desc
+	}
+	desc.Requests++
+	desc.Uploaded += sent
+	desc.Downloaded += received
+	desc.Duration += milliseconds(duration)
+}
+
+// kindUpload is the kind of the requests to blob upload sessions.
+const kindUpload = "upload"
+
+// parsePath parses the path of a registry API request in the form of
+// /v2/<name>/manifests/<reference>, /v2/<name>/blobs/<digest> or
+// /v2/<name>/blobs/uploads/[<reference>].
+func parsePath(path string) (name, kind, reference string, ok bool) {
+	path, ok = strings.CutPrefix(path, "/v2/")
+	if !ok {
+		return "", "", "", false
+	}
+	if name, _, ok = strings.Cut(path, "/blobs/uploads"); ok {
+		return name, kindUpload, "", name != ""
+	}
+	for _, kind := range []string{KindManifest, KindBlob} {
+		sep := "/" + kind + "s/"
+		if i := strings.LastIndex(path, sep); i > 0 {
+			reference, err := url.PathUnescape(path[i+len(sep):])
+			if err != nil || reference == "" || strings.Contains(reference, "/") {
+				return "", "", "", false
+			}
+			return path[:i], kind, reference, true
+		}
+	}
+	return "", "", "", false
+}
+
+// milliseconds returns d in milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+func TestRecorder(t *testing.T) {
+	const (
+		blobDigest     = "sha256:aaaa"
+		manifestDigest = "sha256:bbbb"
+	)
+	var attempts int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/test/blobs/uploads/":
+			w.Header().Set("Location", "/v2/test/blobs/uploads/1")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPatch && r.URL.Path == "/v2/test/blobs/uploads/1":
+			w.Header().Set("Location", "/v2/test/blobs/uploads/2")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/test/blobs/uploads/2":
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/test/manifests/v1":
+			w.Header().Set(headerDigest, manifestDigest)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/test/blobs/"+blobDigest:
+			if attempts++; attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = io.WriteString(w, "hello")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	recorder := NewRecorder()
+	recorder.Enable("report.json", "oras test", []string{"arg"}, "trace")
+	client := &http.Client{
+		Transport: recorder.Transport(&retry.Transport{
+			Base: http.DefaultTransport,
+			Policy: func() retry.Policy {
+				return recorder.RetryPolicy(&retry.GenericPolicy{
+					Retryable: retry.DefaultPredicate,
+					Backoff:   func(int, *http.Response) time.Duration { return 0 },
+					MinWait:   0,
+					MaxWait:   0,
+					MaxRetry:  1,
+				})
+			},
+		}),
+	}
+	send := func(method, path, body string) {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(context.Background(), method, ts.URL+path, reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	send(http.MethodPost, "/v2/test/blobs/uploads/", "")
+	send(http.MethodPatch, "/v2/test/blobs/uploads/1", "hel")
+	send(http.MethodPut, "/v2/test/blobs/uploads/2?digest="+blobDigest, "lo")
+	send(http.MethodPut, "/v2/test/manifests/v1", "{}")
+	send(http.MethodGet, "/v2/test/blobs/"+blobDigest, "")
+	send(http.MethodGet, "/v2/test/manifests/v2", "")
+
+	report := recorder.Report(errors.New("failed"))
+	if report.Command != "oras test" || report.TraceID != "trace" || !reflect.DeepEqual(report.Args, []string{"arg"}) {
+		t.Errorf("Report() command = %q %v %q", report.Command, report.Args, report.TraceID)
+	}
+	if report.Succeeded || report.Error != "failed" {
+		t.Errorf("Report() succeeded = %v, error = %q", report.Succeeded, report.Error)
+	}
+	repository := strings.TrimPrefix(ts.URL, "http://") + "/test"
+	wantReferences := []string{
+		repository + ":v1",
+		repository + "@" + blobDigest,
+		repository + "@" + manifestDigest,
+	}
+	if !reflect.DeepEqual(report.References, wantReferences) {
+		t.Errorf("Report() references = %v, want %v", report.References, wantReferences)
+	}
+	if len(report.Descriptors) != 2 {
+		t.Fatalf("Report() descriptors = %v, want 2", report.Descriptors)
+	}
+	blob, manifest := report.Descriptors[0], report.Descriptors[1]
+	if blob.Digest != blobDigest || blob.Kind != KindBlob || blob.Requests != 2 || blob.Uploaded != 5 || blob.Downloaded != 5 {
+		t.Errorf("Report() blob = %+v", blob)
+	}
+	if manifest.Digest != manifestDigest || manifest.Kind != KindManifest || manifest.Requests != 1 || manifest.Uploaded != 2 {
+		t.Errorf("Report() manifest = %+v", manifest)
+	}
+	wantRequests := Requests{
+		Total:     6,
+		Retries:   1,
+		BytesSent: 7,
+		BytesRecv: 5,
+		StatusCodes: map[string]int{
+			"200": 1,
+			"201": 2,
+			"202": 2,
+			"404": 1,
+		},
+	}
+	if !reflect.DeepEqual(report.Requests, wantRequests) {
+		t.Errorf("Report() requests = %+v, want %+v", report.Requests, wantRequests)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path          string
+		wantName      string
+		wantKind      string
+		wantReference string
+		wantOK        bool
+	}{
+		{"/v2/a/b/manifests/v1", "a/b", KindManifest, "v1", true},
+		{"/v2/a/blobs/sha256:aaaa", "a", KindBlob, "sha256:aaaa", true},
+		{"/v2/a/blobs/uploads/", "a", kindUpload, "", true},
+		{"/v2/a/blobs/uploads/123", "a", kindUpload, "", true},
+		{"/v2/a/tags/list", "", "", "", false},
+		{"/v2/", "", "", "", false},
+		{"/token", "", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			name, kind, reference, ok := parsePath(tt.path)
+			if name != tt.wantName || kind != tt.wantKind || reference != tt.wantReference || ok != tt.wantOK {
+				t.Errorf("parsePath() = %q, %q, %q, %v, want %q, %q, %q, %v", name, kind, reference, ok, tt.wantName, tt.wantKind, tt.wantReference, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package restorestate persists the progress of restoring artifacts to a
+// registry so that an interrupted restore can be resumed.
+package restorestate
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	orasio "oras.land/oras/internal/io"
+)
+
+// State is the set of content already restored from an input to a
+// repository, persisted as a log of digests.
+type State struct {
+	path string
+	mu   sync.Mutex
+	done map[digest.Digest]bool
+	file *os.File
+}
+
+// DefaultDir returns the directory of the restore states in the user cache
+// directory.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oras", "restores"), nil
+}
+
+// Key returns the key of the state of restoring input, an OCI image layout or
+// a tar archive or volume set of it, to repository. The key changes if the
+// input is modified.
+func Key(input, repository string) (string, error) {
+	path, err := filepath.Abs(input)
+	if err != nil {
+		return "", err
+	}
+	fi, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// input may refer to a tar archive volume set
+		fi, err = os.Stat(orasio.VolumePath(path, 1))
+	}
+	if err != nil {
+		return "", err
+	}
+	if fi.IsDir() {
+		// the index of an OCI image layout is updated on modification
+		if fi, err = os.Stat(filepath.Join(path, ocispec.ImageIndexFile)); err != nil {
+			return "", err
+		}
+	}
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s\n%s\n%d\n%d", path, repository, fi.Size(), fi.ModTime().UnixNano()))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Open opens the state identified by key in dir, loading the content already
+// restored.
+func Open(dir, key string) (*State, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, key+".log")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	s := &State{
+		path: path,
+		done: make(map[digest.Digest]bool),
+		file: file,
+	}
+	if err := s.load(file); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// load reads the digests logged. Malformed lines, which may be left by an
+// interrupted write, are ignored.
+func (s *State) load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if dgst, err := digest.Parse(scanner.Text()); err == nil {
+			s.done[dgst] = true
+		}
+	}
+	return scanner.Err()
+}
+
+// Count returns the number of content recorded as restored.
+func (s *State) Count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.done)
+}
+
+// Done reports whether the content identified by dgst is restored.
+func (s *State) Done(dgst digest.Digest) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done[dgst]
+}
+
+// Add records the content identified by dgst as restored.
+func (s *State) Add(dgst digest.Digest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done[dgst] {
+		return nil
+	}
+	if _, err := fmt.Fprintln(s.file, dgst); err != nil {
+		return err
+	}
+	s.done[dgst] = true
+	return nil
+}
+
+// Close closes the state, keeping it for resuming.
+func (s *State) Close() error {
+	return s.file.Close()
+}
+
+// Remove closes and removes the state once the restore is complete.
+func (s *State) Remove() error {
+	err := s.file.Close()
+	if removeErr := os.Remove(s.path); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+		err = errors.Join(err, removeErr)
+	}
+	return err
+}
+
+// Target returns a target restoring to target, which records the content
+// pushed to or found in target and reports the content recorded as existing
+// without checking target.
+func (s *State) Target(target oras.GraphTarget) oras.GraphTarget {
+	return &stateTarget{
+		GraphTarget: target,
+		state:       s,
+	}
+}
+
+// stateTarget records the content restored to a GraphTarget in a State.
+type stateTarget struct {
+	oras.GraphTarget
+	state *State
+}
+
+// Exists returns true if the content is recorded as restored, or exists in
+// the target.
+func (t *stateTarget) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	if t.state.Done(target.Digest) {
+		return true, nil
+	}
+	exists, err := t.GraphTarget.Exists(ctx, target)
+	if err != nil || !exists {
+		return exists, err
+	}
+	return true, t.state.Add(target.Digest)
+}
+
+// Push pushes the content and records it as restored.
+func (t *stateTarget) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	if err := t.GraphTarget.Push(ctx, expected, content); err != nil {
+		return err
+	}
+	return t.state.Add(expected.Digest)
+}
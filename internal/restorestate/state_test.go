@@ -0,0 +1,100 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restorestate
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestState(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	input := filepath.Join(t.TempDir(), "backup.tar")
+	if err := os.WriteFile(input, []byte("archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	key, err := Key(input, "localhost:5000/hello")
+	if err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+	if other, err := Key(input, "localhost:5000/world"); err != nil || other == key {
+		t.Fatalf("Key() = %v, %v, want a different key for a different repository", other, err)
+	}
+
+	// restore a blob and find an existing one
+	state, err := Open(dir, key)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	blob := []byte("blob")
+	blobDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, blob)
+	existing := []byte("existing")
+	existingDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, existing)
+	store := memory.New()
+	if err := store.Push(ctx, existingDesc, bytes.NewReader(existing)); err != nil {
+		t.Fatal(err)
+	}
+	target := state.Target(store)
+	if err := target.Push(ctx, blobDesc, bytes.NewReader(blob)); err != nil {
+		t.Fatalf("Target.Push() error = %v", err)
+	}
+	if exists, err := target.Exists(ctx, existingDesc); err != nil || !exists {
+		t.Fatalf("Target.Exists() = %v, %v, want true", exists, err)
+	}
+	if err := state.Close(); err != nil {
+		t.Fatalf("State.Close() error = %v", err)
+	}
+
+	// resume with another target
+	state, err = Open(dir, key)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if got := state.Count(); got != 2 {
+		t.Errorf("State.Count() = %d, want 2", got)
+	}
+	target = state.Target(memory.New())
+	for _, desc := range []ocispec.Descriptor{blobDesc, existingDesc} {
+		if exists, err := target.Exists(ctx, desc); err != nil || !exists {
+			t.Errorf("Target.Exists(%s) = %v, %v, want true", desc.Digest, exists, err)
+		}
+	}
+	missing := content.NewDescriptorFromBytes(ocispec.MediaTypeImageLayer, []byte("missing"))
+	if exists, err := target.Exists(ctx, missing); err != nil || exists {
+		t.Errorf("Target.Exists(%s) = %v, %v, want false", missing.Digest, exists, err)
+	}
+
+	// remove the state on completion
+	if err := state.Remove(); err != nil {
+		t.Fatalf("State.Remove() error = %v", err)
+	}
+	state, err = Open(dir, key)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer state.Close()
+	if got := state.Count(); got != 0 {
+		t.Errorf("State.Count() = %d, want 0", got)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package retention selects the backups to keep by a retention policy.
+package retention
+
+import (
+	"slices"
+	"time"
+)
+
+// Reasons for keeping a backup.
+const (
+	ReasonLast    = "last"
+	ReasonDaily   = "daily"
+	ReasonWeekly  = "weekly"
+	ReasonMonthly = "monthly"
+	ReasonWithin  = "within"
+)
+
+// Policy is a retention policy. A backup is kept if it is kept by any of the
+// rules.
+type Policy struct {
+	// Last is the number of the most recent backups to keep.
+	Last int
+	// Daily is the number of the most recent days to keep the most recent
+	// backup of.
+	Daily int
+	// Weekly is the number of the most recent ISO weeks to keep the most
+	// recent backup of.
+	Weekly int
+	// Monthly is the number of the most recent months to keep the most recent
+	// backup of.
+	Monthly int
+	// Within is the duration before now to keep all the backups created in.
+	Within time.Duration
+}
+
+// IsZero returns true if the policy has no rules.
+func (p Policy) IsZero() bool {
+	return p == Policy{}
+}
+
+// Apply applies the policy to the backups created at times, and returns the
+// reasons for keeping each backup, which are empty for the backups to delete.
+// Days, weeks and months are determined in the location of each time.
+func (p Policy) Apply(times []time.Time, now time.Time) [][]string {
+	order := make([]int, len(times))
+	for i := range order {
+		order[i] = i
+	}
+	// the most recent backups first
+	slices.SortStableFunc(order, func(a, b int) int {
+		return times[b].Compare(times[a])
+	})
+
+	reasons := make([][]string, len(times))
+	keepPeriodic := func(reason string, count int, period func(time.Time) [2]int) {
+		seen := make(map[[2]int]bool)
+		for _, i := range order {
+			if len(seen) == count {
+				return
+			}
+			if key := period(times[i]); !seen[key] {
+				seen[key] = true
+				reasons[i] = append(reasons[i], reason)
+			}
+		}
+	}
+	for n, i := range order {
+		if n < p.Last {
+			reasons[i] = append(reasons[i], ReasonLast)
+		}
+	}
+	keepPeriodic(ReasonDaily, p.Daily, func(t time.Time) [2]int {
+		return [2]int{t.Year(), t.YearDay()}
+	})
+	keepPeriodic(ReasonWeekly, p.Weekly, func(t time.Time) [2]int {
+		year, week := t.ISOWeek()
+		return [2]int{year, week}
+	})
+	keepPeriodic(ReasonMonthly, p.Monthly, func(t time.Time) [2]int {
+		return [2]int{t.Year(), int(t.Month())}
+	})
+	if p.Within > 0 {
+		since := now.Add(-p.Within)
+		for _, i := range order {
+			if times[i].After(since) {
+				reasons[i] = append(reasons[i], ReasonWithin)
+			}
+		}
+	}
+	return reasons
+}
@@ -0,0 +1,86 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPolicy_Apply(t *testing.T) {
+	day := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	// unordered on purpose
+	times := []time.Time{
+		day(time.January, 1, 0),   // Monday, week 1
+		day(time.March, 4, 12),    // Monday, week 10
+		day(time.March, 4, 6),     // Monday, week 10
+		day(time.February, 26, 0), // Monday, week 9
+		day(time.March, 3, 0),     // Sunday, week 9
+		day(time.January, 31, 0),  // Wednesday, week 5
+	}
+	now := day(time.March, 5, 0)
+	tests := []struct {
+		name   string
+		policy Policy
+		want   [][]string
+	}{
+		{
+			name:   "no rules",
+			policy: Policy{},
+			want:   make([][]string, len(times)),
+		},
+		{
+			name:   "last",
+			policy: Policy{Last: 2},
+			want:   [][]string{nil, {ReasonLast}, {ReasonLast}, nil, nil, nil},
+		},
+		{
+			name:   "daily",
+			policy: Policy{Daily: 2},
+			want:   [][]string{nil, {ReasonDaily}, nil, nil, {ReasonDaily}, nil},
+		},
+		{
+			name:   "weekly",
+			policy: Policy{Weekly: 3},
+			want:   [][]string{nil, {ReasonWeekly}, nil, nil, {ReasonWeekly}, {ReasonWeekly}},
+		},
+		{
+			name:   "monthly more than available",
+			policy: Policy{Monthly: 12},
+			want:   [][]string{nil, {ReasonMonthly}, nil, {ReasonMonthly}, nil, {ReasonMonthly}},
+		},
+		{
+			name:   "within",
+			policy: Policy{Within: 7 * 24 * time.Hour},
+			want:   [][]string{nil, {ReasonWithin}, {ReasonWithin}, nil, {ReasonWithin}, nil},
+		},
+		{
+			name:   "combined",
+			policy: Policy{Last: 1, Weekly: 2},
+			want:   [][]string{nil, {ReasonLast, ReasonWeekly}, nil, nil, {ReasonWeekly}, nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Apply(times, now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Policy.Apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
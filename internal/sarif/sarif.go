@@ -0,0 +1,157 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sarif builds logs in the Static Analysis Results Interchange Format
+// (SARIF) 2.1.0, accepted by code scanning dashboards.
+package sarif
+
+const (
+	// Version is the SARIF version.
+	Version = "2.1.0"
+	// Schema is the JSON schema of SARIF 2.1.0.
+	Schema = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Result levels.
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+	LevelNone    = "none"
+)
+
+// Result kinds.
+const (
+	KindFail          = "fail"
+	KindPass          = "pass"
+	KindNotApplicable = "notApplicable"
+)
+
+// Log is a SARIF log.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []*Run `json:"runs"`
+}
+
+// NewLog returns a SARIF log of runs.
+func NewLog(runs ...*Run) *Log {
+	return &Log{
+		Version: Version,
+		Schema:  Schema,
+		Runs:    runs,
+	}
+}
+
+// Run is a run of an analysis tool.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+// Tool describes the analysis tool.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver is the component of the analysis tool producing the results.
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule is a rule evaluated by the analysis tool.
+type Rule struct {
+	ID               string  `json:"id"`
+	ShortDescription Message `json:"shortDescription"`
+}
+
+// Result is a result of evaluating a rule.
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	RuleIndex int        `json:"ruleIndex"`
+	Level     string     `json:"level"`
+	Kind      string     `json:"kind"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations"`
+}
+
+// Message is a user-facing message.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Location is the location of a result.
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation is the artifact of a result.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+}
+
+// ArtifactLocation identifies an artifact by URI.
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// NewRun returns a run without results of the tool name.
+func NewRun(name, version, informationURI string) *Run {
+	return &Run{
+		Tool: Tool{
+			Driver: Driver{
+				Name:           name,
+				Version:        version,
+				InformationURI: informationURI,
+				Rules:          []Rule{},
+			},
+		},
+		Results: []Result{},
+	}
+}
+
+// AddResult adds a result of the rule ruleID, described by description, at
+// the artifact uri. The rule is added to the tool on the first result.
+func (r *Run) AddResult(ruleID, description, level, kind, message, uri string) {
+	index := -1
+	for i, rule := range r.Tool.Driver.Rules {
+		if rule.ID == ruleID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		index = len(r.Tool.Driver.Rules)
+		r.Tool.Driver.Rules = append(r.Tool.Driver.Rules, Rule{
+			ID:               ruleID,
+			ShortDescription: Message{Text: description},
+		})
+	}
+	r.Results = append(r.Results, Result{
+		RuleID:    ruleID,
+		RuleIndex: index,
+		Level:     level,
+		Kind:      kind,
+		Message:   Message{Text: message},
+		Locations: []Location{{
+			PhysicalLocation: PhysicalLocation{
+				ArtifactLocation: ArtifactLocation{URI: uri},
+			},
+		}},
+	})
+}
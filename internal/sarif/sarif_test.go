@@ -0,0 +1,55 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sarif
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRun_AddResult(t *testing.T) {
+	run := NewRun("oras", "1.0.0", "https://oras.land")
+	run.AddResult("conformance/pull/pull-manifest", "pull manifest", LevelError, KindFail, "not found", "localhost:5000/test")
+	run.AddResult("conformance/push/push-blob", "push blob", LevelNone, KindPass, "passed", "localhost:5000/test")
+	run.AddResult("conformance/pull/pull-manifest", "pull manifest", LevelNone, KindPass, "passed", "localhost:5000/other")
+
+	if got := len(run.Tool.Driver.Rules); got != 2 {
+		t.Fatalf("number of rules = %d, want 2", got)
+	}
+	wantIndexes := []int{0, 1, 0}
+	for i, result := range run.Results {
+		if result.RuleIndex != wantIndexes[i] {
+			t.Errorf("Results[%d].RuleIndex = %d, want %d", i, result.RuleIndex, wantIndexes[i])
+		}
+		if rule := run.Tool.Driver.Rules[result.RuleIndex]; rule.ID != result.RuleID {
+			t.Errorf("Results[%d] refers to rule %s, want %s", i, rule.ID, result.RuleID)
+		}
+	}
+	if got := run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI; got != "localhost:5000/other" {
+		t.Errorf("Results[2] location = %q, want %q", got, "localhost:5000/other")
+	}
+}
+
+func TestNewLog(t *testing.T) {
+	b, err := json.Marshal(NewLog(NewRun("oras", "", "")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"version":"2.1.0","$schema":"https://json.schemastore.org/sarif-2.1.0.json","runs":[{"tool":{"driver":{"name":"oras","rules":[]}},"results":[]}]}`
+	if string(b) != want {
+		t.Errorf("NewLog() = %s, want %s", b, want)
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signature implements reference-bound signatures in the simple
+// signing format, stored as referrers of the signed manifests.
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// ArtifactType is the artifact type of signature manifests.
+	ArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	// MediaTypePayload is the media type of simple signing payloads.
+	MediaTypePayload = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// AnnotationSignature is the layer annotation holding the base64 encoded
+	// signature of the payload.
+	AnnotationSignature = "dev.cosignproject.cosign/signature"
+	// PayloadType is the type of simple signing payloads for container
+	// images.
+	PayloadType = "cosign container image signature"
+)
+
+// ErrInvalidSignature is returned when a signature does not verify.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Payload is a simple signing payload binding a manifest digest to a
+// repository.
+type Payload struct {
+	Critical Critical       `json:"critical"`
+	Optional map[string]any `json:"optional"`
+}
+
+// Critical is the critical section of a simple signing payload.
+type Critical struct {
+	Identity Identity `json:"identity"`
+	Image    Image    `json:"image"`
+	Type     string   `json:"type"`
+}
+
+// Identity identifies the repository the signed image is bound to.
+type Identity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+// Image identifies the signed manifest.
+type Image struct {
+	DockerManifestDigest digest.Digest `json:"docker-manifest-digest"`
+}
+
+// NewPayload returns the payload binding dgst to repository, which is in the
+// form of <registry>/<repository>.
+func NewPayload(repository string, dgst digest.Digest) ([]byte, error) {
+	return json.Marshal(Payload{
+		Critical: Critical{
+			Identity: Identity{DockerReference: repository},
+			Image:    Image{DockerManifestDigest: dgst},
+			Type:     PayloadType,
+		},
+	})
+}
+
+// ParsePayload parses a simple signing payload.
+func ParsePayload(b []byte) (*Payload, error) {
+	var payload Payload
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse signature payload: %w", err)
+	}
+	if payload.Critical.Type != PayloadType {
+		return nil, fmt.Errorf("unsupported signature payload type %q", payload.Critical.Type)
+	}
+	return &payload, nil
+}
+
+// Matches returns true if the payload binds dgst to repository.
+func (p *Payload) Matches(repository string, dgst digest.Digest) bool {
+	return p.Critical.Identity.DockerReference == repository && p.Critical.Image.DockerManifestDigest == dgst
+}
+
+// IsSignature returns true if desc describes a signature manifest.
+func IsSignature(desc ocispec.Descriptor) bool {
+	return desc.ArtifactType == ArtifactType
+}
+
+// Signer signs payloads with a private key.
+type Signer struct {
+	key crypto.Signer
+}
+
+// LoadSigner loads an unencrypted PEM encoded ECDSA, Ed25519 or RSA private
+// key from path.
+func LoadSigner(path string) (*Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM encoded private key found", path)
+	}
+	var key any
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block type %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to parse private key: %w", path, err)
+	}
+	return NewSigner(key)
+}
+
+// NewSigner returns a signer using key.
+func NewSigner(key any) (*Signer, error) {
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey, ed25519.PrivateKey, *rsa.PrivateKey:
+		return &Signer{key: key.(crypto.Signer)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// Public returns the public key of the signer.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+// Sign signs payload.
+func (s *Signer) Sign(payload []byte) ([]byte, error) {
+	if _, ok := s.key.(ed25519.PrivateKey); ok {
+		return s.key.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+	sum := sha256.Sum256(payload)
+	return s.key.Sign(rand.Reader, sum[:], crypto.SHA256)
+}
+
+// Verify verifies the signature of payload against the public key.
+func Verify(key crypto.PublicKey, payload, sig []byte) error {
+	sum := sha256.Sum256(payload)
+	var ok bool
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		ok = ecdsa.VerifyASN1(key, sum[:], sig)
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(key, payload, sig)
+	case *rsa.PublicKey:
+		ok = rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig) == nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+	if !ok {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func TestPayload(t *testing.T) {
+	dgst := digest.FromString("manifest")
+	b, err := NewPayload("localhost:5000/hello", dgst)
+	if err != nil {
+		t.Fatalf("NewPayload() error = %v", err)
+	}
+	payload, err := ParsePayload(b)
+	if err != nil {
+		t.Fatalf("ParsePayload() error = %v", err)
+	}
+	tests := []struct {
+		name       string
+		repository string
+		digest     digest.Digest
+		want       bool
+	}{
+		{"same repository and digest", "localhost:5000/hello", dgst, true},
+		{"different repository", "localhost:6000/hello", dgst, false},
+		{"different digest", "localhost:5000/hello", digest.FromString("other"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := payload.Matches(tt.repository, tt.digest); got != tt.want {
+				t.Errorf("Payload.Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParsePayload([]byte(`{"critical":{"type":"unknown"}}`)); err == nil {
+		t.Error("ParsePayload() error = nil, want error for unknown type")
+	}
+}
+
+func TestSigner(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		block *pem.Block
+	}{
+		{"ecdsa", &pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}},
+		{"ed25519", &pem.Block{Type: "PRIVATE KEY", Bytes: edDER}},
+		{"rsa", &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}},
+	}
+	payload := []byte("payload")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "key.pem")
+			if err := os.WriteFile(path, pem.EncodeToMemory(tt.block), 0600); err != nil {
+				t.Fatal(err)
+			}
+			signer, err := LoadSigner(path)
+			if err != nil {
+				t.Fatalf("LoadSigner() error = %v", err)
+			}
+			sig, err := signer.Sign(payload)
+			if err != nil {
+				t.Fatalf("Signer.Sign() error = %v", err)
+			}
+			if err := Verify(signer.Public(), payload, sig); err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+			if err := Verify(signer.Public(), []byte("tampered"), sig); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("Verify() error = %v, want %v", err, ErrInvalidSignature)
+			}
+		})
+	}
+
+	t.Run("not a PEM file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "key.pem")
+		if err := os.WriteFile(path, []byte("not a key"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadSigner(path); err == nil {
+			t.Error("LoadSigner() error = nil, want error")
+		}
+	})
+}
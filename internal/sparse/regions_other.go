@@ -0,0 +1,26 @@
+//go:build !linux && !darwin && !freebsd
+
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sparse
+
+import "os"
+
+// dataRegions returns the whole file as data as holes cannot be detected on
+// this platform.
+func dataRegions(_ *os.File, size int64) ([]region, error) {
+	return []region{{offset: 0, length: size}}, nil
+}
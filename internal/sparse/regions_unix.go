@@ -0,0 +1,58 @@
+//go:build linux || darwin || freebsd
+
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sparse
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// dataRegions returns the regions of the file f of the given size holding
+// data, as reported by the file system.
+func dataRegions(f *os.File, size int64) ([]region, error) {
+	var regions []region
+	var offset int64
+	for offset < size {
+		start, err := f.Seek(offset, unix.SEEK_DATA)
+		if err != nil {
+			if errors.Is(err, unix.ENXIO) {
+				// no data till the end of the file
+				break
+			}
+			if errors.Is(err, unix.EINVAL) && offset == 0 {
+				// holes are not supported by the file system
+				return []region{{offset: 0, length: size}}, nil
+			}
+			return nil, err
+		}
+		end, err := f.Seek(start, unix.SEEK_HOLE)
+		if err != nil {
+			return nil, err
+		}
+		end = min(end, size)
+		regions = append(regions, region{offset: start, length: end - start})
+		offset = end
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sparse packs and extracts directories keeping the holes of sparse
+// files, so that disk images and the like are not inflated to their logical
+// size on disk.
+package sparse
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// AnnotationSparse marks the directory layers containing sparse files in the
+// GNU sparse 1.0 tar format.
+const AnnotationSparse = "land.oras.sparse"
+
+// blockSize is the granularity of the holes created by Writer.
+const blockSize = 4096
+
+// zeroBlock is a block of zeros.
+var zeroBlock [blockSize]byte
+
+// IsLayer returns true if desc is a directory layer containing sparse files.
+func IsLayer(desc ocispec.Descriptor) bool {
+	return desc.Annotations[AnnotationSparse] == "true" && desc.Annotations[ocispec.AnnotationTitle] != ""
+}
+
+// Writer writes to a file, seeking over the blocks of zeros instead of
+// writing them so that they become holes.
+type Writer struct {
+	file *os.File
+	pos  int64
+	buf  []byte
+}
+
+// NewWriter returns a Writer writing to f from its current offset, which is
+// expected to be the start of an empty file.
+func NewWriter(f *os.File) *Writer {
+	return &Writer{file: f}
+}
+
+// Write writes p, skipping the whole blocks of zeros. The trailing partial
+// block is buffered till the next write or Flush.
+func (w *Writer) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(w.buf) > 0 {
+		k := min(blockSize-len(w.buf), len(p))
+		w.buf = append(w.buf, p[:k]...)
+		p = p[k:]
+		if len(w.buf) < blockSize {
+			return n, nil
+		}
+		if err := w.writeBlocks(w.buf); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[:0]
+	}
+	full := len(p) - len(p)%blockSize
+	if err := w.writeBlocks(p[:full]); err != nil {
+		return 0, err
+	}
+	w.buf = append(w.buf, p[full:]...)
+	return n, nil
+}
+
+// writeBlocks writes the whole blocks of p, seeking over the blocks of zeros
+// and writing the others in runs.
+func (w *Writer) writeBlocks(p []byte) error {
+	for len(p) > 0 {
+		var n int
+		if bytes.Equal(p[:blockSize], zeroBlock[:]) {
+			for n < len(p) && bytes.Equal(p[n:n+blockSize], zeroBlock[:]) {
+				n += blockSize
+			}
+			if _, err := w.file.Seek(int64(n), io.SeekCurrent); err != nil {
+				return err
+			}
+		} else {
+			for n < len(p) && !bytes.Equal(p[n:n+blockSize], zeroBlock[:]) {
+				n += blockSize
+			}
+			if _, err := w.file.Write(p[:n]); err != nil {
+				return err
+			}
+		}
+		w.pos += int64(n)
+		p = p[n:]
+	}
+	return nil
+}
+
+// Flush writes the buffered partial block and sets the size of the file to
+// the number of bytes written, as the content ending with a hole is not
+// written.
+func (w *Writer) Flush() error {
+	if len(w.buf) > 0 {
+		if !bytes.Equal(w.buf, zeroBlock[:len(w.buf)]) {
+			if _, err := w.file.Write(w.buf); err != nil {
+				return err
+			}
+		} else if _, err := w.file.Seek(int64(len(w.buf)), io.SeekCurrent); err != nil {
+			return err
+		}
+		w.pos += int64(len(w.buf))
+		w.buf = w.buf[:0]
+	}
+	fi, err := w.file.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.Size() == w.pos {
+		return nil
+	}
+	return w.file.Truncate(w.pos)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sparse
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestIsLayer(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{"sparse directory", map[string]string{AnnotationSparse: "true", ocispec.AnnotationTitle: "disk"}, true},
+		{"untitled", map[string]string{AnnotationSparse: "true"}, false},
+		{"not sparse", map[string]string{ocispec.AnnotationTitle: "disk"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsLayer(ocispec.Descriptor{Annotations: tt.annotations}); got != tt.want {
+				t.Errorf("IsLayer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		writes  []int
+	}{
+		{"data only", bytes.Repeat([]byte("a"), 10000), []int{10000}},
+		{"trailing hole", append([]byte("head"), make([]byte, 3*blockSize)...), []int{1, 5000, 10000}},
+		{"leading hole", append(make([]byte, 2*blockSize+7), "tail"...), []int{100, 100000}},
+		{"empty", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "file")
+			f, err := os.Create(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w := NewWriter(f)
+			content := tt.content
+			for _, n := range tt.writes {
+				n = min(n, len(content))
+				if _, err := w.Write(content[:n]); err != nil {
+					t.Fatalf("Writer.Write() error = %v", err)
+				}
+				content = content[n:]
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Writer.Flush() error = %v", err)
+			}
+			if err := f.Close(); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tt.content) {
+				t.Errorf("written content of %d bytes, want %d bytes", len(got), len(tt.content))
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sparse
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// GNU sparse 1.0 PAX records, see
+// https://www.gnu.org/software/tar/manual/html_node/Sparse-Formats.html
+const (
+	paxGNUSparseMajor    = "GNU.sparse.major"
+	paxGNUSparseMinor    = "GNU.sparse.minor"
+	paxGNUSparseName     = "GNU.sparse.name"
+	paxGNUSparseRealSize = "GNU.sparse.realsize"
+)
+
+// region is a range of a file.
+type region struct {
+	offset int64
+	length int64
+}
+
+// Detect returns true if any regular file under root has holes.
+func Detect(root string) (bool, error) {
+	var found bool
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		fi, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		regions, err := dataRegions(f, fi.Size())
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if dataSize(regions) < fi.Size() {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found, err
+}
+
+// TarDirectory writes the tar archive of the directory root to w, naming the
+// entries under prefix in the same way as the directories packed by the file
+// store. Regular files with holes are written in the GNU sparse 1.0 format so
+// that only their data is archived. If annotate is not nil, it is called with
+// the path and the header of each entry before the entry is written, e.g. to
+// add PAX records.
+func TarDirectory(ctx context.Context, w io.Writer, root, prefix string, annotate func(path string, header *tar.Header) error) (err error) {
+	tw := tar.NewWriter(w)
+	defer func() {
+		closeErr := tw.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		name, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(filepath.Join(prefix, name))
+
+		// hard links are treated as regular files
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		header.Name = name
+		header.Uid = 0
+		header.Gid = 0
+		header.Uname = ""
+		header.Gname = ""
+		if annotate != nil {
+			if err := annotate(path, header); err != nil {
+				return err
+			}
+		}
+		if !info.Mode().IsRegular() {
+			if err := tw.WriteHeader(header); err != nil {
+				return fmt.Errorf("tar: %w", err)
+			}
+			return nil
+		}
+		return writeFile(w, tw, header, path)
+	})
+}
+
+// writeFile writes the tar entry of the regular file at filename.
+func writeFile(w io.Writer, tw *tar.Writer, header *tar.Header, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	regions, err := dataRegions(f, header.Size)
+	if err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+	if dataSize(regions) == header.Size {
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("tar: %w", err)
+		}
+		if _, err := io.Copy(tw, f); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", filename, err)
+		}
+		return nil
+	}
+
+	// The sparse map and the data regions are archived as the content of an
+	// entry described by the PAX records written ahead of it, which cannot be
+	// produced by tar.Writer.
+	// the map ends with an empty region at the end of the file as GNU tar
+	// does, which otherwise truncates the files ending with a hole
+	sparseMap := encodeSparseMap(append(regions, region{offset: header.Size}))
+	size := int64(len(sparseMap)) + dataSize(regions)
+	format := tar.FormatUSTAR
+	if size >= 1<<33 {
+		// the size cannot be encoded in the USTAR format, and GNU tar does not
+		// read the GNU format entries following PAX records
+		format = tar.FormatGNU
+	}
+	// the name is only used by the readers not supporting sparse files
+	name := path.Join(path.Dir(header.Name), "GNUSparseFile.0", path.Base(header.Name))
+	if len(name) > 100 {
+		name = "GNUSparseFile.0/" + path.Base(header.Name)
+		name = name[:min(len(name), 100)]
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("tar: %w", err)
+	}
+	records := maps.Clone(header.PAXRecords)
+	if records == nil {
+		records = make(map[string]string)
+	}
+	records[paxGNUSparseMajor] = "1"
+	records[paxGNUSparseMinor] = "0"
+	records[paxGNUSparseName] = header.Name
+	records[paxGNUSparseRealSize] = strconv.FormatInt(header.Size, 10)
+	if err := writePAXHeader(w, header.Name, records); err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     header.Mode,
+		ModTime:  header.ModTime.Truncate(time.Second),
+		Size:     size,
+		Format:   format,
+	}); err != nil {
+		return fmt.Errorf("tar: %w", err)
+	}
+	if _, err := tw.Write(sparseMap); err != nil {
+		return err
+	}
+	for _, r := range regions {
+		if _, err := f.Seek(r.offset, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(tw, f, r.length); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", filename, err)
+		}
+	}
+	return nil
+}
+
+// encodeSparseMap encodes the data regions as the GNU sparse 1.0 map, padded
+// to a whole block.
+func encodeSparseMap(regions []region) []byte {
+	sparseMap := strconv.AppendInt(nil, int64(len(regions)), 10)
+	sparseMap = append(sparseMap, '\n')
+	for _, r := range regions {
+		sparseMap = strconv.AppendInt(sparseMap, r.offset, 10)
+		sparseMap = append(sparseMap, '\n')
+		sparseMap = strconv.AppendInt(sparseMap, r.length, 10)
+		sparseMap = append(sparseMap, '\n')
+	}
+	return append(sparseMap, make([]byte, padding(int64(len(sparseMap))))...)
+}
+
+// writePAXHeader writes a PAX extended header entry with the records, which
+// apply to the next entry named name.
+func writePAXHeader(w io.Writer, name string, records map[string]string) error {
+	var data []byte
+	for _, k := range slices.Sorted(maps.Keys(records)) {
+		data = append(data, paxRecord(k, records[k])...)
+	}
+
+	var block [512]byte
+	paxName := path.Join(path.Dir(name), "PaxHeaders.0", path.Base(name))
+	copy(block[0:100], paxName[:min(len(paxName), 100)])
+	copy(block[100:108], "0000644\x00")
+	copy(block[108:116], "0000000\x00")
+	copy(block[116:124], "0000000\x00")
+	copy(block[124:136], fmt.Sprintf("%011o\x00", len(data)))
+	copy(block[136:148], "00000000000\x00")
+	block[156] = tar.TypeXHeader
+	copy(block[257:265], "ustar\x0000")
+	copy(block[148:156], "        ")
+	var sum int64
+	for _, c := range block {
+		sum += int64(c)
+	}
+	copy(block[148:156], fmt.Sprintf("%06o\x00 ", sum))
+
+	data = append(data, make([]byte, padding(int64(len(data))))...)
+	if _, err := w.Write(block[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// paxRecord formats a PAX record, prefixed by its own length.
+func paxRecord(k, v string) string {
+	const padding = 3 // ' ', '=' and '\n'
+	size := len(k) + len(v) + padding
+	size += len(strconv.Itoa(size))
+	record := strconv.Itoa(size) + " " + k + "=" + v + "\n"
+	if len(record) != size {
+		// the length grows by a digit
+		record = strconv.Itoa(len(record)) + " " + k + "=" + v + "\n"
+	}
+	return record
+}
+
+// padding returns the number of bytes padding n bytes to a whole tar block.
+func padding(n int64) int64 {
+	return -n & 511
+}
+
+// dataSize returns the total length of the regions.
+func dataSize(regions []region) int64 {
+	var size int64
+	for _, r := range regions {
+		size += r.length
+	}
+	return size
+}
+
+// Extract extracts the directory named name from the tar archive read from r
+// into dir in the same way as the file store, except that the regular files
+// are written sparsely. Entries outside of the directory are rejected. If
+// restore is not nil, it is called with the path and the header of each
+// extracted entry, e.g. to apply PAX records.
+func Extract(r io.Reader, dir, name string, restore func(path string, header *tar.Header) error) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		rel, err := relPath(dir, name, header.Name)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, rel)
+		switch header.Typeflag {
+		case tar.TypeReg:
+			err = extractFile(path, tr, header.FileInfo().Mode())
+		case tar.TypeDir:
+			err = os.MkdirAll(path, header.FileInfo().Mode())
+		case tar.TypeLink:
+			var target string
+			if target, err = relPath(dir, name, header.Linkname); err == nil {
+				err = os.Link(filepath.Join(dir, target), path)
+			}
+		case tar.TypeSymlink:
+			if err = checkLink(dir, rel, header.Linkname); err != nil {
+				return err
+			}
+			if err = os.Symlink(header.Linkname, path); errors.Is(err, fs.ErrExist) {
+				if err = os.Remove(path); err == nil {
+					err = os.Symlink(header.Linkname, path)
+				}
+			}
+		default:
+			continue // other types are skipped
+		}
+		if err != nil {
+			return err
+		}
+		_ = os.Chtimes(path, header.AccessTime, header.ModTime)
+		if restore != nil {
+			if err := restore(path, header); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extractFile writes the content read from r sparsely to the file at path.
+func extractFile(path string, r io.Reader, perm os.FileMode) (err error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	w := NewWriter(f)
+	if _, err := io.Copy(w, r); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// relPath returns the path of the archived entry relative to the directory
+// named name, which is extracted into dir. Entries outside of the directory
+// or under a symbolic link are rejected.
+func relPath(dir, name, entry string) (string, error) {
+	rel, err := filepath.Rel(filepath.FromSlash(name), filepath.FromSlash(entry))
+	if err != nil {
+		return "", err
+	}
+	if rel != "." && !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%q is outside of %q", entry, name)
+	}
+	for parent := filepath.Dir(rel); parent != "."; parent = filepath.Dir(parent) {
+		if fi, err := os.Lstat(filepath.Join(dir, parent)); err == nil && fi.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("no symbolic link allowed between %q and %q", name, entry)
+		}
+	}
+	return rel, nil
+}
+
+// checkLink ensures the target of the symbolic link at rel in dir is within
+// dir.
+func checkLink(dir, rel, target string) error {
+	resolved := filepath.FromSlash(target)
+	if filepath.IsAbs(resolved) {
+		var err error
+		if resolved, err = filepath.Rel(dir, resolved); err != nil {
+			return err
+		}
+	} else {
+		resolved = filepath.Join(filepath.Dir(rel), resolved)
+	}
+	if resolved != "." && !filepath.IsLocal(resolved) {
+		return fmt.Errorf("link %q is pointing outside of the directory: %q", rel, target)
+	}
+	return nil
+}
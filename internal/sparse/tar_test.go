@@ -0,0 +1,209 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sparse
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// createSparseFile creates a file of size bytes at path with data at the
+// offsets only.
+func createSparseFile(t *testing.T, path string, size int64, data map[int64]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if err := f.Truncate(size); err != nil {
+		t.Fatal(err)
+	}
+	for offset, s := range data {
+		if _, err := f.WriteAt([]byte(s), offset); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestTarDirectory(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "disk")
+	if err := os.MkdirAll(filepath.Join(root, "images"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	const size = 64 << 20
+	createSparseFile(t, filepath.Join(root, "images", "disk.img"), size, map[int64]string{
+		1 << 20:   "boot",
+		size - 10: "end",
+	})
+	if err := os.WriteFile(filepath.Join(root, "README"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("images/disk.img", filepath.Join(root, "latest")); err != nil {
+		t.Fatal(err)
+	}
+	sparse, err := Detect(root)
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if !sparse {
+		t.Skip("holes are not supported by the file system")
+	}
+
+	var archive bytes.Buffer
+	if err := TarDirectory(context.Background(), &archive, root, "disk", nil); err != nil {
+		t.Fatalf("TarDirectory() error = %v", err)
+	}
+	if archive.Len() >= size {
+		t.Errorf("TarDirectory() archived %d bytes, want less than %d", archive.Len(), size)
+	}
+
+	// the archive is readable as a regular tar archive
+	want := map[string]int64{
+		"disk":                 0,
+		"disk/README":          5,
+		"disk/images":          0,
+		"disk/images/disk.img": size,
+		"disk/latest":          0,
+	}
+	tr := tar.NewReader(bytes.NewReader(archive.Bytes()))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar.Reader.Next() error = %v", err)
+		}
+		wantSize, ok := want[header.Name]
+		if !ok {
+			t.Fatalf("unexpected entry %q", header.Name)
+		}
+		delete(want, header.Name)
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("failed to read %q: %v", header.Name, err)
+		}
+		if int64(len(content)) != wantSize {
+			t.Errorf("entry %q has %d bytes, want %d", header.Name, len(content), wantSize)
+		}
+		if header.Name == "disk/images/disk.img" {
+			if got := string(content[1<<20 : 1<<20+4]); got != "boot" {
+				t.Errorf("data at 1MiB = %q, want %q", got, "boot")
+			}
+			if got := string(content[size-10 : size-7]); got != "end" {
+				t.Errorf("data at the end = %q, want %q", got, "end")
+			}
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("missing entries %v", want)
+	}
+
+	// the sparse files are extracted sparsely
+	dir := filepath.Join(t.TempDir(), "out")
+	if err := Extract(bytes.NewReader(archive.Bytes()), dir, "disk", nil); err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "images", "disk.img"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	original, err := os.ReadFile(filepath.Join(root, "images", "disk.img"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, original) {
+		t.Error("Extract() extracted different content")
+	}
+	if sparse, err := Detect(dir); err != nil || !sparse {
+		t.Errorf("Detect() on extracted = %v, %v, want true", sparse, err)
+	}
+	if link, err := os.Readlink(filepath.Join(dir, "latest")); err != nil || link != "images/disk.img" {
+		t.Errorf("extracted link = %q, %v, want %q", link, err, "images/disk.img")
+	}
+}
+
+func TestExtract_outside(t *testing.T) {
+	tests := []struct {
+		name   string
+		header *tar.Header
+		want   string
+	}{
+		{
+			name:   "entry outside",
+			header: &tar.Header{Typeflag: tar.TypeReg, Name: "disk/../evil"},
+			want:   "outside",
+		},
+		{
+			name:   "link outside",
+			header: &tar.Header{Typeflag: tar.TypeSymlink, Name: "disk/link", Linkname: "../../etc/passwd"},
+			want:   "pointing outside",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var archive bytes.Buffer
+			tw := tar.NewWriter(&archive)
+			if err := tw.WriteHeader(tt.header); err != nil {
+				t.Fatal(err)
+			}
+			if err := tw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			err := Extract(&archive, t.TempDir(), "disk", nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Extract() error = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTarDirectory_records(t *testing.T) {
+	root := t.TempDir()
+	createSparseFile(t, filepath.Join(root, "disk.img"), 1<<20, map[int64]string{0: "boot"})
+	if err := os.WriteFile(filepath.Join(root, "README"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	annotate := func(path string, header *tar.Header) error {
+		header.PAXRecords = map[string]string{"SCHILY.xattr.user.name": filepath.Base(path)}
+		return nil
+	}
+	var archive bytes.Buffer
+	if err := TarDirectory(context.Background(), &archive, root, "data", annotate); err != nil {
+		t.Fatalf("TarDirectory() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	restore := func(path string, header *tar.Header) error {
+		got[filepath.Base(path)] = header.PAXRecords["SCHILY.xattr.user.name"]
+		return nil
+	}
+	if err := Extract(&archive, t.TempDir(), "data", restore); err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	for _, name := range []string{"disk.img", "README"} {
+		if got[name] != name {
+			t.Errorf("restored record of %s = %q, want %q", name, got[name], name)
+		}
+	}
+}
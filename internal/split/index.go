@@ -0,0 +1,193 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package split
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras/internal/fastcdc"
+)
+
+// MediaTypeChunkIndex is the config media type of artifacts whose files are
+// stored as content-defined chunks.
+const MediaTypeChunkIndex = "application/vnd.oras.chunk.index.v1+json"
+
+// AlgorithmFastCDC is the FastCDC content-defined chunking algorithm.
+const AlgorithmFastCDC = "fastcdc"
+
+// Chunking describes how files are chunked.
+type Chunking struct {
+	Algorithm string `json:"algorithm"`
+	MinSize   int    `json:"minSize"`
+	AvgSize   int    `json:"avgSize"`
+	MaxSize   int    `json:"maxSize"`
+}
+
+// NewChunking returns the FastCDC chunking for chunks of avgSize bytes on
+// average.
+func NewChunking(avgSize int) (Chunking, error) {
+	opts := fastcdc.NewOptions(avgSize)
+	if err := opts.Validate(); err != nil {
+		return Chunking{}, err
+	}
+	return Chunking{
+		Algorithm: AlgorithmFastCDC,
+		MinSize:   opts.MinSize,
+		AvgSize:   opts.AvgSize,
+		MaxSize:   opts.MaxSize,
+	}, nil
+}
+
+// options returns the chunker options.
+func (c Chunking) options() (fastcdc.Options, error) {
+	if c.Algorithm != AlgorithmFastCDC {
+		return fastcdc.Options{}, fmt.Errorf("unsupported chunking algorithm %q", c.Algorithm)
+	}
+	opts := fastcdc.Options{MinSize: c.MinSize, AvgSize: c.AvgSize, MaxSize: c.MaxSize}
+	return opts, opts.Validate()
+}
+
+// Index is the chunk index stored as the manifest config. It lists the chunks
+// each file is reassembled from.
+type Index struct {
+	Chunking Chunking      `json:"chunking"`
+	Files    []IndexedFile `json:"files"`
+}
+
+// IndexedFile is a file in a chunk index.
+type IndexedFile struct {
+	Name      string        `json:"name"`
+	MediaType string        `json:"mediaType"`
+	Digest    digest.Digest `json:"digest"`
+	Size      int64         `json:"size"`
+	Chunks    []Chunk       `json:"chunks"`
+}
+
+// Chunk is a chunk of an indexed file.
+type Chunk struct {
+	Digest digest.Digest `json:"digest"`
+	Size   int64         `json:"size"`
+}
+
+// ParseIndex parses and validates a chunk index.
+func ParseIndex(data []byte) (*Index, error) {
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("invalid chunk index: %w", err)
+	}
+	if _, err := index.Chunking.options(); err != nil {
+		return nil, fmt.Errorf("invalid chunk index: %w", err)
+	}
+	for _, f := range index.Files {
+		if f.Name == "" {
+			return nil, errors.New("invalid chunk index: missing file name")
+		}
+		if err := f.Digest.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid chunk index: %s: %w", f.Name, err)
+		}
+		var total int64
+		for _, c := range f.Chunks {
+			if err := c.Digest.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid chunk index: %s: %w", f.Name, err)
+			}
+			total += c.Size
+		}
+		if total != f.Size {
+			return nil, fmt.Errorf("invalid chunk index: %s: chunks sum up to %d bytes, expected %d", f.Name, total, f.Size)
+		}
+	}
+	return &index, nil
+}
+
+// Expand returns the files to be reassembled from the chunks.
+func (index *Index) Expand() []File {
+	files := make([]File, 0, len(index.Files))
+	for _, f := range index.Files {
+		chunks := make([]ocispec.Descriptor, 0, len(f.Chunks))
+		for _, c := range f.Chunks {
+			chunks = append(chunks, ocispec.Descriptor{
+				MediaType: f.MediaType,
+				Digest:    c.Digest,
+				Size:      c.Size,
+			})
+		}
+		files = append(files, File{
+			Name:      f.Name,
+			MediaType: f.MediaType,
+			Digest:    f.Digest,
+			Size:      f.Size,
+			Chunks:    chunks,
+			Chunking:  &index.Chunking,
+		})
+	}
+	return files
+}
+
+// chunkFile chunks the regular file at path with chunking and registers the
+// chunks to the store.
+func (s *Store) chunkFile(path string, chunking Chunking) (IndexedFile, error) {
+	opts, err := chunking.options()
+	if err != nil {
+		return IndexedFile{}, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return IndexedFile{}, err
+	}
+	defer func() { _ = f.Close() }()
+	chunker, err := fastcdc.NewChunker(f, opts)
+	if err != nil {
+		return IndexedFile{}, err
+	}
+
+	whole := digest.Canonical.Digester()
+	var indexed IndexedFile
+	for {
+		c, err := chunker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return IndexedFile{}, err
+		}
+		_, _ = whole.Hash().Write(c.Data)
+		dgst := digest.FromBytes(c.Data)
+		size := int64(len(c.Data))
+		indexed.Chunks = append(indexed.Chunks, Chunk{Digest: dgst, Size: size})
+		indexed.Size += size
+
+		s.mu.Lock()
+		if _, ok := s.chunks[dgst]; !ok {
+			s.chunks[dgst] = chunk{path: path, offset: c.Offset, size: size}
+		}
+		s.mu.Unlock()
+	}
+	indexed.Digest = whole.Digest()
+	return indexed, nil
+}
+
+// Scan chunks the existing file at path with chunking so that its chunks can
+// be reused when reassembling a newer version of the file.
+func (s *Store) Scan(path string, chunking Chunking) error {
+	_, err := s.chunkFile(path, chunking)
+	return err
+}
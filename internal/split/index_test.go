@@ -0,0 +1,117 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package split
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChunkingStore(t *testing.T) {
+	ctx := context.Background()
+	chunking, err := NewChunking(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rand.New(rand.NewPCG(3, 4))
+	data := make([]byte, 64*1024)
+	for i := range data {
+		data[i] = byte(r.Uint32())
+	}
+	dir := t.TempDir()
+	v1 := filepath.Join(dir, "v1.bin")
+	v2 := filepath.Join(dir, "v2.bin")
+	if err := os.WriteFile(v1, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(v2, append(bytes.Clone(data), "appended"...), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewChunkingStore(chunking)
+	descs1, err := s.Add("v1.bin", "", v1)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	descs2, err := s.Add("v2.bin", "", v2)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if len(descs1) < 2 || len(descs2) == 0 || len(descs2) > 2 {
+		t.Errorf("Add() returned %d and %d chunks, want the second file to share most chunks", len(descs1), len(descs2))
+	}
+
+	// round trip the index
+	blob, err := json.Marshal(s.Index())
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, err := ParseIndex(blob)
+	if err != nil {
+		t.Fatalf("ParseIndex() error = %v", err)
+	}
+	files := index.Expand()
+	if len(files) != 2 || files[1].Name != "v2.bin" || files[1].Chunking == nil {
+		t.Fatalf("Expand() = %+v", files)
+	}
+	var buf bytes.Buffer
+	if err := Assemble(ctx, s, files[1], &buf); err != nil {
+		t.Fatalf("Assemble() error = %v", err)
+	}
+	if got := buf.String(); got != string(data)+"appended" {
+		t.Error("Assemble() returned different content")
+	}
+
+	// reuse chunks of the local version
+	local := NewStore(0)
+	if err := local.Scan(v1, *files[1].Chunking); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	var reused int
+	for _, c := range files[1].Chunks {
+		if exists, _ := local.Exists(ctx, c); exists {
+			reused++
+		}
+	}
+	if reused < len(files[1].Chunks)-2 {
+		t.Errorf("reused %d of %d chunks", reused, len(files[1].Chunks))
+	}
+}
+
+func TestParseIndex_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"malformed", `{`},
+		{"unknown algorithm", `{"chunking":{"algorithm":"rabin","minSize":256,"avgSize":1024,"maxSize":4096}}`},
+		{"invalid sizes", `{"chunking":{"algorithm":"fastcdc","minSize":256,"avgSize":1000,"maxSize":4096}}`},
+		{"size mismatch", `{"chunking":{"algorithm":"fastcdc","minSize":256,"avgSize":1024,"maxSize":4096},"files":[{"name":"a","digest":"sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855","size":1}]}`},
+		{"missing name", `{"chunking":{"algorithm":"fastcdc","minSize":256,"avgSize":1024,"maxSize":4096},"files":[{"digest":"sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855","size":0}]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseIndex([]byte(tt.data)); err == nil {
+				t.Error("ParseIndex() error = nil, want error")
+			}
+		})
+	}
+}
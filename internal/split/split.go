@@ -0,0 +1,360 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package split splits large files into multiple blobs and reassembles them.
+package split
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+// Annotations of the chunk layers of a split file.
+const (
+	// AnnotationName is the name of the file a chunk belongs to.
+	AnnotationName = "land.oras.split.name"
+	// AnnotationIndex is the zero-based position of a chunk in the file.
+	AnnotationIndex = "land.oras.split.index"
+	// AnnotationCount is the number of chunks of the file.
+	AnnotationCount = "land.oras.split.count"
+	// AnnotationDigest is the digest of the whole file.
+	AnnotationDigest = "land.oras.split.digest"
+	// AnnotationSize is the size of the whole file.
+	AnnotationSize = "land.oras.split.size"
+)
+
+// chunk locates the content of a chunk in a file.
+type chunk struct {
+	path   string
+	offset int64
+	size   int64
+}
+
+// Store is a read-only storage serving the chunks of split files from disk.
+type Store struct {
+	// ChunkSize is the maximum size of a chunk.
+	ChunkSize int64
+	// Chunking, if set, chunks files by content instead of by ChunkSize and
+	// records them in a chunk index.
+	Chunking *Chunking
+
+	mu     sync.RWMutex
+	chunks map[digest.Digest]chunk
+	index  []IndexedFile
+	added  map[digest.Digest]bool
+}
+
+// NewStore returns a store splitting files into chunks of at most chunkSize
+// bytes.
+func NewStore(chunkSize int64) *Store {
+	return &Store{
+		ChunkSize: chunkSize,
+		chunks:    make(map[digest.Digest]chunk),
+	}
+}
+
+// NewChunkingStore returns a store splitting files into content-defined
+// chunks.
+func NewChunkingStore(chunking Chunking) *Store {
+	return &Store{
+		Chunking: &chunking,
+		chunks:   make(map[digest.Digest]chunk),
+		added:    make(map[digest.Digest]bool),
+	}
+}
+
+// Add splits the file at path into chunks and returns the descriptors of the
+// chunks not returned before. No descriptor is returned if the file does not
+// need splitting.
+func (s *Store) Add(name, mediaType, path string) ([]ocispec.Descriptor, error) {
+	if s.Chunking == nil {
+		return s.Split(name, mediaType, path)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() {
+		return nil, nil
+	}
+	if mediaType == "" {
+		mediaType = ocispec.MediaTypeImageLayer
+	}
+	indexed, err := s.chunkFile(path, *s.Chunking)
+	if err != nil {
+		return nil, err
+	}
+	indexed.Name = name
+	indexed.MediaType = mediaType
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index = append(s.index, indexed)
+	descs := []ocispec.Descriptor{}
+	for _, c := range indexed.Chunks {
+		if s.added[c.Digest] {
+			// deduplicated
+			continue
+		}
+		s.added[c.Digest] = true
+		descs = append(descs, ocispec.Descriptor{
+			MediaType: mediaType,
+			Digest:    c.Digest,
+			Size:      c.Size,
+		})
+	}
+	return descs, nil
+}
+
+// Index returns the chunk index of the files added.
+func (s *Store) Index() *Index {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	index := &Index{Files: slices.Clone(s.index)}
+	if s.Chunking != nil {
+		index.Chunking = *s.Chunking
+	}
+	return index
+}
+
+// Split splits the regular file at path into chunks named name and returns
+// their descriptors in order. No descriptor is returned if the file is not
+// larger than the chunk size and thus does not need splitting.
+func (s *Store) Split(name, mediaType, path string) ([]ocispec.Descriptor, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() || fi.Size() <= s.ChunkSize {
+		return nil, nil
+	}
+	if mediaType == "" {
+		mediaType = ocispec.MediaTypeImageLayer
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	whole := digest.Canonical.Digester()
+	var descs []ocispec.Descriptor
+	var chunks []chunk
+	for offset := int64(0); offset < fi.Size(); offset += s.ChunkSize {
+		size := min(s.ChunkSize, fi.Size()-offset)
+		digester := digest.Canonical.Digester()
+		n, err := io.Copy(io.MultiWriter(digester.Hash(), whole.Hash()), io.NewSectionReader(f, offset, size))
+		if err != nil {
+			return nil, err
+		}
+		if n != size {
+			return nil, fmt.Errorf("%s: file changed while splitting", path)
+		}
+		descs = append(descs, ocispec.Descriptor{
+			MediaType: mediaType,
+			Digest:    digester.Digest(),
+			Size:      size,
+		})
+		chunks = append(chunks, chunk{path: path, offset: offset, size: size})
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range descs {
+		descs[i].Annotations = map[string]string{
+			AnnotationName:   name,
+			AnnotationIndex:  strconv.Itoa(i),
+			AnnotationCount:  strconv.Itoa(len(descs)),
+			AnnotationDigest: whole.Digest().String(),
+			AnnotationSize:   strconv.FormatInt(fi.Size(), 10),
+		}
+		s.chunks[descs[i].Digest] = chunks[i]
+	}
+	return descs, nil
+}
+
+// Fetch fetches the content of a chunk.
+func (s *Store) Fetch(_ context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	s.mu.RLock()
+	c, ok := s.chunks[target.Digest]
+	s.mu.RUnlock()
+	if !ok || c.size != target.Size {
+		return nil, fmt.Errorf("%s: %w", target.Digest, errdef.ErrNotFound)
+	}
+	f, err := os.Open(c.path)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.NewSectionReader(f, c.offset, c.size), f}, nil
+}
+
+// Exists returns true if the chunk is known to the store.
+func (s *Store) Exists(_ context.Context, target ocispec.Descriptor) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c, ok := s.chunks[target.Digest]
+	return ok && c.size == target.Size, nil
+}
+
+// Resolve always fails since chunks are not tagged.
+func (s *Store) Resolve(_ context.Context, ref string) (ocispec.Descriptor, error) {
+	return ocispec.Descriptor{}, fmt.Errorf("%s: %w", ref, errdef.ErrNotFound)
+}
+
+// IsChunk returns true if desc is a chunk of a split file.
+func IsChunk(desc ocispec.Descriptor) bool {
+	_, ok := desc.Annotations[AnnotationName]
+	return ok
+}
+
+// File is a split file to be reassembled from its chunks.
+type File struct {
+	Name      string
+	MediaType string
+	Digest    digest.Digest
+	Size      int64
+	// Chunks are the chunks of the file in order.
+	Chunks []ocispec.Descriptor
+	// Chunking is how the file is chunked by content, if it is.
+	Chunking *Chunking
+}
+
+// Descriptor returns the descriptor of the reassembled file.
+func (f File) Descriptor() ocispec.Descriptor {
+	return ocispec.Descriptor{
+		MediaType: f.MediaType,
+		Digest:    f.Digest,
+		Size:      f.Size,
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: f.Name,
+		},
+	}
+}
+
+// Group groups chunks by the files they belong to. Files are returned in the
+// order they first appear and an error is returned if any file is incomplete
+// or has inconsistent chunks.
+func Group(chunks []ocispec.Descriptor) ([]File, error) {
+	var files []File
+	positions := make(map[string]int)
+	for _, c := range chunks {
+		name := c.Annotations[AnnotationName]
+		if name == "" {
+			return nil, fmt.Errorf("chunk %s: missing file name", c.Digest)
+		}
+		index, err := strconv.Atoi(c.Annotations[AnnotationIndex])
+		if err != nil || index < 0 {
+			return nil, fmt.Errorf("chunk %s of %s: invalid index %q", c.Digest, name, c.Annotations[AnnotationIndex])
+		}
+		count, err := strconv.Atoi(c.Annotations[AnnotationCount])
+		if err != nil || count <= index {
+			return nil, fmt.Errorf("chunk %s of %s: invalid count %q", c.Digest, name, c.Annotations[AnnotationCount])
+		}
+		dgst, err := digest.Parse(c.Annotations[AnnotationDigest])
+		if err != nil {
+			return nil, fmt.Errorf("chunk %s of %s: invalid file digest: %w", c.Digest, name, err)
+		}
+		size, err := strconv.ParseInt(c.Annotations[AnnotationSize], 10, 64)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("chunk %s of %s: invalid file size %q", c.Digest, name, c.Annotations[AnnotationSize])
+		}
+
+		pos, ok := positions[name]
+		if !ok {
+			pos = len(files)
+			positions[name] = pos
+			files = append(files, File{
+				Name:      name,
+				MediaType: c.MediaType,
+				Digest:    dgst,
+				Size:      size,
+				Chunks:    make([]ocispec.Descriptor, count),
+			})
+		}
+		f := &files[pos]
+		if f.Digest != dgst || f.Size != size || len(f.Chunks) != count {
+			return nil, fmt.Errorf("chunks of %s describe different files", name)
+		}
+		if existing := f.Chunks[index]; existing.Digest != "" {
+			if content.Equal(existing, c) {
+				// the same chunk referenced more than once
+				continue
+			}
+			return nil, fmt.Errorf("chunk %d of %s is duplicated", index, name)
+		}
+		f.Chunks[index] = c
+	}
+
+	for _, f := range files {
+		var total int64
+		for i, c := range f.Chunks {
+			if c.Digest == "" {
+				return nil, fmt.Errorf("%s: chunk %d of %d is missing", f.Name, i, len(f.Chunks))
+			}
+			total += c.Size
+		}
+		if total != f.Size {
+			return nil, fmt.Errorf("%s: chunks sum up to %d bytes, expected %d", f.Name, total, f.Size)
+		}
+	}
+	return files, nil
+}
+
+// ErrMismatchedDigest is returned if a reassembled file does not match its
+// digest.
+var ErrMismatchedDigest = errors.New("mismatched digest")
+
+// Assemble fetches the chunks of f in order, writes them to w and verifies
+// the reassembled content.
+func Assemble(ctx context.Context, fetcher content.Fetcher, f File, w io.Writer) error {
+	verifier := f.Digest.Verifier()
+	w = io.MultiWriter(w, verifier)
+	for _, c := range f.Chunks {
+		if err := copyChunk(ctx, fetcher, c, w); err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("%s: %w", f.Name, ErrMismatchedDigest)
+	}
+	return nil
+}
+
+// copyChunk copies the verified content of a chunk to w.
+func copyChunk(ctx context.Context, fetcher content.Fetcher, c ocispec.Descriptor, w io.Writer) error {
+	rc, err := fetcher.Fetch(ctx, c)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+	vr := content.NewVerifyReader(rc, c)
+	if _, err := io.Copy(w, vr); err != nil {
+		return err
+	}
+	return vr.Verify()
+}
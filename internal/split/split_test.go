@@ -0,0 +1,165 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package split
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func writeFile(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "model.bin")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestStore_Split(t *testing.T) {
+	ctx := context.Background()
+	data := []byte("0123456789abcdefghij")
+	path := writeFile(t, data)
+	s := NewStore(8)
+
+	descs, err := s.Split("model.bin", "", path)
+	if err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	if len(descs) != 3 {
+		t.Fatalf("Split() returned %d chunks, want 3", len(descs))
+	}
+	wantChunks := [][]byte{data[:8], data[8:16], data[16:]}
+	for i, desc := range descs {
+		if desc.MediaType != ocispec.MediaTypeImageLayer {
+			t.Errorf("chunk %d media type = %s, want default layer type", i, desc.MediaType)
+		}
+		if got := desc.Annotations[AnnotationIndex]; got != strconv.Itoa(i) {
+			t.Errorf("chunk %d index = %s", i, got)
+		}
+		if got := desc.Annotations[AnnotationDigest]; got != digest.FromBytes(data).String() {
+			t.Errorf("chunk %d file digest = %s", i, got)
+		}
+		if _, ok := desc.Annotations[ocispec.AnnotationTitle]; ok {
+			t.Errorf("chunk %d must not be titled", i)
+		}
+		rc, err := s.Fetch(ctx, desc)
+		if err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+		got, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		if !bytes.Equal(got, wantChunks[i]) {
+			t.Errorf("chunk %d = %q, want %q", i, got, wantChunks[i])
+		}
+		if exists, _ := s.Exists(ctx, desc); !exists {
+			t.Errorf("Exists() = false for chunk %d", i)
+		}
+	}
+
+	other := ocispec.Descriptor{Digest: digest.FromString("other"), Size: 5}
+	if _, err := s.Fetch(ctx, other); !errors.Is(err, errdef.ErrNotFound) {
+		t.Errorf("Fetch() error = %v, want ErrNotFound", err)
+	}
+
+	small, err := NewStore(int64(len(data))).Split("model.bin", "", path)
+	if err != nil || small != nil {
+		t.Errorf("Split() = %v, %v, want no chunks for a small file", small, err)
+	}
+}
+
+func TestGroupAndAssemble(t *testing.T) {
+	ctx := context.Background()
+	data := []byte("the quick brown fox jumps over the lazy dog")
+	s := NewStore(10)
+	descs, err := s.Split("fox.txt", "application/vnd.test", writeFile(t, data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// shuffle and duplicate chunks
+	shuffled := append([]ocispec.Descriptor{descs[3], descs[0]}, descs...)
+	files, err := Group(shuffled)
+	if err != nil {
+		t.Fatalf("Group() error = %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Group() returned %d files, want 1", len(files))
+	}
+	f := files[0]
+	if f.Name != "fox.txt" || f.Size != int64(len(data)) || f.Digest != digest.FromBytes(data) || f.MediaType != "application/vnd.test" {
+		t.Errorf("Group() = %+v", f)
+	}
+	if got := f.Descriptor().Annotations[ocispec.AnnotationTitle]; got != "fox.txt" {
+		t.Errorf("Descriptor() title = %q", got)
+	}
+
+	var buf bytes.Buffer
+	if err := Assemble(ctx, s, f, &buf); err != nil {
+		t.Fatalf("Assemble() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("Assemble() = %q, want %q", buf.Bytes(), data)
+	}
+
+	f.Digest = digest.FromString("tampered")
+	if err := Assemble(ctx, s, f, io.Discard); !errors.Is(err, ErrMismatchedDigest) {
+		t.Errorf("Assemble() error = %v, want ErrMismatchedDigest", err)
+	}
+}
+
+func TestGroup_invalid(t *testing.T) {
+	data := []byte("0123456789")
+	descs, err := NewStore(4).Split("f", "", writeFile(t, data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	withAnnotation := func(desc ocispec.Descriptor, key, value string) ocispec.Descriptor {
+		annotations := make(map[string]string)
+		for k, v := range desc.Annotations {
+			annotations[k] = v
+		}
+		annotations[key] = value
+		desc.Annotations = annotations
+		return desc
+	}
+	tests := []struct {
+		name   string
+		chunks []ocispec.Descriptor
+	}{
+		{"missing chunk", []ocispec.Descriptor{descs[0], descs[2]}},
+		{"invalid index", []ocispec.Descriptor{withAnnotation(descs[0], AnnotationIndex, "x")}},
+		{"index out of range", []ocispec.Descriptor{withAnnotation(descs[0], AnnotationIndex, "3")}},
+		{"inconsistent size", []ocispec.Descriptor{descs[0], descs[1], withAnnotation(descs[2], AnnotationSize, "11")}},
+		{"duplicated index", []ocispec.Descriptor{descs[0], descs[1], descs[2], withAnnotation(descs[0], AnnotationIndex, "1")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Group(tt.chunks); err == nil {
+				t.Error("Group() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sshtunnel routes network connections through an SSH jump host.
+package sshtunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// defaultPort is the default SSH port.
+const defaultPort = "22"
+
+// Jump is an SSH jump host.
+type Jump struct {
+	// User is the user to log in as. The current user is used if empty.
+	User string
+	// Address is the address of the jump host in the form of host:port.
+	Address string
+}
+
+// ParseJump parses a jump host in the form of ssh://[user@]host[:port] or
+// [user@]host[:port].
+func ParseJump(s string) (Jump, error) {
+	raw := s
+	if !strings.Contains(raw, "://") {
+		raw = "ssh://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Jump{}, fmt.Errorf("invalid SSH jump host %q: %w", s, err)
+	}
+	if u.Scheme != "ssh" {
+		return Jump{}, fmt.Errorf("invalid SSH jump host %q: unsupported scheme %q", s, u.Scheme)
+	}
+	if u.Hostname() == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+		return Jump{}, fmt.Errorf("invalid SSH jump host %q: expecting ssh://[user@]host[:port]", s)
+	}
+	if _, ok := u.User.Password(); ok {
+		return Jump{}, fmt.Errorf("invalid SSH jump host %q: password is not allowed, use an SSH agent or key instead", s)
+	}
+	port := u.Port()
+	if port == "" {
+		port = defaultPort
+	}
+	return Jump{
+		User:    u.User.Username(),
+		Address: net.JoinHostPort(u.Hostname(), port),
+	}, nil
+}
+
+// Tunnel dials connections through an SSH jump host. The SSH connection is
+// established on the first dial and reused afterwards. A lost or failed
+// connection is established again on the next dial.
+type Tunnel struct {
+	// Jump is the jump host to connect to.
+	Jump Jump
+	// Config returns the SSH client configuration. ClientConfig is used if
+	// nil.
+	Config func(jump Jump) (*ssh.ClientConfig, error)
+
+	lock   sync.Mutex
+	client *ssh.Client
+	closed bool
+}
+
+// DialContext connects to addr from the jump host.
+func (t *Tunnel) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	client, err := t.sshClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := client.DialContext(ctx, network, addr)
+	var openErr *ssh.OpenChannelError
+	if err == nil || errors.As(err, &openErr) || ctx.Err() != nil {
+		// the jump host rejecting addr does not break the SSH connection
+		return conn, err
+	}
+	// the SSH connection is lost, establish it again
+	t.drop(client)
+	if client, err = t.sshClient(ctx); err != nil {
+		return nil, err
+	}
+	return client.DialContext(ctx, network, addr)
+}
+
+// Close closes the SSH connection, if established. The tunnel cannot be
+// used after closed.
+func (t *Tunnel) Close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.closed = true
+	if t.client == nil {
+		return nil
+	}
+	err := t.client.Close()
+	t.client = nil
+	return err
+}
+
+// sshClient returns the SSH connection, establishing it if needed.
+func (t *Tunnel) sshClient(ctx context.Context) (*ssh.Client, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.closed {
+		return nil, net.ErrClosed
+	}
+	if t.client != nil {
+		return t.client, nil
+	}
+	client, err := t.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t.client = client
+	go func() {
+		_ = client.Wait()
+		t.drop(client)
+	}()
+	return client, nil
+}
+
+// drop closes client and forgets it if it is the current SSH connection.
+func (t *Tunnel) drop(client *ssh.Client) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.client == client {
+		_ = client.Close()
+		t.client = nil
+	}
+}
+
+func (t *Tunnel) connect(ctx context.Context) (*ssh.Client, error) {
+	configFunc := t.Config
+	if configFunc == nil {
+		configFunc = ClientConfig
+	}
+	config, err := configFunc(t.Jump)
+	if err != nil {
+		return nil, err
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", t.Jump.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SSH jump host %s: %w", t.Jump.Address, err)
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, t.Jump.Address, config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to establish SSH connection to %s: %w", t.Jump.Address, err)
+	}
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// ClientConfig returns the SSH client configuration for the jump host.
+// Users are authenticated with the SSH agent at $SSH_AUTH_SOCK and the default
+// private keys in ~/.ssh, and host keys are verified against
+// ~/.ssh/known_hosts.
+func ClientConfig(jump Jump) (*ssh.ClientConfig, error) {
+	username := jump.User
+	if username == "" {
+		current, err := user.Current()
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine the SSH user: %w", err)
+		}
+		username = current.Username
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	sshDir := filepath.Join(home, ".ssh")
+	hostKeyCallback, err := knownhosts.New(filepath.Join(sshDir, "known_hosts"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load SSH known hosts: %w", err)
+	}
+
+	var methods []ssh.AuthMethod
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+	var signers []ssh.Signer
+	for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+		key, err := os.ReadFile(filepath.Join(sshDir, name))
+		if err != nil {
+			continue
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			var passphraseErr *ssh.PassphraseMissingError
+			if errors.As(err, &passphraseErr) {
+				// encrypted keys are expected to be served by the agent
+				continue
+			}
+			return nil, fmt.Errorf("failed to parse SSH private key %s: %w", name, err)
+		}
+		signers = append(signers, signer)
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("no SSH credentials found: start an SSH agent or add a private key to ~/.ssh")
+	}
+	return &ssh.ClientConfig{
+		User:            username,
+		Auth:            methods,
+		HostKeyCallback: hostKeyCallback,
+	}, nil
+}
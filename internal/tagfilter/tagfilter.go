@@ -0,0 +1,97 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tagfilter selects tags by keep policies, e.g. to mirror only the
+// latest release of each major version instead of every historical tag.
+package tagfilter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Policy is a keep policy selecting tags by their semantic versions.
+type Policy string
+
+// Keep policies.
+const (
+	// PolicyLatest keeps the tags of the latest version.
+	PolicyLatest Policy = "latest"
+	// PolicyLatestPerMajor keeps the tags of the latest version of each major
+	// version, e.g. the latest 1.x and the latest 2.x.
+	PolicyLatestPerMajor Policy = "latest-per-major"
+	// PolicyLatestPerMinor keeps the tags of the latest version of each minor
+	// version, e.g. the latest 1.1.x and the latest 1.2.x.
+	PolicyLatestPerMinor Policy = "latest-per-minor"
+)
+
+// Policies are the supported keep policies.
+var Policies = []Policy{PolicyLatest, PolicyLatestPerMajor, PolicyLatestPerMinor}
+
+// ParsePolicy parses the keep policy named name.
+func ParsePolicy(name string) (Policy, error) {
+	for _, p := range Policies {
+		if string(p) == name {
+			return p, nil
+		}
+	}
+	names := make([]string, len(Policies))
+	for i, p := range Policies {
+		names[i] = string(p)
+	}
+	return "", fmt.Errorf("unknown keep policy %q, options: %s", name, strings.Join(names, ", "))
+}
+
+// Filter returns the tags kept by the policy, in their order in tags.
+// Tags are parsed as semantic versions, optionally prefixed by "v" and with
+// the minor or patch version omitted, e.g. "v1.2". Tags that are not semantic
+// versions and pre-release versions are not kept. All the tags of a kept
+// version are kept, e.g. both "v1.2" and "1.2.0".
+func (p Policy) Filter(tags []string) []string {
+	versions := make([]*semver.Version, len(tags))
+	latest := make(map[[2]uint64]*semver.Version)
+	for i, tag := range tags {
+		v, err := semver.NewVersion(tag)
+		if err != nil || v.Prerelease() != "" {
+			continue
+		}
+		versions[i] = v
+		if l, ok := latest[p.group(v)]; !ok || v.GreaterThan(l) {
+			latest[p.group(v)] = v
+		}
+	}
+	var kept []string
+	for i, tag := range tags {
+		if v := versions[i]; v != nil && v.Equal(latest[p.group(v)]) {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+// group returns the key of the group of versions of which the latest one is
+// kept.
+func (p Policy) group(v *semver.Version) [2]uint64 {
+	switch p {
+	case PolicyLatestPerMajor:
+		return [2]uint64{v.Major()}
+	case PolicyLatestPerMinor:
+		return [2]uint64{v.Major(), v.Minor()}
+	default:
+		return [2]uint64{}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tagfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePolicy(t *testing.T) {
+	for _, want := range Policies {
+		got, err := ParsePolicy(string(want))
+		if err != nil {
+			t.Fatalf("ParsePolicy(%q) error = %v", want, err)
+		}
+		if got != want {
+			t.Errorf("ParsePolicy(%q) = %q, want %q", want, got, want)
+		}
+	}
+	if _, err := ParsePolicy("newest"); err == nil {
+		t.Error("ParsePolicy(\"newest\") error = nil, want error")
+	}
+}
+
+func TestPolicy_Filter(t *testing.T) {
+	// unordered on purpose
+	tags := []string{
+		"v1.2.0",
+		"latest",
+		"1.10.1",
+		"v1.9.3",
+		"2.0.0-rc.1",
+		"v2.0",
+		"2.0.0",
+		"v1.10.0",
+		"0.9.9",
+		"1.9.3+build.1",
+	}
+	tests := []struct {
+		name   string
+		policy Policy
+		want   []string
+	}{
+		{
+			name:   "latest",
+			policy: PolicyLatest,
+			want:   []string{"v2.0", "2.0.0"},
+		},
+		{
+			name:   "latest per major",
+			policy: PolicyLatestPerMajor,
+			want:   []string{"1.10.1", "v2.0", "2.0.0", "0.9.9"},
+		},
+		{
+			name:   "latest per minor",
+			policy: PolicyLatestPerMinor,
+			want:   []string{"v1.2.0", "1.10.1", "v1.9.3", "v2.0", "2.0.0", "0.9.9", "1.9.3+build.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Filter(tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Policy.Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Filter_noVersions(t *testing.T) {
+	if got := PolicyLatestPerMajor.Filter([]string{"latest", "main", "1.0.0-alpha"}); len(got) != 0 {
+		t.Errorf("Policy.Filter() = %v, want none", got)
+	}
+}
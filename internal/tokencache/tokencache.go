@@ -0,0 +1,151 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tokencache provides an auth cache refreshing bearer tokens before
+// they expire.
+package tokencache
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// minRefreshMargin is the minimum time before the expiry of a token to
+// refresh it.
+const minRefreshMargin = 5 * time.Second
+
+// entry records how to refresh a cached bearer token.
+type entry struct {
+	token     string
+	issuedAt  time.Time
+	expiresAt time.Time
+	fetch     func(context.Context) (string, error)
+}
+
+// Cache is an auth.Cache refreshing bearer tokens proactively when they are
+// about to expire, so that long running operations such as copying large
+// blobs do not fail with stale tokens.
+// The expiry of a token is read from the "exp" claim of JWT tokens. Tokens in
+// other formats are refreshed by the auth client on 401 responses only.
+type Cache struct {
+	base auth.Cache
+	// now returns the current time.
+	now func() time.Time
+
+	lock    sync.Mutex
+	entries map[string]*entry
+}
+
+// New returns a cache refreshing bearer tokens stored in a new auth cache.
+func New() *Cache {
+	return &Cache{
+		base:    auth.NewCache(),
+		now:     time.Now,
+		entries: make(map[string]*entry),
+	}
+}
+
+// GetScheme returns the auth-scheme part cached for the given registry.
+func (c *Cache) GetScheme(ctx context.Context, registry string) (auth.Scheme, error) {
+	return c.base.GetScheme(ctx, registry)
+}
+
+// GetToken returns the auth-token part cached for the given registry of a
+// given scheme. A bearer token about to expire is refreshed first.
+func (c *Cache) GetToken(ctx context.Context, registry string, scheme auth.Scheme, key string) (string, error) {
+	token, err := c.base.GetToken(ctx, registry, scheme, key)
+	if err != nil || scheme != auth.SchemeBearer {
+		return token, err
+	}
+	c.lock.Lock()
+	e := c.entries[entryKey(registry, key)]
+	c.lock.Unlock()
+	if e == nil || e.token != token || e.expiresAt.IsZero() {
+		return token, nil
+	}
+	now := c.now()
+	margin := max(e.expiresAt.Sub(e.issuedAt)/5, minRefreshMargin)
+	if now.Add(margin).Before(e.expiresAt) {
+		return token, nil
+	}
+	refreshed, err := c.Set(ctx, registry, scheme, key, e.fetch)
+	if err != nil {
+		if now.Before(e.expiresAt) {
+			// still valid, fail later if the registry rejects it
+			return token, nil
+		}
+		return "", err
+	}
+	return refreshed, nil
+}
+
+// Set fetches the token using the given fetch function and caches the token
+// for the given scheme with the given key for the given registry.
+func (c *Cache) Set(ctx context.Context, registry string, scheme auth.Scheme, key string, fetch func(context.Context) (string, error)) (string, error) {
+	token, err := c.base.Set(ctx, registry, scheme, key, fetch)
+	if err != nil || scheme != auth.SchemeBearer {
+		return token, err
+	}
+	issuedAt, expiresAt := tokenLifetime(token)
+	if !expiresAt.IsZero() && issuedAt.IsZero() {
+		issuedAt = c.now()
+	}
+	c.lock.Lock()
+	c.entries[entryKey(registry, key)] = &entry{
+		token:     token,
+		issuedAt:  issuedAt,
+		expiresAt: expiresAt,
+		fetch:     fetch,
+	}
+	c.lock.Unlock()
+	return token, nil
+}
+
+func entryKey(registry, key string) string {
+	return registry + " " + key
+}
+
+// tokenLifetime returns the "iat" and "exp" claims of a JWT token. Zero times
+// are returned if the token is not a JWT token or the claims are absent.
+func tokenLifetime(token string) (issuedAt, expiresAt time.Time) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return
+	}
+	var claims struct {
+		IssuedAt  int64 `json:"iat"`
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return
+	}
+	if claims.IssuedAt > 0 {
+		issuedAt = time.Unix(claims.IssuedAt, 0)
+	}
+	if claims.ExpiresAt > 0 {
+		expiresAt = time.Unix(claims.ExpiresAt, 0)
+	}
+	return
+}
@@ -0,0 +1,136 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokencache
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func jwt(issuedAt, expiresAt time.Time, id int) string {
+	payload := fmt.Sprintf(`{"iat":%d,"exp":%d,"jti":"%d"}`, issuedAt.Unix(), expiresAt.Unix(), id)
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig"
+}
+
+func TestCache_GetToken_refresh(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1700000000, 0)
+	now := start
+	cache := New()
+	cache.now = func() time.Time { return now }
+
+	fetches := 0
+	fetch := func(context.Context) (string, error) {
+		fetches++
+		return jwt(now, now.Add(100*time.Second), fetches), nil
+	}
+	first, err := cache.Set(ctx, "registry.example.com", auth.SchemeBearer, "repository:foo:pull", fetch)
+	if err != nil {
+		t.Fatalf("Cache.Set() error = %v", err)
+	}
+
+	now = start.Add(10 * time.Second)
+	got, err := cache.GetToken(ctx, "registry.example.com", auth.SchemeBearer, "repository:foo:pull")
+	if err != nil {
+		t.Fatalf("Cache.GetToken() error = %v", err)
+	}
+	if got != first || fetches != 1 {
+		t.Fatalf("Cache.GetToken() refreshed a fresh token, fetches = %d", fetches)
+	}
+
+	// within the last 20% of the lifetime
+	now = start.Add(85 * time.Second)
+	got, err = cache.GetToken(ctx, "registry.example.com", auth.SchemeBearer, "repository:foo:pull")
+	if err != nil {
+		t.Fatalf("Cache.GetToken() error = %v", err)
+	}
+	if got == first || fetches != 2 {
+		t.Fatalf("Cache.GetToken() did not refresh an expiring token, fetches = %d", fetches)
+	}
+	if scheme, err := cache.GetScheme(ctx, "registry.example.com"); err != nil || scheme != auth.SchemeBearer {
+		t.Errorf("Cache.GetScheme() = %v, %v, want %v", scheme, err, auth.SchemeBearer)
+	}
+}
+
+func TestCache_GetToken_refreshFailure(t *testing.T) {
+	ctx := context.Background()
+	start := time.Unix(1700000000, 0)
+	now := start
+	cache := New()
+	cache.now = func() time.Time { return now }
+
+	fail := false
+	fetch := func(context.Context) (string, error) {
+		if fail {
+			return "", errors.New("token service unavailable")
+		}
+		return jwt(now, now.Add(100*time.Second), 0), nil
+	}
+	token, err := cache.Set(ctx, "registry.example.com", auth.SchemeBearer, "", fetch)
+	if err != nil {
+		t.Fatalf("Cache.Set() error = %v", err)
+	}
+	fail = true
+
+	now = start.Add(90 * time.Second)
+	if got, err := cache.GetToken(ctx, "registry.example.com", auth.SchemeBearer, ""); err != nil || got != token {
+		t.Errorf("Cache.GetToken() = %q, %v, want the valid token", got, err)
+	}
+	now = start.Add(101 * time.Second)
+	if _, err := cache.GetToken(ctx, "registry.example.com", auth.SchemeBearer, ""); err == nil {
+		t.Error("Cache.GetToken() error = nil, want error for an expired token")
+	}
+}
+
+func TestCache_GetToken_opaque(t *testing.T) {
+	ctx := context.Background()
+	cache := New()
+	fetches := 0
+	fetch := func(context.Context) (string, error) {
+		fetches++
+		return "opaque-token", nil
+	}
+	if _, err := cache.Set(ctx, "registry.example.com", auth.SchemeBearer, "", fetch); err != nil {
+		t.Fatalf("Cache.Set() error = %v", err)
+	}
+	cache.now = func() time.Time { return time.Now().Add(24 * time.Hour) }
+	if got, err := cache.GetToken(ctx, "registry.example.com", auth.SchemeBearer, ""); err != nil || got != "opaque-token" {
+		t.Errorf("Cache.GetToken() = %q, %v, want %q", got, err, "opaque-token")
+	}
+	if fetches != 1 {
+		t.Errorf("fetches = %d, want 1", fetches)
+	}
+}
+
+func TestTokenLifetime(t *testing.T) {
+	issuedAt := time.Unix(1700000000, 0)
+	expiresAt := issuedAt.Add(time.Minute)
+	gotIssuedAt, gotExpiresAt := tokenLifetime(jwt(issuedAt, expiresAt, 0))
+	if !gotIssuedAt.Equal(issuedAt) || !gotExpiresAt.Equal(expiresAt) {
+		t.Errorf("tokenLifetime() = %v, %v, want %v, %v", gotIssuedAt, gotExpiresAt, issuedAt, expiresAt)
+	}
+	for _, token := range []string{"opaque", "a.b.c", "a." + base64.RawURLEncoding.EncodeToString([]byte("{}")) + ".c"} {
+		if gotIssuedAt, gotExpiresAt := tokenLifetime(token); !gotIssuedAt.IsZero() || !gotExpiresAt.IsZero() {
+			t.Errorf("tokenLifetime(%q) = %v, %v, want zero times", token, gotIssuedAt, gotExpiresAt)
+		}
+	}
+}
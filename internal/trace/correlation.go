@@ -0,0 +1,95 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HeaderTraceParent is the W3C trace context header carrying the trace ID.
+const HeaderTraceParent = "traceparent"
+
+// NewTraceID returns a random trace ID of 32 lowercase hexadecimal digits,
+// which is valid for both the W3C trace context and request ID headers.
+func NewTraceID() string {
+	return randomHex(16)
+}
+
+// ValidateTraceID validates the trace ID sent in header. The trace ID sent in
+// the traceparent header must be 32 lowercase hexadecimal digits and not all
+// zeros.
+func ValidateTraceID(header, traceID string) error {
+	if traceID == "" {
+		return errors.New("empty trace ID")
+	}
+	if !strings.EqualFold(header, HeaderTraceParent) {
+		return nil
+	}
+	if len(traceID) != 32 || strings.Trim(traceID, "0123456789abcdef") != "" {
+		return fmt.Errorf("invalid trace ID %q for the %s header: expecting 32 lowercase hexadecimal digits", traceID, HeaderTraceParent)
+	}
+	if strings.Trim(traceID, "0") == "" {
+		return fmt.Errorf("invalid trace ID %q for the %s header: all zeros", traceID, HeaderTraceParent)
+	}
+	return nil
+}
+
+// CorrelationTransport is an http.RoundTripper sending the trace ID in a
+// correlation header, such as traceparent or X-Request-Id, with every request.
+type CorrelationTransport struct {
+	http.RoundTripper
+	// Header is the name of the correlation header.
+	Header string
+	// TraceID is the trace ID shared by all the requests.
+	TraceID string
+}
+
+// NewCorrelationTransport returns a transport sending traceID in header with
+// every request sent by base.
+func NewCorrelationTransport(base http.RoundTripper, header, traceID string) *CorrelationTransport {
+	return &CorrelationTransport{
+		RoundTripper: base,
+		Header:       header,
+		TraceID:      traceID,
+	}
+}
+
+// RoundTrip sets the correlation header of req, if not set, and sends it. In
+// the traceparent header, every request is a span of its own.
+func (t *CorrelationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get(t.Header) != "" {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	value := t.TraceID
+	if strings.EqualFold(t.Header, HeaderTraceParent) {
+		value = fmt.Sprintf("00-%s-%s-01", t.TraceID, randomHex(8))
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(t.Header, value)
+	return t.RoundTripper.RoundTrip(req)
+}
+
+// randomHex returns n random bytes in hexadecimal.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
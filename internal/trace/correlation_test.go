@@ -0,0 +1,86 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestValidateTraceID(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		traceID string
+		wantErr bool
+	}{
+		{name: "generated", header: HeaderTraceParent, traceID: NewTraceID()},
+		{name: "request ID", header: "X-Request-Id", traceID: "incident-42"},
+		{name: "empty", header: "X-Request-Id", traceID: "", wantErr: true},
+		{name: "not hexadecimal", header: HeaderTraceParent, traceID: "incident-42", wantErr: true},
+		{name: "uppercase", header: HeaderTraceParent, traceID: "4BF92F3577B34DA6A3CE929D0E0E4736", wantErr: true},
+		{name: "all zeros", header: HeaderTraceParent, traceID: "00000000000000000000000000000000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTraceID(tt.header, tt.traceID); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTraceID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCorrelationTransport_RoundTrip(t *testing.T) {
+	var got []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("traceparent"), r.Header.Get("X-Request-Id"))
+	}))
+	defer ts.Close()
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	client := &http.Client{Transport: NewCorrelationTransport(http.DefaultTransport, HeaderTraceParent, traceID)}
+	for range 2 {
+		resp, err := client.Get(ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	traceParent := regexp.MustCompile(`^00-` + traceID + `-[0-9a-f]{16}-01$`)
+	if !traceParent.MatchString(got[0]) || !traceParent.MatchString(got[2]) {
+		t.Errorf("traceparent headers = %q, %q, want trace ID %s", got[0], got[2], traceID)
+	}
+	if got[0] == got[2] {
+		t.Errorf("traceparent headers of different requests have the same parent ID %q", got[0])
+	}
+
+	got = nil
+	client.Transport = NewCorrelationTransport(http.DefaultTransport, "X-Request-Id", "incident-42")
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if got[0] != "" || got[1] != "incident-42" {
+		t.Errorf("headers = %q, want only X-Request-Id %q", got, "incident-42")
+	}
+	if req.Header.Get("X-Request-Id") != "" {
+		t.Error("the original request is modified")
+	}
+}
@@ -0,0 +1,444 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trust manages the trusted signing roots and the trust policies
+// selecting the roots trusted for the artifacts of repositories.
+package trust
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"oras.land/oras/internal/config"
+)
+
+const (
+	// RootsDir is the directory of the trusted roots in the trust store.
+	RootsDir = "roots"
+	// PolicyFile is the file of the trust policies in the trust store.
+	PolicyFile = "policy.json"
+	// PolicyVersion is the version of the trust policy file.
+	PolicyVersion = "1.0"
+
+	// rootExt is the file extension of trusted roots.
+	rootExt = ".pem"
+)
+
+// Root types.
+const (
+	TypeCertificate = "certificate"
+	TypePublicKey   = "public key"
+)
+
+// Verification levels of trust policies.
+const (
+	// VerificationEnforce fails the operation if the artifact is not signed
+	// by a trusted root.
+	VerificationEnforce = "enforce"
+	// VerificationAudit logs the verification failures.
+	VerificationAudit = "audit"
+	// VerificationSkip skips the verification.
+	VerificationSkip = "skip"
+)
+
+var (
+	// ErrNotFound is returned when a root or a policy is not found.
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists is returned when adding a root or a policy with an
+	// existing name.
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrInUse is returned when removing a root used by policies.
+	ErrInUse = errors.New("in use")
+)
+
+// namePattern is the pattern of root and policy names.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Root describes a trusted signing root, which is an X.509 certificate or a
+// public key.
+type Root struct {
+	// Name is the name of the root.
+	Name string `json:"name"`
+	// Type is the type of the root, TypeCertificate or TypePublicKey.
+	Type string `json:"type"`
+	// Fingerprint is the SHA-256 digest of the DER encoded certificate or
+	// public key.
+	Fingerprint string `json:"fingerprint"`
+	// Subject is the subject of the certificate.
+	Subject string `json:"subject,omitempty"`
+	// NotAfter is the expiry time of the certificate.
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+	// PublicKey is the public key of the root.
+	PublicKey crypto.PublicKey `json:"-"`
+}
+
+// Policy selects the roots trusted for the artifacts in the repositories
+// matching its scopes.
+type Policy struct {
+	// Name is the name of the policy.
+	Name string `json:"name"`
+	// Scopes are the path.Match patterns of the repositories, in the form of
+	// <registry>/<repository>, applying the policy. "*" matches all
+	// repositories.
+	Scopes []string `json:"scopes"`
+	// TrustedRoots are the names of the trusted roots.
+	TrustedRoots []string `json:"trustedRoots,omitempty"`
+	// Verification is the verification level, which is VerificationEnforce,
+	// VerificationAudit or VerificationSkip.
+	Verification string `json:"verification"`
+}
+
+// Matches returns true if the policy applies to repository.
+func (p *Policy) Matches(repository string) bool {
+	for _, scope := range p.Scopes {
+		if scope == "*" {
+			return true
+		}
+		if ok, _ := path.Match(scope, repository); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// policyDocument is the content of the trust policy file.
+type policyDocument struct {
+	Version  string   `json:"version"`
+	Policies []Policy `json:"policies"`
+}
+
+// Store is a trust store in a directory.
+type Store struct {
+	dir string
+}
+
+// NewStore returns the trust store in the directory dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultDir returns the directory of the trust store, which is "trust" next
+// to the oras config file.
+func DefaultDir() (string, error) {
+	configPath, err := config.Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "trust"), nil
+}
+
+// Dir returns the directory of the trust store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// ParseRoot parses a PEM encoded X.509 certificate or public key as the
+// trusted root name.
+func ParseRoot(name string, data []byte) (*Root, error) {
+	block, rest := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded certificate or public key found")
+	}
+	if next, _ := pem.Decode(rest); next != nil {
+		return nil, errors.New("multiple PEM blocks found, add each certificate or public key as a separate root")
+	}
+	sum := sha256.Sum256(block.Bytes)
+	root := &Root{
+		Name:        name,
+		Fingerprint: "sha256:" + hex.EncodeToString(sum[:]),
+	}
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		root.Type = TypeCertificate
+		root.Subject = cert.Subject.String()
+		root.NotAfter = &cert.NotAfter
+		root.PublicKey = cert.PublicKey
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		root.Type = TypePublicKey
+		root.PublicKey = key
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q, expecting a certificate or a public key", block.Type)
+	}
+	return root, nil
+}
+
+// AddRoot adds the PEM encoded certificate or public key in data as the
+// trusted root name. An existing root is replaced if overwrite is true.
+func (s *Store) AddRoot(name string, data []byte, overwrite bool) (*Root, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	root, err := ParseRoot(name, data)
+	if err != nil {
+		return nil, err
+	}
+	rootPath := s.rootPath(name)
+	if _, err := os.Stat(rootPath); err == nil && !overwrite {
+		return nil, fmt.Errorf("root %q: %w", name, ErrAlreadyExists)
+	}
+	if err := os.MkdirAll(filepath.Dir(rootPath), 0700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(rootPath, data, 0600); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// Root returns the trusted root name.
+func (s *Store) Root(name string) (*Root, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(s.rootPath(name))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("root %q: %w", name, ErrNotFound)
+		}
+		return nil, err
+	}
+	root, err := ParseRoot(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("root %q: %w", name, err)
+	}
+	return root, nil
+}
+
+// Roots returns the trusted roots sorted by name.
+func (s *Store) Roots() ([]*Root, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, RootsDir))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	roots := []*Root{}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), rootExt)
+		if !ok || !entry.Type().IsRegular() {
+			continue
+		}
+		root, err := s.Root(name)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, root)
+	}
+	return roots, nil
+}
+
+// RemoveRoot removes the trusted root name. ErrInUse is returned if the root
+// is trusted by policies.
+func (s *Store) RemoveRoot(name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	policies, err := s.Policies()
+	if err != nil {
+		return err
+	}
+	var users []string
+	for _, p := range policies {
+		if slices.Contains(p.TrustedRoots, name) {
+			users = append(users, p.Name)
+		}
+	}
+	if len(users) > 0 {
+		return fmt.Errorf("root %q is trusted by the policies %s: %w", name, strings.Join(users, ", "), ErrInUse)
+	}
+	if err := os.Remove(s.rootPath(name)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("root %q: %w", name, ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+// Policies returns the trust policies in order.
+func (s *Store) Policies() ([]Policy, error) {
+	doc, err := s.readPolicies()
+	if err != nil {
+		return nil, err
+	}
+	return doc.Policies, nil
+}
+
+// AddPolicy adds the trust policy p. An existing policy of the same name is
+// replaced in place if overwrite is true.
+func (s *Store) AddPolicy(p Policy, overwrite bool) error {
+	if err := validateName(p.Name); err != nil {
+		return err
+	}
+	if p.Verification == "" {
+		p.Verification = VerificationEnforce
+	}
+	if err := s.validatePolicy(p); err != nil {
+		return fmt.Errorf("policy %q: %w", p.Name, err)
+	}
+	doc, err := s.readPolicies()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(doc.Policies, func(existing Policy) bool {
+		return existing.Name == p.Name
+	})
+	switch {
+	case i < 0:
+		doc.Policies = append(doc.Policies, p)
+	case overwrite:
+		doc.Policies[i] = p
+	default:
+		return fmt.Errorf("policy %q: %w", p.Name, ErrAlreadyExists)
+	}
+	return s.writePolicies(doc)
+}
+
+// RemovePolicy removes the trust policy name.
+func (s *Store) RemovePolicy(name string) error {
+	doc, err := s.readPolicies()
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(doc.Policies, func(p Policy) bool {
+		return p.Name == name
+	})
+	if i < 0 {
+		return fmt.Errorf("policy %q: %w", name, ErrNotFound)
+	}
+	doc.Policies = slices.Delete(doc.Policies, i, i+1)
+	return s.writePolicies(doc)
+}
+
+// Match returns the first trust policy applying to repository, in the form of
+// <registry>/<repository>. ErrNotFound is returned if no policy applies.
+func (s *Store) Match(repository string) (*Policy, error) {
+	policies, err := s.Policies()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range policies {
+		if p.Matches(repository) {
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("trust policy for %s: %w", repository, ErrNotFound)
+}
+
+// PublicKeys returns the public keys of the roots trusted by policy p.
+func (s *Store) PublicKeys(p *Policy) ([]crypto.PublicKey, error) {
+	keys := make([]crypto.PublicKey, 0, len(p.TrustedRoots))
+	for _, name := range p.TrustedRoots {
+		root, err := s.Root(name)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, root.PublicKey)
+	}
+	return keys, nil
+}
+
+// validatePolicy validates the scopes, the trusted roots and the verification
+// level of p.
+func (s *Store) validatePolicy(p Policy) error {
+	if len(p.Scopes) == 0 {
+		return errors.New("no scopes specified")
+	}
+	for _, scope := range p.Scopes {
+		if _, err := path.Match(scope, ""); err != nil {
+			return fmt.Errorf("invalid scope %q: %w", scope, err)
+		}
+	}
+	switch p.Verification {
+	case VerificationEnforce, VerificationAudit:
+		if len(p.TrustedRoots) == 0 {
+			return fmt.Errorf("no trusted roots specified for the verification level %q", p.Verification)
+		}
+	case VerificationSkip:
+	default:
+		return fmt.Errorf("unknown verification level %q, expecting %q, %q or %q", p.Verification, VerificationEnforce, VerificationAudit, VerificationSkip)
+	}
+	for _, name := range p.TrustedRoots {
+		if _, err := s.Root(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readPolicies reads the trust policy file. An empty document is returned if
+// the file does not exist.
+func (s *Store) readPolicies() (*policyDocument, error) {
+	doc := &policyDocument{Version: PolicyVersion, Policies: []Policy{}}
+	data, err := os.ReadFile(filepath.Join(s.dir, PolicyFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return doc, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("invalid trust policy file: %w", err)
+	}
+	if doc.Version != PolicyVersion {
+		return nil, fmt.Errorf("unsupported trust policy file version %q", doc.Version)
+	}
+	return doc, nil
+}
+
+// writePolicies writes the trust policy file.
+func (s *Store) writePolicies(doc *policyDocument) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, PolicyFile), append(data, '\n'), 0600)
+}
+
+// rootPath returns the path of the trusted root name.
+func (s *Store) rootPath(name string) string {
+	return filepath.Join(s.dir, RootsDir, name+rootExt)
+}
+
+// validateName validates the name of a root or a policy.
+func validateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid name %q, expecting letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trust
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestRoots returns a PEM encoded self-signed certificate and a PEM
+// encoded public key.
+func newTestRoots(t *testing.T) (certPEM []byte, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test root"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: keyDER})
+}
+
+func TestParseRoot(t *testing.T) {
+	certPEM, keyPEM := newTestRoots(t)
+	tests := []struct {
+		name     string
+		data     []byte
+		wantType string
+		wantErr  bool
+	}{
+		{name: "certificate", data: certPEM, wantType: TypeCertificate},
+		{name: "public key", data: keyPEM, wantType: TypePublicKey},
+		{name: "multiple blocks", data: append(certPEM, keyPEM...), wantErr: true},
+		{name: "not PEM", data: []byte("not PEM"), wantErr: true},
+		{name: "private key", data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}), wantErr: true},
+		{name: "invalid certificate", data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("cert")}), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := ParseRoot("test", tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRoot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if root.Type != tt.wantType {
+				t.Errorf("ParseRoot() type = %q, want %q", root.Type, tt.wantType)
+			}
+			if root.PublicKey == nil {
+				t.Error("ParseRoot() public key = nil")
+			}
+			if tt.wantType == TypeCertificate && (root.Subject != "CN=test root" || root.NotAfter == nil) {
+				t.Errorf("ParseRoot() subject = %q, notAfter = %v", root.Subject, root.NotAfter)
+			}
+		})
+	}
+}
+
+func TestStore_roots(t *testing.T) {
+	certPEM, keyPEM := newTestRoots(t)
+	s := NewStore(t.TempDir())
+
+	if roots, err := s.Roots(); err != nil || len(roots) != 0 {
+		t.Fatalf("Store.Roots() = %v, %v, want empty", roots, err)
+	}
+	if _, err := s.AddRoot("b-key", keyPEM, false); err != nil {
+		t.Fatalf("Store.AddRoot() error = %v", err)
+	}
+	if _, err := s.AddRoot("a-cert", certPEM, false); err != nil {
+		t.Fatalf("Store.AddRoot() error = %v", err)
+	}
+	if _, err := s.AddRoot("a-cert", keyPEM, false); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("Store.AddRoot() error = %v, want %v", err, ErrAlreadyExists)
+	}
+	if root, err := s.AddRoot("a-cert", certPEM, true); err != nil || root.Type != TypeCertificate {
+		t.Errorf("Store.AddRoot() overwrite = %v, %v", root, err)
+	}
+	if _, err := s.AddRoot("../escape", certPEM, false); err == nil {
+		t.Error("Store.AddRoot() error = nil, want error for invalid name")
+	}
+
+	roots, err := s.Roots()
+	if err != nil {
+		t.Fatalf("Store.Roots() error = %v", err)
+	}
+	var names []string
+	for _, root := range roots {
+		names = append(names, root.Name)
+	}
+	if want := []string{"a-cert", "b-key"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Store.Roots() names = %v, want %v", names, want)
+	}
+
+	if err := s.RemoveRoot("b-key"); err != nil {
+		t.Fatalf("Store.RemoveRoot() error = %v", err)
+	}
+	if err := s.RemoveRoot("b-key"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Store.RemoveRoot() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestStore_policies(t *testing.T) {
+	certPEM, _ := newTestRoots(t)
+	s := NewStore(t.TempDir())
+	if _, err := s.AddRoot("prod", certPEM, false); err != nil {
+		t.Fatal(err)
+	}
+
+	prod := Policy{Name: "prod", Scopes: []string{"registry.example.com/prod/*"}, TrustedRoots: []string{"prod"}}
+	all := Policy{Name: "all", Scopes: []string{"*"}, Verification: VerificationSkip}
+	for _, p := range []Policy{prod, all} {
+		if err := s.AddPolicy(p, false); err != nil {
+			t.Fatalf("Store.AddPolicy(%s) error = %v", p.Name, err)
+		}
+	}
+	for _, p := range []Policy{
+		{Name: "prod", Scopes: []string{"*"}, Verification: VerificationSkip},
+		{Name: "no-scope", TrustedRoots: []string{"prod"}},
+		{Name: "bad-scope", Scopes: []string{"["}, TrustedRoots: []string{"prod"}},
+		{Name: "no-root", Scopes: []string{"*"}},
+		{Name: "missing-root", Scopes: []string{"*"}, TrustedRoots: []string{"missing"}},
+		{Name: "bad-level", Scopes: []string{"*"}, TrustedRoots: []string{"prod"}, Verification: "strict"},
+	} {
+		if err := s.AddPolicy(p, false); err == nil {
+			t.Errorf("Store.AddPolicy(%s) error = nil, want error", p.Name)
+		}
+	}
+
+	policies, err := s.Policies()
+	if err != nil {
+		t.Fatalf("Store.Policies() error = %v", err)
+	}
+	prod.Verification = VerificationEnforce
+	if want := []Policy{prod, all}; !reflect.DeepEqual(policies, want) {
+		t.Errorf("Store.Policies() = %v, want %v", policies, want)
+	}
+
+	tests := []struct {
+		repository string
+		want       string
+	}{
+		{repository: "registry.example.com/prod/app", want: "prod"},
+		{repository: "registry.example.com/dev/app", want: "all"},
+	}
+	for _, tt := range tests {
+		p, err := s.Match(tt.repository)
+		if err != nil || p.Name != tt.want {
+			t.Errorf("Store.Match(%s) = %v, %v, want %s", tt.repository, p, err, tt.want)
+		}
+	}
+	keys, err := s.PublicKeys(&prod)
+	if err != nil || len(keys) != 1 {
+		t.Errorf("Store.PublicKeys() = %v, %v, want 1 key", keys, err)
+	}
+
+	if err := s.RemoveRoot("prod"); !errors.Is(err, ErrInUse) {
+		t.Errorf("Store.RemoveRoot() error = %v, want %v", err, ErrInUse)
+	}
+	if err := s.RemovePolicy("all"); err != nil {
+		t.Fatalf("Store.RemovePolicy() error = %v", err)
+	}
+	if err := s.RemovePolicy("all"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Store.RemovePolicy() error = %v, want %v", err, ErrNotFound)
+	}
+	if _, err := s.Match("registry.example.com/dev/app"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Store.Match() error = %v, want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uploadsession
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is a journal entry of an upload session.
+type Entry struct {
+	// URL is the location of the upload session.
+	URL string `json:"url"`
+	// Repository is the repository of the session in the form of
+	// registry/repository, e.g. localhost:5000/hello.
+	Repository string `json:"repository"`
+	// CreatedAt is the time the session was opened.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Journal persists the open upload sessions in a directory, one file per
+// session, so that sessions left open by crashed processes can be cleaned up
+// later.
+type Journal struct {
+	Dir string
+}
+
+// DefaultJournal returns the journal in the user cache directory.
+func DefaultJournal() (*Journal, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{Dir: filepath.Join(dir, "oras", "uploads")}, nil
+}
+
+// Add records an open upload session.
+func (j *Journal) Add(entry Entry) error {
+	if err := os.MkdirAll(j.Dir, 0700); err != nil {
+		return err
+	}
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(j.path(entry.URL), content, 0600)
+}
+
+// Remove removes the upload session located at url.
+func (j *Journal) Remove(url string) error {
+	err := os.Remove(j.path(url))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// List returns the recorded upload sessions of repository, or all the
+// recorded sessions if repository is empty. Unreadable entries are skipped.
+func (j *Journal) List(repository string) ([]Entry, error) {
+	files, err := os.ReadDir(j.Dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var entries []Entry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(j.Dir, f.Name()))
+		if err != nil {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(content, &entry); err != nil {
+			continue
+		}
+		if repository == "" || entry.Repository == repository {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// path returns the path of the journal file of the session at url. The query
+// of url, which may change as the upload progresses, is ignored.
+func (j *Journal) path(url string) string {
+	url, _, _ = strings.Cut(url, "?")
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(j.Dir, hex.EncodeToString(sum[:])+".json")
+}
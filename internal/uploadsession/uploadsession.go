@@ -0,0 +1,229 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package uploadsession tracks the blob upload sessions opened on registries
+// so that they can be cancelled when a command is interrupted.
+package uploadsession
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// session is an open blob upload session.
+type session struct {
+	location      *url.URL
+	authorization string
+	transport     http.RoundTripper
+}
+
+// Tracker tracks the open blob upload sessions.
+type Tracker struct {
+	// Journal, if not nil, persists the open sessions.
+	Journal *Journal
+
+	lock     sync.Mutex
+	sessions map[string]session
+}
+
+// NewTracker returns a new upload session tracker.
+func NewTracker() *Tracker {
+	return &Tracker{
+		sessions: make(map[string]session),
+	}
+}
+
+type contextKey struct{}
+
+// WithTracker returns a context carrying t.
+func WithTracker(ctx context.Context, t *Tracker) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the tracker carried by ctx, or nil if none.
+func FromContext(ctx context.Context) *Tracker {
+	if ctx == nil {
+		return nil
+	}
+	t, _ := ctx.Value(contextKey{}).(*Tracker)
+	return t
+}
+
+// Transport returns a transport recording the upload sessions opened and
+// completed through base.
+func (t *Tracker) Transport(base http.RoundTripper) http.RoundTripper {
+	return &transport{tracker: t, base: base}
+}
+
+// Len returns the number of open upload sessions.
+func (t *Tracker) Len() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return len(t.sessions)
+}
+
+// Cancel cancels all the open upload sessions by sending DELETE requests and
+// returns the number of sessions cancelled.
+func (t *Tracker) Cancel(ctx context.Context) (int, error) {
+	t.lock.Lock()
+	sessions := make([]session, 0, len(t.sessions))
+	for _, s := range t.sessions {
+		sessions = append(sessions, s)
+	}
+	clear(t.sessions)
+	t.lock.Unlock()
+
+	var cancelled int
+	var errs []error
+	for _, s := range sessions {
+		if _, err := Cancel(ctx, s.transport, s.location, s.authorization); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		// sessions not found are counted as they do not exist anymore
+		cancelled++
+		t.forget(s.location)
+	}
+	return cancelled, errors.Join(errs...)
+}
+
+// Status is the result of cancelling an upload session.
+type Status int
+
+const (
+	// StatusCancelled means the session is cancelled.
+	StatusCancelled Status = iota
+	// StatusExpired means the session does not exist anymore, e.g. it
+	// has been completed, cancelled or expired.
+	StatusExpired
+)
+
+// Cancel cancels the upload session at location by sending a DELETE request
+// through transport with the given authorization header value, if any.
+func Cancel(ctx context.Context, transport http.RoundTripper, location *url.URL, authorization string) (Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, location.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusAccepted, http.StatusOK:
+		return StatusCancelled, nil
+	case http.StatusNotFound:
+		return StatusExpired, nil
+	default:
+		return 0, fmt.Errorf("failed to cancel upload session %s: %s", location.Path, resp.Status)
+	}
+}
+
+func (t *Tracker) add(key string, s session) {
+	t.lock.Lock()
+	t.sessions[key] = s
+	t.lock.Unlock()
+	if t.Journal != nil {
+		// best effort
+		_ = t.Journal.Add(Entry{
+			URL:        s.location.String(),
+			Repository: s.location.Host + "/" + repositoryName(s.location.Path),
+			CreatedAt:  time.Now(),
+		})
+	}
+}
+
+func (t *Tracker) remove(location *url.URL) {
+	t.lock.Lock()
+	delete(t.sessions, location.Path)
+	t.lock.Unlock()
+	t.forget(location)
+}
+
+// forget removes the session at location from the journal.
+func (t *Tracker) forget(location *url.URL) {
+	if t.Journal != nil {
+		_ = t.Journal.Remove(location.String())
+	}
+}
+
+// transport records upload sessions from the requests and responses.
+type transport struct {
+	tracker *Tracker
+	base    http.RoundTripper
+}
+
+// RoundTrip sends req and records the upload session it opens, updates or
+// closes.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	path := req.URL.Path
+	switch {
+	case req.Method == http.MethodPost && strings.HasSuffix(strings.TrimSuffix(path, "/"), "/blobs/uploads"):
+		if resp.StatusCode == http.StatusAccepted {
+			t.record(req, resp)
+		}
+	case isSessionPath(path) && req.Method == http.MethodPatch:
+		if resp.StatusCode == http.StatusAccepted {
+			t.tracker.remove(req.URL)
+			t.record(req, resp)
+		}
+	case isSessionPath(path) && (req.Method == http.MethodPut || req.Method == http.MethodDelete):
+		if resp.StatusCode < http.StatusBadRequest || resp.StatusCode == http.StatusNotFound {
+			t.tracker.remove(req.URL)
+		}
+	}
+	return resp, nil
+}
+
+// record records the upload session located by the Location header of resp.
+func (t *transport) record(req *http.Request, resp *http.Response) {
+	location, err := resp.Location()
+	if err != nil {
+		return
+	}
+	t.tracker.add(location.Path, session{
+		location:      location,
+		authorization: req.Header.Get("Authorization"),
+		transport:     t.base,
+	})
+}
+
+// repositoryName returns the repository name in the path of an upload
+// session.
+func repositoryName(path string) string {
+	name, _, _ := strings.Cut(strings.TrimPrefix(path, "/v2/"), "/blobs/uploads")
+	return name
+}
+
+// isSessionPath reports whether path is the path of an upload session, i.e.
+// /v2/<name>/blobs/uploads/<reference>.
+func isSessionPath(path string) bool {
+	_, reference, ok := strings.Cut(path, "/blobs/uploads/")
+	return ok && reference != "" && strings.HasPrefix(path, "/v2/")
+}
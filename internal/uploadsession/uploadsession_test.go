@@ -0,0 +1,157 @@
+/*
+Copyright The ORAS Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uploadsession
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTracker(t *testing.T) {
+	var sessionID atomic.Int32
+	var lock sync.Mutex
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v2/test/blobs/uploads/":
+			w.Header().Set("Location", fmt.Sprintf("/v2/test/blobs/uploads/%d?_state=0", sessionID.Add(1)))
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPatch:
+			w.Header().Set("Location", r.URL.Path+"?_state=1")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete:
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			lock.Lock()
+			deleted = append(deleted, r.URL.Path)
+			lock.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	tracker := NewTracker()
+	client := &http.Client{Transport: tracker.Transport(http.DefaultTransport)}
+	send := func(method, path string) {
+		t.Helper()
+		req, err := http.NewRequest(method, ts.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer token")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	send(http.MethodPost, "/v2/test/blobs/uploads/")
+	send(http.MethodPost, "/v2/test/blobs/uploads/")
+	send(http.MethodPatch, "/v2/test/blobs/uploads/2?_state=0")
+	send(http.MethodPut, "/v2/test/blobs/uploads/1?_state=0&digest=sha256:0")
+	if got := tracker.Len(); got != 1 {
+		t.Fatalf("Tracker.Len() = %d, want 1", got)
+	}
+
+	cancelled, err := tracker.Cancel(context.Background())
+	if err != nil {
+		t.Fatalf("Tracker.Cancel() error = %v", err)
+	}
+	if cancelled != 1 || len(deleted) != 1 || deleted[0] != "/v2/test/blobs/uploads/2" {
+		t.Errorf("Tracker.Cancel() = %d, deleted %v, want session 2 deleted", cancelled, deleted)
+	}
+	if got := tracker.Len(); got != 0 {
+		t.Errorf("Tracker.Len() after cancel = %d, want 0", got)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if got := FromContext(context.Background()); got != nil {
+		t.Errorf("FromContext() = %v, want nil", got)
+	}
+	tracker := NewTracker()
+	if got := FromContext(WithTracker(context.Background(), tracker)); got != tracker {
+		t.Errorf("FromContext() = %v, want %v", got, tracker)
+	}
+}
+
+func TestTracker_journal(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", "/v2/test/blobs/uploads/1?_state=0")
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodPatch:
+			w.Header().Set("Location", "/v2/test/blobs/uploads/1?_state=1")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	journal := &Journal{Dir: t.TempDir()}
+	tracker := NewTracker()
+	tracker.Journal = journal
+	client := &http.Client{Transport: tracker.Transport(http.DefaultTransport)}
+	for _, r := range []struct{ method, path string }{
+		{http.MethodPost, "/v2/test/blobs/uploads/"},
+		{http.MethodPatch, "/v2/test/blobs/uploads/1?_state=0"},
+	} {
+		req, err := http.NewRequest(r.method, ts.URL+r.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	host := strings.TrimPrefix(ts.URL, "http://")
+	entries, err := journal.List(host + "/test")
+	if err != nil {
+		t.Fatalf("Journal.List() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].URL != ts.URL+"/v2/test/blobs/uploads/1?_state=1" {
+		t.Fatalf("Journal.List() = %+v, want the latest location of session 1", entries)
+	}
+	if entries, _ := journal.List("example.com/test"); len(entries) != 0 {
+		t.Errorf("Journal.List() of another repository = %+v, want none", entries)
+	}
+
+	// the session is not found on the registry any more
+	if _, err := tracker.Cancel(context.Background()); err != nil {
+		t.Fatalf("Tracker.Cancel() error = %v", err)
+	}
+	if entries, _ := journal.List(""); len(entries) != 0 {
+		t.Errorf("Journal.List() after cancel = %+v, want none", entries)
+	}
+}